@@ -0,0 +1,120 @@
+// Command discord-mcp serves the Discord MCP server over stdin and stdout.
+//
+//	discord-mcp [options]
+//	discord-mcp [options] validate-config [path]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/config"
+	"discord-mcp/pkg/discordmcp"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the process exit code. MCP
+// messages use stdout, so everything else the server says goes to stderr.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("discord-mcp", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", "config.yaml", "Path to configuration file")
+	logLevel := flags.String("log-level", "", "Log level (debug, info, warn, error)")
+	showVersion := flags.Bool("version", false, "Show version and exit")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: discord-mcp [options] [command]\n\nOptions:\n")
+		flags.PrintDefaults()
+		fmt.Fprintf(stderr, "\nCommands:\n  validate-config [path]\n    \tCheck a configuration file and exit (default \"config.yaml\")\n")
+	}
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	if *showVersion {
+		fmt.Fprintf(stdout, "discord-mcp %s\n", config.DefaultConfig().MCP.Version)
+		return 0
+	}
+
+	switch flags.Arg(0) {
+	case "":
+	case "validate-config":
+		path := *configPath
+		if flags.NArg() > 1 {
+			path = flags.Arg(1)
+		}
+		return config.RunValidateCommand(path, stdout)
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n", flags.Arg(0))
+		flags.Usage()
+		return 2
+	}
+
+	cfg, err := discordmcp.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: %v\n", *configPath, err)
+		return 1
+	}
+	if *logLevel != "" {
+		cfg.Server.LogLevel = *logLevel
+	}
+	logger, err := newLogger(cfg.Server, stderr)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	server, err := discordmcp.New(cfg, discordmcp.Options{Logger: logger})
+	if err != nil {
+		logger.Errorf("Failed to create server: %v", err)
+		return 1
+	}
+
+	// Disconnect cleanly when the client stops the process
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		logger.Infof("Received %v, shutting down", sig)
+		server.Stop()
+		os.Exit(0)
+	}()
+
+	if err := server.ServeStdio(); err != nil {
+		logger.Errorf("Server stopped: %v", err)
+		server.Stop()
+		return 1
+	}
+	server.Stop()
+	return 0
+}
+
+// newLogger creates the server logger from the server configuration
+func newLogger(cfg config.ServerConfig, output io.Writer) (*logrus.Logger, error) {
+	level, err := logrus.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level %q (debug, info, warn, error)", cfg.LogLevel)
+	}
+	if cfg.Debug {
+		level = logrus.DebugLevel
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(output)
+	logger.SetLevel(level)
+	if cfg.LogFormat == "json" {
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	}
+	return logger, nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunValidateConfig(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(valid, []byte("discord:\n  token: x\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(invalid, []byte("discord:\n  token: x\n  tokn: y\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{name: "valid file", args: []string{"validate-config", valid}, wantCode: 0, wantOut: "configuration is valid"},
+		{name: "invalid file", args: []string{"validate-config", invalid}, wantCode: 1, wantOut: "discord.tokn: unknown key"},
+		{name: "path from -config", args: []string{"-config", invalid, "validate-config"}, wantCode: 1, wantOut: invalid},
+		{name: "version", args: []string{"-version"}, wantCode: 0, wantOut: "discord-mcp "},
+		{name: "unknown command", args: []string{"frobnicate"}, wantCode: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Fatalf("exit code = %d, want %d (stderr %q)", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantOut) {
+				t.Errorf("stdout = %q, want it to contain %q", stdout.String(), tt.wantOut)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+// Package analytics keeps lightweight per-guild activity counters fed by
+// gateway events.
+package analytics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// RetentionDays is how many days of daily counters are kept
+const RetentionDays = 30
+
+// dayLayout keys daily counters by UTC date
+const dayLayout = "2006-01-02"
+
+// DayStats are the counters for a single UTC day
+type DayStats struct {
+	Date     string `json:"date"`
+	Joins    int    `json:"joins"`
+	Leaves   int    `json:"leaves"`
+	Messages int    `json:"messages"`
+}
+
+// ChannelActivity is the number of messages seen in a channel
+type ChannelActivity struct {
+	ChannelID string `json:"channel_id"`
+	Messages  int    `json:"messages"`
+}
+
+type guildStats struct {
+	days     map[string]*DayStats
+	channels map[string]map[string]int // day -> channel -> messages
+}
+
+// Tracker counts joins, leaves and messages per guild and day
+type Tracker struct {
+	mutex   sync.Mutex
+	guilds  map[string]*guildStats
+	started time.Time
+}
+
+// NewTracker creates an empty tracker
+func NewTracker() *Tracker {
+	return &Tracker{
+		guilds:  make(map[string]*guildStats),
+		started: time.Now(),
+	}
+}
+
+// Since returns when tracking started
+func (t *Tracker) Since() time.Time {
+	return t.started
+}
+
+// RecordJoin counts a member joining a guild
+func (t *Tracker) RecordJoin(guildID string, at time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.day(guildID, at).Joins++
+}
+
+// RecordLeave counts a member leaving a guild
+func (t *Tracker) RecordLeave(guildID string, at time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.day(guildID, at).Leaves++
+}
+
+// RecordMessage counts a message posted in a guild channel
+func (t *Tracker) RecordMessage(guildID, channelID string, at time.Time) {
+	if guildID == "" {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.day(guildID, at).Messages++
+
+	key := at.UTC().Format(dayLayout)
+	g := t.guilds[guildID]
+	if g.channels[key] == nil {
+		g.channels[key] = make(map[string]int)
+	}
+	g.channels[key][channelID]++
+}
+
+// Days returns the daily counters for the last n days, oldest first
+func (t *Tracker) Days(guildID string, n int) []DayStats {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	g := t.guilds[guildID]
+	today := time.Now().UTC()
+	days := make([]DayStats, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		key := today.AddDate(0, 0, -i).Format(dayLayout)
+		stats := DayStats{Date: key}
+		if g != nil {
+			if d, ok := g.days[key]; ok {
+				stats = *d
+			}
+		}
+		days = append(days, stats)
+	}
+	return days
+}
+
+// TopChannels returns the most active channels over the last n days
+func (t *Tracker) TopChannels(guildID string, n, limit int) []ChannelActivity {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	g := t.guilds[guildID]
+	if g == nil {
+		return []ChannelActivity{}
+	}
+
+	totals := make(map[string]int)
+	today := time.Now().UTC()
+	for i := 0; i < n; i++ {
+		for channelID, count := range g.channels[today.AddDate(0, 0, -i).Format(dayLayout)] {
+			totals[channelID] += count
+		}
+	}
+
+	top := make([]ChannelActivity, 0, len(totals))
+	for channelID, count := range totals {
+		top = append(top, ChannelActivity{ChannelID: channelID, Messages: count})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Messages != top[j].Messages {
+			return top[i].Messages > top[j].Messages
+		}
+		return top[i].ChannelID < top[j].ChannelID
+	})
+	if len(top) > limit {
+		top = top[:limit]
+	}
+	return top
+}
+
+// day returns the counters for a guild and day, creating them and pruning
+// expired days as needed; callers must hold the mutex
+func (t *Tracker) day(guildID string, at time.Time) *DayStats {
+	g, ok := t.guilds[guildID]
+	if !ok {
+		g = &guildStats{
+			days:     make(map[string]*DayStats),
+			channels: make(map[string]map[string]int),
+		}
+		t.guilds[guildID] = g
+	}
+
+	key := at.UTC().Format(dayLayout)
+	d, ok := g.days[key]
+	if !ok {
+		d = &DayStats{Date: key}
+		g.days[key] = d
+
+		cutoff := at.UTC().AddDate(0, 0, -RetentionDays).Format(dayLayout)
+		for old := range g.days {
+			if old < cutoff {
+				delete(g.days, old)
+				delete(g.channels, old)
+			}
+		}
+	}
+	return d
+}
@@ -0,0 +1,337 @@
+// Package archive stores messages from subscribed channels in a local SQLite
+// database with an FTS5 full-text index. It uses the pure-Go SQLite driver,
+// so neither cgo nor a sqlite3 binary is needed.
+package archive
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+	_ "modernc.org/sqlite"
+
+	"discord-mcp/internal/config"
+)
+
+// maxPending bounds statements queued between flushes
+const maxPending = 10000
+
+const schema = `
+CREATE TABLE IF NOT EXISTS messages (
+	id              TEXT PRIMARY KEY,
+	guild_id        TEXT NOT NULL,
+	channel_id      TEXT NOT NULL,
+	author_id       TEXT NOT NULL,
+	author_username TEXT NOT NULL,
+	content         TEXT NOT NULL,
+	created_at      INTEGER NOT NULL,
+	edited_at       INTEGER,
+	deleted         INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS messages_channel ON messages (channel_id, created_at);
+CREATE INDEX IF NOT EXISTS messages_author ON messages (author_id, created_at);
+CREATE VIRTUAL TABLE IF NOT EXISTS messages_fts USING fts5 (
+	content, content='messages', content_rowid='rowid'
+);
+CREATE TRIGGER IF NOT EXISTS messages_ai AFTER INSERT ON messages BEGIN
+	INSERT INTO messages_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+CREATE TRIGGER IF NOT EXISTS messages_ad AFTER DELETE ON messages BEGIN
+	INSERT INTO messages_fts (messages_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+END;
+CREATE TRIGGER IF NOT EXISTS messages_au AFTER UPDATE OF content ON messages BEGIN
+	INSERT INTO messages_fts (messages_fts, rowid, content) VALUES ('delete', old.rowid, old.content);
+	INSERT INTO messages_fts (rowid, content) VALUES (new.rowid, new.content);
+END;
+`
+
+// Query filters a search; zero values are ignored
+type Query struct {
+	Text      string
+	GuildID   string
+	ChannelID string
+	AuthorID  string
+	After     time.Time
+	Before    time.Time
+	Limit     int
+}
+
+// Result is an archived message matching a search
+type Result struct {
+	ID             string `json:"id"`
+	GuildID        string `json:"guild_id"`
+	ChannelID      string `json:"channel_id"`
+	AuthorID       string `json:"author_id"`
+	AuthorUsername string `json:"author_username"`
+	Content        string `json:"content"`
+	Snippet        string `json:"snippet,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+	EditedAt       *int64 `json:"edited_at"`
+	Deleted        int    `json:"deleted"`
+}
+
+// change is a queued write and its bound arguments
+type change struct {
+	statement string
+	args      []interface{}
+}
+
+// Archive writes subscribed messages to SQLite in periodic batches
+type Archive struct {
+	config   config.ArchiveConfig
+	logger   *logrus.Logger
+	db       *sql.DB
+	guilds   map[string]bool
+	channels map[string]bool
+
+	mutex   sync.Mutex
+	pending []change
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// New opens (creating if needed) the archive database and starts the
+// background writer
+func New(cfg config.ArchiveConfig, logger *logrus.Logger) (*Archive, error) {
+	a := &Archive{
+		config:   cfg,
+		logger:   logger,
+		guilds:   toSet(cfg.Guilds),
+		channels: toSet(cfg.Channels),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	// Wait for locks held by other processes instead of failing at once
+	db, err := sql.Open("sqlite", "file:"+(&url.URL{Path: cfg.Path}).EscapedPath()+"?_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive database %s: %w", cfg.Path, err)
+	}
+	// One connection serializes flushes and searches
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize archive database %s: %w", cfg.Path, err)
+	}
+	a.db = db
+
+	go a.flushLoop()
+	logger.Infof("Archiving messages to %s", cfg.Path)
+	return a, nil
+}
+
+// Subscribed reports whether messages in a channel are archived. Threads
+// are archived when their parent channel is subscribed.
+func (a *Archive) Subscribed(guildID, channelID, parentID string) bool {
+	return a.guilds[guildID] || a.channels[channelID] || (parentID != "" && a.channels[parentID])
+}
+
+// Store queues a message for insertion, replacing earlier content on edits
+func (a *Archive) Store(msg *discordgo.Message) {
+	if msg.Author == nil {
+		return
+	}
+
+	var edited interface{}
+	if msg.EditedTimestamp != nil {
+		edited = msg.EditedTimestamp.UnixMilli()
+	}
+
+	a.queue("INSERT INTO messages (id, guild_id, channel_id, author_id, author_username, content, created_at, edited_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?, ?) "+
+		"ON CONFLICT (id) DO UPDATE SET content = excluded.content, edited_at = excluded.edited_at",
+		msg.ID, msg.GuildID, msg.ChannelID, msg.Author.ID, msg.Author.Username, msg.Content, msg.Timestamp.UnixMilli(), edited)
+}
+
+// MarkDeleted flags an archived message as deleted; its content is kept
+func (a *Archive) MarkDeleted(messageID string) {
+	a.queue("UPDATE messages SET deleted = 1 WHERE id = ?", messageID)
+}
+
+// Search runs a full-text and/or filtered query against the archive.
+// Text uses FTS5 query syntax (words, "phrases", OR, NOT, prefix*); text that
+// is not valid syntax is searched as plain words instead.
+func (a *Archive) Search(q Query) ([]Result, error) {
+	// Make recently received messages searchable
+	a.Flush()
+
+	results, err := a.search(q)
+	if err != nil && q.Text != "" && isQuerySyntaxError(err) {
+		q.Text = plainQuery(q.Text)
+		return a.search(q)
+	}
+	return results, err
+}
+
+func (a *Archive) search(q Query) ([]Result, error) {
+	var where []string
+	var args []interface{}
+	if q.GuildID != "" {
+		where = append(where, "m.guild_id = ?")
+		args = append(args, q.GuildID)
+	}
+	if q.ChannelID != "" {
+		where = append(where, "m.channel_id = ?")
+		args = append(args, q.ChannelID)
+	}
+	if q.AuthorID != "" {
+		where = append(where, "m.author_id = ?")
+		args = append(args, q.AuthorID)
+	}
+	if !q.After.IsZero() {
+		where = append(where, "m.created_at >= ?")
+		args = append(args, q.After.UnixMilli())
+	}
+	if !q.Before.IsZero() {
+		where = append(where, "m.created_at < ?")
+		args = append(args, q.Before.UnixMilli())
+	}
+
+	columns := "m.id, m.guild_id, m.channel_id, m.author_id, m.author_username, m.content, m.created_at, m.edited_at, m.deleted"
+	var query string
+	if q.Text != "" {
+		where = append(where, "messages_fts MATCH ?")
+		args = append(args, q.Text)
+		query = fmt.Sprintf("SELECT %s, snippet(messages_fts, 0, '**', '**', '…', 16) "+
+			"FROM messages_fts JOIN messages m ON m.rowid = messages_fts.rowid WHERE %s ORDER BY rank LIMIT ?",
+			columns, strings.Join(where, " AND "))
+	} else {
+		filter := ""
+		if len(where) > 0 {
+			filter = " WHERE " + strings.Join(where, " AND ")
+		}
+		query = fmt.Sprintf("SELECT %s FROM messages m%s ORDER BY m.created_at DESC LIMIT ?", columns, filter)
+	}
+	args = append(args, q.Limit)
+
+	rows, err := a.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search archive: %w", err)
+	}
+	defer rows.Close()
+
+	results := []Result{}
+	for rows.Next() {
+		var r Result
+		var edited sql.NullInt64
+		dest := []interface{}{&r.ID, &r.GuildID, &r.ChannelID, &r.AuthorID, &r.AuthorUsername, &r.Content, &r.CreatedAt, &edited, &r.Deleted}
+		if q.Text != "" {
+			dest = append(dest, &r.Snippet)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to read archive results: %w", err)
+		}
+		if edited.Valid {
+			r.EditedAt = &edited.Int64
+		}
+		results = append(results, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search archive: %w", err)
+	}
+	return results, nil
+}
+
+// Flush writes all queued changes in a single transaction
+func (a *Archive) Flush() {
+	a.mutex.Lock()
+	pending := a.pending
+	a.pending = nil
+	a.mutex.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	if err := a.write(pending); err != nil {
+		a.logger.Errorf("Failed to archive %d messages: %v", len(pending), err)
+		return
+	}
+	a.logger.Debugf("Archived %d message changes", len(pending))
+}
+
+// write applies changes in one transaction, rolling back on any failure
+func (a *Archive) write(changes []change) error {
+	tx, err := a.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, c := range changes {
+		if _, err := tx.Exec(c.statement, c.args...); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Close stops the background writer after a final flush and closes the
+// database
+func (a *Archive) Close() {
+	a.once.Do(func() {
+		close(a.stop)
+		<-a.done
+		a.db.Close()
+	})
+}
+
+func (a *Archive) queue(statement string, args ...interface{}) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if len(a.pending) >= maxPending {
+		a.logger.Warn("Archive queue full, dropping oldest pending change")
+		a.pending = a.pending[1:]
+	}
+	a.pending = append(a.pending, change{statement: statement, args: args})
+}
+
+func (a *Archive) flushLoop() {
+	defer close(a.done)
+
+	ticker := time.NewTicker(time.Duration(a.config.FlushIntervalMs) * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.Flush()
+		case <-a.stop:
+			a.Flush()
+			return
+		}
+	}
+}
+
+// isQuerySyntaxError reports whether FTS5 rejected the text of a search,
+// such as an unbalanced quote or a stray operator
+func isQuerySyntaxError(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "fts5: syntax error") ||
+		strings.Contains(message, "unterminated string") ||
+		strings.Contains(message, "no such column")
+}
+
+// plainQuery turns free text into an FTS5 query matching every word
+func plainQuery(text string) string {
+	words := strings.Fields(text)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"`
+	}
+	return strings.Join(words, " ")
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,127 @@
+package archive
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/config"
+)
+
+func newTestArchive(t *testing.T) *Archive {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	a, err := New(config.ArchiveConfig{
+		Enabled:         true,
+		Path:            filepath.Join(t.TempDir(), "archive test.db"),
+		Channels:        []string{"10"},
+		FlushIntervalMs: 60000,
+	}, logger)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(a.Close)
+	return a
+}
+
+func testMessage(id, content string, at time.Time) *discordgo.Message {
+	return &discordgo.Message{
+		ID:        id,
+		GuildID:   "1",
+		ChannelID: "10",
+		Author:    &discordgo.User{ID: "100", Username: "o'brien"},
+		Content:   content,
+		Timestamp: at,
+	}
+}
+
+func TestSearch(t *testing.T) {
+	a := newTestArchive(t)
+	base := time.Date(2025, 1, 5, 18, 0, 0, 0, time.UTC)
+
+	a.Store(testMessage("1", "deploy the release tonight", base))
+	a.Store(testMessage("2", "it's done'); DROP TABLE messages; --", base.Add(time.Minute)))
+	a.Store(testMessage("3", `the "quoted" release notes`, base.Add(2*time.Minute)))
+	edited := base.Add(3 * time.Minute)
+	updated := testMessage("1", "deploy the hotfix tonight", base)
+	updated.EditedTimestamp = &edited
+	a.Store(updated)
+	a.MarkDeleted("3")
+
+	tests := []struct {
+		name  string
+		query Query
+		want  []string
+	}{
+		{name: "filters only, newest first", query: Query{ChannelID: "10", Limit: 10}, want: []string{"3", "2", "1"}},
+		{name: "full-text match", query: Query{Text: "hotfix", Limit: 10}, want: []string{"1"}},
+		{name: "edits replace indexed content", query: Query{Text: "deploy release", Limit: 10}, want: []string{}},
+		{name: "quotes in content are stored as text", query: Query{Text: "DROP", Limit: 10}, want: []string{"2"}},
+		{name: "invalid FTS syntax falls back to words", query: Query{Text: `"quoted`, Limit: 10}, want: []string{"3"}},
+		{name: "column syntax falls back to words", query: Query{Text: "notes: release", Limit: 10}, want: []string{"3"}},
+		{name: "time bounds", query: Query{After: base.Add(30 * time.Second), Before: base.Add(90 * time.Second), Limit: 10}, want: []string{"2"}},
+		{name: "author filter", query: Query{AuthorID: "999", Limit: 10}, want: []string{}},
+		{name: "limit", query: Query{Limit: 1}, want: []string{"3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := a.Search(tt.query)
+			if err != nil {
+				t.Fatalf("Search: %v", err)
+			}
+			got := make([]string, len(results))
+			for i, r := range results {
+				got[i] = r.ID
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("results = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("results = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	results, err := a.Search(Query{Text: "hotfix", Limit: 10})
+	if err != nil || len(results) != 1 {
+		t.Fatalf("Search: %v %v", results, err)
+	}
+	r := results[0]
+	if r.EditedAt == nil || *r.EditedAt != edited.UnixMilli() {
+		t.Errorf("edited_at = %v, want %d", r.EditedAt, edited.UnixMilli())
+	}
+	if r.AuthorUsername != "o'brien" || r.Snippet == "" {
+		t.Errorf("result = %+v", r)
+	}
+
+	results, _ = a.Search(Query{Text: "quoted", Limit: 10})
+	if len(results) != 1 || results[0].Deleted != 1 {
+		t.Errorf("deleted message = %+v", results)
+	}
+}
+
+func TestPlainQuery(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "deploy release", want: `"deploy" "release"`},
+		{text: `say "hi`, want: `"say" """hi"`},
+		{text: "notes: NOT", want: `"notes:" "NOT"`},
+		{text: "  ", want: ""},
+	}
+	for _, tt := range tests {
+		if got := plainQuery(tt.text); got != tt.want {
+			t.Errorf("plainQuery(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+// Package autoresponse holds pattern to reply rules that the server answers
+// directly on new messages, without a round trip to the client.
+package autoresponse
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Trigger types
+const (
+	TriggerExact   = "exact"
+	TriggerRegex   = "regex"
+	TriggerMention = "mention"
+)
+
+// MaxRules bounds the number of registered auto-responses
+const MaxRules = 100
+
+// Rule replies to messages matching a trigger
+type Rule struct {
+	ID      string `json:"id"`
+	Trigger string `json:"trigger"`
+	// Pattern is the exact text, compared case-insensitively, or the regular
+	// expression to match; mention triggers have none
+	Pattern string `json:"pattern,omitempty"`
+	Reply   string `json:"reply"`
+	GuildID string `json:"guild_id,omitempty"`
+	// ChannelIDs limits the rule to these channels; empty means every channel
+	ChannelIDs []string `json:"channel_ids,omitempty"`
+	// CooldownSeconds is the minimum time between replies in one channel
+	CooldownSeconds int       `json:"cooldown_seconds"`
+	CreatedAt       time.Time `json:"created_at"`
+	Triggers        int64     `json:"trigger_count"`
+
+	regex     *regexp.Regexp
+	lastFired map[string]time.Time
+}
+
+// Registry holds the active auto-responses
+type Registry struct {
+	rules  map[string]*Rule
+	nextID int
+	mutex  sync.Mutex
+}
+
+// NewRegistry creates an empty auto-response registry
+func NewRegistry() *Registry {
+	return &Registry{
+		rules: make(map[string]*Rule),
+	}
+}
+
+// Add validates and registers a rule, assigning its ID
+func (r *Registry) Add(rule Rule) (Rule, error) {
+	switch rule.Trigger {
+	case TriggerExact:
+		if strings.TrimSpace(rule.Pattern) == "" {
+			return Rule{}, fmt.Errorf("pattern is required for exact triggers")
+		}
+	case TriggerRegex:
+		if rule.Pattern == "" {
+			return Rule{}, fmt.Errorf("pattern is required for regex triggers")
+		}
+		regex, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid pattern: %w", err)
+		}
+		rule.regex = regex
+	case TriggerMention:
+		rule.Pattern = ""
+	default:
+		return Rule{}, fmt.Errorf("unknown trigger type: %s", rule.Trigger)
+	}
+	if rule.Reply == "" {
+		return Rule{}, fmt.Errorf("reply is required")
+	}
+	if rule.CooldownSeconds < 0 {
+		return Rule{}, fmt.Errorf("cooldown_seconds must not be negative")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.rules) >= MaxRules {
+		return Rule{}, fmt.Errorf("auto-response limit reached (%d)", MaxRules)
+	}
+
+	r.nextID++
+	rule.ID = fmt.Sprintf("ar%d", r.nextID)
+	rule.CreatedAt = time.Now().UTC()
+	rule.lastFired = make(map[string]time.Time)
+	r.rules[rule.ID] = &rule
+	return rule, nil
+}
+
+// Remove deletes a rule, reporting whether it existed
+func (r *Registry) Remove(id string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.rules[id]; !ok {
+		return false
+	}
+	delete(r.rules, id)
+	return true
+}
+
+// List returns all rules ordered by creation
+func (r *Registry) List() []Rule {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rules := make([]Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, *rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+	})
+	return rules
+}
+
+// Match returns the rules that reply to a new message, in creation order,
+// and starts their cooldown in the message's channel. Messages from bots,
+// including this one, never match so auto-responses cannot loop.
+func (r *Registry) Match(msg *discordgo.Message, botID string, now time.Time) []Rule {
+	if msg.Author == nil || msg.Author.Bot || msg.Author.ID == botID {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var matches []Rule
+	for _, rule := range r.rules {
+		if !rule.inScope(msg.GuildID, msg.ChannelID) || !rule.matches(msg, botID) {
+			continue
+		}
+		cooldown := time.Duration(rule.CooldownSeconds) * time.Second
+		if last, ok := rule.lastFired[msg.ChannelID]; ok && now.Sub(last) < cooldown {
+			continue
+		}
+		rule.lastFired[msg.ChannelID] = now
+		rule.Triggers++
+		matches = append(matches, *rule)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].CreatedAt.Before(matches[j].CreatedAt)
+	})
+	return matches
+}
+
+func (rule *Rule) inScope(guildID, channelID string) bool {
+	if rule.GuildID != "" && rule.GuildID != guildID {
+		return false
+	}
+	if len(rule.ChannelIDs) == 0 {
+		return true
+	}
+	for _, id := range rule.ChannelIDs {
+		if id == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule *Rule) matches(msg *discordgo.Message, botID string) bool {
+	switch rule.Trigger {
+	case TriggerExact:
+		return strings.EqualFold(strings.TrimSpace(msg.Content), strings.TrimSpace(rule.Pattern))
+	case TriggerRegex:
+		return rule.regex.MatchString(msg.Content)
+	case TriggerMention:
+		for _, user := range msg.Mentions {
+			if user.ID == botID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+// Package bridge keeps channel bridges: messages posted in a source channel
+// are mirrored into a target channel, possibly in another guild, through a
+// webhook that carries the author's name and avatar. Bridges are persisted
+// to disk.
+package bridge
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+// ErrExists reports that the source channel is already bridged to the target
+var ErrExists = errors.New("channels are already bridged")
+
+// Bridge mirrors one channel into another
+type Bridge struct {
+	ID              string `json:"id"`
+	SourceGuildID   string `json:"source_guild_id"`
+	SourceChannelID string `json:"source_channel_id"`
+	TargetGuildID   string `json:"target_guild_id"`
+	TargetChannelID string `json:"target_channel_id"`
+
+	// WebhookID and WebhookToken post into the target channel. The token
+	// is a credential and is never returned by tools.
+	WebhookID    string `json:"webhook_id"`
+	WebhookToken string `json:"webhook_token,omitempty"`
+
+	// IncludeBots also mirrors messages by bots other than this one
+	IncludeBots bool      `json:"include_bots"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	Mirrored       int        `json:"mirrored"`
+	LastMirroredAt *time.Time `json:"last_mirrored_at,omitempty"`
+}
+
+// Redacted returns a copy of the bridge without its webhook token
+func (b Bridge) Redacted() Bridge {
+	b.WebhookToken = ""
+	return b
+}
+
+// storedBridges is the layout of the persistence file
+type storedBridges struct {
+	NextID  int       `json:"next_id"`
+	Bridges []*Bridge `json:"bridges"`
+}
+
+// Store holds bridges by ID and saves them to a JSON file on every change
+type Store struct {
+	path string
+
+	bridges map[string]*Bridge
+	nextID  int
+	mutex   sync.Mutex
+}
+
+// NewStore creates a store, loading the bridges saved at path
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:    path,
+		bridges: make(map[string]*Bridge),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load bridges from %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Check returns ErrExists when the source channel is already bridged to the
+// target channel
+func (s *Store) Check(sourceChannelID, targetChannelID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, bridge := range s.bridges {
+		if bridge.SourceChannelID == sourceChannelID && bridge.TargetChannelID == targetChannelID {
+			return ErrExists
+		}
+	}
+	return nil
+}
+
+// Add saves a new bridge, assigning its ID
+func (s *Store) Add(bridge Bridge) (Bridge, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, existing := range s.bridges {
+		if existing.SourceChannelID == bridge.SourceChannelID && existing.TargetChannelID == bridge.TargetChannelID {
+			return Bridge{}, ErrExists
+		}
+	}
+
+	s.nextID++
+	bridge.ID = fmt.Sprintf("br%d", s.nextID)
+	bridge.CreatedAt = time.Now().UTC()
+	s.bridges[bridge.ID] = &bridge
+	if err := s.save(); err != nil {
+		delete(s.bridges, bridge.ID)
+		s.nextID--
+		return Bridge{}, err
+	}
+	return bridge, nil
+}
+
+// FromChannel returns the bridges that mirror a channel
+func (s *Store) FromChannel(channelID string) []Bridge {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var bridges []Bridge
+	for _, bridge := range s.bridges {
+		if bridge.SourceChannelID == channelID {
+			bridges = append(bridges, *bridge)
+		}
+	}
+	return bridges
+}
+
+// IsWebhook reports whether a webhook posts for a bridge, so messages it
+// mirrored are not mirrored again
+func (s *Store) IsWebhook(webhookID string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, bridge := range s.bridges {
+		if bridge.WebhookID == webhookID {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the bridges with either end in a guild, or every bridge when
+// guildID is empty, newest first
+func (s *Store) List(guildID string) []Bridge {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bridges := make([]Bridge, 0)
+	for _, bridge := range s.bridges {
+		if guildID == "" || bridge.SourceGuildID == guildID || bridge.TargetGuildID == guildID {
+			bridges = append(bridges, *bridge)
+		}
+	}
+	sort.Slice(bridges, func(i, j int) bool {
+		return bridges[i].CreatedAt.After(bridges[j].CreatedAt)
+	})
+	return bridges
+}
+
+// Record counts a message mirrored through a bridge
+func (s *Store) Record(bridgeID string, at time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bridge, ok := s.bridges[bridgeID]
+	if !ok {
+		return nil
+	}
+	bridge.Mirrored++
+	at = at.UTC()
+	bridge.LastMirroredAt = &at
+	return s.save()
+}
+
+// Remove deletes a bridge, returning it and whether it existed
+func (s *Store) Remove(bridgeID string) (Bridge, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	bridge, ok := s.bridges[bridgeID]
+	if !ok {
+		return Bridge{}, false, nil
+	}
+	delete(s.bridges, bridgeID)
+	if err := s.save(); err != nil {
+		s.bridges[bridgeID] = bridge
+		return Bridge{}, false, err
+	}
+	return *bridge, true, nil
+}
+
+// save writes every bridge to the persistence file; callers must hold the
+// lock
+func (s *Store) save() error {
+	stored := storedBridges{NextID: s.nextID, Bridges: make([]*Bridge, 0, len(s.bridges))}
+	for _, bridge := range s.bridges {
+		stored.Bridges = append(stored.Bridges, bridge)
+	}
+	sort.Slice(stored.Bridges, func(i, j int) bool {
+		return stored.Bridges[i].CreatedAt.Before(stored.Bridges[j].CreatedAt)
+	})
+
+	return jsonfile.Save(s.path, stored)
+}
+
+// load reads saved bridges
+func (s *Store) load() error {
+	var stored storedBridges
+	if found, err := jsonfile.Load(s.path, &stored); err != nil || !found {
+		return err
+	}
+	s.nextID = stored.NextID
+	for _, bridge := range stored.Bridges {
+		s.bridges[bridge.ID] = bridge
+	}
+	return nil
+}
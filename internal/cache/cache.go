@@ -0,0 +1,405 @@
+package cache
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+)
+
+// Cache kinds tracked in statistics
+const (
+	KindChannel     = "channels"
+	KindRoles       = "roles"
+	KindMember      = "members"
+	KindPermissions = "permissions"
+	KindGuildPerms  = "guild_permissions"
+)
+
+// Cache stores Discord entities and computed permissions with a TTL.
+// Entries are invalidated early when matching gateway events arrive.
+type Cache struct {
+	enabled bool
+	ttl     time.Duration
+	logger  *logrus.Logger
+
+	channels    map[string]entry // channelID -> *discordgo.Channel
+	roles       map[string]entry // guildID -> []*discordgo.Role
+	members     map[string]entry // guildID:userID -> *discordgo.Member
+	permissions map[string]entry // channelID -> int64
+	guildPerms  map[string]entry // guildID -> int64
+
+	stats map[string]*KindStats
+	mutex sync.RWMutex
+}
+
+// entry is a single cached value
+type entry struct {
+	value   interface{}
+	guildID string
+	expires time.Time
+}
+
+// KindStats holds hit/miss counters for a cache kind
+type KindStats struct {
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Invalidations int64 `json:"invalidations"`
+	Entries       int   `json:"entries"`
+	// HitRate is the share of lookups that hit, from 0 to 1
+	HitRate float64 `json:"hit_rate"`
+}
+
+// NewCache creates a new entity cache
+func NewCache(enabled bool, ttl time.Duration, logger *logrus.Logger) *Cache {
+	return &Cache{
+		enabled:     enabled,
+		ttl:         ttl,
+		logger:      logger,
+		channels:    make(map[string]entry),
+		roles:       make(map[string]entry),
+		members:     make(map[string]entry),
+		permissions: make(map[string]entry),
+		guildPerms:  make(map[string]entry),
+		stats: map[string]*KindStats{
+			KindChannel:     {},
+			KindRoles:       {},
+			KindMember:      {},
+			KindPermissions: {},
+			KindGuildPerms:  {},
+		},
+	}
+}
+
+// Enabled reports whether caching is active
+func (c *Cache) Enabled() bool {
+	return c.enabled
+}
+
+// Channel returns a cached channel
+func (c *Cache) Channel(channelID string) (*discordgo.Channel, bool) {
+	value, ok := c.get(KindChannel, c.channels, channelID)
+	if !ok {
+		return nil, false
+	}
+	return value.(*discordgo.Channel), true
+}
+
+// SetChannel caches a channel
+func (c *Cache) SetChannel(channel *discordgo.Channel) {
+	c.set(c.channels, channel.ID, channel.GuildID, channel)
+}
+
+// Roles returns the cached roles of a guild
+func (c *Cache) Roles(guildID string) ([]*discordgo.Role, bool) {
+	value, ok := c.get(KindRoles, c.roles, guildID)
+	if !ok {
+		return nil, false
+	}
+	return value.([]*discordgo.Role), true
+}
+
+// SetRoles caches the roles of a guild
+func (c *Cache) SetRoles(guildID string, roles []*discordgo.Role) {
+	c.set(c.roles, guildID, guildID, roles)
+}
+
+// Member returns a cached guild member
+func (c *Cache) Member(guildID, userID string) (*discordgo.Member, bool) {
+	value, ok := c.get(KindMember, c.members, memberKey(guildID, userID))
+	if !ok {
+		return nil, false
+	}
+	return value.(*discordgo.Member), true
+}
+
+// SetMember caches a guild member
+func (c *Cache) SetMember(guildID string, member *discordgo.Member) {
+	if member.User == nil {
+		return
+	}
+	c.set(c.members, memberKey(guildID, member.User.ID), guildID, member)
+}
+
+// Permissions returns the cached bot permissions for a channel
+func (c *Cache) Permissions(channelID string) (int64, bool) {
+	value, ok := c.get(KindPermissions, c.permissions, channelID)
+	if !ok {
+		return 0, false
+	}
+	return value.(int64), true
+}
+
+// SetPermissions caches the bot permissions for a channel
+func (c *Cache) SetPermissions(guildID, channelID string, permissions int64) {
+	c.set(c.permissions, channelID, guildID, permissions)
+}
+
+// GuildPermissions returns the cached bot permissions for a guild
+func (c *Cache) GuildPermissions(guildID string) (int64, bool) {
+	value, ok := c.get(KindGuildPerms, c.guildPerms, guildID)
+	if !ok {
+		return 0, false
+	}
+	return value.(int64), true
+}
+
+// SetGuildPermissions caches the bot permissions for a guild
+func (c *Cache) SetGuildPermissions(guildID string, permissions int64) {
+	c.set(c.guildPerms, guildID, guildID, permissions)
+}
+
+// Stats returns a snapshot of the cache statistics
+func (c *Cache) Stats() map[string]interface{} {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	kinds := map[string]KindStats{
+		KindChannel:     c.snapshot(KindChannel, c.channels),
+		KindRoles:       c.snapshot(KindRoles, c.roles),
+		KindMember:      c.snapshot(KindMember, c.members),
+		KindPermissions: c.snapshot(KindPermissions, c.permissions),
+		KindGuildPerms:  c.snapshot(KindGuildPerms, c.guildPerms),
+	}
+
+	var hits, lookups int64
+	for _, kind := range kinds {
+		hits += kind.Hits
+		lookups += kind.Hits + kind.Misses
+	}
+	hitRate := 0.0
+	if lookups > 0 {
+		hitRate = float64(hits) / float64(lookups)
+	}
+
+	return map[string]interface{}{
+		"enabled":     c.enabled,
+		"ttl_seconds": int(c.ttl.Seconds()),
+		"hit_rate":    hitRate,
+		"kinds":       kinds,
+	}
+}
+
+// Flush drops every cached entry
+func (c *Cache) Flush() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.channels = make(map[string]entry)
+	c.roles = make(map[string]entry)
+	c.members = make(map[string]entry)
+	c.permissions = make(map[string]entry)
+	c.guildPerms = make(map[string]entry)
+}
+
+// Gateway event handlers
+
+// HandleChannelUpdate invalidates a channel and the guild's channel
+// permissions, since synced channels and threads inherit overwrites from
+// the updated channel
+func (c *Cache) HandleChannelUpdate(s *discordgo.Session, e *discordgo.ChannelUpdate) {
+	c.invalidateChannel(e.ID)
+	if e.GuildID != "" {
+		c.invalidateChannelPermissions(e.GuildID)
+	}
+}
+
+// HandleChannelDelete invalidates a deleted channel
+func (c *Cache) HandleChannelDelete(s *discordgo.Session, e *discordgo.ChannelDelete) {
+	c.invalidateChannel(e.ID)
+}
+
+// HandleThreadUpdate invalidates a thread and its permissions
+func (c *Cache) HandleThreadUpdate(s *discordgo.Session, e *discordgo.ThreadUpdate) {
+	c.invalidateChannel(e.ID)
+}
+
+// HandleThreadDelete invalidates a deleted thread
+func (c *Cache) HandleThreadDelete(s *discordgo.Session, e *discordgo.ThreadDelete) {
+	c.invalidateChannel(e.ID)
+}
+
+// HandleGuildUpdate invalidates the guild's computed permissions, which
+// depend on who owns the guild
+func (c *Cache) HandleGuildUpdate(s *discordgo.Session, e *discordgo.GuildUpdate) {
+	c.invalidatePermissions(e.ID)
+}
+
+// HandleGuildDelete drops everything cached for a guild the bot left or
+// lost access to
+func (c *Cache) HandleGuildDelete(s *discordgo.Session, e *discordgo.GuildDelete) {
+	c.invalidateGuild(e.ID)
+}
+
+// HandleGuildRoleCreate invalidates guild roles
+func (c *Cache) HandleGuildRoleCreate(s *discordgo.Session, e *discordgo.GuildRoleCreate) {
+	c.invalidateGuildRoles(e.GuildID)
+}
+
+// HandleGuildRoleUpdate invalidates guild roles and derived permissions
+func (c *Cache) HandleGuildRoleUpdate(s *discordgo.Session, e *discordgo.GuildRoleUpdate) {
+	c.invalidateGuildRoles(e.GuildID)
+}
+
+// HandleGuildRoleDelete invalidates guild roles and derived permissions
+func (c *Cache) HandleGuildRoleDelete(s *discordgo.Session, e *discordgo.GuildRoleDelete) {
+	c.invalidateGuildRoles(e.GuildID)
+}
+
+// HandleGuildMemberUpdate invalidates a member and, when the member is the
+// bot, the guild's computed permissions since its roles may have changed
+func (c *Cache) HandleGuildMemberUpdate(s *discordgo.Session, e *discordgo.GuildMemberUpdate) {
+	if e.Member == nil || e.User == nil {
+		return
+	}
+	c.invalidateMember(e.GuildID, e.User.ID)
+	if isBot(s, e.User.ID) {
+		c.invalidatePermissions(e.GuildID)
+	}
+}
+
+// HandleGuildMemberRemove invalidates a member who left the guild
+func (c *Cache) HandleGuildMemberRemove(s *discordgo.Session, e *discordgo.GuildMemberRemove) {
+	if e.Member == nil || e.User == nil {
+		return
+	}
+	c.invalidateMember(e.GuildID, e.User.ID)
+	if isBot(s, e.User.ID) {
+		c.invalidatePermissions(e.GuildID)
+	}
+}
+
+// Helper Methods
+
+// isBot reports whether userID is the connected bot. Unknown identities are
+// treated as the bot so that permissions are never kept stale.
+func isBot(s *discordgo.Session, userID string) bool {
+	if s == nil || s.State == nil || s.State.User == nil {
+		return true
+	}
+	return s.State.User.ID == userID
+}
+
+func (c *Cache) get(kind string, bucket map[string]entry, key string) (interface{}, bool) {
+	if !c.enabled {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := bucket[key]
+	if !ok || time.Now().After(e.expires) {
+		if ok {
+			delete(bucket, key)
+		}
+		c.stats[kind].Misses++
+		return nil, false
+	}
+
+	c.stats[kind].Hits++
+	return e.value, true
+}
+
+func (c *Cache) set(bucket map[string]entry, key, guildID string, value interface{}) {
+	if !c.enabled {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	bucket[key] = entry{
+		value:   value,
+		guildID: guildID,
+		expires: time.Now().Add(c.ttl),
+	}
+}
+
+func (c *Cache) invalidateChannel(channelID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.remove(KindChannel, c.channels, channelID)
+	c.remove(KindPermissions, c.permissions, channelID)
+	c.logger.Debugf("Cache invalidated channel %s", channelID)
+}
+
+func (c *Cache) invalidateGuildRoles(guildID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.remove(KindRoles, c.roles, guildID)
+	c.removeGuild(KindPermissions, c.permissions, guildID)
+	c.remove(KindGuildPerms, c.guildPerms, guildID)
+	c.logger.Debugf("Cache invalidated roles for guild %s", guildID)
+}
+
+func (c *Cache) invalidateMember(guildID, userID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.remove(KindMember, c.members, memberKey(guildID, userID))
+	c.logger.Debugf("Cache invalidated member %s in guild %s", userID, guildID)
+}
+
+func (c *Cache) invalidateChannelPermissions(guildID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.removeGuild(KindPermissions, c.permissions, guildID)
+}
+
+func (c *Cache) invalidatePermissions(guildID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.removeGuild(KindPermissions, c.permissions, guildID)
+	c.remove(KindGuildPerms, c.guildPerms, guildID)
+	c.logger.Debugf("Cache invalidated permissions for guild %s", guildID)
+}
+
+func (c *Cache) invalidateGuild(guildID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.removeGuild(KindChannel, c.channels, guildID)
+	c.remove(KindRoles, c.roles, guildID)
+	c.removeGuild(KindMember, c.members, guildID)
+	c.removeGuild(KindPermissions, c.permissions, guildID)
+	c.remove(KindGuildPerms, c.guildPerms, guildID)
+	c.logger.Debugf("Cache invalidated guild %s", guildID)
+}
+
+// remove deletes a key; callers must hold the write lock
+func (c *Cache) remove(kind string, bucket map[string]entry, key string) {
+	if _, ok := bucket[key]; ok {
+		delete(bucket, key)
+		c.stats[kind].Invalidations++
+	}
+}
+
+// removeGuild deletes every entry belonging to a guild; callers must hold the write lock
+func (c *Cache) removeGuild(kind string, bucket map[string]entry, guildID string) {
+	for key, e := range bucket {
+		if e.guildID == guildID {
+			delete(bucket, key)
+			c.stats[kind].Invalidations++
+		}
+	}
+}
+
+// snapshot copies the stats for a kind; callers must hold the read lock
+func (c *Cache) snapshot(kind string, bucket map[string]entry) KindStats {
+	stats := *c.stats[kind]
+	stats.Entries = len(bucket)
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(lookups)
+	}
+	return stats
+}
+
+func memberKey(guildID, userID string) string {
+	return guildID + ":" + userID
+}
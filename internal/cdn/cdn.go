@@ -0,0 +1,160 @@
+// Package cdn builds Discord CDN URLs for avatars, icons, banners, emoji and
+// soundboard sounds.
+package cdn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/config"
+)
+
+// BaseURL is the root of Discord's media CDN
+const BaseURL = "https://cdn.discordapp.com/"
+
+// MediaURL is the root of Discord's media proxy, which serves GIF stickers
+const MediaURL = "https://media.discordapp.net/"
+
+// Builder turns image hashes into CDN URLs with the configured size and format
+type Builder struct {
+	size     int
+	format   string
+	animated bool
+}
+
+// New creates a URL builder from configuration
+func New(cfg config.CDNConfig) *Builder {
+	format := strings.ToLower(cfg.ImageFormat)
+	if format == "jpeg" {
+		format = "jpg"
+	}
+	return &Builder{
+		size:     cfg.ImageSize,
+		format:   format,
+		animated: cfg.Animated,
+	}
+}
+
+// image formats a hashed asset path. Animated hashes ("a_" prefix) are
+// served as GIF when animation is enabled.
+func (b *Builder) image(path, hash string) string {
+	if hash == "" {
+		return ""
+	}
+	format := b.format
+	if b.animated && strings.HasPrefix(hash, "a_") {
+		format = "gif"
+	}
+	return fmt.Sprintf("%s%s/%s.%s?size=%d", BaseURL, path, hash, format, b.size)
+}
+
+// UserAvatar returns the user's avatar, or their default avatar when unset
+func (b *Builder) UserAvatar(user *discordgo.User) string {
+	if user == nil {
+		return ""
+	}
+	if user.Avatar == "" {
+		return fmt.Sprintf("%sembed/avatars/%d.png", BaseURL, user.DefaultAvatarIndex())
+	}
+	return b.image("avatars/"+user.ID, user.Avatar)
+}
+
+// MemberAvatar returns the member's guild avatar, falling back to their user
+// avatar
+func (b *Builder) MemberAvatar(guildID string, member *discordgo.Member) string {
+	if member == nil || member.User == nil {
+		return ""
+	}
+	if member.Avatar == "" {
+		return b.UserAvatar(member.User)
+	}
+	return b.image("guilds/"+guildID+"/users/"+member.User.ID+"/avatars", member.Avatar)
+}
+
+// UserBanner returns the user's profile banner
+func (b *Builder) UserBanner(user *discordgo.User) string {
+	if user == nil {
+		return ""
+	}
+	return b.image("banners/"+user.ID, user.Banner)
+}
+
+// GuildIcon returns the guild's icon
+func (b *Builder) GuildIcon(guildID, hash string) string {
+	return b.image("icons/"+guildID, hash)
+}
+
+// GuildBanner returns the guild's banner
+func (b *Builder) GuildBanner(guildID, hash string) string {
+	return b.image("banners/"+guildID, hash)
+}
+
+// GuildSplash returns the guild's invite splash
+func (b *Builder) GuildSplash(guildID, hash string) string {
+	return b.image("splashes/"+guildID, hash)
+}
+
+// GuildDiscoverySplash returns the guild's discovery splash
+func (b *Builder) GuildDiscoverySplash(guildID, hash string) string {
+	return b.image("discovery-splashes/"+guildID, hash)
+}
+
+// RoleIcon returns the role's icon
+func (b *Builder) RoleIcon(role *discordgo.Role) string {
+	if role == nil {
+		return ""
+	}
+	return b.image("role-icons/"+role.ID, role.Icon)
+}
+
+// Emoji returns a custom emoji's image; Unicode emoji have none
+func (b *Builder) Emoji(id string, animated bool) string {
+	format := b.format
+	if animated && b.animated {
+		format = "gif"
+	}
+	return b.EmojiImage(id, format, b.size)
+}
+
+// EmojiImage returns a custom emoji's image in a specific format and size
+func (b *Builder) EmojiImage(id, format string, size int) string {
+	if id == "" {
+		return ""
+	}
+	if format == "jpeg" {
+		format = "jpg"
+	}
+	return fmt.Sprintf("%semojis/%s.%s?size=%d", BaseURL, id, format, size)
+}
+
+// Size returns the configured image size
+func (b *Builder) Size() int {
+	return b.size
+}
+
+// Sticker returns a sticker's image. PNG and APNG stickers are served as
+// PNG, GIF stickers from the media proxy, and Lottie stickers as their JSON
+// animation.
+func (b *Builder) Sticker(id string, format discordgo.StickerFormat) string {
+	if id == "" {
+		return ""
+	}
+	switch format {
+	case discordgo.StickerFormatTypeGIF:
+		return fmt.Sprintf("%sstickers/%s.gif?size=%d", MediaURL, id, b.size)
+	case discordgo.StickerFormatTypeLottie:
+		return fmt.Sprintf("%sstickers/%s.json", BaseURL, id)
+	default:
+		return fmt.Sprintf("%sstickers/%s.png?size=%d", BaseURL, id, b.size)
+	}
+}
+
+// SoundboardSound returns a soundboard sound's audio file
+func (b *Builder) SoundboardSound(id string) string {
+	if id == "" {
+		return ""
+	}
+	return fmt.Sprintf("%ssoundboard-sounds/%s", BaseURL, id)
+}
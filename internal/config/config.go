@@ -3,43 +3,666 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
 
 	"gopkg.in/yaml.v3"
+
+	"discord-mcp/internal/i18n"
 )
 
 // Config holds the application configuration
 type Config struct {
-	Discord DiscordConfig `yaml:"discord"`
-	MCP     MCPConfig     `yaml:"mcp"`
-	Server  ServerConfig  `yaml:"server"`
-	Events  EventsConfig  `yaml:"events"`
+	Discord      DiscordConfig          `yaml:"discord"`
+	MCP          MCPConfig              `yaml:"mcp"`
+	Server       ServerConfig           `yaml:"server"`
+	Guilds       map[string]GuildConfig `yaml:"guilds,omitempty"`
+	Events       EventsConfig           `yaml:"events"`
+	Cache        CacheConfig            `yaml:"cache"`
+	Policy       PolicyConfig           `yaml:"policy"`
+	Validation   ValidationConfig       `yaml:"validation"`
+	Voice        VoiceConfig            `yaml:"voice"`
+	Export       ExportConfig           `yaml:"export"`
+	Summarizer   SummarizerConfig       `yaml:"summarizer"`
+	Archive      ArchiveConfig          `yaml:"archive"`
+	Templates    TemplatesConfig        `yaml:"templates"`
+	CDN          CDNConfig              `yaml:"cdn"`
+	Images       ImagesConfig           `yaml:"images"`
+	Onboarding   OnboardingConfig       `yaml:"onboarding"`
+	Schedule     ScheduleConfig         `yaml:"schedule"`
+	Giveaways    GiveawaysConfig        `yaml:"giveaways"`
+	Starboard    StarboardConfig        `yaml:"starboard"`
+	Pinning      PinningConfig          `yaml:"pinning"`
+	Notes        NotesConfig            `yaml:"user_notes"`
+	Warnings     WarningsConfig         `yaml:"warnings"`
+	Tickets      TicketsConfig          `yaml:"tickets"`
+	RoleMenus    RoleMenusConfig        `yaml:"role_menus"`
+	Verification VerificationConfig     `yaml:"verification"`
+	Broadcast    BroadcastConfig        `yaml:"broadcast"`
+	Bridges      BridgesConfig          `yaml:"bridges"`
+	Relay        RelayConfig            `yaml:"relay"`
+	Feeds        FeedsConfig            `yaml:"feeds"`
+	Raid         RaidConfig             `yaml:"raid"`
+	Undo         UndoConfig             `yaml:"undo"`
+	Membership   MembershipConfig       `yaml:"membership_log"`
+	ThreadDigest ThreadDigestConfig     `yaml:"thread_digest"`
+	Safety       SafetyConfig           `yaml:"safety"`
+	Cooldowns    CooldownConfig         `yaml:"response_cooldowns"`
+	Outbound     OutboundConfig         `yaml:"outbound_queue"`
+	Offline      OfflineConfig          `yaml:"offline_queue"`
+	Trace        TraceConfig            `yaml:"trace"`
+	Telemetry    TelemetryConfig        `yaml:"telemetry"`
+	Plugins      []PluginConfig         `yaml:"plugins,omitempty"`
 }
 
 // DiscordConfig holds Discord-specific configuration
 type DiscordConfig struct {
-	Token              string   `yaml:"token"`
-	DefaultGuildID     string   `yaml:"guild_id,omitempty"`
-	AllowedGuilds      []string `yaml:"allowed_guilds,omitempty"`
-	MaxMessageLength   int      `yaml:"max_message_length"`
-	RateLimitPerMinute int      `yaml:"rate_limit_per_minute"`
+	Token          string       `yaml:"token,omitempty"`
+	TokenFile      string       `yaml:"token_file,omitempty"`
+	TokenSecret    SecretConfig `yaml:"token_secret,omitempty"`
+	DefaultGuildID string       `yaml:"guild_id,omitempty"`
+	AllowedGuilds  []string     `yaml:"allowed_guilds,omitempty"`
+	// Channel access lists; deny rules take precedence over allow rules
+	AllowedChannels    []string    `yaml:"allowed_channels,omitempty"`
+	DeniedChannels     []string    `yaml:"denied_channels,omitempty"`
+	AllowedCategories  []string    `yaml:"allowed_categories,omitempty"`
+	DeniedCategories   []string    `yaml:"denied_categories,omitempty"`
+	MaxMessageLength   int         `yaml:"max_message_length"`
+	RateLimitPerMinute int         `yaml:"rate_limit_per_minute"`
+	Retry              RetryConfig `yaml:"retry"`
+	// HistoryConcurrency bounds the channels a history scan reads at once
+	HistoryConcurrency int `yaml:"history_concurrency"`
+
+	// StrictIDs disables accepting channel, role and user names in place of IDs
+	StrictIDs bool `yaml:"strict_ids"`
+	// MessageContentIntent requests the privileged MESSAGE_CONTENT intent.
+	// It must also be enabled for the bot in the Discord Developer Portal.
+	MessageContentIntent bool `yaml:"message_content_intent"`
+	// CommandPermissionsToken is an OAuth2 bearer token with the
+	// applications.commands.permissions.update scope. Discord does not let
+	// bot tokens change command permissions.
+	CommandPermissionsToken string `yaml:"command_permissions_token,omitempty"`
+}
+
+// RetryConfig holds retry settings for transient Discord REST failures
+type RetryConfig struct {
+	MaxRetries  int `yaml:"max_retries"`
+	BaseDelayMs int `yaml:"base_delay_ms"`
+	MaxDelayMs  int `yaml:"max_delay_ms"`
+	BudgetMs    int `yaml:"budget_ms"`
+}
+
+// SecretConfig selects an external secret provider for the bot token
+type SecretConfig struct {
+	// Provider is one of "file", "env", "vault" or "aws"
+	Provider string `yaml:"provider,omitempty"`
+	// Path is the file path, Vault KV v2 path or AWS secret ID/ARN
+	Path string `yaml:"path,omitempty"`
+	// Field is the key within a Vault secret or JSON-encoded AWS secret
+	Field   string `yaml:"field,omitempty"`
+	EnvVar  string `yaml:"env_var,omitempty"`
+	Address string `yaml:"address,omitempty"`
+	Region  string `yaml:"region,omitempty"`
+	// RefreshIntervalSeconds controls how often the secret is re-read to pick
+	// up rotations; 0 disables polling
+	RefreshIntervalSeconds int `yaml:"refresh_interval_seconds,omitempty"`
+}
+
+// HasToken reports whether any token source is configured
+func (d DiscordConfig) HasToken() bool {
+	return d.Token != "" || d.TokenFile != "" || d.TokenSecret.Provider != ""
 }
 
 // MCPConfig holds MCP server configuration
 type MCPConfig struct {
 	ServerName string `yaml:"server_name"`
 	Version    string `yaml:"version"`
+	// ResponseDetail is the default amount of tool result data: "full", or
+	// "compact" for IDs, names and top-level counts only. Calls can override
+	// it with the detail and fields arguments.
+	ResponseDetail string `yaml:"response_detail"`
+	// MaxResultBytes caps the JSON size of a tool result. Larger results are
+	// truncated with a continuation cursor; 0 disables the limit.
+	MaxResultBytes int `yaml:"max_result_bytes"`
+	// IdempotencyTTLSeconds is how long results of state-changing calls are
+	// remembered by idempotency_key; 0 disables idempotency keys.
+	IdempotencyTTLSeconds int `yaml:"idempotency_ttl_seconds"`
 }
 
 // ServerConfig holds general server configuration
 type ServerConfig struct {
 	LogLevel string `yaml:"log_level"`
-	Debug    bool   `yaml:"debug"`
+	// LogFormat is text, or json for one JSON object per line with
+	// structured fields
+	LogFormat string `yaml:"log_format"`
+	Debug     bool   `yaml:"debug"`
+	// Locale is the default language of tool result text; clients can
+	// override it per call with _meta.locale
+	Locale string `yaml:"locale"`
+	// Timezone is the IANA zone tool results show times in, next to UTC,
+	// and times without a zone are read in
+	Timezone string `yaml:"timezone"`
+}
+
+// GuildConfig overrides server settings for calls acting in one guild,
+// identified by their guild_id or channel_id
+type GuildConfig struct {
+	Timezone string `yaml:"timezone,omitempty"`
+	Locale   string `yaml:"locale,omitempty"`
 }
 
 // EventsConfig holds event streaming configuration
 type EventsConfig struct {
-	Enabled       bool     `yaml:"enabled"`
-	AllowedEvents []string `yaml:"allowed_events"`
+	Enabled       bool              `yaml:"enabled"`
+	AllowedEvents []string          `yaml:"allowed_events"`
+	Buffer        EventBufferConfig `yaml:"buffer"`
+
+	// IncludeMessageObject adds the full formatted message, as returned by
+	// get_channel_messages, to message event payloads
+	IncludeMessageObject bool `yaml:"include_message_object"`
+
+	// RateLimits caps notifications per minute for individual event types;
+	// DefaultRateLimit applies to the rest (0 = unlimited). Events over the
+	// limit are coalesced into discord/eventBatch notifications.
+	RateLimits       map[string]int `yaml:"rate_limits,omitempty"`
+	DefaultRateLimit int            `yaml:"default_rate_limit"`
+	Batching         BatchingConfig `yaml:"batching"`
+
+	AddressedMessages AddressedMessagesConfig `yaml:"addressed_messages"`
+}
+
+// AddressedMessagesConfig controls forwarding of messages that mention or
+// reply to the bot as discord/addressedMessage notifications
+type AddressedMessagesConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// ContextMessages is how many preceding channel messages to include
+	ContextMessages int `yaml:"context_messages"`
+}
+
+// BatchingConfig controls coalescing of events into periodic batches
+type BatchingConfig struct {
+	// Enabled batches every event instead of only rate-limited ones
+	Enabled    bool `yaml:"enabled"`
+	IntervalMs int  `yaml:"interval_ms"`
+	MaxSamples int  `yaml:"max_samples"`
+}
+
+// EventBufferConfig holds the recent-event buffer used by poll_events
+type EventBufferConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Size    int  `yaml:"size"`
+	// PersistPath optionally stores buffered events in a JSON lines file
+	PersistPath string `yaml:"persist_path,omitempty"`
+}
+
+// CacheConfig holds entity cache configuration
+type CacheConfig struct {
+	Enabled    bool `yaml:"enabled"`
+	TTLSeconds int  `yaml:"ttl_seconds"`
+}
+
+// PolicyConfig holds per-operation policy rules evaluated before every tool call
+type PolicyConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// DefaultAction applies when no rule matches: allow, deny or confirm
+	DefaultAction string       `yaml:"default_action"`
+	Rules         []PolicyRule `yaml:"rules,omitempty"`
+}
+
+// PolicyRule maps tools and targets to a decision. Every criterion that is
+// set must match; rules are evaluated in order and the first match wins.
+type PolicyRule struct {
+	Name     string   `yaml:"name,omitempty"`
+	Tools    []string `yaml:"tools,omitempty"`
+	Guilds   []string `yaml:"guilds,omitempty"`
+	Channels []string `yaml:"channels,omitempty"`
+	// Roles matches the role_id argument of role tools
+	Roles []string `yaml:"roles,omitempty"`
+	// RolePositionAbove matches target roles positioned above this value
+	RolePositionAbove *int   `yaml:"role_position_above,omitempty"`
+	Action            string `yaml:"action"`
+	Reason            string `yaml:"reason,omitempty"`
+}
+
+// ValidationConfig controls how tool arguments are checked
+type ValidationConfig struct {
+	// UnknownParameters is "lenient" (drop parameters a tool does not define
+	// and log a warning) or "strict" (reject the call)
+	UnknownParameters string `yaml:"unknown_parameters"`
+	// ToolModes overrides UnknownParameters for individual tools
+	ToolModes map[string]string `yaml:"tool_modes,omitempty"`
+}
+
+// VoiceConfig holds voice channel playback settings
+type VoiceConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// FFmpegPath is the ffmpeg binary used to transcode audio to Opus
+	FFmpegPath         string    `yaml:"ffmpeg_path"`
+	MaxDurationSeconds int       `yaml:"max_duration_seconds"`
+	BitrateKbps        int       `yaml:"bitrate_kbps"`
+	TTS                TTSConfig `yaml:"tts"`
+}
+
+// TTSConfig selects the text-to-speech backend used by speak_in_voice
+type TTSConfig struct {
+	// Backend is "command" or "http"; empty disables speak_in_voice
+	Backend string `yaml:"backend"`
+	// Command is the program and arguments for the command backend
+	Command []string `yaml:"command,omitempty"`
+	// URL is the endpoint for the http backend
+	URL            string `yaml:"url,omitempty"`
+	Voice          string `yaml:"voice,omitempty"`
+	TimeoutSeconds int    `yaml:"timeout_seconds"`
+	MaxTextLength  int    `yaml:"max_text_length"`
+}
+
+// ExportConfig holds channel history export settings
+type ExportConfig struct {
+	// Directory receives transcript files written by export_channel
+	Directory string `yaml:"directory"`
+	// MaxMessages caps the number of messages in a single export
+	MaxMessages int `yaml:"max_messages"`
+}
+
+// SummarizerConfig holds the external summarizer tools call when asked to
+// summarize: true
+type SummarizerConfig struct {
+	// Backend is "command" or "http"; empty disables summaries
+	Backend string `yaml:"backend"`
+	// Command is the program and arguments for the command backend
+	Command []string `yaml:"command,omitempty"`
+	// URL is the endpoint for the http backend
+	URL string `yaml:"url,omitempty"`
+	// Headers are sent with every http request, e.g. an Authorization header
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Instructions are used when a tool call gives none
+	Instructions   string `yaml:"instructions,omitempty"`
+	TimeoutSeconds int    `yaml:"timeout_seconds"`
+	// MaxInputChars caps the transcript sent; the oldest lines are dropped
+	MaxInputChars int `yaml:"max_input_chars"`
+}
+
+// ArchiveConfig holds the local message archive settings
+type ArchiveConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the SQLite database file
+	Path string `yaml:"path"`
+	// SQLitePath is ignored; the archive no longer runs the sqlite3 shell.
+	// It is kept so older configuration files still load.
+	SQLitePath string `yaml:"sqlite_path,omitempty"`
+	// Guilds and Channels select what is archived; threads follow their parent
+	Guilds          []string `yaml:"guilds"`
+	Channels        []string `yaml:"channels"`
+	FlushIntervalMs int      `yaml:"flush_interval_ms"`
+}
+
+// TemplatesConfig holds the message template store settings
+type TemplatesConfig struct {
+	// Directory holds template files (.yaml, .yml or .json)
+	Directory string `yaml:"directory"`
+}
+
+// CDNConfig controls the image URLs added to formatted responses
+type CDNConfig struct {
+	// ImageSize is a power of two between 16 and 4096
+	ImageSize int `yaml:"image_size"`
+	// ImageFormat is png, jpg or webp
+	ImageFormat string `yaml:"image_format"`
+	// Animated serves animated avatars, icons and emoji as GIF
+	Animated bool `yaml:"animated"`
+}
+
+// ImagesConfig limits the attachments, stickers and emoji that tools return as
+// image content
+type ImagesConfig struct {
+	// MaxBytes skips attachments larger than this
+	MaxBytes int `yaml:"max_bytes"`
+	// MaxImages caps the images fetched for a single call
+	MaxImages int `yaml:"max_images"`
+	// TimeoutSeconds bounds each download
+	TimeoutSeconds int `yaml:"timeout_seconds"`
+}
+
+// OnboardingConfig holds the rules run when a member joins a guild
+type OnboardingConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Rules are loaded at startup; set_onboarding_rule adds or replaces
+	// rules at runtime
+	Rules []OnboardingRule `yaml:"rules,omitempty"`
+}
+
+// OnboardingRule assigns roles, sends a welcome direct message and posts a
+// welcome message for every member joining a guild. Messages may use the
+// placeholders {{user}}, {{username}}, {{display_name}}, {{user_id}},
+// {{server}} and {{member_count}}.
+type OnboardingRule struct {
+	Name           string   `yaml:"name"`
+	GuildID        string   `yaml:"guild_id"`
+	RoleIDs        []string `yaml:"role_ids,omitempty"`
+	DirectMessage  string   `yaml:"direct_message,omitempty"`
+	ChannelID      string   `yaml:"channel_id,omitempty"`
+	ChannelMessage string   `yaml:"channel_message,omitempty"`
+	IncludeBots    bool     `yaml:"include_bots,omitempty"`
+}
+
+// ScheduleConfig holds the recurring posts created with create_recurring_post
+type ScheduleConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON file recurring posts are saved to
+	Path string `yaml:"path"`
+}
+
+// GiveawaysConfig holds the giveaways started with start_giveaway
+type GiveawaysConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON file giveaways are saved to
+	Path string `yaml:"path"`
+}
+
+// StarboardConfig holds the starboards set with configure_starboard
+type StarboardConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON file starboard settings and reposts are saved to
+	Path string `yaml:"path"`
+}
+
+// PinningConfig holds the pinning policies set with set_pin_policy
+type PinningConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON file pinning policies are saved to
+	Path string `yaml:"path"`
+}
+
+// NotesConfig holds the member notes kept with add_user_note
+type NotesConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON file notes are saved to
+	Path string `yaml:"path"`
+	// MaxPerUser caps the notes kept about one member of a guild
+	MaxPerUser int `yaml:"max_per_user"`
+}
+
+// WarningsConfig holds the warning ledger kept with warn_member and the
+// actions taken as a member's warnings add up
+type WarningsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON file warnings are saved to
+	Path string `yaml:"path"`
+	// ExpireDays stops warnings counting toward escalation after this many
+	// days; 0 keeps them until cleared
+	ExpireDays int `yaml:"expire_days"`
+	// Escalation lists the actions taken when a member's active warnings
+	// reach a count
+	Escalation []EscalationStep `yaml:"escalation,omitempty"`
+}
+
+// EscalationStep is an action taken once a member has Warnings active
+// warnings
+type EscalationStep struct {
+	Warnings int `yaml:"warnings"`
+	// Action is timeout, kick or ban
+	Action string `yaml:"action"`
+	// DurationMinutes is the length of a timeout
+	DurationMinutes int `yaml:"duration_minutes,omitempty"`
+}
+
+// TicketsConfig holds the support tickets opened with open_ticket
+type TicketsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON file tickets are saved to
+	Path string `yaml:"path"`
+	// RelayDMs posts a member's direct messages to the bot into their open
+	// ticket
+	RelayDMs bool `yaml:"relay_dms"`
+}
+
+// RoleMenusConfig holds the self-assign menus posted with create_role_menu
+type RoleMenusConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON file role menus are saved to
+	Path string `yaml:"path"`
+}
+
+// VerificationConfig holds the verification gates set with
+// configure_verification
+type VerificationConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON file gate settings and unverified members are saved
+	// to
+	Path string `yaml:"path"`
+}
+
+// BroadcastConfig holds the channel lists broadcast_message sends to
+type BroadcastConfig struct {
+	// IntervalMs is the pause between two targets of a broadcast
+	IntervalMs int             `yaml:"interval_ms"`
+	Lists      []BroadcastList `yaml:"lists,omitempty"`
+}
+
+// BroadcastList is a named set of channels, possibly in several guilds
+type BroadcastList struct {
+	Name       string   `yaml:"name"`
+	ChannelIDs []string `yaml:"channel_ids"`
+}
+
+// BridgesConfig holds the channel bridges created with create_bridge
+type BridgesConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON file bridges and their webhook tokens are saved to
+	Path string `yaml:"path"`
+}
+
+// RelayConfig holds the endpoint that turns payloads posted by other
+// services into Discord messages, and the relays created with create_relay
+type RelayConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Listen is the address the /relay/{token} endpoint listens on
+	Listen string `yaml:"listen"`
+	// PublicURL is the base URL senders reach the endpoint at, used to build
+	// the URL returned by create_relay; empty uses http://<listen>
+	PublicURL    string `yaml:"public_url,omitempty"`
+	MaxBodyBytes int64  `yaml:"max_body_bytes"`
+	// Path is the JSON file relays and their tokens are saved to
+	Path string `yaml:"path"`
+}
+
+// FeedsConfig holds the RSS and Atom feeds watched with add_feed
+type FeedsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON file feeds and the entries already posted are saved
+	// to
+	Path string `yaml:"path"`
+	// IntervalMinutes is how often feeds are polled unless add_feed sets
+	// another interval, which cannot be below MinIntervalMinutes
+	IntervalMinutes    int `yaml:"interval_minutes"`
+	MinIntervalMinutes int `yaml:"min_interval_minutes"`
+	// MaxEntriesPerCheck caps the entries posted from one poll; older new
+	// entries are skipped
+	MaxEntriesPerCheck int `yaml:"max_entries_per_check"`
+}
+
+// RaidConfig holds the default raid protection thresholds and responses.
+// Guilds listed in Guilds are armed at startup; others are armed with
+// arm_raid_protection.
+type RaidConfig struct {
+	Enabled              bool     `yaml:"enabled"`
+	Guilds               []string `yaml:"guilds,omitempty"`
+	JoinThreshold        int      `yaml:"join_threshold"`
+	JoinWindowSeconds    int      `yaml:"join_window_seconds"`
+	MessageThreshold     int      `yaml:"message_threshold"`
+	MessageWindowSeconds int      `yaml:"message_window_seconds"`
+	// SlowmodeSeconds is applied to a flooded channel; 0 leaves it alone
+	SlowmodeSeconds int `yaml:"slowmode_seconds"`
+	// PauseInvites disables invites to the guild after a join flood
+	PauseInvites bool `yaml:"pause_invites"`
+	// CooldownSeconds suppresses repeat incidents for the same flood
+	CooldownSeconds int `yaml:"cooldown_seconds"`
+}
+
+// UndoConfig holds the journal of reversible actions used by undo_action
+// and undo_last_action
+type UndoConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// TTLSeconds is how long an action can be undone
+	TTLSeconds int `yaml:"ttl_seconds"`
+}
+
+// MembershipConfig holds the join and leave log read by get_membership_log
+// and get_join_leave_stats
+type MembershipConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the JSON lines file joins and leaves are appended to
+	Path string `yaml:"path"`
+	// RetentionDays is how long joins and leaves are kept
+	RetentionDays int `yaml:"retention_days"`
+	// TrackInvites records the invite each member joined with by comparing
+	// invite use counts before and after the join; it needs Manage Server
+	TrackInvites bool `yaml:"track_invites"`
+}
+
+// ThreadDigestConfig holds the daily digest of threads about to auto-archive
+type ThreadDigestConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// HourUTC is the hour of the day the digest runs
+	HourUTC int `yaml:"hour_utc"`
+	// WindowHours lists the threads that auto-archive within this many hours
+	WindowHours int                       `yaml:"window_hours"`
+	Guilds      []ThreadDigestGuildConfig `yaml:"guilds,omitempty"`
+}
+
+// ThreadDigestGuildConfig is a guild the thread digest runs for
+type ThreadDigestGuildConfig struct {
+	GuildID string `yaml:"guild_id"`
+	// ChannelID receives the digest as a message; without it the digest is
+	// only sent as a discord/threadDigest notification
+	ChannelID string `yaml:"channel_id,omitempty"`
+}
+
+// SafetyConfig holds the link and attachment scanning of inbound and
+// outbound messages
+type SafetyConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// AllowedDomains are trusted and skip every scanner
+	AllowedDomains []string `yaml:"allowed_domains,omitempty"`
+	// DeniedDomains are always flagged, subdomains included
+	DeniedDomains []string `yaml:"denied_domains,omitempty"`
+	// DeniedExtensions flags links and attachments with these file types
+	DeniedExtensions []string         `yaml:"denied_extensions,omitempty"`
+	Hook             SafetyHookConfig `yaml:"hook"`
+	ScanInbound      bool             `yaml:"scan_inbound"`
+	ScanOutbound     bool             `yaml:"scan_outbound"`
+	// DeleteInbound deletes unsafe messages from other users; it needs
+	// Manage Messages
+	DeleteInbound bool `yaml:"delete_inbound"`
+	// BlockOutbound refuses to send unsafe messages instead of only
+	// reporting them
+	BlockOutbound bool `yaml:"block_outbound"`
+}
+
+// SafetyHookConfig holds the external HTTP scanner
+type SafetyHookConfig struct {
+	// URL receives the items to check; empty disables the hook
+	URL string `yaml:"url,omitempty"`
+	// Headers are added to every hook request, e.g. for an API key
+	Headers   map[string]string `yaml:"headers,omitempty"`
+	TimeoutMs int               `yaml:"timeout_ms"`
+}
+
+// CooldownConfig limits how often one user or channel can trigger
+// event-driven responses: auto-response replies and addressed message
+// notifications. Each response counts against both the author and the
+// channel; a limit of 0 disables that scope.
+type CooldownConfig struct {
+	Enabled              bool `yaml:"enabled"`
+	UserLimit            int  `yaml:"user_limit"`
+	UserWindowSeconds    int  `yaml:"user_window_seconds"`
+	ChannelLimit         int  `yaml:"channel_limit"`
+	ChannelWindowSeconds int  `yaml:"channel_window_seconds"`
+	// ExemptUsers are never limited, e.g. the server's moderators
+	ExemptUsers []string `yaml:"exempt_users,omitempty"`
+}
+
+// OutboundConfig holds the queue messages are sent through. Tool calls and
+// replies are sent before event-driven posts, which go before scheduled and
+// bulk posts; messages to one channel keep their order.
+type OutboundConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Workers int  `yaml:"workers"`
+	// InteractiveWorkers of the workers only send tool calls and replies
+	InteractiveWorkers int `yaml:"interactive_workers"`
+	// MaxPending refuses new messages when this many are waiting
+	MaxPending int `yaml:"max_pending"`
+	// BulkMaxPending refuses scheduled and bulk posts at a lower depth, so
+	// they back off before interactive messages do
+	BulkMaxPending int `yaml:"bulk_max_pending"`
+}
+
+// OfflineConfig holds tool calls made while the Discord gateway is down
+// until it reconnects. Only state-changing calls with an idempotency key and
+// repeatable calls such as role assignments are held.
+type OfflineConfig struct {
+	Enabled  bool `yaml:"enabled"`
+	MaxCalls int  `yaml:"max_calls"`
+	// TTLSeconds is how long a call is held before it is dropped
+	TTLSeconds int `yaml:"ttl_seconds"`
+}
+
+// TraceConfig holds Discord REST and gateway tracing configuration.
+// Tracing can also be turned on at runtime with the MCP logging/setLevel
+// request at debug level.
+type TraceConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the trace file; empty keeps traces only in memory
+	Path string `yaml:"path"`
+	// MaxFileBytes rotates the trace file when it reaches this size
+	MaxFileBytes int64 `yaml:"max_file_bytes"`
+	// MaxFiles is the number of rotated trace files kept
+	MaxFiles int `yaml:"max_files"`
+	// Recent is the number of traces get_recent_traces can return
+	Recent int `yaml:"recent"`
+	// MaxBodyBytes cuts recorded request and response bodies
+	MaxBodyBytes int `yaml:"max_body_bytes"`
+}
+
+// TelemetryConfig holds OpenTelemetry trace export configuration
+type TelemetryConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Endpoint is the OTLP/HTTP traces URL of the collector
+	Endpoint string `yaml:"endpoint"`
+	// Headers are sent with every export, e.g. an API key
+	Headers     map[string]string `yaml:"headers"`
+	ServiceName string            `yaml:"service_name"`
+	// SampleRatio is the share of requests traced, from 0 to 1
+	SampleRatio     float64 `yaml:"sample_ratio"`
+	FlushIntervalMs int     `yaml:"flush_interval_ms"`
+	// MaxQueue bounds the spans waiting for export
+	MaxQueue int `yaml:"max_queue"`
+}
+
+// PluginConfig is an external program that provides tools. Each call runs
+// the program with the call as JSON on stdin and reads the result from
+// stdout.
+type PluginConfig struct {
+	// Name identifies the plugin in logs and errors
+	Name string `yaml:"name"`
+	// Command is the program and its arguments
+	Command []string `yaml:"command"`
+	// Env is added to the server's environment
+	Env map[string]string `yaml:"env,omitempty"`
+	// Dir is the working directory; defaults to the server's
+	Dir string `yaml:"dir,omitempty"`
+	// TimeoutSeconds bounds each call; defaults to 30
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty"`
+	// Tools are the tools the program provides
+	Tools []PluginToolConfig `yaml:"tools"`
+}
+
+// PluginToolConfig declares a tool of a plugin
+type PluginToolConfig struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	// InputSchema is the JSON Schema of the tool's arguments; defaults to
+	// an object without parameters
+	InputSchema map[string]interface{} `yaml:"input_schema,omitempty"`
 }
 
 // DefaultConfig returns a configuration with sensible defaults
@@ -49,14 +672,27 @@ func DefaultConfig() *Config {
 			Token:              "", // Must be provided by user
 			MaxMessageLength:   2000,
 			RateLimitPerMinute: 30,
+			Retry: RetryConfig{
+				MaxRetries:  3,
+				BaseDelayMs: 500,
+				MaxDelayMs:  10000,
+				BudgetMs:    30000,
+			},
+			HistoryConcurrency: 3,
 		},
 		MCP: MCPConfig{
-			ServerName: "discord-mcp",
-			Version:    "1.0.0",
+			ServerName:            "discord-mcp",
+			Version:               "1.0.0",
+			ResponseDetail:        "full",
+			MaxResultBytes:        262144,
+			IdempotencyTTLSeconds: 600,
 		},
 		Server: ServerConfig{
-			LogLevel: "info",
-			Debug:    false,
+			LogLevel:  "info",
+			LogFormat: "text",
+			Debug:     false,
+			Locale:    i18n.DefaultLocale,
+			Timezone:  "UTC",
 		},
 		Events: EventsConfig{
 			Enabled: true,
@@ -65,34 +701,214 @@ func DefaultConfig() *Config {
 				"discord/guildMemberAdded",
 				"discord/messageReactionAdded",
 			},
+			Buffer: EventBufferConfig{
+				Enabled: true,
+				Size:    1000,
+			},
+			Batching: BatchingConfig{
+				Enabled:    false,
+				IntervalMs: 5000,
+				MaxSamples: 5,
+			},
+			AddressedMessages: AddressedMessagesConfig{
+				Enabled:         false,
+				ContextMessages: 10,
+			},
+		},
+		Cache: CacheConfig{
+			Enabled:    true,
+			TTLSeconds: 300,
+		},
+		Policy: PolicyConfig{
+			Enabled:       false,
+			DefaultAction: "allow",
+		},
+		Validation: ValidationConfig{
+			UnknownParameters: "lenient",
+		},
+		Voice: VoiceConfig{
+			Enabled:            true,
+			FFmpegPath:         "ffmpeg",
+			MaxDurationSeconds: 600,
+			BitrateKbps:        64,
+			TTS: TTSConfig{
+				TimeoutSeconds: 30,
+				MaxTextLength:  1000,
+			},
+		},
+		Export: ExportConfig{
+			Directory:   "exports",
+			MaxMessages: 10000,
+		},
+		Summarizer: SummarizerConfig{
+			TimeoutSeconds: 60,
+			MaxInputChars:  200000,
+		},
+		Archive: ArchiveConfig{
+			Enabled:         false,
+			Path:            "archive.db",
+			FlushIntervalMs: 2000,
+		},
+		Templates: TemplatesConfig{
+			Directory: "templates",
+		},
+		CDN: CDNConfig{
+			ImageSize:   1024,
+			ImageFormat: "png",
+			Animated:    true,
+		},
+		Images: ImagesConfig{
+			MaxBytes:       1048576,
+			MaxImages:      4,
+			TimeoutSeconds: 10,
+		},
+		Onboarding: OnboardingConfig{
+			Enabled: true,
+		},
+		Schedule: ScheduleConfig{
+			Enabled: true,
+			Path:    "recurring_posts.json",
+		},
+		Giveaways: GiveawaysConfig{
+			Enabled: true,
+			Path:    "giveaways.json",
+		},
+		Starboard: StarboardConfig{
+			Enabled: true,
+			Path:    "starboard.json",
+		},
+		Pinning: PinningConfig{
+			Enabled: true,
+			Path:    "pin_policies.json",
+		},
+		Notes: NotesConfig{
+			Enabled:    true,
+			Path:       "user_notes.json",
+			MaxPerUser: 100,
+		},
+		Warnings: WarningsConfig{
+			Enabled: true,
+			Path:    "warnings.json",
+		},
+		Tickets: TicketsConfig{
+			Enabled:  true,
+			Path:     "tickets.json",
+			RelayDMs: true,
+		},
+		RoleMenus: RoleMenusConfig{
+			Enabled: true,
+			Path:    "role_menus.json",
+		},
+		Verification: VerificationConfig{
+			Enabled: true,
+			Path:    "verification.json",
+		},
+		Broadcast: BroadcastConfig{
+			IntervalMs: 1000,
+		},
+		Bridges: BridgesConfig{
+			Enabled: true,
+			Path:    "bridges.json",
+		},
+		Relay: RelayConfig{
+			Enabled:      false,
+			Listen:       "127.0.0.1:8090",
+			MaxBodyBytes: 1 << 20,
+			Path:         "relays.json",
+		},
+		Feeds: FeedsConfig{
+			Enabled:            true,
+			Path:               "feeds.json",
+			IntervalMinutes:    15,
+			MinIntervalMinutes: 5,
+			MaxEntriesPerCheck: 5,
+		},
+		Raid: RaidConfig{
+			Enabled:              true,
+			JoinThreshold:        10,
+			JoinWindowSeconds:    60,
+			MessageThreshold:     20,
+			MessageWindowSeconds: 10,
+			SlowmodeSeconds:      30,
+			PauseInvites:         true,
+			CooldownSeconds:      300,
+		},
+		Undo: UndoConfig{
+			Enabled:    true,
+			TTLSeconds: 900,
+		},
+		Membership: MembershipConfig{
+			Enabled:       true,
+			Path:          "membership_log.jsonl",
+			RetentionDays: 90,
+		},
+		ThreadDigest: ThreadDigestConfig{
+			Enabled:     true,
+			HourUTC:     9,
+			WindowHours: 24,
+		},
+		Safety: SafetyConfig{
+			Enabled:          false,
+			DeniedExtensions: []string{".exe", ".scr", ".bat", ".cmd", ".msi", ".vbs", ".jar", ".apk"},
+			Hook: SafetyHookConfig{
+				TimeoutMs: 3000,
+			},
+			ScanInbound:   true,
+			ScanOutbound:  true,
+			BlockOutbound: true,
+		},
+		Cooldowns: CooldownConfig{
+			Enabled:              true,
+			UserLimit:            5,
+			UserWindowSeconds:    60,
+			ChannelLimit:         20,
+			ChannelWindowSeconds: 60,
+		},
+		Outbound: OutboundConfig{
+			Enabled:            true,
+			Workers:            4,
+			InteractiveWorkers: 1,
+			MaxPending:         1000,
+			BulkMaxPending:     200,
+		},
+		Offline: OfflineConfig{
+			Enabled:    true,
+			MaxCalls:   100,
+			TTLSeconds: 300,
+		},
+		Trace: TraceConfig{
+			Enabled:      false,
+			Path:         "discord_trace.jsonl",
+			MaxFileBytes: 10485760,
+			MaxFiles:     3,
+			Recent:       200,
+			MaxBodyBytes: 4096,
+		},
+		Telemetry: TelemetryConfig{
+			Enabled:         false,
+			Endpoint:        "http://localhost:4318/v1/traces",
+			ServiceName:     "discord-mcp",
+			SampleRatio:     1.0,
+			FlushIntervalMs: 5000,
+			MaxQueue:        2048,
 		},
 	}
 }
 
-// LoadConfig loads configuration from a YAML file
+// LoadConfig loads configuration from a YAML file over the defaults,
+// applies the environment variables and validates the result. A missing
+// file gives the defaults with the environment applied.
 func LoadConfig(filepath string) (*Config, error) {
-	config := DefaultConfig()
-
-	// If file doesn't exist, return default config
-	if _, err := os.Stat(filepath); os.IsNotExist(err) {
-		return config, nil
-	}
-
 	data, err := os.ReadFile(filepath)
+	if os.IsNotExist(err) {
+		return parseConfig(nil)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	if err := yaml.Unmarshal(data, config); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
-	}
-
-	// Validate required fields
-	if config.Discord.Token == "" {
-		return nil, fmt.Errorf("discord.token is required")
-	}
-
-	return config, nil
+	// Reject unknown keys and invalid values up front, reporting all of them
+	return parseConfig(data)
 }
 
 // SaveConfig saves configuration to a YAML file
@@ -111,13 +927,31 @@ func SaveConfig(config *Config, filepath string) error {
 
 // LoadFromEnv loads configuration values from environment variables
 func (c *Config) LoadFromEnv() {
+	// A token from the environment replaces every token source in the
+	// file; DISCORD_TOKEN wins over DISCORD_TOKEN_FILE
+	if tokenFile := os.Getenv("DISCORD_TOKEN_FILE"); tokenFile != "" {
+		c.Discord.Token, c.Discord.TokenFile, c.Discord.TokenSecret = "", tokenFile, SecretConfig{}
+	}
 	if token := os.Getenv("DISCORD_TOKEN"); token != "" {
-		c.Discord.Token = token
+		c.Discord.Token, c.Discord.TokenFile, c.Discord.TokenSecret = token, "", SecretConfig{}
 	}
 	if guildID := os.Getenv("DISCORD_GUILD_ID"); guildID != "" {
 		c.Discord.DefaultGuildID = guildID
 	}
+	if token := os.Getenv("DISCORD_COMMAND_PERMISSIONS_TOKEN"); token != "" {
+		c.Discord.CommandPermissionsToken = token
+	}
 	if logLevel := os.Getenv("LOG_LEVEL"); logLevel != "" {
 		c.Server.LogLevel = logLevel
 	}
+	if logFormat := os.Getenv("LOG_FORMAT"); logFormat != "" {
+		c.Server.LogFormat = logFormat
+	}
+	if trace, err := strconv.ParseBool(os.Getenv("DISCORD_TRACE")); err == nil {
+		c.Trace.Enabled = trace
+	}
+	// The standard OpenTelemetry exporter variable
+	if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); endpoint != "" {
+		c.Telemetry.Endpoint = endpoint
+	}
 }
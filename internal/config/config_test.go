@@ -0,0 +1,171 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes a configuration file into a test directory
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigEnvironment(t *testing.T) {
+	tokenless := "discord:\n  guild_id: \"123456789012345678\"\n"
+	missing := filepath.Join(t.TempDir(), "missing.yaml")
+
+	tests := []struct {
+		name    string
+		path    string
+		token   string
+		level   string
+		wantErr bool
+	}{
+		{name: "file without token, token from environment", path: writeConfig(t, tokenless), token: "env-token"},
+		{name: "file without token", path: writeConfig(t, tokenless), wantErr: true},
+		{name: "missing file, token from environment", path: missing, token: "env-token"},
+		{name: "missing file without token", path: missing, wantErr: true},
+		{name: "invalid value from environment", path: missing, token: "env-token", level: "loud", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DISCORD_TOKEN", tt.token)
+			t.Setenv("DISCORD_TOKEN_FILE", "")
+			t.Setenv("LOG_LEVEL", tt.level)
+
+			cfg, err := LoadConfig(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadConfig error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.Discord.Token != tt.token {
+				t.Errorf("token = %q, want %q", cfg.Discord.Token, tt.token)
+			}
+		})
+	}
+}
+
+func TestValidateFileEnvironment(t *testing.T) {
+	path := writeConfig(t, "discord:\n  rate_limit_per_minute: 30\n")
+	t.Setenv("DISCORD_TOKEN_FILE", "")
+
+	t.Setenv("DISCORD_TOKEN", "")
+	if _, err := ValidateFile(path); err == nil {
+		t.Errorf("file without a token source validated")
+	}
+	t.Setenv("DISCORD_TOKEN", "env-token")
+	if _, err := ValidateFile(path); err != nil {
+		t.Errorf("file completed by DISCORD_TOKEN rejected: %v", err)
+	}
+	if _, err := ValidateFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Errorf("missing file validated")
+	}
+}
+
+func TestUnknownKeys(t *testing.T) {
+	t.Setenv("DISCORD_TOKEN", "")
+	t.Setenv("DISCORD_TOKEN_FILE", "")
+
+	// Each file starts with a two-line discord section
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "section key",
+			content: "server:\n  log_levl: debug\n",
+			want:    "server.log_levl: unknown key (line 4)",
+		},
+		{
+			name:    "policy rule",
+			content: "policy:\n  rules:\n    - action: deny\n      tool: [delete_channel]\n",
+			want:    "policy.rules[0].tool: unknown key (line 6)",
+		},
+		{
+			name:    "guild override",
+			content: "guilds:\n  \"123456789012345678\":\n    max_mesage_length: 100\n",
+			want:    "guilds.123456789012345678.max_mesage_length: unknown key (line 5)",
+		},
+		{
+			name:    "plugin",
+			content: "plugins:\n  - name: echo\n    comand: ./echo\n",
+			want:    "plugins[0].comand: unknown key (line 5)",
+		},
+		{
+			name:    "onboarding rule",
+			content: "onboarding:\n  rules:\n    - guild_id: \"123456789012345678\"\n      role: x\n",
+			want:    "onboarding.rules[0].role: unknown key (line 6)",
+		},
+		{
+			name:    "warning escalation step",
+			content: "warnings:\n  escalation:\n    - warnings: 3\n      acton: kick\n",
+			want:    "warnings.escalation[0].acton: unknown key (line 6)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateFile(writeConfig(t, "discord:\n  token: x\n"+tt.content))
+			errs, ok := err.(ValidationErrors)
+			if !ok {
+				t.Fatalf("error = %v, want validation errors", err)
+			}
+			found := false
+			for _, e := range errs {
+				if strings.Contains(e, tt.want) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("errors %v do not report %q", errs, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenEnvironmentPrecedence(t *testing.T) {
+	fileConfig := writeConfig(t, "discord:\n  token_file: /run/secrets/discord\n")
+	secretConfig := writeConfig(t, "discord:\n  token_secret:\n    provider: env\n    env_var: BOT_TOKEN\n")
+
+	tests := []struct {
+		name          string
+		path          string
+		token         string
+		tokenFile     string
+		wantToken     string
+		wantTokenFile string
+	}{
+		{name: "DISCORD_TOKEN over token_file", path: fileConfig, token: "env-token", wantToken: "env-token"},
+		{name: "DISCORD_TOKEN over token_secret", path: secretConfig, token: "env-token", wantToken: "env-token"},
+		{name: "DISCORD_TOKEN_FILE over token_secret", path: secretConfig, tokenFile: "/env/token", wantTokenFile: "/env/token"},
+		{name: "DISCORD_TOKEN over DISCORD_TOKEN_FILE", path: fileConfig, token: "env-token", tokenFile: "/env/token", wantToken: "env-token"},
+		{name: "config without environment", path: fileConfig, wantTokenFile: "/run/secrets/discord"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DISCORD_TOKEN", tt.token)
+			t.Setenv("DISCORD_TOKEN_FILE", tt.tokenFile)
+
+			cfg, err := LoadConfig(tt.path)
+			if err != nil {
+				t.Fatalf("LoadConfig: %v", err)
+			}
+			d := cfg.Discord
+			if d.Token != tt.wantToken || d.TokenFile != tt.wantTokenFile {
+				t.Errorf("token = %q, token_file = %q, want %q, %q", d.Token, d.TokenFile, tt.wantToken, tt.wantTokenFile)
+			}
+			if (tt.token != "" || tt.tokenFile != "") && d.TokenSecret.Provider != "" {
+				t.Errorf("token_secret provider %q kept", d.TokenSecret.Provider)
+			}
+		})
+	}
+}
@@ -0,0 +1,871 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/timezone"
+)
+
+// Limits enforced by Validate
+const (
+	maxDiscordMessageLength    = 2000
+	maxRateLimitPerMinute      = 3000 // Discord's global limit is 50 requests/second
+	maxRetries                 = 10
+	maxHistoryConcurrency      = 10
+	maxMembershipRetentionDays = 3650
+	maxThreadDigestWindowHours = 168 // the longest auto-archive duration is a week
+	maxCooldownWindowSeconds   = 86400
+	maxOutboundWorkers         = 32
+	maxOfflineCalls            = 1000
+	maxOfflineTTLSeconds       = 3600
+	maxTraceFiles              = 20
+	maxTraceRecent             = 1000
+	maxTraceBodyBytes          = 65536
+	maxTelemetryFlushMs        = 60000
+	maxTelemetryQueue          = 100000
+	maxBroadcastIntervalMs     = 60000
+	maxBroadcastTargets        = 50
+	maxRelayBodyBytes          = 25 << 20
+	maxPluginTimeoutSeconds    = 600
+	maxFeedIntervalMinutes     = 1440
+	maxFeedEntriesPerCheck     = 10 // embeds per message
+)
+
+// ValidationErrors aggregates every problem found in a configuration
+type ValidationErrors []string
+
+// Error formats all problems, one per line
+func (e ValidationErrors) Error() string {
+	return fmt.Sprintf("invalid configuration (%d problems):\n  - %s", len(e), strings.Join(e, "\n  - "))
+}
+
+// add records a problem
+func (e *ValidationErrors) add(format string, args ...interface{}) {
+	*e = append(*e, fmt.Sprintf(format, args...))
+}
+
+// Validate checks value ranges, identifiers and conflicting options
+func (c *Config) Validate() error {
+	var errs ValidationErrors
+	c.validate(&errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (c *Config) validate(errs *ValidationErrors) {
+	d := c.Discord
+
+	// Token sources
+	if !d.HasToken() {
+		errs.add("discord: one of token, token_file or token_secret is required")
+	}
+	if d.Token != "" && d.TokenFile != "" {
+		errs.add("discord: token and token_file are mutually exclusive")
+	}
+	switch d.TokenSecret.Provider {
+	case "":
+		if d.TokenSecret.Path != "" || d.TokenSecret.EnvVar != "" || d.TokenSecret.RefreshIntervalSeconds != 0 {
+			errs.add("discord.token_secret: provider is required when other token_secret options are set")
+		}
+	case "file", "env", "vault", "aws":
+		if d.Token != "" {
+			errs.add("discord: token and token_secret are mutually exclusive")
+		}
+		if d.TokenFile != "" && d.TokenSecret.Provider != "file" {
+			errs.add("discord: token_file conflicts with token_secret provider %q", d.TokenSecret.Provider)
+		}
+		if d.TokenSecret.Provider == "env" && d.TokenSecret.EnvVar == "" {
+			errs.add("discord.token_secret.env_var is required for the env provider")
+		}
+		if (d.TokenSecret.Provider == "vault" || d.TokenSecret.Provider == "aws") && d.TokenSecret.Path == "" {
+			errs.add("discord.token_secret.path is required for the %s provider", d.TokenSecret.Provider)
+		}
+	default:
+		errs.add("discord.token_secret.provider: unknown provider %q (expected file, env, vault or aws)", d.TokenSecret.Provider)
+	}
+	if d.TokenSecret.RefreshIntervalSeconds < 0 {
+		errs.add("discord.token_secret.refresh_interval_seconds: must not be negative, got %d", d.TokenSecret.RefreshIntervalSeconds)
+	}
+
+	// Guilds
+	if d.DefaultGuildID != "" && !isSnowflake(d.DefaultGuildID) {
+		errs.add("discord.guild_id: %q is not a valid Discord ID", d.DefaultGuildID)
+	}
+	seen := make(map[string]bool)
+	for i, guildID := range d.AllowedGuilds {
+		if !isSnowflake(guildID) {
+			errs.add("discord.allowed_guilds[%d]: %q is not a valid Discord ID", i, guildID)
+		}
+		if seen[guildID] {
+			errs.add("discord.allowed_guilds[%d]: duplicate guild %s", i, guildID)
+		}
+		seen[guildID] = true
+	}
+	if d.DefaultGuildID != "" && len(d.AllowedGuilds) > 0 && !seen[d.DefaultGuildID] {
+		errs.add("discord.guild_id: default guild %s is not listed in allowed_guilds", d.DefaultGuildID)
+	}
+
+	// Channel access lists
+	validateIDList(errs, "discord.allowed_channels", d.AllowedChannels)
+	validateIDList(errs, "discord.denied_channels", d.DeniedChannels)
+	validateIDList(errs, "discord.allowed_categories", d.AllowedCategories)
+	validateIDList(errs, "discord.denied_categories", d.DeniedCategories)
+	for _, channelID := range d.AllowedChannels {
+		for _, denied := range d.DeniedChannels {
+			if channelID == denied {
+				errs.add("discord: channel %s is listed in both allowed_channels and denied_channels", channelID)
+			}
+		}
+	}
+	for _, categoryID := range d.AllowedCategories {
+		for _, denied := range d.DeniedCategories {
+			if categoryID == denied {
+				errs.add("discord: category %s is listed in both allowed_categories and denied_categories", categoryID)
+			}
+		}
+	}
+
+	// Limits
+	if d.MaxMessageLength < 1 || d.MaxMessageLength > maxDiscordMessageLength {
+		errs.add("discord.max_message_length: must be between 1 and %d, got %d", maxDiscordMessageLength, d.MaxMessageLength)
+	}
+	if d.RateLimitPerMinute < 1 || d.RateLimitPerMinute > maxRateLimitPerMinute {
+		errs.add("discord.rate_limit_per_minute: must be between 1 and %d, got %d", maxRateLimitPerMinute, d.RateLimitPerMinute)
+	}
+	if d.Retry.MaxRetries < 0 || d.Retry.MaxRetries > maxRetries {
+		errs.add("discord.retry.max_retries: must be between 0 and %d, got %d", maxRetries, d.Retry.MaxRetries)
+	}
+	if d.Retry.BaseDelayMs <= 0 {
+		errs.add("discord.retry.base_delay_ms: must be positive, got %d", d.Retry.BaseDelayMs)
+	}
+	if d.Retry.MaxDelayMs < d.Retry.BaseDelayMs {
+		errs.add("discord.retry.max_delay_ms: must be at least base_delay_ms (%d), got %d", d.Retry.BaseDelayMs, d.Retry.MaxDelayMs)
+	}
+	if d.Retry.BudgetMs < 0 {
+		errs.add("discord.retry.budget_ms: must not be negative, got %d", d.Retry.BudgetMs)
+	}
+	if d.HistoryConcurrency < 1 || d.HistoryConcurrency > maxHistoryConcurrency {
+		errs.add("discord.history_concurrency: must be between 1 and %d, got %d", maxHistoryConcurrency, d.HistoryConcurrency)
+	}
+
+	// MCP and server
+	if c.MCP.ServerName == "" {
+		errs.add("mcp.server_name: must not be empty")
+	}
+	if c.MCP.ResponseDetail != "full" && c.MCP.ResponseDetail != "compact" {
+		errs.add("mcp.response_detail: %q must be full or compact", c.MCP.ResponseDetail)
+	}
+	if c.MCP.MaxResultBytes != 0 && c.MCP.MaxResultBytes < 1024 {
+		errs.add("mcp.max_result_bytes: must be 0 (unlimited) or at least 1024, got %d", c.MCP.MaxResultBytes)
+	}
+	if c.MCP.IdempotencyTTLSeconds < 0 || c.MCP.IdempotencyTTLSeconds > 86400 {
+		errs.add("mcp.idempotency_ttl_seconds: must be between 0 (disabled) and 86400, got %d", c.MCP.IdempotencyTTLSeconds)
+	}
+	if _, err := logrus.ParseLevel(c.Server.LogLevel); err != nil {
+		errs.add("server.log_level: %q is not a valid level (debug, info, warn, error)", c.Server.LogLevel)
+	}
+	if c.Server.LogFormat != "text" && c.Server.LogFormat != "json" {
+		errs.add("server.log_format: %q must be text or json", c.Server.LogFormat)
+	}
+	if i18n.Normalize(c.Server.Locale) == "" {
+		errs.add("server.locale: %q has no message catalog (available: %s)", c.Server.Locale, strings.Join(i18n.Supported(), ", "))
+	}
+	if _, err := timezone.Load(c.Server.Timezone); err != nil {
+		errs.add("server.timezone: %v", err)
+	}
+
+	// Guilds
+	guildIDs := make([]string, 0, len(c.Guilds))
+	for guildID := range c.Guilds {
+		guildIDs = append(guildIDs, guildID)
+	}
+	sort.Strings(guildIDs)
+	for _, guildID := range guildIDs {
+		guild := c.Guilds[guildID]
+		if !isSnowflake(guildID) {
+			errs.add("guilds: %q is not a valid Discord ID", guildID)
+		}
+		if _, err := timezone.Load(guild.Timezone); guild.Timezone != "" && err != nil {
+			errs.add("guilds.%s.timezone: %v", guildID, err)
+		}
+		if guild.Locale != "" && i18n.Normalize(guild.Locale) == "" {
+			errs.add("guilds.%s.locale: %q has no message catalog (available: %s)", guildID, guild.Locale, strings.Join(i18n.Supported(), ", "))
+		}
+	}
+
+	// Events
+	for i, event := range c.Events.AllowedEvents {
+		if !strings.HasPrefix(event, "discord/") {
+			errs.add("events.allowed_events[%d]: %q is not a discord/ event", i, event)
+		}
+	}
+
+	for event, limit := range c.Events.RateLimits {
+		if !strings.HasPrefix(event, "discord/") {
+			errs.add("events.rate_limits: %q is not a discord/ event", event)
+		}
+		if limit < 0 {
+			errs.add("events.rate_limits[%s]: must not be negative, got %d", event, limit)
+		}
+	}
+	if c.Events.DefaultRateLimit < 0 {
+		errs.add("events.default_rate_limit: must not be negative, got %d", c.Events.DefaultRateLimit)
+	}
+	if c.Events.Batching.IntervalMs < 100 {
+		errs.add("events.batching.interval_ms: must be at least 100, got %d", c.Events.Batching.IntervalMs)
+	}
+	if c.Events.Batching.MaxSamples < 0 {
+		errs.add("events.batching.max_samples: must not be negative, got %d", c.Events.Batching.MaxSamples)
+	}
+	if n := c.Events.AddressedMessages.ContextMessages; n < 0 || n > 100 {
+		errs.add("events.addressed_messages.context_messages: must be between 0 and 100, got %d", n)
+	}
+	if c.Events.Buffer.Enabled && c.Events.Buffer.Size < 1 {
+		errs.add("events.buffer.size: must be positive when the buffer is enabled, got %d", c.Events.Buffer.Size)
+	}
+
+	// Policy
+	if !isPolicyAction(c.Policy.DefaultAction) {
+		errs.add("policy.default_action: %q must be allow, deny or confirm", c.Policy.DefaultAction)
+	}
+	for i, rule := range c.Policy.Rules {
+		if !isPolicyAction(rule.Action) {
+			errs.add("policy.rules[%d].action: %q must be allow, deny or confirm", i, rule.Action)
+		}
+		validateIDList(errs, fmt.Sprintf("policy.rules[%d].guilds", i), withoutWildcard(rule.Guilds))
+		validateIDList(errs, fmt.Sprintf("policy.rules[%d].channels", i), withoutWildcard(rule.Channels))
+		validateIDList(errs, fmt.Sprintf("policy.rules[%d].roles", i), withoutWildcard(rule.Roles))
+	}
+
+	// Validation
+	if !isUnknownParameterMode(c.Validation.UnknownParameters) {
+		errs.add("validation.unknown_parameters: %q must be lenient or strict", c.Validation.UnknownParameters)
+	}
+	tools := make([]string, 0, len(c.Validation.ToolModes))
+	for tool := range c.Validation.ToolModes {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+	for _, tool := range tools {
+		if mode := c.Validation.ToolModes[tool]; !isUnknownParameterMode(mode) {
+			errs.add("validation.tool_modes.%s: %q must be lenient or strict", tool, mode)
+		}
+	}
+
+	// Voice
+	if c.Voice.Enabled {
+		if c.Voice.FFmpegPath == "" {
+			errs.add("voice.ffmpeg_path: must not be empty when voice is enabled")
+		}
+		if c.Voice.MaxDurationSeconds < 1 {
+			errs.add("voice.max_duration_seconds: must be positive, got %d", c.Voice.MaxDurationSeconds)
+		}
+		if c.Voice.BitrateKbps < 8 || c.Voice.BitrateKbps > 512 {
+			errs.add("voice.bitrate_kbps: must be between 8 and 512, got %d", c.Voice.BitrateKbps)
+		}
+	}
+	tts := c.Voice.TTS
+	switch tts.Backend {
+	case "":
+	case "command":
+		if len(tts.Command) == 0 {
+			errs.add("voice.tts.command: required for the command backend")
+		}
+	case "http":
+		if !strings.HasPrefix(tts.URL, "http://") && !strings.HasPrefix(tts.URL, "https://") {
+			errs.add("voice.tts.url: %q must be an http(s) URL", tts.URL)
+		}
+	default:
+		errs.add("voice.tts.backend: %q must be command or http", tts.Backend)
+	}
+	if tts.Backend != "" && tts.TimeoutSeconds < 1 {
+		errs.add("voice.tts.timeout_seconds: must be positive, got %d", tts.TimeoutSeconds)
+	}
+	if tts.Backend != "" && tts.MaxTextLength < 1 {
+		errs.add("voice.tts.max_text_length: must be positive, got %d", tts.MaxTextLength)
+	}
+
+	// Cache
+	if c.Cache.Enabled && c.Cache.TTLSeconds <= 0 {
+		errs.add("cache.ttl_seconds: must be positive when the cache is enabled, got %d", c.Cache.TTLSeconds)
+	}
+
+	// Export
+	if c.Export.Directory == "" {
+		errs.add("export.directory: must not be empty")
+	}
+	if c.Export.MaxMessages < 1 || c.Export.MaxMessages > 1000000 {
+		errs.add("export.max_messages: must be between 1 and 1000000, got %d", c.Export.MaxMessages)
+	}
+
+	// Summarizer
+	summarizer := c.Summarizer
+	switch summarizer.Backend {
+	case "":
+	case "command":
+		if len(summarizer.Command) == 0 {
+			errs.add("summarizer.command: required for the command backend")
+		}
+	case "http":
+		if !strings.HasPrefix(summarizer.URL, "http://") && !strings.HasPrefix(summarizer.URL, "https://") {
+			errs.add("summarizer.url: %q must be an http(s) URL", summarizer.URL)
+		}
+	default:
+		errs.add("summarizer.backend: %q must be command or http", summarizer.Backend)
+	}
+	if summarizer.Backend != "" && summarizer.TimeoutSeconds < 1 {
+		errs.add("summarizer.timeout_seconds: must be positive, got %d", summarizer.TimeoutSeconds)
+	}
+	if summarizer.MaxInputChars < 0 {
+		errs.add("summarizer.max_input_chars: must not be negative, got %d", summarizer.MaxInputChars)
+	}
+
+	// Archive
+	validateIDList(errs, "archive.guilds", c.Archive.Guilds)
+	validateIDList(errs, "archive.channels", c.Archive.Channels)
+	if c.Archive.Enabled {
+		if c.Archive.Path == "" {
+			errs.add("archive.path: must not be empty when the archive is enabled")
+		}
+		if len(c.Archive.Guilds) == 0 && len(c.Archive.Channels) == 0 {
+			errs.add("archive: enabled but no guilds or channels are subscribed")
+		}
+		if c.Archive.FlushIntervalMs < 100 {
+			errs.add("archive.flush_interval_ms: must be at least 100, got %d", c.Archive.FlushIntervalMs)
+		}
+	}
+
+	// Templates
+	if c.Templates.Directory == "" {
+		errs.add("templates.directory: must not be empty")
+	}
+
+	// CDN
+	if size := c.CDN.ImageSize; size < 16 || size > 4096 || size&(size-1) != 0 {
+		errs.add("cdn.image_size: must be a power of two between 16 and 4096, got %d", size)
+	}
+	switch strings.ToLower(c.CDN.ImageFormat) {
+	case "png", "jpg", "jpeg", "webp":
+	default:
+		errs.add("cdn.image_format: must be one of png, jpg or webp, got %q", c.CDN.ImageFormat)
+	}
+
+	// Images
+	if c.Images.MaxBytes < 1 || c.Images.MaxBytes > 20971520 {
+		errs.add("images.max_bytes: must be between 1 and 20971520, got %d", c.Images.MaxBytes)
+	}
+	if c.Images.MaxImages < 1 || c.Images.MaxImages > 20 {
+		errs.add("images.max_images: must be between 1 and 20, got %d", c.Images.MaxImages)
+	}
+	if c.Images.TimeoutSeconds < 1 || c.Images.TimeoutSeconds > 60 {
+		errs.add("images.timeout_seconds: must be between 1 and 60, got %d", c.Images.TimeoutSeconds)
+	}
+
+	// Onboarding
+	ruleNames := make(map[string]bool)
+	for i, rule := range c.Onboarding.Rules {
+		path := fmt.Sprintf("onboarding.rules[%d]", i)
+		if rule.Name == "" {
+			errs.add("%s.name: is required", path)
+		} else if ruleNames[rule.GuildID+"/"+rule.Name] {
+			errs.add("%s.name: %q is already used in guild %s", path, rule.Name, rule.GuildID)
+		}
+		ruleNames[rule.GuildID+"/"+rule.Name] = true
+		if !isSnowflake(rule.GuildID) {
+			errs.add("%s.guild_id: %q is not a valid Discord ID", path, rule.GuildID)
+		}
+		validateIDList(errs, path+".role_ids", rule.RoleIDs)
+		if rule.ChannelID != "" && !isSnowflake(rule.ChannelID) {
+			errs.add("%s.channel_id: %q is not a valid Discord ID", path, rule.ChannelID)
+		}
+		if (rule.ChannelID == "") != (rule.ChannelMessage == "") {
+			errs.add("%s: channel_id and channel_message must be set together", path)
+		}
+		if len(rule.RoleIDs) == 0 && rule.DirectMessage == "" && rule.ChannelMessage == "" {
+			errs.add("%s: set at least one of role_ids, direct_message or channel_message", path)
+		}
+		if n := len([]rune(rule.DirectMessage)); n > maxDiscordMessageLength {
+			errs.add("%s.direct_message: must be at most %d characters, got %d", path, maxDiscordMessageLength, n)
+		}
+		if n := len([]rune(rule.ChannelMessage)); n > maxDiscordMessageLength {
+			errs.add("%s.channel_message: must be at most %d characters, got %d", path, maxDiscordMessageLength, n)
+		}
+	}
+
+	// Schedule
+	if c.Schedule.Enabled && c.Schedule.Path == "" {
+		errs.add("schedule.path: is required when the schedule is enabled")
+	}
+
+	// Giveaways
+	if c.Giveaways.Enabled && c.Giveaways.Path == "" {
+		errs.add("giveaways.path: is required when giveaways are enabled")
+	}
+
+	// Starboard
+	if c.Starboard.Enabled && c.Starboard.Path == "" {
+		errs.add("starboard.path: is required when the starboard is enabled")
+	}
+
+	// Pinning policies
+	if c.Pinning.Enabled && c.Pinning.Path == "" {
+		errs.add("pinning.path: is required when pinning policies are enabled")
+	}
+
+	// User notes
+	if c.Notes.Enabled {
+		if c.Notes.Path == "" {
+			errs.add("user_notes.path: is required when user notes are enabled")
+		}
+		if c.Notes.MaxPerUser < 1 {
+			errs.add("user_notes.max_per_user: must be positive, got %d", c.Notes.MaxPerUser)
+		}
+	}
+
+	// Warnings
+	if c.Warnings.Enabled {
+		if c.Warnings.Path == "" {
+			errs.add("warnings.path: is required when warnings are enabled")
+		}
+		if c.Warnings.ExpireDays < 0 {
+			errs.add("warnings.expire_days: must not be negative, got %d", c.Warnings.ExpireDays)
+		}
+		seen := make(map[int]bool)
+		for i, step := range c.Warnings.Escalation {
+			if step.Warnings < 1 {
+				errs.add("warnings.escalation[%d].warnings: must be positive, got %d", i, step.Warnings)
+			} else if seen[step.Warnings] {
+				errs.add("warnings.escalation[%d].warnings: %d is already used by another step", i, step.Warnings)
+			}
+			seen[step.Warnings] = true
+			switch step.Action {
+			case "timeout":
+				if step.DurationMinutes < 1 || step.DurationMinutes > 28*24*60 {
+					errs.add("warnings.escalation[%d].duration_minutes: must be between 1 and 40320 (28 days), got %d", i, step.DurationMinutes)
+				}
+			case "kick", "ban":
+			default:
+				errs.add("warnings.escalation[%d].action: must be timeout, kick or ban, got %q", i, step.Action)
+			}
+		}
+	}
+
+	// Tickets
+	if c.Tickets.Enabled && c.Tickets.Path == "" {
+		errs.add("tickets.path: is required when tickets are enabled")
+	}
+
+	// Role menus
+	if c.RoleMenus.Enabled && c.RoleMenus.Path == "" {
+		errs.add("role_menus.path: is required when role menus are enabled")
+	}
+
+	// Verification
+	if c.Verification.Enabled && c.Verification.Path == "" {
+		errs.add("verification.path: is required when verification is enabled")
+	}
+
+	// Broadcast
+	if c.Broadcast.IntervalMs < 0 || c.Broadcast.IntervalMs > maxBroadcastIntervalMs {
+		errs.add("broadcast.interval_ms: must be between 0 and %d, got %d", maxBroadcastIntervalMs, c.Broadcast.IntervalMs)
+	}
+	broadcastLists := make(map[string]bool)
+	for i, list := range c.Broadcast.Lists {
+		path := fmt.Sprintf("broadcast.lists[%d]", i)
+		if list.Name == "" {
+			errs.add("%s.name: is required", path)
+		} else if broadcastLists[list.Name] {
+			errs.add("%s.name: duplicate list %q", path, list.Name)
+		}
+		broadcastLists[list.Name] = true
+		if len(list.ChannelIDs) == 0 || len(list.ChannelIDs) > maxBroadcastTargets {
+			errs.add("%s.channel_ids: must list 1 to %d channels, got %d", path, maxBroadcastTargets, len(list.ChannelIDs))
+		}
+		validateIDList(errs, path+".channel_ids", list.ChannelIDs)
+	}
+
+	// Bridges
+	if c.Bridges.Enabled && c.Bridges.Path == "" {
+		errs.add("bridges.path: is required when bridges are enabled")
+	}
+
+	// Relay
+	if c.Relay.Enabled {
+		if _, _, err := net.SplitHostPort(c.Relay.Listen); err != nil {
+			errs.add("relay.listen: must be a host:port address, got %q", c.Relay.Listen)
+		}
+		if c.Relay.Path == "" {
+			errs.add("relay.path: is required when the relay is enabled")
+		}
+	}
+	if c.Relay.PublicURL != "" && !strings.HasPrefix(c.Relay.PublicURL, "http://") && !strings.HasPrefix(c.Relay.PublicURL, "https://") {
+		errs.add("relay.public_url: must be an http or https URL, got %q", c.Relay.PublicURL)
+	}
+	if c.Relay.MaxBodyBytes < 1 || c.Relay.MaxBodyBytes > maxRelayBodyBytes {
+		errs.add("relay.max_body_bytes: must be between 1 and %d, got %d", maxRelayBodyBytes, c.Relay.MaxBodyBytes)
+	}
+
+	// Feeds
+	if c.Feeds.Enabled && c.Feeds.Path == "" {
+		errs.add("feeds.path: is required when feeds are enabled")
+	}
+	if c.Feeds.MinIntervalMinutes < 1 || c.Feeds.MinIntervalMinutes > maxFeedIntervalMinutes {
+		errs.add("feeds.min_interval_minutes: must be between 1 and %d, got %d", maxFeedIntervalMinutes, c.Feeds.MinIntervalMinutes)
+	}
+	if c.Feeds.IntervalMinutes < c.Feeds.MinIntervalMinutes || c.Feeds.IntervalMinutes > maxFeedIntervalMinutes {
+		errs.add("feeds.interval_minutes: must be between feeds.min_interval_minutes and %d, got %d", maxFeedIntervalMinutes, c.Feeds.IntervalMinutes)
+	}
+	if c.Feeds.MaxEntriesPerCheck < 1 || c.Feeds.MaxEntriesPerCheck > maxFeedEntriesPerCheck {
+		errs.add("feeds.max_entries_per_check: must be between 1 and %d, got %d", maxFeedEntriesPerCheck, c.Feeds.MaxEntriesPerCheck)
+	}
+
+	// Raid protection
+	validateIDList(errs, "raid.guilds", c.Raid.Guilds)
+	if c.Raid.JoinThreshold < 2 {
+		errs.add("raid.join_threshold: must be at least 2, got %d", c.Raid.JoinThreshold)
+	}
+	if c.Raid.JoinWindowSeconds < 1 {
+		errs.add("raid.join_window_seconds: must be at least 1, got %d", c.Raid.JoinWindowSeconds)
+	}
+	if c.Raid.MessageThreshold < 2 {
+		errs.add("raid.message_threshold: must be at least 2, got %d", c.Raid.MessageThreshold)
+	}
+	if c.Raid.MessageWindowSeconds < 1 {
+		errs.add("raid.message_window_seconds: must be at least 1, got %d", c.Raid.MessageWindowSeconds)
+	}
+	if c.Raid.SlowmodeSeconds < 0 || c.Raid.SlowmodeSeconds > 21600 {
+		errs.add("raid.slowmode_seconds: must be between 0 and 21600, got %d", c.Raid.SlowmodeSeconds)
+	}
+	if c.Raid.CooldownSeconds < 0 {
+		errs.add("raid.cooldown_seconds: must not be negative, got %d", c.Raid.CooldownSeconds)
+	}
+
+	// Undo journal
+	if c.Undo.Enabled && (c.Undo.TTLSeconds < 1 || c.Undo.TTLSeconds > 86400) {
+		errs.add("undo.ttl_seconds: must be between 1 and 86400 when undo is enabled, got %d", c.Undo.TTLSeconds)
+	}
+
+	// Membership log
+	if c.Membership.Enabled {
+		if c.Membership.Path == "" {
+			errs.add("membership_log.path: is required when the membership log is enabled")
+		}
+		if c.Membership.RetentionDays < 1 || c.Membership.RetentionDays > maxMembershipRetentionDays {
+			errs.add("membership_log.retention_days: must be between 1 and %d, got %d", maxMembershipRetentionDays, c.Membership.RetentionDays)
+		}
+	}
+
+	// Thread digest
+	if c.ThreadDigest.Enabled {
+		if c.ThreadDigest.HourUTC < 0 || c.ThreadDigest.HourUTC > 23 {
+			errs.add("thread_digest.hour_utc: must be between 0 and 23, got %d", c.ThreadDigest.HourUTC)
+		}
+		if c.ThreadDigest.WindowHours < 1 || c.ThreadDigest.WindowHours > maxThreadDigestWindowHours {
+			errs.add("thread_digest.window_hours: must be between 1 and %d, got %d", maxThreadDigestWindowHours, c.ThreadDigest.WindowHours)
+		}
+	}
+	digestGuilds := make(map[string]bool)
+	for i, guild := range c.ThreadDigest.Guilds {
+		path := fmt.Sprintf("thread_digest.guilds[%d]", i)
+		if !isSnowflake(guild.GuildID) {
+			errs.add("%s.guild_id: %q is not a valid Discord ID", path, guild.GuildID)
+		} else if digestGuilds[guild.GuildID] {
+			errs.add("%s.guild_id: guild %s is listed more than once", path, guild.GuildID)
+		}
+		digestGuilds[guild.GuildID] = true
+		if guild.ChannelID != "" && !isSnowflake(guild.ChannelID) {
+			errs.add("%s.channel_id: %q is not a valid Discord ID", path, guild.ChannelID)
+		}
+	}
+
+	// Safety scanning
+	validateDomainList(errs, "safety.allowed_domains", c.Safety.AllowedDomains)
+	validateDomainList(errs, "safety.denied_domains", c.Safety.DeniedDomains)
+	for i, ext := range c.Safety.DeniedExtensions {
+		if !strings.HasPrefix(ext, ".") || len(ext) < 2 {
+			errs.add("safety.denied_extensions[%d]: %q must start with a dot, e.g. .exe", i, ext)
+		}
+	}
+	if c.Safety.Hook.URL != "" && !strings.HasPrefix(c.Safety.Hook.URL, "http://") && !strings.HasPrefix(c.Safety.Hook.URL, "https://") {
+		errs.add("safety.hook.url: must be an http or https URL, got %q", c.Safety.Hook.URL)
+	}
+	if c.Safety.Enabled && (c.Safety.Hook.TimeoutMs < 100 || c.Safety.Hook.TimeoutMs > 30000) {
+		errs.add("safety.hook.timeout_ms: must be between 100 and 30000, got %d", c.Safety.Hook.TimeoutMs)
+	}
+
+	// Response cooldowns
+	if c.Cooldowns.UserLimit < 0 {
+		errs.add("response_cooldowns.user_limit: must not be negative, got %d", c.Cooldowns.UserLimit)
+	}
+	if c.Cooldowns.ChannelLimit < 0 {
+		errs.add("response_cooldowns.channel_limit: must not be negative, got %d", c.Cooldowns.ChannelLimit)
+	}
+	if c.Cooldowns.UserLimit > 0 && (c.Cooldowns.UserWindowSeconds < 1 || c.Cooldowns.UserWindowSeconds > maxCooldownWindowSeconds) {
+		errs.add("response_cooldowns.user_window_seconds: must be between 1 and %d, got %d", maxCooldownWindowSeconds, c.Cooldowns.UserWindowSeconds)
+	}
+	if c.Cooldowns.ChannelLimit > 0 && (c.Cooldowns.ChannelWindowSeconds < 1 || c.Cooldowns.ChannelWindowSeconds > maxCooldownWindowSeconds) {
+		errs.add("response_cooldowns.channel_window_seconds: must be between 1 and %d, got %d", maxCooldownWindowSeconds, c.Cooldowns.ChannelWindowSeconds)
+	}
+	validateIDList(errs, "response_cooldowns.exempt_users", c.Cooldowns.ExemptUsers)
+
+	// Outbound queue
+	if c.Outbound.Enabled {
+		if c.Outbound.Workers < 1 || c.Outbound.Workers > maxOutboundWorkers {
+			errs.add("outbound_queue.workers: must be between 1 and %d, got %d", maxOutboundWorkers, c.Outbound.Workers)
+		}
+		if c.Outbound.InteractiveWorkers < 0 || c.Outbound.InteractiveWorkers >= c.Outbound.Workers {
+			errs.add("outbound_queue.interactive_workers: must be at least 0 and less than workers (%d), got %d", c.Outbound.Workers, c.Outbound.InteractiveWorkers)
+		}
+		if c.Outbound.MaxPending < 1 {
+			errs.add("outbound_queue.max_pending: must be positive, got %d", c.Outbound.MaxPending)
+		}
+		if c.Outbound.BulkMaxPending < 1 || c.Outbound.BulkMaxPending > c.Outbound.MaxPending {
+			errs.add("outbound_queue.bulk_max_pending: must be between 1 and max_pending (%d), got %d", c.Outbound.MaxPending, c.Outbound.BulkMaxPending)
+		}
+	}
+
+	// Offline queue
+	if c.Offline.Enabled {
+		if c.Offline.MaxCalls < 1 || c.Offline.MaxCalls > maxOfflineCalls {
+			errs.add("offline_queue.max_calls: must be between 1 and %d, got %d", maxOfflineCalls, c.Offline.MaxCalls)
+		}
+		if c.Offline.TTLSeconds < 1 || c.Offline.TTLSeconds > maxOfflineTTLSeconds {
+			errs.add("offline_queue.ttl_seconds: must be between 1 and %d, got %d", maxOfflineTTLSeconds, c.Offline.TTLSeconds)
+		}
+	}
+
+	// Tracing can be turned on at runtime, so it is checked even when off
+	if c.Trace.MaxFileBytes < 1024 {
+		errs.add("trace.max_file_bytes: must be at least 1024, got %d", c.Trace.MaxFileBytes)
+	}
+	if c.Trace.MaxFiles < 0 || c.Trace.MaxFiles > maxTraceFiles {
+		errs.add("trace.max_files: must be between 0 and %d, got %d", maxTraceFiles, c.Trace.MaxFiles)
+	}
+	if c.Trace.Recent < 1 || c.Trace.Recent > maxTraceRecent {
+		errs.add("trace.recent: must be between 1 and %d, got %d", maxTraceRecent, c.Trace.Recent)
+	}
+	if c.Trace.MaxBodyBytes < 1 || c.Trace.MaxBodyBytes > maxTraceBodyBytes {
+		errs.add("trace.max_body_bytes: must be between 1 and %d, got %d", maxTraceBodyBytes, c.Trace.MaxBodyBytes)
+	}
+
+	// Telemetry
+	if c.Telemetry.Enabled {
+		if !strings.HasPrefix(c.Telemetry.Endpoint, "http://") && !strings.HasPrefix(c.Telemetry.Endpoint, "https://") {
+			errs.add("telemetry.endpoint: must be an http or https URL, got %q", c.Telemetry.Endpoint)
+		}
+		if c.Telemetry.ServiceName == "" {
+			errs.add("telemetry.service_name: must not be empty")
+		}
+		if c.Telemetry.SampleRatio < 0 || c.Telemetry.SampleRatio > 1 {
+			errs.add("telemetry.sample_ratio: must be between 0 and 1, got %g", c.Telemetry.SampleRatio)
+		}
+		if c.Telemetry.FlushIntervalMs < 100 || c.Telemetry.FlushIntervalMs > maxTelemetryFlushMs {
+			errs.add("telemetry.flush_interval_ms: must be between 100 and %d, got %d", maxTelemetryFlushMs, c.Telemetry.FlushIntervalMs)
+		}
+		if c.Telemetry.MaxQueue < 1 || c.Telemetry.MaxQueue > maxTelemetryQueue {
+			errs.add("telemetry.max_queue: must be between 1 and %d, got %d", maxTelemetryQueue, c.Telemetry.MaxQueue)
+		}
+	}
+
+	// Plugins
+	pluginNames := make(map[string]bool)
+	pluginTools := make(map[string]string)
+	for i, plugin := range c.Plugins {
+		path := fmt.Sprintf("plugins[%d]", i)
+		if plugin.Name == "" {
+			errs.add("%s.name: must not be empty", path)
+		} else if pluginNames[plugin.Name] {
+			errs.add("%s.name: plugin %q is listed more than once", path, plugin.Name)
+		}
+		pluginNames[plugin.Name] = true
+		if len(plugin.Command) == 0 || plugin.Command[0] == "" {
+			errs.add("%s.command: must name a program", path)
+		}
+		if plugin.TimeoutSeconds < 0 || plugin.TimeoutSeconds > maxPluginTimeoutSeconds {
+			errs.add("%s.timeout_seconds: must be between 0 and %d, got %d", path, maxPluginTimeoutSeconds, plugin.TimeoutSeconds)
+		}
+		if len(plugin.Tools) == 0 {
+			errs.add("%s.tools: must declare at least one tool", path)
+		}
+		for j, tool := range plugin.Tools {
+			toolPath := fmt.Sprintf("%s.tools[%d]", path, j)
+			if !pluginToolName.MatchString(tool.Name) {
+				errs.add("%s.name: %q must be 1 to 64 letters, digits, underscores or hyphens", toolPath, tool.Name)
+			} else if owner, ok := pluginTools[tool.Name]; ok {
+				errs.add("%s.name: tool %q is already provided by plugin %q", toolPath, tool.Name, owner)
+			}
+			pluginTools[tool.Name] = plugin.Name
+			if tool.Description == "" {
+				errs.add("%s.description: must not be empty", toolPath)
+			}
+			if schemaType, ok := tool.InputSchema["type"]; tool.InputSchema != nil && (!ok || schemaType != "object") {
+				errs.add("%s.input_schema: type must be object", toolPath)
+			}
+		}
+	}
+}
+
+// ValidateFile strictly loads and validates a configuration file as the
+// server would, environment variables included, reporting unknown keys
+// alongside every other problem
+func ValidateFile(filepath string) (*Config, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return parseConfig(data)
+}
+
+// RunValidateCommand implements the validate-config subcommand. It returns
+// the process exit code.
+func RunValidateCommand(filepath string, out io.Writer) int {
+	if _, err := ValidateFile(filepath); err != nil {
+		fmt.Fprintf(out, "%s: %v\n", filepath, err)
+		return 1
+	}
+
+	fmt.Fprintf(out, "%s: configuration is valid\n", filepath)
+	return 0
+}
+
+// parseConfig decodes data over the defaults, applies the environment
+// variables and validates the result. It is the only place configuration
+// is validated.
+func parseConfig(data []byte) (*Config, error) {
+	config := DefaultConfig()
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	var errs ValidationErrors
+	if len(root.Content) > 0 {
+		unknownKeys(root.Content[0], reflect.TypeOf(*config), "", &errs)
+
+		if err := root.Content[0].Decode(config); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+	}
+
+	// Environment variables such as DISCORD_TOKEN complete a file that
+	// leaves them out, so they apply before validation
+	config.LoadFromEnv()
+	config.validate(&errs)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return config, nil
+}
+
+// unknownKeys reports mapping keys that do not correspond to a yaml-tagged
+// struct field, following lists, maps and pointers down to the structs
+// they hold
+func unknownKeys(node *yaml.Node, t reflect.Type, path string, errs *ValidationErrors) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case node.Kind == yaml.SequenceNode && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+		for i, item := range node.Content {
+			unknownKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+		return
+	case node.Kind == yaml.MappingNode && t.Kind() == reflect.Map:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			unknownKeys(node.Content[i+1], t.Elem(), path+"."+node.Content[i].Value, errs)
+		}
+		return
+	case node.Kind != yaml.MappingNode || t.Kind() != reflect.Struct:
+		return
+	}
+
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = t.Field(i).Type
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		fieldType, ok := fields[key]
+		if !ok {
+			errs.add("%s: unknown key (line %d)", keyPath, node.Content[i].Line)
+			continue
+		}
+		unknownKeys(node.Content[i+1], fieldType, keyPath, errs)
+	}
+}
+
+// validateIDList checks that every entry of a list is a snowflake
+func validateIDList(errs *ValidationErrors, path string, ids []string) {
+	for i, id := range ids {
+		if !isSnowflake(id) {
+			errs.add("%s[%d]: %q is not a valid Discord ID", path, i, id)
+		}
+	}
+}
+
+func validateDomainList(errs *ValidationErrors, path string, domains []string) {
+	for i, domain := range domains {
+		if domain == "" || strings.ContainsAny(domain, "/: ") {
+			errs.add("%s[%d]: %q must be a bare domain such as example.com", path, i, domain)
+		}
+	}
+}
+
+func isPolicyAction(action string) bool {
+	return action == "allow" || action == "deny" || action == "confirm"
+}
+
+func isUnknownParameterMode(mode string) bool {
+	return mode == "lenient" || mode == "strict"
+}
+
+// withoutWildcard drops "*" entries, which match anything
+func withoutWildcard(ids []string) []string {
+	var filtered []string
+	for _, id := range ids {
+		if id != "*" {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered
+}
+
+// pluginToolName matches the tool names plugins may declare
+var pluginToolName = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// isSnowflake reports whether s looks like a Discord ID
+func isSnowflake(s string) bool {
+	if len(s) < 17 || len(s) > 20 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
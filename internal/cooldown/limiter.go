@@ -0,0 +1,210 @@
+// Package cooldown limits how often a single user or channel can trigger
+// event-driven responses, such as auto-response replies and addressed
+// message notifications, so one spammer cannot use up the agent's tokens
+// or the bot's Discord rate limits.
+package cooldown
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Scopes a response can be limited in
+const (
+	ScopeUser    = "user"
+	ScopeChannel = "channel"
+)
+
+// Limits are the number of responses allowed within a sliding window; a
+// limit of 0 disables that scope
+type Limits struct {
+	UserLimit     int
+	UserWindow    time.Duration
+	ChannelLimit  int
+	ChannelWindow time.Duration
+}
+
+// Entry is a user or channel with responses in its current window
+type Entry struct {
+	Scope string `json:"scope"`
+	ID    string `json:"id"`
+	// Responses counts the responses within the window
+	Responses int `json:"responses"`
+	// Suppressed counts the responses refused since the entry was created
+	Suppressed int `json:"suppressed"`
+	// CooldownUntil is when the next response is allowed; nil when one is
+	// allowed now
+	CooldownUntil *time.Time `json:"cooldown_until,omitempty"`
+}
+
+// window holds the response times of one user or channel, oldest first
+type window struct {
+	times      []time.Time
+	suppressed int
+}
+
+// Limiter tracks responses per user and per channel
+type Limiter struct {
+	limits Limits
+	exempt map[string]bool
+
+	mutex     sync.Mutex
+	users     map[string]*window
+	channels  map[string]*window
+	lastSweep time.Time
+}
+
+// NewLimiter creates a limiter; exempt users are never limited
+func NewLimiter(limits Limits, exemptUsers []string) *Limiter {
+	l := &Limiter{
+		limits:   limits,
+		exempt:   make(map[string]bool, len(exemptUsers)),
+		users:    make(map[string]*window),
+		channels: make(map[string]*window),
+	}
+	for _, id := range exemptUsers {
+		l.exempt[id] = true
+	}
+	return l
+}
+
+// Allow records a response to a user in a channel if neither is over its
+// limit. When the response is refused it returns the scope that refused it.
+func (l *Limiter) Allow(userID, channelID string, now time.Time) (bool, string) {
+	if l.exempt[userID] {
+		return true, ""
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.sweep(now)
+	user := l.window(l.users, userID, l.limits.UserWindow, now)
+	channel := l.window(l.channels, channelID, l.limits.ChannelWindow, now)
+
+	scope := ""
+	switch {
+	case l.limits.UserLimit > 0 && len(user.times) >= l.limits.UserLimit:
+		scope = ScopeUser
+		user.suppressed++
+	case l.limits.ChannelLimit > 0 && len(channel.times) >= l.limits.ChannelLimit:
+		scope = ScopeChannel
+		channel.suppressed++
+	}
+	if scope != "" {
+		return false, scope
+	}
+
+	if l.limits.UserLimit > 0 {
+		user.times = append(user.times, now)
+	}
+	if l.limits.ChannelLimit > 0 {
+		channel.times = append(channel.times, now)
+	}
+	return true, ""
+}
+
+// Reset clears the cooldown of a user, a channel, or both, and returns the
+// entries cleared. With neither set every cooldown is cleared.
+func (l *Limiter) Reset(userID, channelID string, now time.Time) []Entry {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	var cleared []Entry
+	drop := func(scope string, windows map[string]*window, id string, length time.Duration) {
+		if w, ok := windows[id]; ok {
+			cleared = append(cleared, l.entry(scope, id, w, length, now))
+			delete(windows, id)
+		}
+	}
+	if userID == "" && channelID == "" {
+		for id := range l.users {
+			drop(ScopeUser, l.users, id, l.limits.UserWindow)
+		}
+		for id := range l.channels {
+			drop(ScopeChannel, l.channels, id, l.limits.ChannelWindow)
+		}
+	}
+	if userID != "" {
+		drop(ScopeUser, l.users, userID, l.limits.UserWindow)
+	}
+	if channelID != "" {
+		drop(ScopeChannel, l.channels, channelID, l.limits.ChannelWindow)
+	}
+	sortEntries(cleared)
+	return cleared
+}
+
+// window returns the window of a user or channel with expired responses
+// dropped, creating it if needed
+func (l *Limiter) window(windows map[string]*window, id string, length time.Duration, now time.Time) *window {
+	w, ok := windows[id]
+	if !ok {
+		w = &window{}
+		windows[id] = w
+	}
+	w.expire(length, now)
+	return w
+}
+
+// sweep forgets users and channels whose windows have emptied, at most once
+// a minute
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < time.Minute {
+		return
+	}
+	l.lastSweep = now
+	for id, w := range l.users {
+		if w.expire(l.limits.UserWindow, now); len(w.times) == 0 {
+			delete(l.users, id)
+		}
+	}
+	for id, w := range l.channels {
+		if w.expire(l.limits.ChannelWindow, now); len(w.times) == 0 {
+			delete(l.channels, id)
+		}
+	}
+}
+
+// entry describes a window
+func (l *Limiter) entry(scope, id string, w *window, length time.Duration, now time.Time) Entry {
+	w.expire(length, now)
+	limit := l.limits.UserLimit
+	if scope == ScopeChannel {
+		limit = l.limits.ChannelLimit
+	}
+	entry := Entry{Scope: scope, ID: id, Responses: len(w.times), Suppressed: w.suppressed}
+	if limit > 0 && len(w.times) >= limit {
+		// The window reopens when enough of its oldest responses expire
+		until := w.times[len(w.times)-limit].Add(length)
+		entry.CooldownUntil = &until
+	}
+	return entry
+}
+
+// expire drops responses older than the window
+func (w *window) expire(length time.Duration, now time.Time) {
+	keep := 0
+	for keep < len(w.times) && now.Sub(w.times[keep]) >= length {
+		keep++
+	}
+	w.times = w.times[keep:]
+}
+
+// sortEntries orders entries in cooldown first, then by responses
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if (a.CooldownUntil == nil) != (b.CooldownUntil == nil) {
+			return a.CooldownUntil != nil
+		}
+		if a.Responses != b.Responses {
+			return a.Responses > b.Responses
+		}
+		if a.Scope != b.Scope {
+			return a.Scope < b.Scope
+		}
+		return a.ID < b.ID
+	})
+}
@@ -0,0 +1,105 @@
+package discord
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// isAddressedToBot reports whether a message mentions the bot or replies to
+// one of the bot's messages
+func isAddressedToBot(msg *discordgo.Message, botID string) bool {
+	if msg.Author == nil || msg.Author.ID == botID {
+		return false
+	}
+
+	for _, user := range msg.Mentions {
+		if user.ID == botID {
+			return true
+		}
+	}
+
+	ref := msg.ReferencedMessage
+	return ref != nil && ref.Author != nil && ref.Author.ID == botID
+}
+
+// forwardAddressedMessage sends a high-priority addressedMessage notification
+// with the preceding channel messages as conversation context
+func (d *EventDispatcher) forwardAddressedMessage(s *discordgo.Session, msg *discordgo.Message) {
+	cfg := d.config.AddressedMessages
+	if !d.config.Enabled || !cfg.Enabled || s.State.User == nil || !isAddressedToBot(msg, s.State.User.ID) {
+		return
+	}
+	if !d.allowResponse(msg, "addressed message") {
+		return
+	}
+	d.logger.Debugf("Forwarding addressed message %s", msg.ID)
+
+	params := map[string]interface{}{
+		"guild_id":        msg.GuildID,
+		"channel_id":      msg.ChannelID,
+		"message_id":      msg.ID,
+		"author_id":       msg.Author.ID,
+		"author_username": msg.Author.Username,
+		"content":         msg.Content,
+		"attachments":     attachmentSummaries(msg.Attachments),
+		"is_reply":        msg.ReferencedMessage != nil,
+	}
+	if d.contentHidden != nil && d.contentHidden(msg) {
+		params["content_unavailable"] = true
+	}
+
+	if cfg.ContextMessages > 0 && d.messageContext != nil {
+		history, err := d.messageContext(msg.ChannelID, msg.ID, cfg.ContextMessages)
+		if err != nil {
+			d.logger.Warnf("Failed to fetch context for addressed message %s: %v", msg.ID, err)
+		} else {
+			params["context"] = contextMessages(history)
+		}
+	}
+	d.enrich(params)
+
+	notification := d.createNotification("discord/addressedMessage", params)
+	if d.buffer != nil {
+		d.buffer.Add(notification)
+	}
+	// Addressed messages bypass batching and rate limits
+	if err := d.notificationSvc.SendImmediate(notification); err != nil {
+		d.logger.Errorf("Failed to send addressedMessage notification: %v", err)
+	}
+}
+
+// contextMessages formats history, which Discord returns newest first, as a
+// compact oldest-first transcript
+func contextMessages(history []*discordgo.Message) []map[string]interface{} {
+	context := make([]map[string]interface{}, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		msg := history[i]
+		entry := map[string]interface{}{
+			"message_id": msg.ID,
+			"content":    msg.Content,
+			"timestamp":  msg.Timestamp.Format(time.RFC3339),
+		}
+		if msg.Author != nil {
+			entry["author_id"] = msg.Author.ID
+			entry["author_username"] = msg.Author.Username
+			entry["bot"] = msg.Author.Bot
+		}
+		context = append(context, entry)
+	}
+	return context
+}
+
+// getMessagesBefore fetches up to limit messages preceding a message
+func (c *Client) getMessagesBefore(channelID, beforeID string, limit int) ([]*discordgo.Message, error) {
+	if limit > 100 {
+		limit = 100
+	}
+
+	var messages []*discordgo.Message
+	_, err := c.Retry(func() (err error) {
+		messages, err = c.session.ChannelMessages(channelID, limit, beforeID, "", "")
+		return err
+	})
+	return messages, err
+}
@@ -0,0 +1,43 @@
+package discord
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/outbound"
+)
+
+// runAutoResponses replies to a new message for every matching
+// auto-response. Replies are sent directly, without a notification.
+func (d *EventDispatcher) runAutoResponses(s *discordgo.Session, msg *discordgo.Message) {
+	if d.autoResponses == nil || s.State.User == nil {
+		return
+	}
+
+	for _, rule := range d.autoResponses.Match(msg, s.State.User.ID, time.Now()) {
+		if !d.allowResponse(msg, "auto-response "+rule.ID) {
+			continue
+		}
+		d.logger.Debugf("Auto-response %s triggered by message %s", rule.ID, msg.ID)
+
+		failIfNotExists := false
+		err := d.enqueue(msg.ChannelID, outbound.PriorityInteractive, func() error {
+			_, err := s.ChannelMessageSendComplex(msg.ChannelID, &discordgo.MessageSend{
+				Content: rule.Reply,
+				Reference: &discordgo.MessageReference{
+					MessageID:       msg.ID,
+					ChannelID:       msg.ChannelID,
+					GuildID:         msg.GuildID,
+					FailIfNotExists: &failIfNotExists,
+				},
+				// Replies never ping anyone, including the author
+				AllowedMentions: &discordgo.MessageAllowedMentions{},
+			})
+			return err
+		})
+		if err != nil {
+			d.logger.Warnf("Auto-response %s failed in channel %s: %v", rule.ID, msg.ChannelID, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package discord
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/bridge"
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/textsplit"
+)
+
+// maxWebhookUsername is the longest name a webhook message can carry
+const maxWebhookUsername = 80
+
+// mirrorMessage copies a message posted in a bridged channel into each of
+// its targets through the bridge's webhook, under the author's name and
+// avatar
+func (d *EventDispatcher) mirrorMessage(s *discordgo.Session, msg *discordgo.Message) {
+	if d.bridges == nil || msg.GuildID == "" || msg.Author == nil {
+		return
+	}
+	if msg.Type != discordgo.MessageTypeDefault && msg.Type != discordgo.MessageTypeReply {
+		return
+	}
+	// Messages posted by a bridge webhook or by the bot itself are never
+	// mirrored, so two bridges pointing at each other do not loop
+	if msg.WebhookID != "" && d.bridges.IsWebhook(msg.WebhookID) {
+		return
+	}
+	if s.State != nil && s.State.User != nil && msg.Author.ID == s.State.User.ID {
+		return
+	}
+
+	bridges := d.bridges.FromChannel(msg.ChannelID)
+	if len(bridges) == 0 {
+		return
+	}
+
+	params := d.mirrorParams(msg)
+	if params.Content == "" && len(params.Embeds) == 0 {
+		return
+	}
+	for _, b := range bridges {
+		if msg.Author.Bot && !b.IncludeBots {
+			continue
+		}
+		d.mirrorTo(s, b, msg, params)
+	}
+}
+
+// mirrorTo posts a mirrored message through one bridge, split when the
+// attachment links push it past the message length limit
+func (d *EventDispatcher) mirrorTo(s *discordgo.Session, b bridge.Bridge, msg *discordgo.Message, params discordgo.WebhookParams) {
+	limit := d.maxMessageLength
+	if limit <= 0 {
+		limit = 2000
+	}
+	parts := textsplit.Message(params.Content, limit)
+	if len(parts) == 0 {
+		parts = []string{""}
+	}
+
+	for i, part := range parts {
+		data := params
+		data.Content = part
+		if i < len(parts)-1 {
+			data.Embeds = nil
+		}
+		err := d.enqueue(b.TargetChannelID, outbound.PriorityNormal, func() error {
+			_, err := s.WebhookExecute(b.WebhookID, b.WebhookToken, false, &data)
+			return err
+		})
+		if err != nil {
+			d.logger.Warnf("Bridge %s failed to mirror message %s: %v", b.ID, msg.ID, err)
+			return
+		}
+	}
+
+	if err := d.bridges.Record(b.ID, msg.Timestamp); err != nil {
+		d.logger.Warnf("Failed to save bridge %s: %v", b.ID, err)
+	}
+}
+
+// mirrorParams builds the webhook message that mirrors msg: its content with
+// attachment links, its rich embeds, and the author's display name and
+// avatar. Mirrored text never pings anyone in the target guild.
+func (d *EventDispatcher) mirrorParams(msg *discordgo.Message) discordgo.WebhookParams {
+	lines := []string{}
+	if msg.Content != "" {
+		lines = append(lines, msg.Content)
+	}
+	for _, attachment := range msg.Attachments {
+		lines = append(lines, attachment.URL)
+	}
+	for _, sticker := range msg.StickerItems {
+		lines = append(lines, "["+sticker.Name+"]")
+	}
+
+	var embeds []*discordgo.MessageEmbed
+	for _, embed := range msg.Embeds {
+		if embed.Type == discordgo.EmbedTypeRich || embed.Type == "" {
+			embeds = append(embeds, embed)
+		}
+	}
+
+	name := msg.Author.GlobalName
+	if name == "" {
+		name = msg.Author.Username
+	}
+	avatar := d.cdn.UserAvatar(msg.Author)
+	if msg.Member != nil {
+		if msg.Member.Nick != "" {
+			name = msg.Member.Nick
+		}
+		member := *msg.Member
+		member.User = msg.Author
+		avatar = d.cdn.MemberAvatar(msg.GuildID, &member)
+	}
+	if runes := []rune(name); len(runes) > maxWebhookUsername {
+		name = string(runes[:maxWebhookUsername])
+	}
+
+	return discordgo.WebhookParams{
+		Content:         strings.Join(lines, "\n"),
+		Username:        name,
+		AvatarURL:       avatar,
+		Embeds:          embeds,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}
+}
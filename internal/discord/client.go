@@ -1,6 +1,7 @@
 package discord
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -8,23 +9,194 @@ import (
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
 
+	"discord-mcp/internal/analytics"
+	"discord-mcp/internal/archive"
+	"discord-mcp/internal/autoresponse"
+	"discord-mcp/internal/bridge"
+	"discord-mcp/internal/cache"
+	"discord-mcp/internal/cdn"
 	"discord-mcp/internal/config"
+	"discord-mcp/internal/cooldown"
+	"discord-mcp/internal/feeds"
+	"discord-mcp/internal/giveaway"
+	"discord-mcp/internal/history"
+	"discord-mcp/internal/journal"
+	"discord-mcp/internal/members"
+	"discord-mcp/internal/membership"
+	"discord-mcp/internal/notes"
 	"discord-mcp/internal/notifications"
+	"discord-mcp/internal/onboarding"
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/pinning"
+	"discord-mcp/internal/raid"
+	"discord-mcp/internal/relay"
+	"discord-mcp/internal/rolemenu"
+	"discord-mcp/internal/safety"
+	"discord-mcp/internal/schedule"
+	"discord-mcp/internal/secrets"
+	"discord-mcp/internal/starboard"
+	"discord-mcp/internal/summarize"
+	"discord-mcp/internal/telemetry"
+	"discord-mcp/internal/threads"
+	"discord-mcp/internal/tickets"
+	"discord-mcp/internal/timezone"
+	"discord-mcp/internal/trace"
+	"discord-mcp/internal/verification"
+	"discord-mcp/internal/voice"
+	"discord-mcp/internal/warnings"
+	"discord-mcp/internal/watch"
+)
+
+// Gateway connection states
+const (
+	StateConnected    = "connected"
+	StateReconnecting = "reconnecting"
+	StateDisconnected = "disconnected"
 )
 
 // Client wraps the Discord session and provides higher-level operations
 type Client struct {
-	session    *discordgo.Session
-	config     *config.Config
-	logger     *logrus.Logger
-	dispatcher *EventDispatcher
+	session         *discordgo.Session
+	config          *config.Config
+	logger          *logrus.Logger
+	dispatcher      *EventDispatcher
+	notificationSvc *notifications.Service
 
 	// Connection state
-	connected bool
-	mutex     sync.RWMutex
+	connected      bool
+	gatewayState   string
+	messageContent bool
+	mutex          sync.RWMutex
 
 	// Rate limiting
 	rateLimiter *rateLimiter
+
+	// Retry handling for transient REST failures
+	retryPolicy retryPolicy
+	// Time spent waiting out Discord rate limits since startup
+	rateLimitWaits rateLimitWaits
+
+	// Entity cache for frequently repeated lookups
+	cache *cache.Cache
+
+	// Time zones of the server and of individual guilds
+	zones *timezone.Zones
+
+	// Bounded concurrent pagination for history scans
+	history *history.Scanner
+
+	// Last presence set by SetPresence, restored after reconnects
+	presence *discordgo.UpdateStatusData
+
+	// Voice connections and audio playback
+	voice *voice.Manager
+
+	// Server-side message and reaction triggers
+	watches *watch.Registry
+
+	// channelAccess reports whether events from a channel may reach the
+	// client; set by the server, since the access rules live in the
+	// permissions checker
+	channelAccess func(guildID, channelID string) bool
+
+	// Pattern to reply rules answered without a client round trip
+	autoResponses *autoresponse.Registry
+
+	// Member join rules; nil when disabled
+	onboarding *onboarding.Registry
+
+	// Recurring channel posts; nil when disabled
+	schedule *schedule.Scheduler
+
+	// Reaction-entry giveaways; nil when disabled
+	giveaways *giveaway.Store
+
+	// Starboard settings and reposts; nil when disabled
+	starboard *starboard.Store
+
+	// Per-channel pinning policies; nil when disabled
+	pinning *pinning.Store
+
+	// Moderator notes about members; nil when disabled
+	notes *notes.Store
+
+	// Member warnings and their escalation; nil when disabled
+	warnings *warnings.Store
+
+	// Support tickets and their DM relay; nil when disabled
+	tickets *tickets.Store
+
+	// Self-assign role menus; nil when disabled
+	roleMenus *rolemenu.Store
+
+	// Verification gates and unverified members; nil when disabled
+	verification *verification.Store
+
+	// Channel bridges; nil when disabled
+	bridges *bridge.Store
+
+	// Incoming payload relays and their endpoint; nil when disabled
+	relays      *relay.Store
+	relayServer *relay.Server
+
+	// Watched RSS and Atom feeds; nil when disabled
+	feeds *feeds.Watcher
+
+	// External summarizer for summarize: true; nil when not configured
+	summarizer summarize.Summarizer
+
+	// Join and message flood detection; nil when disabled
+	raid *raid.Detector
+
+	// Reversible actions for undo; nil when disabled
+	journal *journal.Journal
+
+	// Per-guild join, leave and message counters
+	activity *analytics.Tracker
+
+	// Gateway member requests started with stream_guild_members
+	memberStreams *members.Registry
+
+	// Persisted member joins and leaves; nil when disabled
+	membership *membership.Log
+
+	// Daily digest of threads about to auto-archive; nil when disabled or
+	// no guild is configured
+	threadDigest *threads.Digester
+
+	// Link and attachment scanners; nil when disabled
+	safety *safety.Pipeline
+
+	// Per-user and per-channel limits on event-driven responses; nil when
+	// disabled
+	cooldowns *cooldown.Limiter
+
+	// Prioritized queue messages are sent through; nil when disabled
+	outbound *outbound.Queue
+
+	// Records REST exchanges and gateway events while tracing is on
+	tracer *trace.Recorder
+
+	// Exports OpenTelemetry spans; nil when disabled
+	telemetry *telemetry.Tracer
+
+	// onConnected runs after the gateway reconnects
+	onConnected func()
+
+	// Local SQLite message archive; nil when disabled
+	archive *archive.Archive
+
+	// Recent events for clients that poll instead of receiving notifications
+	eventBuffer *notifications.Buffer
+
+	// Re-reads the bot token from its secret provider to pick up rotations
+	tokenWatcher *secrets.Watcher
+
+	// Builds avatar, icon and emoji URLs for formatted responses
+	cdn *cdn.Builder
+
+	// When the client was created, for uptime reporting
+	startedAt time.Time
 }
 
 // rateLimiter implements simple rate limiting
@@ -33,12 +205,27 @@ type rateLimiter struct {
 	maxReqs  int
 	duration time.Duration
 	mutex    sync.Mutex
+	// rejected counts requests refused since startup
+	rejected int64
 }
 
 // NewClient creates a new Discord client
 func NewClient(cfg *config.Config, logger *logrus.Logger) (*Client, error) {
+	// Resolve the bot token from its configured source
+	provider, err := secrets.NewTokenProvider(cfg.Discord)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure token provider: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	token, err := provider.Fetch(ctx)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Discord token from %s provider: %w", provider.Name(), err)
+	}
+
 	// Create Discord session
-	session, err := discordgo.New("Bot " + cfg.Discord.Token)
+	session, err := discordgo.New("Bot " + token)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Discord session: %w", err)
 	}
@@ -48,13 +235,270 @@ func NewClient(cfg *config.Config, logger *logrus.Logger) (*Client, error) {
 		discordgo.IntentsDirectMessages |
 		discordgo.IntentsGuilds |
 		discordgo.IntentsGuildMembers |
-		discordgo.IntentsGuildMessageReactions
+		discordgo.IntentsGuildMessageReactions |
+		discordgo.IntentsGuildEmojis
+	if cfg.Discord.MessageContentIntent {
+		session.Identify.Intents |= discordgo.IntentsMessageContent
+	}
+	session.Identify.Intents |= eventIntents(cfg.Events)
+	if cfg.Voice.Enabled {
+		// Joining voice waits for the bot's own voice state update
+		session.Identify.Intents |= discordgo.IntentsGuildVoiceStates
+	}
+
+	// Track guilds, channels, roles and members from gateway events so
+	// lookups can be served locally before falling back to REST
+	session.StateEnabled = true
+	session.State.TrackChannels = true
+	session.State.TrackRoles = true
+	session.State.TrackMembers = true
+
+	// Retries are handled by Client.Retry so they can be counted and budgeted
+	session.ShouldRetryOnRateLimit = false
+	session.MaxRestRetries = 0
 
+	retryCfg := cfg.Discord.Retry
 	client := &Client{
-		session:     session,
-		config:      cfg,
-		logger:      logger,
-		rateLimiter: newRateLimiter(cfg.Discord.RateLimitPerMinute, time.Minute),
+		session:       session,
+		config:        cfg,
+		logger:        logger,
+		rateLimiter:   newRateLimiter(cfg.Discord.RateLimitPerMinute, time.Minute),
+		retryPolicy:   newRetryPolicy(retryCfg.MaxRetries, retryCfg.BaseDelayMs, retryCfg.MaxDelayMs, retryCfg.BudgetMs),
+		cache:         cache.NewCache(cfg.Cache.Enabled, time.Duration(cfg.Cache.TTLSeconds)*time.Second, logger),
+		gatewayState:  StateDisconnected,
+		watches:       watch.NewRegistry(),
+		autoResponses: autoresponse.NewRegistry(),
+		activity:      analytics.NewTracker(),
+		memberStreams: members.NewRegistry(),
+		cdn:           cdn.New(cfg.CDN),
+		startedAt:     time.Now(),
+	}
+	guildZones := make(map[string]string, len(cfg.Guilds))
+	for guildID, guild := range cfg.Guilds {
+		guildZones[guildID] = guild.Timezone
+	}
+	client.zones, err = timezone.NewZones(cfg.Server.Timezone, guildZones)
+	if err != nil {
+		return nil, err
+	}
+
+	client.voice = voice.NewManager(session, cfg.Voice, logger)
+	client.history = history.NewScanner(client.fetchHistoryPage, cfg.Discord.HistoryConcurrency)
+
+	if cfg.Archive.Enabled {
+		client.archive, err = archive.New(cfg.Archive, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Onboarding.Enabled {
+		client.onboarding = onboarding.NewRegistry()
+		for _, rule := range cfg.Onboarding.Rules {
+			_, _, err := client.onboarding.Set(onboarding.Rule{
+				Name:           rule.Name,
+				GuildID:        rule.GuildID,
+				RoleIDs:        rule.RoleIDs,
+				DirectMessage:  rule.DirectMessage,
+				ChannelID:      rule.ChannelID,
+				ChannelMessage: rule.ChannelMessage,
+				IncludeBots:    rule.IncludeBots,
+				Enabled:        true,
+				Source:         onboarding.SourceConfig,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("invalid onboarding rule %q: %w", rule.Name, err)
+			}
+		}
+	}
+
+	if cfg.Schedule.Enabled {
+		client.schedule, err = schedule.NewScheduler(cfg.Schedule.Path, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Giveaways.Enabled {
+		client.giveaways, err = giveaway.NewStore(cfg.Giveaways.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Starboard.Enabled {
+		client.starboard, err = starboard.NewStore(cfg.Starboard.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Pinning.Enabled {
+		client.pinning, err = pinning.NewStore(cfg.Pinning.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Notes.Enabled {
+		client.notes, err = notes.NewStore(cfg.Notes.Path, cfg.Notes.MaxPerUser)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Warnings.Enabled {
+		steps := make([]warnings.Step, 0, len(cfg.Warnings.Escalation))
+		for _, step := range cfg.Warnings.Escalation {
+			steps = append(steps, warnings.Step{
+				Warnings: step.Warnings,
+				Action:   step.Action,
+				Duration: time.Duration(step.DurationMinutes) * time.Minute,
+			})
+		}
+		expire := time.Duration(cfg.Warnings.ExpireDays) * 24 * time.Hour
+		client.warnings, err = warnings.NewStore(cfg.Warnings.Path, expire, steps)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Tickets.Enabled {
+		client.tickets, err = tickets.NewStore(cfg.Tickets.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.RoleMenus.Enabled {
+		client.roleMenus, err = rolemenu.NewStore(cfg.RoleMenus.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Verification.Enabled {
+		client.verification, err = verification.NewStore(cfg.Verification.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Bridges.Enabled {
+		client.bridges, err = bridge.NewStore(cfg.Bridges.Path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Relay.Enabled {
+		client.relays, err = relay.NewStore(cfg.Relay.Path)
+		if err != nil {
+			return nil, err
+		}
+		client.relayServer = relay.NewServer(cfg.Relay.Listen, cfg.Relay.MaxBodyBytes, client.relays, client.postRelay, logger)
+	}
+
+	if cfg.Feeds.Enabled {
+		client.feeds, err = feeds.NewWatcher(cfg.Feeds.Path, cfg.Feeds.MaxEntriesPerCheck, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client.summarizer, err = summarize.NewSummarizer(cfg.Summarizer)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Raid.Enabled {
+		client.raid = raid.NewDetector(raid.Settings{
+			JoinThreshold:        cfg.Raid.JoinThreshold,
+			JoinWindowSeconds:    cfg.Raid.JoinWindowSeconds,
+			MessageThreshold:     cfg.Raid.MessageThreshold,
+			MessageWindowSeconds: cfg.Raid.MessageWindowSeconds,
+			SlowmodeSeconds:      cfg.Raid.SlowmodeSeconds,
+			PauseInvites:         cfg.Raid.PauseInvites,
+			CooldownSeconds:      cfg.Raid.CooldownSeconds,
+		})
+		for _, guildID := range cfg.Raid.Guilds {
+			if _, err := client.raid.Arm(guildID, client.raid.Defaults()); err != nil {
+				return nil, fmt.Errorf("invalid raid settings: %w", err)
+			}
+		}
+	}
+
+	if cfg.Undo.Enabled {
+		client.journal = journal.NewJournal(time.Duration(cfg.Undo.TTLSeconds) * time.Second)
+	}
+
+	if cfg.Membership.Enabled {
+		retention := time.Duration(cfg.Membership.RetentionDays) * 24 * time.Hour
+		client.membership, err = membership.NewLog(cfg.Membership.Path, retention, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ThreadDigest.Enabled && len(cfg.ThreadDigest.Guilds) > 0 {
+		client.threadDigest = threads.NewDigester(cfg.ThreadDigest.HourUTC)
+	}
+
+	if cfg.Safety.Enabled {
+		client.safety, err = safety.NewPipeline(cfg.Safety)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Cooldowns.Enabled {
+		client.cooldowns = cooldown.NewLimiter(cooldown.Limits{
+			UserLimit:     cfg.Cooldowns.UserLimit,
+			UserWindow:    time.Duration(cfg.Cooldowns.UserWindowSeconds) * time.Second,
+			ChannelLimit:  cfg.Cooldowns.ChannelLimit,
+			ChannelWindow: time.Duration(cfg.Cooldowns.ChannelWindowSeconds) * time.Second,
+		}, cfg.Cooldowns.ExemptUsers)
+	}
+
+	if cfg.Outbound.Enabled {
+		client.outbound = outbound.NewQueue(outbound.Options{
+			Workers:            cfg.Outbound.Workers,
+			InteractiveWorkers: cfg.Outbound.InteractiveWorkers,
+			MaxPending:         cfg.Outbound.MaxPending,
+			BulkMaxPending:     cfg.Outbound.BulkMaxPending,
+		})
+	}
+
+	client.tracer = trace.NewRecorder(trace.Options{
+		Path:         cfg.Trace.Path,
+		MaxFileBytes: cfg.Trace.MaxFileBytes,
+		MaxFiles:     cfg.Trace.MaxFiles,
+		Recent:       cfg.Trace.Recent,
+		MaxBodyBytes: cfg.Trace.MaxBodyBytes,
+	})
+	client.tracer.SetEnabled(cfg.Trace.Enabled)
+	session.Client.Transport = client.tracer.Transport(session.Client.Transport, client.traceError)
+
+	if cfg.Telemetry.Enabled {
+		client.telemetry = telemetry.NewTracer(telemetry.Options{
+			Endpoint:       cfg.Telemetry.Endpoint,
+			Headers:        cfg.Telemetry.Headers,
+			ServiceName:    cfg.Telemetry.ServiceName,
+			ServiceVersion: cfg.MCP.Version,
+			SampleRatio:    cfg.Telemetry.SampleRatio,
+			FlushInterval:  time.Duration(cfg.Telemetry.FlushIntervalMs) * time.Millisecond,
+			MaxQueue:       cfg.Telemetry.MaxQueue,
+		}, logger)
+		session.Client.Transport = client.telemetry.Transport(session.Client.Transport)
+	}
+
+	if cfg.Events.Buffer.Enabled {
+		client.eventBuffer = notifications.NewBuffer(cfg.Events.Buffer.Size, cfg.Events.Buffer.PersistPath, logger)
+	}
+
+	refresh := time.Duration(cfg.Discord.TokenSecret.RefreshIntervalSeconds) * time.Second
+	if _, static := provider.(*secrets.StaticProvider); !static && refresh > 0 {
+		client.tokenWatcher = secrets.NewWatcher(provider, token, refresh, logger)
 	}
 
 	return client, nil
@@ -63,25 +507,146 @@ func NewClient(cfg *config.Config, logger *logrus.Logger) (*Client, error) {
 // SetupEventHandlers sets up the event handlers for the Discord client
 func (c *Client) SetupEventHandlers(notificationSvc *notifications.Service) {
 	c.dispatcher = NewEventDispatcher(c.logger, notificationSvc, &c.config.Events)
+	c.dispatcher.contentHidden = c.IsMessageContentHidden
+	c.dispatcher.buffer = c.eventBuffer
+	c.dispatcher.state = c.session.State
+	c.dispatcher.formatMessage = c.FormatMessage
+	c.dispatcher.watches = c.watches
+	c.dispatcher.channelAccess = c.channelAccess
+	c.dispatcher.autoResponses = c.autoResponses
+	c.dispatcher.cooldowns = c.cooldowns
+	c.dispatcher.outbound = c.outbound
+	c.dispatcher.onboarding = c.onboarding
+	c.dispatcher.starboard = c.starboard
+	c.dispatcher.pinning = c.pinning
+	c.dispatcher.roleMenus = c.roleMenus
+	c.dispatcher.verification = c.verification
+	if c.tickets != nil && c.config.Tickets.RelayDMs {
+		c.dispatcher.tickets = c.tickets
+	}
+	c.dispatcher.bridges = c.bridges
+	c.dispatcher.maxMessageLength = c.config.Discord.MaxMessageLength
+	c.dispatcher.raid = c.raid
+	c.dispatcher.retry = c.Retry
+	c.dispatcher.activity = c.activity
+	c.dispatcher.memberStreams = c.memberStreams
+	c.dispatcher.membership = c.membership
+	if c.safety != nil && c.config.Safety.ScanInbound {
+		c.dispatcher.safety = c.safety
+		c.dispatcher.safetyDelete = c.config.Safety.DeleteInbound
+	}
+	if c.membership != nil && c.config.Membership.TrackInvites {
+		c.dispatcher.invites = membership.NewInvites()
+	}
+	c.dispatcher.archive = c.archive
+	c.dispatcher.cdn = c.cdn
+	c.dispatcher.messageContext = c.getMessagesBefore
+	c.voice.OnFinished = c.dispatcher.NotifyPlaybackFinished
+	c.notificationSvc = notificationSvc
 
 	c.session.AddHandler(func(s *discordgo.Session, r *discordgo.Ready) {
 		c.logger.WithFields(logrus.Fields{
 			"username": r.User.Username,
 			"id":       r.User.ID,
 		}).Info("Discord bot is ready")
+		c.setGatewayState(StateConnected, false)
+		c.restorePresence()
+	})
+
+	c.session.AddHandler(func(s *discordgo.Session, r *discordgo.Resumed) {
+		c.logger.Info("Discord session resumed")
+		c.setGatewayState(StateConnected, true)
 	})
 
 	c.session.AddHandler(func(s *discordgo.Session, d *discordgo.Disconnect) {
 		c.logger.Warn("Disconnected from Discord")
+		c.setGatewayState(StateReconnecting, false)
 	})
 
+	c.session.AddHandler(c.traceEvent)
 	c.session.AddHandler(c.dispatcher.HandleMessageCreate)
 	c.session.AddHandler(c.dispatcher.HandleGuildMemberAdd)
 	c.session.AddHandler(c.dispatcher.HandleMessageReactionAdd)
+	c.session.AddHandler(c.dispatcher.HandleMessageUpdate)
+	c.session.AddHandler(c.dispatcher.HandleMessageDelete)
+	c.session.AddHandler(c.dispatcher.HandleMessageReactionRemove)
+	c.session.AddHandler(c.dispatcher.HandleGuildMemberRemove)
+	c.session.AddHandler(c.dispatcher.HandleGuildMemberUpdate)
+	c.session.AddHandler(c.dispatcher.HandleGuildBanAdd)
+	c.session.AddHandler(c.dispatcher.HandleGuildBanRemove)
+	c.session.AddHandler(c.dispatcher.HandleGuildRoleCreate)
+	c.session.AddHandler(c.dispatcher.HandleGuildRoleUpdate)
+	c.session.AddHandler(c.dispatcher.HandleGuildRoleDelete)
+	c.session.AddHandler(c.dispatcher.HandleChannelCreate)
+	c.session.AddHandler(c.dispatcher.HandleChannelUpdate)
+	c.session.AddHandler(c.dispatcher.HandleChannelDelete)
+	c.session.AddHandler(c.dispatcher.HandleThreadCreate)
+	c.session.AddHandler(c.dispatcher.HandleThreadUpdate)
+	c.session.AddHandler(c.dispatcher.HandleVoiceStateUpdate)
+	c.session.AddHandler(c.dispatcher.HandlePresenceUpdate)
+	c.session.AddHandler(c.dispatcher.HandleTypingStart)
+	c.session.AddHandler(c.dispatcher.HandleGuildMembersChunk)
+	c.session.AddHandler(c.dispatcher.HandleGuildCreate)
+	c.session.AddHandler(c.dispatcher.HandleInteractionCreate)
+
+	// Keep the entity cache consistent with gateway changes
+	c.session.AddHandler(c.cache.HandleChannelUpdate)
+	c.session.AddHandler(c.cache.HandleChannelDelete)
+	c.session.AddHandler(c.cache.HandleThreadUpdate)
+	c.session.AddHandler(c.cache.HandleThreadDelete)
+	c.session.AddHandler(c.cache.HandleGuildUpdate)
+	c.session.AddHandler(c.cache.HandleGuildDelete)
+	c.session.AddHandler(c.cache.HandleGuildRoleCreate)
+	c.session.AddHandler(c.cache.HandleGuildRoleUpdate)
+	c.session.AddHandler(c.cache.HandleGuildRoleDelete)
+	c.session.AddHandler(c.cache.HandleGuildMemberUpdate)
+	c.session.AddHandler(c.cache.HandleGuildMemberRemove)
 }
 
 // Connect connects to Discord
 func (c *Client) Connect() error {
+	if err := c.open(); err != nil {
+		return err
+	}
+
+	c.detectMessageContentIntent()
+
+	if c.tokenWatcher != nil {
+		c.tokenWatcher.Start(c.rotateToken)
+	}
+	if c.schedule != nil {
+		c.schedule.Start(c.sendRecurringPost)
+	}
+	if c.threadDigest != nil {
+		c.threadDigest.Start(c.runThreadDigests)
+	}
+	if c.verification != nil {
+		c.verification.Start(c.kickUnverified)
+	}
+	if c.feeds != nil {
+		c.feeds.Start(c.postFeedEntries)
+	}
+	if c.relayServer != nil {
+		if err := c.relayServer.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rotateToken swaps in a new bot token. REST calls use it immediately; the
+// gateway picks it up the next time it identifies.
+func (c *Client) rotateToken(token string) {
+	c.session.Lock()
+	c.session.Token = "Bot " + token
+	c.session.Identify.Token = "Bot " + token
+	c.session.Unlock()
+
+	c.logger.Info("Discord bot token rotated")
+}
+
+// open opens the gateway connection if it is not already open
+func (c *Client) open() error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -96,6 +661,7 @@ func (c *Client) Connect() error {
 	}
 
 	c.connected = true
+	c.gatewayState = StateConnected
 	c.logger.Info("Connected to Discord successfully")
 	return nil
 }
@@ -111,20 +677,100 @@ func (c *Client) Disconnect() error {
 
 	c.logger.Info("Disconnecting from Discord...")
 
+	if c.tokenWatcher != nil {
+		c.tokenWatcher.Stop()
+	}
+	if c.schedule != nil {
+		c.schedule.Stop()
+	}
+	if c.threadDigest != nil {
+		c.threadDigest.Stop()
+	}
+	if c.verification != nil {
+		c.verification.Stop()
+	}
+	if c.feeds != nil {
+		c.feeds.Stop()
+	}
+	if c.relayServer != nil {
+		c.relayServer.Close()
+	}
+	c.voice.Close()
+	if c.outbound != nil {
+		c.outbound.Close()
+	}
+	if err := c.tracer.Close(); err != nil {
+		c.logger.Warnf("Failed to close trace file: %v", err)
+	}
+	c.telemetry.Close()
+	if c.archive != nil {
+		c.archive.Close()
+	}
+
 	if err := c.session.Close(); err != nil {
 		return fmt.Errorf("failed to close Discord connection: %w", err)
 	}
 
 	c.connected = false
+	c.gatewayState = StateDisconnected
 	c.logger.Info("Disconnected from Discord")
 	return nil
 }
 
-// IsConnected returns whether the client is connected
+// IsConnected returns whether the client is connected and the gateway is up
 func (c *Client) IsConnected() bool {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
-	return c.connected
+	return c.connected && c.gatewayState == StateConnected
+}
+
+// ConnectionState returns the current gateway connection state
+func (c *Client) ConnectionState() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.gatewayState
+}
+
+// setGatewayState records a gateway state transition and notifies the client.
+// Notifications are held while reconnecting so that events replayed by a
+// session resume are delivered after the reconnect has been announced.
+func (c *Client) setGatewayState(state string, resumed bool) {
+	c.mutex.Lock()
+	if !c.connected || c.gatewayState == state {
+		// Intentional disconnects and duplicate transitions are not announced
+		c.mutex.Unlock()
+		return
+	}
+	previous := c.gatewayState
+	c.gatewayState = state
+	c.mutex.Unlock()
+
+	c.logger.WithFields(logrus.Fields{
+		"previous": previous,
+		"state":    state,
+		"resumed":  resumed,
+	}).Info("Discord gateway state changed")
+
+	if c.dispatcher == nil {
+		return
+	}
+
+	c.dispatcher.NotifyConnectionState(state, previous, resumed)
+
+	switch state {
+	case StateReconnecting:
+		c.notificationSvc.Pause()
+	case StateConnected:
+		c.notificationSvc.Resume()
+		if c.onConnected != nil {
+			go c.onConnected()
+		}
+	}
+}
+
+// OnConnected sets a function to run each time the gateway reconnects
+func (c *Client) OnConnected(fn func()) {
+	c.onConnected = fn
 }
 
 // GetBotUser returns information about the bot user
@@ -133,8 +779,9 @@ func (c *Client) GetBotUser() (*discordgo.User, error) {
 		return nil, fmt.Errorf("not connected to Discord")
 	}
 
-	if !c.rateLimiter.Allow() {
-		return nil, fmt.Errorf("rate limit exceeded")
+	// The bot user is populated from the Ready event, no API call is needed
+	if c.session.State.User == nil {
+		return nil, fmt.Errorf("bot user not available yet")
 	}
 
 	return c.session.State.User, nil
@@ -146,16 +793,24 @@ func (c *Client) GetGuild(guildID string) (*discordgo.Guild, error) {
 		return nil, fmt.Errorf("not connected to Discord")
 	}
 
-	if !c.rateLimiter.Allow() {
-		return nil, fmt.Errorf("rate limit exceeded")
-	}
-
 	// Check if guild is allowed
-	if !c.isGuildAllowed(guildID) {
+	if !c.IsGuildAllowed(guildID) {
 		return nil, fmt.Errorf("access to guild %s is not allowed", guildID)
 	}
 
-	guild, err := c.session.Guild(guildID)
+	if guild, err := c.session.State.Guild(guildID); err == nil {
+		return guild, nil
+	}
+
+	if !c.rateLimiter.Allow() {
+		return nil, fmt.Errorf("rate limit exceeded")
+	}
+
+	var guild *discordgo.Guild
+	_, err := c.Retry(func() (err error) {
+		guild, err = c.session.Guild(guildID)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get guild: %w", err)
 	}
@@ -169,16 +824,24 @@ func (c *Client) GetChannels(guildID string) ([]*discordgo.Channel, error) {
 		return nil, fmt.Errorf("not connected to Discord")
 	}
 
-	if !c.rateLimiter.Allow() {
-		return nil, fmt.Errorf("rate limit exceeded")
-	}
-
 	// Check if guild is allowed
-	if !c.isGuildAllowed(guildID) {
+	if !c.IsGuildAllowed(guildID) {
 		return nil, fmt.Errorf("access to guild %s is not allowed", guildID)
 	}
 
-	channels, err := c.session.GuildChannels(guildID)
+	if guild, err := c.session.State.Guild(guildID); err == nil && len(guild.Channels) > 0 {
+		return guild.Channels, nil
+	}
+
+	if !c.rateLimiter.Allow() {
+		return nil, fmt.Errorf("rate limit exceeded")
+	}
+
+	var channels []*discordgo.Channel
+	_, err := c.Retry(func() (err error) {
+		channels, err = c.session.GuildChannels(guildID)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get channels: %w", err)
 	}
@@ -186,6 +849,99 @@ func (c *Client) GetChannels(guildID string) ([]*discordgo.Channel, error) {
 	return channels, nil
 }
 
+// GetChannel returns a single channel, served from state or the cache when possible
+func (c *Client) GetChannel(channelID string) (*discordgo.Channel, error) {
+	if !c.IsConnected() {
+		return nil, fmt.Errorf("not connected to Discord")
+	}
+
+	if channel, err := c.session.State.Channel(channelID); err == nil {
+		return channel, nil
+	}
+
+	if channel, ok := c.cache.Channel(channelID); ok {
+		return channel, nil
+	}
+
+	if !c.rateLimiter.Allow() {
+		return nil, fmt.Errorf("rate limit exceeded")
+	}
+
+	var channel *discordgo.Channel
+	_, err := c.Retry(func() (err error) {
+		channel, err = c.session.Channel(channelID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	c.cache.SetChannel(channel)
+	return channel, nil
+}
+
+// GetRoles returns all roles in a guild, served from state or the cache when possible
+func (c *Client) GetRoles(guildID string) ([]*discordgo.Role, error) {
+	if !c.IsConnected() {
+		return nil, fmt.Errorf("not connected to Discord")
+	}
+
+	if guild, err := c.session.State.Guild(guildID); err == nil && len(guild.Roles) > 0 {
+		return guild.Roles, nil
+	}
+
+	if roles, ok := c.cache.Roles(guildID); ok {
+		return roles, nil
+	}
+
+	if !c.rateLimiter.Allow() {
+		return nil, fmt.Errorf("rate limit exceeded")
+	}
+
+	var roles []*discordgo.Role
+	_, err := c.Retry(func() (err error) {
+		roles, err = c.session.GuildRoles(guildID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles: %w", err)
+	}
+
+	c.cache.SetRoles(guildID, roles)
+	return roles, nil
+}
+
+// GetMember returns a guild member, served from state or the cache when possible
+func (c *Client) GetMember(guildID, userID string) (*discordgo.Member, error) {
+	if !c.IsConnected() {
+		return nil, fmt.Errorf("not connected to Discord")
+	}
+
+	if member, err := c.session.State.Member(guildID, userID); err == nil {
+		return member, nil
+	}
+
+	if member, ok := c.cache.Member(guildID, userID); ok {
+		return member, nil
+	}
+
+	if !c.rateLimiter.Allow() {
+		return nil, fmt.Errorf("rate limit exceeded")
+	}
+
+	var member *discordgo.Member
+	_, err := c.Retry(func() (err error) {
+		member, err = c.session.GuildMember(guildID, userID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	c.cache.SetMember(guildID, member)
+	return member, nil
+}
+
 // SendMessage sends a message to a channel
 func (c *Client) SendMessage(channelID, content string) (*discordgo.Message, error) {
 	if !c.IsConnected() {
@@ -202,7 +958,11 @@ func (c *Client) SendMessage(channelID, content string) (*discordgo.Message, err
 			c.config.Discord.MaxMessageLength)
 	}
 
-	message, err := c.session.ChannelMessageSend(channelID, content)
+	var message *discordgo.Message
+	_, _, err := c.SendQueued(channelID, outbound.PriorityInteractive, func() (err error) {
+		message, err = c.session.ChannelMessageSend(channelID, content)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
@@ -226,7 +986,11 @@ func (c *Client) GetChannelMessages(channelID string, limit int) ([]*discordgo.M
 		limit = 100
 	}
 
-	messages, err := c.session.ChannelMessages(channelID, limit, "", "", "")
+	var messages []*discordgo.Message
+	_, err := c.Retry(func() (err error) {
+		messages, err = c.session.ChannelMessages(channelID, limit, "", "", "")
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get channel messages: %w", err)
 	}
@@ -234,6 +998,24 @@ func (c *Client) GetChannelMessages(channelID string, limit int) ([]*discordgo.M
 	return messages, nil
 }
 
+// ScanHistory pages backwards through the history of one or more channels
+// with bounded concurrency. Each page is fetched under the retry policy, so
+// rate limits are waited out until the retry budget is spent; a channel
+// that still fails stops, and the result's cursor resumes it.
+func (c *Client) ScanHistory(req history.Request) (history.Result, error) {
+	return c.history.Scan(req)
+}
+
+// fetchHistoryPage reads one page of a channel's history for the scanner
+func (c *Client) fetchHistoryPage(channelID string, limit int, beforeID string) ([]*discordgo.Message, error) {
+	var page []*discordgo.Message
+	_, err := c.Retry(func() (err error) {
+		page, err = c.session.ChannelMessages(channelID, limit, beforeID, "", "")
+		return err
+	})
+	return page, err
+}
+
 // Ping tests the connection to Discord
 func (c *Client) Ping() error {
 	if !c.IsConnected() {
@@ -264,13 +1046,202 @@ func (c *Client) setupEventHandlers() {
 	c.session.AddHandler(c.dispatcher.HandleMessageReactionAdd)
 }
 
+// Cache returns the entity cache
+func (c *Client) Cache() *cache.Cache {
+	return c.cache
+}
+
+// Voice returns the voice connection manager
+func (c *Client) Voice() *voice.Manager {
+	return c.voice
+}
+
+// Archive returns the local message archive, or nil if it is disabled
+func (c *Client) Archive() *archive.Archive {
+	return c.archive
+}
+
+// Activity returns the per-guild activity tracker
+func (c *Client) Activity() *analytics.Tracker {
+	return c.activity
+}
+
+// Watches returns the watch registry
+func (c *Client) Watches() *watch.Registry {
+	return c.watches
+}
+
+// SetChannelAccess sets the check that keeps watch notifications from
+// guilds and channels the configured access rules exclude. It must be set
+// before SetupEventHandlers.
+func (c *Client) SetChannelAccess(accessible func(guildID, channelID string) bool) {
+	c.channelAccess = accessible
+}
+
+// AutoResponses returns the auto-response registry
+func (c *Client) AutoResponses() *autoresponse.Registry {
+	return c.autoResponses
+}
+
+// Onboarding returns the member join rules, or nil if onboarding is disabled
+func (c *Client) Onboarding() *onboarding.Registry {
+	return c.onboarding
+}
+
+// Schedule returns the recurring post scheduler, or nil if it is disabled
+func (c *Client) Schedule() *schedule.Scheduler {
+	return c.schedule
+}
+
+// Giveaways returns the giveaway store, or nil if giveaways are disabled
+func (c *Client) Giveaways() *giveaway.Store {
+	return c.giveaways
+}
+
+// Starboard returns the starboard store, or nil if the starboard is disabled
+func (c *Client) Starboard() *starboard.Store {
+	return c.starboard
+}
+
+// Pinning returns the pinning policy store, or nil if pinning policies are
+// disabled
+func (c *Client) Pinning() *pinning.Store {
+	return c.pinning
+}
+
+// Notes returns the user note store, or nil if user notes are disabled
+func (c *Client) Notes() *notes.Store {
+	return c.notes
+}
+
+// Warnings returns the member warning store, or nil if warnings are disabled
+func (c *Client) Warnings() *warnings.Store {
+	return c.warnings
+}
+
+// Tickets returns the support ticket store, or nil if tickets are disabled
+func (c *Client) Tickets() *tickets.Store {
+	return c.tickets
+}
+
+// RoleMenus returns the role menu store, or nil if role menus are disabled
+func (c *Client) RoleMenus() *rolemenu.Store {
+	return c.roleMenus
+}
+
+// Verification returns the verification store, or nil if verification is
+// disabled
+func (c *Client) Verification() *verification.Store {
+	return c.verification
+}
+
+// Bridges returns the channel bridge store, or nil if bridges are disabled
+func (c *Client) Bridges() *bridge.Store {
+	return c.bridges
+}
+
+// Relays returns the incoming payload relay store, or nil if the relay is
+// disabled
+func (c *Client) Relays() *relay.Store {
+	return c.relays
+}
+
+// Feeds returns the feed watcher, or nil if feeds are disabled
+func (c *Client) Feeds() *feeds.Watcher {
+	return c.feeds
+}
+
+// Summarizer returns the external summarizer, or nil if none is configured
+func (c *Client) Summarizer() summarize.Summarizer {
+	return c.summarizer
+}
+
+// Raid returns the raid detector, or nil if raid protection is disabled
+func (c *Client) Raid() *raid.Detector {
+	return c.raid
+}
+
+// Journal returns the journal of reversible actions, or nil if undo is
+// disabled
+func (c *Client) Journal() *journal.Journal {
+	return c.journal
+}
+
+// MemberStreams returns the registry of gateway member requests
+func (c *Client) MemberStreams() *members.Registry {
+	return c.memberStreams
+}
+
+// Cooldowns returns the response cooldowns, or nil if they are disabled
+func (c *Client) Cooldowns() *cooldown.Limiter {
+	return c.cooldowns
+}
+
+// Safety returns the link and attachment scanners, or nil if safety
+// scanning is disabled
+func (c *Client) Safety() *safety.Pipeline {
+	return c.safety
+}
+
+// MembershipLog returns the log of member joins and leaves, or nil if it is
+// disabled
+func (c *Client) MembershipLog() *membership.Log {
+	return c.membership
+}
+
+// StreamGuildMembers asks the gateway for a guild's members, optionally only
+// those whose username starts with query, and returns the stream the chunks
+// are collected into. A running stream for the same request is returned
+// instead of asking again.
+func (c *Client) StreamGuildMembers(guildID, query string, limit int) (members.Stream, error) {
+	stream, started, err := c.memberStreams.Start(guildID, query, limit)
+	if err != nil || !started {
+		return stream, err
+	}
+
+	// The stream ID is sent as the nonce so chunks can be matched to it
+	if err := c.session.RequestGuildMembers(guildID, query, limit, stream.ID, false); err != nil {
+		c.memberStreams.Remove(stream.ID)
+		return members.Stream{}, err
+	}
+	c.logger.Infof("Requested members of guild %s over the gateway as stream %s", guildID, stream.ID)
+	return stream, nil
+}
+
+// Notifications returns the notification service, or nil before
+// SetupEventHandlers
+func (c *Client) Notifications() *notifications.Service {
+	return c.notificationSvc
+}
+
+// EventBuffer returns the recent-event buffer, or nil if it is disabled
+func (c *Client) EventBuffer() *notifications.Buffer {
+	return c.eventBuffer
+}
+
+// Config returns the application configuration
+func (c *Client) Config() *config.Config {
+	return c.config
+}
+
+// Location returns the time zone of a guild: its guilds entry, or
+// server.timezone
+func (c *Client) Location(guildID string) *time.Location {
+	return c.zones.For(guildID)
+}
+
+// CDN returns the image URL builder
+func (c *Client) CDN() *cdn.Builder {
+	return c.cdn
+}
+
 // Session returns the underlying DiscordGo session for advanced operations
 func (c *Client) Session() *discordgo.Session {
 	return c.session
 }
 
-// isGuildAllowed checks if the guild is in the allowed list (if configured)
-func (c *Client) isGuildAllowed(guildID string) bool {
+// IsGuildAllowed checks if the guild is in the allowed list (if configured)
+func (c *Client) IsGuildAllowed(guildID string) bool {
 	// If no restrictions are configured, allow all guilds
 	if len(c.config.Discord.AllowedGuilds) == 0 {
 		return true
@@ -314,6 +1285,7 @@ func (rl *rateLimiter) Allow() bool {
 
 	// Check if we can make a new request
 	if len(rl.requests) >= rl.maxReqs {
+		rl.rejected++
 		return false
 	}
 
@@ -0,0 +1,21 @@
+package discord
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// allowResponse reports whether the response cooldowns let the bot respond
+// to a message, counting the response against its author and channel
+func (d *EventDispatcher) allowResponse(msg *discordgo.Message, kind string) bool {
+	if d.cooldowns == nil {
+		return true
+	}
+	allowed, scope := d.cooldowns.Allow(msg.Author.ID, msg.ChannelID, time.Now())
+	if !allowed {
+		d.logger.Debugf("Skipping %s for message %s from %s in %s: %s cooldown",
+			kind, msg.ID, msg.Author.ID, msg.ChannelID, scope)
+	}
+	return allowed
+}
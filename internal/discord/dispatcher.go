@@ -3,12 +3,34 @@ package discord
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
 
+	"discord-mcp/internal/analytics"
+	"discord-mcp/internal/archive"
+	"discord-mcp/internal/autoresponse"
+	"discord-mcp/internal/bridge"
+	"discord-mcp/internal/cdn"
 	"discord-mcp/internal/config"
+	"discord-mcp/internal/cooldown"
+	"discord-mcp/internal/members"
+	"discord-mcp/internal/membership"
 	"discord-mcp/internal/notifications"
+	"discord-mcp/internal/onboarding"
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/pinning"
+	"discord-mcp/internal/raid"
+	"discord-mcp/internal/rolemenu"
+	"discord-mcp/internal/safety"
+	"discord-mcp/internal/starboard"
+	"discord-mcp/internal/threads"
+	"discord-mcp/internal/tickets"
+	"discord-mcp/internal/verification"
+	"discord-mcp/internal/voice"
+	"discord-mcp/internal/watch"
 	"discord-mcp/pkg/types"
 )
 
@@ -17,6 +39,103 @@ type EventDispatcher struct {
 	logger          *logrus.Logger
 	notificationSvc *notifications.Service
 	config          *config.EventsConfig
+
+	// contentHidden reports whether a message's content was withheld by Discord
+	contentHidden func(*discordgo.Message) bool
+
+	// buffer records every dispatched event for poll_events; nil when disabled
+	buffer *notifications.Buffer
+
+	// state resolves guild, channel and user names for event payloads
+	state *discordgo.State
+
+	// formatMessage builds the full message object for message events
+	formatMessage func(*discordgo.Message) types.MessageSummary
+
+	// watches holds the triggers registered with create_watch
+	watches *watch.Registry
+
+	// channelAccess reports whether events from a channel may reach the
+	// client; nil allows every channel
+	channelAccess func(guildID, channelID string) bool
+
+	// autoResponses holds the replies registered with create_auto_response
+	autoResponses *autoresponse.Registry
+
+	// cooldowns limits auto-response replies and addressed messages per user
+	// and channel; nil when disabled
+	cooldowns *cooldown.Limiter
+
+	// onboarding holds the rules run for new members; nil when disabled
+	onboarding *onboarding.Registry
+
+	// starboard holds the boards set with configure_starboard; nil when
+	// disabled
+	starboard      *starboard.Store
+	starboardMutex sync.Mutex
+
+	// pinning holds the policies set with set_pin_policy; nil when disabled
+	pinning      *pinning.Store
+	pinningMutex sync.Mutex
+
+	// roleMenus holds the menus posted with create_role_menu; nil when
+	// disabled
+	roleMenus *rolemenu.Store
+
+	// verification holds the gates set with configure_verification; nil
+	// when disabled
+	verification *verification.Store
+
+	// tickets receives members' direct messages relayed into their open
+	// ticket; nil when disabled
+	tickets *tickets.Store
+
+	// bridges mirrors messages between the channels linked with
+	// create_bridge; nil when disabled
+	bridges *bridge.Store
+
+	// maxMessageLength splits relayed and mirrored messages
+	maxMessageLength int
+
+	// raid watches join and message rates of armed guilds; nil when disabled
+	raid *raid.Detector
+
+	// outbound queues the messages event handlers send; nil when disabled
+	outbound *outbound.Queue
+
+	// retry runs REST calls made by onboarding rules, auto-responses, the
+	// starboard, pinning policies and raid protection under the retry policy
+	retry func(func() error) (int, error)
+
+	// messageContext fetches the messages preceding a message
+	messageContext func(channelID, beforeID string, limit int) ([]*discordgo.Message, error)
+
+	// activity counts joins, leaves and messages for get_guild_analytics
+	activity *analytics.Tracker
+
+	// memberStreams collects the member chunks requested with
+	// stream_guild_members
+	memberStreams *members.Registry
+
+	// membership logs joins and leaves; nil when disabled
+	membership *membership.Log
+
+	// invites tracks invite use counts to tell which invite a member joined
+	// with; nil unless membership_log.track_invites is set
+	invites      *membership.Invites
+	invitesMutex sync.Mutex
+
+	// safety scans the links and attachments of other users' messages; nil
+	// unless safety scanning of inbound messages is enabled
+	safety *safety.Pipeline
+	// safetyDelete deletes the messages safety flags
+	safetyDelete bool
+
+	// archive stores messages from subscribed channels; nil when disabled
+	archive *archive.Archive
+
+	// cdn builds avatar and emoji URLs for event payloads
+	cdn *cdn.Builder
 }
 
 // NewEventDispatcher creates a new EventDispatcher
@@ -30,26 +149,50 @@ func NewEventDispatcher(logger *logrus.Logger, notificationSvc *notifications.Se
 
 // HandleMessageCreate handles the MessageCreate event from Discord
 func (d *EventDispatcher) HandleMessageCreate(s *discordgo.Session, m *discordgo.MessageCreate) {
+	if d.activity != nil {
+		d.activity.RecordMessage(m.GuildID, m.ChannelID, m.Timestamp)
+	}
+	d.archiveMessage(m.Message)
+	d.checkSafety(s, m.Message)
+	d.checkMessageRaid(s, m.Message)
+	d.checkMessageWatches(s, m.Message)
+	d.runAutoResponses(s, m.Message)
+	d.applyPinPolicy(s, m.Message)
+	d.relayTicketMessage(s, m.Message)
+	d.mirrorMessage(s, m.Message)
+	d.forwardAddressedMessage(s, m.Message)
+
 	if !d.config.Enabled || !d.isEventAllowed("discord/messageCreated") {
 		return
 	}
 	d.logger.Debugf("Handling MessageCreate event for message ID: %s", m.ID)
 
 	params := map[string]interface{}{
-		"guild_id":   m.GuildID,
-		"channel_id": m.ChannelID,
-		"message_id": m.ID,
-		"author_id":  m.Author.ID,
-		"content":    m.Content,
+		"guild_id":        m.GuildID,
+		"channel_id":      m.ChannelID,
+		"message_id":      m.ID,
+		"author_id":       m.Author.ID,
+		"author_username": m.Author.Username,
+		"content":         m.Content,
+		"attachments":     attachmentSummaries(m.Attachments),
 	}
-
-	if err := d.notificationSvc.Send(d.createNotification("discord/messageCreated", params)); err != nil {
-		d.logger.Errorf("Failed to send messageCreated notification: %v", err)
+	if d.contentHidden != nil && d.contentHidden(m.Message) {
+		params["content_unavailable"] = true
 	}
+	d.addMessageObject(params, m.Message)
+
+	d.send("discord/messageCreated", params)
 }
 
 // HandleGuildMemberAdd handles the GuildMemberAdd event from Discord
 func (d *EventDispatcher) HandleGuildMemberAdd(s *discordgo.Session, m *discordgo.GuildMemberAdd) {
+	if d.activity != nil {
+		d.activity.RecordJoin(m.GuildID, time.Now())
+	}
+	d.recordJoin(s, m.Member)
+	d.checkJoinRaid(s, m.GuildID)
+	d.gateMember(s, m.Member)
+	d.runOnboarding(s, m.Member)
 	if !d.config.Enabled || !d.isEventAllowed("discord/guildMemberAdded") {
 		return
 	}
@@ -58,18 +201,20 @@ func (d *EventDispatcher) HandleGuildMemberAdd(s *discordgo.Session, m *discordg
 	params := map[string]interface{}{
 		"guild_id": m.GuildID,
 		"user": map[string]interface{}{
-			"id":       m.User.ID,
-			"username": m.User.Username,
+			"id":         m.User.ID,
+			"username":   m.User.Username,
+			"avatar_url": d.cdn.MemberAvatar(m.GuildID, m.Member),
 		},
 	}
 
-	if err := d.notificationSvc.Send(d.createNotification("discord/guildMemberAdded", params)); err != nil {
-		d.logger.Errorf("Failed to send guildMemberAdded notification: %v", err)
-	}
+	d.send("discord/guildMemberAdded", params)
 }
 
 // HandleMessageReactionAdd handles the MessageReactionAdd event from Discord
 func (d *EventDispatcher) HandleMessageReactionAdd(s *discordgo.Session, r *discordgo.MessageReactionAdd) {
+	d.checkReactionWatches(r.MessageReaction)
+	d.updateStarboard(s, r.MessageReaction, true)
+
 	if !d.config.Enabled || !d.isEventAllowed("discord/messageReactionAdded") {
 		return
 	}
@@ -83,11 +228,525 @@ func (d *EventDispatcher) HandleMessageReactionAdd(s *discordgo.Session, r *disc
 		"emoji": map[string]interface{}{
 			"id":   r.Emoji.ID,
 			"name": r.Emoji.Name,
+			"url":  d.cdn.Emoji(r.Emoji.ID, r.Emoji.Animated),
 		},
 	}
 
-	if err := d.notificationSvc.Send(d.createNotification("discord/messageReactionAdded", params)); err != nil {
-		d.logger.Errorf("Failed to send messageReactionAdded notification: %v", err)
+	d.addUsername(params, r.GuildID, r.UserID)
+
+	d.send("discord/messageReactionAdded", params)
+}
+
+// HandleMessageUpdate handles the MessageUpdate event from Discord
+func (d *EventDispatcher) HandleMessageUpdate(s *discordgo.Session, m *discordgo.MessageUpdate) {
+	d.archiveMessage(m.Message)
+	// Link previews also update messages; only rescan real edits
+	if m.EditedTimestamp != nil {
+		d.checkSafety(s, m.Message)
+	}
+
+	if !d.config.Enabled || !d.isEventAllowed("discord/messageUpdated") {
+		return
+	}
+	d.logger.Debugf("Handling MessageUpdate event for message ID: %s", m.ID)
+
+	params := map[string]interface{}{
+		"guild_id":   m.GuildID,
+		"channel_id": m.ChannelID,
+		"message_id": m.ID,
+		"content":    m.Content,
+	}
+	if m.Author != nil {
+		params["author_id"] = m.Author.ID
+		params["author_username"] = m.Author.Username
+	}
+	if m.EditedTimestamp != nil {
+		params["edited_at"] = m.EditedTimestamp.Format(time.RFC3339)
+	}
+	if m.BeforeUpdate != nil {
+		params["previous_content"] = m.BeforeUpdate.Content
+	}
+	if m.Author != nil {
+		d.addMessageObject(params, m.Message)
+	}
+
+	d.send("discord/messageUpdated", params)
+}
+
+// HandleMessageDelete handles the MessageDelete event from Discord
+func (d *EventDispatcher) HandleMessageDelete(s *discordgo.Session, m *discordgo.MessageDelete) {
+	if d.archive != nil {
+		d.archive.MarkDeleted(m.ID)
+	}
+
+	if !d.config.Enabled || !d.isEventAllowed("discord/messageDeleted") {
+		return
+	}
+	d.logger.Debugf("Handling MessageDelete event for message ID: %s", m.ID)
+
+	params := map[string]interface{}{
+		"guild_id":   m.GuildID,
+		"channel_id": m.ChannelID,
+		"message_id": m.ID,
+	}
+	if m.BeforeDelete != nil {
+		params["content"] = m.BeforeDelete.Content
+		if m.BeforeDelete.Author != nil {
+			params["author_id"] = m.BeforeDelete.Author.ID
+			params["author_username"] = m.BeforeDelete.Author.Username
+		}
+	}
+
+	d.send("discord/messageDeleted", params)
+}
+
+// HandleMessageReactionRemove handles the MessageReactionRemove event from Discord
+func (d *EventDispatcher) HandleMessageReactionRemove(s *discordgo.Session, r *discordgo.MessageReactionRemove) {
+	d.updateStarboard(s, r.MessageReaction, false)
+
+	if !d.config.Enabled || !d.isEventAllowed("discord/messageReactionRemoved") {
+		return
+	}
+	d.logger.Debugf("Handling MessageReactionRemove event for message ID: %s", r.MessageID)
+
+	params := map[string]interface{}{
+		"guild_id":   r.GuildID,
+		"channel_id": r.ChannelID,
+		"message_id": r.MessageID,
+		"user_id":    r.UserID,
+		"emoji": map[string]interface{}{
+			"id":   r.Emoji.ID,
+			"name": r.Emoji.Name,
+			"url":  d.cdn.Emoji(r.Emoji.ID, r.Emoji.Animated),
+		},
+	}
+
+	d.addUsername(params, r.GuildID, r.UserID)
+
+	d.send("discord/messageReactionRemoved", params)
+}
+
+// HandleGuildMemberRemove handles the GuildMemberRemove event from Discord
+func (d *EventDispatcher) HandleGuildMemberRemove(s *discordgo.Session, m *discordgo.GuildMemberRemove) {
+	if d.activity != nil {
+		d.activity.RecordLeave(m.GuildID, time.Now())
+	}
+	d.recordLeave(m.GuildID, m.User)
+	d.forgetUnverified(m.GuildID, m.User)
+	if !d.config.Enabled || !d.isEventAllowed("discord/guildMemberRemoved") || m.User == nil {
+		return
+	}
+	d.logger.Debugf("Handling GuildMemberRemove event for user ID: %s", m.User.ID)
+
+	params := map[string]interface{}{
+		"guild_id": m.GuildID,
+		"user": map[string]interface{}{
+			"id":         m.User.ID,
+			"username":   m.User.Username,
+			"avatar_url": d.cdn.UserAvatar(m.User),
+		},
+	}
+
+	d.send("discord/guildMemberRemoved", params)
+}
+
+// HandleGuildMemberUpdate handles the GuildMemberUpdate event from Discord
+func (d *EventDispatcher) HandleGuildMemberUpdate(s *discordgo.Session, m *discordgo.GuildMemberUpdate) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/guildMemberUpdated") || m.Member == nil || m.User == nil {
+		return
+	}
+	d.logger.Debugf("Handling GuildMemberUpdate event for user ID: %s", m.User.ID)
+
+	params := map[string]interface{}{
+		"guild_id": m.GuildID,
+		"user": map[string]interface{}{
+			"id":         m.User.ID,
+			"username":   m.User.Username,
+			"avatar_url": d.cdn.MemberAvatar(m.GuildID, m.Member),
+		},
+		"nick":  m.Nick,
+		"roles": m.Roles,
+	}
+	if m.BeforeUpdate != nil {
+		params["previous_nick"] = m.BeforeUpdate.Nick
+		params["previous_roles"] = m.BeforeUpdate.Roles
+	}
+
+	d.send("discord/guildMemberUpdated", params)
+}
+
+// HandleGuildBanAdd handles the GuildBanAdd event from Discord
+func (d *EventDispatcher) HandleGuildBanAdd(s *discordgo.Session, b *discordgo.GuildBanAdd) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/guildBanAdded") || b.User == nil {
+		return
+	}
+	d.logger.Debugf("Handling GuildBanAdd event for user ID: %s", b.User.ID)
+
+	params := map[string]interface{}{
+		"guild_id": b.GuildID,
+		"user": map[string]interface{}{
+			"id":         b.User.ID,
+			"username":   b.User.Username,
+			"avatar_url": d.cdn.UserAvatar(b.User),
+		},
+	}
+
+	d.send("discord/guildBanAdded", params)
+}
+
+// HandleGuildBanRemove handles the GuildBanRemove event from Discord
+func (d *EventDispatcher) HandleGuildBanRemove(s *discordgo.Session, b *discordgo.GuildBanRemove) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/guildBanRemoved") || b.User == nil {
+		return
+	}
+	d.logger.Debugf("Handling GuildBanRemove event for user ID: %s", b.User.ID)
+
+	params := map[string]interface{}{
+		"guild_id": b.GuildID,
+		"user": map[string]interface{}{
+			"id":         b.User.ID,
+			"username":   b.User.Username,
+			"avatar_url": d.cdn.UserAvatar(b.User),
+		},
+	}
+
+	d.send("discord/guildBanRemoved", params)
+}
+
+// HandleGuildRoleCreate handles the GuildRoleCreate event from Discord
+func (d *EventDispatcher) HandleGuildRoleCreate(s *discordgo.Session, r *discordgo.GuildRoleCreate) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/roleCreated") || r.GuildRole == nil || r.Role == nil {
+		return
+	}
+	d.logger.Debugf("Handling GuildRoleCreate event for role ID: %s", r.Role.ID)
+
+	d.send("discord/roleCreated", roleParams(r.GuildID, r.Role))
+}
+
+// HandleGuildRoleUpdate handles the GuildRoleUpdate event from Discord
+func (d *EventDispatcher) HandleGuildRoleUpdate(s *discordgo.Session, r *discordgo.GuildRoleUpdate) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/roleUpdated") || r.GuildRole == nil || r.Role == nil {
+		return
+	}
+	d.logger.Debugf("Handling GuildRoleUpdate event for role ID: %s", r.Role.ID)
+
+	d.send("discord/roleUpdated", roleParams(r.GuildID, r.Role))
+}
+
+// HandleGuildRoleDelete handles the GuildRoleDelete event from Discord
+func (d *EventDispatcher) HandleGuildRoleDelete(s *discordgo.Session, r *discordgo.GuildRoleDelete) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/roleDeleted") {
+		return
+	}
+	d.logger.Debugf("Handling GuildRoleDelete event for role ID: %s", r.RoleID)
+
+	params := map[string]interface{}{
+		"guild_id": r.GuildID,
+		"role_id":  r.RoleID,
+	}
+
+	d.send("discord/roleDeleted", params)
+}
+
+// HandleChannelCreate handles the ChannelCreate event from Discord
+func (d *EventDispatcher) HandleChannelCreate(s *discordgo.Session, c *discordgo.ChannelCreate) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/channelCreated") || c.Channel == nil {
+		return
+	}
+	d.logger.Debugf("Handling ChannelCreate event for channel ID: %s", c.ID)
+
+	d.send("discord/channelCreated", channelParams(c.Channel))
+}
+
+// HandleChannelUpdate handles the ChannelUpdate event from Discord
+func (d *EventDispatcher) HandleChannelUpdate(s *discordgo.Session, c *discordgo.ChannelUpdate) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/channelUpdated") || c.Channel == nil {
+		return
+	}
+	d.logger.Debugf("Handling ChannelUpdate event for channel ID: %s", c.ID)
+
+	d.send("discord/channelUpdated", channelParams(c.Channel))
+}
+
+// HandleChannelDelete handles the ChannelDelete event from Discord
+func (d *EventDispatcher) HandleChannelDelete(s *discordgo.Session, c *discordgo.ChannelDelete) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/channelDeleted") || c.Channel == nil {
+		return
+	}
+	d.logger.Debugf("Handling ChannelDelete event for channel ID: %s", c.ID)
+
+	d.send("discord/channelDeleted", channelParams(c.Channel))
+}
+
+// HandleThreadCreate handles the ThreadCreate event from Discord
+func (d *EventDispatcher) HandleThreadCreate(s *discordgo.Session, t *discordgo.ThreadCreate) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/threadCreated") || t.Channel == nil {
+		return
+	}
+	d.logger.Debugf("Handling ThreadCreate event for thread ID: %s", t.ID)
+
+	params := channelParams(t.Channel)
+	params["owner_id"] = t.OwnerID
+	params["newly_created"] = t.NewlyCreated
+
+	d.send("discord/threadCreated", params)
+}
+
+// HandleThreadUpdate handles the ThreadUpdate event from Discord
+func (d *EventDispatcher) HandleThreadUpdate(s *discordgo.Session, t *discordgo.ThreadUpdate) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/threadUpdated") || t.Channel == nil {
+		return
+	}
+	d.logger.Debugf("Handling ThreadUpdate event for thread ID: %s", t.ID)
+
+	params := channelParams(t.Channel)
+	params["owner_id"] = t.OwnerID
+	if t.ThreadMetadata != nil {
+		params["archived"] = t.ThreadMetadata.Archived
+		params["locked"] = t.ThreadMetadata.Locked
+	}
+
+	d.send("discord/threadUpdated", params)
+}
+
+// HandleVoiceStateUpdate handles the VoiceStateUpdate event from Discord
+func (d *EventDispatcher) HandleVoiceStateUpdate(s *discordgo.Session, v *discordgo.VoiceStateUpdate) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/voiceStateUpdated") || v.VoiceState == nil {
+		return
+	}
+	d.logger.Debugf("Handling VoiceStateUpdate event for user ID: %s", v.UserID)
+
+	params := map[string]interface{}{
+		"guild_id":   v.GuildID,
+		"channel_id": v.ChannelID,
+		"user_id":    v.UserID,
+		"self_mute":  v.SelfMute,
+		"self_deaf":  v.SelfDeaf,
+		"mute":       v.Mute,
+		"deaf":       v.Deaf,
+		"streaming":  v.SelfStream,
+		"video":      v.SelfVideo,
+	}
+	if v.BeforeUpdate != nil {
+		params["previous_channel_id"] = v.BeforeUpdate.ChannelID
+	}
+
+	d.addUsername(params, v.GuildID, v.UserID)
+
+	d.send("discord/voiceStateUpdated", params)
+}
+
+// HandlePresenceUpdate handles the PresenceUpdate event from Discord
+func (d *EventDispatcher) HandlePresenceUpdate(s *discordgo.Session, p *discordgo.PresenceUpdate) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/presenceUpdated") || p.User == nil {
+		return
+	}
+	d.logger.Debugf("Handling PresenceUpdate event for user ID: %s", p.User.ID)
+
+	activities := make([]map[string]interface{}, 0, len(p.Activities))
+	for _, activity := range p.Activities {
+		activities = append(activities, map[string]interface{}{
+			"name":  activity.Name,
+			"type":  activity.Type,
+			"state": activity.State,
+		})
+	}
+
+	params := map[string]interface{}{
+		"guild_id":   p.GuildID,
+		"user_id":    p.User.ID,
+		"status":     p.Status,
+		"activities": activities,
+	}
+
+	d.addUsername(params, p.GuildID, p.User.ID)
+
+	d.send("discord/presenceUpdated", params)
+}
+
+// HandleTypingStart handles the TypingStart event from Discord
+func (d *EventDispatcher) HandleTypingStart(s *discordgo.Session, t *discordgo.TypingStart) {
+	if !d.config.Enabled || !d.isEventAllowed("discord/typingStarted") {
+		return
+	}
+	d.logger.Debugf("Handling TypingStart event for user ID: %s", t.UserID)
+
+	params := map[string]interface{}{
+		"guild_id":   t.GuildID,
+		"channel_id": t.ChannelID,
+		"user_id":    t.UserID,
+		"timestamp":  time.Unix(int64(t.Timestamp), 0).UTC().Format(time.RFC3339),
+	}
+
+	d.addUsername(params, t.GuildID, t.UserID)
+
+	d.send("discord/typingStarted", params)
+}
+
+// checkMessageWatches sends a watchTriggered notification for every watch
+// matching a new message. Watches are explicit subscriptions, so they are not
+// filtered by allowed_events, but messages from guilds and channels the
+// access rules exclude never trigger them.
+func (d *EventDispatcher) checkMessageWatches(s *discordgo.Session, msg *discordgo.Message) {
+	if d.watches == nil || s.State.User == nil || !d.isChannelAccessible(msg.GuildID, msg.ChannelID) {
+		return
+	}
+
+	for _, match := range d.watches.MatchMessage(msg, s.State.User.ID) {
+		d.logger.Debugf("Watch %s triggered by message %s", match.Watch.ID, msg.ID)
+
+		params := map[string]interface{}{
+			"watch_id":        match.Watch.ID,
+			"watch_type":      match.Watch.Type,
+			"guild_id":        msg.GuildID,
+			"channel_id":      msg.ChannelID,
+			"message_id":      msg.ID,
+			"author_id":       msg.Author.ID,
+			"author_username": msg.Author.Username,
+			"content":         msg.Content,
+			"message_url":     fmt.Sprintf("https://discord.com/channels/%s/%s/%s", msg.GuildID, msg.ChannelID, msg.ID),
+		}
+		if match.Watch.Pattern != "" {
+			params["pattern"] = match.Watch.Pattern
+			params["match"] = match.Text
+		}
+		d.addMessageObject(params, msg)
+
+		d.send("discord/watchTriggered", params)
+	}
+}
+
+// checkReactionWatches sends a watchTriggered notification for every emoji
+// watch matching a reaction in an accessible channel
+func (d *EventDispatcher) checkReactionWatches(reaction *discordgo.MessageReaction) {
+	if d.watches == nil || !d.isChannelAccessible(reaction.GuildID, reaction.ChannelID) {
+		return
+	}
+
+	for _, match := range d.watches.MatchReaction(reaction) {
+		d.logger.Debugf("Watch %s triggered by reaction on message %s", match.Watch.ID, reaction.MessageID)
+
+		params := map[string]interface{}{
+			"watch_id":   match.Watch.ID,
+			"watch_type": match.Watch.Type,
+			"guild_id":   reaction.GuildID,
+			"channel_id": reaction.ChannelID,
+			"message_id": reaction.MessageID,
+			"user_id":    reaction.UserID,
+			"emoji": map[string]interface{}{
+				"id":   reaction.Emoji.ID,
+				"name": reaction.Emoji.Name,
+				"url":  d.cdn.Emoji(reaction.Emoji.ID, reaction.Emoji.Animated),
+			},
+			"message_url": fmt.Sprintf("https://discord.com/channels/%s/%s/%s", reaction.GuildID, reaction.ChannelID, reaction.MessageID),
+		}
+		d.addUsername(params, reaction.GuildID, reaction.UserID)
+
+		d.send("discord/watchTriggered", params)
+	}
+}
+
+// isChannelAccessible reports whether events from a channel may reach the
+// client under the configured guild and channel access rules
+func (d *EventDispatcher) isChannelAccessible(guildID, channelID string) bool {
+	return d.channelAccess == nil || d.channelAccess(guildID, channelID)
+}
+
+// HandleGuildMembersChunk stores a chunk of a member stream and announces
+// its progress. It answers an explicit stream request, so it is not
+// filtered by allowed_events.
+func (d *EventDispatcher) HandleGuildMembersChunk(s *discordgo.Session, c *discordgo.GuildMembersChunk) {
+	if d.memberStreams == nil {
+		return
+	}
+	stream, ok := d.memberStreams.AddChunk(c)
+	if !ok {
+		return
+	}
+	d.logger.Debugf("Received member chunk %d/%d for stream %s", c.ChunkIndex+1, c.ChunkCount, stream.ID)
+
+	d.send("discord/memberStreamProgress", map[string]interface{}{
+		"stream_id":       stream.ID,
+		"guild_id":        stream.GuildID,
+		"chunk_index":     c.ChunkIndex,
+		"chunk_count":     stream.ChunkCount,
+		"chunks_received": stream.ChunksReceived,
+		"member_count":    stream.MemberCount,
+		"status":          stream.Status,
+		"complete":        stream.Status == members.StatusCompleted,
+	})
+}
+
+// NotifyPlaybackFinished announces that a queued track finished, was
+// stopped, or failed. It answers an explicit play request, so it is not
+// filtered by allowed_events.
+func (d *EventDispatcher) NotifyPlaybackFinished(result voice.Result) {
+	params := map[string]interface{}{
+		"playback_id":      result.Track.ID,
+		"guild_id":         result.Track.GuildID,
+		"channel_id":       result.Track.ChannelID,
+		"source":           result.Track.Source,
+		"status":           result.Status,
+		"duration_seconds": result.Duration.Seconds(),
+	}
+	if result.Err != nil {
+		params["error"] = result.Err.Error()
+	}
+
+	d.send("discord/playbackFinished", params)
+}
+
+// NotifyThreadDigest announces the daily digest of threads about to
+// auto-archive. The digest is configured explicitly, so it is not filtered
+// by allowed_events.
+func (d *EventDispatcher) NotifyThreadDigest(digest threads.Digest, channelID string, messageIDs []string) {
+	params := map[string]interface{}{
+		"guild_id":     digest.GuildID,
+		"window_hours": digest.WindowHours,
+		"total":        digest.Total,
+		"threads":      digest.Threads,
+	}
+	if channelID != "" {
+		params["channel_id"] = channelID
+		params["message_ids"] = messageIDs
+	}
+
+	d.send("discord/threadDigest", params)
+}
+
+// NotifyConnectionState announces a gateway connection state change. It is
+// always delivered immediately and is not subject to allowed_events.
+func (d *EventDispatcher) NotifyConnectionState(state, previous string, resumed bool) {
+	params := map[string]interface{}{
+		"state":          state,
+		"previous_state": previous,
+		"resumed":        resumed,
+		"timestamp":      time.Now().UTC().Format(time.RFC3339),
+	}
+
+	notification := d.createNotification("discord/connectionStateChanged", params)
+	if d.buffer != nil {
+		d.buffer.Add(notification)
+	}
+	if err := d.notificationSvc.SendImmediate(notification); err != nil {
+		d.logger.Errorf("Failed to send connectionStateChanged notification: %v", err)
+	}
+}
+
+// archiveMessage stores a new or edited message if its channel is subscribed
+func (d *EventDispatcher) archiveMessage(msg *discordgo.Message) {
+	if d.archive == nil || msg.Author == nil {
+		return
+	}
+
+	parentID := ""
+	if d.state != nil {
+		if channel, err := d.state.Channel(msg.ChannelID); err == nil && channel.IsThread() {
+			parentID = channel.ParentID
+		}
+	}
+	if d.archive.Subscribed(msg.GuildID, msg.ChannelID, parentID) {
+		d.archive.Store(msg)
 	}
 }
 
@@ -105,6 +764,111 @@ func (d *EventDispatcher) createNotification(method string, params map[string]in
 	}
 }
 
+// send enriches, records and sends a notification, logging any failure
+func (d *EventDispatcher) send(method string, params map[string]interface{}) {
+	d.enrich(params)
+
+	notification := d.createNotification(method, params)
+	if d.buffer != nil {
+		d.buffer.Add(notification)
+	}
+	if err := d.notificationSvc.Send(notification); err != nil {
+		d.logger.Errorf("Failed to send %s notification: %v", method, err)
+	}
+}
+
+// enrich adds guild and channel names for the IDs in an event, resolved from
+// the gateway state so no REST calls are made
+func (d *EventDispatcher) enrich(params map[string]interface{}) {
+	if d.state == nil {
+		return
+	}
+
+	if guildID, ok := params["guild_id"].(string); ok && guildID != "" {
+		if guild, err := d.state.Guild(guildID); err == nil {
+			params["guild_name"] = guild.Name
+		}
+	}
+
+	if channelID, ok := params["channel_id"].(string); ok && channelID != "" {
+		if channel, err := d.state.Channel(channelID); err == nil {
+			params["channel_name"] = channel.Name
+			if channel.IsThread() {
+				if parent, err := d.state.Channel(channel.ParentID); err == nil {
+					params["parent_channel_name"] = parent.Name
+				}
+			}
+		}
+	}
+}
+
+// addUsername adds the username of a user referenced only by ID, if known
+func (d *EventDispatcher) addUsername(params map[string]interface{}, guildID, userID string) {
+	if d.state == nil || guildID == "" || userID == "" {
+		return
+	}
+
+	member, err := d.state.Member(guildID, userID)
+	if err != nil || member.User == nil {
+		return
+	}
+	params["username"] = member.User.Username
+	if member.Nick != "" {
+		params["nick"] = member.Nick
+	}
+}
+
+// addMessageObject attaches the full formatted message when configured
+func (d *EventDispatcher) addMessageObject(params map[string]interface{}, msg *discordgo.Message) {
+	if !d.config.IncludeMessageObject || d.formatMessage == nil || msg.Author == nil {
+		return
+	}
+	params["message"] = d.formatMessage(msg)
+}
+
+// attachmentSummaries describes message attachments without their content
+func attachmentSummaries(attachments []*discordgo.MessageAttachment) []map[string]interface{} {
+	summaries := make([]map[string]interface{}, len(attachments))
+	for i, att := range attachments {
+		summaries[i] = map[string]interface{}{
+			"filename":     att.Filename,
+			"content_type": att.ContentType,
+			"size":         att.Size,
+			"url":          att.URL,
+		}
+	}
+	return summaries
+}
+
+// roleParams builds the notification parameters for a role event
+func roleParams(guildID string, role *discordgo.Role) map[string]interface{} {
+	return map[string]interface{}{
+		"guild_id": guildID,
+		"role": map[string]interface{}{
+			"id":          role.ID,
+			"name":        role.Name,
+			"color":       role.Color,
+			"position":    role.Position,
+			"permissions": role.Permissions,
+			"hoist":       role.Hoist,
+			"mentionable": role.Mentionable,
+			"managed":     role.Managed,
+		},
+	}
+}
+
+// channelParams builds the notification parameters for a channel or thread event
+func channelParams(channel *discordgo.Channel) map[string]interface{} {
+	return map[string]interface{}{
+		"guild_id":   channel.GuildID,
+		"channel_id": channel.ID,
+		"name":       channel.Name,
+		"type":       channel.Type,
+		"parent_id":  channel.ParentID,
+		"position":   channel.Position,
+	}
+}
+
 func (d *EventDispatcher) isEventAllowed(event string) bool {
 	for _, allowedEvent := range d.config.AllowedEvents {
 		if allowedEvent == event {
@@ -0,0 +1,190 @@
+package discord
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/outbound"
+)
+
+// Stable error codes reported to MCP clients
+const (
+	ErrCodeNotConnected       = "not_connected"
+	ErrCodeGuildNotAllowed    = "guild_not_allowed"
+	ErrCodeRateLimited        = "rate_limited"
+	ErrCodeLocalRateLimited   = "local_rate_limited"
+	ErrCodeQueueFull          = "queue_full"
+	ErrCodeUnknownChannel     = "unknown_channel"
+	ErrCodeUnknownGuild       = "unknown_guild"
+	ErrCodeUnknownMember      = "unknown_member"
+	ErrCodeUnknownMessage     = "unknown_message"
+	ErrCodeUnknownRole        = "unknown_role"
+	ErrCodeUnknownUser        = "unknown_user"
+	ErrCodeUnknownEmoji       = "unknown_emoji"
+	ErrCodeUnknownWebhook     = "unknown_webhook"
+	ErrCodeUnknownBan         = "unknown_ban"
+	ErrCodeUnknownInvite      = "unknown_invite"
+	ErrCodeMissingAccess      = "missing_access"
+	ErrCodeMissingPermissions = "missing_permissions"
+	ErrCodeCannotMessageUser  = "cannot_message_user"
+	ErrCodeInvalidRequest     = "invalid_request"
+	ErrCodeLimitReached       = "limit_reached"
+	ErrCodeReactionBlocked    = "reaction_blocked"
+	ErrCodeUnauthorized       = "unauthorized"
+	ErrCodeForbidden          = "forbidden"
+	ErrCodeNotFound           = "not_found"
+	ErrCodeBadRequest         = "bad_request"
+	ErrCodeServerError        = "server_error"
+	ErrCodeNetwork            = "network_error"
+	ErrCodeUnknown            = "unknown"
+)
+
+// APIError is a classified Discord failure
+type APIError struct {
+	// Code is one of the stable ErrCode values
+	Code string
+	// Retryable reports whether repeating the call may succeed
+	Retryable bool
+	// RetryAfter is how long to wait before retrying, when known
+	RetryAfter time.Duration
+	// Hint suggests how to fix the failure
+	Hint string
+	// DiscordCode is Discord's JSON error code, if any
+	DiscordCode int
+	// HTTPStatus is the response status, if a response was received
+	HTTPStatus int
+}
+
+// errorClass describes a Discord JSON error code
+type errorClass struct {
+	code string
+	hint string
+}
+
+// discordErrorCodes maps Discord JSON error codes to stable codes
+var discordErrorCodes = map[int]errorClass{
+	discordgo.ErrCodeUnknownChannel: {ErrCodeUnknownChannel, "Check the channel ID; the channel may have been deleted"},
+	discordgo.ErrCodeUnknownGuild:   {ErrCodeUnknownGuild, "Check the guild ID; the bot may have been removed from the guild"},
+	discordgo.ErrCodeUnknownMember:  {ErrCodeUnknownMember, "The user is not a member of this guild"},
+	discordgo.ErrCodeUnknownMessage: {ErrCodeUnknownMessage, "Check the message ID; the message may have been deleted"},
+	discordgo.ErrCodeUnknownRole:    {ErrCodeUnknownRole, "Check the role ID; the role may have been deleted"},
+	discordgo.ErrCodeUnknownUser:    {ErrCodeUnknownUser, "Check the user ID"},
+	discordgo.ErrCodeUnknownEmoji:   {ErrCodeUnknownEmoji, "Use a Unicode emoji or a custom emoji from a guild the bot is in"},
+	discordgo.ErrCodeUnknownWebhook: {ErrCodeUnknownWebhook, "Check the webhook ID; the webhook may have been deleted"},
+	discordgo.ErrCodeUnknownBan:     {ErrCodeUnknownBan, "The user is not banned from this guild"},
+	discordgo.ErrCodeUnknownInvite:  {ErrCodeUnknownInvite, "The invite is invalid or has expired"},
+
+	discordgo.ErrCodeMissingAccess:                  {ErrCodeMissingAccess, "Grant the bot the View Channel permission (and Read Message History for history) in this channel"},
+	discordgo.ErrCodeMissingPermissions:             {ErrCodeMissingPermissions, "Grant the bot's role the required permission, and make sure the bot's highest role is above the target role or member"},
+	discordgo.ErrCodeCannotSendMessagesToThisUser:   {ErrCodeCannotMessageUser, "The user has direct messages from server members disabled or has blocked the bot"},
+	discordgo.ErrCodeInvalidFormBody:                {ErrCodeInvalidRequest, "Discord rejected one or more parameter values; see details"},
+	discordgo.ErrCodeCannotExecuteActionOnDMChannel: {ErrCodeInvalidRequest, "This action is not available in direct messages"},
+
+	discordgo.ErrCodeMaximumPinsReached:             {ErrCodeLimitReached, "The channel has 50 pinned messages; unpin one first"},
+	discordgo.ErrCodeMaximumGuildRolesReached:       {ErrCodeLimitReached, "The guild has reached the maximum number of roles"},
+	discordgo.ErrCodeMaximumNumberOfWebhooksReached: {ErrCodeLimitReached, "The channel has reached the maximum number of webhooks"},
+	discordgo.ErrCodeMaximumNumberOfEmojisReached:   {ErrCodeLimitReached, "The guild has reached its emoji limit"},
+	discordgo.ErrCodeTooManyReactions:               {ErrCodeLimitReached, "The message has reached the maximum number of distinct reactions"},
+
+	discordgo.ErrCodeReactionBlocked: {ErrCodeReactionBlocked, "The message author has blocked the bot"},
+	discordgo.ErrCodeUnauthorized:    {ErrCodeUnauthorized, "The bot token is invalid or has been revoked"},
+}
+
+// ClassifyError maps an error returned by the client or discordgo to a
+// stable code with a retry flag and a remediation hint
+func ClassifyError(err error) *APIError {
+	var rateLimitErr *discordgo.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		apiErr := &APIError{
+			Code:       ErrCodeRateLimited,
+			Retryable:  true,
+			HTTPStatus: http.StatusTooManyRequests,
+			Hint:       "Discord rate limited this request; wait before retrying",
+		}
+		if rateLimitErr.RateLimit != nil && rateLimitErr.TooManyRequests != nil {
+			apiErr.RetryAfter = rateLimitErr.RetryAfter
+		}
+		return apiErr
+	}
+
+	if errors.Is(err, outbound.ErrQueueFull) {
+		return &APIError{Code: ErrCodeQueueFull, Retryable: true, RetryAfter: time.Second, Hint: "The outbound message queue is full; retry once pending messages have been sent"}
+	}
+
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) {
+		return classifyRESTError(restErr)
+	}
+
+	var netErr net.Error
+	var urlErr *url.Error
+	if errors.As(err, &netErr) || errors.As(err, &urlErr) || strings.Contains(err.Error(), "Exceeded Max retries HTTP") {
+		return &APIError{
+			Code:      ErrCodeNetwork,
+			Retryable: true,
+			Hint:      "Discord could not be reached; retry shortly",
+		}
+	}
+
+	// Errors raised by the client itself before any request is made
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not connected to Discord"):
+		return &APIError{Code: ErrCodeNotConnected, Retryable: true, Hint: "The bot is not connected to the Discord gateway; retry once it reconnects"}
+	case strings.Contains(msg, "is not allowed"):
+		return &APIError{Code: ErrCodeGuildNotAllowed, Hint: "Add the guild to discord.allowed_guilds to give the server access to it"}
+	case strings.Contains(msg, "rate limit exceeded"):
+		return &APIError{Code: ErrCodeLocalRateLimited, Retryable: true, RetryAfter: time.Second, Hint: "The server's discord.rate_limit_per_minute was reached; slow down"}
+	}
+
+	return &APIError{Code: ErrCodeUnknown}
+}
+
+// classifyRESTError classifies an HTTP error response from Discord
+func classifyRESTError(restErr *discordgo.RESTError) *APIError {
+	apiErr := &APIError{}
+	if restErr.Response != nil {
+		apiErr.HTTPStatus = restErr.Response.StatusCode
+	}
+
+	if restErr.Message != nil {
+		apiErr.DiscordCode = restErr.Message.Code
+		if class, ok := discordErrorCodes[restErr.Message.Code]; ok {
+			apiErr.Code = class.code
+			apiErr.Hint = class.hint
+			return apiErr
+		}
+	}
+
+	switch status := apiErr.HTTPStatus; {
+	case status == http.StatusUnauthorized:
+		apiErr.Code = ErrCodeUnauthorized
+		apiErr.Hint = "The bot token is invalid or has been revoked"
+	case status == http.StatusForbidden:
+		apiErr.Code = ErrCodeForbidden
+		apiErr.Hint = "The bot lacks access or permission for this action"
+	case status == http.StatusNotFound:
+		apiErr.Code = ErrCodeNotFound
+		apiErr.Hint = "Check the IDs; the resource may have been deleted"
+	case status == http.StatusTooManyRequests:
+		apiErr.Code = ErrCodeRateLimited
+		apiErr.Retryable = true
+		apiErr.Hint = "Discord rate limited this request; wait before retrying"
+	case status >= 500:
+		apiErr.Code = ErrCodeServerError
+		apiErr.Retryable = true
+		apiErr.Hint = "Discord returned a server error; retry shortly"
+	case status >= 400:
+		apiErr.Code = ErrCodeBadRequest
+		apiErr.Hint = "Discord rejected the request; see details"
+	default:
+		apiErr.Code = ErrCodeUnknown
+	}
+	return apiErr
+}
@@ -0,0 +1,100 @@
+package discord
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/feeds"
+	"discord-mcp/internal/outbound"
+)
+
+// Embed field limits that feed text is cut to
+const (
+	maxEmbedTitle  = 256
+	maxEmbedAuthor = 256
+	maxEmbedFooter = 2048
+	// maxEmbedsLength is the total text Discord accepts across the embeds
+	// of one message
+	maxEmbedsLength = 6000
+)
+
+// postFeedEntries posts new feed entries to the feed's channel with an
+// embed per entry, starting another message when the embeds of one would
+// exceed Discord's text limit
+func (c *Client) postFeedEntries(feed feeds.Feed, entries []feeds.Entry) error {
+	var batches [][]*discordgo.MessageEmbed
+	length := 0
+	for _, entry := range entries {
+		embed := feedEmbed(feed, entry)
+		size := embedLength(embed)
+		if len(batches) == 0 || length+size > maxEmbedsLength {
+			batches = append(batches, nil)
+			length = 0
+		}
+		batches[len(batches)-1] = append(batches[len(batches)-1], embed)
+		length += size
+	}
+
+	for _, embeds := range batches {
+		msg := &discordgo.MessageSend{
+			Embeds:          embeds,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		}
+		_, _, err := c.SendQueued(feed.ChannelID, outbound.PriorityBulk, func() error {
+			_, err := c.session.ChannelMessageSendComplex(feed.ChannelID, msg)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// feedEmbed renders one feed entry
+func feedEmbed(feed feeds.Feed, entry feeds.Entry) *discordgo.MessageEmbed {
+	embed := &discordgo.MessageEmbed{
+		Title:       truncateRunes(entry.Title, maxEmbedTitle),
+		URL:         entry.Link,
+		Description: entry.Summary,
+	}
+	if embed.Title == "" {
+		embed.Title = truncateRunes(entry.Link, maxEmbedTitle)
+	}
+	if entry.Author != "" {
+		embed.Author = &discordgo.MessageEmbedAuthor{Name: truncateRunes(entry.Author, maxEmbedAuthor)}
+	}
+	if entry.ImageURL != "" {
+		embed.Image = &discordgo.MessageEmbedImage{URL: entry.ImageURL}
+	}
+	footer := feed.Title
+	if footer == "" {
+		footer = feed.URL
+	}
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: truncateRunes(footer, maxEmbedFooter)}
+	if !entry.Published.IsZero() {
+		embed.Timestamp = entry.Published.Format(time.RFC3339)
+	}
+	return embed
+}
+
+// embedLength counts the characters of an embed that Discord limits
+func embedLength(embed *discordgo.MessageEmbed) int {
+	n := len([]rune(embed.Title)) + len([]rune(embed.Description))
+	if embed.Author != nil {
+		n += len([]rune(embed.Author.Name))
+	}
+	if embed.Footer != nil {
+		n += len([]rune(embed.Footer.Text))
+	}
+	return n
+}
+
+// truncateRunes cuts s to at most limit characters
+func truncateRunes(s string, limit int) string {
+	if runes := []rune(s); len(runes) > limit {
+		return string(runes[:limit-1]) + "…"
+	}
+	return s
+}
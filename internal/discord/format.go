@@ -0,0 +1,159 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/pkg/types"
+)
+
+// FormatMessage converts a Discord message to a structured format
+func (c *Client) FormatMessage(msg *discordgo.Message) types.MessageSummary {
+	// Format attachments
+	attachments := make([]types.AttachmentSummary, len(msg.Attachments))
+	for i, att := range msg.Attachments {
+		attachments[i] = types.AttachmentSummary{
+			ID:       att.ID,
+			Filename: att.Filename,
+			Size:     att.Size,
+			URL:      att.URL,
+			Width:    att.Width,
+			Height:   att.Height,
+		}
+	}
+
+	// Format embeds
+	embeds := make([]types.EmbedSummary, len(msg.Embeds))
+	for i, embed := range msg.Embeds {
+		embedData := types.EmbedSummary{
+			Title:       embed.Title,
+			Description: embed.Description,
+			Color:       embed.Color,
+			URL:         embed.URL,
+		}
+
+		if embed.Thumbnail != nil {
+			embedData.Thumbnail = &types.EmbedImageSummary{URL: embed.Thumbnail.URL}
+		}
+
+		if embed.Image != nil {
+			embedData.Image = &types.EmbedImageSummary{URL: embed.Image.URL}
+		}
+
+		if len(embed.Fields) > 0 {
+			embedData.Fields = make([]types.EmbedFieldSummary, len(embed.Fields))
+			for j, field := range embed.Fields {
+				embedData.Fields[j] = types.EmbedFieldSummary{
+					Name:   field.Name,
+					Value:  field.Value,
+					Inline: field.Inline,
+				}
+			}
+		}
+
+		embeds[i] = embedData
+	}
+
+	// Format reactions
+	reactions := make([]types.ReactionSummary, len(msg.Reactions))
+	for i, reaction := range msg.Reactions {
+		reactions[i] = types.ReactionSummary{
+			Emoji: types.EmojiSummary{
+				Name: reaction.Emoji.Name,
+				ID:   reaction.Emoji.ID,
+				URL:  c.cdn.Emoji(reaction.Emoji.ID, reaction.Emoji.Animated),
+			},
+			Count: reaction.Count,
+			Me:    reaction.Me,
+		}
+	}
+
+	// Format stickers
+	stickers := make([]types.StickerSummary, len(msg.StickerItems))
+	for i, sticker := range msg.StickerItems {
+		stickers[i] = types.StickerSummary{
+			ID:         sticker.ID,
+			Name:       sticker.Name,
+			FormatType: int(sticker.FormatType),
+			URL:        c.cdn.Sticker(sticker.ID, sticker.FormatType),
+		}
+	}
+
+	return types.MessageSummary{
+		ID:                 msg.ID,
+		Content:            msg.Content,
+		Author:             c.FormatUser(msg.Author),
+		Timestamp:          msg.Timestamp.Format(time.RFC3339),
+		Edited:             msg.EditedTimestamp != nil,
+		TTS:                msg.TTS,
+		MentionEveryone:    msg.MentionEveryone,
+		Mentions:           c.formatMentions(msg.Mentions),
+		Attachments:        attachments,
+		Embeds:             embeds,
+		Reactions:          reactions,
+		Stickers:           stickers,
+		Pinned:             msg.Pinned,
+		Type:               int(msg.Type),
+		Flags:              int(msg.Flags),
+		MessageURL:         fmt.Sprintf("https://discord.com/channels/%s/%s/%s", msg.GuildID, msg.ChannelID, msg.ID),
+		ContentUnavailable: c.IsMessageContentHidden(msg),
+	}
+}
+
+// formatMentions formats user mentions
+func (c *Client) formatMentions(mentions []*discordgo.User) []types.UserSummary {
+	formatted := make([]types.UserSummary, len(mentions))
+	for i, user := range mentions {
+		formatted[i] = c.FormatUser(user)
+	}
+	return formatted
+}
+
+// FormatUser converts a Discord user to a structured format
+func (c *Client) FormatUser(user *discordgo.User) types.UserSummary {
+	return types.UserSummary{
+		ID:            user.ID,
+		Username:      user.Username,
+		Discriminator: user.Discriminator,
+		Avatar:        user.Avatar,
+		AvatarURL:     c.cdn.UserAvatar(user),
+		Bot:           user.Bot,
+	}
+}
+
+// FormatRole converts a guild role to a structured format
+func (c *Client) FormatRole(role *discordgo.Role) types.RoleSummary {
+	return types.RoleSummary{
+		ID:          role.ID,
+		Name:        role.Name,
+		Color:       role.Color,
+		IconURL:     c.cdn.RoleIcon(role),
+		Hoist:       role.Hoist,
+		Position:    role.Position,
+		Permissions: role.Permissions,
+		Managed:     role.Managed,
+		Mentionable: role.Mentionable,
+	}
+}
+
+// FormatMember converts a guild member to a structured format
+func (c *Client) FormatMember(guildID string, member *discordgo.Member) types.MemberSummary {
+	roles := member.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	return types.MemberSummary{
+		ID:            member.User.ID,
+		Username:      member.User.Username,
+		Discriminator: member.User.Discriminator,
+		Nick:          member.Nick,
+		AvatarURL:     c.cdn.MemberAvatar(guildID, member),
+		Roles:         roles,
+		JoinedAt:      member.JoinedAt,
+		Deaf:          member.Deaf,
+		Mute:          member.Mute,
+		Bot:           member.User.Bot,
+	}
+}
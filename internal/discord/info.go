@@ -0,0 +1,74 @@
+package discord
+
+import "time"
+
+// ApplicationID returns the bot's application ID from the Ready event, or
+// an empty string before the gateway is ready
+func (c *Client) ApplicationID() string {
+	c.session.State.RLock()
+	defer c.session.State.RUnlock()
+
+	if c.session.State.Application == nil {
+		return ""
+	}
+	return c.session.State.Application.ID
+}
+
+// Uptime returns how long the client has existed
+func (c *Client) Uptime() time.Duration {
+	return time.Since(c.startedAt)
+}
+
+// RateLimitBudget returns how many requests the local rate limiter still
+// allows in the current window, and the limit per window
+func (c *Client) RateLimitBudget() (remaining, limit int) {
+	return c.rateLimiter.Remaining(), c.rateLimiter.maxReqs
+}
+
+// Remaining returns how many requests are still allowed in the current window
+func (rl *rateLimiter) Remaining() int {
+	rl.mutex.Lock()
+	defer rl.mutex.Unlock()
+
+	cutoff := time.Now().Add(-rl.duration)
+	used := 0
+	for _, req := range rl.requests {
+		if req.After(cutoff) {
+			used++
+		}
+	}
+
+	if used >= rl.maxReqs {
+		return 0
+	}
+	return rl.maxReqs - used
+}
+
+// RateLimitStats are the rate limit totals since startup
+type RateLimitStats struct {
+	// LocalLimit is discord.rate_limit_per_minute, with LocalRemaining of it
+	// left in the current window
+	LocalLimit     int `json:"local_limit"`
+	LocalRemaining int `json:"local_remaining"`
+	// LocalRejected counts requests refused by the local limiter
+	LocalRejected int64 `json:"local_rejected"`
+	// DiscordWaits counts 429 responses waited out before retrying, for
+	// DiscordWaitMs in total
+	DiscordWaits  int64 `json:"discord_waits"`
+	DiscordWaitMs int64 `json:"discord_wait_ms"`
+}
+
+// RateLimitStats returns the local and Discord rate limit totals
+func (c *Client) RateLimitStats() RateLimitStats {
+	stats := RateLimitStats{LocalLimit: c.rateLimiter.maxReqs, LocalRemaining: c.rateLimiter.Remaining()}
+
+	c.rateLimiter.mutex.Lock()
+	stats.LocalRejected = c.rateLimiter.rejected
+	c.rateLimiter.mutex.Unlock()
+
+	c.rateLimitWaits.mutex.Lock()
+	stats.DiscordWaits = c.rateLimitWaits.count
+	stats.DiscordWaitMs = c.rateLimitWaits.waited.Milliseconds()
+	c.rateLimitWaits.mutex.Unlock()
+	return stats
+}
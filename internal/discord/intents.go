@@ -0,0 +1,145 @@
+package discord
+
+import (
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/config"
+)
+
+// Application flags reporting whether the privileged MESSAGE_CONTENT intent
+// has been enabled for the bot in the Discord Developer Portal
+const (
+	applicationFlagGatewayMessageContent        = 1 << 18
+	applicationFlagGatewayMessageContentLimited = 1 << 19
+)
+
+// detectMessageContentIntent works out whether message content will be
+// delivered to the bot and warns when it will not
+func (c *Client) detectMessageContentIntent() {
+	requested := c.config.Discord.MessageContentIntent
+
+	var flags int
+	_, err := c.Retry(func() error {
+		app, err := c.session.Application("@me")
+		if err != nil {
+			return err
+		}
+		flags = app.Flags
+		return nil
+	})
+	if err != nil {
+		c.logger.Warnf("Could not determine MESSAGE_CONTENT intent status: %v", err)
+	}
+
+	enabledInPortal := flags&(applicationFlagGatewayMessageContent|applicationFlagGatewayMessageContentLimited) != 0
+
+	c.mutex.Lock()
+	// The gateway rejects the identify if a requested privileged intent is not
+	// enabled, so a successful connection with the intent requested means it is
+	// available even if the application lookup failed
+	c.messageContent = requested
+	c.mutex.Unlock()
+
+	switch {
+	case !requested && enabledInPortal:
+		c.logger.Warn("MESSAGE_CONTENT intent is enabled in the Developer Portal but not requested; " +
+			"set discord.message_content_intent: true to receive message content")
+	case !requested:
+		c.logger.Warn("MESSAGE_CONTENT intent is not available; message content will be empty for most messages. " +
+			"Enable it in the Developer Portal and set discord.message_content_intent: true")
+	}
+}
+
+// HasMessageContent reports whether the bot receives message content
+func (c *Client) HasMessageContent() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.messageContent
+}
+
+// IsMessageContentHidden reports whether a message's content was withheld by
+// Discord because the bot lacks the MESSAGE_CONTENT intent. Bots always
+// receive content for DMs, their own messages and messages mentioning them.
+func (c *Client) IsMessageContentHidden(msg *discordgo.Message) bool {
+	if c.HasMessageContent() || msg.Content != "" || msg.GuildID == "" {
+		return false
+	}
+
+	botUser := c.session.State.User
+	if botUser == nil {
+		return true
+	}
+
+	if msg.Author != nil && msg.Author.ID == botUser.ID {
+		return false
+	}
+
+	for _, user := range msg.Mentions {
+		if user.ID == botUser.ID {
+			return false
+		}
+	}
+
+	return true
+}
+
+// eventIntents returns the extra gateway intents needed by the allowed
+// events. High-volume and privileged intents are only requested when an
+// event that needs them is enabled.
+func eventIntents(cfg config.EventsConfig) discordgo.Intent {
+	if !cfg.Enabled {
+		return discordgo.IntentsNone
+	}
+
+	var intents discordgo.Intent
+	for _, event := range cfg.AllowedEvents {
+		switch event {
+		case "discord/guildBanAdded", "discord/guildBanRemoved":
+			intents |= discordgo.IntentsGuildBans
+		case "discord/voiceStateUpdated":
+			intents |= discordgo.IntentsGuildVoiceStates
+		case "discord/presenceUpdated":
+			// Privileged: must also be enabled in the Developer Portal
+			intents |= discordgo.IntentsGuildPresences
+		case "discord/typingStarted":
+			intents |= discordgo.IntentsGuildMessageTyping | discordgo.IntentsDirectMessageTyping
+		}
+	}
+	return intents
+}
+
+// intentNames lists gateway intents with their API names
+var intentNames = []struct {
+	intent discordgo.Intent
+	name   string
+}{
+	{discordgo.IntentsGuilds, "GUILDS"},
+	{discordgo.IntentsGuildMembers, "GUILD_MEMBERS"},
+	{discordgo.IntentsGuildBans, "GUILD_MODERATION"},
+	{discordgo.IntentsGuildEmojis, "GUILD_EMOJIS_AND_STICKERS"},
+	{discordgo.IntentsGuildIntegrations, "GUILD_INTEGRATIONS"},
+	{discordgo.IntentsGuildWebhooks, "GUILD_WEBHOOKS"},
+	{discordgo.IntentsGuildInvites, "GUILD_INVITES"},
+	{discordgo.IntentsGuildVoiceStates, "GUILD_VOICE_STATES"},
+	{discordgo.IntentsGuildPresences, "GUILD_PRESENCES"},
+	{discordgo.IntentsGuildMessages, "GUILD_MESSAGES"},
+	{discordgo.IntentsGuildMessageReactions, "GUILD_MESSAGE_REACTIONS"},
+	{discordgo.IntentsGuildMessageTyping, "GUILD_MESSAGE_TYPING"},
+	{discordgo.IntentsDirectMessages, "DIRECT_MESSAGES"},
+	{discordgo.IntentsDirectMessageReactions, "DIRECT_MESSAGE_REACTIONS"},
+	{discordgo.IntentsDirectMessageTyping, "DIRECT_MESSAGE_TYPING"},
+	{discordgo.IntentsMessageContent, "MESSAGE_CONTENT"},
+	{discordgo.IntentsGuildScheduledEvents, "GUILD_SCHEDULED_EVENTS"},
+}
+
+// Intents returns the names of the gateway intents the client identifies with
+func (c *Client) Intents() []string {
+	intents := c.session.Identify.Intents
+	names := []string{}
+	for _, i := range intentNames {
+		if intents&i.intent != 0 {
+			names = append(names, i.name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,45 @@
+package discord
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/rolemenu"
+	"discord-mcp/internal/verification"
+)
+
+// HandleInteractionCreate routes message component interactions, such as a
+// selection in a role menu or a press of a verify button, to the feature
+// that posted the component by its custom ID prefix
+func (d *EventDispatcher) HandleInteractionCreate(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionMessageComponent {
+		return
+	}
+	customID := i.MessageComponentData().CustomID
+
+	switch {
+	case strings.HasPrefix(customID, rolemenu.CustomIDPrefix):
+		d.applyRoleMenu(s, i.Interaction)
+	case strings.HasPrefix(customID, verification.CustomIDPrefix):
+		d.verifyFromButton(s, i.Interaction)
+	default:
+		d.logger.Debugf("Ignoring component interaction with custom ID %q", customID)
+	}
+}
+
+// respondEphemeral acknowledges an interaction with a reply only the member
+// who used the component can see
+func (d *EventDispatcher) respondEphemeral(s *discordgo.Session, i *discordgo.Interaction, content string) {
+	err := s.InteractionRespond(i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:         content,
+			Flags:           discordgo.MessageFlagsEphemeral,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		},
+	})
+	if err != nil {
+		d.logger.Warnf("Failed to respond to interaction %s: %v", i.ID, err)
+	}
+}
@@ -0,0 +1,88 @@
+package discord
+
+import (
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/membership"
+)
+
+// HandleGuildCreate records the invite use counts of a guild becoming
+// available, so the first join afterwards can be matched to its invite
+func (d *EventDispatcher) HandleGuildCreate(s *discordgo.Session, g *discordgo.GuildCreate) {
+	if d.invites == nil || g.Unavailable {
+		return
+	}
+
+	d.invitesMutex.Lock()
+	defer d.invitesMutex.Unlock()
+
+	invites, err := d.guildInvites(s, g.ID)
+	if err != nil {
+		d.logger.Debugf("Not tracking invites of guild %s: %v", g.ID, err)
+		return
+	}
+	d.invites.Seed(g.ID, invites)
+}
+
+// recordJoin logs a member joining, with the invite they joined with when
+// invite tracking can tell it
+func (d *EventDispatcher) recordJoin(s *discordgo.Session, member *discordgo.Member) {
+	if d.membership == nil || member == nil || member.User == nil {
+		return
+	}
+
+	event := membership.Event{
+		Type:     membership.EventJoin,
+		GuildID:  member.GuildID,
+		UserID:   member.User.ID,
+		Username: member.User.Username,
+		Bot:      member.User.Bot,
+	}
+	if !member.JoinedAt.IsZero() {
+		event.At = member.JoinedAt.UTC()
+	}
+	if d.invites != nil {
+		event.InviteCode, event.InviterID = d.matchInvite(s, member.GuildID)
+	}
+	d.membership.Record(event)
+}
+
+// recordLeave logs a member leaving
+func (d *EventDispatcher) recordLeave(guildID string, user *discordgo.User) {
+	if d.membership == nil || user == nil {
+		return
+	}
+	d.membership.Record(membership.Event{
+		Type:     membership.EventLeave,
+		GuildID:  guildID,
+		UserID:   user.ID,
+		Username: user.Username,
+		Bot:      user.Bot,
+	})
+}
+
+// matchInvite reads a guild's invites after a join and returns the one the
+// join used, or empty strings when it cannot be told. Reads are serialised
+// so each join is compared against the counts left by the previous one.
+func (d *EventDispatcher) matchInvite(s *discordgo.Session, guildID string) (string, string) {
+	d.invitesMutex.Lock()
+	defer d.invitesMutex.Unlock()
+
+	invites, err := d.guildInvites(s, guildID)
+	if err != nil {
+		d.logger.Debugf("Failed to read invites of guild %s: %v", guildID, err)
+		return "", ""
+	}
+	code, inviterID, _ := d.invites.Match(guildID, invites)
+	return code, inviterID
+}
+
+// guildInvites lists a guild's invites
+func (d *EventDispatcher) guildInvites(s *discordgo.Session, guildID string) ([]*discordgo.Invite, error) {
+	var invites []*discordgo.Invite
+	err := d.call(func() (err error) {
+		invites, err = s.GuildInvites(guildID)
+		return err
+	})
+	return invites, err
+}
@@ -0,0 +1,122 @@
+package discord
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/onboarding"
+	"discord-mcp/internal/outbound"
+)
+
+// runOnboarding runs the onboarding rules matching a new member and sends an
+// onboardingCompleted notification for each. Rules are explicit
+// configuration, so they are not filtered by allowed_events.
+func (d *EventDispatcher) runOnboarding(s *discordgo.Session, member *discordgo.Member) {
+	if d.onboarding == nil || member == nil || member.User == nil {
+		return
+	}
+
+	rules := d.onboarding.Match(member.GuildID, member.User.Bot)
+	if len(rules) == 0 {
+		return
+	}
+
+	var guild *discordgo.Guild
+	if d.state != nil {
+		guild, _ = d.state.Guild(member.GuildID)
+	}
+	values := onboarding.Values(member, guild)
+
+	for _, rule := range rules {
+		d.logger.Debugf("Running onboarding rule %q for user %s", rule.Name, member.User.ID)
+
+		actions, err := d.runOnboardingRule(s, rule, member, values)
+		d.onboarding.Record(rule.GuildID, rule.Name, err)
+
+		params := map[string]interface{}{
+			"rule":     rule.Name,
+			"guild_id": member.GuildID,
+			"user": map[string]interface{}{
+				"id":       member.User.ID,
+				"username": member.User.Username,
+			},
+			"actions": actions,
+			"success": err == nil,
+		}
+		if err != nil {
+			d.logger.Warnf("Onboarding rule %q failed for user %s: %v", rule.Name, member.User.ID, err)
+			params["error"] = err.Error()
+		}
+
+		d.send("discord/onboardingCompleted", params)
+	}
+}
+
+// runOnboardingRule performs every action of a rule, continuing past
+// failures so one missing permission does not block the rest. It returns the
+// outcome of each action and the joined errors.
+func (d *EventDispatcher) runOnboardingRule(s *discordgo.Session, rule onboarding.Rule, member *discordgo.Member, values map[string]string) ([]map[string]interface{}, error) {
+	var actions []map[string]interface{}
+	var errs []error
+	record := func(action map[string]interface{}, err error) {
+		action["success"] = err == nil
+		if err != nil {
+			action["error"] = err.Error()
+			errs = append(errs, fmt.Errorf("%s: %w", action["action"], err))
+		}
+		actions = append(actions, action)
+	}
+
+	for _, roleID := range rule.RoleIDs {
+		err := d.call(func() error {
+			return s.GuildMemberRoleAdd(member.GuildID, member.User.ID, roleID)
+		})
+		record(map[string]interface{}{"action": "assign_role", "role_id": roleID}, err)
+	}
+
+	if rule.DirectMessage != "" {
+		content, err := onboarding.Render(rule.DirectMessage, values)
+		if err == nil {
+			var channel *discordgo.Channel
+			err = d.call(func() (err error) {
+				channel, err = s.UserChannelCreate(member.User.ID)
+				return err
+			})
+			if err == nil {
+				err = d.enqueue(channel.ID, outbound.PriorityNormal, func() error {
+					_, err := s.ChannelMessageSend(channel.ID, content)
+					return err
+				})
+			}
+		}
+		record(map[string]interface{}{"action": "direct_message"}, err)
+	}
+
+	if rule.ChannelMessage != "" {
+		content, err := onboarding.Render(rule.ChannelMessage, values)
+		if err == nil {
+			// Only the new member may be pinged by a welcome message
+			err = d.enqueue(rule.ChannelID, outbound.PriorityNormal, func() error {
+				_, err := s.ChannelMessageSendComplex(rule.ChannelID, &discordgo.MessageSend{
+					Content:         content,
+					AllowedMentions: &discordgo.MessageAllowedMentions{Users: []string{member.User.ID}},
+				})
+				return err
+			})
+		}
+		record(map[string]interface{}{"action": "channel_message", "channel_id": rule.ChannelID}, err)
+	}
+
+	return actions, errors.Join(errs...)
+}
+
+// call runs a REST call through the client's retry policy when available
+func (d *EventDispatcher) call(fn func() error) error {
+	if d.retry == nil {
+		return fn()
+	}
+	_, err := d.retry(fn)
+	return err
+}
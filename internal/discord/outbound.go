@@ -0,0 +1,40 @@
+package discord
+
+import (
+	"time"
+
+	"discord-mcp/internal/outbound"
+)
+
+// SendQueued runs a message send through the outbound queue at a priority,
+// retrying it under the retry policy once it is the channel's turn. It
+// returns the retries performed and how long the message waited.
+func (c *Client) SendQueued(channelID string, priority outbound.Priority, send func() error) (int, time.Duration, error) {
+	retries := 0
+	run := func() (err error) {
+		retries, err = c.Retry(send)
+		return err
+	}
+	if c.outbound == nil {
+		return retries, 0, run()
+	}
+	waited, err := c.outbound.Send(channelID, priority, run)
+	return retries, waited, err
+}
+
+// Outbound returns the outbound message queue, or nil if it is disabled
+func (c *Client) Outbound() *outbound.Queue {
+	return c.outbound
+}
+
+// enqueue runs a message send made by an event handler through the
+// outbound queue and the retry policy
+func (d *EventDispatcher) enqueue(channelID string, priority outbound.Priority, send func() error) error {
+	if d.outbound == nil {
+		return d.call(send)
+	}
+	_, err := d.outbound.Send(channelID, priority, func() error {
+		return d.call(send)
+	})
+	return err
+}
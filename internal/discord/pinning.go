@@ -0,0 +1,86 @@
+package discord
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// applyPinPolicy pins a new message that matches its channel's pinning
+// policy. When the channel holds as many pins as the policy allows, the
+// oldest pins are removed first, or the message is left unpinned if the
+// policy does not unpin.
+func (d *EventDispatcher) applyPinPolicy(s *discordgo.Session, msg *discordgo.Message) {
+	if d.pinning == nil || msg.GuildID == "" {
+		return
+	}
+	policy, ok := d.pinning.Policy(msg.ChannelID)
+	if !ok || !policy.Enabled {
+		return
+	}
+	if msg.Type != discordgo.MessageTypeDefault && msg.Type != discordgo.MessageTypeReply {
+		return
+	}
+	var roleIDs []string
+	if msg.Member != nil {
+		roleIDs = msg.Member.Roles
+	}
+	if !policy.Matches(msg.Content, roleIDs) {
+		return
+	}
+
+	// Messages arrive concurrently; handling one at a time keeps two pins
+	// from both counting the same free slot
+	d.pinningMutex.Lock()
+	defer d.pinningMutex.Unlock()
+
+	unpinned, err := d.pin(s, msg, policy.PinLimit, policy.AutoUnpin)
+	pinned := 1
+	if err != nil {
+		pinned = 0
+		d.logger.Warnf("Pinning policy of channel %s failed for message %s: %v", msg.ChannelID, msg.ID, err)
+	} else {
+		d.logger.Infof("Pinning policy pinned message %s in channel %s (unpinned %d)", msg.ID, msg.ChannelID, unpinned)
+	}
+	if recordErr := d.pinning.Record(msg.ChannelID, pinned, unpinned, err); recordErr != nil {
+		d.logger.Warnf("Failed to save pinning policy of channel %s: %v", msg.ChannelID, recordErr)
+	}
+}
+
+// pin makes room under limit by unpinning the oldest pins, if allowed, and
+// pins the message. It returns the number of pins removed.
+func (d *EventDispatcher) pin(s *discordgo.Session, msg *discordgo.Message, limit int, autoUnpin bool) (int, error) {
+	var pins []*discordgo.Message
+	err := d.call(func() (err error) {
+		pins, err = s.ChannelMessagesPinned(msg.ChannelID)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pins: %w", err)
+	}
+
+	// Pins are listed most recently pinned first
+	unpinned := 0
+	for len(pins) >= limit {
+		if !autoUnpin {
+			return unpinned, fmt.Errorf("channel has %d pins, the policy's limit", len(pins))
+		}
+		oldest := pins[len(pins)-1]
+		err := d.call(func() error {
+			return s.ChannelMessageUnpin(msg.ChannelID, oldest.ID, discordgo.WithAuditLogReason("Pinning policy: pin limit reached"))
+		})
+		if err != nil {
+			return unpinned, fmt.Errorf("failed to unpin message %s: %w", oldest.ID, err)
+		}
+		pins = pins[:len(pins)-1]
+		unpinned++
+	}
+
+	err = d.call(func() error {
+		return s.ChannelMessagePin(msg.ChannelID, msg.ID, discordgo.WithAuditLogReason("Pinning policy"))
+	})
+	if err != nil {
+		return unpinned, fmt.Errorf("failed to pin: %w", err)
+	}
+	return unpinned, nil
+}
@@ -0,0 +1,69 @@
+package discord
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// activityTypes maps tool-facing activity names to Discord activity types
+var activityTypes = map[string]discordgo.ActivityType{
+	"playing":   discordgo.ActivityTypeGame,
+	"streaming": discordgo.ActivityTypeStreaming,
+	"listening": discordgo.ActivityTypeListening,
+	"watching":  discordgo.ActivityTypeWatching,
+	"custom":    discordgo.ActivityTypeCustom,
+	"competing": discordgo.ActivityTypeCompeting,
+}
+
+// SetPresence updates the bot's status and activity. An empty activity text
+// clears the activity. The presence is re-applied after reconnects.
+func (c *Client) SetPresence(status, activityType, text, url string) error {
+	if !c.IsConnected() {
+		return fmt.Errorf("not connected to Discord")
+	}
+
+	data := discordgo.UpdateStatusData{
+		Status:     status,
+		Activities: []*discordgo.Activity{},
+	}
+
+	if text != "" {
+		kind, ok := activityTypes[activityType]
+		if !ok {
+			return fmt.Errorf("unknown activity type: %s", activityType)
+		}
+
+		activity := &discordgo.Activity{Name: text, Type: kind, URL: url}
+		if kind == discordgo.ActivityTypeCustom {
+			// Custom statuses display the state rather than the name
+			activity.Name = "Custom Status"
+			activity.State = text
+		}
+		data.Activities = append(data.Activities, activity)
+	}
+
+	if err := c.session.UpdateStatusComplex(data); err != nil {
+		return fmt.Errorf("failed to update presence: %w", err)
+	}
+
+	c.mutex.Lock()
+	c.presence = &data
+	c.mutex.Unlock()
+	return nil
+}
+
+// restorePresence re-applies the last presence set through SetPresence,
+// since Discord resets it when a new gateway session is identified
+func (c *Client) restorePresence() {
+	c.mutex.RLock()
+	presence := c.presence
+	c.mutex.RUnlock()
+
+	if presence == nil {
+		return
+	}
+	if err := c.session.UpdateStatusComplex(*presence); err != nil {
+		c.logger.Warnf("Failed to restore presence: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package discord
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/raid"
+)
+
+// checkJoinRaid counts a member join in a guild with raid protection armed
+func (d *EventDispatcher) checkJoinRaid(s *discordgo.Session, guildID string) {
+	if d.raid == nil {
+		return
+	}
+	if incident, settings := d.raid.RecordJoin(guildID, time.Now()); incident != nil {
+		d.respondToRaid(s, incident, settings)
+	}
+}
+
+// checkMessageRaid counts a message in a guild with raid protection armed.
+// The bot's own messages are not counted.
+func (d *EventDispatcher) checkMessageRaid(s *discordgo.Session, msg *discordgo.Message) {
+	if d.raid == nil || msg.GuildID == "" {
+		return
+	}
+	if msg.Author != nil && s.State.User != nil && msg.Author.ID == s.State.User.ID {
+		return
+	}
+	if incident, settings := d.raid.RecordMessage(msg.GuildID, msg.ChannelID, time.Now()); incident != nil {
+		d.respondToRaid(s, incident, settings)
+	}
+}
+
+// respondToRaid raises slowmode on a flooded channel or pauses invites after
+// a join flood, then sends a raidSuspected notification. Protection is armed
+// explicitly, so the notification is not filtered by allowed_events.
+func (d *EventDispatcher) respondToRaid(s *discordgo.Session, incident *raid.Incident, settings raid.Settings) {
+	d.logger.Warnf("Raid suspected in guild %s: %d %s events in %ds", incident.GuildID, incident.Count, incident.Kind, incident.WindowSeconds)
+
+	actions := []raid.Action{}
+	switch {
+	case incident.Kind == raid.KindMessageRate && settings.SlowmodeSeconds > 0:
+		if action, ok := d.raidSlowmode(s, incident.ChannelID, settings.SlowmodeSeconds); ok {
+			actions = append(actions, action)
+		}
+	case incident.Kind == raid.KindJoinRate && settings.PauseInvites:
+		if action, ok := d.raidPauseInvites(s, incident.GuildID); ok {
+			actions = append(actions, action)
+		}
+	}
+	d.raid.SetActions(incident.ID, actions)
+
+	params := map[string]interface{}{
+		"incident_id":    incident.ID,
+		"guild_id":       incident.GuildID,
+		"kind":           incident.Kind,
+		"count":          incident.Count,
+		"window_seconds": incident.WindowSeconds,
+		"actions":        actions,
+	}
+	if incident.ChannelID != "" {
+		params["channel_id"] = incident.ChannelID
+	}
+
+	d.send("discord/raidSuspected", params)
+}
+
+// raidSlowmode raises a channel's slowmode, reporting false when it is
+// already at least that slow
+func (d *EventDispatcher) raidSlowmode(s *discordgo.Session, channelID string, seconds int) (raid.Action, bool) {
+	action := raid.Action{Type: raid.ActionSlowmode, ChannelID: channelID}
+
+	channel, err := s.State.Channel(channelID)
+	if err != nil {
+		err = d.call(func() (err error) {
+			channel, err = s.Channel(channelID)
+			return err
+		})
+	}
+	if err != nil {
+		action.Error = err.Error()
+		return action, true
+	}
+	if channel.RateLimitPerUser >= seconds {
+		return action, false
+	}
+	action.Previous = channel.RateLimitPerUser
+
+	err = d.call(func() error {
+		_, err := s.ChannelEditComplex(channelID, &discordgo.ChannelEdit{RateLimitPerUser: &seconds},
+			discordgo.WithAuditLogReason("Raid protection: raid detected, enabling slowmode"))
+		return err
+	})
+	if err != nil {
+		d.logger.Warnf("Failed to enable slowmode in channel %s: %v", channelID, err)
+		action.Error = err.Error()
+		return action, true
+	}
+	action.Success = true
+	return action, true
+}
+
+// raidPauseInvites disables invites to a guild, reporting false when they
+// are already paused
+func (d *EventDispatcher) raidPauseInvites(s *discordgo.Session, guildID string) (raid.Action, bool) {
+	action := raid.Action{Type: raid.ActionPauseInvites}
+
+	guild, err := s.State.Guild(guildID)
+	if err != nil {
+		action.Error = err.Error()
+		return action, true
+	}
+	features := make([]discordgo.GuildFeature, 0, len(guild.Features)+1)
+	for _, feature := range guild.Features {
+		if feature == discordgo.GuildFeatureInvitesDisabled {
+			return action, false
+		}
+		features = append(features, feature)
+	}
+	features = append(features, discordgo.GuildFeatureInvitesDisabled)
+
+	err = d.call(func() error {
+		_, err := s.GuildEdit(guildID, &discordgo.GuildParams{Features: features},
+			discordgo.WithAuditLogReason("Raid protection: raid detected, pausing invites"))
+		return err
+	})
+	if err != nil {
+		d.logger.Warnf("Failed to pause invites in guild %s: %v", guildID, err)
+		action.Error = err.Error()
+		return action, true
+	}
+	action.Success = true
+	return action, true
+}
@@ -0,0 +1,33 @@
+package discord
+
+import (
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/schedule"
+	"discord-mcp/internal/templates"
+)
+
+// sendRecurringPost sends one run of a recurring post. Templates are loaded
+// at send time so edits to the template file apply to later runs.
+func (c *Client) sendRecurringPost(post schedule.Post) error {
+	msg := &discordgo.MessageSend{Content: post.Content}
+	if post.Template != "" {
+		tmpl, err := templates.NewStore(c.config.Templates.Directory).Get(post.Template)
+		if err != nil {
+			return err
+		}
+		rendered, err := tmpl.Render(post.Variables)
+		if err != nil {
+			return err
+		}
+		msg.Content = rendered.Content
+		msg.Embeds = rendered.Embeds
+	}
+
+	_, _, err := c.SendQueued(post.ChannelID, outbound.PriorityBulk, func() error {
+		_, err := c.session.ChannelMessageSendComplex(post.ChannelID, msg)
+		return err
+	})
+	return err
+}
@@ -0,0 +1,97 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/relay"
+	"discord-mcp/internal/templates"
+	"discord-mcp/internal/textsplit"
+)
+
+// RelayURL returns the URL other services post payloads to for a relay
+func (c *Client) RelayURL(r relay.Relay) string {
+	base := strings.TrimSuffix(c.config.Relay.PublicURL, "/")
+	if base == "" {
+		base = "http://" + c.config.Relay.Listen
+	}
+	return base + "/relay/" + r.Token
+}
+
+// postRelay posts a payload received by a relay to its channel. Templates
+// are loaded at post time so edits to the template file apply to later
+// payloads; placeholders the payload does not fill render empty.
+func (c *Client) postRelay(r relay.Relay, values map[string]string, payload []byte) error {
+	msg := &discordgo.MessageSend{AllowedMentions: &discordgo.MessageAllowedMentions{}}
+	switch {
+	case r.Template != "" || r.Content != "":
+		tmpl := &templates.Template{Content: r.Content}
+		if r.Template != "" {
+			var err error
+			tmpl, err = templates.NewStore(c.config.Templates.Directory).Get(r.Template)
+			if err != nil {
+				return err
+			}
+		}
+		for _, name := range tmpl.Placeholders() {
+			if _, ok := values[name]; !ok {
+				values[name] = ""
+			}
+		}
+		rendered, err := tmpl.Render(values)
+		if err != nil {
+			return err
+		}
+		msg.Content = rendered.Content
+		msg.Embeds = rendered.Embeds
+	default:
+		msg.Content = c.relayCodeBlock(r, payload)
+	}
+	if strings.TrimSpace(msg.Content) == "" && len(msg.Embeds) == 0 {
+		return fmt.Errorf("relay %s rendered an empty message", r.ID)
+	}
+
+	parts := textsplit.Message(msg.Content, c.config.Discord.MaxMessageLength)
+	if len(parts) == 0 {
+		parts = []string{""}
+	}
+	for i, part := range parts {
+		data := *msg
+		data.Content = part
+		if i < len(parts)-1 {
+			data.Embeds = nil
+		}
+		_, _, err := c.SendQueued(r.ChannelID, outbound.PriorityNormal, func() error {
+			_, err := c.session.ChannelMessageSendComplex(r.ChannelID, &data)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// relayCodeBlock formats a payload posted to a relay without a template as
+// an indented JSON code block, cut to fit in one message
+func (c *Client) relayCodeBlock(r relay.Relay, payload []byte) string {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, payload, "", "  "); err != nil {
+		indented.Reset()
+		indented.Write(payload)
+	}
+	body := strings.ReplaceAll(indented.String(), "```", "`\u200b``")
+
+	header := fmt.Sprintf("**%s**\n```json\n", r.Name)
+	footer := "\n```"
+	room := c.config.Discord.MaxMessageLength - len([]rune(header)) - len([]rune(footer))
+	if runes := []rune(body); len(runes) > room {
+		body = string(runes[:max(room-2, 0)]) + "\n…"
+	}
+	return header + body + footer
+}
@@ -0,0 +1,163 @@
+package discord
+
+import (
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// retryPolicy describes how transient Discord REST failures are retried
+type retryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	budget     time.Duration
+}
+
+// Retry runs fn, retrying rate limits (respecting Retry-After) and the
+// failures isTransientError accepts with jittered exponential backoff. It
+// returns the number of retries performed along with the final error, if any.
+func (c *Client) Retry(fn func() error) (int, error) {
+	policy := c.retryPolicy
+	deadline := time.Now().Add(policy.budget)
+
+	retries := 0
+	for {
+		err := fn()
+		if err == nil {
+			return retries, nil
+		}
+
+		delay, retryable := policy.delayFor(err, retries)
+		if !retryable || retries >= policy.maxRetries {
+			return retries, err
+		}
+
+		if time.Now().Add(delay).After(deadline) {
+			c.logger.Debugf("Retry budget exhausted after %d retries: %v", retries, err)
+			return retries, err
+		}
+
+		if rateLimitError(err) != nil {
+			c.rateLimitWaits.add(delay)
+		}
+		retries++
+		c.logger.Debugf("Transient Discord error, retry %d/%d in %v: %v", retries, policy.maxRetries, delay, err)
+		time.Sleep(delay)
+	}
+}
+
+// rateLimitWaits counts the waits for Discord rate limits
+type rateLimitWaits struct {
+	mutex  sync.Mutex
+	count  int64
+	waited time.Duration
+}
+
+// add records a wait
+func (w *rateLimitWaits) add(delay time.Duration) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.count++
+	w.waited += delay
+}
+
+// rateLimitError returns err as a Discord 429 response, or nil if it is not
+// one
+func rateLimitError(err error) *discordgo.RateLimitError {
+	var rateLimitErr *discordgo.RateLimitError
+	if errors.As(err, &rateLimitErr) && rateLimitErr.RateLimit != nil && rateLimitErr.TooManyRequests != nil {
+		return rateLimitErr
+	}
+	return nil
+}
+
+// delayFor returns how long to wait before retrying err, and whether err is retryable at all
+func (p retryPolicy) delayFor(err error, attempt int) (time.Duration, bool) {
+	if rateLimitErr := rateLimitError(err); rateLimitErr != nil {
+		return rateLimitErr.RetryAfter, true
+	}
+
+	if !isTransientError(err) {
+		return 0, false
+	}
+
+	return p.backoff(attempt), true
+}
+
+// backoff computes a jittered exponential delay for the given attempt
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	delay := p.baseDelay << uint(attempt)
+	if delay <= 0 || delay > p.maxDelay {
+		delay = p.maxDelay
+	}
+
+	// Full jitter in the upper half of the window keeps retries spread out
+	// without collapsing to zero delay
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay
+	}
+	return time.Duration(half + rand.Int63n(half))
+}
+
+// isTransientError reports whether err is worth retrying. Reads are retried
+// on 5xx responses and network failures. A write may already have taken
+// effect when its response is lost, so writes are only retried when the
+// connection could not be established.
+func isTransientError(err error) bool {
+	var restErr *discordgo.RESTError
+	if errors.As(err, &restErr) {
+		if restErr.Response == nil || restErr.Response.StatusCode < 500 {
+			return false
+		}
+		return restErr.Request != nil && isReadMethod(restErr.Request.Method)
+	}
+
+	if isConnectError(err) {
+		return true
+	}
+
+	// net/http names the request method in the operation, such as "Get"
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return isReadMethod(urlErr.Op)
+	}
+
+	// Other network errors and the plain error discordgo returns after its
+	// own 502 retries do not say whether the request was a read
+	return false
+}
+
+// isConnectError reports whether err happened before a request was sent
+func isConnectError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isReadMethod reports whether an HTTP method only reads
+func isReadMethod(method string) bool {
+	return strings.EqualFold(method, http.MethodGet) || strings.EqualFold(method, http.MethodHead)
+}
+
+// newRetryPolicy builds a retry policy from millisecond config values
+func newRetryPolicy(maxRetries, baseDelayMs, maxDelayMs, budgetMs int) retryPolicy {
+	return retryPolicy{
+		maxRetries: maxRetries,
+		baseDelay:  time.Duration(baseDelayMs) * time.Millisecond,
+		maxDelay:   time.Duration(maxDelayMs) * time.Millisecond,
+		budget:     time.Duration(budgetMs) * time.Millisecond,
+	}
+}
@@ -0,0 +1,72 @@
+package discord
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// restError builds the error discordgo returns for a response status
+func restError(method string, status int) error {
+	return &discordgo.RESTError{
+		Request:  &http.Request{Method: method},
+		Response: &http.Response{StatusCode: status},
+	}
+}
+
+// urlError builds the error net/http returns for a failed request; op is
+// the method as net/http spells it, such as "Get"
+func urlError(op string, err error) error {
+	return &url.Error{Op: op, URL: "https://discord.com/api/v9/channels/1/messages", Err: err}
+}
+
+func TestIsTransientError(t *testing.T) {
+	dial := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	dns := &net.DNSError{Err: "no such host", Name: "discord.com"}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "GET 5xx", err: restError(http.MethodGet, http.StatusServiceUnavailable), want: true},
+		{name: "POST 5xx", err: restError(http.MethodPost, http.StatusInternalServerError), want: false},
+		{name: "PATCH 5xx", err: restError(http.MethodPatch, http.StatusBadGateway), want: false},
+		{name: "GET 4xx", err: restError(http.MethodGet, http.StatusNotFound), want: false},
+		{name: "GET reset", err: urlError("Get", reset), want: true},
+		{name: "POST reset", err: urlError("Post", reset), want: false},
+		{name: "POST timeout", err: urlError("Post", errors.New("context deadline exceeded")), want: false},
+		{name: "POST refused", err: urlError("Post", dial), want: true},
+		{name: "DELETE unresolved host", err: urlError("Delete", dns), want: true},
+		{name: "bare read error", err: reset, want: false},
+		{name: "bare dial error", err: dial, want: true},
+		{name: "exhausted 502 retries", err: errors.New("Exceeded Max retries HTTP 502 Bad Gateway, "), want: false},
+		{name: "other error", err: errors.New("invalid emoji"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientError(tt.err); got != tt.want {
+				t.Errorf("isTransientError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDelayForRateLimit(t *testing.T) {
+	policy := newRetryPolicy(3, 500, 10000, 30000)
+	err := &discordgo.RateLimitError{RateLimit: &discordgo.RateLimit{
+		TooManyRequests: &discordgo.TooManyRequests{RetryAfter: 2 * time.Second},
+	}}
+
+	delay, retryable := policy.delayFor(err, 0)
+	if !retryable || delay != 2*time.Second {
+		t.Errorf("delayFor(429) = %v, %v, want 2s, true", delay, retryable)
+	}
+}
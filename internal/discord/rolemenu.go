@@ -0,0 +1,90 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// applyRoleMenu gives a member the roles they selected in a role menu and
+// removes the menu's other roles they hold, then tells them what changed
+func (d *EventDispatcher) applyRoleMenu(s *discordgo.Session, i *discordgo.Interaction) {
+	if i.Member == nil || i.Member.User == nil {
+		return
+	}
+	data := i.MessageComponentData()
+	if d.roleMenus == nil {
+		d.respondEphemeral(s, i, "Role menus are turned off.")
+		return
+	}
+	menu, ok := d.roleMenus.ByCustomID(data.CustomID)
+	if !ok {
+		d.respondEphemeral(s, i, "This role menu is no longer active.")
+		return
+	}
+
+	// Role changes may wait on rate limits, longer than Discord allows
+	// before an interaction must be acknowledged
+	err := s.InteractionRespond(i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	})
+	if err != nil {
+		d.logger.Warnf("Failed to acknowledge role menu %s: %v", menu.ID, err)
+		return
+	}
+
+	add, remove := menu.Changes(data.Values, i.Member.Roles)
+	reason := discordgo.WithAuditLogReason("Role menu " + menu.ID)
+	var added, removed, failed []string
+	for _, roleID := range add {
+		err := d.call(func() error {
+			return s.GuildMemberRoleAdd(i.GuildID, i.Member.User.ID, roleID, reason)
+		})
+		if err != nil {
+			d.logger.Warnf("Role menu %s failed to assign role %s to %s: %v", menu.ID, roleID, i.Member.User.ID, err)
+			failed = append(failed, "<@&"+roleID+">")
+			continue
+		}
+		added = append(added, "<@&"+roleID+">")
+	}
+	for _, roleID := range remove {
+		err := d.call(func() error {
+			return s.GuildMemberRoleRemove(i.GuildID, i.Member.User.ID, roleID, reason)
+		})
+		if err != nil {
+			d.logger.Warnf("Role menu %s failed to remove role %s from %s: %v", menu.ID, roleID, i.Member.User.ID, err)
+			failed = append(failed, "<@&"+roleID+">")
+			continue
+		}
+		removed = append(removed, "<@&"+roleID+">")
+	}
+
+	if err := d.roleMenus.Record(menu.ID, len(added), len(removed)); err != nil {
+		d.logger.Warnf("Failed to save role menu %s: %v", menu.ID, err)
+	}
+	d.logger.Infof("Role menu %s: %s gained %d and lost %d roles", menu.ID, i.Member.User.ID, len(added), len(removed))
+
+	var lines []string
+	if len(added) > 0 {
+		lines = append(lines, "Added "+strings.Join(added, ", "))
+	}
+	if len(removed) > 0 {
+		lines = append(lines, "Removed "+strings.Join(removed, ", "))
+	}
+	if len(failed) > 0 {
+		lines = append(lines, fmt.Sprintf("Could not update %s; please ask a moderator", strings.Join(failed, ", ")))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "Your roles already match your selection.")
+	}
+	content := strings.Join(lines, "\n")
+	_, err = s.InteractionResponseEdit(i, &discordgo.WebhookEdit{
+		Content:         &content,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		d.logger.Warnf("Failed to answer role menu %s: %v", menu.ID, err)
+	}
+}
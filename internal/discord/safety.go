@@ -0,0 +1,105 @@
+package discord
+
+import (
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/safety"
+)
+
+// Actions taken on unsafe content
+const (
+	safetyActionReported = "reported"
+	safetyActionDeleted  = "deleted"
+	safetyActionBlocked  = "blocked"
+)
+
+// checkSafety scans the links and attachments of a message from another
+// user, deleting the message when delete_inbound is set, and announces
+// unsafe content. The bot's own messages are checked before they are sent.
+func (d *EventDispatcher) checkSafety(s *discordgo.Session, msg *discordgo.Message) {
+	if d.safety == nil || msg.Author == nil {
+		return
+	}
+	if s.State.User != nil && msg.Author.ID == s.State.User.ID {
+		return
+	}
+
+	result := d.safety.Scan(msg.Content, msg.Embeds, msg.Attachments)
+	for scanner, err := range result.Errors {
+		d.logger.Warnf("Safety scanner %s failed on message %s: %s", scanner, msg.ID, err)
+	}
+	if !result.Unsafe() {
+		return
+	}
+
+	action := safetyActionReported
+	var deleteErr error
+	if d.safetyDelete {
+		deleteErr = d.call(func() error {
+			return s.ChannelMessageDelete(msg.ChannelID, msg.ID)
+		})
+		if deleteErr != nil {
+			d.logger.Warnf("Failed to delete unsafe message %s: %v", msg.ID, deleteErr)
+		} else {
+			action = safetyActionDeleted
+		}
+	}
+
+	params := map[string]interface{}{
+		"direction":       safety.DirectionInbound,
+		"guild_id":        msg.GuildID,
+		"channel_id":      msg.ChannelID,
+		"message_id":      msg.ID,
+		"author_id":       msg.Author.ID,
+		"author_username": msg.Author.Username,
+		"findings":        result.Findings,
+		"action":          action,
+	}
+	if deleteErr != nil {
+		params["delete_error"] = deleteErr.Error()
+	}
+	d.NotifyUnsafeContent(params)
+}
+
+// NotifyUnsafeContent announces a message a safety scanner flagged. Safety
+// scanning is configured explicitly, so it is not filtered by
+// allowed_events.
+func (d *EventDispatcher) NotifyUnsafeContent(params map[string]interface{}) {
+	d.send("discord/unsafeContentDetected", params)
+}
+
+// CheckOutbound scans a message the bot is about to send to a channel. It
+// announces unsafe content and reports whether block_outbound forbids
+// sending it.
+func (c *Client) CheckOutbound(channelID, content string, embeds []*discordgo.MessageEmbed) (safety.Result, bool) {
+	if c.safety == nil || !c.config.Safety.ScanOutbound {
+		return safety.Result{}, false
+	}
+
+	result := c.safety.Scan(content, embeds, nil)
+	for scanner, err := range result.Errors {
+		c.logger.Warnf("Safety scanner %s failed on an outbound message: %s", scanner, err)
+	}
+	if !result.Unsafe() {
+		return result, false
+	}
+
+	blocked := c.config.Safety.BlockOutbound
+	action := safetyActionReported
+	if blocked {
+		action = safetyActionBlocked
+	}
+	if c.dispatcher != nil {
+		params := map[string]interface{}{
+			"direction":  safety.DirectionOutbound,
+			"channel_id": channelID,
+			"findings":   result.Findings,
+			"action":     action,
+		}
+		if channel, err := c.GetChannel(channelID); err == nil {
+			params["guild_id"] = channel.GuildID
+		}
+		c.dispatcher.NotifyUnsafeContent(params)
+	}
+	return result, blocked
+}
@@ -0,0 +1,149 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/starboard"
+)
+
+// starboardColor is the embed color of starboard reposts
+const starboardColor = 0xFFAC33
+
+// updateStarboard reposts a message to its guild's starboard once it
+// reaches the threshold, and keeps the star count of earlier reposts current
+// as reactions are added and removed. Reposts are sent directly, without a
+// notification.
+func (d *EventDispatcher) updateStarboard(s *discordgo.Session, reaction *discordgo.MessageReaction, added bool) {
+	if d.starboard == nil || reaction.GuildID == "" {
+		return
+	}
+	board, ok := d.starboard.Board(reaction.GuildID)
+	if !ok || !board.Enabled || reaction.ChannelID == board.ChannelID || !starboardEmoji(&reaction.Emoji, board) {
+		return
+	}
+
+	// Reactions arrive concurrently; handling one at a time keeps a burst of
+	// stars from posting the same message twice
+	d.starboardMutex.Lock()
+	defer d.starboardMutex.Unlock()
+
+	entry, reposted := d.starboard.Entry(reaction.MessageID)
+	if !reposted && !added {
+		return
+	}
+
+	var msg *discordgo.Message
+	err := d.call(func() (err error) {
+		msg, err = s.ChannelMessage(reaction.ChannelID, reaction.MessageID)
+		return err
+	})
+	if err != nil {
+		d.logger.Warnf("Starboard failed to get message %s: %v", reaction.MessageID, err)
+		return
+	}
+	stars := 0
+	for _, r := range msg.Reactions {
+		if starboardEmoji(r.Emoji, board) {
+			stars = r.Count
+		}
+	}
+
+	if !reposted {
+		if stars < board.Threshold || msg.Author == nil {
+			return
+		}
+		var repost *discordgo.Message
+		err := d.enqueue(board.ChannelID, outbound.PriorityNormal, func() (err error) {
+			repost, err = s.ChannelMessageSendComplex(board.ChannelID, &discordgo.MessageSend{
+				Content: starboardHeader(board, stars, msg.ChannelID),
+				Embeds:  []*discordgo.MessageEmbed{d.starboardEmbed(reaction.GuildID, msg)},
+				// The repost quotes the message without pinging anyone again
+				AllowedMentions: &discordgo.MessageAllowedMentions{},
+			})
+			return err
+		})
+		if err != nil {
+			d.logger.Warnf("Starboard failed to repost message %s: %v", msg.ID, err)
+			return
+		}
+		entry = starboard.Entry{
+			GuildID:            reaction.GuildID,
+			ChannelID:          msg.ChannelID,
+			MessageID:          msg.ID,
+			AuthorID:           msg.Author.ID,
+			StarboardMessageID: repost.ID,
+		}
+	} else {
+		if stars == entry.Stars {
+			return
+		}
+		content := starboardHeader(board, stars, msg.ChannelID)
+		err := d.call(func() error {
+			_, err := s.ChannelMessageEdit(board.ChannelID, entry.StarboardMessageID, content)
+			return err
+		})
+		if err != nil {
+			d.logger.Warnf("Starboard failed to update the repost of message %s: %v", msg.ID, err)
+			return
+		}
+	}
+
+	entry.Stars = stars
+	if err := d.starboard.SaveEntry(entry); err != nil {
+		d.logger.Warn(err)
+	}
+}
+
+// starboardEmbed quotes a message with its author, first image and a link
+// back to the original
+func (d *EventDispatcher) starboardEmbed(guildID string, msg *discordgo.Message) *discordgo.MessageEmbed {
+	description := msg.Content
+	if runes := []rune(description); len(runes) > 4000 {
+		description = string(runes[:4000]) + "…"
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Author: &discordgo.MessageEmbedAuthor{
+			Name:    msg.Author.Username,
+			IconURL: d.cdn.UserAvatar(msg.Author),
+		},
+		Description: description,
+		Color:       starboardColor,
+		Fields: []*discordgo.MessageEmbedField{{
+			Name:  "Source",
+			Value: fmt.Sprintf("[Jump to message](https://discord.com/channels/%s/%s/%s)", guildID, msg.ChannelID, msg.ID),
+		}},
+		Footer:    &discordgo.MessageEmbedFooter{Text: msg.ID},
+		Timestamp: msg.Timestamp.Format(time.RFC3339),
+	}
+	for _, att := range msg.Attachments {
+		if strings.HasPrefix(att.ContentType, "image/") {
+			embed.Image = &discordgo.MessageEmbedImage{URL: att.URL}
+			break
+		}
+	}
+	return embed
+}
+
+// starboardHeader is the repost text carrying the star count and source
+// channel
+func starboardHeader(board starboard.Board, stars int, channelID string) string {
+	return fmt.Sprintf("%s **%d** <#%s>", board.Emoji, stars, channelID)
+}
+
+// starboardEmoji reports whether a reaction uses the board's emoji. Custom
+// emoji are compared by ID so renaming one does not break the board.
+func starboardEmoji(emoji *discordgo.Emoji, board starboard.Board) bool {
+	if emoji == nil {
+		return false
+	}
+	if emoji.ID != "" {
+		return strings.HasSuffix(board.EmojiAPIName, ":"+emoji.ID)
+	}
+	return emoji.Name == board.EmojiAPIName
+}
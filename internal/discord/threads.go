@@ -0,0 +1,127 @@
+package discord
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/config"
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/textsplit"
+	"discord-mcp/internal/threads"
+)
+
+// participantScanSize is how many recent messages are read to find a
+// thread's participants
+const participantScanSize = 50
+
+// StaleThreads lists a guild's active threads that auto-archive within the
+// window, soonest first
+func (c *Client) StaleThreads(guildID string, within time.Duration) ([]threads.Stale, error) {
+	var list *discordgo.ThreadsList
+	_, err := c.Retry(func() (err error) {
+		list, err = c.session.GuildThreadsActive(guildID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stale := threads.FindStale(list.Threads, time.Now(), within)
+	for i := range stale {
+		if parent, err := c.GetChannel(stale[i].ParentID); err == nil {
+			stale[i].ParentName = parent.Name
+		}
+	}
+	return stale, nil
+}
+
+// ThreadParticipants returns the members who posted most recently in a
+// thread
+func (c *Client) ThreadParticipants(threadID string) ([]threads.Participant, error) {
+	var messages []*discordgo.Message
+	_, err := c.Retry(func() (err error) {
+		messages, err = c.session.ChannelMessages(threadID, participantScanSize, "", "", "")
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return threads.Participants(messages), nil
+}
+
+// ThreadDigest builds the digest of a guild's threads that auto-archive
+// within windowHours, with the participants of the listed threads
+func (c *Client) ThreadDigest(guildID string, windowHours int) (threads.Digest, error) {
+	stale, err := c.StaleThreads(guildID, time.Duration(windowHours)*time.Hour)
+	if err != nil {
+		return threads.Digest{}, err
+	}
+
+	digest := threads.Digest{
+		GuildID:     guildID,
+		GeneratedAt: time.Now().UTC(),
+		WindowHours: windowHours,
+		Total:       len(stale),
+	}
+	if len(stale) > threads.MaxDigestThreads {
+		stale = stale[:threads.MaxDigestThreads]
+	}
+	for i := range stale {
+		participants, err := c.ThreadParticipants(stale[i].ThreadID)
+		if err != nil {
+			c.logger.Debugf("Failed to read participants of thread %s: %v", stale[i].ThreadID, err)
+			continue
+		}
+		stale[i].Participants = participants
+	}
+	digest.Threads = stale
+	return digest, nil
+}
+
+// runThreadDigests builds the digest of every configured guild, posting it
+// to the guild's digest channel and announcing it with a notification.
+// Guilds with no thread about to archive are skipped.
+func (c *Client) runThreadDigests() {
+	cfg := c.config.ThreadDigest
+	for _, guild := range cfg.Guilds {
+		digest, err := c.ThreadDigest(guild.GuildID, cfg.WindowHours)
+		if err != nil {
+			c.logger.Warnf("Failed to build thread digest for guild %s: %v", guild.GuildID, err)
+			continue
+		}
+		if digest.Total == 0 {
+			continue
+		}
+
+		var messageIDs []string
+		if guild.ChannelID != "" {
+			messageIDs = c.postThreadDigest(guild, digest)
+		}
+		if c.dispatcher != nil {
+			c.dispatcher.NotifyThreadDigest(digest, guild.ChannelID, messageIDs)
+		}
+	}
+}
+
+// postThreadDigest sends a digest to a guild's digest channel, split across
+// messages when it is long, and returns the IDs of the messages sent
+func (c *Client) postThreadDigest(guild config.ThreadDigestGuildConfig, digest threads.Digest) []string {
+	var messageIDs []string
+	for _, part := range textsplit.Message(digest.Render(), c.config.Discord.MaxMessageLength) {
+		var msg *discordgo.Message
+		_, _, err := c.SendQueued(guild.ChannelID, outbound.PriorityBulk, func() (err error) {
+			msg, err = c.session.ChannelMessageSendComplex(guild.ChannelID, &discordgo.MessageSend{
+				Content:         part,
+				AllowedMentions: &discordgo.MessageAllowedMentions{},
+			})
+			return err
+		})
+		if err != nil {
+			c.logger.Warnf("Failed to post thread digest to channel %s: %v", guild.ChannelID, err)
+			break
+		}
+		messageIDs = append(messageIDs, msg.ID)
+	}
+	return messageIDs
+}
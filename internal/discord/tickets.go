@@ -0,0 +1,51 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/textsplit"
+)
+
+// relayTicketMessage posts a member's direct message to the bot into their
+// open ticket, so staff reading the ticket see the whole conversation
+func (d *EventDispatcher) relayTicketMessage(s *discordgo.Session, msg *discordgo.Message) {
+	if d.tickets == nil || msg.GuildID != "" || msg.Author == nil || msg.Author.Bot {
+		return
+	}
+	ticket, ok := d.tickets.ForUser(msg.Author.ID)
+	if !ok {
+		return
+	}
+
+	lines := []string{fmt.Sprintf("**%s**: %s", msg.Author.Username, msg.Content)}
+	for _, attachment := range msg.Attachments {
+		lines = append(lines, attachment.URL)
+	}
+	limit := d.maxMessageLength
+	if limit <= 0 {
+		limit = 2000
+	}
+
+	for _, part := range textsplit.Message(strings.Join(lines, "\n"), limit) {
+		err := d.enqueue(ticket.ChannelID, outbound.PriorityNormal, func() error {
+			// Relayed text must not ping anyone in the guild
+			_, err := s.ChannelMessageSendComplex(ticket.ChannelID, &discordgo.MessageSend{
+				Content:         part,
+				AllowedMentions: &discordgo.MessageAllowedMentions{},
+			})
+			return err
+		})
+		if err != nil {
+			d.logger.Warnf("Failed to relay direct message %s into ticket %s: %v", msg.ID, ticket.ID, err)
+			return
+		}
+	}
+
+	if err := d.tickets.RecordMessage(ticket.ID, msg.Timestamp); err != nil {
+		d.logger.Warnf("Failed to save ticket %s: %v", ticket.ID, err)
+	}
+}
@@ -0,0 +1,39 @@
+package discord
+
+import (
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/telemetry"
+	"discord-mcp/internal/trace"
+)
+
+// Tracer returns the recorder of REST exchanges and gateway events
+func (c *Client) Tracer() *trace.Recorder {
+	return c.tracer
+}
+
+// Telemetry returns the OpenTelemetry tracer, or nil if telemetry is
+// disabled
+func (c *Client) Telemetry() *telemetry.Tracer {
+	return c.telemetry
+}
+
+// traceEvent records a gateway event while tracing is on
+func (c *Client) traceEvent(s *discordgo.Session, e *discordgo.Event) {
+	if !c.tracer.Enabled() {
+		return
+	}
+	c.traceError(c.tracer.Record(trace.Entry{
+		Kind:     trace.KindGateway,
+		Event:    e.Type,
+		Sequence: e.Sequence,
+		Response: string(e.RawData),
+	}))
+}
+
+// traceError logs a trace file error
+func (c *Client) traceError(err error) {
+	if err != nil {
+		c.logger.Warnf("Tracing: %v", err)
+	}
+}
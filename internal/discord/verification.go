@@ -0,0 +1,202 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/verification"
+)
+
+// gateMember gives a new member of a guild with an enabled verification gate
+// the unverified role and records them as awaiting verification
+func (d *EventDispatcher) gateMember(s *discordgo.Session, member *discordgo.Member) {
+	if d.verification == nil || member == nil || member.User == nil || member.User.Bot {
+		return
+	}
+	settings, ok := d.verification.Settings(member.GuildID)
+	if !ok || !settings.Enabled {
+		return
+	}
+
+	err := d.call(func() error {
+		return s.GuildMemberRoleAdd(member.GuildID, member.User.ID, settings.UnverifiedRoleID,
+			discordgo.WithAuditLogReason("Awaiting verification"))
+	})
+	if err != nil {
+		d.logger.Warnf("Failed to give unverified role to %s in guild %s: %v", member.User.ID, member.GuildID, err)
+		return
+	}
+
+	joinedAt := member.JoinedAt
+	if joinedAt.IsZero() {
+		joinedAt = time.Now()
+	}
+	if _, err := d.verification.AddPending(member.GuildID, member.User.ID, joinedAt); err != nil {
+		d.logger.Warnf("Failed to save unverified member %s: %v", member.User.ID, err)
+	}
+}
+
+// forgetUnverified stops waiting on a member who left before verifying
+func (d *EventDispatcher) forgetUnverified(guildID string, user *discordgo.User) {
+	if d.verification == nil || user == nil {
+		return
+	}
+	if _, err := d.verification.RemovePending(guildID, user.ID); err != nil {
+		d.logger.Warnf("Failed to save verification after %s left: %v", user.ID, err)
+	}
+}
+
+// verifyFromButton lifts the verification gate for a member who pressed a
+// guild's verify button
+func (d *EventDispatcher) verifyFromButton(s *discordgo.Session, i *discordgo.Interaction) {
+	if i.Member == nil || i.Member.User == nil {
+		return
+	}
+	if d.verification == nil {
+		d.respondEphemeral(s, i, "Verification is turned off.")
+		return
+	}
+	guildID, _ := verification.GuildFromCustomID(i.MessageComponentData().CustomID)
+	settings, ok := d.verification.Settings(guildID)
+	if !ok || !settings.Enabled || guildID != i.GuildID {
+		d.respondEphemeral(s, i, "This verify button is no longer active.")
+		return
+	}
+	if !hasRole(i.Member.Roles, settings.UnverifiedRoleID) &&
+		(settings.MemberRoleID == "" || hasRole(i.Member.Roles, settings.MemberRoleID)) {
+		d.respondEphemeral(s, i, "You are already verified.")
+		return
+	}
+
+	// Role changes may wait on rate limits, longer than Discord allows
+	// before an interaction must be acknowledged
+	err := s.InteractionRespond(i, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	})
+	if err != nil {
+		d.logger.Warnf("Failed to acknowledge verify button in guild %s: %v", guildID, err)
+		return
+	}
+
+	content := "You are verified. Welcome!"
+	if _, err := liftGate(s, d.call, d.verification, settings, i.Member.User.ID, "Verified with the verify button"); err != nil {
+		d.logger.Warnf("Failed to verify %s in guild %s: %v", i.Member.User.ID, guildID, err)
+		content = "Verification failed; please ask a moderator."
+	}
+	_, err = s.InteractionResponseEdit(i, &discordgo.WebhookEdit{
+		Content:         &content,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	if err != nil {
+		d.logger.Warnf("Failed to answer verify button in guild %s: %v", guildID, err)
+	}
+}
+
+// VerifyMember lifts a guild's verification gate for a member, returning
+// whether they were awaiting verification
+func (c *Client) VerifyMember(settings verification.Settings, userID, reason string) (bool, error) {
+	call := func(fn func() error) error {
+		_, err := c.Retry(fn)
+		return err
+	}
+	return liftGate(c.session, call, c.verification, settings, userID, reason)
+}
+
+// kickUnverified kicks the members still unverified when their guild's
+// deadline passes. Members who left or lost the unverified role some other
+// way are forgotten instead.
+func (c *Client) kickUnverified() {
+	for _, pending := range c.verification.Due(time.Now()) {
+		settings, ok := c.verification.Settings(pending.GuildID)
+		if !ok {
+			continue
+		}
+
+		var member *discordgo.Member
+		_, err := c.Retry(func() (err error) {
+			member, err = c.session.GuildMember(pending.GuildID, pending.UserID)
+			return err
+		})
+		if err != nil {
+			c.logger.Warnf("Failed to look up unverified member %s in guild %s: %v", pending.UserID, pending.GuildID, err)
+			if !ClassifyError(err).Retryable {
+				c.forgetPending(pending)
+			}
+			continue
+		}
+		if !hasRole(member.Roles, settings.UnverifiedRoleID) {
+			c.forgetPending(pending)
+			continue
+		}
+
+		reason := fmt.Sprintf("Not verified within %d minutes of joining", settings.KickAfterMinutes)
+		_, err = c.Retry(func() error {
+			return c.session.GuildMemberDelete(pending.GuildID, pending.UserID, discordgo.WithAuditLogReason(reason))
+		})
+		if err != nil {
+			c.logger.Warnf("Failed to kick unverified member %s from guild %s: %v", pending.UserID, pending.GuildID, err)
+			// Retry on the next sweep unless the kick can never succeed,
+			// such as when the bot lacks permission
+			if !ClassifyError(err).Retryable {
+				c.forgetPending(pending)
+			}
+			continue
+		}
+
+		c.logger.Infof("Kicked unverified member %s from guild %s", pending.UserID, pending.GuildID)
+		c.forgetPending(pending)
+		if err := c.verification.Record(pending.GuildID, 0, 1); err != nil {
+			c.logger.Warnf("Failed to save verification of guild %s: %v", pending.GuildID, err)
+		}
+	}
+}
+
+// forgetPending stops waiting on a member's verification
+func (c *Client) forgetPending(pending verification.Pending) {
+	if _, err := c.verification.RemovePending(pending.GuildID, pending.UserID); err != nil {
+		c.logger.Warnf("Failed to save verification of guild %s: %v", pending.GuildID, err)
+	}
+}
+
+// liftGate removes a guild's unverified role from a member, gives them the
+// member role if one is set and forgets them as pending, returning whether
+// they were awaiting verification
+func liftGate(s *discordgo.Session, call func(func() error) error, store *verification.Store, settings verification.Settings, userID, reason string) (bool, error) {
+	option := discordgo.WithAuditLogReason(reason)
+	if settings.MemberRoleID != "" {
+		err := call(func() error {
+			return s.GuildMemberRoleAdd(settings.GuildID, userID, settings.MemberRoleID, option)
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to give member role: %w", err)
+		}
+	}
+	err := call(func() error {
+		return s.GuildMemberRoleRemove(settings.GuildID, userID, settings.UnverifiedRoleID, option)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to remove unverified role: %w", err)
+	}
+
+	wasPending, err := store.RemovePending(settings.GuildID, userID)
+	if err != nil {
+		return false, err
+	}
+	if err := store.Record(settings.GuildID, 1, 0); err != nil {
+		return wasPending, err
+	}
+	return wasPending, nil
+}
+
+// hasRole reports whether roleIDs includes roleID
+func hasRole(roleIDs []string, roleID string) bool {
+	for _, id := range roleIDs {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
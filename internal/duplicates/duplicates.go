@@ -0,0 +1,242 @@
+// Package duplicates groups messages with identical or near-identical
+// content, to spot spam campaigns and copypasta posted across channels.
+package duplicates
+
+import (
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// shingleSize is the length of the character n-grams compared for
+// similarity
+const shingleSize = 4
+
+// mentionPattern matches user, role and channel mentions and custom emojis,
+// which spam often varies between copies
+var mentionPattern = regexp.MustCompile(`<(@[!&]?|#|a?:\w+:)\d+>`)
+
+// Message is a message to compare
+type Message struct {
+	ID        string    `json:"message_id"`
+	ChannelID string    `json:"channel_id"`
+	AuthorID  string    `json:"author_id"`
+	Author    string    `json:"author"`
+	Content   string    `json:"-"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Options tune the comparison
+type Options struct {
+	// Threshold is the similarity from 0 to 1 at which two messages are
+	// duplicates; 1 only groups identical text
+	Threshold float64
+	// MinLength ignores messages shorter than this after normalization
+	MinLength int
+	// MinCount is the fewest messages a group needs to be reported
+	MinCount int
+}
+
+// Group is a set of messages with the same or similar content
+type Group struct {
+	// Sample is the content of the group's earliest message
+	Sample string `json:"sample"`
+	Count  int    `json:"count"`
+	// Exact is true when every message normalizes to the same text
+	Exact bool `json:"exact"`
+	// MinSimilarity is the lowest similarity that joined a message to the
+	// group
+	MinSimilarity float64   `json:"min_similarity"`
+	AuthorCount   int       `json:"author_count"`
+	ChannelCount  int       `json:"channel_count"`
+	FirstSeen     time.Time `json:"first_seen"`
+	LastSeen      time.Time `json:"last_seen"`
+	Messages      []Message `json:"messages"`
+}
+
+// variant is one distinct normalized text and the messages that have it
+type variant struct {
+	text     string
+	shingles []uint64
+	messages []Message
+}
+
+// Find groups duplicate messages, largest groups first
+func Find(messages []Message, opts Options) []Group {
+	variants := make([]*variant, 0)
+	byText := make(map[string]*variant)
+	for _, msg := range messages {
+		text := Normalize(msg.Content)
+		if len([]rune(text)) < opts.MinLength {
+			continue
+		}
+		v, ok := byText[text]
+		if !ok {
+			v = &variant{text: text}
+			if opts.Threshold < 1 {
+				v.shingles = shingles(text)
+			}
+			byText[text] = v
+			variants = append(variants, v)
+		}
+		v.messages = append(v.messages, msg)
+	}
+
+	parent := make([]int, len(variants))
+	similarity := make([]float64, len(variants))
+	for i := range parent {
+		parent[i] = i
+		similarity[i] = 1
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	if opts.Threshold < 1 {
+		// Sets of very different sizes cannot reach the threshold, so only
+		// neighbours in size order are compared
+		order := make([]int, len(variants))
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(a, b int) bool { return len(variants[order[a]].shingles) < len(variants[order[b]].shingles) })
+		for a := 0; a < len(order); a++ {
+			small := variants[order[a]]
+			for b := a + 1; b < len(order); b++ {
+				large := variants[order[b]]
+				if float64(len(small.shingles)) < opts.Threshold*float64(len(large.shingles)) {
+					break
+				}
+				score := jaccard(small.shingles, large.shingles)
+				if score < opts.Threshold {
+					continue
+				}
+				rootA, rootB := find(order[a]), find(order[b])
+				if rootA != rootB {
+					parent[rootB] = rootA
+				}
+				if score < similarity[rootA] {
+					similarity[rootA] = score
+				}
+				if similarity[rootB] < similarity[rootA] {
+					similarity[rootA] = similarity[rootB]
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]*variant)
+	for i, v := range variants {
+		root := find(i)
+		members[root] = append(members[root], v)
+	}
+
+	groups := make([]Group, 0)
+	for root, vs := range members {
+		group := Group{Exact: len(vs) == 1, MinSimilarity: similarity[root]}
+		for _, v := range vs {
+			group.Messages = append(group.Messages, v.messages...)
+		}
+		if len(group.Messages) < opts.MinCount {
+			continue
+		}
+		summarize(&group)
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].FirstSeen.Before(groups[j].FirstSeen)
+	})
+	return groups
+}
+
+// summarize fills in a group's counts and time range and orders its
+// messages oldest first
+func summarize(group *Group) {
+	sort.SliceStable(group.Messages, func(i, j int) bool {
+		return group.Messages[i].Timestamp.Before(group.Messages[j].Timestamp)
+	})
+	authors := make(map[string]bool)
+	channels := make(map[string]bool)
+	for _, msg := range group.Messages {
+		authors[msg.AuthorID] = true
+		channels[msg.ChannelID] = true
+	}
+	group.Count = len(group.Messages)
+	group.AuthorCount = len(authors)
+	group.ChannelCount = len(channels)
+	group.Sample = group.Messages[0].Content
+	group.FirstSeen = group.Messages[0].Timestamp
+	group.LastSeen = group.Messages[len(group.Messages)-1].Timestamp
+}
+
+// Normalize reduces content to the text that is compared: mentions are
+// removed, letters are lower-cased, punctuation and invisible characters
+// are dropped and whitespace is collapsed
+func Normalize(content string) string {
+	content = mentionPattern.ReplaceAllString(content, " ")
+	var b strings.Builder
+	for _, c := range strings.ToLower(content) {
+		switch {
+		case unicode.IsLetter(c), unicode.IsDigit(c):
+			b.WriteRune(c)
+		case unicode.IsSpace(c), unicode.IsPunct(c), unicode.IsSymbol(c):
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// shingles returns the sorted, distinct hashes of a text's character
+// n-grams
+func shingles(text string) []uint64 {
+	runes := []rune(text)
+	if len(runes) < shingleSize {
+		return []uint64{hash(text)}
+	}
+	seen := make(map[uint64]bool, len(runes))
+	out := make([]uint64, 0, len(runes))
+	for i := 0; i+shingleSize <= len(runes); i++ {
+		h := hash(string(runes[i : i+shingleSize]))
+		if !seen[h] {
+			seen[h] = true
+			out = append(out, h)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// jaccard returns the similarity of two sorted sets
+func jaccard(a, b []uint64) float64 {
+	shared := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			shared++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// hash returns the FNV-1a hash of a string
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
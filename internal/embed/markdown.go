@@ -0,0 +1,220 @@
+// Package embed builds Discord embeds from Markdown documents.
+package embed
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"discord-mcp/internal/textsplit"
+)
+
+// Discord embed limits
+const (
+	MaxTitle            = 256
+	MaxDescription      = 4096
+	MaxTotalPerMessage  = 6000
+	MaxEmbedsPerMessage = 10
+)
+
+var (
+	headingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	imagePattern   = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	bulletPattern  = regexp.MustCompile(`^(\s*)[-*+]\s+`)
+	rulePattern    = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+)
+
+// Embed is a Discord embed in the shape accepted by send_message
+type Embed struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Color       int    `json:"color,omitempty"`
+	Image       *Image `json:"image,omitempty"`
+}
+
+// Image is an embed image
+type Image struct {
+	URL string `json:"url"`
+}
+
+// Size returns the characters the embed counts against Discord's total limit
+func (e Embed) Size() int {
+	return utf8.RuneCountInString(e.Title) + utf8.RuneCountInString(e.Description)
+}
+
+// section is a part of the document that starts a new embed
+type section struct {
+	title  string
+	blocks []string
+	images []string
+}
+
+// FromMarkdown converts a Markdown document into embeds. A level-one heading
+// or horizontal rule starts a new embed, other headings become bold lines,
+// bullets become "•" and images become embed images. Text longer than an
+// embed description continues in further embeds, never splitting a code
+// block unless it alone exceeds the limit.
+func FromMarkdown(markdown string, color int) []Embed {
+	var embeds []Embed
+	for _, sec := range parseSections(markdown) {
+		embeds = append(embeds, sec.embeds(color)...)
+	}
+	return embeds
+}
+
+// GroupMessages packs embeds into messages that respect the per-message
+// embed count and total character limits
+func GroupMessages(embeds []Embed) [][]Embed {
+	var messages [][]Embed
+	var current []Embed
+	size := 0
+	for _, e := range embeds {
+		if len(current) > 0 && (len(current) >= MaxEmbedsPerMessage || size+e.Size() > MaxTotalPerMessage) {
+			messages = append(messages, current)
+			current, size = nil, 0
+		}
+		current = append(current, e)
+		size += e.Size()
+	}
+	if len(current) > 0 {
+		messages = append(messages, current)
+	}
+	return messages
+}
+
+// parseSections splits a document into sections of Markdown blocks
+func parseSections(markdown string) []*section {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	sections := []*section{{}}
+	current := sections[0]
+	var block []string
+	inFence := false
+
+	flush := func() {
+		text := strings.TrimSpace(strings.Join(block, "\n"))
+		if text != "" {
+			current.blocks = append(current.blocks, text)
+		}
+		block = nil
+	}
+	newSection := func(title string) {
+		flush()
+		if current.title == "" && len(current.blocks) == 0 && len(current.images) == 0 {
+			current.title = title
+			return
+		}
+		current = &section{title: title}
+		sections = append(sections, current)
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			if !inFence {
+				flush()
+			}
+			block = append(block, line)
+			inFence = !inFence
+			if !inFence {
+				flush()
+			}
+			continue
+		}
+		if inFence {
+			block = append(block, line)
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flush()
+		case rulePattern.MatchString(trimmed):
+			newSection("")
+		case headingPattern.MatchString(trimmed):
+			match := headingPattern.FindStringSubmatch(trimmed)
+			if len(match[1]) == 1 {
+				newSection(match[2])
+			} else {
+				flush()
+				current.blocks = append(current.blocks, "**"+match[2]+"**")
+			}
+		default:
+			for _, img := range imagePattern.FindAllStringSubmatch(line, -1) {
+				current.images = append(current.images, img[2])
+			}
+			line = imagePattern.ReplaceAllString(line, "")
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			line = bulletPattern.ReplaceAllString(line, "${1}• ")
+			block = append(block, line)
+		}
+	}
+	flush()
+
+	return sections
+}
+
+// embeds renders a section, continuing into more embeds as needed
+func (s *section) embeds(color int) []Embed {
+	title := truncate(s.title, MaxTitle)
+
+	var descriptions []string
+	var desc strings.Builder
+	for _, block := range s.blocks {
+		for _, part := range textsplit.Block(block, MaxDescription) {
+			sep := 0
+			if desc.Len() > 0 {
+				sep = 2
+			}
+			if utf8.RuneCountInString(desc.String())+sep+utf8.RuneCountInString(part) > MaxDescription {
+				descriptions = append(descriptions, desc.String())
+				desc.Reset()
+				sep = 0
+			}
+			if sep > 0 {
+				desc.WriteString("\n\n")
+			}
+			desc.WriteString(part)
+		}
+	}
+	if desc.Len() > 0 || len(descriptions) == 0 {
+		descriptions = append(descriptions, desc.String())
+	}
+
+	var embeds []Embed
+	for i, d := range descriptions {
+		e := Embed{Description: d, Color: color}
+		if i == 0 {
+			e.Title = title
+		}
+		embeds = append(embeds, e)
+	}
+
+	// The first image goes on the last text embed; further images get
+	// embeds of their own
+	for i, url := range s.images {
+		if i == 0 {
+			embeds[len(embeds)-1].Image = &Image{URL: url}
+			continue
+		}
+		embeds = append(embeds, Embed{Color: color, Image: &Image{URL: url}})
+	}
+
+	// Drop an empty section (e.g. a rule at the very start)
+	if len(embeds) == 1 && embeds[0].Title == "" && embeds[0].Description == "" && embeds[0].Image == nil {
+		return nil
+	}
+	return embeds
+}
+
+// truncate shortens s to at most n characters
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
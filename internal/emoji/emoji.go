@@ -0,0 +1,185 @@
+package emoji
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"discord-mcp/internal/resolve"
+)
+
+// Code points that combine with emoji rather than standing alone
+const (
+	zeroWidthJoiner   = '\u200D'
+	textPresentation  = '\uFE0E'
+	emojiPresentation = '\uFE0F'
+	combiningKeycap   = '\u20E3'
+	cancelTag         = '\U000E007F'
+)
+
+var (
+	// customMention matches <:name:id> and <a:name:id>
+	customMention = regexp.MustCompile(`^<(a?):([A-Za-z0-9_]{2,32}):([0-9]+)>$`)
+	// customReaction matches the name:id form used by the reactions API
+	customReaction = regexp.MustCompile(`^(a?:)?([A-Za-z0-9_]{2,32}):([0-9]+)$`)
+	// shortcode matches :name: with an optional Discord skin tone suffix
+	shortcode = regexp.MustCompile(`^:([A-Za-z0-9_+\-]+):(?::skin-tone-([1-5]):)?$`)
+)
+
+// Custom identifies a guild emoji given by the caller. ID is empty when only
+// the name is known.
+type Custom struct {
+	Name     string
+	ID       string
+	Animated bool
+}
+
+// ParseCustom recognizes a custom emoji given as <:name:id>, <a:name:id> or
+// name:id
+func ParseCustom(s string) (Custom, bool) {
+	if m := customMention.FindStringSubmatch(s); m != nil {
+		return Custom{Name: m[2], ID: m[3], Animated: m[1] == "a"}, true
+	}
+	if m := customReaction.FindStringSubmatch(s); m != nil {
+		return Custom{Name: m[2], ID: m[3], Animated: m[1] == "a:"}, true
+	}
+	return Custom{}, false
+}
+
+// ParseShortcode splits :name: or :name::skin-tone-N: into the name and
+// skin tone (0 when none is given)
+func ParseShortcode(s string) (name string, tone int, ok bool) {
+	m := shortcode.FindStringSubmatch(s)
+	if m == nil {
+		return "", 0, false
+	}
+	if m[2] != "" {
+		tone = int(m[2][0] - '0')
+	}
+	return strings.ToLower(m[1]), tone, true
+}
+
+// FromShortcode converts a shortcode name such as "thumbsup" to its Unicode
+// emoji, applying a skin tone from 1 (light) to 5 (dark) when given. The
+// tone is ignored for emoji that have no skin tone variants.
+func FromShortcode(name string, tone int) (string, bool) {
+	name = strings.ToLower(name)
+	unicode, ok := shortcodes[name]
+	if !ok {
+		return "", false
+	}
+	if tone >= 1 && tone <= 5 && skinTones[name] {
+		// The modifier follows the base character, replacing any
+		// presentation selector
+		runes := []rune(strings.TrimSuffix(unicode, string(emojiPresentation)))
+		modifier := rune(0x1F3FB + tone - 1)
+		unicode = string(runes[0]) + string(modifier) + string(runes[1:])
+	}
+	return unicode, true
+}
+
+// SuggestShortcodes returns up to limit known shortcodes that resemble name,
+// best match first
+func SuggestShortcodes(name string, limit int) []string {
+	type scored struct {
+		name  string
+		score float64
+	}
+	var matches []scored
+	for candidate := range shortcodes {
+		if score := resolve.Score(name, candidate); score > 0 {
+			matches = append(matches, scored{candidate, score})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	var names []string
+	for i := 0; i < len(matches) && i < limit; i++ {
+		names = append(names, ":"+matches[i].name+":")
+	}
+	return names
+}
+
+// IsUnicode reports whether s is a single Unicode emoji, including ZWJ
+// sequences, skin tone modifiers, keycaps, flags and subdivision flags
+func IsUnicode(s string) bool {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return false
+	}
+
+	// Keycaps: a digit, # or * followed by an optional FE0F and U+20E3
+	if strings.ContainsRune("0123456789#*", runes[0]) {
+		rest := runes[1:]
+		if len(rest) > 0 && rest[0] == emojiPresentation {
+			rest = rest[1:]
+		}
+		return len(rest) == 1 && rest[0] == combiningKeycap
+	}
+
+	// Flags are two regional indicators; a single letter is also a valid
+	// reaction
+	if isRegionalIndicator(runes[0]) {
+		return len(runes) == 1 || len(runes) == 2 && isRegionalIndicator(runes[1])
+	}
+
+	// ZWJ sequences of pictographs, each with optional modifiers and tags
+	expectBase := true
+	for _, r := range runes {
+		switch {
+		case expectBase:
+			if !isPictographic(r) {
+				return false
+			}
+			expectBase = false
+		case r == zeroWidthJoiner:
+			expectBase = true
+		case r == emojiPresentation, r == textPresentation, isSkinTone(r), isTag(r):
+		default:
+			return false
+		}
+	}
+	return !expectBase
+}
+
+type runeRange struct{ lo, hi rune }
+
+// pictographic covers the Unicode code points that have emoji presentation
+// or are commonly used as emoji
+var pictographic = []runeRange{
+	{0x00A9, 0x00A9}, {0x00AE, 0x00AE}, {0x203C, 0x203C}, {0x2049, 0x2049},
+	{0x2122, 0x2122}, {0x2139, 0x2139}, {0x2194, 0x2199}, {0x21A9, 0x21AA},
+	{0x231A, 0x231B}, {0x2328, 0x2328}, {0x23CF, 0x23CF}, {0x23E9, 0x23F3},
+	{0x23F8, 0x23FA}, {0x24C2, 0x24C2}, {0x25AA, 0x25AB}, {0x25B6, 0x25B6},
+	{0x25C0, 0x25C0}, {0x25FB, 0x25FE}, {0x2600, 0x27BF}, {0x2934, 0x2935},
+	{0x2B05, 0x2B07}, {0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55},
+	{0x3030, 0x3030}, {0x303D, 0x303D}, {0x3297, 0x3297}, {0x3299, 0x3299},
+	{0x1F000, 0x1F0FF}, {0x1F10D, 0x1F1AD}, {0x1F200, 0x1F2FF},
+	{0x1F300, 0x1F3FA}, {0x1F400, 0x1FAFF},
+}
+
+func isPictographic(r rune) bool {
+	for _, rr := range pictographic {
+		if r >= rr.lo && r <= rr.hi {
+			return true
+		}
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+func isSkinTone(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+func isTag(r rune) bool {
+	return r >= 0xE0020 && r <= cancelTag
+}
@@ -0,0 +1,515 @@
+package emoji
+
+// shortcodes maps Discord emoji shortcode names, without colons, to Unicode
+// emoji. It covers the emoji commonly used for reactions rather than the
+// full Unicode set.
+var shortcodes = map[string]string{
+	"grinning":                      "😀",
+	"smiley":                        "😃",
+	"smile":                         "😄",
+	"grin":                          "😁",
+	"laughing":                      "😆",
+	"satisfied":                     "😆",
+	"sweat_smile":                   "😅",
+	"joy":                           "😂",
+	"rofl":                          "🤣",
+	"rolling_on_the_floor_laughing": "🤣",
+	"slight_smile":                  "🙂",
+	"slightly_smiling_face":         "🙂",
+	"upside_down":                   "🙃",
+	"wink":                          "😉",
+	"blush":                         "😊",
+	"innocent":                      "😇",
+	"smiling_face_with_3_hearts":    "🥰",
+	"heart_eyes":                    "😍",
+	"star_struck":                   "🤩",
+	"kissing_heart":                 "😘",
+	"yum":                           "😋",
+	"stuck_out_tongue":              "😛",
+	"stuck_out_tongue_winking_eye":  "😜",
+	"zany_face":                     "🤪",
+	"money_mouth":                   "🤑",
+	"hugging":                       "🤗",
+	"hugs":                          "🤗",
+	"thinking":                      "🤔",
+	"thinking_face":                 "🤔",
+	"zipper_mouth":                  "🤐",
+	"raised_eyebrow":                "🤨",
+	"neutral_face":                  "😐",
+	"expressionless":                "😑",
+	"no_mouth":                      "😶",
+	"smirk":                         "😏",
+	"unamused":                      "😒",
+	"rolling_eyes":                  "🙄",
+	"grimacing":                     "😬",
+	"relieved":                      "😌",
+	"pensive":                       "😔",
+	"sleepy":                        "😪",
+	"sleeping":                      "😴",
+	"mask":                          "😷",
+	"nerd":                          "🤓",
+	"nerd_face":                     "🤓",
+	"sunglasses":                    "😎",
+	"confused":                      "😕",
+	"worried":                       "😟",
+	"slight_frown":                  "🙁",
+	"frowning2":                     "☹️",
+	"open_mouth":                    "😮",
+	"hushed":                        "😯",
+	"astonished":                    "😲",
+	"flushed":                       "😳",
+	"pleading_face":                 "🥺",
+	"cry":                           "😢",
+	"sob":                           "😭",
+	"scream":                        "😱",
+	"confounded":                    "😖",
+	"persevere":                     "😣",
+	"disappointed":                  "😞",
+	"sweat":                         "😓",
+	"weary":                         "😩",
+	"tired_face":                    "😫",
+	"yawning_face":                  "🥱",
+	"triumph":                       "😤",
+	"rage":                          "😡",
+	"angry":                         "😠",
+	"skull":                         "💀",
+	"poop":                          "💩",
+	"clown":                         "🤡",
+	"ghost":                         "👻",
+	"alien":                         "👽",
+	"robot":                         "🤖",
+	"eyes":                          "👀",
+	"saluting_face":                 "🫡",
+	"melting_face":                  "🫠",
+	"face_with_monocle":             "🧐",
+	"shushing_face":                 "🤫",
+	"exploding_head":                "🤯",
+	"partying_face":                 "🥳",
+	"cold_face":                     "🥶",
+	"hot_face":                      "🥵",
+	"thumbsup":                      "👍",
+	"+1":                            "👍",
+	"thumbup":                       "👍",
+	"thumbsdown":                    "👎",
+	"-1":                            "👎",
+	"thumbdown":                     "👎",
+	"ok_hand":                       "👌",
+	"pinched_fingers":               "🤌",
+	"v":                             "✌️",
+	"fingers_crossed":               "🤞",
+	"metal":                         "🤘",
+	"call_me":                       "🤙",
+	"point_left":                    "👈",
+	"point_right":                   "👉",
+	"point_up_2":                    "👆",
+	"point_down":                    "👇",
+	"point_up":                      "☝️",
+	"raised_hand":                   "✋",
+	"hand_splayed":                  "🖐️",
+	"vulcan":                        "🖖",
+	"wave":                          "👋",
+	"clap":                          "👏",
+	"raised_hands":                  "🙌",
+	"open_hands":                    "👐",
+	"palms_up_together":             "🤲",
+	"handshake":                     "🤝",
+	"pray":                          "🙏",
+	"writing_hand":                  "✍️",
+	"muscle":                        "💪",
+	"punch":                         "👊",
+	"fist":                          "✊",
+	"left_facing_fist":              "🤛",
+	"right_facing_fist":             "🤜",
+	"nail_care":                     "💅",
+	"selfie":                        "🤳",
+	"heart":                         "❤️",
+	"red_heart":                     "❤️",
+	"orange_heart":                  "🧡",
+	"yellow_heart":                  "💛",
+	"green_heart":                   "💚",
+	"blue_heart":                    "💙",
+	"purple_heart":                  "💜",
+	"black_heart":                   "🖤",
+	"white_heart":                   "🤍",
+	"brown_heart":                   "🤎",
+	"broken_heart":                  "💔",
+	"two_hearts":                    "💕",
+	"sparkling_heart":               "💖",
+	"heartpulse":                    "💗",
+	"heartbeat":                     "💓",
+	"revolving_hearts":              "💞",
+	"cupid":                         "💘",
+	"gift_heart":                    "💝",
+	"heart_on_fire":                 "❤️‍🔥",
+	"100":                           "💯",
+	"fire":                          "🔥",
+	"sparkles":                      "✨",
+	"star":                          "⭐",
+	"star2":                         "🌟",
+	"dizzy":                         "💫",
+	"boom":                          "💥",
+	"collision":                     "💥",
+	"zap":                           "⚡",
+	"tada":                          "🎉",
+	"confetti_ball":                 "🎊",
+	"balloon":                       "🎈",
+	"gift":                          "🎁",
+	"trophy":                        "🏆",
+	"first_place":                   "🥇",
+	"second_place":                  "🥈",
+	"third_place":                   "🥉",
+	"medal":                         "🏅",
+	"crown":                         "👑",
+	"gem":                           "💎",
+	"bell":                          "🔔",
+	"no_bell":                       "🔕",
+	"mega":                          "📣",
+	"loudspeaker":                   "📢",
+	"speech_balloon":                "💬",
+	"thought_balloon":               "💭",
+	"zzz":                           "💤",
+	"white_check_mark":              "✅",
+	"heavy_check_mark":              "✔️",
+	"ballot_box_with_check":         "☑️",
+	"x":                             "❌",
+	"negative_squared_cross_mark":   "❎",
+	"heavy_multiplication_x":        "✖️",
+	"question":                      "❓",
+	"grey_question":                 "❔",
+	"exclamation":                   "❗",
+	"grey_exclamation":              "❕",
+	"bangbang":                      "‼️",
+	"interrobang":                   "⁉️",
+	"warning":                       "⚠️",
+	"no_entry":                      "⛔",
+	"no_entry_sign":                 "🚫",
+	"stop_sign":                     "🛑",
+	"octagonal_sign":                "🛑",
+	"red_circle":                    "🔴",
+	"orange_circle":                 "🟠",
+	"yellow_circle":                 "🟡",
+	"green_circle":                  "🟢",
+	"blue_circle":                   "🔵",
+	"purple_circle":                 "🟣",
+	"black_circle":                  "⚫",
+	"white_circle":                  "⚪",
+	"arrow_up":                      "⬆️",
+	"arrow_down":                    "⬇️",
+	"arrow_left":                    "⬅️",
+	"arrow_right":                   "➡️",
+	"arrows_counterclockwise":       "🔄",
+	"repeat":                        "🔁",
+	"heavy_plus_sign":               "➕",
+	"heavy_minus_sign":              "➖",
+	"infinity":                      "♾️",
+	"recycle":                       "♻️",
+	"copyright":                     "©️",
+	"registered":                    "®️",
+	"tm":                            "™️",
+	"information_source":            "ℹ️",
+	"new":                           "🆕",
+	"free":                          "🆓",
+	"up":                            "🆙",
+	"cool":                          "🆒",
+	"ok":                            "🆗",
+	"sos":                           "🆘",
+	"pushpin":                       "📌",
+	"round_pushpin":                 "📍",
+	"paperclip":                     "📎",
+	"link":                          "🔗",
+	"lock":                          "🔒",
+	"unlock":                        "🔓",
+	"key":                           "🔑",
+	"hammer":                        "🔨",
+	"tools":                         "🛠️",
+	"wrench":                        "🔧",
+	"gear":                          "⚙️",
+	"shield":                        "🛡️",
+	"bulb":                          "💡",
+	"mag":                           "🔍",
+	"memo":                          "📝",
+	"pencil":                        "📝",
+	"pencil2":                       "✏️",
+	"books":                         "📚",
+	"book":                          "📖",
+	"bookmark":                      "🔖",
+	"calendar":                      "📆",
+	"date":                          "📅",
+	"clipboard":                     "📋",
+	"chart_with_upwards_trend":      "📈",
+	"chart_with_downwards_trend":    "📉",
+	"bar_chart":                     "📊",
+	"email":                         "📧",
+	"envelope":                      "✉️",
+	"inbox_tray":                    "📥",
+	"outbox_tray":                   "📤",
+	"package":                       "📦",
+	"computer":                      "💻",
+	"desktop":                       "🖥️",
+	"keyboard":                      "⌨️",
+	"iphone":                        "📱",
+	"telephone":                     "☎️",
+	"camera":                        "📷",
+	"movie_camera":                  "🎥",
+	"tv":                            "📺",
+	"headphones":                    "🎧",
+	"microphone":                    "🎤",
+	"musical_note":                  "🎵",
+	"notes":                         "🎶",
+	"video_game":                    "🎮",
+	"game_die":                      "🎲",
+	"dart":                          "🎯",
+	"jigsaw":                        "🧩",
+	"art":                           "🎨",
+	"money_with_wings":              "💸",
+	"moneybag":                      "💰",
+	"dollar":                        "💵",
+	"credit_card":                   "💳",
+	"hourglass":                     "⌛",
+	"hourglass_flowing_sand":        "⏳",
+	"stopwatch":                     "⏱️",
+	"alarm_clock":                   "⏰",
+	"watch":                         "⌚",
+	"rocket":                        "🚀",
+	"airplane":                      "✈️",
+	"car":                           "🚗",
+	"red_car":                       "🚗",
+	"bike":                          "🚲",
+	"ship":                          "🚢",
+	"construction":                  "🚧",
+	"rotating_light":                "🚨",
+	"checkered_flag":                "🏁",
+	"triangular_flag_on_post":       "🚩",
+	"white_flag":                    "🏳️",
+	"black_flag":                    "🏴",
+	"rainbow_flag":                  "🏳️‍🌈",
+	"pirate_flag":                   "🏴‍☠️",
+	"house":                         "🏠",
+	"office":                        "🏢",
+	"globe_with_meridians":          "🌐",
+	"earth_americas":                "🌎",
+	"earth_africa":                  "🌍",
+	"earth_asia":                    "🌏",
+	"map":                           "🗺️",
+	"compass":                       "🧭",
+	"sunny":                         "☀️",
+	"cloud":                         "☁️",
+	"umbrella":                      "☔",
+	"snowflake":                     "❄️",
+	"rainbow":                       "🌈",
+	"crescent_moon":                 "🌙",
+	"full_moon":                     "🌕",
+	"sun_with_face":                 "🌞",
+	"ocean":                         "🌊",
+	"seedling":                      "🌱",
+	"evergreen_tree":                "🌲",
+	"deciduous_tree":                "🌳",
+	"palm_tree":                     "🌴",
+	"cactus":                        "🌵",
+	"four_leaf_clover":              "🍀",
+	"maple_leaf":                    "🍁",
+	"fallen_leaf":                   "🍂",
+	"rose":                          "🌹",
+	"tulip":                         "🌷",
+	"sunflower":                     "🌻",
+	"cherry_blossom":                "🌸",
+	"bouquet":                       "💐",
+	"mushroom":                      "🍄",
+	"dog":                           "🐶",
+	"cat":                           "🐱",
+	"mouse":                         "🐭",
+	"rabbit":                        "🐰",
+	"fox":                           "🦊",
+	"bear":                          "🐻",
+	"panda_face":                    "🐼",
+	"koala":                         "🐨",
+	"tiger":                         "🐯",
+	"lion_face":                     "🦁",
+	"cow":                           "🐮",
+	"pig":                           "🐷",
+	"frog":                          "🐸",
+	"monkey":                        "🐒",
+	"see_no_evil":                   "🙈",
+	"hear_no_evil":                  "🙉",
+	"speak_no_evil":                 "🙊",
+	"chicken":                       "🐔",
+	"penguin":                       "🐧",
+	"bird":                          "🐦",
+	"eagle":                         "🦅",
+	"owl":                           "🦉",
+	"duck":                          "🦆",
+	"unicorn":                       "🦄",
+	"bee":                           "🐝",
+	"bug":                           "🐛",
+	"butterfly":                     "🦋",
+	"snail":                         "🐌",
+	"turtle":                        "🐢",
+	"snake":                         "🐍",
+	"dragon":                        "🐉",
+	"whale":                         "🐳",
+	"dolphin":                       "🐬",
+	"fish":                          "🐟",
+	"octopus":                       "🐙",
+	"crab":                          "🦀",
+	"shark":                         "🦈",
+	"apple":                         "🍎",
+	"green_apple":                   "🍏",
+	"banana":                        "🍌",
+	"grapes":                        "🍇",
+	"watermelon":                    "🍉",
+	"strawberry":                    "🍓",
+	"peach":                         "🍑",
+	"cherries":                      "🍒",
+	"lemon":                         "🍋",
+	"avocado":                       "🥑",
+	"eggplant":                      "🍆",
+	"carrot":                        "🥕",
+	"corn":                          "🌽",
+	"hot_pepper":                    "🌶️",
+	"bread":                         "🍞",
+	"cheese":                        "🧀",
+	"egg":                           "🥚",
+	"bacon":                         "🥓",
+	"hamburger":                     "🍔",
+	"fries":                         "🍟",
+	"pizza":                         "🍕",
+	"hotdog":                        "🌭",
+	"taco":                          "🌮",
+	"burrito":                       "🌯",
+	"popcorn":                       "🍿",
+	"sushi":                         "🍣",
+	"ramen":                         "🍜",
+	"rice":                          "🍚",
+	"cookie":                        "🍪",
+	"doughnut":                      "🍩",
+	"cake":                          "🍰",
+	"birthday":                      "🎂",
+	"ice_cream":                     "🍨",
+	"icecream":                      "🍦",
+	"chocolate_bar":                 "🍫",
+	"candy":                         "🍬",
+	"coffee":                        "☕",
+	"tea":                           "🍵",
+	"beer":                          "🍺",
+	"beers":                         "🍻",
+	"wine_glass":                    "🍷",
+	"champagne":                     "🍾",
+	"tropical_drink":                "🍹",
+	"cocktail":                      "🍸",
+	"milk":                          "🥛",
+	"soccer":                        "⚽",
+	"basketball":                    "🏀",
+	"football":                      "🏈",
+	"baseball":                      "⚾",
+	"tennis":                        "🎾",
+	"8ball":                         "🎱",
+	"bowling":                       "🎳",
+	"chess_pawn":                    "♟️",
+	"runner":                        "🏃",
+	"dancer":                        "💃",
+	"man_dancing":                   "🕺",
+	"person_shrugging":              "🤷",
+	"shrug":                         "🤷",
+	"person_facepalming":            "🤦",
+	"facepalm":                      "🤦",
+	"person_raising_hand":           "🙋",
+	"raising_hand":                  "🙋",
+	"person_bowing":                 "🙇",
+	"bow":                           "🙇",
+	"ninja":                         "🥷",
+	"detective":                     "🕵️",
+	"technologist":                  "🧑‍💻",
+	"brain":                         "🧠",
+	"speaking_head":                 "🗣️",
+	"busts_in_silhouette":           "👥",
+	"bust_in_silhouette":            "👤",
+	"baby":                          "👶",
+	"older_person":                  "🧓",
+	"zero":                          "0️⃣",
+	"one":                           "1️⃣",
+	"two":                           "2️⃣",
+	"three":                         "3️⃣",
+	"four":                          "4️⃣",
+	"five":                          "5️⃣",
+	"six":                           "6️⃣",
+	"seven":                         "7️⃣",
+	"eight":                         "8️⃣",
+	"nine":                          "9️⃣",
+	"keycap_ten":                    "🔟",
+	"hash":                          "#️⃣",
+	"asterisk":                      "*️⃣",
+	"regional_indicator_a":          "🇦",
+	"regional_indicator_b":          "🇧",
+	"regional_indicator_c":          "🇨",
+	"regional_indicator_d":          "🇩",
+	"regional_indicator_e":          "🇪",
+	"regional_indicator_f":          "🇫",
+	"regional_indicator_g":          "🇬",
+	"regional_indicator_h":          "🇭",
+	"regional_indicator_i":          "🇮",
+	"regional_indicator_j":          "🇯",
+	"regional_indicator_k":          "🇰",
+	"regional_indicator_l":          "🇱",
+	"regional_indicator_m":          "🇲",
+	"regional_indicator_n":          "🇳",
+	"regional_indicator_o":          "🇴",
+	"regional_indicator_p":          "🇵",
+	"regional_indicator_q":          "🇶",
+	"regional_indicator_r":          "🇷",
+	"regional_indicator_s":          "🇸",
+	"regional_indicator_t":          "🇹",
+	"regional_indicator_u":          "🇺",
+	"regional_indicator_v":          "🇻",
+	"regional_indicator_w":          "🇼",
+	"regional_indicator_x":          "🇽",
+	"regional_indicator_y":          "🇾",
+	"regional_indicator_z":          "🇿",
+	"flag_us":                       "🇺🇸",
+	"flag_gb":                       "🇬🇧",
+	"flag_ca":                       "🇨🇦",
+	"flag_au":                       "🇦🇺",
+	"flag_de":                       "🇩🇪",
+	"flag_fr":                       "🇫🇷",
+	"flag_es":                       "🇪🇸",
+	"flag_it":                       "🇮🇹",
+	"flag_jp":                       "🇯🇵",
+	"flag_kr":                       "🇰🇷",
+	"flag_cn":                       "🇨🇳",
+	"flag_in":                       "🇮🇳",
+	"flag_br":                       "🇧🇷",
+	"flag_mx":                       "🇲🇽",
+	"flag_nl":                       "🇳🇱",
+	"flag_se":                       "🇸🇪",
+	"flag_no":                       "🇳🇴",
+	"flag_dk":                       "🇩🇰",
+	"flag_fi":                       "🇫🇮",
+	"flag_pl":                       "🇵🇱",
+	"flag_ua":                       "🇺🇦",
+	"flag_tr":                       "🇹🇷",
+	"flag_ru":                       "🇷🇺",
+	"flag_ie":                       "🇮🇪",
+	"flag_pt":                       "🇵🇹",
+	"flag_ch":                       "🇨🇭",
+	"flag_at":                       "🇦🇹",
+	"flag_be":                       "🇧🇪",
+	"flag_nz":                       "🇳🇿",
+	"flag_za":                       "🇿🇦",
+}
+
+// skinTones lists the shortcodes whose emoji accept a skin tone modifier
+var skinTones = map[string]bool{
+	"thumbsup": true, "+1": true, "thumbup": true, "thumbsdown": true, "-1": true,
+	"thumbdown": true, "ok_hand": true, "pinched_fingers": true, "v": true,
+	"fingers_crossed": true, "metal": true, "call_me": true, "point_left": true,
+	"point_right": true, "point_up_2": true, "point_down": true, "point_up": true,
+	"raised_hand": true, "hand_splayed": true, "vulcan": true, "wave": true,
+	"clap": true, "raised_hands": true, "open_hands": true,
+	"palms_up_together": true, "handshake": true, "pray": true,
+	"writing_hand": true, "muscle": true, "punch": true, "fist": true,
+	"left_facing_fist": true, "right_facing_fist": true, "nail_care": true,
+	"selfie": true, "runner": true, "dancer": true, "man_dancing": true,
+	"person_shrugging": true, "shrug": true, "person_facepalming": true,
+	"facepalm": true, "person_raising_hand": true, "raising_hand": true,
+	"person_bowing": true, "bow": true, "ninja": true, "detective": true,
+	"baby": true, "older_person": true,
+}
@@ -0,0 +1,185 @@
+// Package export renders channel history as JSON, CSV or Markdown transcripts.
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/pkg/types"
+)
+
+// Supported transcript formats
+const (
+	FormatJSON     = "json"
+	FormatCSV      = "csv"
+	FormatMarkdown = "markdown"
+)
+
+// Transcript is a channel's messages plus the metadata describing the export
+type Transcript struct {
+	GuildID     string
+	ChannelID   string
+	ChannelName string
+	ExportedAt  time.Time
+	// After and Before bound the exported range; zero values are unbounded
+	After  time.Time
+	Before time.Time
+	// Messages are ordered oldest first
+	Messages []*discordgo.Message
+}
+
+// Extension returns the file extension for a format
+func Extension(format string) string {
+	if format == FormatMarkdown {
+		return "md"
+	}
+	return format
+}
+
+// MimeType returns the MIME type for a format
+func MimeType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatMarkdown:
+		return "text/markdown"
+	default:
+		return "application/json"
+	}
+}
+
+// Render encodes a transcript. formatMessage produces the per-message objects
+// for the JSON format, matching get_channel_messages.
+func Render(format string, t *Transcript, formatMessage func(*discordgo.Message) types.MessageSummary) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return renderJSON(t, formatMessage)
+	case FormatCSV:
+		return renderCSV(t)
+	case FormatMarkdown:
+		return renderMarkdown(t), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+func renderJSON(t *Transcript, formatMessage func(*discordgo.Message) types.MessageSummary) ([]byte, error) {
+	messages := make([]types.MessageSummary, len(t.Messages))
+	for i, msg := range t.Messages {
+		messages[i] = formatMessage(msg)
+	}
+
+	doc := map[string]interface{}{
+		"guild_id":      t.GuildID,
+		"channel_id":    t.ChannelID,
+		"channel_name":  t.ChannelName,
+		"exported_at":   t.ExportedAt.UTC().Format(time.RFC3339),
+		"message_count": len(t.Messages),
+		"messages":      messages,
+	}
+	if !t.After.IsZero() {
+		doc["after"] = t.After.UTC().Format(time.RFC3339)
+	}
+	if !t.Before.IsZero() {
+		doc["before"] = t.Before.UTC().Format(time.RFC3339)
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func renderCSV(t *Transcript) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"id", "timestamp", "author_id", "author_username", "content", "attachments", "reply_to"}
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, msg := range t.Messages {
+		authorID, username := author(msg)
+		replyTo := ""
+		if msg.MessageReference != nil {
+			replyTo = msg.MessageReference.MessageID
+		}
+
+		record := []string{
+			msg.ID,
+			msg.Timestamp.UTC().Format(time.RFC3339),
+			authorID,
+			username,
+			msg.Content,
+			strings.Join(attachmentURLs(msg), " "),
+			replyTo,
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func renderMarkdown(t *Transcript) []byte {
+	var b strings.Builder
+
+	name := t.ChannelName
+	if name == "" {
+		name = t.ChannelID
+	}
+	fmt.Fprintf(&b, "# #%s\n\n", name)
+	fmt.Fprintf(&b, "Exported %s, %d messages", t.ExportedAt.UTC().Format("2006-01-02 15:04 UTC"), len(t.Messages))
+	if !t.After.IsZero() {
+		fmt.Fprintf(&b, ", after %s", t.After.UTC().Format(time.RFC3339))
+	}
+	if !t.Before.IsZero() {
+		fmt.Fprintf(&b, ", before %s", t.Before.UTC().Format(time.RFC3339))
+	}
+	b.WriteString("\n")
+
+	day := ""
+	for _, msg := range t.Messages {
+		ts := msg.Timestamp.UTC()
+		if d := ts.Format("2006-01-02"); d != day {
+			day = d
+			fmt.Fprintf(&b, "\n## %s\n", day)
+		}
+
+		_, username := author(msg)
+		fmt.Fprintf(&b, "\n**%s** — %s\n", username, ts.Format("15:04"))
+		if msg.MessageReference != nil {
+			fmt.Fprintf(&b, "> ↪ reply to %s\n", msg.MessageReference.MessageID)
+		}
+		if msg.Content != "" {
+			b.WriteString(msg.Content)
+			b.WriteString("\n")
+		}
+		for _, att := range msg.Attachments {
+			fmt.Fprintf(&b, "📎 [%s](%s)\n", att.Filename, att.URL)
+		}
+	}
+
+	return []byte(b.String())
+}
+
+func author(msg *discordgo.Message) (string, string) {
+	if msg.Author == nil {
+		return "", "unknown"
+	}
+	return msg.Author.ID, msg.Author.Username
+}
+
+func attachmentURLs(msg *discordgo.Message) []string {
+	urls := make([]string, len(msg.Attachments))
+	for i, att := range msg.Attachments {
+		urls[i] = att.URL
+	}
+	return urls
+}
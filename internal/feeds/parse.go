@@ -0,0 +1,297 @@
+package feeds
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxSummaryLength is the number of characters kept from an entry's summary
+const maxSummaryLength = 300
+
+// Document is a parsed RSS or Atom feed
+type Document struct {
+	Title   string
+	Link    string
+	Entries []Entry
+}
+
+// Entry is one item of a feed, in the order the feed lists it
+type Entry struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Link      string    `json:"link,omitempty"`
+	Summary   string    `json:"summary,omitempty"`
+	Author    string    `json:"author,omitempty"`
+	ImageURL  string    `json:"image_url,omitempty"`
+	Published time.Time `json:"published"`
+}
+
+// rssChannel is the channel of an RSS 2.0 feed. Links is a list because
+// feeds often add an atom:link element, which shares the local name.
+type rssChannel struct {
+	Title string    `xml:"title"`
+	Links []string  `xml:"link"`
+	Items []rssItem `xml:"item"`
+}
+
+// rssDocument covers RSS 2.0 (items in the channel) and RSS 1.0 (items
+// next to it)
+type rssDocument struct {
+	Channel rssChannel `xml:"channel"`
+	Items   []rssItem  `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string     `xml:"title"`
+	Links       []string   `xml:"link"`
+	GUID        string     `xml:"guid"`
+	Description string     `xml:"description"`
+	Encoded     string     `xml:"encoded"`
+	PubDate     string     `xml:"pubDate"`
+	Date        string     `xml:"date"`
+	Author      string     `xml:"author"`
+	Creator     string     `xml:"creator"`
+	Enclosures  []mediaRef `xml:"enclosure"`
+	Media       []mediaRef `xml:"content"`
+	Thumbnails  []mediaRef `xml:"thumbnail"`
+}
+
+type atomDocument struct {
+	Title   string      `xml:"title"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Links     []atomLink `xml:"link"`
+	Summary   string     `xml:"summary"`
+	Content   string     `xml:"content"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Authors   []struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+	// YouTube and others group media elements
+	Thumbnails       []mediaRef `xml:"group>thumbnail"`
+	GroupDescription string     `xml:"group>description"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type mediaRef struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
+}
+
+// Parse reads an RSS 2.0, RSS 1.0 or Atom feed
+func Parse(data []byte) (*Document, error) {
+	root, err := rootElement(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch root {
+	case "rss", "RDF":
+		var doc rssDocument
+		if err := newDecoder(data).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("invalid RSS feed: %w", err)
+		}
+		return doc.document(), nil
+	case "feed":
+		var doc atomDocument
+		if err := newDecoder(data).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("invalid Atom feed: %w", err)
+		}
+		return doc.document(), nil
+	default:
+		return nil, fmt.Errorf("not an RSS or Atom feed (root element <%s>)", root)
+	}
+}
+
+// rootElement returns the local name of the document's first element
+func rootElement(data []byte) (string, error) {
+	decoder := newDecoder(data)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return "", fmt.Errorf("not an RSS or Atom feed (no elements)")
+		}
+		if err != nil {
+			return "", fmt.Errorf("not an RSS or Atom feed: %w", err)
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Local, nil
+		}
+	}
+}
+
+// newDecoder creates a lenient decoder that also reads Latin-1 feeds
+func newDecoder(data []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		switch strings.ToLower(charset) {
+		case "utf-8", "us-ascii", "ascii":
+			return input, nil
+		case "iso-8859-1", "latin1", "windows-1252":
+			return latin1Reader(input)
+		}
+		return nil, fmt.Errorf("unsupported feed encoding %q", charset)
+	}
+	return decoder
+}
+
+// latin1Reader converts ISO-8859-1 text to UTF-8. Windows-1252 is read the
+// same way, which only differs for a few punctuation characters.
+func latin1Reader(input io.Reader) (io.Reader, error) {
+	raw, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(raw))
+	for _, b := range raw {
+		out = utf8.AppendRune(out, rune(b))
+	}
+	return bytes.NewReader(out), nil
+}
+
+func (d *rssDocument) document() *Document {
+	doc := &Document{
+		Title: strings.TrimSpace(d.Channel.Title),
+		Link:  firstNonEmpty(d.Channel.Links...),
+	}
+	items := d.Channel.Items
+	if len(items) == 0 {
+		items = d.Items
+	}
+	for _, item := range items {
+		entry := Entry{
+			Title:     cleanText(item.Title, 0),
+			Link:      firstNonEmpty(item.Links...),
+			Summary:   cleanText(firstNonEmpty(item.Description, item.Encoded), maxSummaryLength),
+			Author:    cleanText(firstNonEmpty(item.Creator, item.Author), 0),
+			Published: parseTime(firstNonEmpty(item.PubDate, item.Date)),
+		}
+		entry.ID = firstNonEmpty(item.GUID, entry.Link, entry.Title+entry.Published.String())
+		entry.ImageURL = imageURL(item.Thumbnails, item.Media, item.Enclosures)
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return doc
+}
+
+func (d *atomDocument) document() *Document {
+	doc := &Document{
+		Title: cleanText(d.Title, 0),
+		Link:  alternateLink(d.Links),
+	}
+	for _, e := range d.Entries {
+		entry := Entry{
+			Title:     cleanText(e.Title, 0),
+			Link:      alternateLink(e.Links),
+			Summary:   cleanText(firstNonEmpty(e.Summary, e.Content, e.GroupDescription), maxSummaryLength),
+			Published: parseTime(firstNonEmpty(e.Published, e.Updated)),
+		}
+		if len(e.Authors) > 0 {
+			entry.Author = cleanText(e.Authors[0].Name, 0)
+		}
+		entry.ID = firstNonEmpty(e.ID, entry.Link, entry.Title+entry.Published.String())
+		var enclosures []mediaRef
+		for _, link := range e.Links {
+			if link.Rel == "enclosure" {
+				enclosures = append(enclosures, mediaRef{URL: link.Href, Type: link.Type})
+			}
+		}
+		entry.ImageURL = imageURL(e.Thumbnails, nil, enclosures)
+		doc.Entries = append(doc.Entries, entry)
+	}
+	return doc
+}
+
+// alternateLink returns the page an Atom element links to
+func alternateLink(links []atomLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	return ""
+}
+
+// imageURL picks the first image among an entry's media
+func imageURL(groups ...[]mediaRef) string {
+	for _, group := range groups {
+		for _, ref := range group {
+			if ref.URL == "" {
+				continue
+			}
+			if ref.Medium == "image" || strings.HasPrefix(ref.Type, "image/") || (ref.Medium == "" && ref.Type == "") {
+				return strings.TrimSpace(ref.URL)
+			}
+		}
+	}
+	return ""
+}
+
+// tagPattern matches HTML tags in summaries
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// cleanText strips HTML from feed text and collapses whitespace, cutting it
+// to limit characters when limit is positive
+func cleanText(s string, limit int) string {
+	s = html.UnescapeString(tagPattern.ReplaceAllString(s, " "))
+	s = strings.Join(strings.Fields(s), " ")
+	if runes := []rune(s); limit > 0 && len(runes) > limit {
+		s = strings.TrimSpace(string(runes[:limit-1])) + "…"
+	}
+	return s
+}
+
+// timeLayouts are the date formats seen in RSS and Atom feeds
+var timeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC3339,
+	time.RFC3339Nano,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseTime parses a feed date, returning the zero time when it is unknown
+func parseTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
+// firstNonEmpty returns the first argument that is not blank, trimmed
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,450 @@
+// Package feeds polls RSS and Atom feeds and hands their new entries to a
+// poster. Feeds are persisted to disk with the entries already seen, so a
+// restart neither loses feeds nor reposts old entries.
+package feeds
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+const (
+	// MaxFeeds bounds the number of watched feeds
+	MaxFeeds = 100
+	// maxSeen bounds the entry IDs remembered per feed
+	maxSeen = 500
+	// maxFeedBytes bounds the size of a fetched feed
+	maxFeedBytes = 5 << 20
+	// fetchTimeout bounds one fetch
+	fetchTimeout = 20 * time.Second
+)
+
+// ErrExists reports that a feed already posts to the channel
+var ErrExists = errors.New("feed is already watched for this channel")
+
+// Feed is a feed whose new entries are posted to a channel
+type Feed struct {
+	ID              string `json:"id"`
+	URL             string `json:"url"`
+	Title           string `json:"title"`
+	GuildID         string `json:"guild_id"`
+	ChannelID       string `json:"channel_id"`
+	IntervalMinutes int    `json:"interval_minutes"`
+
+	CreatedAt     time.Time  `json:"created_at"`
+	NextCheckAt   time.Time  `json:"next_check_at"`
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"`
+	LastPostedAt  *time.Time `json:"last_posted_at,omitempty"`
+	Posted        int        `json:"posted"`
+	LastError     string     `json:"last_error,omitempty"`
+
+	// ETag and LastModified make polls conditional, so unchanged feeds are
+	// not downloaded again
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// storedFeeds is the layout of the persistence file
+type storedFeeds struct {
+	NextID int                 `json:"next_id"`
+	Feeds  []*Feed             `json:"feeds"`
+	Seen   map[string][]string `json:"seen"`
+}
+
+// PostFunc posts new entries of a feed, oldest first
+type PostFunc func(feed Feed, entries []Entry) error
+
+// Watcher polls feeds when they are due. Feeds are saved to a JSON file on
+// every change; checks missed while the server was down happen at startup.
+type Watcher struct {
+	path       string
+	maxEntries int
+	client     *http.Client
+	logger     *logrus.Logger
+
+	feeds  map[string]*Feed
+	seen   map[string][]string
+	nextID int
+	mutex  sync.Mutex
+
+	wake     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewWatcher creates a watcher, loading the feeds saved at path. At most
+// maxEntries new entries of a feed are posted per check.
+func NewWatcher(path string, maxEntries int, logger *logrus.Logger) (*Watcher, error) {
+	w := &Watcher{
+		path:       path,
+		maxEntries: maxEntries,
+		client:     &http.Client{Timeout: fetchTimeout},
+		logger:     logger,
+		feeds:      make(map[string]*Feed),
+		seen:       make(map[string][]string),
+		wake:       make(chan struct{}, 1),
+		stop:       make(chan struct{}),
+	}
+	if err := w.load(); err != nil {
+		return nil, fmt.Errorf("failed to load feeds from %s: %w", path, err)
+	}
+	return w, nil
+}
+
+// Start posts the new entries of due feeds with post until Stop is called
+func (w *Watcher) Start(post PostFunc) {
+	go func() {
+		timer := time.NewTimer(w.untilNext())
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-w.wake:
+			case <-timer.C:
+				w.checkDue(post, time.Now())
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(w.untilNext())
+		}
+	}()
+}
+
+// Stop stops polling
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
+
+// Add fetches a feed to check that it parses, marks its current entries as
+// seen so only later ones are posted, and saves it, assigning its ID. It
+// returns the feed and its newest entry, if any.
+func (w *Watcher) Add(feed Feed) (Feed, *Entry, error) {
+	w.mutex.Lock()
+	for _, existing := range w.feeds {
+		if existing.URL == feed.URL && existing.ChannelID == feed.ChannelID {
+			w.mutex.Unlock()
+			return Feed{}, nil, ErrExists
+		}
+	}
+	if len(w.feeds) >= MaxFeeds {
+		w.mutex.Unlock()
+		return Feed{}, nil, fmt.Errorf("feed limit reached (%d)", MaxFeeds)
+	}
+	w.mutex.Unlock()
+
+	doc, etag, lastModified, err := w.fetch(feed.URL, "", "")
+	if err != nil {
+		return Feed{}, nil, err
+	}
+
+	now := time.Now().UTC()
+	feed.Title = doc.Title
+	feed.CreatedAt = now
+	feed.LastCheckedAt = &now
+	feed.NextCheckAt = now.Add(time.Duration(feed.IntervalMinutes) * time.Minute)
+	feed.ETag = etag
+	feed.LastModified = lastModified
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.nextID++
+	feed.ID = fmt.Sprintf("fd%d", w.nextID)
+	w.feeds[feed.ID] = &feed
+	w.seen[feed.ID] = remember(nil, doc.Entries)
+	if err := w.save(); err != nil {
+		delete(w.feeds, feed.ID)
+		delete(w.seen, feed.ID)
+		w.nextID--
+		return Feed{}, nil, err
+	}
+
+	w.signal()
+	var latest *Entry
+	if len(doc.Entries) > 0 {
+		latest = &doc.Entries[0]
+	}
+	return feed, latest, nil
+}
+
+// Remove deletes a feed, returning it and whether it existed
+func (w *Watcher) Remove(id string) (Feed, bool, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	feed, ok := w.feeds[id]
+	if !ok {
+		return Feed{}, false, nil
+	}
+	seen := w.seen[id]
+	delete(w.feeds, id)
+	delete(w.seen, id)
+	if err := w.save(); err != nil {
+		w.feeds[id] = feed
+		w.seen[id] = seen
+		return Feed{}, false, err
+	}
+
+	w.signal()
+	return *feed, true, nil
+}
+
+// List returns the feeds posting to a guild, or every feed when guildID is
+// empty, ordered by creation
+func (w *Watcher) List(guildID string) []Feed {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	feeds := make([]Feed, 0, len(w.feeds))
+	for _, feed := range w.feeds {
+		if guildID == "" || feed.GuildID == guildID {
+			feeds = append(feeds, *feed)
+		}
+	}
+	sort.Slice(feeds, func(i, j int) bool {
+		return feeds[i].CreatedAt.Before(feeds[j].CreatedAt)
+	})
+	return feeds
+}
+
+// checkDue polls every feed whose next check has passed. Fetching and
+// posting happen outside the lock so tools stay responsive.
+func (w *Watcher) checkDue(post PostFunc, now time.Time) {
+	w.mutex.Lock()
+	var due []Feed
+	for _, feed := range w.feeds {
+		if !feed.NextCheckAt.After(now) {
+			due = append(due, *feed)
+			feed.NextCheckAt = now.Add(time.Duration(feed.IntervalMinutes) * time.Minute).UTC()
+		}
+	}
+	w.mutex.Unlock()
+
+	for _, feed := range due {
+		w.check(feed, post, now)
+	}
+
+	if len(due) > 0 {
+		w.mutex.Lock()
+		if err := w.save(); err != nil {
+			w.logger.Warn(err)
+		}
+		w.mutex.Unlock()
+	}
+}
+
+// check polls one feed and posts its unseen entries. Entries beyond
+// maxEntries are marked seen without being posted; entries whose post
+// failed are tried again on the next check.
+func (w *Watcher) check(feed Feed, post PostFunc, now time.Time) {
+	w.logger.Debugf("Checking feed %s (%s)", feed.ID, feed.URL)
+	doc, etag, lastModified, err := w.fetch(feed.URL, feed.ETag, feed.LastModified)
+
+	var fresh []Entry
+	if err == nil && doc != nil {
+		w.mutex.Lock()
+		seen := make(map[string]bool, len(w.seen[feed.ID]))
+		for _, id := range w.seen[feed.ID] {
+			seen[id] = true
+		}
+		w.mutex.Unlock()
+		for _, entry := range doc.Entries {
+			if !seen[entry.ID] {
+				fresh = append(fresh, entry)
+			}
+		}
+	}
+
+	// Post the newest few, oldest first
+	newestFirst(fresh)
+	toPost := fresh[:min(len(fresh), w.maxEntries)]
+	for i, j := 0, len(toPost)-1; i < j; i, j = i+1, j-1 {
+		toPost[i], toPost[j] = toPost[j], toPost[i]
+	}
+
+	var postErr error
+	if len(toPost) > 0 {
+		postErr = post(feed, toPost)
+	}
+	if err == nil && postErr != nil {
+		err = postErr
+	}
+	if err != nil {
+		w.logger.Warnf("Feed %s failed: %v", feed.ID, err)
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	stored, ok := w.feeds[feed.ID]
+	if !ok {
+		return
+	}
+	checked := now.UTC()
+	stored.LastCheckedAt = &checked
+	stored.LastError = ""
+	if err != nil {
+		stored.LastError = err.Error()
+	}
+	if doc == nil || postErr != nil {
+		return
+	}
+	if doc.Title != "" {
+		stored.Title = doc.Title
+	}
+	stored.ETag = etag
+	stored.LastModified = lastModified
+	w.seen[feed.ID] = remember(w.seen[feed.ID], doc.Entries)
+	if len(toPost) > 0 {
+		stored.Posted += len(toPost)
+		stored.LastPostedAt = &checked
+	}
+}
+
+// fetch downloads and parses a feed. A nil document with no error means the
+// feed has not changed since etag or lastModified.
+func (w *Watcher) fetch(url, etag, lastModified string) (*Document, string, string, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("invalid feed URL: %w", err)
+	}
+	req.Header.Set("User-Agent", "discord-mcp feed watcher")
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.5")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to fetch feed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, etag, lastModified, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", "", fmt.Errorf("failed to fetch feed: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedBytes+1))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to read feed: %w", err)
+	}
+	if len(data) > maxFeedBytes {
+		return nil, "", "", fmt.Errorf("feed is larger than %d bytes", maxFeedBytes)
+	}
+
+	doc, err := Parse(data)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return doc, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"), nil
+}
+
+// remember adds the IDs of entries to seen, keeping the current entries and
+// the most recent others up to maxSeen
+func remember(seen []string, entries []Entry) []string {
+	ids := make([]string, 0, len(entries)+len(seen))
+	present := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if !present[entry.ID] {
+			present[entry.ID] = true
+			ids = append(ids, entry.ID)
+		}
+	}
+	for _, id := range seen {
+		if len(ids) >= maxSeen {
+			break
+		}
+		if !present[id] {
+			present[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// newestFirst orders entries by publication time when every entry has one.
+// Otherwise the feed's own order is kept, which is newest first by
+// convention.
+func newestFirst(entries []Entry) {
+	for _, entry := range entries {
+		if entry.Published.IsZero() {
+			return
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Published.After(entries[j].Published)
+	})
+}
+
+// untilNext returns the time until the earliest next check, capped so the
+// timer also recovers from clock changes
+func (w *Watcher) untilNext() time.Duration {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	wait := time.Hour
+	for _, feed := range w.feeds {
+		if d := time.Until(feed.NextCheckAt); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// signal wakes the poll loop to recompute its timer
+func (w *Watcher) signal() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// save writes every feed to the persistence file; callers must hold the
+// lock
+func (w *Watcher) save() error {
+	stored := storedFeeds{NextID: w.nextID, Feeds: make([]*Feed, 0, len(w.feeds)), Seen: w.seen}
+	for _, feed := range w.feeds {
+		stored.Feeds = append(stored.Feeds, feed)
+	}
+	sort.Slice(stored.Feeds, func(i, j int) bool {
+		return stored.Feeds[i].CreatedAt.Before(stored.Feeds[j].CreatedAt)
+	})
+
+	return jsonfile.Save(w.path, stored)
+}
+
+// load reads saved feeds
+func (w *Watcher) load() error {
+	var stored storedFeeds
+	if found, err := jsonfile.Load(w.path, &stored); err != nil || !found {
+		return err
+	}
+	w.nextID = stored.NextID
+	for _, feed := range stored.Feeds {
+		w.feeds[feed.ID] = feed
+		w.seen[feed.ID] = stored.Seen[feed.ID]
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+// Package giveaway tracks reaction-entry giveaways and draws their winners.
+// Giveaways are persisted to disk so draws still work after a restart.
+package giveaway
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+// MaxGiveaways bounds the number of stored giveaways
+const MaxGiveaways = 100
+
+// MaxWinners bounds the winners drawn per giveaway
+const MaxWinners = 20
+
+// Giveaway statuses
+const (
+	StatusOpen  = "open"
+	StatusDrawn = "drawn"
+)
+
+// Giveaway is a message members enter by reacting with Emoji
+type Giveaway struct {
+	ID           string     `json:"id"`
+	GuildID      string     `json:"guild_id,omitempty"`
+	ChannelID    string     `json:"channel_id"`
+	MessageID    string     `json:"message_id"`
+	Prize        string     `json:"prize"`
+	Description  string     `json:"description,omitempty"`
+	Emoji        string     `json:"emoji"`
+	EmojiAPIName string     `json:"emoji_api_name"`
+	WinnerCount  int        `json:"winner_count"`
+	EndsAt       *time.Time `json:"ends_at,omitempty"`
+	Status       string     `json:"status"`
+	Winners      []string   `json:"winners,omitempty"`
+	EntrantCount int        `json:"entrant_count"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DrawnAt      *time.Time `json:"drawn_at,omitempty"`
+}
+
+// storedGiveaways is the layout of the persistence file
+type storedGiveaways struct {
+	NextID    int         `json:"next_id"`
+	Giveaways []*Giveaway `json:"giveaways"`
+}
+
+// Store holds giveaways and saves them to a JSON file on every change
+type Store struct {
+	path string
+
+	giveaways map[string]*Giveaway
+	nextID    int
+	mutex     sync.Mutex
+}
+
+// NewStore creates a store, loading the giveaways saved at path
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:      path,
+		giveaways: make(map[string]*Giveaway),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load giveaways from %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Add validates and saves an open giveaway, assigning its ID
+func (s *Store) Add(g Giveaway) (Giveaway, error) {
+	if g.ChannelID == "" || g.MessageID == "" {
+		return Giveaway{}, fmt.Errorf("channel and message are required")
+	}
+	if g.Prize == "" {
+		return Giveaway{}, fmt.Errorf("prize is required")
+	}
+	if g.WinnerCount < 1 || g.WinnerCount > MaxWinners {
+		return Giveaway{}, fmt.Errorf("winner count must be between 1 and %d", MaxWinners)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.giveaways) >= MaxGiveaways {
+		return Giveaway{}, fmt.Errorf("giveaway limit reached (%d)", MaxGiveaways)
+	}
+
+	s.nextID++
+	g.ID = fmt.Sprintf("g%d", s.nextID)
+	g.Status = StatusOpen
+	g.CreatedAt = time.Now().UTC()
+	s.giveaways[g.ID] = &g
+	if err := s.save(); err != nil {
+		delete(s.giveaways, g.ID)
+		return Giveaway{}, err
+	}
+	return g, nil
+}
+
+// Get returns a giveaway by ID
+func (s *Store) Get(id string) (Giveaway, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	g, ok := s.giveaways[id]
+	if !ok {
+		return Giveaway{}, false
+	}
+	return *g, true
+}
+
+// List returns all giveaways ordered by creation
+func (s *Store) List() []Giveaway {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	giveaways := make([]Giveaway, 0, len(s.giveaways))
+	for _, g := range s.giveaways {
+		giveaways = append(giveaways, *g)
+	}
+	sort.Slice(giveaways, func(i, j int) bool {
+		return giveaways[i].CreatedAt.Before(giveaways[j].CreatedAt)
+	})
+	return giveaways
+}
+
+// SetWinners marks a giveaway drawn and saves its winners
+func (s *Store) SetWinners(id string, winners []string, entrants int) (Giveaway, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	g, ok := s.giveaways[id]
+	if !ok {
+		return Giveaway{}, fmt.Errorf("giveaway %s not found", id)
+	}
+	previous := *g
+
+	drawnAt := time.Now().UTC()
+	g.Status = StatusDrawn
+	g.Winners = winners
+	g.EntrantCount = entrants
+	g.DrawnAt = &drawnAt
+	if err := s.save(); err != nil {
+		*g = previous
+		return Giveaway{}, err
+	}
+	return *g, nil
+}
+
+// Draw picks up to n distinct entrants uniformly at random, skipping any in
+// exclude. It uses crypto/rand so results cannot be predicted from earlier
+// draws.
+func Draw(entrants []string, exclude []string, n int) ([]string, error) {
+	skip := make(map[string]bool, len(exclude))
+	for _, id := range exclude {
+		skip[id] = true
+	}
+	pool := make([]string, 0, len(entrants))
+	seen := make(map[string]bool, len(entrants))
+	for _, id := range entrants {
+		if !skip[id] && !seen[id] {
+			seen[id] = true
+			pool = append(pool, id)
+		}
+	}
+	if n > len(pool) {
+		n = len(pool)
+	}
+
+	// Partial Fisher-Yates shuffle: the first n slots end up a uniform sample
+	for i := 0; i < n; i++ {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(len(pool)-i)))
+		if err != nil {
+			return nil, err
+		}
+		k := i + int(j.Int64())
+		pool[i], pool[k] = pool[k], pool[i]
+	}
+	return pool[:n], nil
+}
+
+// save writes all giveaways to the persistence file; callers must hold the lock
+func (s *Store) save() error {
+	stored := storedGiveaways{NextID: s.nextID, Giveaways: make([]*Giveaway, 0, len(s.giveaways))}
+	for _, g := range s.giveaways {
+		stored.Giveaways = append(stored.Giveaways, g)
+	}
+	sort.Slice(stored.Giveaways, func(i, j int) bool {
+		return stored.Giveaways[i].CreatedAt.Before(stored.Giveaways[j].CreatedAt)
+	})
+
+	return jsonfile.Save(s.path, stored)
+}
+
+// load reads saved giveaways
+func (s *Store) load() error {
+	var stored storedGiveaways
+	if found, err := jsonfile.Load(s.path, &stored); err != nil || !found {
+		return err
+	}
+
+	s.nextID = stored.NextID
+	for _, g := range stored.Giveaways {
+		s.giveaways[g.ID] = g
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// maxAnalyticsMembers bounds the members scanned for role distribution
+const maxAnalyticsMembers = 10000
+
+// GetGuildAnalyticsTool implements the get_guild_analytics MCP tool
+type GetGuildAnalyticsTool struct {
+	handler *GuildHandler
+}
+
+// NewGetGuildAnalyticsTool creates a new get guild analytics tool
+func NewGetGuildAnalyticsTool(handler *GuildHandler) *GetGuildAnalyticsTool {
+	return &GetGuildAnalyticsTool{handler: handler}
+}
+
+// Execute executes the get_guild_analytics tool
+func (t *GetGuildAnalyticsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_guild_analytics", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	// Extract parameters
+	guildID := params.Arguments["guild_id"].(string)
+
+	days := 7
+	if daysVal, ok := params.Arguments["days"]; ok {
+		if daysFloat, ok := daysVal.(float64); ok {
+			days = int(daysFloat)
+		} else if daysInt, ok := daysVal.(int); ok {
+			days = daysInt
+		}
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	guild, err := t.handler.discord.GetGuild(guildID)
+	if err != nil {
+		return t.formatError("Failed to get guild info", err), nil
+	}
+
+	channels, err := t.handler.discord.GetChannels(guildID)
+	if err != nil {
+		return t.formatError("Failed to get channels", err), nil
+	}
+
+	roles, err := t.handler.discord.GetRoles(guildID)
+	if err != nil {
+		return t.formatError("Failed to get roles", err), nil
+	}
+
+	members, complete, err := t.fetchMembers(guildID)
+	if err != nil {
+		return t.formatError("Failed to list guild members", err), nil
+	}
+
+	channelNames := make(map[string]string, len(channels))
+	channelsByType := make(map[string]int)
+	for _, channel := range channels {
+		channelNames[channel.ID] = channel.Name
+		channelsByType[channelTypeToString(channel.Type)]++
+	}
+
+	activity := t.handler.discord.Activity()
+	trend := activity.Days(guildID, days)
+	since := time.Now().UTC().AddDate(0, 0, -(days - 1)).Format("2006-01-02")
+
+	// Joins of current members are known from their join dates even before
+	// the tracker started; leaves are only known from observed events
+	joinedByDay := make(map[string]int)
+	for _, member := range members {
+		if day := member.JoinedAt.UTC().Format("2006-01-02"); day >= since {
+			joinedByDay[day]++
+		}
+	}
+	memberTrend := make([]map[string]interface{}, len(trend))
+	for i, day := range trend {
+		memberTrend[i] = map[string]interface{}{
+			"date":            day.Date,
+			"joined_members":  joinedByDay[day.Date],
+			"observed_joins":  day.Joins,
+			"observed_leaves": day.Leaves,
+			"messages":        day.Messages,
+		}
+	}
+
+	topChannels := activity.TopChannels(guildID, days, 10)
+	mostActive := make([]map[string]interface{}, len(topChannels))
+	for i, channel := range topChannels {
+		mostActive[i] = map[string]interface{}{
+			"channel_id":   channel.ChannelID,
+			"channel_name": channelNames[channel.ChannelID],
+			"messages":     channel.Messages,
+		}
+	}
+
+	memberCount := guild.MemberCount
+	if memberCount == 0 {
+		memberCount = guild.ApproximateMemberCount
+	}
+
+	snapshot := map[string]interface{}{
+		"guild_id":          guildID,
+		"guild_name":        guild.Name,
+		"generated_at":      time.Now().UTC().Format(time.RFC3339),
+		"days":              days,
+		"tracking_since":    activity.Since().UTC().Format(time.RFC3339),
+		"member_count":      memberCount,
+		"member_trend":      memberTrend,
+		"channel_count":     len(channels),
+		"channels_by_type":  channelsByType,
+		"role_distribution": t.roleDistribution(guildID, roles, members),
+		"members_scanned":   len(members),
+		"members_complete":  complete,
+		"boost": map[string]interface{}{
+			"level":              int(guild.PremiumTier),
+			"subscription_count": guild.PremiumSubscriptionCount,
+		},
+		"most_active_channels": mostActive,
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "analytics.summary", guild.Name, days),
+			Data: snapshot,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetGuildAnalyticsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_guild_analytics", "Get a server-wide analytics snapshot: member trends, channel and role breakdowns, boost level and most active channels")
+}
+
+// fetchMembers pages through guild members up to maxAnalyticsMembers and
+// reports whether every member was read
+func (t *GetGuildAnalyticsTool) fetchMembers(guildID string) ([]*discordgo.Member, bool, error) {
+	var all []*discordgo.Member
+	after := ""
+	for len(all) < maxAnalyticsMembers {
+		var page []*discordgo.Member
+		_, err := t.handler.discord.Retry(func() (err error) {
+			page, err = t.handler.discord.Session().GuildMembers(guildID, after, 1000)
+			return err
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		all = append(all, page...)
+		if len(page) < 1000 {
+			return all, true, nil
+		}
+		after = page[len(page)-1].User.ID
+	}
+	return all, false, nil
+}
+
+// roleDistribution counts members per role, highest role first
+func (t *GetGuildAnalyticsTool) roleDistribution(guildID string, roles []*discordgo.Role, members []*discordgo.Member) []map[string]interface{} {
+	counts := make(map[string]int)
+	for _, member := range members {
+		for _, roleID := range member.Roles {
+			counts[roleID]++
+		}
+	}
+
+	sorted := make([]*discordgo.Role, 0, len(roles))
+	for _, role := range roles {
+		// @everyone shares the guild's ID and applies to every member
+		if role.ID != guildID {
+			sorted = append(sorted, role)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Position > sorted[j].Position })
+
+	distribution := make([]map[string]interface{}, len(sorted))
+	for i, role := range sorted {
+		distribution[i] = map[string]interface{}{
+			"role_id":      role.ID,
+			"role_name":    role.Name,
+			"member_count": counts[role.ID],
+		}
+	}
+	return distribution
+}
+
+// formatError creates a standardized error response
+func (t *GetGuildAnalyticsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
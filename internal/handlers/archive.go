@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"discord-mcp/internal/archive"
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// archiveStreamBatch is how many search results each streamed chunk holds
+const archiveStreamBatch = 25
+
+// SearchArchiveTool implements the search_archive MCP tool
+type SearchArchiveTool struct {
+	discord     *discord.Client
+	permissions *permissions.Checker
+	validator   *validation.Validator
+}
+
+// NewSearchArchiveTool creates a new search archive tool
+func NewSearchArchiveTool(discordClient *discord.Client, permChecker *permissions.Checker, validator *validation.Validator) *SearchArchiveTool {
+	return &SearchArchiveTool{
+		discord:     discordClient,
+		permissions: permChecker,
+		validator:   validator,
+	}
+}
+
+// Execute executes the search_archive tool
+func (t *SearchArchiveTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("search_archive", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.discord.Archive()
+	if store == nil {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "archive.disabled"),
+				Data: map[string]interface{}{
+					"error_type": "configuration",
+					"message":    "archive disabled",
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	stream, invalid := newResultStream(t.discord, "search_archive", params)
+	if invalid != nil {
+		return *invalid, nil
+	}
+
+	query := archive.Query{Limit: intArgument(params.Arguments, "limit", 25)}
+	query.Text, _ = params.Arguments["query"].(string)
+	query.GuildID, _ = params.Arguments["guild_id"].(string)
+	query.ChannelID, _ = params.Arguments["channel_id"].(string)
+	query.AuthorID, _ = params.Arguments["author_id"].(string)
+
+	for name, dst := range map[string]*time.Time{"after": &query.After, "before": &query.Before} {
+		value, ok := params.Arguments[name].(string)
+		if !ok {
+			continue
+		}
+		parsed, err := parseExportTime(value, guildLocation(t.discord, query.GuildID, query.ChannelID))
+		if err != nil {
+			return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+				fmt.Sprintf("%s must be %s", name, timeFormats), name)), nil
+		}
+		*dst = parsed
+	}
+
+	if query.Text == "" && query.GuildID == "" && query.ChannelID == "" && query.AuthorID == "" {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+			"provide a query or at least one of guild_id, channel_id or author_id", nil)), nil
+	}
+
+	results, err := store.Search(query)
+	if err != nil {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "archive.search_failed", err),
+				Data: map[string]interface{}{
+					"error_type": "archive",
+					"message":    "Archive search failed",
+					"details":    err.Error(),
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	// Only return messages from channels the bot may still read
+	readable := make(map[string]bool)
+	formatted := make([]map[string]interface{}, 0, len(results))
+	streamed := 0
+	for _, result := range results {
+		allowed, checked := readable[result.ChannelID]
+		if !checked {
+			allowed = t.permissions.ValidateMessageOperation("get_messages", result.ChannelID, nil) == nil
+			readable[result.ChannelID] = allowed
+		}
+		if !allowed {
+			continue
+		}
+
+		entry := map[string]interface{}{
+			"message_id":      result.ID,
+			"guild_id":        result.GuildID,
+			"channel_id":      result.ChannelID,
+			"author_id":       result.AuthorID,
+			"author_username": result.AuthorUsername,
+			"content":         result.Content,
+			"timestamp":       time.UnixMilli(result.CreatedAt).UTC().Format(time.RFC3339),
+			"deleted":         result.Deleted != 0,
+			"url":             fmt.Sprintf("https://discord.com/channels/%s/%s/%s", result.GuildID, result.ChannelID, result.ID),
+		}
+		if result.Snippet != "" {
+			entry["snippet"] = result.Snippet
+		}
+		if result.EditedAt != nil {
+			entry["edited_at"] = time.UnixMilli(*result.EditedAt).UTC().Format(time.RFC3339)
+		}
+		formatted = append(formatted, entry)
+
+		// Stream results in batches while the remaining channels are checked
+		if stream != nil && len(formatted)-streamed == archiveStreamBatch {
+			stream.send(formatted[streamed:], archiveStreamBatch)
+			streamed = len(formatted)
+		}
+	}
+
+	data := map[string]interface{}{
+		"query":         query.Text,
+		"result_count":  len(formatted),
+		"results":       formatted,
+		"omitted_count": len(results) - len(formatted),
+	}
+	if stream != nil {
+		stream.send(formatted[streamed:], len(formatted)-streamed)
+		delete(data, "results")
+		data["streamed"] = stream.summary()
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "archive.found", len(formatted)),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *SearchArchiveTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("search_archive", "Full-text search over the local message archive with author, channel and date filters")
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"fmt"
+
+	"discord-mcp/internal/autoresponse"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// CreateAutoResponseTool implements the create_auto_response MCP tool
+type CreateAutoResponseTool struct {
+	handler *MessageHandler
+}
+
+// NewCreateAutoResponseTool creates a new create auto response tool
+func NewCreateAutoResponseTool(handler *MessageHandler) *CreateAutoResponseTool {
+	return &CreateAutoResponseTool{handler: handler}
+}
+
+// Execute executes the create_auto_response tool
+func (t *CreateAutoResponseTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("create_auto_response", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	rule := autoresponse.Rule{CooldownSeconds: intArgument(params.Arguments, "cooldown_seconds", 10)}
+	rule.Trigger = params.Arguments["trigger"].(string)
+	rule.Reply = params.Arguments["reply"].(string)
+	rule.Pattern, _ = params.Arguments["pattern"].(string)
+	rule.GuildID, _ = params.Arguments["guild_id"].(string)
+	if channelIDs, ok := params.Arguments["channel_ids"].([]interface{}); ok {
+		for _, channelID := range channelIDs {
+			if id, ok := channelID.(string); ok {
+				rule.ChannelIDs = append(rule.ChannelIDs, id)
+			}
+		}
+	}
+
+	// Validate permissions
+	if err := t.checkPermissions(rule); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		if validationErr, ok := err.(*validation.ValidationError); ok {
+			return validation.FormatValidationError(validationErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	created, err := t.handler.discord.AutoResponses().Add(rule)
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid auto-response", err.Error(), nil)), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "autoresponses.created", created.Trigger, created.ID),
+			Data: created,
+		}},
+	}, nil
+}
+
+// checkPermissions checks that the bot can reply in every scoped channel
+func (t *CreateAutoResponseTool) checkPermissions(rule autoresponse.Rule) error {
+	if rule.GuildID != "" {
+		if err := t.handler.permissions.CanViewGuild(rule.GuildID); err != nil {
+			return err
+		}
+	}
+	for _, channelID := range rule.ChannelIDs {
+		channel, err := t.handler.discord.GetChannel(channelID)
+		if err != nil {
+			return err
+		}
+		if rule.GuildID != "" && channel.GuildID != rule.GuildID {
+			return validation.NewValidationError("invalid channel",
+				fmt.Sprintf("channel %s is not in guild %s", channelID, rule.GuildID), "channel_ids")
+		}
+		if err := t.handler.permissions.CanSendMessages(channelID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDefinition returns the tool definition
+func (t *CreateAutoResponseTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("create_auto_response", "Register a reply the server sends by itself when a message matches exact text, a regex, or mentions the bot")
+}
+
+// formatError creates a standardized error response
+func (t *CreateAutoResponseTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListAutoResponsesTool implements the list_auto_responses MCP tool
+type ListAutoResponsesTool struct {
+	handler *MessageHandler
+}
+
+// NewListAutoResponsesTool creates a new list auto responses tool
+func NewListAutoResponsesTool(handler *MessageHandler) *ListAutoResponsesTool {
+	return &ListAutoResponsesTool{handler: handler}
+}
+
+// Execute executes the list_auto_responses tool
+func (t *ListAutoResponsesTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_auto_responses", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	rules := t.handler.discord.AutoResponses().List()
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "autoresponses.list", len(rules)),
+			Data: map[string]interface{}{
+				"auto_response_count": len(rules),
+				"auto_responses":      rules,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListAutoResponsesTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_auto_responses", "List registered auto-responses with their trigger counts")
+}
+
+// DeleteAutoResponseTool implements the delete_auto_response MCP tool
+type DeleteAutoResponseTool struct {
+	handler *MessageHandler
+}
+
+// NewDeleteAutoResponseTool creates a new delete auto response tool
+func NewDeleteAutoResponseTool(handler *MessageHandler) *DeleteAutoResponseTool {
+	return &DeleteAutoResponseTool{handler: handler}
+}
+
+// Execute executes the delete_auto_response tool
+func (t *DeleteAutoResponseTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("delete_auto_response", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	ruleID := params.Arguments["auto_response_id"].(string)
+	if !t.handler.discord.AutoResponses().Remove(ruleID) {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "autoresponses.not_found", ruleID),
+				Data: map[string]interface{}{
+					"error_type":       "not_found",
+					"auto_response_id": ruleID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "autoresponses.deleted", ruleID),
+			Data: map[string]interface{}{
+				"auto_response_id": ruleID,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *DeleteAutoResponseTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("delete_auto_response", "Delete a registered auto-response")
+}
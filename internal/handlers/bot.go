@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// BotHandler handles operations on the bot account itself
+type BotHandler struct {
+	discord   *discord.Client
+	validator *validation.Validator
+	logger    *logrus.Logger
+}
+
+// NewBotHandler creates a new bot handler
+func NewBotHandler(discordClient *discord.Client, validator *validation.Validator, logger *logrus.Logger) *BotHandler {
+	return &BotHandler{
+		discord:   discordClient,
+		validator: validator,
+		logger:    logger,
+	}
+}
+
+// SetPresenceTool implements the set_presence MCP tool
+type SetPresenceTool struct {
+	handler *BotHandler
+}
+
+// NewSetPresenceTool creates a new set presence tool
+func NewSetPresenceTool(handler *BotHandler) *SetPresenceTool {
+	return &SetPresenceTool{handler: handler}
+}
+
+// Execute executes the set_presence tool
+func (t *SetPresenceTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("set_presence", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	// Extract parameters
+	status := "online"
+	if statusVal, ok := params.Arguments["status"].(string); ok {
+		status = statusVal
+	}
+
+	activityType := "playing"
+	if typeVal, ok := params.Arguments["activity_type"].(string); ok {
+		activityType = typeVal
+	}
+
+	activityText, _ := params.Arguments["activity_text"].(string)
+	streamURL, _ := params.Arguments["stream_url"].(string)
+
+	if activityType == "streaming" && activityText != "" && streamURL == "" {
+		return validation.FormatValidationError(validation.NewValidationError("missing required parameter",
+			"stream_url is required for the streaming activity type", "stream_url")), nil
+	}
+
+	if err := t.handler.discord.SetPresence(status, activityType, activityText, streamURL); err != nil {
+		return t.formatError("Failed to set presence", err), nil
+	}
+
+	text := i18n.T(i18n.Locale(params), "bot.presence", status)
+	if activityText != "" {
+		text = i18n.T(i18n.Locale(params), "bot.presence_activity", status, activityType, activityText)
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: map[string]interface{}{
+				"status":        status,
+				"activity_type": activityType,
+				"activity_text": activityText,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *SetPresenceTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("set_presence", "Set the bot's status (online/idle/dnd/invisible) and activity text")
+}
+
+// formatError creates a standardized error response
+func (t *SetPresenceTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ToolLister reports the tools registered with the MCP server
+type ToolLister interface {
+	ToolNames() []string
+}
+
+// GetBotInfoTool implements the get_bot_info MCP tool
+type GetBotInfoTool struct {
+	handler *BotHandler
+	tools   ToolLister
+}
+
+// NewGetBotInfoTool creates a new get bot info tool. tools is usually the
+// MCP server the tool is registered with.
+func NewGetBotInfoTool(handler *BotHandler, tools ToolLister) *GetBotInfoTool {
+	return &GetBotInfoTool{handler: handler, tools: tools}
+}
+
+// Execute executes the get_bot_info tool
+func (t *GetBotInfoTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_bot_info", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	client := t.handler.discord
+	botUser, err := client.GetBotUser()
+	if err != nil {
+		return t.formatError("Failed to get bot user", err), nil
+	}
+
+	cfg := client.Config()
+	remaining, limit := client.RateLimitBudget()
+	uptime := client.Uptime()
+
+	guildCount := 0
+	if client.Session().State != nil {
+		guildCount = len(client.Session().State.Guilds)
+	}
+
+	data := map[string]interface{}{
+		"user":           formatUserProfile(client, botUser),
+		"application_id": client.ApplicationID(),
+		"connection":     client.ConnectionState(),
+		"guild_count":    guildCount,
+		"access": map[string]interface{}{
+			"allowed_guilds":     nonNil(cfg.Discord.AllowedGuilds),
+			"allowed_channels":   nonNil(cfg.Discord.AllowedChannels),
+			"denied_channels":    nonNil(cfg.Discord.DeniedChannels),
+			"allowed_categories": nonNil(cfg.Discord.AllowedCategories),
+			"denied_categories":  nonNil(cfg.Discord.DeniedCategories),
+		},
+		"intents":               client.Intents(),
+		"message_content":       client.HasMessageContent(),
+		"policies_enabled":      cfg.Policy.Enabled,
+		"rate_limit_remaining":  remaining,
+		"rate_limit_per_minute": limit,
+		"uptime_seconds":        int(uptime.Seconds()),
+		"accepts_names_for_ids": !cfg.Discord.StrictIDs,
+		"events_enabled":        cfg.Events.Enabled,
+		"archive_enabled":       client.Archive() != nil,
+		"voice_enabled":         cfg.Voice.Enabled,
+		"cache_enabled":         cfg.Cache.Enabled,
+		"event_buffer_enabled":  client.EventBuffer() != nil,
+	}
+
+	toolCount := 0
+	if t.tools != nil {
+		names := t.tools.ToolNames()
+		data["tools"] = names
+		toolCount = len(names)
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "bot.info",
+				botUser.Username, botUser.ID, guildCount, toolCount, uptime.Truncate(time.Second), remaining, limit),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetBotInfoTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_bot_info", "Describe the bot itself: its user and application, configured guild and channel access, gateway intents, enabled tools, remaining rate-limit budget and uptime")
+}
+
+// formatError creates a standardized error response
+func (t *GetBotInfoTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// nonNil returns list, or an empty list when it is nil, so that empty
+// settings are reported as [] rather than null
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/bridge"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// CreateBridgeTool implements the create_bridge MCP tool
+type CreateBridgeTool struct {
+	handler *ChannelHandler
+}
+
+// NewCreateBridgeTool creates a new create bridge tool
+func NewCreateBridgeTool(handler *ChannelHandler) *CreateBridgeTool {
+	return &CreateBridgeTool{handler: handler}
+}
+
+// Execute executes the create_bridge tool
+func (t *CreateBridgeTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("create_bridge", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Bridges()
+	if store == nil {
+		return bridgesDisabledResult(params), nil
+	}
+
+	sourceID := params.Arguments["source_channel_id"].(string)
+	targetID := params.Arguments["target_channel_id"].(string)
+	includeBots, _ := params.Arguments["include_bots"].(bool)
+	webhookName := "Bridge"
+	if val, ok := params.Arguments["webhook_name"].(string); ok {
+		webhookName = val
+	}
+	if sourceID == targetID {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+			"a channel cannot be bridged into itself", "target_channel_id")), nil
+	}
+
+	source, target, err := t.resolveChannels(sourceID, targetID)
+	if err != nil {
+		if validationErr, ok := err.(*validation.ValidationError); ok {
+			return validation.FormatValidationError(validationErr), nil
+		}
+		return t.formatError("Failed to get channel", err), nil
+	}
+
+	// Validate permissions
+	if err := t.checkPermissions(sourceID, targetID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	if err := store.Check(sourceID, targetID); err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+			fmt.Sprintf("channel %s is already bridged into %s", sourceID, targetID), "target_channel_id")), nil
+	}
+
+	var webhook *discordgo.Webhook
+	_, err = t.handler.discord.Retry(func() (err error) {
+		webhook, err = t.handler.discord.Session().WebhookCreate(targetID, webhookName, "")
+		return err
+	})
+	if err != nil {
+		return t.formatError("Failed to create webhook", err), nil
+	}
+
+	saved, err := store.Add(bridge.Bridge{
+		SourceGuildID:   source.GuildID,
+		SourceChannelID: sourceID,
+		TargetGuildID:   target.GuildID,
+		TargetChannelID: targetID,
+		WebhookID:       webhook.ID,
+		WebhookToken:    webhook.Token,
+		IncludeBots:     includeBots,
+	})
+	if err != nil {
+		// Without a saved bridge nothing posts through the webhook
+		if _, delErr := t.handler.discord.Retry(func() error {
+			_, err := t.handler.discord.Session().WebhookDeleteWithToken(webhook.ID, webhook.Token)
+			return err
+		}); delErr != nil {
+			t.handler.logger.Warnf("Failed to delete unused bridge webhook %s: %v", webhook.ID, delErr)
+		}
+		if errors.Is(err, bridge.ErrExists) {
+			return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+				fmt.Sprintf("channel %s is already bridged into %s", sourceID, targetID), "target_channel_id")), nil
+		}
+		return t.formatError("Failed to save bridge", err), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "bridges.created", saved.ID, sourceID, targetID),
+			Data: saved.Redacted(),
+		}},
+	}, nil
+}
+
+// resolveChannels looks up both ends of a bridge, checking that their guilds
+// are allowed and that the target can hold a webhook
+func (t *CreateBridgeTool) resolveChannels(sourceID, targetID string) (*discordgo.Channel, *discordgo.Channel, error) {
+	source, err := t.handler.discord.GetChannel(sourceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	target, err := t.handler.discord.GetChannel(targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if source.GuildID == "" {
+		return nil, nil, validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s is not in a guild", sourceID), "source_channel_id")
+	}
+	if target.Type != discordgo.ChannelTypeGuildText && target.Type != discordgo.ChannelTypeGuildNews {
+		return nil, nil, validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s must be a text or announcement channel", targetID), "target_channel_id")
+	}
+
+	// GetGuild refuses guilds outside discord.allowed_guilds
+	if _, err := t.handler.discord.GetGuild(source.GuildID); err != nil {
+		return nil, nil, err
+	}
+	if _, err := t.handler.discord.GetGuild(target.GuildID); err != nil {
+		return nil, nil, err
+	}
+	return source, target, nil
+}
+
+// checkPermissions checks that the bot can read the source channel and
+// create a webhook in the target
+func (t *CreateBridgeTool) checkPermissions(sourceID, targetID string) error {
+	if err := t.handler.permissions.CanViewChannel(sourceID); err != nil {
+		return err
+	}
+	return t.handler.permissions.CanManageWebhooks(targetID)
+}
+
+// GetDefinition returns the tool definition
+func (t *CreateBridgeTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("create_bridge", "Bridge two channels, possibly in different guilds: messages posted in the source channel are mirrored into the target through a webhook with the author's name and avatar")
+}
+
+// formatError creates a standardized error response
+func (t *CreateBridgeTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListBridgesTool implements the list_bridges MCP tool
+type ListBridgesTool struct {
+	handler *ChannelHandler
+}
+
+// NewListBridgesTool creates a new list bridges tool
+func NewListBridgesTool(handler *ChannelHandler) *ListBridgesTool {
+	return &ListBridgesTool{handler: handler}
+}
+
+// Execute executes the list_bridges tool
+func (t *ListBridgesTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_bridges", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Bridges()
+	if store == nil {
+		return bridgesDisabledResult(params), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	if guildID != "" {
+		// Validate permissions
+		if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return t.formatError("Permission check failed", err), nil
+		}
+	}
+
+	bridges := store.List(guildID)
+	for i := range bridges {
+		bridges[i] = bridges[i].Redacted()
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "bridges.list", len(bridges)),
+			Data: map[string]interface{}{
+				"bridge_count": len(bridges),
+				"bridges":      bridges,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListBridgesTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_bridges", "List the channel bridges created with create_bridge, with how many messages each has mirrored")
+}
+
+// formatError creates a standardized error response
+func (t *ListBridgesTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// DeleteBridgeTool implements the delete_bridge MCP tool
+type DeleteBridgeTool struct {
+	handler *ChannelHandler
+}
+
+// NewDeleteBridgeTool creates a new delete bridge tool
+func NewDeleteBridgeTool(handler *ChannelHandler) *DeleteBridgeTool {
+	return &DeleteBridgeTool{handler: handler}
+}
+
+// Execute executes the delete_bridge tool
+func (t *DeleteBridgeTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("delete_bridge", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Bridges()
+	if store == nil {
+		return bridgesDisabledResult(params), nil
+	}
+
+	bridgeID := params.Arguments["bridge_id"].(string)
+	deleteWebhook := true
+	if val, ok := params.Arguments["delete_webhook"].(bool); ok {
+		deleteWebhook = val
+	}
+
+	removed, ok, err := store.Remove(bridgeID)
+	if err != nil {
+		return t.formatError("Failed to save bridges", err), nil
+	}
+	if !ok {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "bridges.not_found", bridgeID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"bridge_id":  bridgeID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	data := map[string]interface{}{
+		"bridge_id":       bridgeID,
+		"deleted":         true,
+		"webhook_deleted": false,
+	}
+	// Mirroring stops once the bridge is removed; a webhook left behind
+	// stays in the target channel's integrations until deleted there
+	if deleteWebhook {
+		_, err := t.handler.discord.Retry(func() error {
+			_, err := t.handler.discord.Session().WebhookDeleteWithToken(removed.WebhookID, removed.WebhookToken)
+			return err
+		})
+		if err != nil {
+			data["webhook_error"] = err.Error()
+		} else {
+			data["webhook_deleted"] = true
+		}
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "bridges.deleted", bridgeID),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *DeleteBridgeTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("delete_bridge", "Remove a channel bridge and, by default, its webhook; messages already mirrored are kept")
+}
+
+// formatError creates a standardized error response
+func (t *DeleteBridgeTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// bridgesDisabledResult reports that bridges are turned off in the
+// configuration
+func bridgesDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "bridges.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "bridges disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// Broadcast target statuses
+const (
+	broadcastReady   = "ready"
+	broadcastSent    = "sent"
+	broadcastFailed  = "failed"
+	broadcastSkipped = "skipped"
+)
+
+// broadcastTarget is the outcome of a broadcast for one channel
+type broadcastTarget struct {
+	ChannelID   string `json:"channel_id"`
+	ChannelName string `json:"channel_name,omitempty"`
+	GuildID     string `json:"guild_id,omitempty"`
+	GuildName   string `json:"guild_name,omitempty"`
+	Status      string `json:"status"`
+	MessageID   string `json:"message_id,omitempty"`
+	MessageURL  string `json:"message_url,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// BroadcastMessageTool implements the broadcast_message MCP tool
+type BroadcastMessageTool struct {
+	handler *MessageHandler
+}
+
+// NewBroadcastMessageTool creates a new broadcast message tool
+func NewBroadcastMessageTool(handler *MessageHandler) *BroadcastMessageTool {
+	return &BroadcastMessageTool{handler: handler}
+}
+
+// Execute executes the broadcast_message tool
+func (t *BroadcastMessageTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("broadcast_message", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	cfg := t.handler.discord.Config().Broadcast
+	content, _ := params.Arguments["content"].(string)
+	dryRun, _ := params.Arguments["dry_run"].(bool)
+	interval := time.Duration(intArgument(params.Arguments, "interval_ms", cfg.IntervalMs)) * time.Millisecond
+
+	var embeds []*discordgo.MessageEmbed
+	if embedsSlice, ok := params.Arguments["embeds"].([]interface{}); ok {
+		embeds = make([]*discordgo.MessageEmbed, len(embedsSlice))
+		for i, embedData := range embedsSlice {
+			embed, err := parseEmbed(embedData)
+			if err != nil {
+				return validation.FormatValidationError(fmt.Errorf("invalid embed at index %d: %w", i, err)), nil
+			}
+			embeds[i] = embed
+		}
+	}
+
+	channelIDs, err := t.channelIDs(params.Arguments)
+	if err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	// Check links before anything is sent; every target gets the same
+	// message, so one scan covers them all
+	if blocked := checkOutbound(t.handler.discord, params, channelIDs[0], content, embeds); blocked != nil {
+		return *blocked, nil
+	}
+
+	targets := make([]broadcastTarget, 0, len(channelIDs))
+	sent, failed, skipped := 0, 0, 0
+	attempted := false
+	for _, channelID := range channelIDs {
+		target := t.resolveTarget(channelID)
+		switch {
+		case target.Status == broadcastSkipped:
+			skipped++
+		case dryRun:
+			target.Status = broadcastReady
+		default:
+			// Pace the sends so a long list does not crowd out other
+			// traffic to Discord
+			if attempted && interval > 0 {
+				time.Sleep(interval)
+			}
+			attempted = true
+			t.send(&target, content, embeds)
+			if target.Status == broadcastSent {
+				sent++
+			} else {
+				failed++
+			}
+		}
+		targets = append(targets, target)
+	}
+
+	data := map[string]interface{}{
+		"dry_run":      dryRun,
+		"target_count": len(targets),
+		"sent":         sent,
+		"failed":       failed,
+		"skipped":      skipped,
+		"interval_ms":  interval.Milliseconds(),
+		"targets":      targets,
+	}
+	if list, ok := params.Arguments["list"].(string); ok {
+		data["list"] = list
+	}
+
+	text := i18n.T(i18n.Locale(params), "broadcast.sent", sent, len(targets))
+	if dryRun {
+		text = i18n.T(i18n.Locale(params), "broadcast.preview", len(targets)-skipped, len(targets))
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: data,
+		}},
+		IsError: !dryRun && sent == 0,
+	}, nil
+}
+
+// channelIDs returns the channels to broadcast to, from the named list or
+// channel_ids, without duplicates
+func (t *BroadcastMessageTool) channelIDs(args map[string]interface{}) ([]string, error) {
+	listName, hasList := args["list"].(string)
+	rawIDs, hasIDs := args["channel_ids"].([]interface{})
+	if hasList == hasIDs {
+		return nil, validation.NewValidationError("invalid parameters", "pass either list or channel_ids", "list")
+	}
+
+	var ids []string
+	if hasList {
+		var names []string
+		for _, list := range t.handler.discord.Config().Broadcast.Lists {
+			if list.Name == listName {
+				ids = list.ChannelIDs
+			}
+			names = append(names, list.Name)
+		}
+		if ids == nil {
+			sort.Strings(names)
+			return nil, validation.NewValidationError("invalid parameter",
+				fmt.Sprintf("no broadcast list named %q; configured lists: %s", listName, strings.Join(names, ", ")), "list")
+		}
+	} else {
+		for _, raw := range rawIDs {
+			if id, ok := raw.(string); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique, nil
+}
+
+// resolveTarget looks up a channel and its guild and checks that the bot may
+// send there, marking the target skipped when it cannot
+func (t *BroadcastMessageTool) resolveTarget(channelID string) broadcastTarget {
+	target := broadcastTarget{ChannelID: channelID}
+
+	channel, err := t.handler.discord.GetChannel(channelID)
+	if err != nil {
+		target.Status = broadcastSkipped
+		target.Error = err.Error()
+		return target
+	}
+	target.ChannelName = channel.Name
+	target.GuildID = channel.GuildID
+	if channel.GuildID == "" {
+		target.Status = broadcastSkipped
+		target.Error = "not a guild channel"
+		return target
+	}
+
+	// GetGuild refuses guilds outside discord.allowed_guilds
+	guild, err := t.handler.discord.GetGuild(channel.GuildID)
+	if err != nil {
+		target.Status = broadcastSkipped
+		target.Error = err.Error()
+		return target
+	}
+	target.GuildName = guild.Name
+
+	err = t.handler.permissions.ValidateMessageOperation("send_message", channelID, map[string]interface{}{"tts": false})
+	if err != nil {
+		target.Status = broadcastSkipped
+		target.Error = err.Error()
+	}
+	return target
+}
+
+// send posts the broadcast to one target and records the outcome
+func (t *BroadcastMessageTool) send(target *broadcastTarget, content string, embeds []*discordgo.MessageEmbed) {
+	msgData := &discordgo.MessageSend{
+		Content: content,
+		Embeds:  embeds,
+	}
+
+	var message *discordgo.Message
+	_, _, err := t.handler.discord.SendQueued(target.ChannelID, outbound.PriorityBulk, func() (err error) {
+		message, err = t.handler.discord.Session().ChannelMessageSendComplex(target.ChannelID, msgData)
+		return err
+	})
+	if err != nil {
+		t.handler.logger.Warnf("Broadcast to channel %s failed: %v", target.ChannelID, err)
+		target.Status = broadcastFailed
+		target.Error = err.Error()
+		return
+	}
+	target.Status = broadcastSent
+	target.MessageID = message.ID
+	target.MessageURL = fmt.Sprintf("https://discord.com/channels/%s/%s/%s", target.GuildID, target.ChannelID, message.ID)
+}
+
+// GetDefinition returns the tool definition
+func (t *BroadcastMessageTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("broadcast_message", "Send the same content and embeds to a named list of channels or to channel_ids across allowed guilds, paced, with a result per channel; dry_run previews the targets without sending")
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/notifications"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// Tool is implemented by every built-in tool
+type Tool interface {
+	Execute(params types.CallToolParams) (types.CallToolResult, error)
+	GetDefinition() types.Tool
+}
+
+// Host is the server the built-in tools are registered with, which
+// get_bot_info and get_server_stats report on
+type Host interface {
+	ToolLister
+	StatsSource
+}
+
+// Builtin creates every built-in tool, sharing one handler of each kind.
+// Progress of long exports is reported through notificationSvc.
+func Builtin(client *discord.Client, notificationSvc *notifications.Service, host Host, logger *logrus.Logger) []Tool {
+	validator := validation.NewValidator(client.Config().Validation, logger)
+	checker := permissions.NewChecker(client, logger)
+
+	guild := NewGuildHandler(client, checker, validator, logger)
+	channel := NewChannelHandler(client, checker, validator, logger)
+	message := NewMessageHandler(client, checker, validator, logger)
+	role := NewRoleHandler(client, checker, validator, logger)
+	template := NewTemplateHandler(client, checker, validator, logger)
+	voice := NewVoiceHandler(client, checker, validator, logger)
+	resolver := NewResolveHandler(client, checker, validator, logger)
+	user := NewUserHandler(client, checker, validator, logger)
+	export := NewExportHandler(client, checker, notificationSvc, validator, logger)
+	bot := NewBotHandler(client, validator, logger)
+
+	return []Tool{
+		// Guilds, members and moderation
+		NewGetGuildAnalyticsTool(guild),
+		NewListCommandPermissionsTool(guild),
+		NewSetCommandPermissionsTool(guild),
+		NewGetGuildInfoTool(guild),
+		NewListGuildMembersTool(guild),
+		NewListIntegrationsTool(guild),
+		NewGetStreamedMembersTool(guild),
+		NewStreamGuildMembersTool(guild),
+		NewGetJoinLeaveStatsTool(guild),
+		NewGetMembershipLogTool(guild),
+		NewAuditNicknamesTool(guild),
+		NewAddUserNoteTool(guild),
+		NewDeleteUserNoteTool(guild),
+		NewGetUserNotesTool(guild),
+		NewListOnboardingRulesTool(guild),
+		NewSetOnboardingRuleTool(guild),
+		NewGetGuildPreviewTool(guild),
+		NewArmRaidProtectionTool(guild),
+		NewDisarmRaidProtectionTool(guild),
+		NewListRaidIncidentsTool(guild),
+		NewDeleteSoundboardSoundTool(guild),
+		NewListSoundboardSoundsTool(guild),
+		NewUploadSoundboardSoundTool(guild),
+		NewApplyGuildStructureTool(guild),
+		NewExportGuildStructureTool(guild),
+		NewCloseTicketTool(guild),
+		NewListTicketsTool(guild),
+		NewOpenTicketTool(guild),
+		NewReplyTicketTool(guild),
+		NewUndoActionTool(guild),
+		NewUndoLastActionTool(guild),
+		NewConfigureVerificationTool(guild),
+		NewListUnverifiedMembersTool(guild),
+		NewVerifyMemberTool(guild),
+		NewClearWarningTool(guild),
+		NewListWarningsTool(guild),
+		NewWarnMemberTool(guild),
+		NewGetGuildWidgetTool(guild),
+		NewGetVanityURLTool(guild),
+		NewUpdateGuildWidgetTool(guild),
+		// Channels
+		NewCreateBridgeTool(channel),
+		NewDeleteBridgeTool(channel),
+		NewListBridgesTool(channel),
+		NewGetChannelInfoTool(channel),
+		NewListChannelsTool(channel),
+		NewFollowAnnouncementChannelTool(channel),
+		NewListAnnouncementFollowsTool(channel),
+		NewGetStaleThreadsTool(channel),
+		// Messages
+		NewCreateAutoResponseTool(message),
+		NewDeleteAutoResponseTool(message),
+		NewListAutoResponsesTool(message),
+		NewBroadcastMessageTool(message),
+		NewGetConversationContextTool(message),
+		NewResetCooldownTool(message),
+		NewFindDuplicateMessagesTool(message),
+		NewGetEmojiImageTool(message),
+		NewAddFeedTool(message),
+		NewListFeedsTool(message),
+		NewRemoveFeedTool(message),
+		NewDrawGiveawayWinnerTool(message),
+		NewListGiveawaysTool(message),
+		NewStartGiveawayTool(message),
+		NewAddReactionTool(message),
+		NewDeleteMessageTool(message),
+		NewEditMessageTool(message),
+		NewGetChannelMessagesTool(message),
+		NewSendMessageTool(message),
+		NewDeletePinPolicyTool(message),
+		NewListPinPoliciesTool(message),
+		NewSetPinPolicyTool(message),
+		NewGetReactionStatsTool(message),
+		NewCreateRelayTool(message),
+		NewDeleteRelayTool(message),
+		NewListRelaysTool(message),
+		NewConfigureStarboardTool(message),
+		NewListStarboardEntriesTool(message),
+		NewGetThreadTranscriptTool(message),
+		// Roles
+		NewCreateRoleMenuTool(role),
+		NewDeleteRoleMenuTool(role),
+		NewListRoleMenusTool(role),
+		NewAssignRoleTool(role),
+		NewCreateRoleTool(role),
+		NewDeleteRoleTool(role),
+		NewGetRoleInfoTool(role),
+		NewListRolesTool(role),
+		NewUnassignRoleTool(role),
+		// Templates and recurring posts
+		NewCreateRecurringPostTool(template),
+		NewDeleteRecurringPostTool(template),
+		NewListRecurringPostsTool(template),
+		NewListTemplatesTool(template),
+		NewRenderTemplateTool(template),
+		NewSendTemplatedMessageTool(template),
+		// Voice
+		NewPlaySoundboardSoundTool(voice),
+		NewJoinVoiceChannelTool(voice),
+		NewLeaveVoiceChannelTool(voice),
+		NewPlayAudioTool(voice),
+		NewSpeakInVoiceTool(voice),
+		// Name resolution
+		NewResolveChannelTool(resolver),
+		NewResolveRoleTool(resolver),
+		NewResolveUserTool(resolver),
+		// Users
+		NewGetUserInfoTool(user),
+		// Export
+		NewExportChannelTool(export),
+		// Bot
+		NewGetBotInfoTool(bot, host),
+		NewSetPresenceTool(bot),
+		NewGetServerStatsTool(bot, host),
+		// Stateless and client-level tools
+		NewSearchArchiveTool(client, checker, validator),
+		NewCacheStatsTool(client, validator),
+		NewBuildEmbedFromMarkdownTool(validator),
+		NewPollEventsTool(client, validator),
+		NewGetSendQueueTool(client, validator),
+		NewCheckPermissionsTool(client, checker, validator, logger),
+		NewPermissionMatrixTool(client, checker, validator, logger),
+		NewPingTool(client),
+		NewParseSnowflakeTool(validator),
+		NewSnowflakeForTimeTool(validator),
+		NewGetRecentTracesTool(client, validator),
+		NewCreateWatchTool(client, checker, validator, logger),
+		NewDeleteWatchTool(client, validator),
+		NewListWatchesTool(client, validator),
+	}
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// CacheStatsTool implements the cache_stats debug tool
+type CacheStatsTool struct {
+	discord   *discord.Client
+	validator *validation.Validator
+}
+
+// NewCacheStatsTool creates a new cache stats tool
+func NewCacheStatsTool(discordClient *discord.Client, validator *validation.Validator) *CacheStatsTool {
+	return &CacheStatsTool{
+		discord:   discordClient,
+		validator: validator,
+	}
+}
+
+// Execute executes the cache_stats tool
+func (t *CacheStatsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("cache_stats", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	flush := false
+	if flushVal, ok := params.Arguments["flush"]; ok {
+		flush = flushVal.(bool)
+	}
+
+	stats := t.discord.Cache().Stats()
+	if flush {
+		t.discord.Cache().Flush()
+	}
+	stats["flushed"] = flush
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "cache.stats", t.discord.Cache().Enabled()),
+			Data: stats,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *CacheStatsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("cache_stats", "Show entity cache hit/miss statistics (debug)")
+}
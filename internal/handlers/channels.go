@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"fmt"
+	"encoding/json"
 	"strings"
 	"time"
 
@@ -9,7 +9,9 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
 	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/snowflake"
 	"discord-mcp/internal/validation"
 	"discord-mcp/pkg/types"
 )
@@ -62,6 +64,8 @@ func (t *ListChannelsTool) Execute(params types.CallToolParams) (types.CallToolR
 		includePerms = permsVal.(bool)
 	}
 
+	includeActivity, _ := params.Arguments["include_activity"].(bool)
+
 	// Validate permissions
 	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
 		if permErr, ok := err.(*permissions.PermissionError); ok {
@@ -76,19 +80,33 @@ func (t *ListChannelsTool) Execute(params types.CallToolParams) (types.CallToolR
 		return t.formatError("Failed to list channels", err), nil
 	}
 
-	// Filter channels
-	filteredChannels := t.filterChannels(channels, filterType)
+	// Filter channels, hiding those excluded by the channel access lists
+	var accessible []*discordgo.Channel
+	for _, ch := range channels {
+		if t.handler.permissions.IsChannelAccessible(ch) {
+			accessible = append(accessible, ch)
+		}
+	}
+	filteredChannels := t.filterChannels(accessible, filterType)
+
+	var activity *channelActivity
+	if includeActivity {
+		activity = t.channelActivity(guildID)
+	}
 
 	// Format channels for response
-	formattedChannels := make([]map[string]interface{}, len(filteredChannels))
+	formattedChannels := make([]*types.ChannelSummary, len(filteredChannels))
 	for i, ch := range filteredChannels {
-		formattedChannels[i] = t.formatChannel(ch, includePerms)
+		formattedChannels[i] = t.handler.formatChannel(ch, includePerms)
+		if activity != nil {
+			activity.apply(formattedChannels[i], ch)
+		}
 	}
 
 	return types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("Found %d channels in guild %s", len(formattedChannels), guildID),
+			Text: i18n.T(i18n.Locale(params), "channels.list", len(formattedChannels), guildID),
 			Data: map[string]interface{}{
 				"guild_id":      guildID,
 				"channel_count": len(formattedChannels),
@@ -136,60 +154,184 @@ func channelTypeToString(channelType discordgo.ChannelType) string {
 		return "public_thread"
 	case discordgo.ChannelTypeGuildPrivateThread:
 		return "private_thread"
+	case discordgo.ChannelTypeGuildStageVoice:
+		return "stage"
+	case discordgo.ChannelTypeGuildDirectory:
+		return "directory"
+	case discordgo.ChannelTypeGuildForum:
+		return "forum"
+	case discordgo.ChannelTypeGuildMedia:
+		return "media"
 	default:
 		return "unknown"
 	}
 }
 
 // formatChannel formats a single channel for the response
-func (t *ListChannelsTool) formatChannel(channel *discordgo.Channel, includePerms bool) map[string]interface{} {
-	data := map[string]interface{}{
-		"id":        channel.ID,
-		"name":      channel.Name,
-		"type":      channelTypeToString(channel.Type),
-		"position":  channel.Position,
-		"nsfw":      channel.NSFW,
-		"parent_id": channel.ParentID,
-		"guild_id":  channel.GuildID,
-		"topic":     channel.Topic,
+func (h *ChannelHandler) formatChannel(channel *discordgo.Channel, includePerms bool) *types.ChannelSummary {
+	data := &types.ChannelSummary{
+		ID:       channel.ID,
+		Name:     channel.Name,
+		Type:     channelTypeToString(channel.Type),
+		Position: channel.Position,
+		NSFW:     channel.NSFW,
+		ParentID: channel.ParentID,
+		GuildID:  channel.GuildID,
+		Topic:    channel.Topic,
 	}
 
 	createdAt, err := discordgo.SnowflakeTimestamp(channel.ID)
 	if err != nil {
-		t.handler.logger.Warnf("Could not parse snowflake ID %s: %v", channel.ID, err)
-		data["created_at"] = "error"
+		h.logger.Warnf("Could not parse snowflake ID %s: %v", channel.ID, err)
+		data.CreatedAt = "error"
 	} else {
-		data["created_at"] = createdAt.Format(time.RFC3339)
+		data.CreatedAt = createdAt.Format(time.RFC3339)
 	}
 
+	addChannelMetadata(data, channel)
+
 	if includePerms {
-		perms, err := t.handler.permissions.GetChannelPermissions(channel.ID)
+		perms, err := h.permissions.GetChannelPermissions(channel.ID)
 		if err != nil {
-			t.handler.logger.Warnf("Could not get permissions for channel %s: %v", channel.ID, err)
-			data["permissions"] = "error"
+			h.logger.Warnf("Could not get permissions for channel %s: %v", channel.ID, err)
+			data.Permissions = "error"
 		} else {
-			data["permissions"] = perms
+			data.Permissions = perms
 		}
 	}
 
 	return data
 }
 
+// channelActivity holds per-channel counts gathered once per list_channels call
+type channelActivity struct {
+	// activeThreads counts active threads by parent channel
+	activeThreads map[string]int
+	// voiceMembers counts connected members by voice channel
+	voiceMembers map[string]int
+}
+
+// channelActivity gathers active thread counts from the REST API, falling
+// back to gateway state, and voice channel occupancy from gateway state
+func (t *ListChannelsTool) channelActivity(guildID string) *channelActivity {
+	activity := &channelActivity{
+		activeThreads: make(map[string]int),
+		voiceMembers:  make(map[string]int),
+	}
+
+	var threads []*discordgo.Channel
+	var list *discordgo.ThreadsList
+	_, err := t.handler.discord.Retry(func() (err error) {
+		list, err = t.handler.discord.Session().GuildThreadsActive(guildID)
+		return err
+	})
+	if err == nil {
+		threads = list.Threads
+	} else {
+		t.handler.logger.Warnf("Could not list active threads for guild %s, using gateway state: %v", guildID, err)
+	}
+
+	// Voice states are only tracked when the voice states intent is enabled
+	if guild, err := t.handler.discord.Session().State.Guild(guildID); err == nil {
+		if threads == nil {
+			threads = guild.Threads
+		}
+		for _, vs := range guild.VoiceStates {
+			if vs.ChannelID != "" {
+				activity.voiceMembers[vs.ChannelID]++
+			}
+		}
+	}
+
+	for _, thread := range threads {
+		if thread.ThreadMetadata == nil || !thread.ThreadMetadata.Archived {
+			activity.activeThreads[thread.ParentID]++
+		}
+	}
+	return activity
+}
+
+// apply adds the activity fields relevant to the channel's type
+func (a *channelActivity) apply(data *types.ChannelSummary, channel *discordgo.Channel) {
+	switch channel.Type {
+	case discordgo.ChannelTypeGuildCategory:
+		return
+	case discordgo.ChannelTypeGuildVoice, discordgo.ChannelTypeGuildStageVoice:
+		data.VoiceMemberCount = intField(a.voiceMembers[channel.ID])
+	default:
+		data.ActiveThreadCount = intField(a.activeThreads[channel.ID])
+	}
+
+	data.SlowmodeSeconds = intField(channel.RateLimitPerUser)
+
+	// The last message ID is a snowflake, so it encodes when the message
+	// was sent
+	if channel.LastMessageID != "" {
+		data.LastMessageID = channel.LastMessageID
+		if parts, err := snowflake.Parse(channel.LastMessageID); err == nil {
+			data.LastMessageAt = parts.Timestamp.Format(time.RFC3339)
+		}
+	}
+}
+
+// addChannelMetadata adds the type-specific settings and permission
+// overwrites of a channel
+func addChannelMetadata(data *types.ChannelSummary, channel *discordgo.Channel) {
+	if channel.Type != discordgo.ChannelTypeGuildCategory {
+		data.RateLimitPerUser = intField(channel.RateLimitPerUser)
+	}
+
+	switch channel.Type {
+	case discordgo.ChannelTypeGuildVoice, discordgo.ChannelTypeGuildStageVoice:
+		data.Bitrate = intField(channel.Bitrate)
+		data.UserLimit = intField(channel.UserLimit)
+	case discordgo.ChannelTypeGuildForum, discordgo.ChannelTypeGuildMedia:
+		data.AvailableTags = make([]types.ForumTagSummary, len(channel.AvailableTags))
+		for i, tag := range channel.AvailableTags {
+			data.AvailableTags[i] = types.ForumTagSummary{
+				ID:        tag.ID,
+				Name:      tag.Name,
+				Moderated: tag.Moderated,
+				EmojiID:   tag.EmojiID,
+				EmojiName: tag.EmojiName,
+			}
+		}
+		data.DefaultThreadRateLimitPerUser = intField(channel.DefaultThreadRateLimitPerUser)
+	}
+
+	if channel.ThreadMetadata != nil {
+		archived, locked := channel.ThreadMetadata.Archived, channel.ThreadMetadata.Locked
+		data.Archived = &archived
+		data.Locked = &locked
+		data.AutoArchiveDuration = intField(channel.ThreadMetadata.AutoArchiveDuration)
+		data.MessageCount = intField(channel.MessageCount)
+		data.MemberCount = intField(channel.MemberCount)
+	}
+
+	data.PermissionOverwrites = make([]types.OverwriteSummary, len(channel.PermissionOverwrites))
+	for i, ow := range channel.PermissionOverwrites {
+		kind := "role"
+		if ow.Type == discordgo.PermissionOverwriteTypeMember {
+			kind = "member"
+		}
+		data.PermissionOverwrites[i] = types.OverwriteSummary{
+			ID:    ow.ID,
+			Type:  kind,
+			Allow: ow.Allow,
+			Deny:  ow.Deny,
+		}
+	}
+}
+
+// intField returns a pointer for an optional numeric field, which is left
+// out of the response when nil
+func intField(v int) *int {
+	return &v
+}
+
 // formatError creates a standardized error response
 func (t *ListChannelsTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
 
 // GetChannelInfoTool implements the get_channel_info MCP tool
@@ -226,18 +368,19 @@ func (t *GetChannelInfoTool) Execute(params types.CallToolParams) (types.CallToo
 	}
 
 	// Get channel info from Discord
-	channel, err := t.handler.discord.Session().Channel(channelID)
+	channel, err := t.handler.discord.GetChannel(channelID)
 	if err != nil {
 		return t.formatError("Failed to get channel info", err), nil
 	}
 
 	// Format channel for response
-	formattedChannel := t.formatChannel(channel, includePerms)
+	formattedChannel := t.handler.formatChannel(channel, includePerms)
+	t.addChannelExtras(formattedChannel, channel)
 
 	return types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("Channel: %s", channel.Name),
+			Text: i18n.T(i18n.Locale(params), "channels.info", channel.Name),
 			Data: formattedChannel,
 		}},
 	}, nil
@@ -248,53 +391,44 @@ func (t *GetChannelInfoTool) GetDefinition() types.Tool {
 	return validation.GetToolDefinition("get_channel_info", "Get information about a specific Discord channel")
 }
 
-// formatChannel formats a single channel for the response
-func (t *GetChannelInfoTool) formatChannel(channel *discordgo.Channel, includePerms bool) map[string]interface{} {
-	data := map[string]interface{}{
-		"id":        channel.ID,
-		"name":      channel.Name,
-		"type":      channelTypeToString(channel.Type),
-		"position":  channel.Position,
-		"nsfw":      channel.NSFW,
-		"parent_id": channel.ParentID,
-		"guild_id":  channel.GuildID,
-		"topic":     channel.Topic,
+// addChannelExtras adds fields that discordgo does not decode, read from the
+// raw channel object. Failures are logged and the fields omitted.
+func (t *GetChannelInfoTool) addChannelExtras(data *types.ChannelSummary, channel *discordgo.Channel) {
+	if channel.Type == discordgo.ChannelTypeGuildCategory || channel.IsThread() {
+		return
 	}
 
-	createdAt, err := discordgo.SnowflakeTimestamp(channel.ID)
-	if err != nil {
-		t.handler.logger.Warnf("Could not parse snowflake ID %s: %v", channel.ID, err)
-		data["created_at"] = "error"
-	} else {
-		data["created_at"] = createdAt.Format(time.RFC3339)
+	var extras struct {
+		RTCRegion                  *string `json:"rtc_region"`
+		DefaultAutoArchiveDuration int     `json:"default_auto_archive_duration"`
 	}
-
-	if includePerms {
-		perms, err := t.handler.permissions.GetChannelPermissions(channel.ID)
+	endpoint := discordgo.EndpointChannel(channel.ID)
+	_, err := t.handler.discord.Retry(func() error {
+		body, err := t.handler.discord.Session().RequestWithBucketID("GET", endpoint, nil, endpoint)
 		if err != nil {
-			t.handler.logger.Warnf("Could not get permissions for channel %s: %v", channel.ID, err)
-			data["permissions"] = "error"
-		} else {
-			data["permissions"] = perms
+			return err
 		}
+		return json.Unmarshal(body, &extras)
+	})
+	if err != nil {
+		t.handler.logger.Warnf("Could not get extra fields for channel %s: %v", channel.ID, err)
+		return
 	}
 
-	return data
+	switch channel.Type {
+	case discordgo.ChannelTypeGuildVoice, discordgo.ChannelTypeGuildStageVoice:
+		// A null region means automatic selection
+		region := "automatic"
+		if extras.RTCRegion != nil {
+			region = *extras.RTCRegion
+		}
+		data.RTCRegion = region
+	default:
+		data.DefaultAutoArchiveDuration = extras.DefaultAutoArchiveDuration
+	}
 }
 
 // formatError creates a standardized error response
 func (t *GetChannelInfoTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
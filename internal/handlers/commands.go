@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// maxCommandPermissions is the most overwrites Discord allows per command
+const maxCommandPermissions = 100
+
+// commandPermissionTypes maps overwrite type names to the API's values
+var commandPermissionTypes = map[string]discordgo.ApplicationCommandPermissionType{
+	"role":    discordgo.ApplicationCommandPermissionTypeRole,
+	"user":    discordgo.ApplicationCommandPermissionTypeUser,
+	"channel": discordgo.ApplicationCommandPermissionTypeChannel,
+}
+
+// ListCommandPermissionsTool implements the list_command_permissions MCP tool
+type ListCommandPermissionsTool struct {
+	handler *GuildHandler
+}
+
+// NewListCommandPermissionsTool creates a new list command permissions tool
+func NewListCommandPermissionsTool(handler *GuildHandler) *ListCommandPermissionsTool {
+	return &ListCommandPermissionsTool{handler: handler}
+}
+
+// Execute executes the list_command_permissions tool
+func (t *ListCommandPermissionsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_command_permissions", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	commandID, _ := params.Arguments["command_id"].(string)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	bot, err := t.handler.discord.GetBotUser()
+	if err != nil {
+		return t.formatError("Failed to get bot user", err), nil
+	}
+	appID := bot.ID
+
+	// Global commands apply everywhere; guild commands only here
+	var commands []*discordgo.ApplicationCommand
+	for _, scope := range []string{"", guildID} {
+		var scoped []*discordgo.ApplicationCommand
+		_, err := t.handler.discord.Retry(func() (err error) {
+			scoped, err = t.handler.discord.Session().ApplicationCommands(appID, scope)
+			return err
+		})
+		if err != nil {
+			return t.formatError("Failed to list application commands", err), nil
+		}
+		commands = append(commands, scoped...)
+	}
+
+	var overwrites []*discordgo.GuildApplicationCommandPermissions
+	_, err = t.handler.discord.Retry(func() (err error) {
+		overwrites, err = t.handler.discord.Session().GuildApplicationCommandsPermissions(appID, guildID)
+		return err
+	})
+	if err != nil {
+		return t.formatError("Failed to get command permissions", err), nil
+	}
+	byCommand := make(map[string][]*discordgo.ApplicationCommandPermissions, len(overwrites))
+	for _, entry := range overwrites {
+		byCommand[entry.ID] = entry.Permissions
+	}
+
+	names := t.targetNames(guildID)
+	formatted := make([]map[string]interface{}, 0, len(commands))
+	for _, command := range commands {
+		if commandID != "" && command.ID != commandID {
+			continue
+		}
+		formatted = append(formatted, formatCommand(command, byCommand[command.ID], guildID, names))
+	}
+	if commandID != "" && len(formatted) == 0 {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "commands.not_found", commandID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"command_id": commandID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+	sort.Slice(formatted, func(i, j int) bool {
+		return formatted[i]["name"].(string) < formatted[j]["name"].(string)
+	})
+
+	// Overwrites stored under the application ID apply to every command
+	// without overwrites of its own
+	defaults := formatCommandPermissions(byCommand[appID], guildID, names)
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "commands.list", len(formatted), guildID),
+			Data: map[string]interface{}{
+				"guild_id":             guildID,
+				"application_id":       appID,
+				"command_count":        len(formatted),
+				"commands":             formatted,
+				"application_defaults": defaults,
+			},
+		}},
+	}, nil
+}
+
+// targetNames maps the guild's role and channel IDs to names, including the
+// IDs that stand for @everyone and every channel
+func (t *ListCommandPermissionsTool) targetNames(guildID string) map[string]string {
+	names := map[string]string{guildID: "@everyone"}
+	if allChannels, err := discordgo.GuildAllChannelsID(guildID); err == nil {
+		names[allChannels] = "all channels"
+	}
+	if roles, err := t.handler.discord.GetRoles(guildID); err == nil {
+		for _, role := range roles {
+			if role.ID != guildID {
+				names[role.ID] = "@" + role.Name
+			}
+		}
+	}
+	if channels, err := t.handler.discord.GetChannels(guildID); err == nil {
+		for _, channel := range channels {
+			names[channel.ID] = "#" + channel.Name
+		}
+	}
+	return names
+}
+
+// GetDefinition returns the tool definition
+func (t *ListCommandPermissionsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_command_permissions", "List the bot's slash commands in a Discord server with the roles, users and channels allowed or denied to use each one")
+}
+
+// formatError creates a standardized error response
+func (t *ListCommandPermissionsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// SetCommandPermissionsTool implements the set_command_permissions MCP tool
+type SetCommandPermissionsTool struct {
+	handler *GuildHandler
+}
+
+// NewSetCommandPermissionsTool creates a new set command permissions tool
+func NewSetCommandPermissionsTool(handler *GuildHandler) *SetCommandPermissionsTool {
+	return &SetCommandPermissionsTool{handler: handler}
+}
+
+// Execute executes the set_command_permissions tool
+func (t *SetCommandPermissionsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("set_command_permissions", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	token := t.handler.discord.Config().Discord.CommandPermissionsToken
+	if token == "" {
+		return commandPermissionsDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	commandID := params.Arguments["command_id"].(string)
+
+	var overwrites []*discordgo.ApplicationCommandPermissions
+	if entries, ok := params.Arguments["permissions"].([]interface{}); ok {
+		for _, entry := range entries {
+			fields, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			typeName, _ := fields["type"].(string)
+			id, _ := fields["id"].(string)
+			allow, _ := fields["allow"].(bool)
+			overwrites = append(overwrites, &discordgo.ApplicationCommandPermissions{
+				ID:         id,
+				Type:       commandPermissionTypes[typeName],
+				Permission: allow,
+			})
+		}
+	}
+
+	// Validate permissions
+	if err := t.checkTargets(guildID, overwrites); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		if validationErr, ok := err.(*validation.ValidationError); ok {
+			return validation.FormatValidationError(validationErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	bot, err := t.handler.discord.GetBotUser()
+	if err != nil {
+		return t.formatError("Failed to get bot user", err), nil
+	}
+
+	// The edit replaces every overwrite of the command and must be made with
+	// the bearer token instead of the bot token
+	list := &discordgo.ApplicationCommandPermissionsList{Permissions: overwrites}
+	retries, err := t.handler.discord.Retry(func() error {
+		return t.handler.discord.Session().ApplicationCommandPermissionsEdit(bot.ID, guildID, commandID, list,
+			discordgo.WithHeader("authorization", "Bearer "+token))
+	})
+	if err != nil {
+		return t.formatError("Failed to set command permissions", err), nil
+	}
+
+	t.handler.logger.Infof("Set %d permission overwrites on command %s in guild %s", len(overwrites), commandID, guildID)
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "commands.permissions_set", len(overwrites), commandID),
+			Data: map[string]interface{}{
+				"guild_id":    guildID,
+				"command_id":  commandID,
+				"permissions": formatCommandPermissions(overwrites, guildID, nil),
+				"retries":     retries,
+			},
+		}},
+	}, nil
+}
+
+// checkTargets checks that the bot can see the guild and that every role
+// and channel overwrite names one of the guild's roles or channels
+func (t *SetCommandPermissionsTool) checkTargets(guildID string, overwrites []*discordgo.ApplicationCommandPermissions) error {
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		return err
+	}
+	if len(overwrites) > maxCommandPermissions {
+		return validation.NewValidationError("too many permissions",
+			fmt.Sprintf("a command takes at most %d permission overwrites", maxCommandPermissions), "permissions")
+	}
+
+	allChannels, _ := discordgo.GuildAllChannelsID(guildID)
+	known := map[string]bool{guildID: true, allChannels: true}
+	roles, err := t.handler.discord.GetRoles(guildID)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		known[role.ID] = true
+	}
+	channels, err := t.handler.discord.GetChannels(guildID)
+	if err != nil {
+		return err
+	}
+	for _, channel := range channels {
+		known[channel.ID] = true
+	}
+
+	for _, overwrite := range overwrites {
+		if overwrite.Type == discordgo.ApplicationCommandPermissionTypeUser || known[overwrite.ID] {
+			continue
+		}
+		return validation.NewValidationError("invalid target",
+			fmt.Sprintf("%s %s is not in guild %s", commandPermissionTypeName(overwrite.Type), overwrite.ID, guildID), "permissions")
+	}
+	return nil
+}
+
+// GetDefinition returns the tool definition
+func (t *SetCommandPermissionsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("set_command_permissions", "Replace which roles, users and channels can use one of the bot's slash commands in a Discord server, e.g. to restrict admin commands to moderators. Requires discord.command_permissions_token")
+}
+
+// formatError creates a standardized error response
+func (t *SetCommandPermissionsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// formatCommand formats a command with its permission overwrites in a guild
+func formatCommand(command *discordgo.ApplicationCommand, overwrites []*discordgo.ApplicationCommandPermissions, guildID string, names map[string]string) map[string]interface{} {
+	scope := "global"
+	if command.GuildID != "" {
+		scope = "guild"
+	}
+	formatted := map[string]interface{}{
+		"id":          command.ID,
+		"name":        command.Name,
+		"description": command.Description,
+		"type":        int(command.Type),
+		"scope":       scope,
+		"permissions": formatCommandPermissions(overwrites, guildID, names),
+		// Without overwrites of its own a command follows the application
+		// defaults and its default member permissions
+		"uses_defaults": len(overwrites) == 0,
+	}
+	if command.DefaultMemberPermissions != nil {
+		formatted["default_member_permissions"] = permissions.PermissionNames(*command.DefaultMemberPermissions)
+	}
+	if command.NSFW != nil {
+		formatted["nsfw"] = *command.NSFW
+	}
+	return formatted
+}
+
+// formatCommandPermissions formats permission overwrites, naming their
+// targets where names are known
+func formatCommandPermissions(overwrites []*discordgo.ApplicationCommandPermissions, guildID string, names map[string]string) []map[string]interface{} {
+	formatted := make([]map[string]interface{}, 0, len(overwrites))
+	for _, overwrite := range overwrites {
+		entry := map[string]interface{}{
+			"type":  commandPermissionTypeName(overwrite.Type),
+			"id":    overwrite.ID,
+			"allow": overwrite.Permission,
+		}
+		if name, ok := names[overwrite.ID]; ok {
+			entry["name"] = name
+		} else if overwrite.ID == guildID {
+			entry["name"] = "@everyone"
+		}
+		formatted = append(formatted, entry)
+	}
+	return formatted
+}
+
+// commandPermissionTypeName returns the name of an overwrite type
+func commandPermissionTypeName(permissionType discordgo.ApplicationCommandPermissionType) string {
+	for name, value := range commandPermissionTypes {
+		if value == permissionType {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+// commandPermissionsDisabledResult reports that no bearer token is
+// configured for changing command permissions
+func commandPermissionsDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "commands.token_missing"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "discord.command_permissions_token not set",
+			},
+		}},
+		IsError: true,
+	}
+}
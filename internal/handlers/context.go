@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// maxReplyDepth bounds how far get_conversation_context follows a reply chain
+const maxReplyDepth = 20
+
+// charsPerToken approximates tokens for max_tokens budgets
+const charsPerToken = 4
+
+// contextEntry is a message in a conversation transcript
+type contextEntry struct {
+	msg      *discordgo.Message
+	target   bool
+	inChain  bool
+	starter  bool
+	distance int
+}
+
+// GetConversationContextTool implements the get_conversation_context MCP tool
+type GetConversationContextTool struct {
+	handler *MessageHandler
+}
+
+// NewGetConversationContextTool creates a new get conversation context tool
+func NewGetConversationContextTool(handler *MessageHandler) *GetConversationContextTool {
+	return &GetConversationContextTool{handler: handler}
+}
+
+// Execute executes the get_conversation_context tool
+func (t *GetConversationContextTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_conversation_context", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	// Extract parameters
+	channelID := params.Arguments["channel_id"].(string)
+	messageID := params.Arguments["message_id"].(string)
+
+	surrounding := intArgument(params.Arguments, "surrounding", 20)
+	maxChars := intArgument(params.Arguments, "max_chars", 8000)
+	if _, ok := params.Arguments["max_tokens"]; ok {
+		maxChars = intArgument(params.Arguments, "max_tokens", 0) * charsPerToken
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.ValidateMessageOperation("get_messages", channelID, nil); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	target, err := t.fetchMessage(channelID, messageID)
+	if err != nil {
+		return t.formatError("Failed to get message", err), nil
+	}
+
+	entries := map[string]*contextEntry{target.ID: {msg: target, target: true, inChain: true}}
+
+	// Walk the reply chain upwards
+	current := target
+	for depth := 0; depth < maxReplyDepth && current.MessageReference != nil; depth++ {
+		parent := current.ReferencedMessage
+		if parent == nil {
+			refChannel := current.MessageReference.ChannelID
+			if refChannel == "" {
+				refChannel = channelID
+			}
+			if parent, err = t.fetchMessage(refChannel, current.MessageReference.MessageID); err != nil {
+				t.handler.logger.Debugf("Reply chain for %s ends at %s: %v", messageID, current.ID, err)
+				break
+			}
+		}
+		if _, seen := entries[parent.ID]; seen {
+			break
+		}
+		entries[parent.ID] = &contextEntry{msg: parent, inChain: true}
+		current = parent
+	}
+
+	// Surrounding messages, ranked by distance from the target
+	if surrounding > 0 {
+		var around []*discordgo.Message
+		_, err := t.handler.discord.Retry(func() (err error) {
+			around, err = t.handler.discord.Session().ChannelMessages(channelID, surrounding, "", "", messageID)
+			return err
+		})
+		if err != nil {
+			return t.formatError("Failed to get surrounding messages", err), nil
+		}
+		sort.Slice(around, func(i, j int) bool { return around[i].Timestamp.Before(around[j].Timestamp) })
+		targetIndex := 0
+		for i, msg := range around {
+			if msg.ID == messageID {
+				targetIndex = i
+			}
+		}
+		for i, msg := range around {
+			if _, seen := entries[msg.ID]; !seen {
+				distance := i - targetIndex
+				if distance < 0 {
+					distance = -distance
+				}
+				entries[msg.ID] = &contextEntry{msg: msg, distance: distance}
+			}
+		}
+	}
+
+	// Resolve the thread parent and the message the thread was started from
+	var threadInfo map[string]interface{}
+	channel, err := t.handler.discord.GetChannel(channelID)
+	if err == nil && channel.IsThread() {
+		threadInfo = map[string]interface{}{
+			"id":        channel.ID,
+			"name":      channel.Name,
+			"parent_id": channel.ParentID,
+		}
+		if parent, err := t.handler.discord.GetChannel(channel.ParentID); err == nil {
+			threadInfo["parent_name"] = parent.Name
+		}
+		if t.handler.permissions.ValidateMessageOperation("get_messages", channel.ParentID, nil) == nil {
+			if starter, err := t.fetchMessage(channel.ParentID, channel.ID); err == nil {
+				entries[starter.ID] = &contextEntry{msg: starter, starter: true}
+			}
+		}
+	}
+
+	botID := ""
+	if bot, err := t.handler.discord.GetBotUser(); err == nil {
+		botID = bot.ID
+	}
+
+	ordered, omitted := t.fitBudget(entries, maxChars, botID)
+
+	lines := make([]string, len(ordered))
+	formatted := make([]map[string]interface{}, len(ordered))
+	for i, entry := range ordered {
+		lines[i] = transcriptLine(entry, botID)
+		formatted[i] = map[string]interface{}{
+			"message_id":     entry.msg.ID,
+			"channel_id":     entry.msg.ChannelID,
+			"role":           conversationRole(entry.msg, botID),
+			"author":         authorName(entry.msg),
+			"content":        entry.msg.Content,
+			"timestamp":      entry.msg.Timestamp.Format(time.RFC3339),
+			"is_target":      entry.target,
+			"in_reply_chain": entry.inChain,
+			"thread_starter": entry.starter,
+		}
+	}
+	transcript := strings.Join(lines, "\n")
+
+	data := map[string]interface{}{
+		"channel_id":    channelID,
+		"message_id":    messageID,
+		"messages":      formatted,
+		"transcript":    transcript,
+		"message_count": len(ordered),
+		"omitted_count": omitted,
+		"char_count":    len(transcript),
+		"max_chars":     maxChars,
+	}
+	if threadInfo != nil {
+		data["thread"] = threadInfo
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{
+			{
+				Type: "text",
+				Text: transcript,
+				Data: data,
+			},
+		},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetConversationContextTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_conversation_context", "Build an ordered, role-labeled transcript around a message (reply chain, surrounding messages and thread parent) sized to a character or token budget")
+}
+
+// fetchMessage loads a single message
+func (t *GetConversationContextTool) fetchMessage(channelID, messageID string) (*discordgo.Message, error) {
+	var msg *discordgo.Message
+	_, err := t.handler.discord.Retry(func() (err error) {
+		msg, err = t.handler.discord.Session().ChannelMessage(channelID, messageID)
+		return err
+	})
+	return msg, err
+}
+
+// fitBudget keeps the target, reply chain and thread starter, then adds the
+// nearest surrounding messages while the transcript fits in maxChars. It
+// returns the kept entries oldest first and the number left out.
+func (t *GetConversationContextTool) fitBudget(entries map[string]*contextEntry, maxChars int, botID string) ([]*contextEntry, int) {
+	var required, optional []*contextEntry
+	for _, entry := range entries {
+		if entry.target || entry.inChain || entry.starter {
+			required = append(required, entry)
+		} else {
+			optional = append(optional, entry)
+		}
+	}
+	sort.Slice(optional, func(i, j int) bool { return optional[i].distance < optional[j].distance })
+
+	used := 0
+	for _, entry := range required {
+		used += len(transcriptLine(entry, botID)) + 1
+	}
+
+	kept := required
+	omitted := 0
+	for _, entry := range optional {
+		size := len(transcriptLine(entry, botID)) + 1
+		if used+size > maxChars {
+			omitted++
+			continue
+		}
+		used += size
+		kept = append(kept, entry)
+	}
+
+	sort.Slice(kept, func(i, j int) bool { return kept[i].msg.Timestamp.Before(kept[j].msg.Timestamp) })
+
+	// Trim the oldest required messages if they alone exceed the budget,
+	// never dropping the target itself
+	for used > maxChars && len(kept) > 1 {
+		drop := 0
+		if kept[0].target {
+			drop = 1
+		}
+		used -= len(transcriptLine(kept[drop], botID)) + 1
+		kept = append(kept[:drop], kept[drop+1:]...)
+		omitted++
+	}
+
+	return kept, omitted
+}
+
+// formatError creates a standardized error response
+func (t *GetConversationContextTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// transcriptLine renders a message as "[time] role (author): content"
+func transcriptLine(entry *contextEntry, botID string) string {
+	msg := entry.msg
+	content := msg.Content
+	for _, att := range msg.Attachments {
+		content += fmt.Sprintf(" [attachment: %s]", att.Filename)
+	}
+
+	marker := ""
+	switch {
+	case entry.target:
+		marker = " ◀ target"
+	case entry.starter:
+		marker = " (thread starter)"
+	}
+
+	return fmt.Sprintf("[%s] %s (%s): %s%s", msg.Timestamp.UTC().Format("2006-01-02 15:04"),
+		conversationRole(msg, botID), authorName(msg), content, marker)
+}
+
+// conversationRole labels the bot's own messages "assistant" and all others "user"
+func conversationRole(msg *discordgo.Message, botID string) string {
+	if msg.Author != nil && msg.Author.ID == botID {
+		return "assistant"
+	}
+	return "user"
+}
+
+// authorName returns the best display name for a message author
+func authorName(msg *discordgo.Message) string {
+	switch {
+	case msg.Member != nil && msg.Member.Nick != "":
+		return msg.Member.Nick
+	case msg.Author == nil:
+		return "unknown"
+	case msg.Author.GlobalName != "":
+		return msg.Author.GlobalName
+	default:
+		return msg.Author.Username
+	}
+}
+
+// intArgument reads an integer argument sent as a JSON number
+func intArgument(args map[string]interface{}, name string, def int) int {
+	if val, ok := args[name]; ok {
+		if f, ok := val.(float64); ok {
+			return int(f)
+		} else if i, ok := val.(int); ok {
+			return i
+		}
+	}
+	return def
+}
+
+// auditLogOptions returns the request options that record the reason
+// argument, if any, in the guild's audit log
+func auditLogOptions(args map[string]interface{}) []discordgo.RequestOption {
+	if reason, ok := args["reason"].(string); ok && reason != "" {
+		return []discordgo.RequestOption{discordgo.WithAuditLogReason(reason)}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"time"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// ResetCooldownTool implements the reset_cooldown MCP tool
+type ResetCooldownTool struct {
+	handler *MessageHandler
+}
+
+// NewResetCooldownTool creates a new reset cooldown tool
+func NewResetCooldownTool(handler *MessageHandler) *ResetCooldownTool {
+	return &ResetCooldownTool{handler: handler}
+}
+
+// Execute executes the reset_cooldown tool
+func (t *ResetCooldownTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("reset_cooldown", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	limiter := t.handler.discord.Cooldowns()
+	if limiter == nil {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "cooldowns.disabled"),
+				Data: map[string]interface{}{
+					"error_type": "configuration",
+					"message":    "response cooldowns disabled",
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	userID, _ := params.Arguments["user_id"].(string)
+	channelID, _ := params.Arguments["channel_id"].(string)
+	all, _ := params.Arguments["all"].(bool)
+	if userID == "" && channelID == "" && !all {
+		return validation.FormatValidationError(validation.NewValidationError("missing required parameter",
+			"user_id, channel_id or all: true is required", "user_id")), nil
+	}
+	if all {
+		userID, channelID = "", ""
+	}
+
+	cleared := limiter.Reset(userID, channelID, time.Now())
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "cooldowns.reset", len(cleared)),
+			Data: map[string]interface{}{
+				"user_id":       userID,
+				"channel_id":    channelID,
+				"cleared_count": len(cleared),
+				"cleared":       cleared,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ResetCooldownTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("reset_cooldown", "Clear the response cooldown of a user or channel so auto-responses and addressed message notifications resume for them")
+}
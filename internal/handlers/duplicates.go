@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"errors"
+
+	"discord-mcp/internal/duplicates"
+	"discord-mcp/internal/history"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// FindDuplicateMessagesTool implements the find_duplicate_messages MCP tool
+type FindDuplicateMessagesTool struct {
+	handler *MessageHandler
+}
+
+// NewFindDuplicateMessagesTool creates a new find duplicate messages tool
+func NewFindDuplicateMessagesTool(handler *MessageHandler) *FindDuplicateMessagesTool {
+	return &FindDuplicateMessagesTool{handler: handler}
+}
+
+// Execute executes the find_duplicate_messages tool
+func (t *FindDuplicateMessagesTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("find_duplicate_messages", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	var channelIDs []string
+	for _, id := range params.Arguments["channel_ids"].([]interface{}) {
+		if s, ok := id.(string); ok {
+			channelIDs = append(channelIDs, s)
+		}
+	}
+	opts := duplicates.Options{
+		Threshold: 0.85,
+		MinLength: intArgument(params.Arguments, "min_length", 10),
+		MinCount:  intArgument(params.Arguments, "min_count", 3),
+	}
+	if val, ok := params.Arguments["threshold"].(float64); ok {
+		opts.Threshold = val
+	}
+	limit := intArgument(params.Arguments, "limit", 200)
+	maxGroups := intArgument(params.Arguments, "max_groups", 20)
+	includeBots, _ := params.Arguments["include_bots"].(bool)
+	cursor, _ := params.Arguments["scan_cursor"].(string)
+
+	// Validate permissions
+	for _, channelID := range channelIDs {
+		if err := t.handler.permissions.CanReadMessageHistory(channelID); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return t.formatError("Permission check failed", err), nil
+		}
+	}
+
+	result, err := t.handler.discord.ScanHistory(history.Request{
+		ChannelIDs: channelIDs,
+		Limit:      limit,
+		Cursor:     cursor,
+	})
+	if errors.Is(err, history.ErrInvalidCursor) {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameter", err.Error(), "scan_cursor")), nil
+	}
+	if err != nil {
+		return t.formatError("Failed to get channel messages", err), nil
+	}
+
+	var messages []duplicates.Message
+	scanned := 0
+	for channelID, page := range result.Messages {
+		for _, msg := range page {
+			scanned++
+			if msg.Author == nil || (msg.Author.Bot && !includeBots) {
+				continue
+			}
+			messages = append(messages, duplicates.Message{
+				ID:        msg.ID,
+				ChannelID: channelID,
+				AuthorID:  msg.Author.ID,
+				Author:    msg.Author.Username,
+				Content:   msg.Content,
+				Timestamp: msg.Timestamp,
+			})
+		}
+	}
+
+	groups := duplicates.Find(messages, opts)
+	groupCount := len(groups)
+	if len(groups) > maxGroups {
+		groups = groups[:maxGroups]
+	}
+	duplicateCount := 0
+	for _, group := range groups {
+		duplicateCount += group.Count
+	}
+
+	channelErrors := make(map[string]string, len(result.Errors))
+	for channelID, err := range result.Errors {
+		channelErrors[channelID] = err.Error()
+	}
+	data := map[string]interface{}{
+		"channel_ids":      channelIDs,
+		"scanned":          scanned,
+		"compared":         len(messages),
+		"threshold":        opts.Threshold,
+		"group_count":      groupCount,
+		"duplicate_count":  duplicateCount,
+		"groups":           groups,
+		"scan_complete":    result.Complete,
+		"channel_failures": channelErrors,
+	}
+	if result.Cursor != "" {
+		data["scan_cursor"] = result.Cursor
+	}
+	if !t.handler.discord.HasMessageContent() {
+		// Without the intent most messages arrive empty and cannot be compared
+		data["message_content_unavailable"] = true
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "duplicates.found", groupCount, scanned, len(channelIDs)),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *FindDuplicateMessagesTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("find_duplicate_messages", "Scan recent history across channels for identical or near-identical messages, grouping them to expose spam campaigns, crossposts and copypasta floods")
+}
+
+// formatError creates a standardized error response
+func (t *FindDuplicateMessagesTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
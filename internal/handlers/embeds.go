@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"discord-mcp/internal/embed"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// BuildEmbedFromMarkdownTool implements the build_embed_from_markdown MCP tool
+type BuildEmbedFromMarkdownTool struct {
+	validator *validation.Validator
+}
+
+// NewBuildEmbedFromMarkdownTool creates a new build embed from markdown tool
+func NewBuildEmbedFromMarkdownTool(validator *validation.Validator) *BuildEmbedFromMarkdownTool {
+	return &BuildEmbedFromMarkdownTool{validator: validator}
+}
+
+// Execute executes the build_embed_from_markdown tool
+func (t *BuildEmbedFromMarkdownTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("build_embed_from_markdown", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	markdown := params.Arguments["markdown"].(string)
+
+	color := 0
+	if colorVal, ok := params.Arguments["color"].(string); ok {
+		parsed, err := strconv.ParseInt(strings.TrimPrefix(colorVal, "#"), 16, 32)
+		if err != nil {
+			return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+				"color must be a hex color such as #5865F2", "color")), nil
+		}
+		color = int(parsed)
+	}
+
+	embeds := embed.FromMarkdown(markdown, color)
+	if len(embeds) == 0 {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+			"markdown has no content", "markdown")), nil
+	}
+	messages := embed.GroupMessages(embeds)
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "embeds.built", len(embeds), len(messages)),
+			Data: map[string]interface{}{
+				"embed_count":   len(embeds),
+				"message_count": len(messages),
+				"embeds":        embeds,
+				"messages":      messages,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *BuildEmbedFromMarkdownTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("build_embed_from_markdown", "Convert a Markdown document (headings, lists, images, links) into valid Discord embeds, split to fit Discord's size limits")
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/emoji"
+	"discord-mcp/internal/resolve"
+	"discord-mcp/internal/validation"
+)
+
+// maxEmojiSuggestions caps the alternatives listed for an unknown emoji
+const maxEmojiSuggestions = 5
+
+// resolveReactionEmoji turns a Unicode emoji, a :shortcode:, or a custom
+// emoji given as <:name:id>, name:id or :name: into an emoji the bot can
+// react with. Custom emoji must belong to an allowed guild the bot is in.
+func resolveReactionEmoji(discordClient *discord.Client, input string) (*discordgo.Emoji, error) {
+	input = strings.TrimSpace(input)
+
+	if emoji.IsUnicode(input) {
+		return &discordgo.Emoji{Name: input}, nil
+	}
+
+	if custom, ok := emoji.ParseCustom(input); ok {
+		if found := findGuildEmoji(discordClient, func(e *discordgo.Emoji) bool { return e.ID == custom.ID }); found != nil {
+			return found, nil
+		}
+		return nil, emojiError(fmt.Sprintf("custom emoji %s (ID %s) was not found in any server the bot is in and may use; "+
+			"bots can only react with custom emoji from servers they share", custom.Name, custom.ID))
+	}
+
+	if name, tone, ok := emoji.ParseShortcode(input); ok {
+		if unicode, ok := emoji.FromShortcode(name, tone); ok {
+			return &discordgo.Emoji{Name: unicode}, nil
+		}
+		if found := findGuildEmoji(discordClient, func(e *discordgo.Emoji) bool { return strings.EqualFold(e.Name, name) }); found != nil {
+			return found, nil
+		}
+
+		message := fmt.Sprintf("unknown emoji :%s:", name)
+		suggestions := append(emoji.SuggestShortcodes(name, maxEmojiSuggestions), similarGuildEmoji(discordClient, name)...)
+		if len(suggestions) > maxEmojiSuggestions {
+			suggestions = suggestions[:maxEmojiSuggestions]
+		}
+		if len(suggestions) > 0 {
+			message += "; did you mean " + strings.Join(suggestions, ", ") + "?"
+		}
+		return nil, emojiError(message)
+	}
+
+	return nil, emojiError(fmt.Sprintf("%q is not an emoji; use a Unicode emoji (👍), a shortcode (:thumbsup:) "+
+		"or a custom emoji (<:name:id>)", input))
+}
+
+// findGuildEmoji returns the first custom emoji in the bot's allowed guilds
+// that matches, searching guilds in ID order so the result is stable.
+// Guilds left out of discord.allowed_guilds are never searched, so their
+// emoji do not leak into results or suggestions.
+func findGuildEmoji(discordClient *discord.Client, match func(*discordgo.Emoji) bool) *discordgo.Emoji {
+	state := discordClient.Session().State
+	state.RLock()
+	defer state.RUnlock()
+
+	guilds := make([]*discordgo.Guild, len(state.Guilds))
+	copy(guilds, state.Guilds)
+	sort.Slice(guilds, func(i, j int) bool { return guilds[i].ID < guilds[j].ID })
+
+	for _, guild := range guilds {
+		if !discordClient.IsGuildAllowed(guild.ID) {
+			continue
+		}
+		for _, e := range guild.Emojis {
+			if match(e) {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+// similarGuildEmoji lists custom emoji in the bot's allowed guilds whose names
+// resemble name, in <:name:id> form
+func similarGuildEmoji(discordClient *discord.Client, name string) []string {
+	type scored struct {
+		mention string
+		score   float64
+	}
+
+	var matches []scored
+	findGuildEmoji(discordClient, func(e *discordgo.Emoji) bool {
+		if score := resolve.Score(name, e.Name); score > 0 {
+			matches = append(matches, scored{e.MessageFormat(), score})
+		}
+		return false
+	})
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	var mentions []string
+	for i := 0; i < len(matches) && i < maxEmojiSuggestions; i++ {
+		mentions = append(mentions, matches[i].mention)
+	}
+	return mentions
+}
+
+func emojiError(message string) error {
+	return validation.NewValidationError("invalid emoji", message, "emoji")
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// GetEmojiImageTool implements the get_emoji_image MCP tool
+type GetEmojiImageTool struct {
+	handler *MessageHandler
+}
+
+// NewGetEmojiImageTool creates a new get emoji image tool
+func NewGetEmojiImageTool(handler *MessageHandler) *GetEmojiImageTool {
+	return &GetEmojiImageTool{handler: handler}
+}
+
+// Execute executes the get_emoji_image tool
+func (t *GetEmojiImageTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_emoji_image", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	emojiID := params.Arguments["emoji_id"].(string)
+	size := intArgument(params.Arguments, "size", t.handler.discord.CDN().Size())
+	includeImage := true
+	if val, ok := params.Arguments["include_image"].(bool); ok {
+		includeImage = val
+	}
+
+	// Emoji in the bot's guilds report their name and whether they are
+	// animated; any other ID is still served by the CDN
+	known := findGuildEmoji(t.handler.discord, func(e *discordgo.Emoji) bool { return e.ID == emojiID })
+	format, ok := params.Arguments["format"].(string)
+	if !ok {
+		format = "png"
+		if known != nil && known.Animated {
+			format = "gif"
+		}
+	}
+
+	url := t.handler.discord.CDN().EmojiImage(emojiID, format, size)
+	data := map[string]interface{}{
+		"emoji_id": emojiID,
+		"url":      url,
+		"size":     size,
+		"format":   format,
+		"known":    known != nil,
+	}
+	label := emojiID
+	if known != nil {
+		data["name"] = known.Name
+		data["animated"] = known.Animated
+		data["mention"] = known.MessageFormat()
+		label = known.MessageFormat()
+	}
+
+	result := types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "emoji.image", label, url),
+			Data: data,
+		}},
+	}
+	if !includeImage {
+		return result, nil
+	}
+
+	image, err := t.handler.images.image(url)
+	if err != nil {
+		return t.formatError("Failed to fetch emoji image", err), nil
+	}
+	data["mime_type"] = image.MimeType
+	result.Content = append(result.Content, image)
+	return result, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetEmojiImageTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_emoji_image", "Get a custom emoji's image as image content and a CDN URL at the requested size and format")
+}
+
+// formatError creates a standardized error response
+func (t *GetEmojiImageTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/config"
+	"discord-mcp/internal/discord"
+)
+
+// newEmojiTestClient returns a client whose state holds an allowed and an
+// excluded guild, each with one custom emoji
+func newEmojiTestClient(t *testing.T) *discord.Client {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	cfg := config.DefaultConfig()
+	cfg.Discord.Token = "test-token"
+	cfg.Discord.AllowedGuilds = []string{"100"}
+	client, err := discord.NewClient(cfg, logger)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	state := client.Session().State
+	for _, guild := range []*discordgo.Guild{
+		{ID: "100", Emojis: []*discordgo.Emoji{{ID: "1001", Name: "party_parrot"}}},
+		{ID: "200", Emojis: []*discordgo.Emoji{{ID: "2001", Name: "party_secret"}}},
+	} {
+		if err := state.GuildAdd(guild); err != nil {
+			t.Fatalf("GuildAdd: %v", err)
+		}
+	}
+	return client
+}
+
+func TestResolveReactionEmojiAllowedGuilds(t *testing.T) {
+	client := newEmojiTestClient(t)
+
+	tests := []struct {
+		name    string
+		input   string
+		wantID  string
+		wantErr bool
+	}{
+		{name: "allowed guild by name", input: ":party_parrot:", wantID: "1001"},
+		{name: "allowed guild by ID", input: "<:party_parrot:1001>", wantID: "1001"},
+		{name: "excluded guild by name", input: ":party_secret:", wantErr: true},
+		{name: "excluded guild by ID", input: "<:party_secret:2001>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := resolveReactionEmoji(client, tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && found.ID != tt.wantID {
+				t.Errorf("emoji ID = %s, want %s", found.ID, tt.wantID)
+			}
+			if err != nil && strings.Contains(err.Error(), "2001") && tt.input != "<:party_secret:2001>" {
+				t.Errorf("error leaks an excluded guild's emoji: %v", err)
+			}
+		})
+	}
+
+	suggestions := similarGuildEmoji(client, "party")
+	if len(suggestions) != 1 || suggestions[0] != "<:party_parrot:1001>" {
+		t.Errorf("suggestions = %v, want only the allowed guild's emoji", suggestions)
+	}
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/pkg/types"
+)
+
+// discordErrorResult creates a standardized error response for a failed
+// Discord operation. The error is classified so clients can branch on a
+// stable code and retry flag instead of parsing the message.
+func discordErrorResult(logger *logrus.Logger, message string, err error) types.CallToolResult {
+	logger.Errorf("%s: %v", message, err)
+
+	apiErr := discord.ClassifyError(err)
+	data := map[string]interface{}{
+		"error_type": "discord_api",
+		"message":    message,
+		"details":    err.Error(),
+		"code":       apiErr.Code,
+		"retryable":  apiErr.Retryable,
+	}
+	if apiErr.RetryAfter > 0 {
+		data["retry_after_ms"] = apiErr.RetryAfter.Milliseconds()
+	}
+	if apiErr.DiscordCode != 0 {
+		data["discord_code"] = apiErr.DiscordCode
+	}
+	if apiErr.HTTPStatus != 0 {
+		data["http_status"] = apiErr.HTTPStatus
+	}
+
+	text := i18n.T(i18n.DefaultLocale, "error.discord", message, err)
+	if apiErr.Hint != "" {
+		data["hint"] = apiErr.Hint
+		text += "\n" + i18n.T(i18n.DefaultLocale, "error.hint", apiErr.Hint)
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: data,
+		}},
+		IsError: true,
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// PollEventsTool implements the poll_events MCP tool for clients that do not
+// handle server notifications
+type PollEventsTool struct {
+	discord   *discord.Client
+	validator *validation.Validator
+}
+
+// NewPollEventsTool creates a new poll events tool
+func NewPollEventsTool(discordClient *discord.Client, validator *validation.Validator) *PollEventsTool {
+	return &PollEventsTool{
+		discord:   discordClient,
+		validator: validator,
+	}
+}
+
+// Execute executes the poll_events tool
+func (t *PollEventsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("poll_events", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	buffer := t.discord.EventBuffer()
+	if buffer == nil {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "events.disabled"),
+				Data: map[string]interface{}{
+					"error_type": "configuration",
+					"message":    "event buffer disabled",
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	var cursor int64
+	if cursorVal, ok := params.Arguments["cursor"]; ok {
+		if cursorFloat, ok := cursorVal.(float64); ok {
+			cursor = int64(cursorFloat)
+		} else if cursorInt, ok := cursorVal.(int); ok {
+			cursor = int64(cursorInt)
+		}
+	}
+
+	limit := 100
+	if limitVal, ok := params.Arguments["limit"]; ok {
+		if limitFloat, ok := limitVal.(float64); ok {
+			limit = int(limitFloat)
+		} else if limitInt, ok := limitVal.(int); ok {
+			limit = limitInt
+		}
+	}
+
+	var methods []string
+	if eventsVal, ok := params.Arguments["events"].([]interface{}); ok {
+		for _, event := range eventsVal {
+			if method, ok := event.(string); ok {
+				methods = append(methods, method)
+			}
+		}
+	}
+
+	result := buffer.Poll(cursor, limit, methods)
+
+	text := i18n.T(i18n.Locale(params), "events.polled", len(result.Events), cursor)
+	if result.Missed > 0 {
+		text = i18n.T(i18n.Locale(params), "events.polled_missed", len(result.Events), cursor, result.Missed)
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: result,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *PollEventsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("poll_events", "Poll recent Discord events since a cursor, for clients that do not receive notifications")
+}
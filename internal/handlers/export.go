@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/export"
+	"discord-mcp/internal/history"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/notifications"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/snowflake"
+	"discord-mcp/internal/timezone"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// unsafeFilenameChars matches characters replaced in export file names
+var unsafeFilenameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// ExportHandler handles channel history exports
+type ExportHandler struct {
+	discord       *discord.Client
+	permissions   *permissions.Checker
+	notifications *notifications.Service
+	validator     *validation.Validator
+	logger        *logrus.Logger
+}
+
+// NewExportHandler creates a new export handler
+func NewExportHandler(discordClient *discord.Client, permChecker *permissions.Checker, notificationSvc *notifications.Service, validator *validation.Validator, logger *logrus.Logger) *ExportHandler {
+	return &ExportHandler{
+		discord:       discordClient,
+		permissions:   permChecker,
+		notifications: notificationSvc,
+		validator:     validator,
+		logger:        logger,
+	}
+}
+
+// formatError creates a standardized error response
+func (h *ExportHandler) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(h.logger, message, err)
+}
+
+// ExportChannelTool implements the export_channel MCP tool
+type ExportChannelTool struct {
+	handler *ExportHandler
+}
+
+// NewExportChannelTool creates a new export channel tool
+func NewExportChannelTool(handler *ExportHandler) *ExportChannelTool {
+	return &ExportChannelTool{handler: handler}
+}
+
+// Execute executes the export_channel tool
+func (t *ExportChannelTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("export_channel", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	channelID := params.Arguments["channel_id"].(string)
+	stream, invalid := newResultStream(t.handler.discord, "export_channel", params)
+	if invalid != nil {
+		return *invalid, nil
+	}
+
+	format := export.FormatJSON
+	if formatVal, ok := params.Arguments["format"].(string); ok {
+		format = formatVal
+	}
+
+	output := "file"
+	if outputVal, ok := params.Arguments["output"].(string); ok {
+		output = outputVal
+	}
+
+	summary := summaryArguments(params.Arguments)
+	if summary.requested && t.handler.discord.Summarizer() == nil {
+		return summarizerDisabledResult(params), nil
+	}
+
+	cfg := t.handler.discord.Config().Export
+	maxMessages := cfg.MaxMessages
+	if maxVal, ok := params.Arguments["max_messages"]; ok {
+		if maxFloat, ok := maxVal.(float64); ok {
+			maxMessages = int(maxFloat)
+		} else if maxInt, ok := maxVal.(int); ok {
+			maxMessages = maxInt
+		}
+	}
+	if maxMessages > cfg.MaxMessages {
+		maxMessages = cfg.MaxMessages
+	}
+
+	var after, before time.Time
+	for name, dst := range map[string]*time.Time{"after": &after, "before": &before} {
+		value, ok := params.Arguments[name].(string)
+		if !ok {
+			continue
+		}
+		parsed, err := parseExportTime(value, guildLocation(t.handler.discord, "", channelID))
+		if err != nil {
+			return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+				fmt.Sprintf("%s must be %s", name, timeFormats), name)), nil
+		}
+		*dst = parsed
+	}
+	if !after.IsZero() && !before.IsZero() && !after.Before(before) {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+			"after must be earlier than before", nil)), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.ValidateMessageOperation("get_messages", channelID, nil); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.handler.formatError("Permission check failed", err), nil
+	}
+
+	channel, err := t.handler.discord.GetChannel(channelID)
+	if err != nil {
+		return t.handler.formatError("Failed to get channel", err), nil
+	}
+
+	var progressToken interface{}
+	if params.Meta != nil {
+		progressToken = params.Meta.ProgressToken
+	}
+
+	cursor, _ := params.Arguments["scan_cursor"].(string)
+	messages, next, err := t.fetchHistory(channelID, after, before, maxMessages, cursor, progressToken, stream)
+	if errors.Is(err, history.ErrInvalidCursor) {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameter", err.Error(), "scan_cursor")), nil
+	}
+	if err != nil {
+		return t.handler.formatError("Failed to get channel messages", err), nil
+	}
+	truncated := next != ""
+
+	transcript := &export.Transcript{
+		GuildID:     channel.GuildID,
+		ChannelID:   channelID,
+		ChannelName: channel.Name,
+		ExportedAt:  time.Now(),
+		After:       after,
+		Before:      before,
+		Messages:    messages,
+	}
+	rendered, err := export.Render(format, transcript, t.handler.discord.FormatMessage)
+	if err != nil {
+		return t.handler.formatError("Failed to render transcript", err), nil
+	}
+
+	data := map[string]interface{}{
+		"channel_id":    channelID,
+		"channel_name":  channel.Name,
+		"format":        format,
+		"message_count": len(messages),
+		"truncated":     truncated,
+		"size_bytes":    len(rendered),
+	}
+	if truncated {
+		data["scan_cursor"] = next
+	}
+	if stream != nil {
+		data["streamed"] = stream.summary()
+	}
+
+	summaryText := ""
+	if summary.requested {
+		if text := addSummary(t.handler.discord, t.handler.logger, data, "channel:"+channelID, summaryLines(messages), summary); text != "" {
+			summaryText = "\n\n" + i18n.T(i18n.Locale(params), "summarizer.summary") + "\n" + text
+		}
+	}
+
+	if output == "resource" {
+		uri := fmt.Sprintf("discord://channels/%s/export.%s", channelID, export.Extension(format))
+		data["uri"] = uri
+		content := []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "export.exported", len(messages), channel.Name) + summaryText,
+			Data: data,
+		}}
+		// summary_only leaves the transcript out, unless summarizing failed
+		if !summary.only || summaryText == "" {
+			content = append(content, types.Content{
+				Type: "resource",
+				Resource: &types.ResourceContents{
+					URI:      uri,
+					MimeType: export.MimeType(format),
+					Text:     string(rendered),
+				},
+			})
+		}
+		return types.CallToolResult{Content: content}, nil
+	}
+
+	path, err := writeTranscriptFile(t.handler.logger, cfg.Directory, channel, format, rendered)
+	if err != nil {
+		return t.handler.formatError("Failed to write export file", err), nil
+	}
+	data["path"] = path
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "export.written", len(messages), channel.Name, path) + summaryText,
+			Data: data,
+		}},
+	}, nil
+}
+
+// fetchHistory pages backwards through a channel and returns messages oldest
+// first, with the cursor that continues with older messages when the export
+// stopped at maxMessages
+func (t *ExportChannelTool) fetchHistory(channelID string, after, before time.Time, maxMessages int, cursor string, progressToken interface{}, stream *resultStream) ([]*discordgo.Message, string, error) {
+	var beforeID string
+	if !before.IsZero() {
+		beforeID = snowflake.At(before)
+	}
+
+	var page func(string, []*discordgo.Message)
+	if stream != nil {
+		page = func(_ string, messages []*discordgo.Message) {
+			formatted := make([]types.MessageSummary, len(messages))
+			for i, msg := range messages {
+				formatted[i] = t.handler.discord.FormatMessage(msg)
+			}
+			stream.send(formatted, len(formatted))
+		}
+	}
+
+	result, err := t.handler.discord.ScanHistory(history.Request{
+		ChannelIDs: []string{channelID},
+		BeforeID:   beforeID,
+		After:      after,
+		Limit:      maxMessages,
+		Cursor:     cursor,
+		Progress: func(progress history.Progress) {
+			t.handler.notifications.SendProgress(progressToken, float64(progress.Messages), 0,
+				fmt.Sprintf("Fetched %d messages", progress.Messages))
+		},
+		Page: page,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if err := result.Errors[channelID]; err != nil {
+		return nil, "", err
+	}
+
+	collected := result.Messages[channelID]
+	reverseMessages(collected)
+	return collected, result.Cursor, nil
+}
+
+// writeTranscriptFile stores a rendered transcript in the export directory
+func writeTranscriptFile(logger *logrus.Logger, dir string, channel *discordgo.Channel, format string, data []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	name := unsafeFilenameChars.ReplaceAllString(channel.Name, "_")
+	filename := fmt.Sprintf("%s-%s-%s.%s", name, channel.ID, time.Now().UTC().Format("20060102-150405"), export.Extension(format))
+	path := filepath.Join(dir, filename)
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", err
+	}
+
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	logger.Infof("Exported channel %s to %s", channel.ID, path)
+	return path, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ExportChannelTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("export_channel", "Export a channel's message history (or a date range) as a JSON, CSV or Markdown transcript")
+}
+
+// timeFormats describes the values parseExportTime accepts, for error
+// messages
+const timeFormats = "an RFC 3339 timestamp, a date such as 2025-01-05 18:00 CET, or a relative time such as 2h, yesterday or last friday 6pm"
+
+// parseExportTime accepts an RFC 3339 timestamp, a YYYY-MM-DD date with an
+// optional time and zone, or a time relative to now such as 2h or since
+// yesterday, which counts back; times without a zone are read in loc
+func parseExportTime(value string, loc *time.Location) (time.Time, error) {
+	return timezone.Resolve(value, time.Now(), loc, timezone.Past)
+}
+
+// guildLocation returns the time zone of a guild, or of the guild a
+// channel is in when no guild is given
+func guildLocation(client *discord.Client, guildID, channelID string) *time.Location {
+	if guildID == "" && channelID != "" {
+		if channel, err := client.GetChannel(channelID); err == nil {
+			guildID = channel.GuildID
+		}
+	}
+	return client.Location(guildID)
+}
+
+// reverseMessages reverses a message slice in place
+func reverseMessages(messages []*discordgo.Message) {
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"discord-mcp/internal/feeds"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/jsonfile"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// AddFeedTool implements the add_feed MCP tool
+type AddFeedTool struct {
+	handler *MessageHandler
+}
+
+// NewAddFeedTool creates a new add feed tool
+func NewAddFeedTool(handler *MessageHandler) *AddFeedTool {
+	return &AddFeedTool{handler: handler}
+}
+
+// Execute executes the add_feed tool
+func (t *AddFeedTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("add_feed", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	watcher := t.handler.discord.Feeds()
+	if watcher == nil {
+		return feedsDisabledResult(params), nil
+	}
+
+	cfg := t.handler.discord.Config().Feeds
+	feedURL := params.Arguments["url"].(string)
+	channelID := params.Arguments["channel_id"].(string)
+	interval := intArgument(params.Arguments, "interval_minutes", cfg.IntervalMinutes)
+	if interval < cfg.MinIntervalMinutes {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+			fmt.Sprintf("interval_minutes must be at least %d (feeds.min_interval_minutes)", cfg.MinIntervalMinutes), "interval_minutes")), nil
+	}
+
+	channel, err := t.handler.discord.GetChannel(channelID)
+	if err != nil {
+		return t.formatError("Failed to get channel", err), nil
+	}
+	if channel.GuildID == "" {
+		return validation.FormatValidationError(validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s is not in a guild", channelID), "channel_id")), nil
+	}
+	// GetGuild refuses guilds outside discord.allowed_guilds
+	if _, err := t.handler.discord.GetGuild(channel.GuildID); err != nil {
+		return t.formatError("Failed to get guild", err), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanSendMessages(channelID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	added, latest, err := watcher.Add(feeds.Feed{
+		URL:             feedURL,
+		GuildID:         channel.GuildID,
+		ChannelID:       channelID,
+		IntervalMinutes: interval,
+	})
+	if errors.Is(err, jsonfile.ErrSave) {
+		return t.formatError("Failed to save feed", err), nil
+	}
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid feed", err.Error(), "url")), nil
+	}
+
+	data := map[string]interface{}{
+		"feed": added,
+	}
+	if latest != nil {
+		data["latest_entry"] = latest
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "feeds.added", added.ID, added.Title, channelID, added.NextCheckAt.Format(time.RFC3339)),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *AddFeedTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("add_feed", "Watch an RSS or Atom feed and post its new entries to a channel as embeds. Entries already in the feed are not posted")
+}
+
+// formatError creates a standardized error response
+func (t *AddFeedTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListFeedsTool implements the list_feeds MCP tool
+type ListFeedsTool struct {
+	handler *MessageHandler
+}
+
+// NewListFeedsTool creates a new list feeds tool
+func NewListFeedsTool(handler *MessageHandler) *ListFeedsTool {
+	return &ListFeedsTool{handler: handler}
+}
+
+// Execute executes the list_feeds tool
+func (t *ListFeedsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_feeds", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	watcher := t.handler.discord.Feeds()
+	if watcher == nil {
+		return feedsDisabledResult(params), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	channelID, _ := params.Arguments["channel_id"].(string)
+	if guildID != "" {
+		// Validate permissions
+		if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return t.formatError("Permission check failed", err), nil
+		}
+	}
+
+	list := make([]feeds.Feed, 0)
+	for _, feed := range watcher.List(guildID) {
+		if channelID == "" || feed.ChannelID == channelID {
+			list = append(list, feed)
+		}
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "feeds.list", len(list)),
+			Data: map[string]interface{}{
+				"feed_count": len(list),
+				"feeds":      list,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListFeedsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_feeds", "List watched RSS and Atom feeds with their channel, next check, entries posted and most recent error")
+}
+
+// formatError creates a standardized error response
+func (t *ListFeedsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// RemoveFeedTool implements the remove_feed MCP tool
+type RemoveFeedTool struct {
+	handler *MessageHandler
+}
+
+// NewRemoveFeedTool creates a new remove feed tool
+func NewRemoveFeedTool(handler *MessageHandler) *RemoveFeedTool {
+	return &RemoveFeedTool{handler: handler}
+}
+
+// Execute executes the remove_feed tool
+func (t *RemoveFeedTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("remove_feed", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	watcher := t.handler.discord.Feeds()
+	if watcher == nil {
+		return feedsDisabledResult(params), nil
+	}
+
+	feedID := params.Arguments["feed_id"].(string)
+	removed, ok, err := watcher.Remove(feedID)
+	if err != nil {
+		return t.formatError("Failed to save feeds", err), nil
+	}
+	if !ok {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "feeds.not_found", feedID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"feed_id":    feedID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "feeds.removed", feedID),
+			Data: map[string]interface{}{
+				"feed_id": feedID,
+				"url":     removed.URL,
+				"removed": true,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *RemoveFeedTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("remove_feed", "Stop watching a feed; entries already posted are kept")
+}
+
+// formatError creates a standardized error response
+func (t *RemoveFeedTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// feedsDisabledResult reports that feeds are turned off in the
+// configuration
+func feedsDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "feeds.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "feeds disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// followerWebhook is a channel follower webhook as returned by the API.
+// The API client's type has no source guild or channel.
+type followerWebhook struct {
+	ID            string          `json:"id"`
+	Type          int             `json:"type"`
+	GuildID       string          `json:"guild_id"`
+	ChannelID     string          `json:"channel_id"`
+	Name          string          `json:"name"`
+	User          *discordgo.User `json:"user"`
+	SourceGuild   *followSource   `json:"source_guild"`
+	SourceChannel *followSource   `json:"source_channel"`
+}
+
+// followSource names the guild or channel a follow posts from
+type followSource struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// FollowAnnouncementChannelTool implements the follow_announcement_channel
+// MCP tool
+type FollowAnnouncementChannelTool struct {
+	handler *ChannelHandler
+}
+
+// NewFollowAnnouncementChannelTool creates a new follow announcement channel
+// tool
+func NewFollowAnnouncementChannelTool(handler *ChannelHandler) *FollowAnnouncementChannelTool {
+	return &FollowAnnouncementChannelTool{handler: handler}
+}
+
+// Execute executes the follow_announcement_channel tool
+func (t *FollowAnnouncementChannelTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("follow_announcement_channel", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	sourceID := params.Arguments["source_channel_id"].(string)
+	targetID := params.Arguments["target_channel_id"].(string)
+
+	// Validate permissions
+	if err := t.checkPermissions(sourceID, targetID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		if validationErr, ok := err.(*validation.ValidationError); ok {
+			return validation.FormatValidationError(validationErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	var follow *discordgo.ChannelFollow
+	retries, err := t.handler.discord.Retry(func() (err error) {
+		follow, err = t.handler.discord.Session().ChannelNewsFollow(sourceID, targetID, auditLogOptions(params.Arguments)...)
+		return err
+	})
+	if err != nil {
+		return t.formatError("Failed to follow announcement channel", err), nil
+	}
+
+	t.handler.logger.Infof("Channel %s now follows announcement channel %s", targetID, sourceID)
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "follows.created", targetID, sourceID),
+			Data: map[string]interface{}{
+				"source_channel_id": follow.ChannelID,
+				"target_channel_id": targetID,
+				"webhook_id":        follow.WebhookID,
+				"retries":           retries,
+			},
+		}},
+	}, nil
+}
+
+// checkPermissions checks that the source is an announcement channel the
+// bot can see and that the bot can add webhooks to the target
+func (t *FollowAnnouncementChannelTool) checkPermissions(sourceID, targetID string) error {
+	if err := t.handler.permissions.CanViewChannel(sourceID); err != nil {
+		return err
+	}
+	source, err := t.handler.discord.GetChannel(sourceID)
+	if err != nil {
+		return err
+	}
+	if source.Type != discordgo.ChannelTypeGuildNews {
+		return validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s is not an announcement channel", sourceID), "source_channel_id")
+	}
+	return t.handler.permissions.CanManageWebhooks(targetID)
+}
+
+// GetDefinition returns the tool definition
+func (t *FollowAnnouncementChannelTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("follow_announcement_channel", "Subscribe a channel to an announcement channel, so every message published there is crossposted into it")
+}
+
+// formatError creates a standardized error response
+func (t *FollowAnnouncementChannelTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListAnnouncementFollowsTool implements the list_announcement_follows MCP
+// tool
+type ListAnnouncementFollowsTool struct {
+	handler *ChannelHandler
+}
+
+// NewListAnnouncementFollowsTool creates a new list announcement follows tool
+func NewListAnnouncementFollowsTool(handler *ChannelHandler) *ListAnnouncementFollowsTool {
+	return &ListAnnouncementFollowsTool{handler: handler}
+}
+
+// Execute executes the list_announcement_follows tool
+func (t *ListAnnouncementFollowsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_announcement_follows", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	channelID, _ := params.Arguments["channel_id"].(string)
+	if guildID == "" && channelID == "" {
+		return validation.FormatValidationError(validation.NewValidationError("missing parameter",
+			"pass guild_id or channel_id", nil)), nil
+	}
+
+	// A channel's follows need Manage Webhooks there; a guild's need it
+	// guild-wide
+	var endpoint string
+	var err error
+	if channelID != "" {
+		endpoint = discordgo.EndpointChannelWebhooks(channelID)
+		err = t.handler.permissions.CanManageWebhooks(channelID)
+	} else {
+		endpoint = discordgo.EndpointGuildWebhooks(guildID)
+		err = t.handler.permissions.CanManageGuildWebhooks(guildID)
+	}
+	if err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	var webhooks []followerWebhook
+	_, err = t.handler.discord.Retry(func() error {
+		body, err := t.handler.discord.Session().RequestWithBucketID("GET", endpoint, nil, endpoint)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &webhooks)
+	})
+	if err != nil {
+		return t.formatError("Failed to list announcement follows", err), nil
+	}
+
+	follows := make([]map[string]interface{}, 0)
+	for _, webhook := range webhooks {
+		if webhook.Type != int(discordgo.WebhookTypeChannelFollower) {
+			continue
+		}
+		// Follows into channels hidden by the access lists are left out
+		if channelID == "" && t.handler.permissions.CanAccessChannel(webhook.ChannelID) != nil {
+			continue
+		}
+		follow := map[string]interface{}{
+			"webhook_id":        webhook.ID,
+			"name":              webhook.Name,
+			"target_channel_id": webhook.ChannelID,
+		}
+		if webhook.SourceGuild != nil {
+			follow["source_guild_id"] = webhook.SourceGuild.ID
+			follow["source_guild_name"] = webhook.SourceGuild.Name
+		}
+		if webhook.SourceChannel != nil {
+			follow["source_channel_id"] = webhook.SourceChannel.ID
+			follow["source_channel_name"] = webhook.SourceChannel.Name
+		}
+		if webhook.User != nil {
+			follow["created_by"] = map[string]interface{}{
+				"id":       webhook.User.ID,
+				"username": webhook.User.Username,
+			}
+		}
+		follows = append(follows, follow)
+	}
+
+	scope := channelID
+	if scope == "" {
+		scope = guildID
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "follows.list", len(follows), scope),
+			Data: map[string]interface{}{
+				"follow_count": len(follows),
+				"follows":      follows,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListAnnouncementFollowsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_announcement_follows", "List the announcement channels a channel or server follows, from its channel follower webhooks")
+}
+
+// formatError creates a standardized error response
+func (t *ListAnnouncementFollowsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
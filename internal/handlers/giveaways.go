@@ -0,0 +1,426 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"discord-mcp/internal/giveaway"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/jsonfile"
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/timezone"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// giveawayColor is the embed color of giveaway messages
+const giveawayColor = 0xF1C40F
+
+// StartGiveawayTool implements the start_giveaway MCP tool
+type StartGiveawayTool struct {
+	handler *MessageHandler
+}
+
+// NewStartGiveawayTool creates a new start giveaway tool
+func NewStartGiveawayTool(handler *MessageHandler) *StartGiveawayTool {
+	return &StartGiveawayTool{handler: handler}
+}
+
+// Execute executes the start_giveaway tool
+func (t *StartGiveawayTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("start_giveaway", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Giveaways()
+	if store == nil {
+		return giveawaysDisabledResult(params), nil
+	}
+
+	g := giveaway.Giveaway{WinnerCount: intArgument(params.Arguments, "winner_count", 1)}
+	g.ChannelID = params.Arguments["channel_id"].(string)
+	g.Prize = params.Arguments["prize"].(string)
+	g.Description, _ = params.Arguments["description"].(string)
+	if endsAt, ok := params.Arguments["ends_at"].(string); ok {
+		parsed, err := timezone.Resolve(endsAt, time.Now(), guildLocation(t.handler.discord, "", g.ChannelID), timezone.Future)
+		if err != nil {
+			return validation.FormatValidationError(validation.NewValidationError("invalid ends_at",
+				"ends_at must be an RFC 3339 timestamp, a date such as 2024-06-01 18:00 CET, or a relative time such as 3d or next friday 6pm", "ends_at")), nil
+		}
+		parsed = parsed.UTC()
+		g.EndsAt = &parsed
+	}
+
+	emojiInput := "🎉"
+	if input, ok := params.Arguments["emoji"].(string); ok {
+		emojiInput = input
+	}
+	resolved, err := resolveReactionEmoji(t.handler.discord, emojiInput)
+	if err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+	g.Emoji = resolved.MessageFormat()
+	g.EmojiAPIName = resolved.APIName()
+
+	// Validate permissions
+	if err := t.checkPermissions(g.ChannelID, g.Emoji); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	var message *discordgo.Message
+	_, _, err = t.handler.discord.SendQueued(g.ChannelID, outbound.PriorityInteractive, func() (err error) {
+		message, err = t.handler.discord.Session().ChannelMessageSendComplex(g.ChannelID, &discordgo.MessageSend{
+			Embeds: []*discordgo.MessageEmbed{giveawayEmbed(i18n.Locale(params), g)},
+		})
+		return err
+	})
+	if err != nil {
+		return t.formatError("Failed to send giveaway", err), nil
+	}
+	g.MessageID = message.ID
+	g.GuildID = message.GuildID
+
+	// The bot's own reaction gives members a button to click
+	_, err = t.handler.discord.Retry(func() error {
+		return t.handler.discord.Session().MessageReactionAdd(g.ChannelID, g.MessageID, resolved.APIName())
+	})
+	if err != nil {
+		return t.formatError("Failed to add giveaway reaction", err), nil
+	}
+
+	created, err := store.Add(g)
+	if errors.Is(err, jsonfile.ErrSave) {
+		return t.formatError("Failed to save giveaway", err), nil
+	}
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid giveaway", err.Error(), nil)), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "giveaways.started", created.ID, created.Prize, created.ChannelID),
+			Data: map[string]interface{}{
+				"giveaway":    created,
+				"message_url": fmt.Sprintf("https://discord.com/channels/%s/%s/%s", message.GuildID, created.ChannelID, created.MessageID),
+			},
+		}},
+	}, nil
+}
+
+// checkPermissions checks that the bot can post the giveaway and react to it
+func (t *StartGiveawayTool) checkPermissions(channelID, emoji string) error {
+	if err := t.handler.permissions.ValidateMessageOperation("send_message", channelID, map[string]interface{}{"tts": false}); err != nil {
+		return err
+	}
+	if err := t.handler.permissions.ValidateMessageOperation("add_reaction", channelID, map[string]interface{}{"emoji": emoji}); err != nil {
+		return err
+	}
+	return t.handler.permissions.CanReadMessageHistory(channelID)
+}
+
+// GetDefinition returns the tool definition
+func (t *StartGiveawayTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("start_giveaway", "Post a giveaway embed that members enter by reacting, for drawing winners later with draw_giveaway_winner")
+}
+
+// formatError creates a standardized error response
+func (t *StartGiveawayTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListGiveawaysTool implements the list_giveaways MCP tool
+type ListGiveawaysTool struct {
+	handler *MessageHandler
+}
+
+// NewListGiveawaysTool creates a new list giveaways tool
+func NewListGiveawaysTool(handler *MessageHandler) *ListGiveawaysTool {
+	return &ListGiveawaysTool{handler: handler}
+}
+
+// Execute executes the list_giveaways tool
+func (t *ListGiveawaysTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_giveaways", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Giveaways()
+	if store == nil {
+		return giveawaysDisabledResult(params), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	status, _ := params.Arguments["status"].(string)
+	giveaways := make([]giveaway.Giveaway, 0)
+	for _, g := range store.List() {
+		if (guildID == "" || g.GuildID == guildID) && (status == "" || g.Status == status) {
+			giveaways = append(giveaways, g)
+		}
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "giveaways.list", len(giveaways)),
+			Data: map[string]interface{}{
+				"giveaway_count": len(giveaways),
+				"giveaways":      giveaways,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListGiveawaysTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_giveaways", "List giveaways with their status, entrant count and winners")
+}
+
+// DrawGiveawayWinnerTool implements the draw_giveaway_winner MCP tool
+type DrawGiveawayWinnerTool struct {
+	handler *MessageHandler
+}
+
+// NewDrawGiveawayWinnerTool creates a new draw giveaway winner tool
+func NewDrawGiveawayWinnerTool(handler *MessageHandler) *DrawGiveawayWinnerTool {
+	return &DrawGiveawayWinnerTool{handler: handler}
+}
+
+// Execute executes the draw_giveaway_winner tool
+func (t *DrawGiveawayWinnerTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("draw_giveaway_winner", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Giveaways()
+	if store == nil {
+		return giveawaysDisabledResult(params), nil
+	}
+
+	giveawayID := params.Arguments["giveaway_id"].(string)
+	reroll, _ := params.Arguments["reroll"].(bool)
+	announce := true
+	if val, ok := params.Arguments["announce"].(bool); ok {
+		announce = val
+	}
+
+	g, ok := store.Get(giveawayID)
+	if !ok {
+		return giveawayNotFoundResult(params, giveawayID), nil
+	}
+	if g.Status == giveaway.StatusDrawn && !reroll {
+		return validation.FormatValidationError(validation.NewValidationError("giveaway already drawn",
+			fmt.Sprintf("giveaway %s already has winners; pass reroll to draw again", giveawayID), "reroll")), nil
+	}
+	count := intArgument(params.Arguments, "winner_count", g.WinnerCount)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanReadMessageHistory(g.ChannelID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	entrants, err := t.entrants(g)
+	if err != nil {
+		return t.formatError("Failed to get giveaway entrants", err), nil
+	}
+
+	// A reroll replaces winners, so earlier winners cannot win again
+	var exclude []string
+	if reroll {
+		exclude = g.Winners
+	}
+	winners, err := giveaway.Draw(entrants, exclude, count)
+	if err != nil {
+		return t.formatError("Failed to draw winners", err), nil
+	}
+	if len(winners) == 0 {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "giveaways.no_entrants", giveawayID),
+				Data: map[string]interface{}{
+					"giveaway_id":   giveawayID,
+					"entrant_count": len(entrants),
+					"winners":       winners,
+				},
+			}},
+		}, nil
+	}
+
+	drawn, err := store.SetWinners(giveawayID, winners, len(entrants))
+	if err != nil {
+		return t.formatError("Failed to save giveaway", err), nil
+	}
+
+	// Announcing is best effort; the draw is already saved
+	announced := false
+	if announce {
+		if err := t.announce(i18n.Locale(params), drawn); err != nil {
+			t.handler.logger.Warnf("Failed to announce giveaway %s winners: %v", giveawayID, err)
+		} else {
+			announced = true
+		}
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "giveaways.drawn", len(winners), giveawayID, len(entrants)),
+			Data: map[string]interface{}{
+				"giveaway":      drawn,
+				"winners":       winners,
+				"entrant_count": len(entrants),
+				"announced":     announced,
+			},
+		}},
+	}, nil
+}
+
+// entrants returns the IDs of every non-bot user who reacted with the
+// giveaway emoji, paging through the reaction list
+func (t *DrawGiveawayWinnerTool) entrants(g giveaway.Giveaway) ([]string, error) {
+	var entrants []string
+	afterID := ""
+	for {
+		var page []*discordgo.User
+		_, err := t.handler.discord.Retry(func() (err error) {
+			page, err = t.handler.discord.Session().MessageReactions(g.ChannelID, g.MessageID, g.EmojiAPIName, 100, "", afterID)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range page {
+			if !user.Bot {
+				entrants = append(entrants, user.ID)
+			}
+		}
+		if len(page) < 100 {
+			return entrants, nil
+		}
+		afterID = page[len(page)-1].ID
+	}
+}
+
+// announce replies to the giveaway with the winners and updates its embed
+func (t *DrawGiveawayWinnerTool) announce(locale string, g giveaway.Giveaway) error {
+	mentions := make([]string, len(g.Winners))
+	for i, id := range g.Winners {
+		mentions[i] = "<@" + id + ">"
+	}
+
+	failIfNotExists := false
+	_, _, err := t.handler.discord.SendQueued(g.ChannelID, outbound.PriorityInteractive, func() error {
+		_, err := t.handler.discord.Session().ChannelMessageSendComplex(g.ChannelID, &discordgo.MessageSend{
+			Content: i18n.T(locale, "giveaways.announcement", strings.Join(mentions, ", "), g.Prize),
+			Reference: &discordgo.MessageReference{
+				MessageID:       g.MessageID,
+				ChannelID:       g.ChannelID,
+				GuildID:         g.GuildID,
+				FailIfNotExists: &failIfNotExists,
+			},
+			// Only the winners are pinged
+			AllowedMentions: &discordgo.MessageAllowedMentions{Users: g.Winners},
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	embeds := []*discordgo.MessageEmbed{giveawayEmbed(locale, g)}
+	_, err = t.handler.discord.Retry(func() error {
+		_, err := t.handler.discord.Session().ChannelMessageEditComplex(&discordgo.MessageEdit{
+			ID:      g.MessageID,
+			Channel: g.ChannelID,
+			Embeds:  &embeds,
+		})
+		return err
+	})
+	return err
+}
+
+// GetDefinition returns the tool definition
+func (t *DrawGiveawayWinnerTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("draw_giveaway_winner", "Draw random winners from the members who reacted to a giveaway, or reroll to replace earlier winners")
+}
+
+// formatError creates a standardized error response
+func (t *DrawGiveawayWinnerTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// giveawayEmbed renders the giveaway message, listing the winners once drawn
+func giveawayEmbed(locale string, g giveaway.Giveaway) *discordgo.MessageEmbed {
+	lines := []string{}
+	if g.Description != "" {
+		lines = append(lines, g.Description, "")
+	}
+	lines = append(lines, i18n.T(locale, "giveaways.enter", g.Emoji))
+	lines = append(lines, i18n.T(locale, "giveaways.winner_count", g.WinnerCount))
+	if g.EndsAt != nil {
+		lines = append(lines, i18n.T(locale, "giveaways.ends", fmt.Sprintf("<t:%d:R>", g.EndsAt.Unix())))
+	}
+	if g.Status == giveaway.StatusDrawn {
+		mentions := make([]string, len(g.Winners))
+		for i, id := range g.Winners {
+			mentions[i] = "<@" + id + ">"
+		}
+		lines = append(lines, "", i18n.T(locale, "giveaways.winners", strings.Join(mentions, ", ")))
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🎁 " + g.Prize,
+		Description: strings.Join(lines, "\n"),
+		Color:       giveawayColor,
+	}
+	if g.EndsAt != nil {
+		embed.Timestamp = g.EndsAt.Format(time.RFC3339)
+	}
+	return embed
+}
+
+// giveawayNotFoundResult reports an unknown giveaway ID
+func giveawayNotFoundResult(params types.CallToolParams, giveawayID string) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "giveaways.not_found", giveawayID),
+			Data: map[string]interface{}{
+				"error_type":  "not_found",
+				"giveaway_id": giveawayID,
+			},
+		}},
+		IsError: true,
+	}
+}
+
+// giveawaysDisabledResult reports that giveaways are turned off in the
+// configuration
+func giveawaysDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "giveaways.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "giveaways disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
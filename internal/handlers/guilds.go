@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
 
 	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
 	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/snowflake"
 	"discord-mcp/internal/validation"
 	"discord-mcp/pkg/types"
 )
@@ -58,6 +61,15 @@ func (t *GetGuildInfoTool) Execute(params types.CallToolParams) (types.CallToolR
 		return t.formatError("Permission check failed", err), nil
 	}
 
+	includeCounts := true
+	if val, ok := params.Arguments["include_counts"].(bool); ok {
+		includeCounts = val
+	}
+	includeFeatures := true
+	if val, ok := params.Arguments["include_features"].(bool); ok {
+		includeFeatures = val
+	}
+
 	// Get guild from Discord
 	guild, err := t.handler.discord.GetGuild(guildID)
 	if err != nil {
@@ -67,10 +79,42 @@ func (t *GetGuildInfoTool) Execute(params types.CallToolParams) (types.CallToolR
 	// Format guild for response
 	formattedGuild := t.formatGuild(guild)
 
+	if includeFeatures {
+		features := make([]string, len(guild.Features))
+		for i, feature := range guild.Features {
+			features[i] = string(feature)
+		}
+		formattedGuild["features"] = features
+	}
+
+	if includeCounts {
+		// Presence counts are only returned by the REST endpoint with
+		// with_counts; gateway state never includes them
+		var counted *discordgo.Guild
+		_, err := t.handler.discord.Retry(func() (err error) {
+			counted, err = t.handler.discord.Session().GuildWithCounts(guildID)
+			return err
+		})
+		if err != nil {
+			t.handler.logger.Warnf("Failed to get approximate counts for guild %s: %v", guildID, err)
+			formattedGuild["counts_error"] = err.Error()
+		} else {
+			formattedGuild["approximate_member_count"] = counted.ApproximateMemberCount
+			formattedGuild["approximate_presence_count"] = counted.ApproximatePresenceCount
+		}
+
+		if channels, err := t.handler.discord.GetChannels(guildID); err == nil {
+			formattedGuild["channel_count"] = len(channels)
+		}
+		if roles, err := t.handler.discord.GetRoles(guildID); err == nil {
+			formattedGuild["role_count"] = len(roles)
+		}
+	}
+
 	return types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("Guild: %s", guild.Name),
+			Text: i18n.T(i18n.Locale(params), "guilds.info", guild.Name),
 			Data: formattedGuild,
 		}},
 	}, nil
@@ -83,33 +127,58 @@ func (t *GetGuildInfoTool) GetDefinition() types.Tool {
 
 // formatGuild formats a single guild for the response
 func (t *GetGuildInfoTool) formatGuild(guild *discordgo.Guild) map[string]interface{} {
-	return map[string]interface{}{
-		"id":          guild.ID,
-		"name":        guild.Name,
-		"description": guild.Description,
-		"icon":        guild.Icon,
-		"splash":      guild.Splash,
-		"banner":      guild.Banner,
-		"owner_id":    guild.OwnerID,
-		"member_count": guild.MemberCount,
+	urls := t.handler.discord.CDN()
+	formatted := map[string]interface{}{
+		"id":                         guild.ID,
+		"name":                       guild.Name,
+		"description":                guild.Description,
+		"icon":                       guild.Icon,
+		"splash":                     guild.Splash,
+		"banner":                     guild.Banner,
+		"icon_url":                   urls.GuildIcon(guild.ID, guild.Icon),
+		"splash_url":                 urls.GuildSplash(guild.ID, guild.Splash),
+		"banner_url":                 urls.GuildBanner(guild.ID, guild.Banner),
+		"discovery_splash_url":       urls.GuildDiscoverySplash(guild.ID, guild.DiscoverySplash),
+		"owner_id":                   guild.OwnerID,
+		"member_count":               guild.MemberCount,
+		"premium_tier":               int(guild.PremiumTier),
+		"premium_subscription_count": guild.PremiumSubscriptionCount,
+		"verification_level":         verificationLevelName(guild.VerificationLevel),
+		"nsfw_level":                 int(guild.NSFWLevel),
+		"preferred_locale":           guild.PreferredLocale,
+		"system_channel_id":          guild.SystemChannelID,
+		"rules_channel_id":           guild.RulesChannelID,
 	}
+	if guild.VanityURLCode != "" {
+		formatted["vanity_url_code"] = guild.VanityURLCode
+		formatted["vanity_url"] = "https://discord.gg/" + guild.VanityURLCode
+	}
+	if parts, err := snowflake.Parse(guild.ID); err == nil {
+		formatted["created_at"] = parts.Timestamp.Format(time.RFC3339)
+	}
+	return formatted
+}
+
+// verificationLevelName returns the API name of a guild verification level
+func verificationLevelName(level discordgo.VerificationLevel) string {
+	switch level {
+	case discordgo.VerificationLevelNone:
+		return "none"
+	case discordgo.VerificationLevelLow:
+		return "low"
+	case discordgo.VerificationLevelMedium:
+		return "medium"
+	case discordgo.VerificationLevelHigh:
+		return "high"
+	case discordgo.VerificationLevelVeryHigh:
+		return "very_high"
+	}
+	return fmt.Sprintf("unknown_%d", level)
 }
 
 // formatError creates a standardized error response
 func (t *GetGuildInfoTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
 
 // ListGuildMembersTool implements the list_guild_members MCP tool
@@ -131,6 +200,10 @@ func (t *ListGuildMembersTool) Execute(params types.CallToolParams) (types.CallT
 
 	// Extract parameters
 	guildID := params.Arguments["guild_id"].(string)
+	stream, invalid := newResultStream(t.handler.discord, "list_guild_members", params)
+	if invalid != nil {
+		return *invalid, nil
+	}
 
 	// Validate permissions
 	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
@@ -140,63 +213,90 @@ func (t *ListGuildMembersTool) Execute(params types.CallToolParams) (types.CallT
 		return t.formatError("Permission check failed", err), nil
 	}
 
+	if stream != nil {
+		return t.streamMembers(params, guildID, stream), nil
+	}
+
 	// Get members from Discord
-	members, err := t.handler.discord.Session().GuildMembers(guildID, "", 1000)
+	var members []*discordgo.Member
+	retries, err := t.handler.discord.Retry(func() (err error) {
+		members, err = t.handler.discord.Session().GuildMembers(guildID, "", 1000)
+		return err
+	})
 	if err != nil {
 		return t.formatError("Failed to list guild members", err), nil
 	}
 
 	// Format members for response
-	formattedMembers := make([]map[string]interface{}, len(members))
+	formattedMembers := make([]types.MemberSummary, len(members))
 	for i, member := range members {
-		formattedMembers[i] = t.formatMember(member)
+		formattedMembers[i] = t.handler.discord.FormatMember(guildID, member)
 	}
 
 	return types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("Found %d members in guild %s", len(formattedMembers), guildID),
+			Text: i18n.T(i18n.Locale(params), "guilds.members", len(formattedMembers), guildID),
 			Data: map[string]interface{}{
 				"guild_id":      guildID,
 				"member_count":  len(formattedMembers),
 				"members":       formattedMembers,
+				"retries":       retries,
 			},
 		}},
 	}, nil
 }
 
-// GetDefinition returns the tool definition
-func (t *ListGuildMembersTool) GetDefinition() types.Tool {
-	return validation.GetToolDefinition("list_guild_members", "List all members in a Discord server (guild)")
-}
+// streamMembers pages through every member of a guild, streaming each page
+// as it arrives. The final result only reports counts.
+func (t *ListGuildMembersTool) streamMembers(params types.CallToolParams, guildID string, stream *resultStream) types.CallToolResult {
+	afterID := ""
+	total := 0
+	retries := 0
+	for {
+		var page []*discordgo.Member
+		pageRetries, err := t.handler.discord.Retry(func() (err error) {
+			page, err = t.handler.discord.Session().GuildMembers(guildID, afterID, 1000)
+			return err
+		})
+		retries += pageRetries
+		if err != nil {
+			return t.formatError(fmt.Sprintf("Failed to list guild members after %d members", total), err)
+		}
+
+		formattedMembers := make([]types.MemberSummary, len(page))
+		for i, member := range page {
+			formattedMembers[i] = t.handler.discord.FormatMember(guildID, member)
+		}
+		stream.send(formattedMembers, len(formattedMembers))
+		total += len(page)
 
-// formatMember formats a single member for the response
-func (t *ListGuildMembersTool) formatMember(member *discordgo.Member) map[string]interface{} {
-	return map[string]interface{}{
-		"id":          member.User.ID,
-		"username":    member.User.Username,
-		"discriminator": member.User.Discriminator,
-		"nick":        member.Nick,
-		"roles":       member.Roles,
-		"joined_at":   member.JoinedAt,
-		"deaf":        member.Deaf,
-		"mute":        member.Mute,
+		if len(page) < 1000 {
+			break
+		}
+		afterID = page[len(page)-1].User.ID
 	}
-}
 
-// formatError creates a standardized error response
-func (t *ListGuildMembersTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
 	return types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
+			Text: i18n.T(i18n.Locale(params), "guilds.members", total, guildID),
 			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
+				"guild_id":     guildID,
+				"member_count": total,
+				"streamed":     stream.summary(),
+				"retries":      retries,
 			},
 		}},
-		IsError: true,
 	}
 }
+
+// GetDefinition returns the tool definition
+func (t *ListGuildMembersTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_guild_members", "List all members in a Discord server (guild)")
+}
+
+// formatError creates a standardized error response
+func (t *ListGuildMembersTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
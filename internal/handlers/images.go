@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/cdn"
+	"discord-mcp/internal/config"
+	"discord-mcp/pkg/types"
+)
+
+// imageTypes are the attachment formats returned as image content
+var imageTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// imageHosts are the Discord hosts images are downloaded from
+var imageHosts = map[string]bool{
+	"cdn.discordapp.com":   true,
+	"media.discordapp.net": true,
+}
+
+// imageFetcher downloads attachments, stickers and emoji as MCP image content
+type imageFetcher struct {
+	cfg    config.ImagesConfig
+	client *http.Client
+	logger *logrus.Logger
+}
+
+func newImageFetcher(cfg config.ImagesConfig, logger *logrus.Logger) *imageFetcher {
+	return &imageFetcher{
+		cfg:    cfg,
+		client: &http.Client{Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second},
+		logger: logger,
+	}
+}
+
+// imageBatch collects the images fetched for one tool call
+type imageBatch struct {
+	fetcher  *imageFetcher
+	contents []types.Content
+	fetched  []map[string]interface{}
+	skipped  []map[string]interface{}
+}
+
+// add fetches one image unless the batch is full or the image is known to be
+// too large, recording the outcome in info
+func (b *imageBatch) add(info map[string]interface{}, rawURL string, size int) {
+	reason := ""
+	switch {
+	case len(b.contents) >= b.fetcher.cfg.MaxImages:
+		reason = "max_images"
+	case size > b.fetcher.cfg.MaxBytes:
+		reason = "too_large"
+	}
+	if reason != "" {
+		info["reason"] = reason
+		b.skipped = append(b.skipped, info)
+		return
+	}
+
+	content, err := b.fetcher.image(rawURL)
+	if err != nil {
+		b.fetcher.logger.Warnf("Failed to fetch image %s: %v", rawURL, err)
+		info["reason"] = "fetch_failed"
+		if _, ok := err.(unsupportedImageError); ok {
+			info["reason"] = "unsupported_type"
+		}
+		b.skipped = append(b.skipped, info)
+		return
+	}
+	info["mime_type"] = content.MimeType
+	b.fetched = append(b.fetched, info)
+	b.contents = append(b.contents, content)
+}
+
+// fetch returns image content for the image attachments and stickers of
+// messages, in message order
+func (f *imageFetcher) fetch(messages []*discordgo.Message, cdnBuilder *cdn.Builder) *imageBatch {
+	batch := &imageBatch{fetcher: f}
+	for _, msg := range messages {
+		for _, att := range msg.Attachments {
+			if !imageTypes[attachmentType(att)] {
+				continue
+			}
+			batch.add(map[string]interface{}{
+				"message_id":    msg.ID,
+				"attachment_id": att.ID,
+				"filename":      att.Filename,
+			}, att.URL, att.Size)
+		}
+		for _, sticker := range msg.StickerItems {
+			// Lottie stickers are vector animations, not images
+			if sticker.FormatType == discordgo.StickerFormatTypeLottie {
+				continue
+			}
+			batch.add(map[string]interface{}{
+				"message_id": msg.ID,
+				"sticker_id": sticker.ID,
+				"name":       sticker.Name,
+			}, cdnBuilder.Sticker(sticker.ID, sticker.FormatType), 0)
+		}
+	}
+	return batch
+}
+
+// fetchEmoji returns image content for custom emoji; Unicode emoji are
+// skipped since they have no image
+func (f *imageFetcher) fetchEmoji(emojis []*discordgo.Emoji, cdnBuilder *cdn.Builder) *imageBatch {
+	batch := &imageBatch{fetcher: f}
+	for _, e := range emojis {
+		if e.ID == "" {
+			continue
+		}
+		batch.add(map[string]interface{}{
+			"emoji_id":   e.ID,
+			"emoji_name": e.Name,
+		}, cdnBuilder.Emoji(e.ID, e.Animated), 0)
+	}
+	return batch
+}
+
+// unsupportedImageError reports a download that is not a supported image
+type unsupportedImageError string
+
+func (e unsupportedImageError) Error() string {
+	return fmt.Sprintf("unsupported image type %s", string(e))
+}
+
+// image downloads one image as MCP image content
+func (f *imageFetcher) image(rawURL string) (types.Content, error) {
+	body, err := f.download(rawURL)
+	if err != nil {
+		return types.Content{}, err
+	}
+	// Trust the bytes over the declared type
+	mimeType := http.DetectContentType(body)
+	if !imageTypes[mimeType] {
+		return types.Content{}, unsupportedImageError(mimeType)
+	}
+	return types.Content{
+		Type:     "image",
+		Data:     base64.StdEncoding.EncodeToString(body),
+		MimeType: mimeType,
+	}, nil
+}
+
+// download reads an image from the Discord CDN, refusing bodies larger than
+// the configured limit
+func (f *imageFetcher) download(rawURL string) ([]byte, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme != "https" || !imageHosts[parsed.Hostname()] {
+		return nil, fmt.Errorf("image URL is not on the Discord CDN: %s", parsed.Host)
+	}
+
+	resp, err := f.client.Get(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CDN returned %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(f.cfg.MaxBytes)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > f.cfg.MaxBytes {
+		return nil, fmt.Errorf("image is larger than %d bytes", f.cfg.MaxBytes)
+	}
+	return body, nil
+}
+
+// attachmentType returns an attachment's media type, guessing from the file
+// extension when Discord did not report one
+func attachmentType(att *discordgo.MessageAttachment) string {
+	contentType := att.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(strings.ToLower(path.Ext(att.Filename)))
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return ""
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// guildIntegration is an integration as returned by the API. The API
+// client's type has no application or scopes.
+type guildIntegration struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+	Account struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"account"`
+	Application *struct {
+		ID          string          `json:"id"`
+		Name        string          `json:"name"`
+		Description string          `json:"description"`
+		Bot         *discordgo.User `json:"bot"`
+	} `json:"application"`
+	Scopes []string        `json:"scopes"`
+	User   *discordgo.User `json:"user"`
+	RoleID string          `json:"role_id"`
+}
+
+// botMember is a bot member with the integration that installed it and the
+// guild permissions its roles grant
+type botMember struct {
+	types.MemberSummary
+	// RoleDetails are the member's roles, highest first
+	RoleDetails   []types.RoleSummary `json:"role_details"`
+	Administrator bool                `json:"administrator"`
+	Permissions   []string            `json:"permissions"`
+	IntegrationID string              `json:"integration_id,omitempty"`
+	Scopes        []string            `json:"scopes,omitempty"`
+}
+
+// ListIntegrationsTool implements the list_integrations MCP tool
+type ListIntegrationsTool struct {
+	handler *GuildHandler
+}
+
+// NewListIntegrationsTool creates a new list integrations tool
+func NewListIntegrationsTool(handler *GuildHandler) *ListIntegrationsTool {
+	return &ListIntegrationsTool{handler: handler}
+}
+
+// Execute executes the list_integrations tool
+func (t *ListIntegrationsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_integrations", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	maxMembers := intArgument(params.Arguments, "max_members", 1000)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	roles, err := t.handler.discord.GetRoles(guildID)
+	if err != nil {
+		return t.formatError("Failed to get guild roles", err), nil
+	}
+	members, complete, err := fetchGuildMembers(t.handler, guildID, maxMembers)
+	if err != nil {
+		return t.formatError("Failed to list guild members", err), nil
+	}
+
+	data := map[string]interface{}{
+		"guild_id":         guildID,
+		"members_scanned":  len(members),
+		"members_complete": complete,
+	}
+
+	// Integrations need Manage Server; without it the bots are still listed
+	integrations, err := t.fetchIntegrations(guildID)
+	if err != nil {
+		t.handler.logger.Warnf("Failed to get integrations for guild %s: %v", guildID, err)
+		data["integrations_error"] = err.Error()
+	}
+
+	// Bots installed through an integration are matched to it
+	integrationByBot := make(map[string]guildIntegration)
+	formattedIntegrations := make([]map[string]interface{}, 0, len(integrations))
+	for _, integration := range integrations {
+		formatted := map[string]interface{}{
+			"id":         integration.ID,
+			"name":       integration.Name,
+			"type":       integration.Type,
+			"enabled":    integration.Enabled,
+			"account_id": integration.Account.ID,
+			"scopes":     integration.Scopes,
+		}
+		if integration.Application != nil {
+			formatted["application_id"] = integration.Application.ID
+			formatted["application_name"] = integration.Application.Name
+			formatted["description"] = integration.Application.Description
+			if integration.Application.Bot != nil {
+				formatted["bot_id"] = integration.Application.Bot.ID
+				integrationByBot[integration.Application.Bot.ID] = integration
+			}
+		}
+		if integration.User != nil {
+			formatted["installed_by"] = t.handler.discord.FormatUser(integration.User)
+		}
+		if integration.RoleID != "" {
+			formatted["role_id"] = integration.RoleID
+		}
+		formattedIntegrations = append(formattedIntegrations, formatted)
+	}
+
+	bots := make([]botMember, 0)
+	for _, member := range members {
+		if member.User == nil || !member.User.Bot {
+			continue
+		}
+		bot := t.formatBot(guildID, member, roles)
+		if integration, ok := integrationByBot[member.User.ID]; ok {
+			bot.IntegrationID = integration.ID
+			bot.Scopes = integration.Scopes
+		}
+		bots = append(bots, bot)
+	}
+	sort.Slice(bots, func(i, j int) bool {
+		return bots[i].Username < bots[j].Username
+	})
+
+	data["integration_count"] = len(formattedIntegrations)
+	data["integrations"] = formattedIntegrations
+	data["bot_count"] = len(bots)
+	data["bots"] = bots
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "integrations.list", len(formattedIntegrations), len(bots), guildID),
+			Data: data,
+		}},
+	}, nil
+}
+
+// fetchIntegrations reads a guild's integrations
+func (t *ListIntegrationsTool) fetchIntegrations(guildID string) ([]guildIntegration, error) {
+	if err := t.handler.permissions.CanManageGuild(guildID); err != nil {
+		return nil, err
+	}
+	var integrations []guildIntegration
+	endpoint := discordgo.EndpointGuildIntegrations(guildID)
+	_, err := t.handler.discord.Retry(func() error {
+		body, err := t.handler.discord.Session().RequestWithBucketID("GET", endpoint, nil, endpoint)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &integrations)
+	})
+	return integrations, err
+}
+
+// formatBot formats a bot member with its roles and the guild permissions
+// they grant
+func (t *ListIntegrationsTool) formatBot(guildID string, member *discordgo.Member, roles []*discordgo.Role) botMember {
+	memberRoles := make(map[string]bool, len(member.Roles))
+	for _, roleID := range member.Roles {
+		memberRoles[roleID] = true
+	}
+
+	var perms int64
+	roleDetails := make([]types.RoleSummary, 0, len(member.Roles))
+	for _, role := range roles {
+		// @everyone shares the guild's ID and applies to every member
+		if role.ID == guildID {
+			perms |= role.Permissions
+			continue
+		}
+		if !memberRoles[role.ID] {
+			continue
+		}
+		perms |= role.Permissions
+		roleDetails = append(roleDetails, t.handler.discord.FormatRole(role))
+	}
+	sort.Slice(roleDetails, func(i, j int) bool {
+		return roleDetails[i].Position > roleDetails[j].Position
+	})
+
+	return botMember{
+		MemberSummary: t.handler.discord.FormatMember(guildID, member),
+		RoleDetails:   roleDetails,
+		Administrator: perms&discordgo.PermissionAdministrator != 0,
+		Permissions:   permissions.PermissionNames(perms),
+	}
+}
+
+// GetDefinition returns the tool definition
+func (t *ListIntegrationsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_integrations", "List the integrations installed in a Discord server with their OAuth2 scopes, and every bot member with its roles and permissions, to audit third-party access")
+}
+
+// formatError creates a standardized error response
+func (t *ListIntegrationsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/members"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// StreamGuildMembersTool implements the stream_guild_members MCP tool
+type StreamGuildMembersTool struct {
+	handler *GuildHandler
+}
+
+// NewStreamGuildMembersTool creates a new stream guild members tool
+func NewStreamGuildMembersTool(handler *GuildHandler) *StreamGuildMembersTool {
+	return &StreamGuildMembersTool{handler: handler}
+}
+
+// Execute executes the stream_guild_members tool
+func (t *StreamGuildMembersTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("stream_guild_members", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	query, _ := params.Arguments["query"].(string)
+	limit := intArgument(params.Arguments, "limit", 0)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	stream, err := t.handler.discord.StreamGuildMembers(guildID, query, limit)
+	if err != nil {
+		return t.formatError("Failed to request guild members", err), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "members.streaming", guildID, stream.ID),
+			Data: stream,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *StreamGuildMembersTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("stream_guild_members", "Request a guild's members over the gateway in chunks, for guilds too large to list; progress is announced with discord/memberStreamProgress and members are read with get_streamed_members")
+}
+
+// formatError creates a standardized error response
+func (t *StreamGuildMembersTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// GetStreamedMembersTool implements the get_streamed_members MCP tool
+type GetStreamedMembersTool struct {
+	handler *GuildHandler
+}
+
+// NewGetStreamedMembersTool creates a new get streamed members tool
+func NewGetStreamedMembersTool(handler *GuildHandler) *GetStreamedMembersTool {
+	return &GetStreamedMembersTool{handler: handler}
+}
+
+// Execute executes the get_streamed_members tool
+func (t *GetStreamedMembersTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_streamed_members", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	streamID := params.Arguments["stream_id"].(string)
+	cursor := intArgument(params.Arguments, "cursor", 0)
+	limit := intArgument(params.Arguments, "limit", 100)
+
+	page, stream, ok := t.handler.discord.MemberStreams().Page(streamID, cursor, limit)
+	if !ok {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "members.not_found", streamID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"stream_id":  streamID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	formattedMembers := make([]types.MemberSummary, len(page))
+	for i, member := range page {
+		formattedMembers[i] = t.handler.discord.FormatMember(stream.GuildID, member)
+	}
+
+	// Members still arriving can be read from next_cursor later, so the
+	// cursor is returned until the stream stops and every member was read
+	next := cursor + len(page)
+	data := map[string]interface{}{
+		"stream":   stream,
+		"members":  formattedMembers,
+		"returned": len(formattedMembers),
+	}
+	if stream.Status == members.StatusRunning || next < stream.MemberCount {
+		data["next_cursor"] = next
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "members.page", len(formattedMembers), stream.MemberCount, stream.ID, stream.Status),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetStreamedMembersTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_streamed_members", "Read a page of the members collected by stream_guild_members, starting at a cursor; pages can be read while chunks are still arriving")
+}
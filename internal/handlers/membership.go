@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"time"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/membership"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// GetMembershipLogTool implements the get_membership_log MCP tool
+type GetMembershipLogTool struct {
+	handler *GuildHandler
+}
+
+// NewGetMembershipLogTool creates a new get membership log tool
+func NewGetMembershipLogTool(handler *GuildHandler) *GetMembershipLogTool {
+	return &GetMembershipLogTool{handler: handler}
+}
+
+// Execute executes the get_membership_log tool
+func (t *GetMembershipLogTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_membership_log", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	log := t.handler.discord.MembershipLog()
+	if log == nil {
+		return membershipDisabledResult(params), nil
+	}
+
+	filter := membership.Filter{GuildID: params.Arguments["guild_id"].(string)}
+	filter.UserID, _ = params.Arguments["user_id"].(string)
+	filter.Type, _ = params.Arguments["type"].(string)
+	for _, bound := range []struct {
+		name string
+		dest *time.Time
+	}{{"after", &filter.After}, {"before", &filter.Before}} {
+		value, ok := params.Arguments[bound.name].(string)
+		if !ok {
+			continue
+		}
+		parsed, err := parseExportTime(value, t.handler.discord.Location(filter.GuildID))
+		if err != nil {
+			return validation.FormatValidationError(validation.NewValidationError("invalid "+bound.name,
+				bound.name+" must be "+timeFormats, bound.name)), nil
+		}
+		*bound.dest = parsed
+	}
+	cursor := intArgument(params.Arguments, "cursor", 0)
+	limit := intArgument(params.Arguments, "limit", 50)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(filter.GuildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	events, total := log.Query(filter, cursor, limit)
+	data := map[string]interface{}{
+		"guild_id":       filter.GuildID,
+		"events":         events,
+		"total":          total,
+		"retention_days": int(log.Retention().Hours() / 24),
+	}
+	if next := cursor + len(events); next < total {
+		data["next_cursor"] = next
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "membership.log", total, filter.GuildID),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetMembershipLogTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_membership_log", "List the members who joined or left a guild, newest first, with their account age, time in the guild and the invite they joined with when known")
+}
+
+// formatError creates a standardized error response
+func (t *GetMembershipLogTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// GetJoinLeaveStatsTool implements the get_join_leave_stats MCP tool
+type GetJoinLeaveStatsTool struct {
+	handler *GuildHandler
+}
+
+// NewGetJoinLeaveStatsTool creates a new get join leave stats tool
+func NewGetJoinLeaveStatsTool(handler *GuildHandler) *GetJoinLeaveStatsTool {
+	return &GetJoinLeaveStatsTool{handler: handler}
+}
+
+// Execute executes the get_join_leave_stats tool
+func (t *GetJoinLeaveStatsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_join_leave_stats", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	log := t.handler.discord.MembershipLog()
+	if log == nil {
+		return membershipDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	days := intArgument(params.Arguments, "days", 30)
+	// The log holds nothing older than its retention
+	if retentionDays := int(log.Retention().Hours() / 24); days > retentionDays {
+		days = retentionDays
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	stats := log.Stats(guildID, time.Now().UTC().AddDate(0, 0, -days))
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "membership.stats", stats.Joins, stats.Leaves, guildID, days, stats.Net),
+			Data: map[string]interface{}{
+				"days":  days,
+				"stats": stats,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetJoinLeaveStatsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_join_leave_stats", "Summarise a guild's joins and leaves for churn analysis: daily counts, retention and churn rates, quick leaves, rejoins, new accounts and the invites members joined with")
+}
+
+// formatError creates a standardized error response
+func (t *GetJoinLeaveStatsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// membershipDisabledResult reports that the membership log is turned off in
+// the configuration
+func membershipDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "membership.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "membership log disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
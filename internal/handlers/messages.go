@@ -4,22 +4,31 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/bwmarrin/discordgo"
 	"github.com/sirupsen/logrus"
 
 	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/journal"
+	"discord-mcp/internal/outbound"
 	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/textsplit"
 	"discord-mcp/internal/validation"
 	"discord-mcp/pkg/types"
 )
 
+// maxContentLength is Discord's limit for a single message's content
+const maxContentLength = 2000
+
 // MessageHandler handles Discord message operations
 type MessageHandler struct {
 	discord     *discord.Client
 	permissions *permissions.Checker
 	validator   *validation.Validator
 	logger      *logrus.Logger
+	images      *imageFetcher
 }
 
 // NewMessageHandler creates a new message handler
@@ -29,6 +38,7 @@ func NewMessageHandler(discordClient *discord.Client, permChecker *permissions.C
 		permissions: permChecker,
 		validator:   validator,
 		logger:      logger,
+		images:      newImageFetcher(discordClient.Config().Images, logger),
 	}
 }
 
@@ -64,6 +74,13 @@ func (t *SendMessageTool) Execute(params types.CallToolParams) (types.CallToolRe
 		replyTo = replyVal.(string)
 	}
 
+	autoSplit, _ := params.Arguments["auto_split"].(bool)
+	if length := utf8.RuneCountInString(content); length > maxContentLength && !autoSplit {
+		return validation.FormatValidationError(validation.NewValidationError("length constraint",
+			fmt.Sprintf("parameter 'content' must be at most %d characters, got %d; set auto_split to send it as several messages", maxContentLength, length),
+			"content")), nil
+	}
+
 	var embeds []*discordgo.MessageEmbed
 	if embedsVal, ok := params.Arguments["embeds"]; ok {
 		embedsSlice, ok := embedsVal.([]interface{})
@@ -92,44 +109,100 @@ func (t *SendMessageTool) Execute(params types.CallToolParams) (types.CallToolRe
 		return t.formatError("Permission check failed", err), nil
 	}
 
-	// Prepare message data
-	msgData := &discordgo.MessageSend{
-		Content: content,
-		TTS:     tts,
-		Embeds:  embeds,
+	// Check links before anything is sent
+	if blocked := checkOutbound(t.handler.discord, params, channelID, content, embeds); blocked != nil {
+		return *blocked, nil
+	}
+
+	// Long content goes out as several messages; the reply reference is
+	// attached to the first and embeds to the last
+	parts := []string{content}
+	if autoSplit {
+		parts = textsplit.Message(content, maxContentLength)
 	}
 
-	// Add reply reference if specified
-	if replyTo != "" {
-		msgData.Reference = &discordgo.MessageReference{
-			MessageID: replyTo,
-			ChannelID: channelID,
+	var messages []*discordgo.Message
+	totalRetries := 0
+	var queueWait time.Duration
+	for i, part := range parts {
+		msgData := &discordgo.MessageSend{
+			Content: part,
+			TTS:     tts,
+		}
+		if i == len(parts)-1 {
+			msgData.Embeds = embeds
 		}
+
+		// Add reply reference if specified
+		if replyTo != "" && i == 0 {
+			msgData.Reference = &discordgo.MessageReference{
+				MessageID: replyTo,
+				ChannelID: channelID,
+			}
+		}
+
+		// Send the message
+		var message *discordgo.Message
+		retries, waited, err := t.handler.discord.SendQueued(channelID, outbound.PriorityInteractive, func() (err error) {
+			message, err = t.handler.discord.Session().ChannelMessageSendComplex(channelID, msgData)
+			return err
+		})
+		totalRetries += retries
+		queueWait += waited
+		if err != nil {
+			if len(messages) > 0 {
+				return t.formatError(fmt.Sprintf("Failed to send message part %d of %d after sending %s",
+					i+1, len(parts), strings.Join(messageIDs(messages), ", ")), err), nil
+			}
+			return t.formatError("Failed to send message", err), nil
+		}
+		messages = append(messages, message)
 	}
 
-	// Send the message
-	message, err := t.handler.discord.Session().ChannelMessageSendComplex(channelID, msgData)
-	if err != nil {
-		return t.formatError("Failed to send message", err), nil
+	message := messages[0]
+	text := i18n.T(i18n.Locale(params), "messages.sent", channelID)
+	if len(messages) > 1 {
+		text = i18n.T(i18n.Locale(params), "messages.sent_split", channelID, len(messages))
 	}
 
 	// Format success response
-	return types.CallToolResult{
+	result := types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("✅ Message sent successfully to <#%s>", channelID),
+			Text: text,
 			Data: map[string]interface{}{
-				"message_id":  message.ID,
-				"channel_id":  channelID,
-				"content":     message.Content,
-				"timestamp":   message.Timestamp.Format(time.RFC3339),
-				"tts":         message.TTS,
-				"embed_count": len(message.Embeds),
-				"has_reply":   replyTo != "",
-				"message_url": fmt.Sprintf("https://discord.com/channels/%s/%s/%s", message.GuildID, channelID, message.ID),
+				"message_id":    message.ID,
+				"message_ids":   messageIDs(messages),
+				"message_count": len(messages),
+				"channel_id":    channelID,
+				"content":       content,
+				"timestamp":     message.Timestamp.Format(time.RFC3339),
+				"tts":           message.TTS,
+				"embed_count":   len(messages[len(messages)-1].Embeds),
+				"has_reply":     replyTo != "",
+				"message_url":   fmt.Sprintf("https://discord.com/channels/%s/%s/%s", message.GuildID, channelID, message.ID),
+				"retries":       totalRetries,
+				"queue_wait_ms": queueWait.Milliseconds(),
 			},
 		}},
-	}, nil
+	}
+	return recordAction(t.handler.discord, result, journal.Action{
+		Type:       journal.TypeMessageSent,
+		Tool:       "send_message",
+		GuildID:    channelGuildID(t.handler.discord, channelID),
+		ChannelID:  channelID,
+		MessageID:  message.ID,
+		MessageIDs: messageIDs(messages),
+	}), nil
+}
+
+// messageIDs returns the IDs of messages in order
+func messageIDs(messages []*discordgo.Message) []string {
+	ids := make([]string, len(messages))
+	for i, m := range messages {
+		ids[i] = m.ID
+	}
+	return ids
 }
 
 // GetDefinition returns the tool definition
@@ -139,19 +212,7 @@ func (t *SendMessageTool) GetDefinition() types.Tool {
 
 // formatError creates a standardized error response
 func (t *SendMessageTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
 
 // GetChannelMessagesTool implements the get_channel_messages MCP tool
@@ -188,14 +249,22 @@ func (t *GetChannelMessagesTool) Execute(params types.CallToolParams) (types.Cal
 	}
 
 	var beforeID, afterID, aroundID string
-	if beforeVal, ok := params.Arguments["before"].(string); ok {
-		beforeID = beforeVal
-	}
-	if afterVal, ok := params.Arguments["after"].(string); ok {
-		afterID = afterVal
+	for name, dst := range map[string]*string{"before": &beforeID, "after": &afterID, "around": &aroundID} {
+		value, ok := params.Arguments[name].(string)
+		if !ok {
+			continue
+		}
+		id, err := messageCursor(value, guildLocation(t.handler.discord, "", channelID))
+		if err != nil {
+			return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+				fmt.Sprintf("%s must be a message ID or %s", name, timeFormats), name)), nil
+		}
+		*dst = id
 	}
-	if aroundVal, ok := params.Arguments["around"].(string); ok {
-		aroundID = aroundVal
+	includeImages, _ := params.Arguments["include_images"].(bool)
+	summary := summaryArguments(params.Arguments)
+	if summary.requested && t.handler.discord.Summarizer() == nil {
+		return summarizerDisabledResult(params), nil
 	}
 
 	// Validate permissions
@@ -207,33 +276,66 @@ func (t *GetChannelMessagesTool) Execute(params types.CallToolParams) (types.Cal
 	}
 
 	// Get messages from Discord
-	messages, err := t.handler.discord.Session().ChannelMessages(channelID, limit, beforeID, afterID, aroundID)
+	var messages []*discordgo.Message
+	retries, err := t.handler.discord.Retry(func() (err error) {
+		messages, err = t.handler.discord.Session().ChannelMessages(channelID, limit, beforeID, afterID, aroundID)
+		return err
+	})
 	if err != nil {
 		return t.formatError("Failed to get channel messages", err), nil
 	}
 
 	// Format messages for response
-	formattedMessages := make([]map[string]interface{}, len(messages))
+	formattedMessages := make([]types.MessageSummary, len(messages))
 	for i, msg := range messages {
-		formattedMessages[i] = t.formatMessage(msg)
+		formattedMessages[i] = t.handler.discord.FormatMessage(msg)
+	}
+
+	data := map[string]interface{}{
+		"channel_id":    channelID,
+		"message_count": len(messages),
+		"messages":      formattedMessages,
+		"query": map[string]interface{}{
+			"limit":          limit,
+			"before":         beforeID,
+			"after":          afterID,
+			"around":         aroundID,
+			"include_images": includeImages,
+		},
+		"retries": retries,
+	}
+	text := i18n.T(i18n.Locale(params), "messages.retrieved", len(messages), channelID)
+
+	// Attach image attachments and stickers as image content after the text
+	var images []types.Content
+	if includeImages {
+		batch := t.handler.images.fetch(messages, t.handler.discord.CDN())
+		images = batch.contents
+		data["images"] = batch.fetched
+		data["images_skipped"] = batch.skipped
+		text = i18n.T(i18n.Locale(params), "messages.retrieved_images", len(messages), channelID, len(images))
+	}
+
+	// Summarize oldest first; messages arrive newest first
+	if summary.requested {
+		ordered := make([]*discordgo.Message, len(messages))
+		copy(ordered, messages)
+		reverseMessages(ordered)
+		if summaryText := addSummary(t.handler.discord, t.handler.logger, data, "channel:"+channelID, summaryLines(ordered), summary); summaryText != "" {
+			text += "\n\n" + i18n.T(i18n.Locale(params), "summarizer.summary") + "\n" + summaryText
+			// summary_only leaves the messages out, unless summarizing failed
+			if summary.only {
+				delete(data, "messages")
+			}
+		}
 	}
 
 	return types.CallToolResult{
-		Content: []types.Content{{
+		Content: append([]types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("📨 Retrieved %d messages from <#%s>", len(messages), channelID),
-			Data: map[string]interface{}{
-				"channel_id":    channelID,
-				"message_count": len(messages),
-				"messages":      formattedMessages,
-				"query": map[string]interface{}{
-					"limit":  limit,
-					"before": beforeID,
-					"after":  afterID,
-					"around": aroundID,
-				},
-			},
-		}},
+			Text: text,
+			Data: data,
+		}}, images...),
 	}, nil
 }
 
@@ -242,126 +344,10 @@ func (t *GetChannelMessagesTool) GetDefinition() types.Tool {
 	return validation.GetToolDefinition("get_channel_messages", "Retrieve message history from a Discord channel with pagination support")
 }
 
-// formatMessage converts a Discord message to a structured format
-func (t *GetChannelMessagesTool) formatMessage(msg *discordgo.Message) map[string]interface{} {
-	// Format attachments
-	attachments := make([]map[string]interface{}, len(msg.Attachments))
-	for i, att := range msg.Attachments {
-		attachments[i] = map[string]interface{}{
-			"id":       att.ID,
-			"filename": att.Filename,
-			"size":     att.Size,
-			"url":      att.URL,
-			"width":    att.Width,
-			"height":   att.Height,
-		}
-	}
-
-	// Format embeds
-	embeds := make([]map[string]interface{}, len(msg.Embeds))
-	for i, embed := range msg.Embeds {
-		embedData := map[string]interface{}{
-			"title":       embed.Title,
-			"description": embed.Description,
-			"color":       embed.Color,
-			"url":         embed.URL,
-		}
-
-		if embed.Thumbnail != nil {
-			embedData["thumbnail"] = map[string]interface{}{
-				"url": embed.Thumbnail.URL,
-			}
-		}
-
-		if embed.Image != nil {
-			embedData["image"] = map[string]interface{}{
-				"url": embed.Image.URL,
-			}
-		}
-
-		if len(embed.Fields) > 0 {
-			fields := make([]map[string]interface{}, len(embed.Fields))
-			for j, field := range embed.Fields {
-				fields[j] = map[string]interface{}{
-					"name":   field.Name,
-					"value":  field.Value,
-					"inline": field.Inline,
-				}
-			}
-			embedData["fields"] = fields
-		}
-
-		embeds[i] = embedData
-	}
-
-	// Format reactions
-	reactions := make([]map[string]interface{}, len(msg.Reactions))
-	for i, reaction := range msg.Reactions {
-		reactions[i] = map[string]interface{}{
-			"emoji": map[string]interface{}{
-				"name": reaction.Emoji.Name,
-				"id":   reaction.Emoji.ID,
-			},
-			"count": reaction.Count,
-			"me":    reaction.Me,
-		}
-	}
-
-	return map[string]interface{}{
-		"id":      msg.ID,
-		"content": msg.Content,
-		"author": map[string]interface{}{
-			"id":            msg.Author.ID,
-			"username":      msg.Author.Username,
-			"discriminator": msg.Author.Discriminator,
-			"avatar":        msg.Author.Avatar,
-			"bot":           msg.Author.Bot,
-		},
-		"timestamp":        msg.Timestamp.Format(time.RFC3339),
-		"edited":           msg.EditedTimestamp != nil,
-		"tts":              msg.TTS,
-		"mention_everyone": msg.MentionEveryone,
-		"mentions":         t.formatMentions(msg.Mentions),
-		"attachments":      attachments,
-		"embeds":           embeds,
-		"reactions":        reactions,
-		"pinned":           msg.Pinned,
-		"type":             int(msg.Type),
-		"flags":            int(msg.Flags),
-		"message_url":      fmt.Sprintf("https://discord.com/channels/%s/%s/%s", msg.GuildID, msg.ChannelID, msg.ID),
-	}
-}
-
-// formatMentions formats user mentions
-func (t *GetChannelMessagesTool) formatMentions(mentions []*discordgo.User) []map[string]interface{} {
-	formatted := make([]map[string]interface{}, len(mentions))
-	for i, user := range mentions {
-		formatted[i] = map[string]interface{}{
-			"id":            user.ID,
-			"username":      user.Username,
-			"discriminator": user.Discriminator,
-			"avatar":        user.Avatar,
-			"bot":           user.Bot,
-		}
-	}
-	return formatted
-}
 
 // formatError creates a standardized error response
 func (t *GetChannelMessagesTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
 
 // EditMessageTool implements the edit_message MCP tool
@@ -418,6 +404,11 @@ func (t *EditMessageTool) Execute(params types.CallToolParams) (types.CallToolRe
 		return t.formatError("Permission check failed", err), nil
 	}
 
+	// Check links before the message is changed
+	if blocked := checkOutbound(t.handler.discord, params, channelID, newContent, newEmbeds); blocked != nil {
+		return *blocked, nil
+	}
+
 	// Prepare message edit data
 	msgEdit := &discordgo.MessageEdit{
 		Content: &newContent,
@@ -429,17 +420,27 @@ func (t *EditMessageTool) Execute(params types.CallToolParams) (types.CallToolRe
 		msgEdit.Embeds = &newEmbeds
 	}
 
+	// Keep the current content so the edit can be undone
+	var before *journal.Before
+	if t.handler.discord.Journal() != nil {
+		before = messageBefore(t.handler.discord, channelID, messageID)
+	}
+
 	// Edit the message
-	message, err := t.handler.discord.Session().ChannelMessageEditComplex(msgEdit)
+	var message *discordgo.Message
+	retries, err := t.handler.discord.Retry(func() (err error) {
+		message, err = t.handler.discord.Session().ChannelMessageEditComplex(msgEdit)
+		return err
+	})
 	if err != nil {
 		return t.formatError("Failed to edit message", err), nil
 	}
 
 	// Format success response
-	return types.CallToolResult{
+	result := types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("✏️ Message edited successfully in <#%s>", channelID),
+			Text: i18n.T(i18n.Locale(params), "messages.edited", channelID),
 			Data: map[string]interface{}{
 				"message_id":       message.ID,
 				"channel_id":       channelID,
@@ -447,9 +448,21 @@ func (t *EditMessageTool) Execute(params types.CallToolParams) (types.CallToolRe
 				"edited_timestamp": message.EditedTimestamp.Format(time.RFC3339),
 				"embed_count":      len(message.Embeds),
 				"message_url":      fmt.Sprintf("https://discord.com/channels/%s/%s/%s", message.GuildID, channelID, message.ID),
+				"retries":          retries,
 			},
 		}},
-	}, nil
+	}
+	if before == nil {
+		return result, nil
+	}
+	return recordAction(t.handler.discord, result, journal.Action{
+		Type:      journal.TypeMessageEdited,
+		Tool:      "edit_message",
+		GuildID:   channelGuildID(t.handler.discord, channelID),
+		ChannelID: channelID,
+		MessageID: messageID,
+		Before:    before,
+	}), nil
 }
 
 // GetDefinition returns the tool definition
@@ -459,19 +472,7 @@ func (t *EditMessageTool) GetDefinition() types.Tool {
 
 // formatError creates a standardized error response
 func (t *EditMessageTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
 
 // DeleteMessageTool implements the delete_message MCP tool
@@ -512,13 +513,19 @@ func (t *DeleteMessageTool) Execute(params types.CallToolParams) (types.CallTool
 	}
 
 	// Get message info before deletion (for logging)
-	message, err := t.handler.discord.Session().ChannelMessage(channelID, messageID)
+	var message *discordgo.Message
+	lookupRetries, err := t.handler.discord.Retry(func() (err error) {
+		message, err = t.handler.discord.Session().ChannelMessage(channelID, messageID)
+		return err
+	})
 	if err != nil {
 		return t.formatError("Failed to get message info before deletion", err), nil
 	}
 
 	// Delete the message
-	err = t.handler.discord.Session().ChannelMessageDelete(channelID, messageID)
+	deleteRetries, err := t.handler.discord.Retry(func() error {
+		return t.handler.discord.Session().ChannelMessageDelete(channelID, messageID, auditLogOptions(params.Arguments)...)
+	})
 	if err != nil {
 		return t.formatError("Failed to delete message", err), nil
 	}
@@ -532,7 +539,7 @@ func (t *DeleteMessageTool) Execute(params types.CallToolParams) (types.CallTool
 	return types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("🗑️ Message deleted successfully from <#%s>", channelID),
+			Text: i18n.T(i18n.Locale(params), "messages.deleted", channelID),
 			Data: map[string]interface{}{
 				"deleted_message_id": messageID,
 				"channel_id":         channelID,
@@ -541,6 +548,7 @@ func (t *DeleteMessageTool) Execute(params types.CallToolParams) (types.CallTool
 				"author_username":    message.Author.Username,
 				"deletion_reason":    reason,
 				"deleted_at":         time.Now().Format(time.RFC3339),
+				"retries":            lookupRetries + deleteRetries,
 			},
 		}},
 	}, nil
@@ -553,19 +561,7 @@ func (t *DeleteMessageTool) GetDefinition() types.Tool {
 
 // formatError creates a standardized error response
 func (t *DeleteMessageTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
 
 // AddReactionTool implements the add_reaction MCP tool
@@ -590,9 +586,16 @@ func (t *AddReactionTool) Execute(params types.CallToolParams) (types.CallToolRe
 	messageID := params.Arguments["message_id"].(string)
 	emoji := params.Arguments["emoji"].(string)
 
+	// Convert shortcodes and check that custom emoji are available to the bot
+	resolved, err := resolveReactionEmoji(t.handler.discord, emoji)
+	if err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+	isCustom := resolved.ID != ""
+
 	// Validate permissions
 	extraData := map[string]interface{}{
-		"emoji": emoji,
+		"emoji": resolved.MessageFormat(),
 	}
 	if err := t.handler.permissions.ValidateMessageOperation("add_reaction", channelID, extraData); err != nil {
 		if permErr, ok := err.(*permissions.PermissionError); ok {
@@ -601,34 +604,40 @@ func (t *AddReactionTool) Execute(params types.CallToolParams) (types.CallToolRe
 		return t.formatError("Permission check failed", err), nil
 	}
 
-	// Validate and format emoji
-	formattedEmoji := t.formatEmoji(emoji)
-	if formattedEmoji == "" {
-		return validation.FormatValidationError(fmt.Errorf("invalid emoji format: %s", emoji)), nil
-	}
-
 	// Add the reaction
-	err := t.handler.discord.Session().MessageReactionAdd(channelID, messageID, formattedEmoji)
+	retries, err := t.handler.discord.Retry(func() error {
+		return t.handler.discord.Session().MessageReactionAdd(channelID, messageID, resolved.APIName())
+	})
 	if err != nil {
 		return t.formatError("Failed to add reaction", err), nil
 	}
 
 	// Format success response
-	return types.CallToolResult{
+	result := types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("👍 Added reaction %s to message in <#%s>", emoji, channelID),
+			Text: i18n.T(i18n.Locale(params), "reactions.added", resolved.MessageFormat(), channelID),
 			Data: map[string]interface{}{
 				"message_id":      messageID,
 				"channel_id":      channelID,
 				"emoji":           emoji,
-				"formatted_emoji": formattedEmoji,
-				"is_custom_emoji": t.isCustomEmoji(emoji),
+				"formatted_emoji": resolved.APIName(),
+				"resolved_emoji":  resolved.MessageFormat(),
+				"is_custom_emoji": isCustom,
 				"added_at":        time.Now().Format(time.RFC3339),
+				"retries":         retries,
 				"message_url":     fmt.Sprintf("https://discord.com/channels/%s/%s/%s", "@me", channelID, messageID), // Guild ID is not available in this context, so we use @me to link to the channel.
 			},
 		}},
-	}, nil
+	}
+	return recordAction(t.handler.discord, result, journal.Action{
+		Type:      journal.TypeReactionAdded,
+		Tool:      "add_reaction",
+		GuildID:   channelGuildID(t.handler.discord, channelID),
+		ChannelID: channelID,
+		MessageID: messageID,
+		Emoji:     resolved.APIName(),
+	}), nil
 }
 
 // GetDefinition returns the tool definition
@@ -636,22 +645,6 @@ func (t *AddReactionTool) GetDefinition() types.Tool {
 	return validation.GetToolDefinition("add_reaction", "Add an emoji reaction to a Discord message")
 }
 
-// formatEmoji ensures the emoji is in the correct format for Discord API
-func (t *AddReactionTool) formatEmoji(emoji string) string {
-	// If it's already a custom emoji format <:name:id> or <a:name:id>, extract the name:id part
-	if t.isCustomEmoji(emoji) {
-		// Remove < and > and the initial : or a:
-		if strings.HasPrefix(emoji, "<a:") {
-			return emoji[3 : len(emoji)-1] // Remove <a: and >
-		} else if strings.HasPrefix(emoji, "<:") {
-			return emoji[2 : len(emoji)-1] // Remove <: and >
-		}
-	}
-
-	// For Unicode emojis, return as-is
-	return emoji
-}
-
 // parseEmbed converts interface{} to discordgo.MessageEmbed
 func parseEmbed(embedData interface{}) (*discordgo.MessageEmbed, error) {
 	embedMap, ok := embedData.(map[string]interface{})
@@ -726,24 +719,7 @@ func parseEmbed(embedData interface{}) (*discordgo.MessageEmbed, error) {
 	return embed, nil
 }
 
-// isCustomEmoji checks if an emoji is a custom Discord emoji
-func (t *AddReactionTool) isCustomEmoji(emoji string) bool {
-	return len(emoji) > 2 && emoji[0] == '<' && emoji[len(emoji)-1] == '>' && (strings.HasPrefix(emoji, "<:") || strings.HasPrefix(emoji, "<a:"))
-}
-
 // formatError creates a standardized error response
 func (t *AddReactionTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/nickname"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// Nickname audit actions
+const (
+	nicknameActionReport    = "report"
+	nicknameActionNormalize = "normalize"
+	nicknameActionReset     = "reset"
+)
+
+// flaggedMember is a member whose display name broke the rules, and what
+// became of the nickname
+type flaggedMember struct {
+	types.MemberSummary
+	DisplayName string   `json:"display_name"`
+	Reasons     []string `json:"reasons"`
+	NewNick     string   `json:"new_nick"`
+	Status      string   `json:"status"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// AuditNicknamesTool implements the audit_nicknames MCP tool
+type AuditNicknamesTool struct {
+	handler *GuildHandler
+}
+
+// NewAuditNicknamesTool creates a new audit nicknames tool
+func NewAuditNicknamesTool(handler *GuildHandler) *AuditNicknamesTool {
+	return &AuditNicknamesTool{handler: handler}
+}
+
+// Execute executes the audit_nicknames tool
+func (t *AuditNicknamesTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("audit_nicknames", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	pattern, _ := params.Arguments["pattern"].(string)
+	var bannedWords []string
+	if words, ok := params.Arguments["banned_words"].([]interface{}); ok {
+		for _, word := range words {
+			if s, ok := word.(string); ok {
+				bannedWords = append(bannedWords, s)
+			}
+		}
+	}
+	dehoist := true
+	if val, ok := params.Arguments["dehoist"].(bool); ok {
+		dehoist = val
+	}
+	action := nicknameActionReport
+	if val, ok := params.Arguments["action"].(string); ok {
+		action = val
+	}
+	fallback := "Moderated Nickname"
+	if val, ok := params.Arguments["fallback_nickname"].(string); ok {
+		fallback = val
+	}
+	maxMembers := intArgument(params.Arguments, "max_members", 1000)
+
+	rules, err := nickname.NewRules(pattern, bannedWords, dehoist)
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters", err.Error(), nil)), nil
+	}
+	if len(rules.Check(fallback)) > 0 {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+			"fallback_nickname breaks the audit rules itself", "fallback_nickname")), nil
+	}
+
+	// Validate permissions
+	if err := t.checkPermissions(guildID, action); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	members, complete, err := fetchGuildMembers(t.handler, guildID, maxMembers)
+	if err != nil {
+		return t.formatError("Failed to list guild members", err), nil
+	}
+
+	counts := map[string]int{"flagged": 0, "changed": 0, "skipped": 0, "failed": 0}
+	byReason := make(map[string]int)
+	flagged := make([]flaggedMember, 0)
+	for _, member := range members {
+		name := member.DisplayName()
+		reasons := rules.Check(name)
+		if len(reasons) == 0 {
+			continue
+		}
+		counts["flagged"]++
+		for _, reason := range reasons {
+			byReason[reason]++
+		}
+
+		replacement := rules.Normalize(name)
+		if replacement == "" {
+			replacement = fallback
+		}
+		if action == nicknameActionReset {
+			replacement = resetNickname(member, rules, fallback)
+		}
+
+		entry := flaggedMember{
+			MemberSummary: t.handler.discord.FormatMember(guildID, member),
+			DisplayName:   name,
+			Reasons:       reasons,
+			NewNick:       replacement,
+			Status:        "flagged",
+		}
+		if action != nicknameActionReport {
+			t.rename(guildID, member, replacement, params.Arguments, &entry)
+			counts[entry.Status]++
+		}
+		flagged = append(flagged, entry)
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "nicks.audited", len(members), counts["flagged"], counts["changed"], counts["failed"]+counts["skipped"]),
+			Data: map[string]interface{}{
+				"guild_id":      guildID,
+				"action":        action,
+				"scanned":       len(members),
+				"scan_complete": complete,
+				"flagged_count": counts["flagged"],
+				"changed_count": counts["changed"],
+				"skipped_count": counts["skipped"],
+				"failed_count":  counts["failed"],
+				"reason_counts": byReason,
+				"flagged":       flagged,
+			},
+		}},
+	}, nil
+}
+
+// checkPermissions checks that the bot can read the guild and, unless it
+// only reports, change nicknames
+func (t *AuditNicknamesTool) checkPermissions(guildID, action string) error {
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		return err
+	}
+	if action == nicknameActionReport {
+		return nil
+	}
+	checks, err := t.handler.permissions.Preflight(guildID, "", []string{"manage_nicknames"}, permissions.Target{})
+	if err != nil {
+		return err
+	}
+	if !checks[0].Allowed {
+		return permissions.NewPermissionError("audit_nicknames", "MANAGE_NICKNAMES",
+			fmt.Sprintf("guild:%s", guildID),
+			"Bot cannot change nicknames in this guild; grant Manage Nicknames or use action report")
+	}
+	return nil
+}
+
+// fetchGuildMembers pages through up to maxMembers members, reporting
+// whether every member was read
+func fetchGuildMembers(handler *GuildHandler, guildID string, maxMembers int) ([]*discordgo.Member, bool, error) {
+	var members []*discordgo.Member
+	afterID := ""
+	for len(members) < maxMembers {
+		limit := maxMembers - len(members)
+		if limit > 1000 {
+			limit = 1000
+		}
+
+		var page []*discordgo.Member
+		_, err := handler.discord.Retry(func() (err error) {
+			page, err = handler.discord.Session().GuildMembers(guildID, afterID, limit)
+			return err
+		})
+		if err != nil {
+			return nil, false, err
+		}
+
+		members = append(members, page...)
+		if len(page) < limit {
+			return members, true, nil
+		}
+		afterID = page[len(page)-1].User.ID
+	}
+	return members, false, nil
+}
+
+// rename changes a flagged member's nickname and records the outcome in
+// its entry. Members the bot may not rename are skipped.
+func (t *AuditNicknamesTool) rename(guildID string, member *discordgo.Member, nick string, args map[string]interface{}, entry *flaggedMember) {
+	if nick == member.Nick {
+		entry.Status = "skipped"
+		entry.Error = "nickname is already the replacement"
+		return
+	}
+	if err := t.handler.permissions.CanModerateMember(guildID, member.User.ID, "manage_nicknames"); err != nil {
+		entry.Status = "skipped"
+		entry.Error = err.Error()
+		return
+	}
+
+	_, err := t.handler.discord.Retry(func() error {
+		return t.handler.discord.Session().GuildMemberNickname(guildID, member.User.ID, nick, auditLogOptions(args)...)
+	})
+	if err != nil {
+		t.handler.logger.Warnf("Failed to rename member %s in guild %s: %v", member.User.ID, guildID, err)
+		entry.Status = "failed"
+		entry.Error = err.Error()
+		return
+	}
+	entry.Status = "changed"
+}
+
+// resetNickname returns the nickname that resets a member: none when the
+// account name passes the rules, and the fallback when it does not
+func resetNickname(member *discordgo.Member, rules nickname.Rules, fallback string) string {
+	if len(rules.Check(member.User.DisplayName())) == 0 {
+		return ""
+	}
+	return fallback
+}
+
+// GetDefinition returns the tool definition
+func (t *AuditNicknamesTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("audit_nicknames", "Scan member display names against a regex, banned words and hoisting characters, and optionally normalize or reset the offending nicknames")
+}
+
+// formatError creates a standardized error response
+func (t *AuditNicknamesTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/jsonfile"
+	"discord-mcp/internal/notes"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// AddUserNoteTool implements the add_user_note MCP tool
+type AddUserNoteTool struct {
+	handler *GuildHandler
+}
+
+// NewAddUserNoteTool creates a new add user note tool
+func NewAddUserNoteTool(handler *GuildHandler) *AddUserNoteTool {
+	return &AddUserNoteTool{handler: handler}
+}
+
+// Execute executes the add_user_note tool
+func (t *AddUserNoteTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("add_user_note", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Notes()
+	if store == nil {
+		return notesDisabledResult(params), nil
+	}
+
+	note := notes.Note{
+		GuildID: params.Arguments["guild_id"].(string),
+		UserID:  params.Arguments["user_id"].(string),
+		Content: params.Arguments["content"].(string),
+	}
+	note.Author, _ = params.Arguments["author"].(string)
+	if tags, ok := params.Arguments["tags"].([]interface{}); ok {
+		for _, tag := range tags {
+			if s, ok := tag.(string); ok {
+				note.Tags = append(note.Tags, strings.ToLower(s))
+			}
+		}
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(note.GuildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	saved, err := store.Add(note)
+	if errors.Is(err, jsonfile.ErrSave) {
+		return t.formatError("Failed to save user note", err), nil
+	}
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid note", err.Error(), nil)), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "notes.added", saved.ID, saved.UserID),
+			Data: saved,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *AddUserNoteTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("add_user_note", "Add a private note about a server member, such as a warning given, kept locally across sessions and never shown in Discord")
+}
+
+// formatError creates a standardized error response
+func (t *AddUserNoteTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// GetUserNotesTool implements the get_user_notes MCP tool
+type GetUserNotesTool struct {
+	handler *GuildHandler
+}
+
+// NewGetUserNotesTool creates a new get user notes tool
+func NewGetUserNotesTool(handler *GuildHandler) *GetUserNotesTool {
+	return &GetUserNotesTool{handler: handler}
+}
+
+// Execute executes the get_user_notes tool
+func (t *GetUserNotesTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_user_notes", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Notes()
+	if store == nil {
+		return notesDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	userID, _ := params.Arguments["user_id"].(string)
+	tag, _ := params.Arguments["tag"].(string)
+	limit := intArgument(params.Arguments, "limit", 50)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	found := store.List(guildID, userID)
+	if tag != "" {
+		tagged := make([]notes.Note, 0, len(found))
+		for _, note := range found {
+			for _, noteTag := range note.Tags {
+				if noteTag == strings.ToLower(tag) {
+					tagged = append(tagged, note)
+					break
+				}
+			}
+		}
+		found = tagged
+	}
+	total := len(found)
+	if len(found) > limit {
+		found = found[:limit]
+	}
+
+	scope := guildID
+	if userID != "" {
+		scope = userID
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "notes.list", total, scope),
+			Data: map[string]interface{}{
+				"guild_id":    guildID,
+				"user_id":     userID,
+				"total_count": total,
+				"note_count":  len(found),
+				"notes":       found,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetUserNotesTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_user_notes", "Read the private notes kept about a server member, newest first, or about every member of the server")
+}
+
+// formatError creates a standardized error response
+func (t *GetUserNotesTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// DeleteUserNoteTool implements the delete_user_note MCP tool
+type DeleteUserNoteTool struct {
+	handler *GuildHandler
+}
+
+// NewDeleteUserNoteTool creates a new delete user note tool
+func NewDeleteUserNoteTool(handler *GuildHandler) *DeleteUserNoteTool {
+	return &DeleteUserNoteTool{handler: handler}
+}
+
+// Execute executes the delete_user_note tool
+func (t *DeleteUserNoteTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("delete_user_note", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Notes()
+	if store == nil {
+		return notesDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	noteID := params.Arguments["note_id"].(string)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	note, removed, err := store.Remove(guildID, noteID)
+	if err != nil {
+		return t.formatError("Failed to save user notes", err), nil
+	}
+	if !removed {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "notes.not_found", noteID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"note_id":    noteID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "notes.deleted", noteID, note.UserID),
+			Data: map[string]interface{}{
+				"note_id": noteID,
+				"user_id": note.UserID,
+				"deleted": true,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *DeleteUserNoteTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("delete_user_note", "Delete a private note about a server member")
+}
+
+// formatError creates a standardized error response
+func (t *DeleteUserNoteTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// notesDisabledResult reports that user notes are turned off in the
+// configuration
+func notesDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "notes.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "user notes disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"fmt"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/onboarding"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// SetOnboardingRuleTool implements the set_onboarding_rule MCP tool
+type SetOnboardingRuleTool struct {
+	handler *GuildHandler
+}
+
+// NewSetOnboardingRuleTool creates a new set onboarding rule tool
+func NewSetOnboardingRuleTool(handler *GuildHandler) *SetOnboardingRuleTool {
+	return &SetOnboardingRuleTool{handler: handler}
+}
+
+// Execute executes the set_onboarding_rule tool
+func (t *SetOnboardingRuleTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("set_onboarding_rule", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	registry := t.handler.discord.Onboarding()
+	if registry == nil {
+		return onboardingDisabledResult(params), nil
+	}
+
+	rule := onboarding.Rule{
+		Enabled: true,
+		Source:  onboarding.SourceTool,
+	}
+	rule.GuildID = params.Arguments["guild_id"].(string)
+	rule.Name = params.Arguments["name"].(string)
+	rule.DirectMessage, _ = params.Arguments["direct_message"].(string)
+	rule.ChannelID, _ = params.Arguments["channel_id"].(string)
+	rule.ChannelMessage, _ = params.Arguments["channel_message"].(string)
+	rule.IncludeBots, _ = params.Arguments["include_bots"].(bool)
+	if val, ok := params.Arguments["enabled"].(bool); ok {
+		rule.Enabled = val
+	}
+	if roleIDs, ok := params.Arguments["role_ids"].([]interface{}); ok {
+		for _, roleID := range roleIDs {
+			if id, ok := roleID.(string); ok {
+				rule.RoleIDs = append(rule.RoleIDs, id)
+			}
+		}
+	}
+
+	// Validate permissions for every action so a rule cannot fail on join
+	if err := t.checkPermissions(rule); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		if validationErr, ok := err.(*validation.ValidationError); ok {
+			return validation.FormatValidationError(validationErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	saved, created, err := registry.Set(rule)
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid onboarding rule", err.Error(), nil)), nil
+	}
+
+	key := "onboarding.updated"
+	if created {
+		key = "onboarding.created"
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), key, saved.Name, saved.GuildID),
+			Data: saved,
+		}},
+	}, nil
+}
+
+// checkPermissions checks that the bot can assign the rule's roles and post
+// its welcome message
+func (t *SetOnboardingRuleTool) checkPermissions(rule onboarding.Rule) error {
+	if err := t.handler.permissions.CanViewGuild(rule.GuildID); err != nil {
+		return err
+	}
+	for _, roleID := range rule.RoleIDs {
+		if err := t.handler.permissions.CanManageRole(rule.GuildID, roleID); err != nil {
+			return err
+		}
+	}
+	if rule.ChannelID == "" {
+		return nil
+	}
+
+	channel, err := t.handler.discord.GetChannel(rule.ChannelID)
+	if err != nil {
+		return err
+	}
+	if channel.GuildID != rule.GuildID {
+		return validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s is not in guild %s", rule.ChannelID, rule.GuildID), "channel_id")
+	}
+	return t.handler.permissions.CanSendMessages(rule.ChannelID)
+}
+
+// GetDefinition returns the tool definition
+func (t *SetOnboardingRuleTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("set_onboarding_rule", "Create or replace a rule that assigns roles, sends a welcome DM and posts a welcome message when a member joins a server")
+}
+
+// formatError creates a standardized error response
+func (t *SetOnboardingRuleTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListOnboardingRulesTool implements the list_onboarding_rules MCP tool
+type ListOnboardingRulesTool struct {
+	handler *GuildHandler
+}
+
+// NewListOnboardingRulesTool creates a new list onboarding rules tool
+func NewListOnboardingRulesTool(handler *GuildHandler) *ListOnboardingRulesTool {
+	return &ListOnboardingRulesTool{handler: handler}
+}
+
+// Execute executes the list_onboarding_rules tool
+func (t *ListOnboardingRulesTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_onboarding_rules", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	registry := t.handler.discord.Onboarding()
+	if registry == nil {
+		return onboardingDisabledResult(params), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	if guildID != "" {
+		if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return t.formatError("Permission check failed", err), nil
+		}
+	}
+
+	rules := registry.List(guildID)
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "onboarding.list", len(rules)),
+			Data: map[string]interface{}{
+				"rule_count":   len(rules),
+				"rules":        rules,
+				"placeholders": onboarding.Placeholders,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListOnboardingRulesTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_onboarding_rules", "List member onboarding rules with their run counts and most recent errors")
+}
+
+// formatError creates a standardized error response
+func (t *ListOnboardingRulesTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// onboardingDisabledResult reports that onboarding is turned off in the
+// configuration
+func onboardingDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "onboarding.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "onboarding disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
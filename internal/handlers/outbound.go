@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// GetSendQueueTool implements the get_send_queue MCP tool
+type GetSendQueueTool struct {
+	discord   *discord.Client
+	validator *validation.Validator
+}
+
+// NewGetSendQueueTool creates a new get send queue tool
+func NewGetSendQueueTool(discordClient *discord.Client, validator *validation.Validator) *GetSendQueueTool {
+	return &GetSendQueueTool{
+		discord:   discordClient,
+		validator: validator,
+	}
+}
+
+// Execute executes the get_send_queue tool
+func (t *GetSendQueueTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("get_send_queue", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	queue := t.discord.Outbound()
+	if queue == nil {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "outbound.disabled"),
+				Data: map[string]interface{}{
+					"error_type": "configuration",
+					"message":    "outbound queue disabled",
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	stats := queue.Stats()
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "outbound.stats", stats.Pending,
+				stats.ByPriority[outbound.PriorityInteractive.String()],
+				stats.ByPriority[outbound.PriorityNormal.String()],
+				stats.ByPriority[outbound.PriorityBulk.String()]),
+			Data: stats,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetSendQueueTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_send_queue", "Show the outbound message queue: pending messages by priority, the oldest wait, whether bulk sends are being refused, and send totals")
+}
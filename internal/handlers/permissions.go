@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// CheckPermissionsTool implements the check_permissions MCP tool
+type CheckPermissionsTool struct {
+	discord     *discord.Client
+	permissions *permissions.Checker
+	validator   *validation.Validator
+	logger      *logrus.Logger
+}
+
+// NewCheckPermissionsTool creates a new check permissions tool
+func NewCheckPermissionsTool(discordClient *discord.Client, permChecker *permissions.Checker, validator *validation.Validator, logger *logrus.Logger) *CheckPermissionsTool {
+	return &CheckPermissionsTool{
+		discord:     discordClient,
+		permissions: permChecker,
+		validator:   validator,
+		logger:      logger,
+	}
+}
+
+// Execute executes the check_permissions tool
+func (t *CheckPermissionsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("check_permissions", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	channelID, _ := params.Arguments["channel_id"].(string)
+	if guildID == "" && channelID == "" {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+			"provide guild_id or channel_id", nil)), nil
+	}
+
+	var operations []string
+	for _, op := range params.Arguments["operations"].([]interface{}) {
+		operations = append(operations, op.(string))
+	}
+
+	var target permissions.Target
+	target.RoleID, _ = params.Arguments["role_id"].(string)
+	target.UserID, _ = params.Arguments["user_id"].(string)
+
+	checks, err := t.permissions.Preflight(guildID, channelID, operations, target)
+	if err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return discordErrorResult(t.logger, "Failed to check permissions", err), nil
+	}
+
+	allowed := []string{}
+	denied := []permissions.OperationCheck{}
+	for _, check := range checks {
+		if check.Allowed {
+			allowed = append(allowed, check.Operation)
+		} else {
+			denied = append(denied, check)
+		}
+	}
+
+	locale := i18n.Locale(params)
+	text := i18n.T(locale, "permissions.summary", len(allowed), len(checks))
+	for _, check := range denied {
+		text += "\n" + i18n.T(locale, "permissions.denied", check.Operation, check.Reason)
+		if len(check.Missing) > 0 {
+			text += i18n.T(locale, "permissions.missing", check.Missing)
+		}
+	}
+
+	data := map[string]interface{}{
+		"all_allowed": len(denied) == 0,
+		"allowed":     allowed,
+		"denied":      denied,
+		"checks":      checks,
+	}
+	if guildID != "" {
+		data["guild_id"] = guildID
+	}
+	if channelID != "" {
+		data["channel_id"] = channelID
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *CheckPermissionsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("check_permissions", "Check which operations the bot can perform in a channel or guild before attempting them, and which permissions or roles are missing")
+}
+
+// PermissionMatrixTool implements the permission_matrix MCP tool
+type PermissionMatrixTool struct {
+	discord     *discord.Client
+	permissions *permissions.Checker
+	validator   *validation.Validator
+	logger      *logrus.Logger
+}
+
+// NewPermissionMatrixTool creates a new permission matrix tool
+func NewPermissionMatrixTool(discordClient *discord.Client, permChecker *permissions.Checker, validator *validation.Validator, logger *logrus.Logger) *PermissionMatrixTool {
+	return &PermissionMatrixTool{
+		discord:     discordClient,
+		permissions: permChecker,
+		validator:   validator,
+		logger:      logger,
+	}
+}
+
+// Execute executes the permission_matrix tool
+func (t *PermissionMatrixTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("permission_matrix", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	channelID, _ := params.Arguments["channel_id"].(string)
+	if guildID == "" && channelID == "" {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+			"provide guild_id or channel_id", nil)), nil
+	}
+
+	// Validate permissions
+	var channel *discordgo.Channel
+	if channelID != "" {
+		if err := t.permissions.CanViewChannel(channelID); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return discordErrorResult(t.logger, "Permission check failed", err), nil
+		}
+		var err error
+		if channel, err = t.discord.GetChannel(channelID); err != nil {
+			return discordErrorResult(t.logger, "Failed to get channel", err), nil
+		}
+		guildID = channel.GuildID
+	} else if err := t.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return discordErrorResult(t.logger, "Permission check failed", err), nil
+	}
+
+	roles, err := t.discord.GetRoles(guildID)
+	if err != nil {
+		return discordErrorResult(t.logger, "Failed to get roles", err), nil
+	}
+
+	rows, risks := permissions.Matrix(guildID, roles, channel)
+	columns := make([]string, len(permissions.MatrixColumns))
+	for i, column := range permissions.MatrixColumns {
+		columns[i] = column.Key
+	}
+
+	locale := i18n.Locale(params)
+	text := i18n.T(locale, "permissions.matrix", len(rows), len(risks))
+	for _, risk := range risks {
+		text += "\n" + i18n.T(locale, "permissions.risk", risk.Severity, risk.Message)
+	}
+	text += "\n\n" + matrixTable(columns, rows)
+
+	data := map[string]interface{}{
+		"guild_id": guildID,
+		"columns":  columns,
+		"roles":    rows,
+		"risks":    risks,
+	}
+	if channel != nil {
+		data["channel_id"] = channel.ID
+		data["channel_name"] = channel.Name
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: data,
+		}},
+	}, nil
+}
+
+// matrixTable renders the matrix as a Markdown table, highest role first
+func matrixTable(columns []string, rows []permissions.MatrixRow) string {
+	var b strings.Builder
+	b.WriteString("| role | " + strings.Join(columns, " | ") + " |\n|---|")
+	b.WriteString(strings.Repeat("---|", len(columns)) + "\n")
+	for _, row := range rows {
+		b.WriteString("| " + strings.ReplaceAll(row.RoleName, "|", "\\|"))
+		for _, column := range columns {
+			if row.Permissions[column] {
+				b.WriteString(" | ✓")
+			} else {
+				b.WriteString(" | ·")
+			}
+		}
+		b.WriteString(" |\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// GetDefinition returns the tool definition
+func (t *PermissionMatrixTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("permission_matrix", "Tabulate what each role can do in a guild or channel (view, send, manage, mention everyone and more) and flag risky grants such as @everyone mentioning everyone")
+}
@@ -1,10 +1,10 @@
 package handlers
 
 import (
-	"fmt"
 	"time"
 
 	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
 	"discord-mcp/pkg/types"
 )
 
@@ -30,7 +30,7 @@ func (p *PingTool) Execute(params types.CallToolParams) (types.CallToolResult, e
 		return types.CallToolResult{
 			Content: []types.Content{{
 				Type: "text",
-				Text: fmt.Sprintf("Discord connection failed: %v", err),
+				Text: i18n.T(i18n.Locale(params), "ping.failed", err),
 			}},
 			IsError: true,
 		}, nil
@@ -42,7 +42,7 @@ func (p *PingTool) Execute(params types.CallToolParams) (types.CallToolResult, e
 		return types.CallToolResult{
 			Content: []types.Content{{
 				Type: "text",
-				Text: fmt.Sprintf("Failed to get bot user info: %v", err),
+				Text: i18n.T(i18n.Locale(params), "ping.bot_user_failed", err),
 			}},
 			IsError: true,
 		}, nil
@@ -50,11 +50,7 @@ func (p *PingTool) Execute(params types.CallToolParams) (types.CallToolResult, e
 
 	duration := time.Since(startTime)
 	
-	response := fmt.Sprintf("✅ Discord MCP Server is healthy!\n\n"+
-		"🤖 Bot: %s#%s (ID: %s)\n"+
-		"📡 Connected: %t\n"+
-		"⏱️ Response time: %v\n"+
-		"🕒 Timestamp: %s",
+	response := i18n.T(i18n.Locale(params), "ping.healthy",
 		botUser.Username,
 		botUser.Discriminator,
 		botUser.ID,
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/jsonfile"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/pinning"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// SetPinPolicyTool implements the set_pin_policy MCP tool
+type SetPinPolicyTool struct {
+	handler *MessageHandler
+}
+
+// NewSetPinPolicyTool creates a new set pin policy tool
+func NewSetPinPolicyTool(handler *MessageHandler) *SetPinPolicyTool {
+	return &SetPinPolicyTool{handler: handler}
+}
+
+// Execute executes the set_pin_policy tool
+func (t *SetPinPolicyTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("set_pin_policy", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Pinning()
+	if store == nil {
+		return pinningDisabledResult(params), nil
+	}
+
+	policy := pinning.Policy{
+		PinLimit:  intArgument(params.Arguments, "pin_limit", pinning.DefaultPinLimit),
+		AutoUnpin: true,
+		Enabled:   true,
+	}
+	policy.ChannelID = params.Arguments["channel_id"].(string)
+	if roleIDs, ok := params.Arguments["role_ids"].([]interface{}); ok {
+		for _, roleID := range roleIDs {
+			if id, ok := roleID.(string); ok {
+				policy.RoleIDs = append(policy.RoleIDs, id)
+			}
+		}
+	}
+	if keywords, ok := params.Arguments["keywords"].([]interface{}); ok {
+		for _, keyword := range keywords {
+			if s, ok := keyword.(string); ok {
+				policy.Keywords = append(policy.Keywords, s)
+			}
+		}
+	}
+	if val, ok := params.Arguments["auto_unpin"].(bool); ok {
+		policy.AutoUnpin = val
+	}
+	if val, ok := params.Arguments["enabled"].(bool); ok {
+		policy.Enabled = val
+	}
+
+	// Validate permissions
+	if err := t.checkPermissions(&policy); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		if validationErr, ok := err.(*validation.ValidationError); ok {
+			return validation.FormatValidationError(validationErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	saved, created, err := store.SetPolicy(policy)
+	if errors.Is(err, jsonfile.ErrSave) {
+		return t.formatError("Failed to save pinning policy", err), nil
+	}
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid pinning policy", err.Error(), nil)), nil
+	}
+
+	key := "pinning.updated"
+	if created {
+		key = "pinning.created"
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), key, saved.ChannelID, saved.PinLimit),
+			Data: saved,
+		}},
+	}, nil
+}
+
+// checkPermissions sets the policy's guild from its channel, checks that
+// its roles belong to that guild and that the bot can pin and unpin there
+func (t *SetPinPolicyTool) checkPermissions(policy *pinning.Policy) error {
+	channel, err := t.handler.discord.GetChannel(policy.ChannelID)
+	if err != nil {
+		return err
+	}
+	if channel.GuildID == "" {
+		return validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s is not in a guild", policy.ChannelID), "channel_id")
+	}
+	policy.GuildID = channel.GuildID
+
+	if len(policy.RoleIDs) > 0 {
+		roles, err := t.handler.discord.GetRoles(policy.GuildID)
+		if err != nil {
+			return err
+		}
+		known := make(map[string]bool, len(roles))
+		for _, role := range roles {
+			known[role.ID] = true
+		}
+		for _, roleID := range policy.RoleIDs {
+			if !known[roleID] {
+				return validation.NewValidationError("invalid role",
+					fmt.Sprintf("role %s is not in guild %s", roleID, policy.GuildID), "role_ids")
+			}
+		}
+	}
+	return t.handler.permissions.CanManageMessages(policy.ChannelID)
+}
+
+// GetDefinition returns the tool definition
+func (t *SetPinPolicyTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("set_pin_policy", "Set a channel's pinning policy: new messages from members with given roles or containing given keywords are pinned, and the oldest pins are removed as the pin limit is reached")
+}
+
+// formatError creates a standardized error response
+func (t *SetPinPolicyTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListPinPoliciesTool implements the list_pin_policies MCP tool
+type ListPinPoliciesTool struct {
+	handler *MessageHandler
+}
+
+// NewListPinPoliciesTool creates a new list pin policies tool
+func NewListPinPoliciesTool(handler *MessageHandler) *ListPinPoliciesTool {
+	return &ListPinPoliciesTool{handler: handler}
+}
+
+// Execute executes the list_pin_policies tool
+func (t *ListPinPoliciesTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_pin_policies", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Pinning()
+	if store == nil {
+		return pinningDisabledResult(params), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	policies := store.List(guildID)
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "pinning.list", len(policies)),
+			Data: map[string]interface{}{
+				"policy_count": len(policies),
+				"policies":     policies,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListPinPoliciesTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_pin_policies", "List channel pinning policies with their pin and unpin counts and most recent errors")
+}
+
+// DeletePinPolicyTool implements the delete_pin_policy MCP tool
+type DeletePinPolicyTool struct {
+	handler *MessageHandler
+}
+
+// NewDeletePinPolicyTool creates a new delete pin policy tool
+func NewDeletePinPolicyTool(handler *MessageHandler) *DeletePinPolicyTool {
+	return &DeletePinPolicyTool{handler: handler}
+}
+
+// Execute executes the delete_pin_policy tool
+func (t *DeletePinPolicyTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("delete_pin_policy", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Pinning()
+	if store == nil {
+		return pinningDisabledResult(params), nil
+	}
+
+	channelID := params.Arguments["channel_id"].(string)
+	removed, err := store.Remove(channelID)
+	if err != nil {
+		return t.formatError("Failed to save pinning policies", err), nil
+	}
+	if !removed {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "pinning.not_found", channelID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"channel_id": channelID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "pinning.deleted", channelID),
+			Data: map[string]interface{}{
+				"channel_id": channelID,
+				"deleted":    true,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *DeletePinPolicyTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("delete_pin_policy", "Remove a channel's pinning policy; messages it pinned stay pinned")
+}
+
+// formatError creates a standardized error response
+func (t *DeletePinPolicyTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// pinningDisabledResult reports that pinning policies are turned off in the
+// configuration
+func pinningDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "pinning.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "pinning policies disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
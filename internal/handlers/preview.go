@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// GetGuildPreviewTool implements the get_guild_preview MCP tool
+type GetGuildPreviewTool struct {
+	handler *GuildHandler
+}
+
+// NewGetGuildPreviewTool creates a new get guild preview tool
+func NewGetGuildPreviewTool(handler *GuildHandler) *GetGuildPreviewTool {
+	return &GetGuildPreviewTool{handler: handler}
+}
+
+// Execute executes the get_guild_preview tool
+func (t *GetGuildPreviewTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_guild_preview", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+
+	// The preview is public for discoverable servers, so the bot need not
+	// be a member; Discord answers 404 for other servers it is not in
+	var preview *discordgo.GuildPreview
+	retries, err := t.handler.discord.Retry(func() (err error) {
+		preview, err = t.handler.discord.Session().GuildPreview(guildID)
+		return err
+	})
+	if err != nil {
+		return t.formatError("Failed to get guild preview", err), nil
+	}
+
+	cdn := t.handler.discord.CDN()
+	emojis := make([]map[string]interface{}, 0, len(preview.Emojis))
+	for _, emoji := range preview.Emojis {
+		emojis = append(emojis, map[string]interface{}{
+			"id":        emoji.ID,
+			"name":      emoji.Name,
+			"animated":  emoji.Animated,
+			"available": emoji.Available,
+			"url":       cdn.Emoji(emoji.ID, emoji.Animated),
+		})
+	}
+	features := append([]string{}, preview.Features...)
+	sort.Strings(features)
+
+	_, memberErr := t.handler.discord.GetGuild(guildID)
+	data := map[string]interface{}{
+		"id":                         preview.ID,
+		"name":                       preview.Name,
+		"description":                preview.Description,
+		"approximate_member_count":   preview.ApproximateMemberCount,
+		"approximate_presence_count": preview.ApproximatePresenceCount,
+		"features":                   features,
+		"discoverable":               hasFeature(features, "DISCOVERABLE"),
+		"emoji_count":                len(emojis),
+		"emojis":                     emojis,
+		"bot_is_member":              memberErr == nil,
+		"retries":                    retries,
+	}
+	if preview.Icon != "" {
+		data["icon_url"] = cdn.GuildIcon(preview.ID, preview.Icon)
+	}
+	if preview.Splash != "" {
+		data["splash_url"] = cdn.GuildSplash(preview.ID, preview.Splash)
+	}
+	if preview.DiscoverySplash != "" {
+		data["discovery_splash_url"] = cdn.GuildDiscoverySplash(preview.ID, preview.DiscoverySplash)
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "preview.info", preview.Name, preview.ApproximateMemberCount, preview.ApproximatePresenceCount, len(emojis)),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetGuildPreviewTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_guild_preview", "Preview a Discord server the bot may not be in: name, description, approximate member and online counts, custom emojis and features. Works for discoverable servers and servers the bot is in")
+}
+
+// formatError creates a standardized error response
+func (t *GetGuildPreviewTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// hasFeature reports whether a guild feature list contains feature
+func hasFeature(features []string, feature string) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"fmt"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/raid"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ArmRaidProtectionTool implements the arm_raid_protection MCP tool
+type ArmRaidProtectionTool struct {
+	handler *GuildHandler
+}
+
+// NewArmRaidProtectionTool creates a new arm raid protection tool
+func NewArmRaidProtectionTool(handler *GuildHandler) *ArmRaidProtectionTool {
+	return &ArmRaidProtectionTool{handler: handler}
+}
+
+// Execute executes the arm_raid_protection tool
+func (t *ArmRaidProtectionTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("arm_raid_protection", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	detector := t.handler.discord.Raid()
+	if detector == nil {
+		return raidDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	settings := detector.Defaults()
+	settings.JoinThreshold = intArgument(params.Arguments, "join_threshold", settings.JoinThreshold)
+	settings.JoinWindowSeconds = intArgument(params.Arguments, "join_window_seconds", settings.JoinWindowSeconds)
+	settings.MessageThreshold = intArgument(params.Arguments, "message_threshold", settings.MessageThreshold)
+	settings.MessageWindowSeconds = intArgument(params.Arguments, "message_window_seconds", settings.MessageWindowSeconds)
+	settings.SlowmodeSeconds = intArgument(params.Arguments, "slowmode_seconds", settings.SlowmodeSeconds)
+	settings.CooldownSeconds = intArgument(params.Arguments, "cooldown_seconds", settings.CooldownSeconds)
+	if val, ok := params.Arguments["pause_invites"].(bool); ok {
+		settings.PauseInvites = val
+	}
+
+	// Validate permissions for every response so protection cannot fail
+	// during a raid
+	if err := t.checkPermissions(guildID, settings); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	protection, err := detector.Arm(guildID, settings)
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid raid settings", err.Error(), nil)), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "raid.armed", guildID),
+			Data: protection,
+		}},
+	}, nil
+}
+
+// checkPermissions checks that the bot can raise slowmode and pause invites
+// when the settings call for it
+func (t *ArmRaidProtectionTool) checkPermissions(guildID string, settings raid.Settings) error {
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		return err
+	}
+	if settings.SlowmodeSeconds > 0 {
+		if err := t.handler.permissions.CanManageChannels(guildID); err != nil {
+			return err
+		}
+	}
+	if settings.PauseInvites {
+		checks, err := t.handler.permissions.Preflight(guildID, "", []string{"manage_guild"}, permissions.Target{})
+		if err != nil {
+			return err
+		}
+		if !checks[0].Allowed {
+			return permissions.NewPermissionError("arm_raid_protection", "MANAGE_GUILD",
+				fmt.Sprintf("guild:%s", guildID),
+				"Bot cannot pause invites in this guild; grant Manage Server or set pause_invites to false")
+		}
+	}
+	return nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ArmRaidProtectionTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("arm_raid_protection", "Watch a guild for join and message floods, raising slowmode or pausing invites automatically when a threshold is crossed")
+}
+
+// formatError creates a standardized error response
+func (t *ArmRaidProtectionTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// DisarmRaidProtectionTool implements the disarm_raid_protection MCP tool
+type DisarmRaidProtectionTool struct {
+	handler *GuildHandler
+}
+
+// NewDisarmRaidProtectionTool creates a new disarm raid protection tool
+func NewDisarmRaidProtectionTool(handler *GuildHandler) *DisarmRaidProtectionTool {
+	return &DisarmRaidProtectionTool{handler: handler}
+}
+
+// Execute executes the disarm_raid_protection tool
+func (t *DisarmRaidProtectionTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("disarm_raid_protection", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	detector := t.handler.discord.Raid()
+	if detector == nil {
+		return raidDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	revert, _ := params.Arguments["revert"].(bool)
+
+	if !detector.Disarm(guildID) {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "raid.not_armed", guildID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"guild_id":   guildID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	reverted := []raid.Action{}
+	failed := []raid.Action{}
+	if revert {
+		reverted, failed = t.revertActions(detector, guildID)
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "raid.disarmed", guildID, len(reverted)),
+			Data: map[string]interface{}{
+				"guild_id":       guildID,
+				"reverted":       reverted,
+				"revert_failed":  failed,
+				"reverted_count": len(reverted),
+			},
+		}},
+	}, nil
+}
+
+// revertActions undoes every successful action taken for the guild's
+// incidents, restoring each channel's earlier slowmode and re-enabling
+// invites. It continues past failures and returns both lists.
+func (t *DisarmRaidProtectionTool) revertActions(detector *raid.Detector, guildID string) ([]raid.Action, []raid.Action) {
+	reverted := []raid.Action{}
+	failed := []raid.Action{}
+	// Only the oldest change per target holds the value from before the raid
+	done := make(map[string]bool)
+
+	incidents := detector.Incidents(guildID)
+	for i := len(incidents) - 1; i >= 0; i-- {
+		incident := incidents[i]
+		for index, action := range incident.Actions {
+			key := action.Type + "/" + action.ChannelID
+			if !action.Success || action.Reverted || done[key] {
+				continue
+			}
+			done[key] = true
+
+			var err error
+			switch action.Type {
+			case raid.ActionSlowmode:
+				err = t.restoreSlowmode(action.ChannelID, action.Previous)
+			case raid.ActionPauseInvites:
+				err = t.resumeInvites(guildID)
+			}
+			if err != nil {
+				t.handler.logger.Warnf("Failed to revert %s for raid incident %s: %v", action.Type, incident.ID, err)
+				action.Error = err.Error()
+				failed = append(failed, action)
+				continue
+			}
+			detector.MarkReverted(incident.ID, index)
+			action.Reverted = true
+			reverted = append(reverted, action)
+		}
+	}
+	return reverted, failed
+}
+
+// restoreSlowmode sets a channel's slowmode back to its earlier value
+func (t *DisarmRaidProtectionTool) restoreSlowmode(channelID string, seconds int) error {
+	_, err := t.handler.discord.Retry(func() error {
+		_, err := t.handler.discord.Session().ChannelEditComplex(channelID, &discordgo.ChannelEdit{RateLimitPerUser: &seconds},
+			discordgo.WithAuditLogReason("Raid protection disarmed: restoring slowmode"))
+		return err
+	})
+	return err
+}
+
+// resumeInvites removes the invites-disabled feature from a guild
+func (t *DisarmRaidProtectionTool) resumeInvites(guildID string) error {
+	guild, err := t.handler.discord.Session().State.Guild(guildID)
+	if err != nil {
+		return err
+	}
+	features := make([]discordgo.GuildFeature, 0, len(guild.Features))
+	for _, feature := range guild.Features {
+		if feature != discordgo.GuildFeatureInvitesDisabled {
+			features = append(features, feature)
+		}
+	}
+	_, err = t.handler.discord.Retry(func() error {
+		_, err := t.handler.discord.Session().GuildEdit(guildID, &discordgo.GuildParams{Features: features},
+			discordgo.WithAuditLogReason("Raid protection disarmed: resuming invites"))
+		return err
+	})
+	return err
+}
+
+// GetDefinition returns the tool definition
+func (t *DisarmRaidProtectionTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("disarm_raid_protection", "Stop raid protection for a guild, optionally reverting the slowmode and invite pauses it applied")
+}
+
+// ListRaidIncidentsTool implements the list_raid_incidents MCP tool
+type ListRaidIncidentsTool struct {
+	handler *GuildHandler
+}
+
+// NewListRaidIncidentsTool creates a new list raid incidents tool
+func NewListRaidIncidentsTool(handler *GuildHandler) *ListRaidIncidentsTool {
+	return &ListRaidIncidentsTool{handler: handler}
+}
+
+// Execute executes the list_raid_incidents tool
+func (t *ListRaidIncidentsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_raid_incidents", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	detector := t.handler.discord.Raid()
+	if detector == nil {
+		return raidDisabledResult(params), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	limit := intArgument(params.Arguments, "limit", 25)
+
+	incidents := detector.Incidents(guildID)
+	total := len(incidents)
+	if len(incidents) > limit {
+		incidents = incidents[:limit]
+	}
+	protections := make([]raid.Protection, 0)
+	for _, protection := range detector.Protections() {
+		if guildID == "" || protection.GuildID == guildID {
+			protections = append(protections, protection)
+		}
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "raid.incidents", total, len(protections)),
+			Data: map[string]interface{}{
+				"incident_count": total,
+				"incidents":      incidents,
+				"protections":    protections,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListRaidIncidentsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_raid_incidents", "List suspected raids, newest first, with the actions taken, and the guilds with raid protection armed")
+}
+
+// raidDisabledResult reports that raid protection is turned off in the
+// configuration
+func raidDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "raid.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "raid protection disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
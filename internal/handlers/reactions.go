@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/history"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// maxReactorLookups bounds the reaction user lookups made for top reactors
+const maxReactorLookups = 50
+
+// GetReactionStatsTool implements the get_reaction_stats MCP tool
+type GetReactionStatsTool struct {
+	handler *MessageHandler
+}
+
+// NewGetReactionStatsTool creates a new get reaction stats tool
+func NewGetReactionStatsTool(handler *MessageHandler) *GetReactionStatsTool {
+	return &GetReactionStatsTool{handler: handler}
+}
+
+// Execute executes the get_reaction_stats tool
+func (t *GetReactionStatsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_reaction_stats", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	// Extract parameters
+	channelID := params.Arguments["channel_id"].(string)
+	limit := intArgument(params.Arguments, "limit", 100)
+	top := intArgument(params.Arguments, "top", 10)
+	includeReactors := true
+	if val, ok := params.Arguments["include_reactors"].(bool); ok {
+		includeReactors = val
+	}
+	includeImages, _ := params.Arguments["include_images"].(bool)
+
+	// Validate permissions
+	if err := t.handler.permissions.ValidateMessageOperation("get_messages", channelID, nil); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	cursor, _ := params.Arguments["scan_cursor"].(string)
+	messages, next, err := t.fetchRecent(channelID, limit, cursor)
+	if errors.Is(err, history.ErrInvalidCursor) {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameter", err.Error(), "scan_cursor")), nil
+	}
+	if err != nil {
+		return t.formatError("Failed to get channel messages", err), nil
+	}
+
+	type emojiStats struct {
+		emoji    *discordgo.Emoji
+		count    int
+		messages int
+	}
+	byEmoji := make(map[string]*emojiStats)
+	var reacted []*discordgo.Message
+	totalReactions := 0
+
+	for _, msg := range messages {
+		if len(msg.Reactions) == 0 {
+			continue
+		}
+		reacted = append(reacted, msg)
+		for _, reaction := range msg.Reactions {
+			key := reaction.Emoji.APIName()
+			stats, ok := byEmoji[key]
+			if !ok {
+				stats = &emojiStats{emoji: reaction.Emoji}
+				byEmoji[key] = stats
+			}
+			stats.count += reaction.Count
+			stats.messages++
+			totalReactions += reaction.Count
+		}
+	}
+
+	ranked := make([]*emojiStats, 0, len(byEmoji))
+	for _, stats := range byEmoji {
+		ranked = append(ranked, stats)
+	}
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].count > ranked[j].count })
+
+	emojiUsage := make([]map[string]interface{}, 0, len(ranked))
+	for _, stats := range ranked {
+		emojiUsage = append(emojiUsage, map[string]interface{}{
+			"emoji":         emojiLabel(stats.emoji),
+			"emoji_id":      stats.emoji.ID,
+			"emoji_name":    stats.emoji.Name,
+			"emoji_url":     t.handler.discord.CDN().Emoji(stats.emoji.ID, stats.emoji.Animated),
+			"count":         stats.count,
+			"message_count": stats.messages,
+		})
+	}
+	sort.SliceStable(reacted, func(i, j int) bool { return reactionTotal(reacted[i]) > reactionTotal(reacted[j]) })
+	if len(reacted) > top {
+		reacted = reacted[:top]
+	}
+	mostReacted := make([]map[string]interface{}, len(reacted))
+	for i, msg := range reacted {
+		reactions := make([]map[string]interface{}, len(msg.Reactions))
+		for j, reaction := range msg.Reactions {
+			reactions[j] = map[string]interface{}{
+				"emoji": emojiLabel(reaction.Emoji),
+				"count": reaction.Count,
+			}
+		}
+		entry := map[string]interface{}{
+			"message_id":      msg.ID,
+			"content":         msg.Content,
+			"total_reactions": reactionTotal(msg),
+			"reactions":       reactions,
+			"timestamp":       msg.Timestamp,
+		}
+		if msg.Author != nil {
+			entry["author_id"] = msg.Author.ID
+			entry["author_username"] = msg.Author.Username
+		}
+		mostReacted[i] = entry
+	}
+
+	data := map[string]interface{}{
+		"channel_id":       channelID,
+		"messages_scanned": len(messages),
+		"messages_reacted": len(reacted),
+		"total_reactions":  totalReactions,
+		"emoji_usage":      emojiUsage,
+		"most_reacted":     mostReacted,
+	}
+	if next != "" {
+		data["scan_cursor"] = next
+	}
+	if includeReactors {
+		reactors, complete := t.topReactors(channelID, reacted, top)
+		data["top_reactors"] = reactors
+		data["reactors_complete"] = complete
+	}
+
+	// Attach the most used custom emoji as image content after the text
+	var images []types.Content
+	if includeImages {
+		emojis := make([]*discordgo.Emoji, len(ranked))
+		for i, stats := range ranked {
+			emojis[i] = stats.emoji
+		}
+		batch := t.handler.images.fetchEmoji(emojis, t.handler.discord.CDN())
+		images = batch.contents
+		data["images"] = batch.fetched
+		data["images_skipped"] = batch.skipped
+	}
+
+	return types.CallToolResult{
+		Content: append([]types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "reactions.stats", totalReactions, len(reacted), len(messages), channelID),
+			Data: data,
+		}}, images...),
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetReactionStatsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_reaction_stats", "Scan recent messages in a channel for per-emoji reaction counts, most-reacted messages and top reactors")
+}
+
+// fetchRecent pages backwards for up to limit recent messages, returning the
+// cursor that continues with older messages
+func (t *GetReactionStatsTool) fetchRecent(channelID string, limit int, cursor string) ([]*discordgo.Message, string, error) {
+	result, err := t.handler.discord.ScanHistory(history.Request{
+		ChannelIDs: []string{channelID},
+		Limit:      limit,
+		Cursor:     cursor,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if err := result.Errors[channelID]; err != nil {
+		return nil, "", err
+	}
+	return result.Messages[channelID], result.Cursor, nil
+}
+
+// topReactors counts reacting users on the most-reacted messages. Each
+// message and emoji pair costs an API call, so lookups stop after
+// maxReactorLookups and the result reports whether every pair was read.
+func (t *GetReactionStatsTool) topReactors(channelID string, messages []*discordgo.Message, top int) ([]map[string]interface{}, bool) {
+	counts := make(map[string]int)
+	users := make(map[string]*discordgo.User)
+	lookups := 0
+	complete := true
+
+	for _, msg := range messages {
+		for _, reaction := range msg.Reactions {
+			if lookups >= maxReactorLookups {
+				complete = false
+				break
+			}
+			lookups++
+
+			var reactors []*discordgo.User
+			_, err := t.handler.discord.Retry(func() (err error) {
+				reactors, err = t.handler.discord.Session().MessageReactions(channelID, msg.ID, reaction.Emoji.APIName(), 100, "", "")
+				return err
+			})
+			if err != nil {
+				t.handler.logger.Warnf("Failed to get reactions for message %s: %v", msg.ID, err)
+				complete = false
+				continue
+			}
+			if reaction.Count > len(reactors) {
+				complete = false
+			}
+			for _, user := range reactors {
+				counts[user.ID]++
+				users[user.ID] = user
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if counts[ids[i]] != counts[ids[j]] {
+			return counts[ids[i]] > counts[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	if len(ids) > top {
+		ids = ids[:top]
+	}
+
+	reactors := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		reactors[i] = map[string]interface{}{
+			"user_id":    id,
+			"username":   users[id].Username,
+			"avatar_url": t.handler.discord.CDN().UserAvatar(users[id]),
+			"bot":        users[id].Bot,
+			"reactions":  counts[id],
+		}
+	}
+	return reactors, complete
+}
+
+// formatError creates a standardized error response
+func (t *GetReactionStatsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// emojiLabel renders an emoji as it appears in message content
+func emojiLabel(emoji *discordgo.Emoji) string {
+	if emoji.ID == "" {
+		return emoji.Name
+	}
+	return emoji.MessageFormat()
+}
+
+// reactionTotal sums all reactions on a message
+func reactionTotal(msg *discordgo.Message) int {
+	total := 0
+	for _, reaction := range msg.Reactions {
+		total += reaction.Count
+	}
+	return total
+}
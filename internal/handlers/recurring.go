@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/jsonfile"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/schedule"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// CreateRecurringPostTool implements the create_recurring_post MCP tool
+type CreateRecurringPostTool struct {
+	handler *TemplateHandler
+}
+
+// NewCreateRecurringPostTool creates a new create recurring post tool
+func NewCreateRecurringPostTool(handler *TemplateHandler) *CreateRecurringPostTool {
+	return &CreateRecurringPostTool{handler: handler}
+}
+
+// Execute executes the create_recurring_post tool
+func (t *CreateRecurringPostTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("create_recurring_post", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	scheduler := t.handler.discord.Schedule()
+	if scheduler == nil {
+		return scheduleDisabledResult(params), nil
+	}
+
+	post := schedule.Post{}
+	post.ChannelID = params.Arguments["channel_id"].(string)
+	post.Cron = params.Arguments["cron"].(string)
+	post.Timezone, _ = params.Arguments["timezone"].(string)
+	post.Template, _ = params.Arguments["template"].(string)
+	post.Content, _ = params.Arguments["content"].(string)
+
+	// Render the template now so missing variables are reported up front
+	if post.Template != "" {
+		values, errResult := templateValues(params.Arguments)
+		if errResult != nil {
+			return *errResult, nil
+		}
+		if _, errResult := t.handler.render(params.Arguments); errResult != nil {
+			return *errResult, nil
+		}
+		post.Variables = values
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.ValidateMessageOperation("send_message", post.ChannelID, map[string]interface{}{"tts": false}); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.handler.formatError("Permission check failed", err), nil
+	}
+	if channel, err := t.handler.discord.GetChannel(post.ChannelID); err == nil {
+		post.GuildID = channel.GuildID
+	}
+	if post.Timezone == "" {
+		post.Timezone = t.handler.discord.Location(post.GuildID).String()
+	}
+
+	created, err := scheduler.Add(post)
+	if errors.Is(err, jsonfile.ErrSave) {
+		return t.handler.formatError("Failed to save recurring post", err), nil
+	}
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid recurring post", err.Error(), nil)), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "schedule.created", created.ID, created.ChannelID, created.NextRunAt.Format(time.RFC3339)),
+			Data: created,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *CreateRecurringPostTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("create_recurring_post", "Schedule a template or message to be posted to a channel on a cron schedule, such as weekly standup reminders")
+}
+
+// ListRecurringPostsTool implements the list_recurring_posts MCP tool
+type ListRecurringPostsTool struct {
+	handler *TemplateHandler
+}
+
+// NewListRecurringPostsTool creates a new list recurring posts tool
+func NewListRecurringPostsTool(handler *TemplateHandler) *ListRecurringPostsTool {
+	return &ListRecurringPostsTool{handler: handler}
+}
+
+// Execute executes the list_recurring_posts tool
+func (t *ListRecurringPostsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_recurring_posts", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	scheduler := t.handler.discord.Schedule()
+	if scheduler == nil {
+		return scheduleDisabledResult(params), nil
+	}
+
+	channelID, _ := params.Arguments["channel_id"].(string)
+	posts := make([]schedule.Post, 0)
+	for _, post := range scheduler.List() {
+		if channelID == "" || post.ChannelID == channelID {
+			posts = append(posts, post)
+		}
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "schedule.list", len(posts)),
+			Data: map[string]interface{}{
+				"recurring_post_count": len(posts),
+				"recurring_posts":      posts,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListRecurringPostsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_recurring_posts", "List recurring posts with their next run time, run count and most recent error")
+}
+
+// DeleteRecurringPostTool implements the delete_recurring_post MCP tool
+type DeleteRecurringPostTool struct {
+	handler *TemplateHandler
+}
+
+// NewDeleteRecurringPostTool creates a new delete recurring post tool
+func NewDeleteRecurringPostTool(handler *TemplateHandler) *DeleteRecurringPostTool {
+	return &DeleteRecurringPostTool{handler: handler}
+}
+
+// Execute executes the delete_recurring_post tool
+func (t *DeleteRecurringPostTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("delete_recurring_post", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	scheduler := t.handler.discord.Schedule()
+	if scheduler == nil {
+		return scheduleDisabledResult(params), nil
+	}
+
+	postID := params.Arguments["recurring_post_id"].(string)
+	removed, err := scheduler.Remove(postID)
+	if err != nil {
+		return t.handler.formatError("Failed to save recurring posts", err), nil
+	}
+	if !removed {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "schedule.not_found", postID),
+				Data: map[string]interface{}{
+					"error_type":        "not_found",
+					"recurring_post_id": postID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "schedule.deleted", postID),
+			Data: map[string]interface{}{
+				"recurring_post_id": postID,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *DeleteRecurringPostTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("delete_recurring_post", "Delete a recurring post")
+}
+
+// scheduleDisabledResult reports that recurring posts are turned off in the
+// configuration
+func scheduleDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "schedule.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "schedule disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"fmt"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/relay"
+	"discord-mcp/internal/templates"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// CreateRelayTool implements the create_relay MCP tool
+type CreateRelayTool struct {
+	handler *MessageHandler
+}
+
+// NewCreateRelayTool creates a new create relay tool
+func NewCreateRelayTool(handler *MessageHandler) *CreateRelayTool {
+	return &CreateRelayTool{handler: handler}
+}
+
+// Execute executes the create_relay tool
+func (t *CreateRelayTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("create_relay", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Relays()
+	if store == nil {
+		return relaysDisabledResult(params), nil
+	}
+
+	channelID := params.Arguments["channel_id"].(string)
+	name := params.Arguments["name"].(string)
+	templateName, _ := params.Arguments["template"].(string)
+	content, _ := params.Arguments["content"].(string)
+	if templateName != "" && content != "" {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+			"template and content cannot be used together", "content")), nil
+	}
+
+	channel, err := t.handler.discord.GetChannel(channelID)
+	if err != nil {
+		return t.formatError("Failed to get channel", err), nil
+	}
+	if channel.GuildID == "" {
+		return validation.FormatValidationError(validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s is not in a guild", channelID), "channel_id")), nil
+	}
+	// GetGuild refuses guilds outside discord.allowed_guilds
+	if _, err := t.handler.discord.GetGuild(channel.GuildID); err != nil {
+		return t.formatError("Failed to get guild", err), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanSendMessages(channelID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	// The template is loaded again for every payload; checking it here
+	// catches typos before any payload is lost to them
+	if templateName != "" {
+		if _, err := templates.NewStore(t.handler.discord.Config().Templates.Directory).Get(templateName); err != nil {
+			return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+				err.Error(), "template")), nil
+		}
+	}
+
+	saved, err := store.Add(relay.Relay{
+		Name:      name,
+		GuildID:   channel.GuildID,
+		ChannelID: channelID,
+		Template:  templateName,
+		Content:   content,
+	})
+	if err != nil {
+		return t.formatError("Failed to save relay", err), nil
+	}
+
+	url := t.handler.discord.RelayURL(saved)
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "relays.created", saved.ID, channelID, url),
+			Data: map[string]interface{}{
+				"relay": saved,
+				"url":   url,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *CreateRelayTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("create_relay", "Create an incoming webhook URL: JSON payloads POSTed to it, e.g. by GitHub or Grafana, are posted to a channel through a template whose placeholders are the payload's flattened keys. The URL is only shown once")
+}
+
+// formatError creates a standardized error response
+func (t *CreateRelayTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListRelaysTool implements the list_relays MCP tool
+type ListRelaysTool struct {
+	handler *MessageHandler
+}
+
+// NewListRelaysTool creates a new list relays tool
+func NewListRelaysTool(handler *MessageHandler) *ListRelaysTool {
+	return &ListRelaysTool{handler: handler}
+}
+
+// Execute executes the list_relays tool
+func (t *ListRelaysTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_relays", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Relays()
+	if store == nil {
+		return relaysDisabledResult(params), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	if guildID != "" {
+		// Validate permissions
+		if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return t.formatError("Permission check failed", err), nil
+		}
+	}
+
+	relays := store.List(guildID)
+	for i := range relays {
+		relays[i] = relays[i].Redacted()
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "relays.list", len(relays)),
+			Data: map[string]interface{}{
+				"relay_count": len(relays),
+				"relays":      relays,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListRelaysTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_relays", "List the incoming webhook relays created with create_relay, with how many payloads each has received and the last error; URLs are not shown")
+}
+
+// formatError creates a standardized error response
+func (t *ListRelaysTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// DeleteRelayTool implements the delete_relay MCP tool
+type DeleteRelayTool struct {
+	handler *MessageHandler
+}
+
+// NewDeleteRelayTool creates a new delete relay tool
+func NewDeleteRelayTool(handler *MessageHandler) *DeleteRelayTool {
+	return &DeleteRelayTool{handler: handler}
+}
+
+// Execute executes the delete_relay tool
+func (t *DeleteRelayTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("delete_relay", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Relays()
+	if store == nil {
+		return relaysDisabledResult(params), nil
+	}
+
+	relayID := params.Arguments["relay_id"].(string)
+	_, ok, err := store.Remove(relayID)
+	if err != nil {
+		return t.formatError("Failed to save relays", err), nil
+	}
+	if !ok {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "relays.not_found", relayID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"relay_id":   relayID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "relays.deleted", relayID),
+			Data: map[string]interface{}{
+				"relay_id": relayID,
+				"deleted":  true,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *DeleteRelayTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("delete_relay", "Delete an incoming webhook relay; its URL stops accepting payloads immediately")
+}
+
+// formatError creates a standardized error response
+func (t *DeleteRelayTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// relaysDisabledResult reports that the relay is turned off in the
+// configuration
+func relaysDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "relays.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "relay disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
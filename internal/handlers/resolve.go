@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/resolve"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// ResolveHandler handles name-to-ID resolution
+type ResolveHandler struct {
+	discord     *discord.Client
+	permissions *permissions.Checker
+	validator   *validation.Validator
+	logger      *logrus.Logger
+	resolver    *resolve.Resolver
+}
+
+// NewResolveHandler creates a new resolve handler
+func NewResolveHandler(discordClient *discord.Client, permChecker *permissions.Checker, validator *validation.Validator, logger *logrus.Logger) *ResolveHandler {
+	return &ResolveHandler{
+		discord:     discordClient,
+		permissions: permChecker,
+		validator:   validator,
+		logger:      logger,
+		resolver:    resolve.NewResolver(discordClient, permChecker),
+	}
+}
+
+// execute runs a resolution for one of the resolve_* tools
+func (h *ResolveHandler) execute(toolName, kind string, params types.CallToolParams,
+	lookup func(guildID, query string, limit int) (*resolve.Result, error)) types.CallToolResult {
+	// Validate parameters
+	if err := h.validator.ValidateToolParams(toolName, params.Arguments); err != nil {
+		return validation.FormatValidationError(err)
+	}
+
+	query := params.Arguments["query"].(string)
+	limit := intArgument(params.Arguments, "limit", 5)
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	if guildID == "" {
+		guildID = h.discord.Config().Discord.DefaultGuildID
+	}
+	if guildID == "" {
+		return validation.FormatValidationError(validation.NewValidationError("missing required parameter",
+			"guild_id is required when no default guild is configured", "guild_id"))
+	}
+
+	// Validate permissions
+	if err := h.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr)
+		}
+		return h.formatError("Permission check failed", err)
+	}
+
+	result, err := lookup(guildID, query, limit)
+	if err != nil {
+		return h.formatError(fmt.Sprintf("Failed to resolve %s", kind), err)
+	}
+
+	var text string
+	switch {
+	case result.Match != nil:
+		text = i18n.T(i18n.Locale(params), "resolve.match", query, kind, result.Match.Name, result.Match.ID)
+	case result.Ambiguous:
+		text = i18n.T(i18n.Locale(params), "resolve.ambiguous", query, len(result.Candidates), kind)
+	default:
+		text = i18n.T(i18n.Locale(params), "resolve.no_match", kind, query)
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: map[string]interface{}{
+				"guild_id":   guildID,
+				"query":      result.Query,
+				"match":      result.Match,
+				"ambiguous":  result.Ambiguous,
+				"candidates": result.Candidates,
+			},
+		}},
+	}
+}
+
+// formatError creates a standardized error response
+func (h *ResolveHandler) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(h.logger, message, err)
+}
+
+// ResolveChannelTool implements the resolve_channel MCP tool
+type ResolveChannelTool struct {
+	handler *ResolveHandler
+}
+
+// NewResolveChannelTool creates a new resolve channel tool
+func NewResolveChannelTool(handler *ResolveHandler) *ResolveChannelTool {
+	return &ResolveChannelTool{handler: handler}
+}
+
+// Execute executes the resolve_channel tool
+func (t *ResolveChannelTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	return t.handler.execute("resolve_channel", "channel", params, t.handler.resolver.Channel), nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ResolveChannelTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("resolve_channel", "Find a channel ID by name (e.g. \"#announcements\") with fuzzy matching and a disambiguation list")
+}
+
+// ResolveRoleTool implements the resolve_role MCP tool
+type ResolveRoleTool struct {
+	handler *ResolveHandler
+}
+
+// NewResolveRoleTool creates a new resolve role tool
+func NewResolveRoleTool(handler *ResolveHandler) *ResolveRoleTool {
+	return &ResolveRoleTool{handler: handler}
+}
+
+// Execute executes the resolve_role tool
+func (t *ResolveRoleTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	return t.handler.execute("resolve_role", "role", params, t.handler.resolver.Role), nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ResolveRoleTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("resolve_role", "Find a role ID by name with fuzzy matching and a disambiguation list")
+}
+
+// ResolveUserTool implements the resolve_user MCP tool
+type ResolveUserTool struct {
+	handler *ResolveHandler
+}
+
+// NewResolveUserTool creates a new resolve user tool
+func NewResolveUserTool(handler *ResolveHandler) *ResolveUserTool {
+	return &ResolveUserTool{handler: handler}
+}
+
+// Execute executes the resolve_user tool
+func (t *ResolveUserTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	return t.handler.execute("resolve_user", "user", params, t.handler.resolver.User), nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ResolveUserTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("resolve_user", "Find a member's user ID by username, display name or nickname with fuzzy matching and a disambiguation list")
+}
@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/jsonfile"
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/rolemenu"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// CreateRoleMenuTool implements the create_role_menu MCP tool
+type CreateRoleMenuTool struct {
+	handler *RoleHandler
+}
+
+// NewCreateRoleMenuTool creates a new create role menu tool
+func NewCreateRoleMenuTool(handler *RoleHandler) *CreateRoleMenuTool {
+	return &CreateRoleMenuTool{handler: handler}
+}
+
+// Execute executes the create_role_menu tool
+func (t *CreateRoleMenuTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("create_role_menu", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.RoleMenus()
+	if store == nil {
+		return roleMenusDisabledResult(params), nil
+	}
+
+	channelID := params.Arguments["channel_id"].(string)
+	content := params.Arguments["content"].(string)
+	placeholder, _ := params.Arguments["placeholder"].(string)
+
+	var options []rolemenu.Option
+	seen := make(map[string]bool)
+	for _, raw := range params.Arguments["options"].([]interface{}) {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		option := rolemenu.Option{RoleID: entry["role_id"].(string)}
+		option.Label, _ = entry["label"].(string)
+		option.Description, _ = entry["description"].(string)
+		option.Emoji, _ = entry["emoji"].(string)
+		if seen[option.RoleID] {
+			return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+				fmt.Sprintf("role %s is listed twice", option.RoleID), "options")), nil
+		}
+		seen[option.RoleID] = true
+		options = append(options, option)
+	}
+	minValues := intArgument(params.Arguments, "min_values", 0)
+	maxValues := intArgument(params.Arguments, "max_values", len(options))
+	if maxValues > len(options) || minValues > maxValues {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+			fmt.Sprintf("need min_values <= max_values <= %d (the number of options)", len(options)), "max_values")), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanSendMessages(channelID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	channel, err := t.handler.discord.GetChannel(channelID)
+	if err != nil {
+		return t.formatError("Failed to get channel", err), nil
+	}
+	guildID := channel.GuildID
+
+	// The bot assigns the roles when members choose them, so it must be
+	// able to manage every one
+	for _, option := range options {
+		if err := t.handler.permissions.CanManageRole(guildID, option.RoleID); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return t.formatError("Permission check failed", err), nil
+		}
+	}
+
+	roles, err := t.handler.discord.GetRoles(guildID)
+	if err != nil {
+		return t.formatError("Failed to get roles", err), nil
+	}
+	roleNames := make(map[string]string, len(roles))
+	for _, role := range roles {
+		roleNames[role.ID] = role.Name
+	}
+
+	menuOptions := make([]discordgo.SelectMenuOption, 0, len(options))
+	for i, option := range options {
+		if option.Label == "" {
+			option.Label = roleNames[option.RoleID]
+		}
+		menuOption := discordgo.SelectMenuOption{
+			Label:       option.Label,
+			Value:       option.RoleID,
+			Description: option.Description,
+		}
+		if option.Emoji != "" {
+			emoji, err := resolveReactionEmoji(t.handler.discord, option.Emoji)
+			if err != nil {
+				return validation.FormatValidationError(err), nil
+			}
+			menuOption.Emoji = &discordgo.ComponentEmoji{Name: emoji.Name, ID: emoji.ID, Animated: emoji.Animated}
+			option.Emoji = emoji.APIName()
+		}
+		options[i] = option
+		menuOptions = append(menuOptions, menuOption)
+	}
+
+	menu := rolemenu.Menu{
+		ID:          store.NextID(),
+		GuildID:     guildID,
+		ChannelID:   channelID,
+		Placeholder: placeholder,
+		MinValues:   minValues,
+		MaxValues:   maxValues,
+		Options:     options,
+	}
+	msgData := &discordgo.MessageSend{
+		Content:         content,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+				discordgo.SelectMenu{
+					CustomID:    menu.CustomID(),
+					Placeholder: placeholder,
+					MinValues:   &minValues,
+					MaxValues:   maxValues,
+					Options:     menuOptions,
+				},
+			}},
+		},
+	}
+
+	var message *discordgo.Message
+	_, _, err = t.handler.discord.SendQueued(channelID, outbound.PriorityInteractive, func() (err error) {
+		message, err = t.handler.discord.Session().ChannelMessageSendComplex(channelID, msgData)
+		return err
+	})
+	if err != nil {
+		return t.formatError("Failed to post role menu", err), nil
+	}
+	menu.MessageID = message.ID
+
+	saved, err := store.Add(menu)
+	if errors.Is(err, jsonfile.ErrSave) {
+		return t.formatError(fmt.Sprintf("Failed to save role menu; message %s was posted", message.ID), err), nil
+	}
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid role menu", err.Error(), "options")), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "rolemenus.created", saved.ID, len(saved.Options), channelID),
+			Data: saved,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *CreateRoleMenuTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("create_role_menu", "Post a self-assign role menu: a message with a select menu whose options are roles that members pick to add or remove themselves")
+}
+
+// formatError creates a standardized error response
+func (t *CreateRoleMenuTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListRoleMenusTool implements the list_role_menus MCP tool
+type ListRoleMenusTool struct {
+	handler *RoleHandler
+}
+
+// NewListRoleMenusTool creates a new list role menus tool
+func NewListRoleMenusTool(handler *RoleHandler) *ListRoleMenusTool {
+	return &ListRoleMenusTool{handler: handler}
+}
+
+// Execute executes the list_role_menus tool
+func (t *ListRoleMenusTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_role_menus", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.RoleMenus()
+	if store == nil {
+		return roleMenusDisabledResult(params), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	if guildID != "" {
+		// Validate permissions
+		if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return t.formatError("Permission check failed", err), nil
+		}
+	}
+
+	menus := store.List(guildID)
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "rolemenus.list", len(menus)),
+			Data: map[string]interface{}{
+				"menu_count": len(menus),
+				"menus":      menus,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListRoleMenusTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_role_menus", "List the self-assign role menus posted with create_role_menu, with their roles and how many roles they have assigned and removed")
+}
+
+// formatError creates a standardized error response
+func (t *ListRoleMenusTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// DeleteRoleMenuTool implements the delete_role_menu MCP tool
+type DeleteRoleMenuTool struct {
+	handler *RoleHandler
+}
+
+// NewDeleteRoleMenuTool creates a new delete role menu tool
+func NewDeleteRoleMenuTool(handler *RoleHandler) *DeleteRoleMenuTool {
+	return &DeleteRoleMenuTool{handler: handler}
+}
+
+// Execute executes the delete_role_menu tool
+func (t *DeleteRoleMenuTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("delete_role_menu", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.RoleMenus()
+	if store == nil {
+		return roleMenusDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	menuID := params.Arguments["menu_id"].(string)
+	deleteMessage := true
+	if val, ok := params.Arguments["delete_message"].(bool); ok {
+		deleteMessage = val
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	menu, removed, err := store.Remove(guildID, menuID)
+	if err != nil {
+		return t.formatError("Failed to save role menus", err), nil
+	}
+	if !removed {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "rolemenus.not_found", menuID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"menu_id":    menuID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	data := map[string]interface{}{
+		"menu_id":         menuID,
+		"deleted":         true,
+		"message_deleted": false,
+	}
+	// The menu stops working once removed; a message left behind answers
+	// selections with a notice that it is no longer active
+	if deleteMessage {
+		_, err := t.handler.discord.Retry(func() error {
+			return t.handler.discord.Session().ChannelMessageDelete(menu.ChannelID, menu.MessageID)
+		})
+		if err != nil {
+			data["message_error"] = err.Error()
+		} else {
+			data["message_deleted"] = true
+		}
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "rolemenus.deleted", menuID),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *DeleteRoleMenuTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("delete_role_menu", "Remove a self-assign role menu and, by default, its message; roles members already chose are kept")
+}
+
+// formatError creates a standardized error response
+func (t *DeleteRoleMenuTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// roleMenusDisabledResult reports that role menus are turned off in the
+// configuration
+func roleMenusDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "rolemenus.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "role menus disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/journal"
 	"discord-mcp/internal/permissions"
 	"discord-mcp/internal/validation"
 	"discord-mcp/pkg/types"
@@ -58,22 +60,22 @@ func (t *ListRolesTool) Execute(params types.CallToolParams) (types.CallToolResu
 		return t.formatError("Permission check failed", err), nil
 	}
 
-	// Get roles from Discord
-	roles, err := t.handler.discord.Session().GuildRoles(guildID)
+	// Get roles from state, falling back to Discord
+	roles, err := t.handler.discord.GetRoles(guildID)
 	if err != nil {
 		return t.formatError("Failed to list roles", err), nil
 	}
 
 	// Format roles for response
-	formattedRoles := make([]map[string]interface{}, len(roles))
+	formattedRoles := make([]types.RoleSummary, len(roles))
 	for i, role := range roles {
-		formattedRoles[i] = t.formatRole(role)
+		formattedRoles[i] = t.handler.discord.FormatRole(role)
 	}
 
 	return types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("Found %d roles in guild %s", len(formattedRoles), guildID),
+			Text: i18n.T(i18n.Locale(params), "roles.list", len(formattedRoles), guildID),
 			Data: map[string]interface{}{
 				"guild_id":   guildID,
 				"role_count": len(formattedRoles),
@@ -88,35 +90,9 @@ func (t *ListRolesTool) GetDefinition() types.Tool {
 	return validation.GetToolDefinition("list_roles", "List all roles in a Discord server (guild)")
 }
 
-// formatRole formats a single role for the response
-func (t *ListRolesTool) formatRole(role *discordgo.Role) map[string]interface{} {
-	return map[string]interface{}{
-		"id":          role.ID,
-		"name":        role.Name,
-		"color":       role.Color,
-		"hoist":       role.Hoist,
-		"position":    role.Position,
-		"permissions": role.Permissions,
-		"managed":     role.Managed,
-		"mentionable": role.Mentionable,
-	}
-}
-
 // formatError creates a standardized error response
 func (t *ListRolesTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
 
 // GetRoleInfoTool implements the get_role_info MCP tool
@@ -148,19 +124,30 @@ func (t *GetRoleInfoTool) Execute(params types.CallToolParams) (types.CallToolRe
 		return t.formatError("Permission check failed", err), nil
 	}
 
-	// Get role from Discord
-	role, err := t.handler.discord.Session().State.Role(guildID, roleID)
+	// Get role from state, falling back to Discord
+	roles, err := t.handler.discord.GetRoles(guildID)
 	if err != nil {
 		return t.formatError("Failed to get role info", err), nil
 	}
 
+	var role *discordgo.Role
+	for _, r := range roles {
+		if r.ID == roleID {
+			role = r
+			break
+		}
+	}
+	if role == nil {
+		return t.formatError("Failed to get role info", fmt.Errorf("role %s not found in guild %s", roleID, guildID)), nil
+	}
+
 	// Format role for response
-	formattedRole := t.formatRole(role)
+	formattedRole := t.handler.discord.FormatRole(role)
 
 	return types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("Role: %s", role.Name),
+			Text: i18n.T(i18n.Locale(params), "roles.info", role.Name),
 			Data: formattedRole,
 		}},
 	}, nil
@@ -171,35 +158,9 @@ func (t *GetRoleInfoTool) GetDefinition() types.Tool {
 	return validation.GetToolDefinition("get_role_info", "Get information about a specific Discord role")
 }
 
-// formatRole formats a single role for the response
-func (t *GetRoleInfoTool) formatRole(role *discordgo.Role) map[string]interface{} {
-	return map[string]interface{}{
-		"id":          role.ID,
-		"name":        role.Name,
-		"color":       role.Color,
-		"hoist":       role.Hoist,
-		"position":    role.Position,
-		"permissions": role.Permissions,
-		"managed":     role.Managed,
-		"mentionable": role.Mentionable,
-	}
-}
-
 // formatError creates a standardized error response
 func (t *GetRoleInfoTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
 
 // CreateRoleTool implements the create_role MCP tool
@@ -232,21 +193,34 @@ func (t *CreateRoleTool) Execute(params types.CallToolParams) (types.CallToolRes
 	}
 
 	// Create role
-	role, err := t.handler.discord.Session().GuildRoleCreate(guildID, &discordgo.RoleParams{Name: name})
+	var role *discordgo.Role
+	retries, err := t.handler.discord.Retry(func() (err error) {
+		role, err = t.handler.discord.Session().GuildRoleCreate(guildID, &discordgo.RoleParams{Name: name}, auditLogOptions(params.Arguments)...)
+		return err
+	})
 	if err != nil {
 		return t.formatError("Failed to create role", err), nil
 	}
 
 	// Format role for response
-	formattedRole := t.formatRole(role)
+	formattedRole := struct {
+		types.RoleSummary
+		Retries int `json:"retries"`
+	}{t.handler.discord.FormatRole(role), retries}
 
-	return types.CallToolResult{
+	result := types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("Created role: %s", role.Name),
+			Text: i18n.T(i18n.Locale(params), "roles.created", role.Name),
 			Data: formattedRole,
 		}},
-	}, nil
+	}
+	return recordAction(t.handler.discord, result, journal.Action{
+		Type:    journal.TypeRoleCreated,
+		Tool:    "create_role",
+		GuildID: guildID,
+		RoleID:  role.ID,
+	}), nil
 }
 
 // GetDefinition returns the tool definition
@@ -254,35 +228,9 @@ func (t *CreateRoleTool) GetDefinition() types.Tool {
 	return validation.GetToolDefinition("create_role", "Create a new role in a Discord server (guild)")
 }
 
-// formatRole formats a single role for the response
-func (t *CreateRoleTool) formatRole(role *discordgo.Role) map[string]interface{} {
-	return map[string]interface{}{
-		"id":          role.ID,
-		"name":        role.Name,
-		"color":       role.Color,
-		"hoist":       role.Hoist,
-		"position":    role.Position,
-		"permissions": role.Permissions,
-		"managed":     role.Managed,
-		"mentionable": role.Mentionable,
-	}
-}
-
 // formatError creates a standardized error response
 func (t *CreateRoleTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
 
 // DeleteRoleTool implements the delete_role MCP tool
@@ -307,7 +255,7 @@ func (t *DeleteRoleTool) Execute(params types.CallToolParams) (types.CallToolRes
 	roleID := params.Arguments["role_id"].(string)
 
 	// Validate permissions
-	if err := t.handler.permissions.CanManageRoles(guildID); err != nil {
+	if err := t.handler.permissions.CanManageRole(guildID, roleID); err != nil {
 		if permErr, ok := err.(*permissions.PermissionError); ok {
 			return permissions.FormatPermissionError(permErr), nil
 		}
@@ -315,14 +263,22 @@ func (t *DeleteRoleTool) Execute(params types.CallToolParams) (types.CallToolRes
 	}
 
 	// Delete role
-	if err := t.handler.discord.Session().GuildRoleDelete(guildID, roleID); err != nil {
+	retries, err := t.handler.discord.Retry(func() error {
+		return t.handler.discord.Session().GuildRoleDelete(guildID, roleID, auditLogOptions(params.Arguments)...)
+	})
+	if err != nil {
 		return t.formatError("Failed to delete role", err), nil
 	}
 
 	return types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("Deleted role with ID: %s", roleID),
+			Text: i18n.T(i18n.Locale(params), "roles.deleted", roleID),
+			Data: map[string]interface{}{
+				"guild_id": guildID,
+				"role_id":  roleID,
+				"retries":  retries,
+			},
 		}},
 	}, nil
 }
@@ -334,19 +290,7 @@ func (t *DeleteRoleTool) GetDefinition() types.Tool {
 
 // formatError creates a standardized error response
 func (t *DeleteRoleTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
 
 // AssignRoleTool implements the assign_role MCP tool
@@ -372,24 +316,46 @@ func (t *AssignRoleTool) Execute(params types.CallToolParams) (types.CallToolRes
 	userID := params.Arguments["user_id"].(string)
 
 	// Validate permissions
-	if err := t.handler.permissions.CanManageRoles(guildID); err != nil {
+	if err := t.handler.permissions.CanManageRole(guildID, roleID); err != nil {
 		if permErr, ok := err.(*permissions.PermissionError); ok {
 			return permissions.FormatPermissionError(permErr), nil
 		}
 		return t.formatError("Permission check failed", err), nil
 	}
 
+	// Undoing must not remove a role the member already had
+	hadRole := t.handler.discord.Journal() != nil && memberHasRole(t.handler.discord, guildID, userID, roleID)
+
 	// Assign role
-	if err := t.handler.discord.Session().GuildMemberRoleAdd(guildID, userID, roleID); err != nil {
+	retries, err := t.handler.discord.Retry(func() error {
+		return t.handler.discord.Session().GuildMemberRoleAdd(guildID, userID, roleID, auditLogOptions(params.Arguments)...)
+	})
+	if err != nil {
 		return t.formatError("Failed to assign role", err), nil
 	}
 
-	return types.CallToolResult{
+	result := types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("Assigned role %s to user %s", roleID, userID),
+			Text: i18n.T(i18n.Locale(params), "roles.assigned", roleID, userID),
+			Data: map[string]interface{}{
+				"guild_id": guildID,
+				"role_id":  roleID,
+				"user_id":  userID,
+				"retries":  retries,
+			},
 		}},
-	}, nil
+	}
+	if hadRole {
+		return result, nil
+	}
+	return recordAction(t.handler.discord, result, journal.Action{
+		Type:    journal.TypeRoleAssigned,
+		Tool:    "assign_role",
+		GuildID: guildID,
+		RoleID:  roleID,
+		UserID:  userID,
+	}), nil
 }
 
 // GetDefinition returns the tool definition
@@ -399,19 +365,7 @@ func (t *AssignRoleTool) GetDefinition() types.Tool {
 
 // formatError creates a standardized error response
 func (t *AssignRoleTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
 
 // UnassignRoleTool implements the unassign_role MCP tool
@@ -437,24 +391,46 @@ func (t *UnassignRoleTool) Execute(params types.CallToolParams) (types.CallToolR
 	userID := params.Arguments["user_id"].(string)
 
 	// Validate permissions
-	if err := t.handler.permissions.CanManageRoles(guildID); err != nil {
+	if err := t.handler.permissions.CanManageRole(guildID, roleID); err != nil {
 		if permErr, ok := err.(*permissions.PermissionError); ok {
 			return permissions.FormatPermissionError(permErr), nil
 		}
 		return t.formatError("Permission check failed", err), nil
 	}
 
+	// Undoing must not add a role the member did not have
+	hadRole := t.handler.discord.Journal() != nil && memberHasRole(t.handler.discord, guildID, userID, roleID)
+
 	// Unassign role
-	if err := t.handler.discord.Session().GuildMemberRoleRemove(guildID, userID, roleID); err != nil {
+	retries, err := t.handler.discord.Retry(func() error {
+		return t.handler.discord.Session().GuildMemberRoleRemove(guildID, userID, roleID, auditLogOptions(params.Arguments)...)
+	})
+	if err != nil {
 		return t.formatError("Failed to unassign role", err), nil
 	}
 
-	return types.CallToolResult{
+	result := types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("Unassigned role %s from user %s", roleID, userID),
+			Text: i18n.T(i18n.Locale(params), "roles.unassigned", roleID, userID),
+			Data: map[string]interface{}{
+				"guild_id": guildID,
+				"role_id":  roleID,
+				"user_id":  userID,
+				"retries":  retries,
+			},
 		}},
-	}, nil
+	}
+	if !hadRole {
+		return result, nil
+	}
+	return recordAction(t.handler.discord, result, journal.Action{
+		Type:    journal.TypeRoleUnassigned,
+		Tool:    "unassign_role",
+		GuildID: guildID,
+		RoleID:  roleID,
+		UserID:  userID,
+	}), nil
 }
 
 // GetDefinition returns the tool definition
@@ -464,17 +440,5 @@ func (t *UnassignRoleTool) GetDefinition() types.Tool {
 
 // formatError creates a standardized error response
 func (t *UnassignRoleTool) formatError(message string, err error) types.CallToolResult {
-	t.handler.logger.Errorf("%s: %v", message, err)
-	return types.CallToolResult{
-		Content: []types.Content{{
-			Type: "text",
-			Text: fmt.Sprintf("❌ %s: %v", message, err),
-			Data: map[string]interface{}{
-				"error_type": "discord_api",
-				"message":    message,
-				"details":    err.Error(),
-			},
-		}},
-		IsError: true,
-	}
+	return discordErrorResult(t.handler.logger, message, err)
 }
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/pkg/types"
+)
+
+// checkOutbound scans a message before it is sent and returns an error
+// result when the safety settings block it
+func checkOutbound(client *discord.Client, params types.CallToolParams, channelID, content string, embeds []*discordgo.MessageEmbed) *types.CallToolResult {
+	result, blocked := client.CheckOutbound(channelID, content, embeds)
+	if !blocked {
+		return nil
+	}
+	return &types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "safety.blocked", len(result.Findings)),
+			Data: map[string]interface{}{
+				"error_type": "unsafe_content",
+				"channel_id": channelID,
+				"findings":   result.Findings,
+			},
+		}},
+		IsError: true,
+	}
+}
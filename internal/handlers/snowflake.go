@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"time"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/snowflake"
+	"discord-mcp/internal/timezone"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// ParseSnowflakeTool implements the parse_snowflake MCP tool
+type ParseSnowflakeTool struct {
+	validator *validation.Validator
+}
+
+// NewParseSnowflakeTool creates a new parse snowflake tool
+func NewParseSnowflakeTool(validator *validation.Validator) *ParseSnowflakeTool {
+	return &ParseSnowflakeTool{validator: validator}
+}
+
+// Execute executes the parse_snowflake tool
+func (t *ParseSnowflakeTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("parse_snowflake", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	id := params.Arguments["id"].(string)
+
+	parts, err := snowflake.Parse(id)
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+			err.Error(), "id")), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "snowflake.parsed", id, parts.Timestamp.Format(time.RFC3339)),
+			Data: map[string]interface{}{
+				"id":          id,
+				"created_at":  parts.Timestamp.Format(time.RFC3339Nano),
+				"unix_ms":     parts.Timestamp.UnixMilli(),
+				"age_seconds": int64(time.Since(parts.Timestamp).Seconds()),
+				"worker_id":   parts.WorkerID,
+				"process_id":  parts.ProcessID,
+				"increment":   parts.Increment,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ParseSnowflakeTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("parse_snowflake", "Extract the creation timestamp and internal fields from any Discord ID")
+}
+
+// SnowflakeForTimeTool implements the snowflake_for_time MCP tool
+type SnowflakeForTimeTool struct {
+	validator *validation.Validator
+}
+
+// NewSnowflakeForTimeTool creates a new snowflake for time tool
+func NewSnowflakeForTimeTool(validator *validation.Validator) *SnowflakeForTimeTool {
+	return &SnowflakeForTimeTool{validator: validator}
+}
+
+// Execute executes the snowflake_for_time tool
+func (t *SnowflakeForTimeTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("snowflake_for_time", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	timestamp, hasTimestamp := params.Arguments["timestamp"].(string)
+	ago, hasAgo := params.Arguments["ago"].(string)
+	if hasTimestamp == hasAgo {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+			"exactly one of timestamp or ago is required", nil)), nil
+	}
+
+	var at time.Time
+	if hasTimestamp {
+		parsed, err := parseExportTime(timestamp, time.UTC)
+		if err != nil {
+			return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+				"timestamp must be "+timeFormats, "timestamp")), nil
+		}
+		at = parsed
+	} else {
+		duration, err := timezone.ParseDuration(ago)
+		if err != nil {
+			return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+				"ago must be a positive duration such as 90m, 24h, 3d or \"2 hours\"", "ago")), nil
+		}
+		at = time.Now().Add(-duration)
+	}
+
+	if at.UnixMilli() < snowflake.Epoch {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+			"time is before the Discord epoch (2015-01-01)", nil)), nil
+	}
+
+	id := snowflake.At(at)
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "snowflake.boundary", at.UTC().Format(time.RFC3339), id),
+			Data: map[string]interface{}{
+				"id":        id,
+				"timestamp": at.UTC().Format(time.RFC3339Nano),
+				"usage":     "Pass as 'after' to get messages from this time onwards, or as 'before' to get earlier messages",
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *SnowflakeForTimeTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("snowflake_for_time", "Produce a Discord ID boundary for a timestamp, for time-based pagination (e.g. messages since yesterday)")
+}
+
+// messageCursor returns a message ID as it is, and turns a time given as
+// parseExportTime accepts into the ID boundary of that time
+func messageCursor(value string, loc *time.Location) (string, error) {
+	if _, err := snowflake.Parse(value); err == nil {
+		return value, nil
+	}
+	at, err := parseExportTime(value, loc)
+	if err != nil {
+		return "", err
+	}
+	return snowflake.At(at), nil
+}
@@ -0,0 +1,463 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/cdn"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// maxSoundBytes is the largest sound file Discord accepts for the soundboard
+const maxSoundBytes = 512 * 1024
+
+// soundboardSound is a soundboard sound as returned by the API. The API
+// client has no soundboard support.
+type soundboardSound struct {
+	SoundID   string          `json:"sound_id"`
+	Name      string          `json:"name"`
+	Volume    float64         `json:"volume"`
+	EmojiID   *string         `json:"emoji_id"`
+	EmojiName *string         `json:"emoji_name"`
+	GuildID   string          `json:"guild_id"`
+	Available bool            `json:"available"`
+	User      *discordgo.User `json:"user"`
+}
+
+// guildSoundsEndpoint returns the endpoint of a guild's soundboard sounds
+func guildSoundsEndpoint(guildID string) string {
+	return discordgo.EndpointGuild(guildID) + "/soundboard-sounds"
+}
+
+// ListSoundboardSoundsTool implements the list_soundboard_sounds MCP tool
+type ListSoundboardSoundsTool struct {
+	handler *GuildHandler
+}
+
+// NewListSoundboardSoundsTool creates a new list soundboard sounds tool
+func NewListSoundboardSoundsTool(handler *GuildHandler) *ListSoundboardSoundsTool {
+	return &ListSoundboardSoundsTool{handler: handler}
+}
+
+// Execute executes the list_soundboard_sounds tool
+func (t *ListSoundboardSoundsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_soundboard_sounds", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	includeDefaults, _ := params.Arguments["include_defaults"].(bool)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	var listed struct {
+		Items []soundboardSound `json:"items"`
+	}
+	endpoint := guildSoundsEndpoint(guildID)
+	_, err := t.handler.discord.Retry(func() error {
+		body, err := t.handler.discord.Session().RequestWithBucketID("GET", endpoint, nil, endpoint)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &listed)
+	})
+	if err != nil {
+		return t.formatError("Failed to list soundboard sounds", err), nil
+	}
+
+	cdnBuilder := t.handler.discord.CDN()
+	sounds := make([]map[string]interface{}, 0, len(listed.Items))
+	for _, sound := range listed.Items {
+		sounds = append(sounds, formatSound(sound, cdnBuilder))
+	}
+	data := map[string]interface{}{
+		"guild_id":    guildID,
+		"sound_count": len(sounds),
+		"sounds":      sounds,
+	}
+
+	// Discord's built-in sounds can be played in every guild
+	if includeDefaults {
+		var defaults []soundboardSound
+		endpoint := discordgo.EndpointAPI + "soundboard-default-sounds"
+		_, err := t.handler.discord.Retry(func() error {
+			body, err := t.handler.discord.Session().RequestWithBucketID("GET", endpoint, nil, endpoint)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(body, &defaults)
+		})
+		if err != nil {
+			return t.formatError("Failed to list default soundboard sounds", err), nil
+		}
+		formatted := make([]map[string]interface{}, 0, len(defaults))
+		for _, sound := range defaults {
+			formatted = append(formatted, formatSound(sound, cdnBuilder))
+		}
+		data["default_sounds"] = formatted
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "soundboard.list", len(sounds), guildID),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListSoundboardSoundsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_soundboard_sounds", "List a Discord server's soundboard sounds with their volume, emoji and audio URL, optionally with Discord's default sounds")
+}
+
+// formatError creates a standardized error response
+func (t *ListSoundboardSoundsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// UploadSoundboardSoundTool implements the upload_soundboard_sound MCP tool
+type UploadSoundboardSoundTool struct {
+	handler *GuildHandler
+}
+
+// NewUploadSoundboardSoundTool creates a new upload soundboard sound tool
+func NewUploadSoundboardSoundTool(handler *GuildHandler) *UploadSoundboardSoundTool {
+	return &UploadSoundboardSoundTool{handler: handler}
+}
+
+// Execute executes the upload_soundboard_sound tool
+func (t *UploadSoundboardSoundTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("upload_soundboard_sound", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	name := params.Arguments["name"].(string)
+
+	audio, err := base64.StdEncoding.DecodeString(params.Arguments["sound_base64"].(string))
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+			"sound_base64 is not valid base64", "sound_base64")), nil
+	}
+	if len(audio) > maxSoundBytes {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+			fmt.Sprintf("sound exceeds %d bytes", maxSoundBytes), "sound_base64")), nil
+	}
+	mimeType := soundMimeType(audio)
+	if mimeType == "" {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+			"sound must be an MP3 or Ogg file", "sound_base64")), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanCreateGuildExpressions(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	request := map[string]interface{}{
+		"name":  name,
+		"sound": fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(audio)),
+	}
+	if volume, ok := params.Arguments["volume"].(float64); ok {
+		request["volume"] = volume
+	}
+	if emoji, ok := params.Arguments["emoji"].(string); ok && emoji != "" {
+		resolved, err := resolveReactionEmoji(t.handler.discord, emoji)
+		if err != nil {
+			return validation.FormatValidationError(err), nil
+		}
+		if resolved.ID != "" {
+			request["emoji_id"] = resolved.ID
+		} else {
+			request["emoji_name"] = resolved.Name
+		}
+	}
+
+	var sound soundboardSound
+	endpoint := guildSoundsEndpoint(guildID)
+	retries, err := t.handler.discord.Retry(func() error {
+		body, err := t.handler.discord.Session().RequestWithBucketID("POST", endpoint, request, endpoint, auditLogOptions(params.Arguments)...)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &sound)
+	})
+	if err != nil {
+		return t.formatError("Failed to upload soundboard sound", err), nil
+	}
+
+	t.handler.logger.Infof("Uploaded soundboard sound %s (%s) to guild %s", sound.SoundID, sound.Name, guildID)
+
+	data := formatSound(sound, t.handler.discord.CDN())
+	data["retries"] = retries
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "soundboard.uploaded", sound.Name, sound.SoundID),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *UploadSoundboardSoundTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("upload_soundboard_sound", "Add a sound to a Discord server's soundboard from a base64 MP3 or Ogg file of up to 512 KB")
+}
+
+// formatError creates a standardized error response
+func (t *UploadSoundboardSoundTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// DeleteSoundboardSoundTool implements the delete_soundboard_sound MCP tool
+type DeleteSoundboardSoundTool struct {
+	handler *GuildHandler
+}
+
+// NewDeleteSoundboardSoundTool creates a new delete soundboard sound tool
+func NewDeleteSoundboardSoundTool(handler *GuildHandler) *DeleteSoundboardSoundTool {
+	return &DeleteSoundboardSoundTool{handler: handler}
+}
+
+// Execute executes the delete_soundboard_sound tool
+func (t *DeleteSoundboardSoundTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("delete_soundboard_sound", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	soundID := params.Arguments["sound_id"].(string)
+
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	var sound soundboardSound
+	endpoint := guildSoundsEndpoint(guildID) + "/" + soundID
+	_, err := t.handler.discord.Retry(func() error {
+		body, err := t.handler.discord.Session().RequestWithBucketID("GET", endpoint, nil, endpoint)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &sound)
+	})
+	if err != nil {
+		return t.formatError("Failed to get soundboard sound", err), nil
+	}
+
+	// The bot's own sounds need Create Expressions; others need Manage
+	// Expressions
+	if bot, err := t.handler.discord.GetBotUser(); err == nil && sound.User != nil && sound.User.ID == bot.ID {
+		err = t.handler.permissions.CanCreateGuildExpressions(guildID)
+	} else {
+		err = t.handler.permissions.CanManageGuildExpressions(guildID)
+	}
+	if err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	retries, err := t.handler.discord.Retry(func() error {
+		_, err := t.handler.discord.Session().RequestWithBucketID("DELETE", endpoint, nil, guildSoundsEndpoint(guildID), auditLogOptions(params.Arguments)...)
+		return err
+	})
+	if err != nil {
+		return t.formatError("Failed to delete soundboard sound", err), nil
+	}
+
+	t.handler.logger.Infof("Deleted soundboard sound %s (%s) from guild %s", soundID, sound.Name, guildID)
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "soundboard.deleted", sound.Name, soundID),
+			Data: map[string]interface{}{
+				"guild_id": guildID,
+				"sound_id": soundID,
+				"name":     sound.Name,
+				"deleted":  true,
+				"retries":  retries,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *DeleteSoundboardSoundTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("delete_soundboard_sound", "Delete a sound from a Discord server's soundboard")
+}
+
+// formatError creates a standardized error response
+func (t *DeleteSoundboardSoundTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// PlaySoundboardSoundTool implements the play_soundboard_sound MCP tool
+type PlaySoundboardSoundTool struct {
+	handler *VoiceHandler
+}
+
+// NewPlaySoundboardSoundTool creates a new play soundboard sound tool
+func NewPlaySoundboardSoundTool(handler *VoiceHandler) *PlaySoundboardSoundTool {
+	return &PlaySoundboardSoundTool{handler: handler}
+}
+
+// Execute executes the play_soundboard_sound tool
+func (t *PlaySoundboardSoundTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("play_soundboard_sound", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	soundID := params.Arguments["sound_id"].(string)
+	sourceGuildID, _ := params.Arguments["source_guild_id"].(string)
+	external := sourceGuildID != "" && sourceGuildID != guildID
+
+	voice := t.handler.discord.Voice()
+
+	// Join the requested channel first, if any; sounds play where the bot is
+	channelID, connected := voice.ChannelID(guildID)
+	if requested, ok := params.Arguments["channel_id"].(string); ok && (!connected || requested != channelID) {
+		channel, err := t.handler.discord.GetChannel(requested)
+		if err != nil {
+			return t.handler.formatError("Failed to get channel", err), nil
+		}
+		if channel.GuildID != guildID {
+			return validation.FormatValidationError(validation.NewValidationError("invalid channel",
+				fmt.Sprintf("channel %s is not in guild %s", requested, guildID), "channel_id")), nil
+		}
+		if channel.Type != discordgo.ChannelTypeGuildVoice && channel.Type != discordgo.ChannelTypeGuildStageVoice {
+			return validation.FormatValidationError(validation.NewValidationError("invalid channel",
+				fmt.Sprintf("channel %s is not a voice channel", requested), "channel_id")), nil
+		}
+		if err := t.handler.permissions.CanUseVoice(requested); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return t.handler.formatError("Permission check failed", err), nil
+		}
+		if err := voice.Join(guildID, requested); err != nil {
+			return t.handler.formatError("Failed to join voice channel", err), nil
+		}
+		channelID, connected = requested, true
+	}
+	if !connected {
+		return validation.FormatValidationError(validation.NewValidationError("not in voice",
+			fmt.Sprintf("the bot is not in a voice channel in guild %s; pass channel_id or use join_voice_channel first", guildID), "channel_id")), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanUseSoundboard(channelID, external); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.handler.formatError("Permission check failed", err), nil
+	}
+
+	// Discord refuses soundboard sounds from deafened users, and the bot
+	// joins voice self-deafened
+	if err := voice.Undeafen(guildID); err != nil {
+		return t.handler.formatError("Failed to undeafen", err), nil
+	}
+
+	request := map[string]interface{}{"sound_id": soundID}
+	if sourceGuildID != "" {
+		request["source_guild_id"] = sourceGuildID
+	}
+	endpoint := discordgo.EndpointChannel(channelID) + "/send-soundboard-sound"
+	retries, err := t.handler.discord.Retry(func() error {
+		_, err := t.handler.discord.Session().RequestWithBucketID("POST", endpoint, request, endpoint)
+		return err
+	})
+	if err != nil {
+		return t.handler.formatError("Failed to play soundboard sound", err), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "soundboard.played", soundID, channelID),
+			Data: map[string]interface{}{
+				"guild_id":        guildID,
+				"channel_id":      channelID,
+				"sound_id":        soundID,
+				"source_guild_id": sourceGuildID,
+				"retries":         retries,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *PlaySoundboardSoundTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("play_soundboard_sound", "Play a soundboard sound in the bot's voice channel, joining channel_id first if given")
+}
+
+// formatSound formats a soundboard sound with its audio URL
+func formatSound(sound soundboardSound, cdnBuilder *cdn.Builder) map[string]interface{} {
+	formatted := map[string]interface{}{
+		"sound_id":  sound.SoundID,
+		"name":      sound.Name,
+		"volume":    sound.Volume,
+		"available": sound.Available,
+		"url":       cdnBuilder.SoundboardSound(sound.SoundID),
+	}
+	if sound.GuildID != "" {
+		formatted["guild_id"] = sound.GuildID
+	}
+	if sound.EmojiID != nil {
+		formatted["emoji_id"] = *sound.EmojiID
+	}
+	if sound.EmojiName != nil {
+		formatted["emoji_name"] = *sound.EmojiName
+	}
+	if sound.User != nil {
+		formatted["uploaded_by"] = map[string]interface{}{
+			"id":       sound.User.ID,
+			"username": sound.User.Username,
+		}
+	}
+	return formatted
+}
+
+// soundMimeType returns the MIME type of an MP3 or Ogg file, or "" for
+// other data
+func soundMimeType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("OggS")):
+		return "audio/ogg"
+	case bytes.HasPrefix(data, []byte("ID3")):
+		return "audio/mpeg"
+	case len(data) > 1 && data[0] == 0xFF && data[1]&0xE0 == 0xE0:
+		// An MP3 frame without an ID3 tag starts with the frame sync bits
+		return "audio/mpeg"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/jsonfile"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/starboard"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// ConfigureStarboardTool implements the configure_starboard MCP tool
+type ConfigureStarboardTool struct {
+	handler *MessageHandler
+}
+
+// NewConfigureStarboardTool creates a new configure starboard tool
+func NewConfigureStarboardTool(handler *MessageHandler) *ConfigureStarboardTool {
+	return &ConfigureStarboardTool{handler: handler}
+}
+
+// Execute executes the configure_starboard tool
+func (t *ConfigureStarboardTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("configure_starboard", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Starboard()
+	if store == nil {
+		return starboardDisabledResult(params), nil
+	}
+
+	board := starboard.Board{
+		Threshold: intArgument(params.Arguments, "threshold", 3),
+		Enabled:   true,
+	}
+	board.GuildID = params.Arguments["guild_id"].(string)
+	board.ChannelID = params.Arguments["channel_id"].(string)
+	if val, ok := params.Arguments["enabled"].(bool); ok {
+		board.Enabled = val
+	}
+
+	emojiInput := "⭐"
+	if input, ok := params.Arguments["emoji"].(string); ok {
+		emojiInput = input
+	}
+	resolved, err := resolveReactionEmoji(t.handler.discord, emojiInput)
+	if err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+	board.Emoji = resolved.MessageFormat()
+	board.EmojiAPIName = resolved.APIName()
+
+	// Validate permissions
+	if err := t.checkPermissions(board); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		if validationErr, ok := err.(*validation.ValidationError); ok {
+			return validation.FormatValidationError(validationErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	saved, created, err := store.SetBoard(board)
+	if errors.Is(err, jsonfile.ErrSave) {
+		return t.formatError("Failed to save starboard", err), nil
+	}
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid starboard", err.Error(), nil)), nil
+	}
+
+	key := "starboard.updated"
+	if created {
+		key = "starboard.created"
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), key, saved.GuildID, saved.ChannelID, saved.Threshold, saved.Emoji),
+			Data: saved,
+		}},
+	}, nil
+}
+
+// checkPermissions checks that the starboard channel belongs to the guild
+// and that the bot can post and edit reposts there
+func (t *ConfigureStarboardTool) checkPermissions(board starboard.Board) error {
+	channel, err := t.handler.discord.GetChannel(board.ChannelID)
+	if err != nil {
+		return err
+	}
+	if channel.GuildID != board.GuildID {
+		return validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s is not in guild %s", board.ChannelID, board.GuildID), "channel_id")
+	}
+	return t.handler.permissions.ValidateMessageOperation("send_message", board.ChannelID, map[string]interface{}{"tts": false})
+}
+
+// GetDefinition returns the tool definition
+func (t *ConfigureStarboardTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("configure_starboard", "Set a guild's starboard: messages reaching a number of reactions of an emoji are reposted to a channel with attribution and a link back")
+}
+
+// formatError creates a standardized error response
+func (t *ConfigureStarboardTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListStarboardEntriesTool implements the list_starboard_entries MCP tool
+type ListStarboardEntriesTool struct {
+	handler *MessageHandler
+}
+
+// NewListStarboardEntriesTool creates a new list starboard entries tool
+func NewListStarboardEntriesTool(handler *MessageHandler) *ListStarboardEntriesTool {
+	return &ListStarboardEntriesTool{handler: handler}
+}
+
+// Execute executes the list_starboard_entries tool
+func (t *ListStarboardEntriesTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_starboard_entries", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Starboard()
+	if store == nil {
+		return starboardDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	limit := intArgument(params.Arguments, "limit", 25)
+
+	board, ok := store.Board(guildID)
+	if !ok {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "starboard.not_configured", guildID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"guild_id":   guildID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	entries := store.Entries(guildID)
+	total := len(entries)
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "starboard.list", total, guildID),
+			Data: map[string]interface{}{
+				"starboard":   board,
+				"entry_count": total,
+				"entries":     entries,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListStarboardEntriesTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_starboard_entries", "List a guild's starboard settings and the messages reposted to it, most starred first")
+}
+
+// starboardDisabledResult reports that the starboard is turned off in the
+// configuration
+func starboardDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "starboard.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "starboard disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"time"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/notifications"
+	"discord-mcp/internal/stats"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// StatsSource reports the MCP server's tool call and notification totals
+type StatsSource interface {
+	ToolStats() ([]stats.ToolStats, stats.ToolStats)
+	NotificationStats() notifications.Stats
+}
+
+// GetServerStatsTool implements the get_server_stats MCP tool
+type GetServerStatsTool struct {
+	handler *BotHandler
+	source  StatsSource
+}
+
+// NewGetServerStatsTool creates a new get server stats tool. source is
+// usually the MCP server the tool is registered with.
+func NewGetServerStatsTool(handler *BotHandler, source StatsSource) *GetServerStatsTool {
+	return &GetServerStatsTool{handler: handler, source: source}
+}
+
+// Execute executes the get_server_stats tool
+func (t *GetServerStatsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_server_stats", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	client := t.handler.discord
+	uptime := client.Uptime()
+	tools, total := t.source.ToolStats()
+	notified := t.source.NotificationStats()
+	rateLimits := client.RateLimitStats()
+
+	data := map[string]interface{}{
+		"uptime_seconds": int(uptime.Seconds()),
+		"tool_calls": map[string]interface{}{
+			"total":          total.Calls,
+			"errors":         total.Errors,
+			"error_rate":     total.ErrorRate,
+			"avg_latency_ms": total.AvgLatencyMs,
+			"max_latency_ms": total.MaxLatencyMs,
+			"by_tool":        tools,
+		},
+		"rate_limits":   rateLimits,
+		"notifications": notified,
+		"cache":         client.Cache().Stats(),
+	}
+	if queue := client.Outbound(); queue != nil {
+		data["outbound_queue"] = queue.Stats()
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "stats.summary",
+				total.Calls, len(tools), total.Errors, uptime.Truncate(time.Second), notified.Total, rateLimits.DiscordWaits),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetServerStatsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_server_stats", "Show what the agent has done since startup: calls, error rate and latency per tool, rate-limit waits, notifications sent and cache hit rates")
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"sync"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/notifications"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// resultStream sends the items of a large result as discord/partialResult
+// notifications while a tool is still collecting them. The notifications
+// carry the call's progress token so clients can match them to the call.
+type resultStream struct {
+	notifications *notifications.Service
+	token         interface{}
+	tool          string
+	chunks        int
+	items         int
+	mutex         sync.Mutex
+}
+
+// newResultStream returns the stream a call asked for with stream: true, or
+// nil when it did not. A call without a progress token to match the chunks
+// to gets a validation error result instead.
+func newResultStream(client *discord.Client, tool string, params types.CallToolParams) (*resultStream, *types.CallToolResult) {
+	if stream, _ := params.Arguments["stream"].(bool); !stream {
+		return nil, nil
+	}
+	if params.Meta == nil || params.Meta.ProgressToken == nil || client.Notifications() == nil {
+		result := validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+			"stream requires a _meta.progressToken to tie partial results to the call", "stream"))
+		return nil, &result
+	}
+	return &resultStream{
+		notifications: client.Notifications(),
+		token:         params.Meta.ProgressToken,
+		tool:          tool,
+	}, nil
+}
+
+// send streams a chunk of count items; empty chunks are skipped
+func (s *resultStream) send(items interface{}, count int) {
+	if count == 0 {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.chunks++
+	s.items += count
+	s.notifications.SendPartialResult(s.token, s.tool, s.chunks, count, items)
+}
+
+// summary reports what was streamed, for the final result
+func (s *resultStream) summary() map[string]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return map[string]interface{}{
+		"chunks": s.chunks,
+		"items":  s.items,
+	}
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"fmt"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/journal"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/structure"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// ExportGuildStructureTool implements the export_guild_structure MCP tool
+type ExportGuildStructureTool struct {
+	handler *GuildHandler
+}
+
+// NewExportGuildStructureTool creates a new export guild structure tool
+func NewExportGuildStructureTool(handler *GuildHandler) *ExportGuildStructureTool {
+	return &ExportGuildStructureTool{handler: handler}
+}
+
+// Execute executes the export_guild_structure tool
+func (t *ExportGuildStructureTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("export_guild_structure", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	layout, err := guildLayout(t.handler, guildID)
+	if err != nil {
+		return t.formatError("Failed to read guild structure", err), nil
+	}
+	encoded, err := structure.Marshal(layout)
+	if err != nil {
+		return t.formatError("Failed to encode guild structure", err), nil
+	}
+
+	channelCount := len(layout.Channels)
+	for _, category := range layout.Categories {
+		channelCount += len(category.Channels)
+	}
+
+	uri := fmt.Sprintf("discord://guilds/%s/structure.yaml", guildID)
+	return types.CallToolResult{
+		Content: []types.Content{
+			{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "structure.exported", len(layout.Roles), len(layout.Categories), channelCount, guildID),
+				Data: map[string]interface{}{
+					"guild_id":       guildID,
+					"role_count":     len(layout.Roles),
+					"category_count": len(layout.Categories),
+					"channel_count":  channelCount,
+					"uri":            uri,
+					"structure":      string(encoded),
+				},
+			},
+			{
+				Type: "resource",
+				Resource: &types.ResourceContents{
+					URI:      uri,
+					MimeType: "application/yaml",
+					Text:     string(encoded),
+				},
+			},
+		},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ExportGuildStructureTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("export_guild_structure", "Export a server's roles, categories, channels and permission overwrites as YAML for apply_guild_structure")
+}
+
+// formatError creates a standardized error response
+func (t *ExportGuildStructureTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ApplyGuildStructureTool implements the apply_guild_structure MCP tool
+type ApplyGuildStructureTool struct {
+	handler *GuildHandler
+}
+
+// NewApplyGuildStructureTool creates a new apply guild structure tool
+func NewApplyGuildStructureTool(handler *GuildHandler) *ApplyGuildStructureTool {
+	return &ApplyGuildStructureTool{handler: handler}
+}
+
+// Execute executes the apply_guild_structure tool
+func (t *ApplyGuildStructureTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("apply_guild_structure", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	dryRun := true
+	if val, ok := params.Arguments["dry_run"].(bool); ok {
+		dryRun = val
+	}
+	prune, _ := params.Arguments["prune"].(bool)
+
+	desired, err := structure.Parse([]byte(params.Arguments["structure"].(string)))
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid structure",
+			err.Error(), "structure")), nil
+	}
+
+	// Validate permissions
+	for _, check := range []func(string) error{t.handler.permissions.CanManageRoles, t.handler.permissions.CanManageChannels} {
+		if err := check(guildID); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return t.formatError("Permission check failed", err), nil
+		}
+	}
+
+	roles, err := t.handler.discord.GetRoles(guildID)
+	if err != nil {
+		return t.formatError("Failed to read guild roles", err), nil
+	}
+	channels, err := t.handler.discord.GetChannels(guildID)
+	if err != nil {
+		return t.formatError("Failed to read guild channels", err), nil
+	}
+
+	plan, err := structure.NewPlan(guildID, desired, roles, channels, prune)
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid structure",
+			err.Error(), "structure")), nil
+	}
+
+	// Roles above the bot's highest role cannot be edited or deleted
+	var blocked []*permissions.PermissionError
+	for _, change := range plan.Changes {
+		if change.Kind != structure.KindRole || change.ID == "" || change.ID == guildID {
+			continue
+		}
+		if err := t.handler.permissions.CanManageRole(guildID, change.ID); err != nil {
+			permErr, ok := err.(*permissions.PermissionError)
+			if !ok {
+				return t.formatError("Permission check failed", err), nil
+			}
+			blocked = append(blocked, permErr)
+		}
+	}
+
+	data := map[string]interface{}{
+		"guild_id":     guildID,
+		"dry_run":      dryRun,
+		"prune":        prune,
+		"change_count": len(plan.Changes),
+		"changes":      plan.Changes,
+	}
+	locale := i18n.Locale(params)
+
+	if dryRun || len(plan.Changes) == 0 {
+		text := i18n.T(locale, "structure.planned", len(plan.Changes), guildID)
+		if len(plan.Changes) == 0 {
+			text = i18n.T(locale, "structure.unchanged", guildID)
+		}
+		if len(blocked) > 0 {
+			problems := make([]string, len(blocked))
+			for i, permErr := range blocked {
+				problems[i] = permErr.Description
+			}
+			data["blocked"] = problems
+		}
+		return types.CallToolResult{
+			Content: []types.Content{{Type: "text", Text: text, Data: data}},
+		}, nil
+	}
+	if len(blocked) > 0 {
+		return permissions.FormatPermissionError(blocked[0]), nil
+	}
+
+	// Channels in state are updated in place, so settings are captured
+	// before the changes for undo
+	before := make(map[string]*journal.ChannelState, len(channels))
+	for _, channel := range channels {
+		before[channel.ID] = journal.SnapshotChannel(channel)
+	}
+
+	applied, err := plan.Apply(t.handler.discord.Session(), func(fn func() error) error {
+		_, err := t.handler.discord.Retry(fn)
+		return err
+	}, auditLogOptions(params.Arguments)...)
+	actionIDs := t.recordChannelEdits(guildID, before, applied)
+	if err != nil {
+		result := t.formatError(fmt.Sprintf("Failed after applying %d of %d changes", len(applied), len(plan.Changes)), err)
+		if errData, ok := result.Content[0].Data.(map[string]interface{}); ok {
+			errData["applied"] = applied
+			if actionIDs != nil {
+				errData["action_ids"] = actionIDs
+			}
+		}
+		return result, nil
+	}
+
+	data["changes"] = applied
+	if actionIDs != nil {
+		data["action_ids"] = actionIDs
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(locale, "structure.applied", len(applied), guildID),
+			Data: data,
+		}},
+	}, nil
+}
+
+// recordChannelEdits journals each applied channel and category update with
+// the channel's settings from before, so it can be undone. It returns the
+// action IDs, or nil when undo is disabled.
+func (t *ApplyGuildStructureTool) recordChannelEdits(guildID string, before map[string]*journal.ChannelState, applied []structure.Change) []string {
+	actions := t.handler.discord.Journal()
+	if actions == nil {
+		return nil
+	}
+
+	ids := []string{}
+	for _, change := range applied {
+		state, ok := before[change.ID]
+		if change.Action != structure.ActionUpdate || change.Kind == structure.KindRole || !ok {
+			continue
+		}
+		recorded := actions.Record(journal.Action{
+			Type:      journal.TypeChannelEdited,
+			Tool:      "apply_guild_structure",
+			GuildID:   guildID,
+			ChannelID: change.ID,
+			Fields:    change.Fields,
+			Before:    &journal.Before{Channel: state},
+		})
+		ids = append(ids, recorded.ID)
+	}
+	return ids
+}
+
+// GetDefinition returns the tool definition
+func (t *ApplyGuildStructureTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("apply_guild_structure", "Plan, and unless dry_run, apply the changes that make a server's roles and channels match a YAML structure")
+}
+
+// formatError creates a standardized error response
+func (t *ApplyGuildStructureTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// guildLayout reads a guild's roles and channels as a layout
+func guildLayout(handler *GuildHandler, guildID string) (*structure.Layout, error) {
+	roles, err := handler.discord.GetRoles(guildID)
+	if err != nil {
+		return nil, err
+	}
+	channels, err := handler.discord.GetChannels(guildID)
+	if err != nil {
+		return nil, err
+	}
+	return structure.FromGuild(guildID, roles, channels), nil
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/summarize"
+	"discord-mcp/pkg/types"
+)
+
+// summaryOptions holds the summarize arguments of a tool call
+type summaryOptions struct {
+	requested    bool
+	instructions string
+	only         bool
+}
+
+// summaryArguments reads the summarize, summary_instructions and
+// summary_only arguments
+func summaryArguments(args map[string]interface{}) summaryOptions {
+	var opts summaryOptions
+	opts.requested, _ = args["summarize"].(bool)
+	opts.instructions, _ = args["summary_instructions"].(string)
+	opts.only, _ = args["summary_only"].(bool)
+	return opts
+}
+
+// summaryLines renders messages, oldest first, as "[time] author: content"
+// lines, leaving out system messages and empty ones
+func summaryLines(messages []*discordgo.Message) []string {
+	lines := make([]string, 0, len(messages))
+	for _, msg := range messages {
+		if isTranscriptNoise(msg) {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("[%s] %s: %s", msg.Timestamp.UTC().Format("2006-01-02 15:04"), authorName(msg), transcriptContent(msg)))
+	}
+	return lines
+}
+
+// addSummary sends transcript lines, oldest first, to the configured
+// summarizer and records the summary in data. A failed summary is recorded
+// as summary_error so the raw data is still returned. It returns the
+// summary, or "" if there is none.
+func addSummary(client *discord.Client, logger *logrus.Logger, data map[string]interface{}, source string, lines []string, opts summaryOptions) string {
+	cfg := client.Config().Summarizer
+	if opts.instructions == "" {
+		opts.instructions = cfg.Instructions
+	}
+	text, dropped := summarize.Fit(lines, cfg.MaxInputChars)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.TimeoutSeconds)*time.Second)
+	defer cancel()
+
+	started := time.Now()
+	summary, err := client.Summarizer().Summarize(ctx, summarize.Request{
+		Text:         text,
+		Instructions: opts.instructions,
+		Source:       source,
+		MessageCount: len(lines) - dropped,
+	})
+	if err != nil {
+		logger.Warnf("Failed to summarize %s: %v", source, err)
+		data["summary_error"] = err.Error()
+		return ""
+	}
+
+	data["summary"] = summary
+	data["summary_input_lines"] = len(lines) - dropped
+	data["summary_dropped_lines"] = dropped
+	data["summary_ms"] = time.Since(started).Milliseconds()
+	return summary
+}
+
+// summarizerDisabledResult reports that summarize was requested without a
+// summarizer configured
+func summarizerDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "summarizer.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "no summarizer configured",
+			},
+		}},
+		IsError: true,
+	}
+}
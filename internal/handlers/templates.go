@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/journal"
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/templates"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// TemplateHandler handles message template operations
+type TemplateHandler struct {
+	discord     *discord.Client
+	permissions *permissions.Checker
+	validator   *validation.Validator
+	logger      *logrus.Logger
+	store       *templates.Store
+}
+
+// NewTemplateHandler creates a new template handler reading templates from
+// the configured directory
+func NewTemplateHandler(discordClient *discord.Client, permChecker *permissions.Checker, validator *validation.Validator, logger *logrus.Logger) *TemplateHandler {
+	return &TemplateHandler{
+		discord:     discordClient,
+		permissions: permChecker,
+		validator:   validator,
+		logger:      logger,
+		store:       templates.NewStore(discordClient.Config().Templates.Directory),
+	}
+}
+
+// formatError creates a standardized error response
+func (h *TemplateHandler) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(h.logger, message, err)
+}
+
+// render loads a template and substitutes the variables argument. A
+// non-nil result is an error response for the caller to return.
+func (h *TemplateHandler) render(args map[string]interface{}) (*templates.Rendered, *types.CallToolResult) {
+	name := args["template"].(string)
+
+	values, errResult := templateValues(args)
+	if errResult != nil {
+		return nil, errResult
+	}
+
+	tmpl, err := h.store.Get(name)
+	if err != nil {
+		result := validation.FormatValidationError(validation.NewValidationError("invalid parameter", err.Error(), "template"))
+		return nil, &result
+	}
+
+	rendered, err := tmpl.Render(values)
+	if err != nil {
+		result := validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+			fmt.Sprintf("template %q: %v", name, err), "variables"))
+		return nil, &result
+	}
+	if length := utf8.RuneCountInString(rendered.Content); length > maxContentLength {
+		result := validation.FormatValidationError(validation.NewValidationError("length constraint",
+			fmt.Sprintf("rendered content must be at most %d characters, got %d", maxContentLength, length), "variables"))
+		return nil, &result
+	}
+	return rendered, nil
+}
+
+// templateValues converts the variables argument to template values. A
+// non-nil result is an error response for the caller to return.
+func templateValues(args map[string]interface{}) (map[string]string, *types.CallToolResult) {
+	values := make(map[string]string)
+	if raw, ok := args["variables"].(map[string]interface{}); ok {
+		for key, val := range raw {
+			switch v := val.(type) {
+			case string:
+				values[key] = v
+			case float64:
+				values[key] = strconv.FormatFloat(v, 'f', -1, 64)
+			case bool:
+				values[key] = strconv.FormatBool(v)
+			default:
+				result := validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+					fmt.Sprintf("variable '%s' must be a string, number or boolean", key), "variables"))
+				return nil, &result
+			}
+		}
+	}
+	return values, nil
+}
+
+// ListTemplatesTool implements the list_templates MCP tool
+type ListTemplatesTool struct {
+	handler *TemplateHandler
+}
+
+// NewListTemplatesTool creates a new list templates tool
+func NewListTemplatesTool(handler *TemplateHandler) *ListTemplatesTool {
+	return &ListTemplatesTool{handler: handler}
+}
+
+// Execute executes the list_templates tool
+func (t *ListTemplatesTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_templates", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	loaded, errs := t.handler.store.Load()
+
+	templateList := make([]map[string]interface{}, 0, len(loaded))
+	for _, tmpl := range loaded {
+		declared := make(map[string]bool)
+		variables := make([]map[string]interface{}, 0, len(tmpl.Variables))
+		for _, v := range tmpl.Variables {
+			declared[v.Name] = true
+			variable := map[string]interface{}{
+				"name":     v.Name,
+				"required": v.Default == nil,
+			}
+			if v.Description != "" {
+				variable["description"] = v.Description
+			}
+			if v.Default != nil {
+				variable["default"] = *v.Default
+			}
+			variables = append(variables, variable)
+		}
+		// Placeholders used without a declaration are still required
+		for _, name := range tmpl.Placeholders() {
+			if !declared[name] {
+				variables = append(variables, map[string]interface{}{
+					"name":     name,
+					"required": true,
+				})
+			}
+		}
+
+		templateList = append(templateList, map[string]interface{}{
+			"name":        tmpl.Name,
+			"description": tmpl.Description,
+			"file":        tmpl.File,
+			"variables":   variables,
+			"has_content": tmpl.Content != "",
+			"embed_count": len(tmpl.Embeds),
+		})
+	}
+
+	loadErrors := make([]string, len(errs))
+	for i, err := range errs {
+		loadErrors[i] = err.Error()
+	}
+
+	text := i18n.T(i18n.Locale(params), "templates.list", len(templateList))
+	if len(loadErrors) > 0 {
+		text = i18n.T(i18n.Locale(params), "templates.list_failures", len(templateList), len(loadErrors))
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: map[string]interface{}{
+				"templates":   templateList,
+				"count":       len(templateList),
+				"load_errors": loadErrors,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListTemplatesTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_templates", "List the message templates available in the template directory and the variables they take")
+}
+
+// RenderTemplateTool implements the render_template MCP tool
+type RenderTemplateTool struct {
+	handler *TemplateHandler
+}
+
+// NewRenderTemplateTool creates a new render template tool
+func NewRenderTemplateTool(handler *TemplateHandler) *RenderTemplateTool {
+	return &RenderTemplateTool{handler: handler}
+}
+
+// Execute executes the render_template tool
+func (t *RenderTemplateTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("render_template", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	rendered, errResult := t.handler.render(params.Arguments)
+	if errResult != nil {
+		return *errResult, nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "templates.rendered", params.Arguments["template"].(string)),
+			Data: map[string]interface{}{
+				"template": params.Arguments["template"].(string),
+				"content":  rendered.Content,
+				"embeds":   rendered.Embeds,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *RenderTemplateTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("render_template", "Preview a message template with variables substituted, without sending it")
+}
+
+// SendTemplatedMessageTool implements the send_templated_message MCP tool
+type SendTemplatedMessageTool struct {
+	handler *TemplateHandler
+}
+
+// NewSendTemplatedMessageTool creates a new send templated message tool
+func NewSendTemplatedMessageTool(handler *TemplateHandler) *SendTemplatedMessageTool {
+	return &SendTemplatedMessageTool{handler: handler}
+}
+
+// Execute executes the send_templated_message tool
+func (t *SendTemplatedMessageTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("send_templated_message", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	channelID := params.Arguments["channel_id"].(string)
+	replyTo, _ := params.Arguments["reply_to"].(string)
+
+	rendered, errResult := t.handler.render(params.Arguments)
+	if errResult != nil {
+		return *errResult, nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.ValidateMessageOperation("send_message", channelID, map[string]interface{}{"tts": false}); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.handler.formatError("Permission check failed", err), nil
+	}
+
+	if blocked := checkOutbound(t.handler.discord, params, channelID, rendered.Content, rendered.Embeds); blocked != nil {
+		return *blocked, nil
+	}
+
+	msgData := &discordgo.MessageSend{
+		Content: rendered.Content,
+		Embeds:  rendered.Embeds,
+	}
+	if replyTo != "" {
+		msgData.Reference = &discordgo.MessageReference{
+			MessageID: replyTo,
+			ChannelID: channelID,
+		}
+	}
+
+	var message *discordgo.Message
+	retries, waited, err := t.handler.discord.SendQueued(channelID, outbound.PriorityInteractive, func() (err error) {
+		message, err = t.handler.discord.Session().ChannelMessageSendComplex(channelID, msgData)
+		return err
+	})
+	if err != nil {
+		return t.handler.formatError("Failed to send message", err), nil
+	}
+
+	result := types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "templates.sent", params.Arguments["template"].(string), channelID),
+			Data: map[string]interface{}{
+				"message_id":    message.ID,
+				"channel_id":    channelID,
+				"template":      params.Arguments["template"].(string),
+				"content":       message.Content,
+				"embed_count":   len(message.Embeds),
+				"message_url":   fmt.Sprintf("https://discord.com/channels/%s/%s/%s", message.GuildID, channelID, message.ID),
+				"retries":       retries,
+				"queue_wait_ms": waited.Milliseconds(),
+			},
+		}},
+	}
+	return recordAction(t.handler.discord, result, journal.Action{
+		Type:       journal.TypeMessageSent,
+		Tool:       "send_templated_message",
+		GuildID:    channelGuildID(t.handler.discord, channelID),
+		ChannelID:  channelID,
+		MessageID:  message.ID,
+		MessageIDs: []string{message.ID},
+	}), nil
+}
+
+// GetDefinition returns the tool definition
+func (t *SendTemplatedMessageTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("send_templated_message", "Render a message template with variables and send it to a Discord channel")
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/threads"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// GetStaleThreadsTool implements the get_stale_threads MCP tool
+type GetStaleThreadsTool struct {
+	handler *ChannelHandler
+}
+
+// NewGetStaleThreadsTool creates a new get stale threads tool
+func NewGetStaleThreadsTool(handler *ChannelHandler) *GetStaleThreadsTool {
+	return &GetStaleThreadsTool{handler: handler}
+}
+
+// Execute executes the get_stale_threads tool
+func (t *GetStaleThreadsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_stale_threads", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	parentID, _ := params.Arguments["parent_id"].(string)
+	withinHours := intArgument(params.Arguments, "within_hours", 24)
+	limit := intArgument(params.Arguments, "limit", threads.MaxDigestThreads)
+	includeParticipants, _ := params.Arguments["include_participants"].(bool)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	stale, err := t.handler.discord.StaleThreads(guildID, time.Duration(withinHours)*time.Hour)
+	if err != nil {
+		return t.formatError("Failed to list active threads", err), nil
+	}
+
+	// Drop threads outside the requested parent or the configured channel
+	// access lists
+	visible := make([]threads.Stale, 0, len(stale))
+	for _, thread := range stale {
+		if parentID != "" && thread.ParentID != parentID {
+			continue
+		}
+		channel := &discordgo.Channel{ID: thread.ThreadID, ParentID: thread.ParentID, Type: discordgo.ChannelTypeGuildPublicThread}
+		if !t.handler.permissions.IsChannelAccessible(channel) {
+			continue
+		}
+		visible = append(visible, thread)
+	}
+
+	total := len(visible)
+	if len(visible) > limit {
+		visible = visible[:limit]
+	}
+	if includeParticipants {
+		for i := range visible {
+			participants, err := t.handler.discord.ThreadParticipants(visible[i].ThreadID)
+			if err != nil {
+				t.handler.logger.Debugf("Failed to read participants of thread %s: %v", visible[i].ThreadID, err)
+				continue
+			}
+			visible[i].Participants = participants
+		}
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "threads.stale", total, guildID, withinHours),
+			Data: map[string]interface{}{
+				"guild_id":     guildID,
+				"within_hours": withinHours,
+				"total":        total,
+				"truncated":    total > len(visible),
+				"threads":      visible,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetStaleThreadsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_stale_threads", "List a guild's active threads that will auto-archive soon without new activity, soonest first, with their last activity and optionally their recent participants")
+}
+
+// formatError creates a standardized error response
+func (t *GetStaleThreadsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
@@ -0,0 +1,572 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/export"
+	"discord-mcp/internal/history"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/tickets"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// ticketNameChars matches characters replaced in ticket channel names
+var ticketNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ticketStaffPermissions are granted to the bot and staff roles in a ticket
+// channel
+const ticketStaffPermissions = discordgo.PermissionViewChannel | discordgo.PermissionSendMessages |
+	discordgo.PermissionReadMessageHistory | discordgo.PermissionAttachFiles | discordgo.PermissionEmbedLinks
+
+// OpenTicketTool implements the open_ticket MCP tool
+type OpenTicketTool struct {
+	handler *GuildHandler
+}
+
+// NewOpenTicketTool creates a new open ticket tool
+func NewOpenTicketTool(handler *GuildHandler) *OpenTicketTool {
+	return &OpenTicketTool{handler: handler}
+}
+
+// Execute executes the open_ticket tool
+func (t *OpenTicketTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("open_ticket", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Tickets()
+	if store == nil {
+		return ticketsDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	userID := params.Arguments["user_id"].(string)
+	subject, _ := params.Arguments["subject"].(string)
+	openedBy, _ := params.Arguments["opened_by"].(string)
+	message, _ := params.Arguments["message"].(string)
+	parentID, _ := params.Arguments["parent_id"].(string)
+	kind := tickets.KindChannel
+	if val, ok := params.Arguments["kind"].(string); ok {
+		kind = val
+	}
+	var staffRoleIDs []string
+	if roles, ok := params.Arguments["staff_role_ids"].([]interface{}); ok {
+		for _, role := range roles {
+			if s, ok := role.(string); ok {
+				staffRoleIDs = append(staffRoleIDs, s)
+			}
+		}
+	}
+	if kind == tickets.KindThread && parentID == "" {
+		return validation.FormatValidationError(validation.NewValidationError("missing parameter",
+			"parent_id is required for thread tickets", "parent_id")), nil
+	}
+
+	// Validate permissions
+	var permErr error
+	if kind == tickets.KindThread {
+		permErr = t.handler.permissions.CanSendMessages(parentID)
+	} else {
+		permErr = t.handler.permissions.CanManageChannels(guildID)
+	}
+	if permErr != nil {
+		if permErr, ok := permErr.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", permErr), nil
+	}
+
+	if open, err := store.CheckOpen(guildID, userID); err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("ticket already open",
+			fmt.Sprintf("user %s already has open ticket %s in <#%s>", userID, open.ID, open.ChannelID), "user_id")), nil
+	}
+
+	var user *discordgo.User
+	_, err := t.handler.discord.Retry(func() (err error) {
+		user, err = t.handler.discord.Session().User(userID)
+		return err
+	})
+	if err != nil {
+		return t.formatError("Failed to get user", err), nil
+	}
+	bot, err := t.handler.discord.GetBotUser()
+	if err != nil {
+		return t.formatError("Failed to get bot user", err), nil
+	}
+
+	ticketID := store.NextID()
+	name := strings.Trim(ticketNameChars.ReplaceAllString(strings.ToLower(user.Username), "-"), "-")
+	if name == "" {
+		name = userID
+	}
+	name = "ticket-" + name
+	topic := fmt.Sprintf("Ticket %s for %s (%s)", ticketID, user.Username, userID)
+	if subject != "" {
+		topic += ": " + subject
+	}
+
+	var channel *discordgo.Channel
+	_, err = t.handler.discord.Retry(func() (err error) {
+		if kind == tickets.KindThread {
+			channel, err = t.handler.discord.Session().ThreadStartComplex(parentID, &discordgo.ThreadStart{
+				Name:                name,
+				Type:                discordgo.ChannelTypeGuildPrivateThread,
+				AutoArchiveDuration: 10080,
+				Invitable:           false,
+			}, auditLogOptions(params.Arguments)...)
+			return err
+		}
+		overwrites := []*discordgo.PermissionOverwrite{
+			{ID: guildID, Type: discordgo.PermissionOverwriteTypeRole, Deny: discordgo.PermissionViewChannel},
+			{ID: bot.ID, Type: discordgo.PermissionOverwriteTypeMember, Allow: ticketStaffPermissions | discordgo.PermissionManageChannels},
+		}
+		for _, roleID := range staffRoleIDs {
+			overwrites = append(overwrites, &discordgo.PermissionOverwrite{
+				ID: roleID, Type: discordgo.PermissionOverwriteTypeRole, Allow: ticketStaffPermissions,
+			})
+		}
+		channel, err = t.handler.discord.Session().GuildChannelCreateComplex(guildID, discordgo.GuildChannelCreateData{
+			Name:                 name,
+			Type:                 discordgo.ChannelTypeGuildText,
+			Topic:                topic,
+			ParentID:             parentID,
+			PermissionOverwrites: overwrites,
+		}, auditLogOptions(params.Arguments)...)
+		return err
+	})
+	if err != nil {
+		return t.formatError("Failed to create ticket channel", err), nil
+	}
+
+	ticket, err := store.Open(tickets.Ticket{
+		ID:        ticketID,
+		GuildID:   guildID,
+		UserID:    userID,
+		ChannelID: channel.ID,
+		Kind:      kind,
+		Subject:   subject,
+		OpenedBy:  openedBy,
+	})
+	if err != nil {
+		return t.formatError(fmt.Sprintf("Failed to save ticket; its channel %s was created", channel.ID), err), nil
+	}
+
+	data := map[string]interface{}{
+		"ticket":       ticket,
+		"channel_name": channel.Name,
+	}
+	// The ticket is open once saved; the intro post and the member's DM
+	// are reported rather than failing it. Mentioning staff roles in a
+	// private thread adds their members to it.
+	intro := &discordgo.MessageSend{
+		Content:         "🎫 " + topic,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	}
+	if kind == tickets.KindThread {
+		for _, roleID := range staffRoleIDs {
+			intro.Content += " <@&" + roleID + ">"
+		}
+		intro.AllowedMentions.Roles = staffRoleIDs
+	}
+	_, _, err = t.handler.discord.SendQueued(channel.ID, outbound.PriorityInteractive, func() error {
+		_, err := t.handler.discord.Session().ChannelMessageSendComplex(channel.ID, intro)
+		return err
+	})
+	if err != nil {
+		data["intro_error"] = err.Error()
+	}
+	if message != "" {
+		if _, err := sendTicketDM(t.handler, userID, message); err != nil {
+			data["dm_error"] = err.Error()
+		} else {
+			data["dm_sent"] = true
+		}
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "tickets.opened", ticket.ID, userID, channel.ID),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *OpenTicketTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("open_ticket", "Open a modmail-style support ticket for a member: a private staff channel or thread that the member's direct messages to the bot are relayed into")
+}
+
+// formatError creates a standardized error response
+func (t *OpenTicketTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ReplyTicketTool implements the reply_ticket MCP tool
+type ReplyTicketTool struct {
+	handler *GuildHandler
+}
+
+// NewReplyTicketTool creates a new reply ticket tool
+func NewReplyTicketTool(handler *GuildHandler) *ReplyTicketTool {
+	return &ReplyTicketTool{handler: handler}
+}
+
+// Execute executes the reply_ticket tool
+func (t *ReplyTicketTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("reply_ticket", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Tickets()
+	if store == nil {
+		return ticketsDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	ticketID := params.Arguments["ticket_id"].(string)
+	content := params.Arguments["content"].(string)
+	author, _ := params.Arguments["author"].(string)
+
+	ticket, ok := store.Get(guildID, ticketID)
+	if !ok || ticket.Status != tickets.StatusOpen {
+		return ticketNotFoundResult(params, ticketID), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanSendMessages(ticket.ChannelID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	dm, err := sendTicketDM(t.handler, ticket.UserID, content)
+	if err != nil {
+		return t.formatError("Failed to send direct message", err), nil
+	}
+
+	data := map[string]interface{}{
+		"ticket_id":  ticket.ID,
+		"user_id":    ticket.UserID,
+		"message_id": dm.ID,
+	}
+	// Staff see the reply in the ticket alongside the member's messages
+	if author == "" {
+		author = "Staff"
+	}
+	mirror := fmt.Sprintf("↩️ **%s**: %s", author, content)
+	if len(mirror) > 2000 {
+		mirror = mirror[:1997] + "..."
+	}
+	_, _, err = t.handler.discord.SendQueued(ticket.ChannelID, outbound.PriorityInteractive, func() error {
+		_, err := t.handler.discord.Session().ChannelMessageSendComplex(ticket.ChannelID, &discordgo.MessageSend{
+			Content:         mirror,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		})
+		return err
+	})
+	if err != nil {
+		data["mirror_error"] = err.Error()
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "tickets.replied", ticket.UserID, ticket.ID),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ReplyTicketTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("reply_ticket", "Reply to the member of an open ticket by direct message, copying the reply into the ticket channel")
+}
+
+// formatError creates a standardized error response
+func (t *ReplyTicketTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// CloseTicketTool implements the close_ticket MCP tool
+type CloseTicketTool struct {
+	handler *GuildHandler
+}
+
+// NewCloseTicketTool creates a new close ticket tool
+func NewCloseTicketTool(handler *GuildHandler) *CloseTicketTool {
+	return &CloseTicketTool{handler: handler}
+}
+
+// Execute executes the close_ticket tool
+func (t *CloseTicketTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("close_ticket", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Tickets()
+	if store == nil {
+		return ticketsDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	ticketID := params.Arguments["ticket_id"].(string)
+	reason, _ := params.Arguments["reason"].(string)
+	closedBy, _ := params.Arguments["closed_by"].(string)
+	message, _ := params.Arguments["message"].(string)
+	format := export.FormatMarkdown
+	if val, ok := params.Arguments["format"].(string); ok {
+		format = val
+	}
+	deleteChannel := true
+	if val, ok := params.Arguments["delete_channel"].(bool); ok {
+		deleteChannel = val
+	}
+
+	ticket, ok := store.Get(guildID, ticketID)
+	if !ok || ticket.Status != tickets.StatusOpen {
+		return ticketNotFoundResult(params, ticketID), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.ValidateMessageOperation("get_messages", ticket.ChannelID, nil); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	channel, err := t.handler.discord.GetChannel(ticket.ChannelID)
+	if err != nil {
+		return t.formatError("Failed to get ticket channel", err), nil
+	}
+
+	// The transcript is saved before the channel goes away
+	cfg := t.handler.discord.Config().Export
+	result, err := t.handler.discord.ScanHistory(history.Request{
+		ChannelIDs: []string{ticket.ChannelID},
+		Limit:      cfg.MaxMessages,
+	})
+	if err == nil {
+		err = result.Errors[ticket.ChannelID]
+	}
+	if err != nil {
+		return t.formatError("Failed to get ticket messages", err), nil
+	}
+	messages := result.Messages[ticket.ChannelID]
+	reverseMessages(messages)
+
+	rendered, err := export.Render(format, &export.Transcript{
+		GuildID:     guildID,
+		ChannelID:   ticket.ChannelID,
+		ChannelName: channel.Name,
+		ExportedAt:  time.Now(),
+		Messages:    messages,
+	}, t.handler.discord.FormatMessage)
+	if err != nil {
+		return t.formatError("Failed to render transcript", err), nil
+	}
+	path, err := writeTranscriptFile(t.handler.logger, cfg.Directory, channel, format, rendered)
+	if err != nil {
+		return t.formatError("Failed to write transcript", err), nil
+	}
+
+	closed, ok, err := store.Close(guildID, ticketID, closedBy, reason, path)
+	if err != nil {
+		return t.formatError("Failed to save ticket", err), nil
+	}
+	if !ok {
+		return ticketNotFoundResult(params, ticketID), nil
+	}
+
+	data := map[string]interface{}{
+		"ticket":        closed,
+		"message_count": len(messages),
+		"truncated":     result.Cursor != "",
+	}
+	if message != "" {
+		if _, err := sendTicketDM(t.handler, ticket.UserID, message); err != nil {
+			data["dm_error"] = err.Error()
+		} else {
+			data["dm_sent"] = true
+		}
+	}
+
+	// Threads are archived and locked; channels are deleted unless kept
+	_, err = t.handler.discord.Retry(func() error {
+		if ticket.Kind == tickets.KindThread {
+			archived, locked := true, true
+			_, err := t.handler.discord.Session().ChannelEditComplex(ticket.ChannelID, &discordgo.ChannelEdit{
+				Archived: &archived,
+				Locked:   &locked,
+			}, auditLogOptions(params.Arguments)...)
+			return err
+		}
+		if !deleteChannel {
+			return nil
+		}
+		_, err := t.handler.discord.Session().ChannelDelete(ticket.ChannelID, auditLogOptions(params.Arguments)...)
+		return err
+	})
+	if err != nil {
+		t.handler.logger.Warnf("Failed to archive channel of ticket %s: %v", ticket.ID, err)
+		data["archive_error"] = err.Error()
+	}
+	data["channel_deleted"] = ticket.Kind == tickets.KindChannel && deleteChannel && err == nil
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "tickets.closed", ticket.ID, len(messages), path),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *CloseTicketTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("close_ticket", "Close a support ticket: save its transcript to the export directory, then archive its thread or delete its channel")
+}
+
+// formatError creates a standardized error response
+func (t *CloseTicketTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListTicketsTool implements the list_tickets MCP tool
+type ListTicketsTool struct {
+	handler *GuildHandler
+}
+
+// NewListTicketsTool creates a new list tickets tool
+func NewListTicketsTool(handler *GuildHandler) *ListTicketsTool {
+	return &ListTicketsTool{handler: handler}
+}
+
+// Execute executes the list_tickets tool
+func (t *ListTicketsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_tickets", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Tickets()
+	if store == nil {
+		return ticketsDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	userID, _ := params.Arguments["user_id"].(string)
+	status := tickets.StatusOpen
+	if val, ok := params.Arguments["status"].(string); ok {
+		status = val
+	}
+	limit := intArgument(params.Arguments, "limit", 50)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	filter := status
+	if filter == "all" {
+		filter = ""
+	}
+	found := store.List(guildID, userID, filter)
+	total := len(found)
+	if len(found) > limit {
+		found = found[:limit]
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "tickets.list", total, status),
+			Data: map[string]interface{}{
+				"guild_id":     guildID,
+				"status":       status,
+				"total_count":  total,
+				"ticket_count": len(found),
+				"tickets":      found,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListTicketsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_tickets", "List a server's support tickets, newest first, with their channel, relayed message count and transcript path")
+}
+
+// formatError creates a standardized error response
+func (t *ListTicketsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// sendTicketDM sends a direct message to a ticket's member
+func sendTicketDM(handler *GuildHandler, userID, content string) (*discordgo.Message, error) {
+	var channel *discordgo.Channel
+	_, err := handler.discord.Retry(func() (err error) {
+		channel, err = handler.discord.Session().UserChannelCreate(userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var message *discordgo.Message
+	_, _, err = handler.discord.SendQueued(channel.ID, outbound.PriorityInteractive, func() (err error) {
+		message, err = handler.discord.Session().ChannelMessageSend(channel.ID, content)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// ticketNotFoundResult reports a ticket that does not exist or is closed
+func ticketNotFoundResult(params types.CallToolParams, ticketID string) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "tickets.not_found", ticketID),
+			Data: map[string]interface{}{
+				"error_type": "not_found",
+				"ticket_id":  ticketID,
+			},
+		}},
+		IsError: true,
+	}
+}
+
+// ticketsDisabledResult reports that tickets are turned off in the
+// configuration
+func ticketsDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "tickets.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "tickets disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// GetRecentTracesTool implements the get_recent_traces MCP tool
+type GetRecentTracesTool struct {
+	discord   *discord.Client
+	validator *validation.Validator
+}
+
+// NewGetRecentTracesTool creates a new get recent traces tool
+func NewGetRecentTracesTool(discordClient *discord.Client, validator *validation.Validator) *GetRecentTracesTool {
+	return &GetRecentTracesTool{
+		discord:   discordClient,
+		validator: validator,
+	}
+}
+
+// Execute executes the get_recent_traces tool
+func (t *GetRecentTracesTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("get_recent_traces", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	limit := 20
+	if limitVal, ok := params.Arguments["limit"]; ok {
+		if limitFloat, ok := limitVal.(float64); ok {
+			limit = int(limitFloat)
+		} else if limitInt, ok := limitVal.(int); ok {
+			limit = limitInt
+		}
+	}
+	kind, _ := params.Arguments["kind"].(string)
+	failedOnly, _ := params.Arguments["failed_only"].(bool)
+	contains, _ := params.Arguments["contains"].(string)
+
+	tracer := t.discord.Tracer()
+	traces := tracer.Recent(limit, kind, failedOnly, contains)
+	key := "traces.list"
+	if !tracer.Enabled() {
+		key = "traces.off"
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), key, len(traces)),
+			Data: map[string]interface{}{
+				"tracing": tracer.Enabled(),
+				"count":   len(traces),
+				"traces":  traces,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetRecentTracesTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_recent_traces", "Show recent sanitized Discord REST requests and responses and gateway events recorded while tracing is on, to debug why a tool call failed")
+}
@@ -0,0 +1,344 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/history"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// transcriptBlock is a run of consecutive messages by one author, merged
+// into one transcript line
+type transcriptBlock struct {
+	authorID   string
+	author     string
+	start      time.Time
+	end        time.Time
+	messageIDs []string
+	parts      []string
+	starter    bool
+}
+
+// line renders the block as "[time] author: content"
+func (b *transcriptBlock) line() string {
+	marker := ""
+	if b.starter {
+		marker = " (thread starter)"
+	}
+	return fmt.Sprintf("[%s] %s%s: %s", b.start.UTC().Format("2006-01-02 15:04"), b.author, marker, strings.Join(b.parts, "\n"))
+}
+
+// transcriptParticipant counts one author's contribution to a thread
+type transcriptParticipant struct {
+	UserID       string    `json:"user_id"`
+	Name         string    `json:"name"`
+	Bot          bool      `json:"bot"`
+	MessageCount int       `json:"message_count"`
+	CharCount    int       `json:"char_count"`
+	FirstAt      time.Time `json:"first_at"`
+	LastAt       time.Time `json:"last_at"`
+}
+
+// GetThreadTranscriptTool implements the get_thread_transcript MCP tool
+type GetThreadTranscriptTool struct {
+	handler *MessageHandler
+}
+
+// NewGetThreadTranscriptTool creates a new get thread transcript tool
+func NewGetThreadTranscriptTool(handler *MessageHandler) *GetThreadTranscriptTool {
+	return &GetThreadTranscriptTool{handler: handler}
+}
+
+// Execute executes the get_thread_transcript tool
+func (t *GetThreadTranscriptTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_thread_transcript", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	threadID := params.Arguments["thread_id"].(string)
+	maxMessages := intArgument(params.Arguments, "max_messages", 2000)
+	mergeWindow := time.Duration(intArgument(params.Arguments, "merge_window_minutes", 10)) * time.Minute
+	maxChars := intArgument(params.Arguments, "max_chars", 16000)
+	if _, ok := params.Arguments["max_tokens"]; ok {
+		maxChars = intArgument(params.Arguments, "max_tokens", 0) * charsPerToken
+	}
+	summary := summaryArguments(params.Arguments)
+	if summary.requested && t.handler.discord.Summarizer() == nil {
+		return summarizerDisabledResult(params), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.ValidateMessageOperation("get_messages", threadID, nil); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	thread, err := t.handler.discord.GetChannel(threadID)
+	if err != nil {
+		return t.formatError("Failed to get thread", err), nil
+	}
+	if !thread.IsThread() {
+		return validation.FormatValidationError(validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s is not a thread", threadID), "thread_id")), nil
+	}
+
+	// Read the whole thread, newest first
+	result, err := t.handler.discord.ScanHistory(history.Request{
+		ChannelIDs: []string{threadID},
+		Limit:      maxMessages,
+	})
+	if err != nil {
+		return t.formatError("Failed to read thread", err), nil
+	}
+	if err := result.Errors[threadID]; err != nil {
+		return t.formatError("Failed to read thread", err), nil
+	}
+	messages := result.Messages[threadID]
+	reverseMessages(messages)
+
+	starter := t.starterMessage(thread, messages)
+	blocks, participants, noise := buildTranscript(starter, messages, mergeWindow)
+	lines, omittedBlocks, omittedMessages := fitTranscript(blocks, maxChars)
+	transcript := strings.Join(lines, "\n")
+
+	threadInfo := map[string]interface{}{
+		"id":        thread.ID,
+		"name":      thread.Name,
+		"parent_id": thread.ParentID,
+	}
+	if parent, err := t.handler.discord.GetChannel(thread.ParentID); err == nil {
+		threadInfo["parent_name"] = parent.Name
+	}
+	if thread.ThreadMetadata != nil {
+		threadInfo["archived"] = thread.ThreadMetadata.Archived
+		threadInfo["locked"] = thread.ThreadMetadata.Locked
+	}
+
+	data := map[string]interface{}{
+		"thread":             threadInfo,
+		"transcript":         transcript,
+		"char_count":         len(transcript),
+		"max_chars":          maxChars,
+		"message_count":      len(messages) - noise,
+		"noise_removed":      noise,
+		"block_count":        len(blocks),
+		"omitted_blocks":     omittedBlocks,
+		"omitted_messages":   omittedMessages,
+		"participants":       participants,
+		"participant_count":  len(participants),
+		"complete":           result.Complete,
+		"starter_message_id": starterID(starter),
+	}
+	text := i18n.T(i18n.Locale(params), "transcript.built", thread.Name, len(messages)-noise, len(participants))
+
+	// The summary covers every block, including those omitted above
+	summaryText := ""
+	if summary.requested {
+		allLines := make([]string, len(blocks))
+		for i, block := range blocks {
+			allLines[i] = block.line()
+		}
+		summaryText = addSummary(t.handler.discord, t.handler.logger, data, "thread:"+threadID, allLines, summary)
+	}
+	if summaryText != "" {
+		text += "\n\n" + i18n.T(i18n.Locale(params), "summarizer.summary") + "\n" + summaryText
+	}
+	// summary_only leaves the transcript out, unless summarizing failed
+	if summary.only && summaryText != "" {
+		delete(data, "transcript")
+	} else {
+		text += "\n\n" + transcript
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: data,
+		}},
+	}, nil
+}
+
+// starterMessage returns the message a thread was started from, or nil.
+// Threads started from a message share its ID and keep it in the parent
+// channel; forum posts keep it as the thread's first message.
+func (t *GetThreadTranscriptTool) starterMessage(thread *discordgo.Channel, messages []*discordgo.Message) *discordgo.Message {
+	for _, msg := range messages {
+		if msg.ID == thread.ID {
+			return nil
+		}
+		if msg.Type == discordgo.MessageTypeThreadStarterMessage && msg.ReferencedMessage != nil {
+			return msg.ReferencedMessage
+		}
+	}
+	if t.handler.permissions.ValidateMessageOperation("get_messages", thread.ParentID, nil) != nil {
+		return nil
+	}
+	var starter *discordgo.Message
+	_, err := t.handler.discord.Retry(func() (err error) {
+		starter, err = t.handler.discord.Session().ChannelMessage(thread.ParentID, thread.ID)
+		return err
+	})
+	if err != nil {
+		return nil
+	}
+	return starter
+}
+
+// GetDefinition returns the tool definition
+func (t *GetThreadTranscriptTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_thread_transcript", "Read a whole thread as a compact transcript for summarization: consecutive messages by one author are merged, join and system messages are dropped, and participant stats are included")
+}
+
+// formatError creates a standardized error response
+func (t *GetThreadTranscriptTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// isTranscriptNoise reports whether a message carries no conversation, such
+// as joins, pins and thread system messages
+func isTranscriptNoise(msg *discordgo.Message) bool {
+	if msg.Type != discordgo.MessageTypeDefault && msg.Type != discordgo.MessageTypeReply {
+		return true
+	}
+	return strings.TrimSpace(msg.Content) == "" && len(msg.Attachments) == 0 && len(msg.Embeds) == 0
+}
+
+// transcriptContent returns a message's text with its attachments and
+// embeds noted inline
+func transcriptContent(msg *discordgo.Message) string {
+	content := msg.Content
+	for _, att := range msg.Attachments {
+		content += fmt.Sprintf(" [attachment: %s]", att.Filename)
+	}
+	for _, embed := range msg.Embeds {
+		if embed.Title != "" {
+			content += fmt.Sprintf(" [embed: %s]", embed.Title)
+		}
+	}
+	return strings.TrimSpace(content)
+}
+
+// buildTranscript drops noise and merges consecutive messages by the same
+// author sent within mergeWindow of each other. It returns the blocks oldest
+// first, the participants by message count and the number of noise messages.
+func buildTranscript(starter *discordgo.Message, messages []*discordgo.Message, mergeWindow time.Duration) ([]*transcriptBlock, []*transcriptParticipant, int) {
+	var blocks []*transcriptBlock
+	participants := make(map[string]*transcriptParticipant)
+	noise := 0
+
+	add := func(msg *discordgo.Message, isStarter bool) {
+		authorID := ""
+		bot := false
+		if msg.Author != nil {
+			authorID = msg.Author.ID
+			bot = msg.Author.Bot
+		}
+		content := transcriptContent(msg)
+
+		participant, ok := participants[authorID]
+		if !ok {
+			participant = &transcriptParticipant{UserID: authorID, Name: authorName(msg), Bot: bot, FirstAt: msg.Timestamp}
+			participants[authorID] = participant
+		}
+		participant.MessageCount++
+		participant.CharCount += len(content)
+		participant.LastAt = msg.Timestamp
+
+		if n := len(blocks); n > 0 && !isStarter && !blocks[n-1].starter {
+			last := blocks[n-1]
+			if last.authorID == authorID && msg.Timestamp.Sub(last.end) <= mergeWindow {
+				last.parts = append(last.parts, content)
+				last.messageIDs = append(last.messageIDs, msg.ID)
+				last.end = msg.Timestamp
+				return
+			}
+		}
+		blocks = append(blocks, &transcriptBlock{
+			authorID:   authorID,
+			author:     authorName(msg),
+			start:      msg.Timestamp,
+			end:        msg.Timestamp,
+			messageIDs: []string{msg.ID},
+			parts:      []string{content},
+			starter:    isStarter,
+		})
+	}
+
+	if starter != nil && !isTranscriptNoise(starter) {
+		add(starter, true)
+	}
+	for _, msg := range messages {
+		if isTranscriptNoise(msg) {
+			noise++
+			continue
+		}
+		add(msg, starter == nil && len(blocks) == 0)
+	}
+
+	ranked := make([]*transcriptParticipant, 0, len(participants))
+	for _, participant := range participants {
+		ranked = append(ranked, participant)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].MessageCount != ranked[j].MessageCount {
+			return ranked[i].MessageCount > ranked[j].MessageCount
+		}
+		return ranked[i].FirstAt.Before(ranked[j].FirstAt)
+	})
+	return blocks, ranked, noise
+}
+
+// fitTranscript renders blocks within maxChars. The first block, which sets
+// the topic, is always kept; the newest blocks are kept next, and the
+// middle of the thread is left out when it does not fit. It returns the
+// lines and the number of blocks and messages left out.
+func fitTranscript(blocks []*transcriptBlock, maxChars int) ([]string, int, int) {
+	if len(blocks) == 0 {
+		return nil, 0, 0
+	}
+	first := blocks[0].line()
+	used := len(first) + 1
+
+	keepFrom := len(blocks)
+	for i := len(blocks) - 1; i > 0; i-- {
+		size := len(blocks[i].line()) + 1
+		if used+size > maxChars {
+			break
+		}
+		used += size
+		keepFrom = i
+	}
+
+	lines := []string{first}
+	omittedMessages := 0
+	for _, block := range blocks[1:keepFrom] {
+		omittedMessages += len(block.messageIDs)
+	}
+	if omittedMessages > 0 {
+		lines = append(lines, fmt.Sprintf("[… %d messages omitted …]", omittedMessages))
+	}
+	for _, block := range blocks[keepFrom:] {
+		lines = append(lines, block.line())
+	}
+	return lines, keepFrom - 1, omittedMessages
+}
+
+// starterID returns a starter message's ID, or "" without one
+func starterID(starter *discordgo.Message) string {
+	if starter == nil {
+		return ""
+	}
+	return starter.ID
+}
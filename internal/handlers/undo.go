@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/journal"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// UndoActionTool implements the undo_action MCP tool
+type UndoActionTool struct {
+	handler *GuildHandler
+}
+
+// NewUndoActionTool creates a new undo action tool
+func NewUndoActionTool(handler *GuildHandler) *UndoActionTool {
+	return &UndoActionTool{handler: handler}
+}
+
+// Execute executes the undo_action tool
+func (t *UndoActionTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("undo_action", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	actions := t.handler.discord.Journal()
+	if actions == nil {
+		return undoDisabledResult(params), nil
+	}
+
+	actionID := params.Arguments["action_id"].(string)
+	action, ok := actions.Get(actionID)
+	if !ok {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "undo.not_found", actionID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"action_id":  actionID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+	if action.UndoneAt != nil {
+		return validation.FormatValidationError(validation.NewValidationError("already undone",
+			fmt.Sprintf("action %s was already undone", actionID), "action_id")), nil
+	}
+
+	return undoAction(t.handler, params, action), nil
+}
+
+// GetDefinition returns the tool definition
+func (t *UndoActionTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("undo_action", "Reverse a recent action by the action_id its tool returned: delete a sent message, restore an edited message or channel, remove a reaction, or reverse a role change")
+}
+
+// UndoLastActionTool implements the undo_last_action MCP tool
+type UndoLastActionTool struct {
+	handler *GuildHandler
+}
+
+// NewUndoLastActionTool creates a new undo last action tool
+func NewUndoLastActionTool(handler *GuildHandler) *UndoLastActionTool {
+	return &UndoLastActionTool{handler: handler}
+}
+
+// Execute executes the undo_last_action tool
+func (t *UndoLastActionTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("undo_last_action", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	actions := t.handler.discord.Journal()
+	if actions == nil {
+		return undoDisabledResult(params), nil
+	}
+
+	guildID, _ := params.Arguments["guild_id"].(string)
+	action, ok := actions.Last(guildID)
+	if !ok {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "undo.nothing"),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"guild_id":   guildID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return undoAction(t.handler, params, action), nil
+}
+
+// GetDefinition returns the tool definition
+func (t *UndoLastActionTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("undo_last_action", "Reverse the most recent action that has not been undone, optionally limited to one guild")
+}
+
+// undoAction reverses an action and marks it undone in the journal
+func undoAction(handler *GuildHandler, params types.CallToolParams, action journal.Action) types.CallToolResult {
+	if err := checkUndoPermissions(handler, action); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr)
+		}
+		return discordErrorResult(handler.logger, "Permission check failed", err)
+	}
+
+	if err := reverseAction(handler.discord, action); err != nil {
+		return discordErrorResult(handler.logger, fmt.Sprintf("Failed to undo %s %s", action.Type, action.ID), err)
+	}
+	undone, _ := handler.discord.Journal().MarkUndone(action.ID)
+	handler.logger.Infof("Undid %s %s from %s", action.Type, action.ID, action.Tool)
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "undo.done", action.Tool, action.ID),
+			Data: undone,
+		}},
+	}
+}
+
+// checkUndoPermissions checks the permissions that reversing an action
+// needs. Messages and reactions are the bot's own and need none.
+func checkUndoPermissions(handler *GuildHandler, action journal.Action) error {
+	switch action.Type {
+	case journal.TypeRoleAssigned, journal.TypeRoleUnassigned, journal.TypeRoleCreated:
+		return handler.permissions.CanManageRole(action.GuildID, action.RoleID)
+	case journal.TypeChannelEdited:
+		return handler.permissions.CanManageChannels(action.GuildID)
+	}
+	return nil
+}
+
+// reverseAction makes the Discord calls that undo an action
+func reverseAction(client *discord.Client, action journal.Action) error {
+	session := client.Session()
+	var err error
+	switch action.Type {
+	case journal.TypeMessageSent:
+		for _, messageID := range action.MessageIDs {
+			_, err = client.Retry(func() error {
+				return session.ChannelMessageDelete(action.ChannelID, messageID)
+			})
+			// A message deleted since is already undone
+			if err != nil && discord.ClassifyError(err).Code != discord.ErrCodeUnknownMessage {
+				return err
+			}
+		}
+		return nil
+
+	case journal.TypeMessageEdited:
+		embeds := action.Before.Embeds
+		if embeds == nil {
+			embeds = []*discordgo.MessageEmbed{}
+		}
+		_, err = client.Retry(func() error {
+			_, err := session.ChannelMessageEditComplex(&discordgo.MessageEdit{
+				ID:      action.MessageID,
+				Channel: action.ChannelID,
+				Content: &action.Before.Content,
+				Embeds:  &embeds,
+			})
+			return err
+		})
+
+	case journal.TypeReactionAdded:
+		_, err = client.Retry(func() error {
+			return session.MessageReactionRemove(action.ChannelID, action.MessageID, action.Emoji, "@me")
+		})
+
+	case journal.TypeRoleCreated:
+		_, err = client.Retry(func() error {
+			return session.GuildRoleDelete(action.GuildID, action.RoleID)
+		})
+
+	case journal.TypeRoleAssigned:
+		_, err = client.Retry(func() error {
+			return session.GuildMemberRoleRemove(action.GuildID, action.UserID, action.RoleID)
+		})
+
+	case journal.TypeRoleUnassigned:
+		_, err = client.Retry(func() error {
+			return session.GuildMemberRoleAdd(action.GuildID, action.UserID, action.RoleID)
+		})
+
+	case journal.TypeChannelEdited:
+		// The request is built by hand because the API client omits empty
+		// topics, parents and overwrite lists, which restoring may need
+		data := channelRestoreData(action.Before.Channel, action.Fields)
+		endpoint := discordgo.EndpointChannel(action.ChannelID)
+		_, err = client.Retry(func() error {
+			_, err := session.RequestWithBucketID("PATCH", endpoint, data, endpoint)
+			return err
+		})
+
+	default:
+		err = fmt.Errorf("action type %q cannot be undone", action.Type)
+	}
+	return err
+}
+
+// channelRestoreData returns the channel edit that sets the changed fields
+// back to their earlier values
+func channelRestoreData(state *journal.ChannelState, fields []string) map[string]interface{} {
+	data := make(map[string]interface{})
+	for _, field := range fields {
+		switch field {
+		case "topic":
+			data["topic"] = state.Topic
+		case "nsfw":
+			data["nsfw"] = state.NSFW
+		case "slowmode":
+			data["rate_limit_per_user"] = state.RateLimitPerUser
+		case "bitrate":
+			data["bitrate"] = state.Bitrate
+		case "user_limit":
+			data["user_limit"] = state.UserLimit
+		case "category":
+			if state.ParentID == "" {
+				data["parent_id"] = nil
+			} else {
+				data["parent_id"] = state.ParentID
+			}
+		case "overwrites":
+			data["permission_overwrites"] = state.PermissionOverwrites
+		}
+	}
+	return data
+}
+
+// recordAction journals a reversible action and adds its ID to the result
+// data so it can be passed to undo_action. It does nothing when undo is
+// disabled.
+func recordAction(client *discord.Client, result types.CallToolResult, action journal.Action) types.CallToolResult {
+	actions := client.Journal()
+	if actions == nil {
+		return result
+	}
+	recorded := actions.Record(action)
+	data, ok := result.Content[0].Data.(map[string]interface{})
+	if !ok {
+		// Typed data, such as a role summary, is flattened into a map first
+		encoded, err := json.Marshal(result.Content[0].Data)
+		if err != nil || json.Unmarshal(encoded, &data) != nil || data == nil {
+			return result
+		}
+		result.Content[0].Data = data
+	}
+	data["action_id"] = recorded.ID
+	return result
+}
+
+// channelGuildID returns the guild a channel belongs to, or "" for direct
+// messages and unknown channels
+func channelGuildID(client *discord.Client, channelID string) string {
+	channel, err := client.GetChannel(channelID)
+	if err != nil {
+		return ""
+	}
+	return channel.GuildID
+}
+
+// messageBefore returns a message's current content and embeds, or nil
+// when the message cannot be read
+func messageBefore(client *discord.Client, channelID, messageID string) *journal.Before {
+	message, err := client.Session().State.Message(channelID, messageID)
+	if err != nil {
+		_, err = client.Retry(func() (err error) {
+			message, err = client.Session().ChannelMessage(channelID, messageID)
+			return err
+		})
+	}
+	if err != nil {
+		return nil
+	}
+	return &journal.Before{Content: message.Content, Embeds: message.Embeds}
+}
+
+// memberHasRole reports whether a member holds a role, and false when the
+// member cannot be read
+func memberHasRole(client *discord.Client, guildID, userID, roleID string) bool {
+	member, err := client.GetMember(guildID, userID)
+	if err != nil {
+		return false
+	}
+	for _, id := range member.Roles {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// undoDisabledResult reports that undo is turned off in the configuration
+func undoDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "undo.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "undo disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/snowflake"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// maxMutualGuildLookups bounds the member lookups made for guilds whose
+// member list is not in the gateway state
+const maxMutualGuildLookups = 25
+
+// UserHandler handles Discord user operations
+type UserHandler struct {
+	discord     *discord.Client
+	permissions *permissions.Checker
+	validator   *validation.Validator
+	logger      *logrus.Logger
+}
+
+// NewUserHandler creates a new user handler
+func NewUserHandler(discordClient *discord.Client, permChecker *permissions.Checker, validator *validation.Validator, logger *logrus.Logger) *UserHandler {
+	return &UserHandler{
+		discord:     discordClient,
+		permissions: permChecker,
+		validator:   validator,
+		logger:      logger,
+	}
+}
+
+// formatError creates a standardized error response
+func (h *UserHandler) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(h.logger, message, err)
+}
+
+// userInfo is the data get_user_info returns
+type userInfo struct {
+	types.UserProfile
+	Retries int `json:"retries"`
+	// The mutual guilds are left out unless requested
+	MutualGuilds         *[]mutualGuild `json:"mutual_guilds,omitempty"`
+	MutualGuildsComplete *bool          `json:"mutual_guilds_complete,omitempty"`
+}
+
+// mutualGuild is a guild the user shares with the bot
+type mutualGuild struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Nick     string    `json:"nick"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// GetUserInfoTool implements the get_user_info MCP tool
+type GetUserInfoTool struct {
+	handler *UserHandler
+}
+
+// NewGetUserInfoTool creates a new get user info tool
+func NewGetUserInfoTool(handler *UserHandler) *GetUserInfoTool {
+	return &GetUserInfoTool{handler: handler}
+}
+
+// Execute executes the get_user_info tool
+func (t *GetUserInfoTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_user_info", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	userID := params.Arguments["user_id"].(string)
+
+	includeMutual := true
+	if val, ok := params.Arguments["include_mutual_guilds"].(bool); ok {
+		includeMutual = val
+	}
+
+	// The REST user object carries the banner and accent color, which
+	// gateway member payloads omit
+	var user *discordgo.User
+	retries, err := t.handler.discord.Retry(func() (err error) {
+		user, err = t.handler.discord.Session().User(userID)
+		return err
+	})
+	if err != nil {
+		return t.handler.formatError("Failed to get user", err), nil
+	}
+
+	data := userInfo{UserProfile: formatUserProfile(t.handler.discord, user), Retries: retries}
+	if includeMutual {
+		mutual, complete := t.mutualGuilds(userID)
+		data.MutualGuilds = &mutual
+		data.MutualGuildsComplete = &complete
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "users.info", user.Username),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetUserInfoTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_user_info", "Get a Discord user's global profile, account age and the guilds they share with the bot")
+}
+
+// mutualGuilds returns the accessible guilds the user is a member of. The
+// result is incomplete when lookups were capped or failed.
+func (t *GetUserInfoTool) mutualGuilds(userID string) ([]mutualGuild, bool) {
+	mutual := make([]mutualGuild, 0)
+	complete := true
+	lookups := 0
+
+	for _, guild := range t.handler.discord.Session().State.Guilds {
+		if err := t.handler.permissions.CanViewGuild(guild.ID); err != nil {
+			continue
+		}
+
+		member, err := t.handler.discord.Session().State.Member(guild.ID, userID)
+		if err != nil {
+			if lookups >= maxMutualGuildLookups {
+				complete = false
+				continue
+			}
+			lookups++
+
+			member, err = t.handler.discord.GetMember(guild.ID, userID)
+			if err != nil {
+				var restErr *discordgo.RESTError
+				if !errors.As(err, &restErr) || restErr.Response == nil || restErr.Response.StatusCode != http.StatusNotFound {
+					complete = false
+				}
+				continue
+			}
+		}
+
+		mutual = append(mutual, mutualGuild{
+			ID:       guild.ID,
+			Name:     guild.Name,
+			Nick:     member.Nick,
+			JoinedAt: member.JoinedAt,
+		})
+	}
+
+	return mutual, complete
+}
+
+// formatUserProfile formats a global user for the response
+func formatUserProfile(client *discord.Client, user *discordgo.User) types.UserProfile {
+	profile := types.UserProfile{
+		UserSummary: client.FormatUser(user),
+		GlobalName:  user.GlobalName,
+		DisplayName: user.GlobalName,
+		System:      user.System,
+		PublicFlags: int(user.PublicFlags),
+		AccentColor: user.AccentColor,
+	}
+	if profile.DisplayName == "" {
+		profile.DisplayName = user.Username
+	}
+	if user.Banner != "" {
+		profile.BannerURL = client.CDN().UserBanner(user)
+	}
+	if parts, err := snowflake.Parse(user.ID); err == nil {
+		ageDays := int(time.Since(parts.Timestamp).Hours() / 24)
+		profile.CreatedAt = parts.Timestamp.Format(time.RFC3339)
+		profile.AccountAgeDays = &ageDays
+	}
+	return profile
+}
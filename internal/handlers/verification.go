@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/outbound"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/internal/verification"
+	"discord-mcp/pkg/types"
+)
+
+// defaultVerifyMessage is posted with the Verify button when no
+// button_message is given
+const defaultVerifyMessage = "Press the button below to verify and unlock the rest of the server."
+
+// ConfigureVerificationTool implements the configure_verification MCP tool
+type ConfigureVerificationTool struct {
+	handler *GuildHandler
+}
+
+// NewConfigureVerificationTool creates a new configure verification tool
+func NewConfigureVerificationTool(handler *GuildHandler) *ConfigureVerificationTool {
+	return &ConfigureVerificationTool{handler: handler}
+}
+
+// Execute executes the configure_verification tool
+func (t *ConfigureVerificationTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("configure_verification", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Verification()
+	if store == nil {
+		return verificationDisabledResult(params), nil
+	}
+
+	settings := verification.Settings{
+		Enabled:          true,
+		KickAfterMinutes: intArgument(params.Arguments, "kick_after_minutes", 0),
+	}
+	settings.GuildID = params.Arguments["guild_id"].(string)
+	settings.UnverifiedRoleID = params.Arguments["unverified_role_id"].(string)
+	settings.MemberRoleID, _ = params.Arguments["member_role_id"].(string)
+	if val, ok := params.Arguments["enabled"].(bool); ok {
+		settings.Enabled = val
+	}
+	if settings.MemberRoleID == settings.UnverifiedRoleID {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+			"member_role_id must differ from unverified_role_id", "member_role_id")), nil
+	}
+	buttonChannelID, _ := params.Arguments["button_channel_id"].(string)
+	buttonMessage := defaultVerifyMessage
+	if val, ok := params.Arguments["button_message"].(string); ok {
+		buttonMessage = val
+	}
+	buttonLabel := "Verify"
+	if val, ok := params.Arguments["button_label"].(string); ok {
+		buttonLabel = val
+	}
+
+	// Validate permissions
+	if err := t.checkPermissions(settings, buttonChannelID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		if validationErr, ok := err.(*validation.ValidationError); ok {
+			return validation.FormatValidationError(validationErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	// Keep the button posted earlier unless a new one is requested
+	if previous, ok := store.Settings(settings.GuildID); ok {
+		settings.ButtonChannelID = previous.ButtonChannelID
+		settings.ButtonMessageID = previous.ButtonMessageID
+	}
+	if buttonChannelID != "" {
+		msgData := &discordgo.MessageSend{
+			Content:         buttonMessage,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    buttonLabel,
+						Style:    discordgo.SuccessButton,
+						CustomID: settings.ButtonCustomID(),
+					},
+				}},
+			},
+		}
+		var message *discordgo.Message
+		_, _, err := t.handler.discord.SendQueued(buttonChannelID, outbound.PriorityInteractive, func() (err error) {
+			message, err = t.handler.discord.Session().ChannelMessageSendComplex(buttonChannelID, msgData)
+			return err
+		})
+		if err != nil {
+			return t.formatError("Failed to post verify button", err), nil
+		}
+		settings.ButtonChannelID = buttonChannelID
+		settings.ButtonMessageID = message.ID
+	}
+
+	saved, err := store.Configure(settings)
+	if err != nil {
+		return t.formatError("Failed to save verification", err), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "verify.configured", saved.GuildID, saved.UnverifiedRoleID),
+			Data: saved,
+		}},
+	}, nil
+}
+
+// checkPermissions checks that the bot can manage both roles and, when a
+// button is posted, that the channel belongs to the guild and the bot can
+// send there
+func (t *ConfigureVerificationTool) checkPermissions(settings verification.Settings, buttonChannelID string) error {
+	if err := t.handler.permissions.CanManageRole(settings.GuildID, settings.UnverifiedRoleID); err != nil {
+		return err
+	}
+	if settings.MemberRoleID != "" {
+		if err := t.handler.permissions.CanManageRole(settings.GuildID, settings.MemberRoleID); err != nil {
+			return err
+		}
+	}
+	if buttonChannelID == "" {
+		return nil
+	}
+	channel, err := t.handler.discord.GetChannel(buttonChannelID)
+	if err != nil {
+		return err
+	}
+	if channel.GuildID != settings.GuildID {
+		return validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s is not in guild %s", buttonChannelID, settings.GuildID), "button_channel_id")
+	}
+	return t.handler.permissions.CanSendMessages(buttonChannelID)
+}
+
+// GetDefinition returns the tool definition
+func (t *ConfigureVerificationTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("configure_verification", "Set a guild's verification gate: new members get a restricted role until verified, optionally with a Verify button they press themselves and a kick for members who stay unverified too long")
+}
+
+// formatError creates a standardized error response
+func (t *ConfigureVerificationTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// VerifyMemberTool implements the verify_member MCP tool
+type VerifyMemberTool struct {
+	handler *GuildHandler
+}
+
+// NewVerifyMemberTool creates a new verify member tool
+func NewVerifyMemberTool(handler *GuildHandler) *VerifyMemberTool {
+	return &VerifyMemberTool{handler: handler}
+}
+
+// Execute executes the verify_member tool
+func (t *VerifyMemberTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("verify_member", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Verification()
+	if store == nil {
+		return verificationDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	userID := params.Arguments["user_id"].(string)
+	reason := "Verified with verify_member"
+	if val, ok := params.Arguments["reason"].(string); ok && val != "" {
+		reason = val
+	}
+
+	settings, ok := store.Settings(guildID)
+	if !ok {
+		return verificationNotConfiguredResult(params, guildID), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanManageRole(guildID, settings.UnverifiedRoleID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	wasPending, err := t.handler.discord.VerifyMember(settings, userID, reason)
+	if err != nil {
+		return t.formatError("Failed to verify member", err), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "verify.verified", userID, guildID),
+			Data: map[string]interface{}{
+				"guild_id":       guildID,
+				"user_id":        userID,
+				"verified":       true,
+				"was_pending":    wasPending,
+				"member_role_id": settings.MemberRoleID,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *VerifyMemberTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("verify_member", "Verify a member: remove the guild's unverified role, give them its member role and cancel their pending kick")
+}
+
+// formatError creates a standardized error response
+func (t *VerifyMemberTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListUnverifiedMembersTool implements the list_unverified_members MCP tool
+type ListUnverifiedMembersTool struct {
+	handler *GuildHandler
+}
+
+// NewListUnverifiedMembersTool creates a new list unverified members tool
+func NewListUnverifiedMembersTool(handler *GuildHandler) *ListUnverifiedMembersTool {
+	return &ListUnverifiedMembersTool{handler: handler}
+}
+
+// Execute executes the list_unverified_members tool
+func (t *ListUnverifiedMembersTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_unverified_members", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Verification()
+	if store == nil {
+		return verificationDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	settings, ok := store.Settings(guildID)
+	if !ok {
+		return verificationNotConfiguredResult(params, guildID), nil
+	}
+
+	pending := store.Pending(guildID)
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "verify.list", len(pending)),
+			Data: map[string]interface{}{
+				"guild_id":     guildID,
+				"settings":     settings,
+				"member_count": len(pending),
+				"members":      pending,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListUnverifiedMembersTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_unverified_members", "List the members of a guild still awaiting verification, longest waiting first, with when each joined and when they will be kicked")
+}
+
+// formatError creates a standardized error response
+func (t *ListUnverifiedMembersTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// verificationNotConfiguredResult reports that a guild has no verification
+// gate set with configure_verification
+func verificationNotConfiguredResult(params types.CallToolParams, guildID string) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "verify.not_configured", guildID),
+			Data: map[string]interface{}{
+				"error_type": "not_found",
+				"guild_id":   guildID,
+			},
+		}},
+		IsError: true,
+	}
+}
+
+// verificationDisabledResult reports that verification is turned off in the
+// configuration
+func verificationDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "verify.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "verification disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
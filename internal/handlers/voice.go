@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// maxAudioBytes bounds uploaded audio passed to play_audio
+const maxAudioBytes = 25 * 1024 * 1024
+
+// VoiceHandler handles Discord voice operations
+type VoiceHandler struct {
+	discord     *discord.Client
+	permissions *permissions.Checker
+	validator   *validation.Validator
+	logger      *logrus.Logger
+}
+
+// NewVoiceHandler creates a new voice handler
+func NewVoiceHandler(discordClient *discord.Client, permChecker *permissions.Checker, validator *validation.Validator, logger *logrus.Logger) *VoiceHandler {
+	return &VoiceHandler{
+		discord:     discordClient,
+		permissions: permChecker,
+		validator:   validator,
+		logger:      logger,
+	}
+}
+
+// formatError creates a standardized error response
+func (h *VoiceHandler) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(h.logger, message, err)
+}
+
+// JoinVoiceChannelTool implements the join_voice_channel MCP tool
+type JoinVoiceChannelTool struct {
+	handler *VoiceHandler
+}
+
+// NewJoinVoiceChannelTool creates a new join voice channel tool
+func NewJoinVoiceChannelTool(handler *VoiceHandler) *JoinVoiceChannelTool {
+	return &JoinVoiceChannelTool{handler: handler}
+}
+
+// Execute executes the join_voice_channel tool
+func (t *JoinVoiceChannelTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("join_voice_channel", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	channelID := params.Arguments["channel_id"].(string)
+
+	channel, err := t.handler.discord.GetChannel(channelID)
+	if err != nil {
+		return t.handler.formatError("Failed to get channel", err), nil
+	}
+	if channel.Type != discordgo.ChannelTypeGuildVoice && channel.Type != discordgo.ChannelTypeGuildStageVoice {
+		return validation.FormatValidationError(validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s is not a voice channel", channelID), "channel_id")), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanUseVoice(channelID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.handler.formatError("Permission check failed", err), nil
+	}
+
+	if err := t.handler.discord.Voice().Join(channel.GuildID, channelID); err != nil {
+		return t.handler.formatError("Failed to join voice channel", err), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "voice.joined", channel.Name),
+			Data: map[string]interface{}{
+				"guild_id":     channel.GuildID,
+				"channel_id":   channelID,
+				"channel_name": channel.Name,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *JoinVoiceChannelTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("join_voice_channel", "Join (or move to) a Discord voice channel")
+}
+
+// LeaveVoiceChannelTool implements the leave_voice_channel MCP tool
+type LeaveVoiceChannelTool struct {
+	handler *VoiceHandler
+}
+
+// NewLeaveVoiceChannelTool creates a new leave voice channel tool
+func NewLeaveVoiceChannelTool(handler *VoiceHandler) *LeaveVoiceChannelTool {
+	return &LeaveVoiceChannelTool{handler: handler}
+}
+
+// Execute executes the leave_voice_channel tool
+func (t *LeaveVoiceChannelTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("leave_voice_channel", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+
+	if err := t.handler.discord.Voice().Leave(guildID); err != nil {
+		return t.handler.formatError("Failed to leave voice channel", err), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "voice.left", guildID),
+			Data: map[string]interface{}{
+				"guild_id": guildID,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *LeaveVoiceChannelTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("leave_voice_channel", "Leave the voice channel the bot is connected to in a guild")
+}
+
+// PlayAudioTool implements the play_audio MCP tool
+type PlayAudioTool struct {
+	handler *VoiceHandler
+}
+
+// NewPlayAudioTool creates a new play audio tool
+func NewPlayAudioTool(handler *VoiceHandler) *PlayAudioTool {
+	return &PlayAudioTool{handler: handler}
+}
+
+// Execute executes the play_audio tool
+func (t *PlayAudioTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("play_audio", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	audioURL, _ := params.Arguments["url"].(string)
+	audioData, _ := params.Arguments["audio_base64"].(string)
+
+	if (audioURL == "") == (audioData == "") {
+		return validation.FormatValidationError(validation.NewValidationError("invalid parameters",
+			"exactly one of url or audio_base64 is required", nil)), nil
+	}
+
+	voice := t.handler.discord.Voice()
+	if channelID, ok := voice.ChannelID(guildID); ok {
+		if err := t.handler.permissions.CanUseVoice(channelID); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return t.handler.formatError("Permission check failed", err), nil
+		}
+	}
+
+	var (
+		playbackID string
+		position   int
+		err        error
+	)
+	if audioURL != "" {
+		track, pos, playErr := voice.PlayURL(guildID, audioURL)
+		playbackID, position, err = track.ID, pos, playErr
+	} else {
+		data, decodeErr := base64.StdEncoding.DecodeString(audioData)
+		if decodeErr != nil {
+			return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+				"audio_base64 is not valid base64", "audio_base64")), nil
+		}
+		if len(data) > maxAudioBytes {
+			return validation.FormatValidationError(validation.NewValidationError("invalid parameter",
+				fmt.Sprintf("audio exceeds %d bytes", maxAudioBytes), "audio_base64")), nil
+		}
+
+		source, _ := params.Arguments["filename"].(string)
+		if source == "" {
+			source = "upload"
+		}
+		track, pos, playErr := voice.PlayData(guildID, source, data)
+		playbackID, position, err = track.ID, pos, playErr
+	}
+	if err != nil {
+		return t.handler.formatError("Failed to queue audio", err), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "voice.queued_audio", playbackID, position),
+			Data: map[string]interface{}{
+				"guild_id":       guildID,
+				"playback_id":    playbackID,
+				"queue_position": position,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *PlayAudioTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("play_audio", "Play audio from a URL or uploaded file in the bot's current voice channel")
+}
+
+// SpeakInVoiceTool implements the speak_in_voice MCP tool
+type SpeakInVoiceTool struct {
+	handler *VoiceHandler
+}
+
+// NewSpeakInVoiceTool creates a new speak in voice tool
+func NewSpeakInVoiceTool(handler *VoiceHandler) *SpeakInVoiceTool {
+	return &SpeakInVoiceTool{handler: handler}
+}
+
+// Execute executes the speak_in_voice tool
+func (t *SpeakInVoiceTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("speak_in_voice", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	text := params.Arguments["text"].(string)
+	voiceName, _ := params.Arguments["voice"].(string)
+
+	voice := t.handler.discord.Voice()
+
+	// Join the requested channel first, if any
+	if channelID, ok := params.Arguments["channel_id"].(string); ok {
+		channel, err := t.handler.discord.GetChannel(channelID)
+		if err != nil {
+			return t.handler.formatError("Failed to get channel", err), nil
+		}
+		if channel.GuildID != guildID {
+			return validation.FormatValidationError(validation.NewValidationError("invalid channel",
+				fmt.Sprintf("channel %s is not in guild %s", channelID, guildID), "channel_id")), nil
+		}
+		if channel.Type != discordgo.ChannelTypeGuildVoice && channel.Type != discordgo.ChannelTypeGuildStageVoice {
+			return validation.FormatValidationError(validation.NewValidationError("invalid channel",
+				fmt.Sprintf("channel %s is not a voice channel", channelID), "channel_id")), nil
+		}
+		if current, connected := voice.ChannelID(guildID); !connected || current != channelID {
+			if err := t.handler.permissions.CanUseVoice(channelID); err != nil {
+				if permErr, ok := err.(*permissions.PermissionError); ok {
+					return permissions.FormatPermissionError(permErr), nil
+				}
+				return t.handler.formatError("Permission check failed", err), nil
+			}
+			if err := voice.Join(guildID, channelID); err != nil {
+				return t.handler.formatError("Failed to join voice channel", err), nil
+			}
+		}
+	} else if channelID, ok := voice.ChannelID(guildID); ok {
+		if err := t.handler.permissions.CanUseVoice(channelID); err != nil {
+			if permErr, ok := err.(*permissions.PermissionError); ok {
+				return permissions.FormatPermissionError(permErr), nil
+			}
+			return t.handler.formatError("Permission check failed", err), nil
+		}
+	}
+
+	track, position, err := voice.Speak(guildID, text, voiceName)
+	if err != nil {
+		return t.handler.formatError("Failed to speak in voice", err), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "voice.queued_speech", track.ID, position),
+			Data: map[string]interface{}{
+				"guild_id":       guildID,
+				"playback_id":    track.ID,
+				"queue_position": position,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *SpeakInVoiceTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("speak_in_voice", "Speak text in a voice channel using the configured text-to-speech engine")
+}
@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/jsonfile"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/internal/warnings"
+	"discord-mcp/pkg/types"
+)
+
+// WarnMemberTool implements the warn_member MCP tool
+type WarnMemberTool struct {
+	handler *GuildHandler
+}
+
+// NewWarnMemberTool creates a new warn member tool
+func NewWarnMemberTool(handler *GuildHandler) *WarnMemberTool {
+	return &WarnMemberTool{handler: handler}
+}
+
+// Execute executes the warn_member tool
+func (t *WarnMemberTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("warn_member", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Warnings()
+	if store == nil {
+		return warningsDisabledResult(params), nil
+	}
+
+	warning := warnings.Warning{
+		GuildID: params.Arguments["guild_id"].(string),
+		UserID:  params.Arguments["user_id"].(string),
+		Reason:  params.Arguments["reason"].(string),
+	}
+	warning.Moderator, _ = params.Arguments["moderator"].(string)
+	escalate := true
+	if val, ok := params.Arguments["escalate"].(bool); ok {
+		escalate = val
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(warning.GuildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	saved, active, step, err := store.Add(warning)
+	if errors.Is(err, jsonfile.ErrSave) {
+		return t.formatError("Failed to save warning", err), nil
+	}
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid warning", err.Error(), nil)), nil
+	}
+
+	data := map[string]interface{}{
+		"warning":         saved,
+		"active_warnings": active,
+	}
+	text := i18n.T(i18n.Locale(params), "warnings.added", saved.ID, saved.UserID, active)
+
+	if step != nil && escalate {
+		escalationErr := t.applyStep(saved, *step, active)
+		if err := store.RecordEscalation(saved.ID, step.String(), escalationErr); err != nil {
+			t.handler.logger.Warnf("Failed to record escalation of warning %s: %v", saved.ID, err)
+		}
+		escalation := map[string]interface{}{
+			"action":   step.Action,
+			"warnings": step.Warnings,
+			"applied":  escalationErr == nil,
+		}
+		if step.Action == warnings.ActionTimeout {
+			escalation["duration_minutes"] = int(step.Duration / time.Minute)
+		}
+		if escalationErr != nil {
+			escalation["error"] = escalationErr.Error()
+			text = i18n.T(i18n.Locale(params), "warnings.not_escalated", saved.ID, saved.UserID, active, step.String(), escalationErr)
+		} else {
+			text = i18n.T(i18n.Locale(params), "warnings.escalated", saved.ID, saved.UserID, active, step.String())
+		}
+		data["escalation"] = escalation
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: data,
+		}},
+	}, nil
+}
+
+// applyStep takes an escalation step against the warned member, recording
+// the warning in the audit log reason
+func (t *WarnMemberTool) applyStep(warning warnings.Warning, step warnings.Step, active int) error {
+	operation := map[string]string{
+		warnings.ActionTimeout: "timeout_member",
+		warnings.ActionKick:    "kick_member",
+		warnings.ActionBan:     "ban_member",
+	}[step.Action]
+	if operation == "" {
+		return fmt.Errorf("unknown escalation action %q", step.Action)
+	}
+	if err := t.handler.permissions.CanModerateMember(warning.GuildID, warning.UserID, operation); err != nil {
+		return err
+	}
+
+	reason := fmt.Sprintf("Automatic %s after %d warnings (%s): %s", step.Action, active, warning.ID, warning.Reason)
+	if len(reason) > 512 {
+		reason = reason[:509] + "..."
+	}
+	options := []discordgo.RequestOption{discordgo.WithAuditLogReason(reason)}
+
+	_, err := t.handler.discord.Retry(func() error {
+		session := t.handler.discord.Session()
+		switch step.Action {
+		case warnings.ActionTimeout:
+			until := time.Now().Add(step.Duration)
+			return session.GuildMemberTimeout(warning.GuildID, warning.UserID, &until, options...)
+		case warnings.ActionKick:
+			return session.GuildMemberDelete(warning.GuildID, warning.UserID, options...)
+		default:
+			return session.GuildBanCreate(warning.GuildID, warning.UserID, 0, options...)
+		}
+	})
+	if err != nil {
+		t.handler.logger.Warnf("Failed to %s member %s in guild %s after warning %s: %v", step.Action, warning.UserID, warning.GuildID, warning.ID, err)
+	}
+	return err
+}
+
+// GetDefinition returns the tool definition
+func (t *WarnMemberTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("warn_member", "Record a warning against a server member; once their active warnings reach a configured threshold the member is automatically timed out, kicked or banned")
+}
+
+// formatError creates a standardized error response
+func (t *WarnMemberTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ListWarningsTool implements the list_warnings MCP tool
+type ListWarningsTool struct {
+	handler *GuildHandler
+}
+
+// NewListWarningsTool creates a new list warnings tool
+func NewListWarningsTool(handler *GuildHandler) *ListWarningsTool {
+	return &ListWarningsTool{handler: handler}
+}
+
+// Execute executes the list_warnings tool
+func (t *ListWarningsTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("list_warnings", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Warnings()
+	if store == nil {
+		return warningsDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	userID, _ := params.Arguments["user_id"].(string)
+	includeInactive, _ := params.Arguments["include_inactive"].(bool)
+	limit := intArgument(params.Arguments, "limit", 50)
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	found := store.List(guildID, userID, includeInactive)
+	total := len(found)
+	if len(found) > limit {
+		found = found[:limit]
+	}
+
+	data := map[string]interface{}{
+		"guild_id":      guildID,
+		"total_count":   total,
+		"warning_count": len(found),
+		"warnings":      found,
+	}
+	scope := guildID
+	if userID != "" {
+		scope = userID
+		data["user_id"] = userID
+		data["active_warnings"] = store.ActiveCount(guildID, userID)
+	}
+
+	steps := store.Steps()
+	escalation := make([]map[string]interface{}, 0, len(steps))
+	for _, step := range steps {
+		entry := map[string]interface{}{
+			"warnings": step.Warnings,
+			"action":   step.Action,
+		}
+		if step.Action == warnings.ActionTimeout {
+			entry["duration_minutes"] = int(step.Duration / time.Minute)
+		}
+		escalation = append(escalation, entry)
+	}
+	data["escalation"] = escalation
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "warnings.list", total, scope),
+			Data: data,
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListWarningsTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_warnings", "List the warnings given to a server member, or to every member of the server, newest first, with the configured escalation thresholds")
+}
+
+// formatError creates a standardized error response
+func (t *ListWarningsTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// ClearWarningTool implements the clear_warning MCP tool
+type ClearWarningTool struct {
+	handler *GuildHandler
+}
+
+// NewClearWarningTool creates a new clear warning tool
+func NewClearWarningTool(handler *GuildHandler) *ClearWarningTool {
+	return &ClearWarningTool{handler: handler}
+}
+
+// Execute executes the clear_warning tool
+func (t *ClearWarningTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("clear_warning", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	store := t.handler.discord.Warnings()
+	if store == nil {
+		return warningsDisabledResult(params), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+	warningID, _ := params.Arguments["warning_id"].(string)
+	userID, _ := params.Arguments["user_id"].(string)
+	moderator, _ := params.Arguments["moderator"].(string)
+	reason, _ := params.Arguments["reason"].(string)
+
+	if (warningID == "") == (userID == "") {
+		return validation.FormatValidationError(validation.NewValidationError("warning_id", "exactly one of warning_id or user_id is required", nil)), nil
+	}
+
+	// Validate permissions
+	if err := t.handler.permissions.CanViewGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	cleared, err := store.Clear(guildID, warningID, userID, moderator, reason)
+	if err != nil {
+		return t.formatError("Failed to save warnings", err), nil
+	}
+	if len(cleared) == 0 {
+		target := warningID
+		if target == "" {
+			target = userID
+		}
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "warnings.not_found", target),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"warning_id": warningID,
+					"user_id":    userID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	memberID := cleared[0].UserID
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "warnings.cleared", len(cleared), memberID),
+			Data: map[string]interface{}{
+				"user_id":         memberID,
+				"cleared_count":   len(cleared),
+				"cleared":         cleared,
+				"active_warnings": store.ActiveCount(guildID, memberID),
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ClearWarningTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("clear_warning", "Clear one warning, or every active warning of a member, so it no longer counts toward escalation; cleared warnings stay in the ledger")
+}
+
+// formatError creates a standardized error response
+func (t *ClearWarningTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// warningsDisabledResult reports that warnings are turned off in the
+// configuration
+func warningsDisabledResult(params types.CallToolParams) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "warnings.disabled"),
+			Data: map[string]interface{}{
+				"error_type": "configuration",
+				"message":    "warnings disabled",
+			},
+		}},
+		IsError: true,
+	}
+}
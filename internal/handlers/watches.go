@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/internal/watch"
+	"discord-mcp/pkg/types"
+)
+
+// CreateWatchTool implements the create_watch MCP tool
+type CreateWatchTool struct {
+	discord     *discord.Client
+	permissions *permissions.Checker
+	validator   *validation.Validator
+	logger      *logrus.Logger
+}
+
+// NewCreateWatchTool creates a new create watch tool
+func NewCreateWatchTool(discordClient *discord.Client, permChecker *permissions.Checker, validator *validation.Validator, logger *logrus.Logger) *CreateWatchTool {
+	return &CreateWatchTool{
+		discord:     discordClient,
+		permissions: permChecker,
+		validator:   validator,
+		logger:      logger,
+	}
+}
+
+// Execute executes the create_watch tool
+func (t *CreateWatchTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("create_watch", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	w := watch.Watch{}
+	w.Type, _ = params.Arguments["type"].(string)
+	w.Pattern, _ = params.Arguments["pattern"].(string)
+	w.UserID, _ = params.Arguments["user_id"].(string)
+	w.Emoji, _ = params.Arguments["emoji"].(string)
+	w.GuildID, _ = params.Arguments["guild_id"].(string)
+	w.ChannelID, _ = params.Arguments["channel_id"].(string)
+
+	// Validate permissions
+	if err := t.checkAccess(w.GuildID, w.ChannelID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return discordErrorResult(t.logger, "Permission check failed", err), nil
+	}
+
+	created, err := t.discord.Watches().Add(w)
+	if err != nil {
+		return validation.FormatValidationError(validation.NewValidationError("invalid watch", err.Error(), nil)), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "watches.created", created.Type, created.ID),
+			Data: created,
+		}},
+	}, nil
+}
+
+// checkAccess refuses watches scoped to a guild or channel the access
+// rules exclude
+func (t *CreateWatchTool) checkAccess(guildID, channelID string) error {
+	if guildID != "" {
+		if err := t.permissions.CanViewGuild(guildID); err != nil {
+			return err
+		}
+	}
+	if channelID != "" {
+		return t.permissions.CanAccessChannel(channelID)
+	}
+	return nil
+}
+
+// GetDefinition returns the tool definition
+func (t *CreateWatchTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("create_watch", "Register a trigger (keyword regex, bot mention, user or emoji) that sends discord/watchTriggered notifications")
+}
+
+// ListWatchesTool implements the list_watches MCP tool
+type ListWatchesTool struct {
+	discord   *discord.Client
+	validator *validation.Validator
+}
+
+// NewListWatchesTool creates a new list watches tool
+func NewListWatchesTool(discordClient *discord.Client, validator *validation.Validator) *ListWatchesTool {
+	return &ListWatchesTool{
+		discord:   discordClient,
+		validator: validator,
+	}
+}
+
+// Execute executes the list_watches tool
+func (t *ListWatchesTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("list_watches", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	watches := t.discord.Watches().List()
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "watches.list", len(watches)),
+			Data: map[string]interface{}{
+				"watch_count": len(watches),
+				"watches":     watches,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *ListWatchesTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("list_watches", "List registered message and reaction watches")
+}
+
+// DeleteWatchTool implements the delete_watch MCP tool
+type DeleteWatchTool struct {
+	discord   *discord.Client
+	validator *validation.Validator
+}
+
+// NewDeleteWatchTool creates a new delete watch tool
+func NewDeleteWatchTool(discordClient *discord.Client, validator *validation.Validator) *DeleteWatchTool {
+	return &DeleteWatchTool{
+		discord:   discordClient,
+		validator: validator,
+	}
+}
+
+// Execute executes the delete_watch tool
+func (t *DeleteWatchTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams("delete_watch", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	watchID := params.Arguments["watch_id"].(string)
+	if !t.discord.Watches().Remove(watchID) {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "watches.not_found", watchID),
+				Data: map[string]interface{}{
+					"error_type": "not_found",
+					"watch_id":   watchID,
+				},
+			}},
+			IsError: true,
+		}, nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "watches.deleted", watchID),
+			Data: map[string]interface{}{
+				"watch_id": watchID,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *DeleteWatchTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("delete_watch", "Delete a registered watch")
+}
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// guildWidget is a guild's widget settings as returned by the API
+type guildWidget struct {
+	Enabled   bool    `json:"enabled"`
+	ChannelID *string `json:"channel_id"`
+}
+
+// GetGuildWidgetTool implements the get_guild_widget MCP tool
+type GetGuildWidgetTool struct {
+	handler *GuildHandler
+}
+
+// NewGetGuildWidgetTool creates a new get guild widget tool
+func NewGetGuildWidgetTool(handler *GuildHandler) *GetGuildWidgetTool {
+	return &GetGuildWidgetTool{handler: handler}
+}
+
+// Execute executes the get_guild_widget tool
+func (t *GetGuildWidgetTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_guild_widget", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+
+	// Reading the widget settings needs Manage Server
+	if err := t.handler.permissions.CanManageGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	var widget guildWidget
+	endpoint := discordgo.EndpointGuildWidget(guildID)
+	_, err := t.handler.discord.Retry(func() error {
+		body, err := t.handler.discord.Session().RequestWithBucketID("GET", endpoint, nil, endpoint)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &widget)
+	})
+	if err != nil {
+		return t.formatError("Failed to get guild widget", err), nil
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: widgetText(params, guildID, widget),
+			Data: formatWidget(guildID, widget),
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetGuildWidgetTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_guild_widget", "Get a Discord server's widget settings: whether the widget is enabled and which channel its invite points to")
+}
+
+// formatError creates a standardized error response
+func (t *GetGuildWidgetTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// UpdateGuildWidgetTool implements the update_guild_widget MCP tool
+type UpdateGuildWidgetTool struct {
+	handler *GuildHandler
+}
+
+// NewUpdateGuildWidgetTool creates a new update guild widget tool
+func NewUpdateGuildWidgetTool(handler *GuildHandler) *UpdateGuildWidgetTool {
+	return &UpdateGuildWidgetTool{handler: handler}
+}
+
+// Execute executes the update_guild_widget tool
+func (t *UpdateGuildWidgetTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("update_guild_widget", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+
+	// The request is built by hand because the API client omits an empty
+	// channel, which clearing it needs
+	data := make(map[string]interface{})
+	if enabled, ok := params.Arguments["enabled"].(bool); ok {
+		data["enabled"] = enabled
+	}
+	if channelID, ok := params.Arguments["channel_id"].(string); ok {
+		if channelID == "" {
+			data["channel_id"] = nil
+		} else {
+			data["channel_id"] = channelID
+		}
+	}
+	if len(data) == 0 {
+		return validation.FormatValidationError(validation.NewValidationError("nothing to update",
+			"pass enabled, channel_id or both", nil)), nil
+	}
+
+	// Validate permissions
+	if err := t.checkPermissions(guildID, params.Arguments); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		if validationErr, ok := err.(*validation.ValidationError); ok {
+			return validation.FormatValidationError(validationErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	var widget guildWidget
+	endpoint := discordgo.EndpointGuildWidget(guildID)
+	retries, err := t.handler.discord.Retry(func() error {
+		body, err := t.handler.discord.Session().RequestWithBucketID("PATCH", endpoint, data, endpoint, auditLogOptions(params.Arguments)...)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &widget)
+	})
+	if err != nil {
+		return t.formatError("Failed to update guild widget", err), nil
+	}
+
+	t.handler.logger.Infof("Updated widget of guild %s (enabled: %t)", guildID, widget.Enabled)
+
+	formatted := formatWidget(guildID, widget)
+	formatted["retries"] = retries
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "widget.updated", guildID),
+			Data: formatted,
+		}},
+	}, nil
+}
+
+// checkPermissions checks that the bot can manage the guild and that a new
+// widget channel belongs to it
+func (t *UpdateGuildWidgetTool) checkPermissions(guildID string, args map[string]interface{}) error {
+	if err := t.handler.permissions.CanManageGuild(guildID); err != nil {
+		return err
+	}
+	channelID, _ := args["channel_id"].(string)
+	if channelID == "" {
+		return nil
+	}
+
+	channel, err := t.handler.discord.GetChannel(channelID)
+	if err != nil {
+		return err
+	}
+	if channel.GuildID != guildID {
+		return validation.NewValidationError("invalid channel",
+			fmt.Sprintf("channel %s is not in guild %s", channelID, guildID), "channel_id")
+	}
+	return nil
+}
+
+// GetDefinition returns the tool definition
+func (t *UpdateGuildWidgetTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("update_guild_widget", "Enable or disable a Discord server's widget and set the channel its invite points to")
+}
+
+// formatError creates a standardized error response
+func (t *UpdateGuildWidgetTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// GetVanityURLTool implements the get_vanity_url MCP tool
+type GetVanityURLTool struct {
+	handler *GuildHandler
+}
+
+// NewGetVanityURLTool creates a new get vanity URL tool
+func NewGetVanityURLTool(handler *GuildHandler) *GetVanityURLTool {
+	return &GetVanityURLTool{handler: handler}
+}
+
+// Execute executes the get_vanity_url tool
+func (t *GetVanityURLTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.handler.validator.ValidateToolParams("get_vanity_url", params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	guildID := params.Arguments["guild_id"].(string)
+
+	// Reading the vanity URL needs Manage Server
+	if err := t.handler.permissions.CanManageGuild(guildID); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), nil
+		}
+		return t.formatError("Permission check failed", err), nil
+	}
+
+	// The API client has no call for the vanity URL
+	var vanity struct {
+		Code *string `json:"code"`
+		Uses int     `json:"uses"`
+	}
+	endpoint := discordgo.EndpointGuilds + guildID + "/vanity-url"
+	_, err := t.handler.discord.Retry(func() error {
+		body, err := t.handler.discord.Session().RequestWithBucketID("GET", endpoint, nil, endpoint)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(body, &vanity)
+	})
+	if err != nil {
+		return t.formatError("Failed to get vanity URL", err), nil
+	}
+
+	// Guilds without the vanity URL feature have no code
+	if vanity.Code == nil || *vanity.Code == "" {
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(i18n.Locale(params), "vanity.none", guildID),
+				Data: map[string]interface{}{
+					"guild_id": guildID,
+					"code":     nil,
+					"uses":     vanity.Uses,
+				},
+			}},
+		}, nil
+	}
+
+	url := "https://discord.gg/" + *vanity.Code
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(i18n.Locale(params), "vanity.info", url, vanity.Uses),
+			Data: map[string]interface{}{
+				"guild_id": guildID,
+				"code":     *vanity.Code,
+				"uses":     vanity.Uses,
+				"url":      url,
+			},
+		}},
+	}, nil
+}
+
+// GetDefinition returns the tool definition
+func (t *GetVanityURLTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition("get_vanity_url", "Get a Discord server's vanity invite URL and how many times it has been used")
+}
+
+// formatError creates a standardized error response
+func (t *GetVanityURLTool) formatError(message string, err error) types.CallToolResult {
+	return discordErrorResult(t.handler.logger, message, err)
+}
+
+// formatWidget formats widget settings for the response, with the public
+// widget URLs
+func formatWidget(guildID string, widget guildWidget) map[string]interface{} {
+	var channelID interface{}
+	if widget.ChannelID != nil {
+		channelID = *widget.ChannelID
+	}
+	return map[string]interface{}{
+		"guild_id":   guildID,
+		"enabled":    widget.Enabled,
+		"channel_id": channelID,
+		"json_url":   fmt.Sprintf("https://discord.com/api/guilds/%s/widget.json", guildID),
+		"image_url":  fmt.Sprintf("https://discord.com/api/guilds/%s/widget.png", guildID),
+	}
+}
+
+// widgetText describes the widget settings
+func widgetText(params types.CallToolParams, guildID string, widget guildWidget) string {
+	if !widget.Enabled {
+		return i18n.T(i18n.Locale(params), "widget.disabled", guildID)
+	}
+	return i18n.T(i18n.Locale(params), "widget.enabled", guildID)
+}
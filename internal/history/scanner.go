@@ -0,0 +1,250 @@
+// Package history pages backwards through channel message history for the
+// tools that scan it, reading several channels at once and returning a
+// cursor that resumes a scan that stopped early.
+package history
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// PageSize is the most messages Discord returns per request
+const PageSize = 100
+
+// Fetcher reads up to limit messages of a channel older than beforeID,
+// newest first; an empty beforeID reads from the newest message
+type Fetcher func(channelID string, limit int, beforeID string) ([]*discordgo.Message, error)
+
+// Request describes a history scan
+type Request struct {
+	ChannelIDs []string
+	// BeforeID starts each channel below this message ID; "" starts at the
+	// newest message
+	BeforeID string
+	// After stops a channel at its first message older than this time
+	After time.Time
+	// Limit caps the messages read per channel; 0 reads to the start
+	Limit int
+	// Cursor resumes a scan from the positions a previous result returned
+	Cursor string
+	// Progress is called after every page with the totals so far
+	Progress func(Progress)
+	// Page is called with the messages kept from every page, newest first.
+	// Channels are read concurrently, so it may be called from several
+	// goroutines at once.
+	Page func(channelID string, messages []*discordgo.Message)
+}
+
+// Progress reports how far a scan has come
+type Progress struct {
+	Channels     int
+	ChannelsDone int
+	Pages        int
+	Messages     int
+}
+
+// Result holds the messages a scan read, newest first per channel
+type Result struct {
+	Messages map[string][]*discordgo.Message
+	Pages    int
+	// Complete is true when every channel was read to its end or to After
+	Complete bool
+	// Cursor resumes the channels that stopped early; "" when complete
+	Cursor string
+	// Errors holds the failure that stopped each failed channel
+	Errors map[string]error
+	// RateLimited is true when a channel stopped on a rate limit that
+	// outlasted the retries
+	RateLimited bool
+}
+
+// Scanner reads channel history with a bounded number of channels in flight
+type Scanner struct {
+	fetch       Fetcher
+	concurrency int
+}
+
+// NewScanner creates a scanner that reads at most concurrency channels at
+// once
+func NewScanner(fetch Fetcher, concurrency int) *Scanner {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Scanner{fetch: fetch, concurrency: concurrency}
+}
+
+// ErrInvalidCursor is returned for a cursor that was not produced by a scan
+var ErrInvalidCursor = errors.New("invalid scan cursor")
+
+// channelScan is one channel's position and messages
+type channelScan struct {
+	channelID string
+	beforeID  string
+	messages  []*discordgo.Message
+	// stopped is true when the channel stopped before its end
+	stopped bool
+	err     error
+}
+
+// Scan reads the requested channels and returns what was read, stopping a
+// channel at its limit or its first failure. Messages already read are
+// returned with a cursor either way.
+func (s *Scanner) Scan(req Request) (Result, error) {
+	positions := make(map[string]string, len(req.ChannelIDs))
+	for _, channelID := range req.ChannelIDs {
+		positions[channelID] = req.BeforeID
+	}
+	if req.Cursor != "" {
+		resumed, err := DecodeCursor(req.Cursor)
+		if err != nil {
+			return Result{}, err
+		}
+		// Channels missing from the cursor were already finished
+		for channelID := range positions {
+			beforeID, ok := resumed[channelID]
+			if !ok {
+				delete(positions, channelID)
+				continue
+			}
+			positions[channelID] = beforeID
+		}
+	}
+
+	scans := make([]*channelScan, 0, len(positions))
+	for _, channelID := range req.ChannelIDs {
+		if beforeID, ok := positions[channelID]; ok {
+			scans = append(scans, &channelScan{channelID: channelID, beforeID: beforeID})
+		}
+	}
+
+	var (
+		progress = Progress{Channels: len(scans)}
+		mutex    sync.Mutex
+		wg       sync.WaitGroup
+		slots    = make(chan struct{}, s.concurrency)
+	)
+	report := func(pages, messages, done int) {
+		mutex.Lock()
+		progress.Pages += pages
+		progress.Messages += messages
+		progress.ChannelsDone += done
+		current := progress
+		mutex.Unlock()
+		if req.Progress != nil {
+			req.Progress(current)
+		}
+	}
+
+	for _, scan := range scans {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(scan *channelScan) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			s.scanChannel(scan, req, report)
+		}(scan)
+	}
+	wg.Wait()
+
+	result := Result{
+		Messages: make(map[string][]*discordgo.Message, len(scans)),
+		Pages:    progress.Pages,
+		Complete: true,
+		Errors:   make(map[string]error),
+	}
+	remaining := make(map[string]string)
+	for _, scan := range scans {
+		result.Messages[scan.channelID] = scan.messages
+		if scan.err != nil {
+			result.Errors[scan.channelID] = scan.err
+			var rateLimitErr *discordgo.RateLimitError
+			if errors.As(scan.err, &rateLimitErr) {
+				result.RateLimited = true
+			}
+		}
+		if scan.stopped {
+			remaining[scan.channelID] = scan.beforeID
+		}
+	}
+	if len(remaining) > 0 {
+		result.Complete = false
+		result.Cursor = encodeCursor(remaining)
+	}
+	return result, nil
+}
+
+// scanChannel pages backwards through one channel, leaving beforeID at the
+// next page to read
+func (s *Scanner) scanChannel(scan *channelScan, req Request, report func(pages, messages, done int)) {
+	for {
+		pageSize := PageSize
+		if req.Limit > 0 {
+			left := req.Limit - len(scan.messages)
+			if left <= 0 {
+				scan.stopped = true
+				report(0, 0, 1)
+				return
+			}
+			if left < pageSize {
+				pageSize = left
+			}
+		}
+
+		page, err := s.fetch(scan.channelID, pageSize, scan.beforeID)
+		if err != nil {
+			scan.err = err
+			scan.stopped = true
+			report(0, 0, 1)
+			return
+		}
+
+		kept := 0
+		done := len(page) < pageSize
+		for _, msg := range page {
+			if !req.After.IsZero() && msg.Timestamp.Before(req.After) {
+				done = true
+				break
+			}
+			scan.messages = append(scan.messages, msg)
+			scan.beforeID = msg.ID
+			kept++
+		}
+		if req.Page != nil && kept > 0 {
+			req.Page(scan.channelID, page[:kept])
+		}
+
+		if done || len(page) == 0 {
+			report(1, kept, 1)
+			return
+		}
+		report(1, kept, 0)
+	}
+}
+
+// encodeCursor packs each unfinished channel's next position
+func encodeCursor(positions map[string]string) string {
+	raw, _ := json.Marshal(positions)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor unpacks a cursor into the next position of each unfinished
+// channel
+func DecodeCursor(cursor string) (map[string]string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	var positions map[string]string
+	if err := json.Unmarshal(raw, &positions); err != nil || len(positions) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return positions, nil
+}
@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"discord-mcp/pkg/types"
+)
+
+// DefaultLocale is used when no locale is configured or requested, and for
+// messages missing from a catalog
+const DefaultLocale = "en"
+
+// catalogs maps locales to their messages, keyed by message ID
+var catalogs = map[string]map[string]string{
+	"en": messagesEN,
+	"es": messagesES,
+	"de": messagesDE,
+	"fr": messagesFR,
+	"pt": messagesPT,
+}
+
+// Supported returns the locales with a message catalog, sorted
+func Supported() []string {
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Normalize maps a locale tag such as "pt-BR" or "de_AT" to a supported
+// locale, or returns an empty string when there is no catalog for it
+func Normalize(locale string) string {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+	if _, ok := catalogs[locale]; ok {
+		return locale
+	}
+	if i := strings.Index(locale, "-"); i > 0 {
+		if _, ok := catalogs[locale[:i]]; ok {
+			return locale[:i]
+		}
+	}
+	return ""
+}
+
+// Locale returns the locale a tool call should respond in. The server sets
+// _meta.locale on every call, from the client's request or the configured
+// default.
+func Locale(params types.CallToolParams) string {
+	if params.Meta == nil {
+		return DefaultLocale
+	}
+	if locale := Normalize(params.Meta.Locale); locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// T returns the message for an ID in a locale, formatted with args. Messages
+// missing from the locale's catalog fall back to English.
+func T(locale, id string, args ...interface{}) string {
+	format, ok := catalogs[Normalize(locale)][id]
+	if !ok {
+		format, ok = messagesEN[id]
+	}
+	if !ok {
+		format = id
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,230 @@
+package i18n
+
+// messagesDE is the German catalog
+var messagesDE = map[string]string{
+	// Server
+	"server.not_initialized":     "Server ist nicht initialisiert",
+	"server.tool_not_found":      "Tool nicht gefunden: %s",
+	"server.discord_unavailable": "Discord ist nicht erreichbar (Verbindungsstatus: %s), bitte gleich erneut versuchen",
+	"server.call_queued":         "⏳ Discord ist nicht erreichbar, daher wurde %s als %s eingereiht und läuft nach der Wiederverbindung (Verbindungsstatus: %s)",
+	"server.call_replaying":      "⏳ %s ist bereits als %s eingereiht und läuft in Kürze; das Ergebnis wird als discord/queuedCallCompleted gesendet",
+	"server.tool_failed":         "Tool-Ausführung fehlgeschlagen: %v",
+
+	// Errors
+	"error.validation": "❌ Validierungsfehler: %s",
+	"error.permission": "🔒 Berechtigungsfehler: %s",
+	"error.discord":    "❌ %s: %s",
+	"error.hint":       "💡 %s",
+	"error.name":       "❌ %v",
+
+	// Result paging
+	"response.truncated": "✂️ %d von %d %s zurückgegeben; das Tool mit result_cursor %q erneut aufrufen, um mehr zu erhalten",
+	"response.continued": "📄 %s: %s ab Eintrag %d von %d",
+
+	// Policies
+	"policy.confirm": "⚠️ %s muss bestätigt werden (%s). Zum Fortfahren mit \"confirm\": true erneut ausführen",
+	"policy.denied":  "🚫 Richtlinie verweigert %s (%s)",
+
+	// General
+	"ping.failed":           "Verbindung zu Discord fehlgeschlagen: %v",
+	"ping.bot_user_failed":  "Bot-Informationen konnten nicht abgerufen werden: %v",
+	"ping.healthy":          "✅ Discord-MCP-Server ist betriebsbereit!\n\n🤖 Bot: %s#%s (ID: %s)\n📡 Verbunden: %t\n⏱️ Antwortzeit: %v\n🕒 Zeitstempel: %s",
+	"bot.info":              "🤖 %s (ID: %s), %d Server, %d Tools, seit %s aktiv, %d/%d Anfragen in dieser Minute übrig",
+	"stats.summary":         "📊 %d Tool-Aufrufe an %d Tools (%d fehlgeschlagen) in %s; %d Benachrichtigungen gesendet, %d Wartezeiten wegen Discord-Ratenlimits",
+	"bot.presence":          "✅ Status auf %s gesetzt",
+	"bot.presence_activity": "✅ Status auf %s gesetzt (%s %s)",
+	"cache.stats":           "📊 Cache aktiviert: %t",
+	"events.disabled":       "❌ Ereignispuffer ist deaktiviert (events.buffer.enabled)",
+	"events.polled":         "📬 %d Ereignisse seit Cursor %d",
+	"events.polled_missed":  "📬 %d Ereignisse seit Cursor %d (%d ältere Ereignisse wurden vor dieser Abfrage verworfen)",
+	"snowflake.parsed":      "🕒 %s wurde am %s erstellt",
+	"snowflake.boundary":    "🕒 Snowflake-Grenze für %s: %s",
+
+	// Guilds, users and permissions
+	"guilds.info":              "Server: %s",
+	"guilds.members":           "%d Mitglieder im Server %s gefunden",
+	"widget.enabled":           "Das Widget von Server %s ist aktiviert",
+	"widget.disabled":          "Das Widget von Server %s ist deaktiviert",
+	"widget.updated":           "✅ Widget von Server %s aktualisiert",
+	"vanity.info":              "Vanity-URL: %s (%d Nutzungen)",
+	"vanity.none":              "Server %s hat keine Vanity-URL",
+	"integrations.list":        "%d Integrationen und %d Bots im Server %s gefunden",
+	"preview.info":             "Server %s: etwa %d Mitglieder, %d online, %d eigene Emojis",
+	"commands.list":            "%d Slash-Befehle in Server %s gefunden",
+	"commands.not_found":       "Befehl %s nicht gefunden",
+	"commands.permissions_set": "✅ %d Berechtigungen für Befehl %s gesetzt",
+	"commands.token_missing":   "❌ Zum Ändern von Befehlsberechtigungen wird ein OAuth2-Bearer-Token benötigt (discord.command_permissions_token)",
+	"analytics.summary":        "📊 Statistiken für %s der letzten %d Tage",
+	"users.info":               "👤 Benutzer: %s",
+	"permissions.summary":      "🔐 %d von %d Operationen erlaubt",
+	"permissions.denied":       "❌ %s: %s",
+	"permissions.missing":      " (fehlt: %v)",
+	"permissions.matrix":       "🔐 Berechtigungsmatrix für %d Rollen, %d riskante Berechtigungen",
+	"permissions.risk":         "⚠️ [%s] %s",
+	"resolve.match":            "✅ %q aufgelöst zu %s %s (%s)",
+	"resolve.ambiguous":        "❓ %q passt auf %d Einträge vom Typ %s; bitte einen der Kandidaten wählen",
+	"resolve.no_match":         "❌ Kein %s passt auf %q",
+
+	// Channels and roles
+	"channels.list":       "%d Kanäle im Server %s gefunden",
+	"channels.info":       "Kanal: %s",
+	"transcript.built":    "🧵 Verlauf von %s: %d Nachrichten von %d Teilnehmern",
+	"summarizer.disabled": "❌ Zusammenfassungen sind nicht verfügbar: kein Summarizer konfiguriert (summarizer.backend)",
+	"summarizer.summary":  "📝 Zusammenfassung:",
+	"follows.created":     "📣 Kanal %s folgt jetzt dem Ankündigungskanal %s",
+	"follows.list":        "%d gefolgte Ankündigungskanäle in %s gefunden",
+	"roles.list":          "%d Rollen im Server %s gefunden",
+	"roles.info":          "Rolle: %s",
+	"roles.created":       "Rolle erstellt: %s",
+	"roles.deleted":       "Rolle mit ID %s gelöscht",
+	"roles.assigned":      "Rolle %s an Benutzer %s vergeben",
+	"roles.unassigned":    "Rolle %s von Benutzer %s entfernt",
+	"structure.exported":  "🏗️ %d Rollen, %d Kategorien und %d Kanäle aus Server %s exportiert",
+	"structure.planned":   "📋 %d Änderungen für Server %s geplant (Probelauf)",
+	"structure.unchanged": "✅ Server %s entspricht bereits der Struktur",
+	"structure.applied":   "✅ %d Änderungen auf Server %s angewendet",
+
+	// Messages
+	"messages.sent":             "✅ Nachricht an <#%s> gesendet",
+	"messages.sent_split":       "✅ Nachricht an <#%s> als %d Nachrichten gesendet",
+	"broadcast.sent":            "📣 Rundsendung an %d von %d Kanälen gesendet",
+	"broadcast.preview":         "🔎 Rundsendung würde %d von %d Kanälen erreichen",
+	"messages.retrieved":        "📨 %d Nachrichten aus <#%s> abgerufen",
+	"messages.retrieved_images": "📨 %d Nachrichten aus <#%s> mit %d Bildern abgerufen",
+	"messages.edited":           "✏️ Nachricht in <#%s> bearbeitet",
+	"messages.deleted":          "🗑️ Nachricht aus <#%s> gelöscht",
+	"reactions.added":           "👍 Reaktion %s zu Nachricht in <#%s> hinzugefügt",
+	"reactions.stats":           "📈 %d Reaktionen auf %d von %d Nachrichten in <#%s>",
+	"emoji.image":               "🖼️ Emoji-Bild für %s: %s",
+	"embeds.built":              "🧱 %d Embeds für %d Nachricht(en) erstellt. Jeden Eintrag von 'messages' als Embeds eines send_message-Aufrufs übergeben",
+	"export.exported":           "📦 %d Nachrichten aus #%s exportiert",
+	"export.written":            "📦 %d Nachrichten aus #%s nach %s exportiert",
+	"archive.disabled":          "❌ Das Nachrichtenarchiv ist deaktiviert (archive.enabled)",
+	"archive.search_failed":     "❌ Archivsuche fehlgeschlagen: %v",
+	"archive.found":             "🔎 %d archivierte Nachrichten gefunden",
+	"templates.list":            "📄 %d Vorlagen gefunden",
+	"templates.list_failures":   "📄 %d Vorlagen gefunden (%d Dateien konnten nicht geladen werden)",
+	"templates.rendered":        "📝 Vorlage %s gerendert",
+	"templates.sent":            "✅ Vorlage %s an <#%s> gesendet",
+	"schedule.created":          "⏰ Wiederkehrender Beitrag %s für Kanal %s erstellt, nächste Ausführung %s",
+	"schedule.list":             "%d wiederkehrende Beiträge gefunden",
+	"schedule.not_found":        "❌ Wiederkehrender Beitrag %s nicht gefunden",
+	"schedule.deleted":          "🗑️ Wiederkehrender Beitrag %s gelöscht",
+	"schedule.disabled":         "❌ Wiederkehrende Beiträge sind deaktiviert (schedule.enabled)",
+	"giveaways.started":         "🎉 Gewinnspiel %s für %s in Kanal %s gestartet",
+	"giveaways.list":            "%d Gewinnspiele gefunden",
+	"giveaways.not_found":       "❌ Gewinnspiel %s nicht gefunden",
+	"giveaways.drawn":           "🏆 %d Gewinner für Gewinnspiel %s aus %d Teilnehmern gezogen",
+	"giveaways.no_entrants":     "⚠️ Gewinnspiel %s hat noch keine berechtigten Teilnehmer",
+	"giveaways.disabled":        "❌ Gewinnspiele sind deaktiviert (giveaways.enabled)",
+	"giveaways.announcement":    "🎉 Glückwunsch %s, du hast **%s** gewonnen!",
+	"giveaways.enter":           "Reagiere mit %s, um teilzunehmen",
+	"giveaways.winner_count":    "Gewinner: %d",
+	"giveaways.ends":            "Endet %s",
+	"giveaways.winners":         "🏆 Gewinner: %s",
+	"starboard.created":         "⭐ Starboard für Server %s in <#%s> erstellt (%d × %s)",
+	"starboard.updated":         "⭐ Starboard für Server %s in <#%s> aktualisiert (%d × %s)",
+	"starboard.list":            "%d Starboard-Einträge in Server %s gefunden",
+	"starboard.not_configured":  "❌ Server %s hat kein Starboard",
+	"starboard.disabled":        "❌ Das Starboard ist deaktiviert (starboard.enabled)",
+	"pinning.created":           "📌 Anheft-Regel für <#%s> erstellt (Limit %d)",
+	"pinning.updated":           "📌 Anheft-Regel für <#%s> aktualisiert (Limit %d)",
+	"pinning.list":              "%d Anheft-Regeln gefunden",
+	"pinning.not_found":         "❌ Kanal %s hat keine Anheft-Regel",
+	"pinning.deleted":           "🗑️ Anheft-Regel für <#%s> entfernt",
+	"pinning.disabled":          "❌ Anheft-Regeln sind deaktiviert (pinning.enabled)",
+	"notes.added":               "📝 Notiz %s zu Benutzer %s hinzugefügt",
+	"notes.list":                "%d Notizen zu %s gefunden",
+	"notes.not_found":           "❌ Notiz %s nicht gefunden",
+	"notes.deleted":             "🗑️ Notiz %s zu Benutzer %s gelöscht",
+	"notes.disabled":            "❌ Benutzernotizen sind deaktiviert (user_notes.enabled)",
+	"warnings.added":            "⚠️ Verwarnung %s an Benutzer %s erteilt (%d aktiv)",
+	"warnings.escalated":        "⚠️ Verwarnung %s an Benutzer %s erteilt (%d aktiv) und %s angewendet",
+	"warnings.not_escalated":    "⚠️ Verwarnung %s an Benutzer %s erteilt (%d aktiv), aber %s konnte nicht angewendet werden: %v",
+	"warnings.list":             "%d Verwarnungen für %s gefunden",
+	"warnings.not_found":        "❌ Keine aktive Verwarnung für %s gefunden",
+	"warnings.cleared":          "✅ %d Verwarnungen von Benutzer %s aufgehoben",
+	"warnings.disabled":         "❌ Verwarnungen sind deaktiviert (warnings.enabled)",
+	"tickets.opened":            "🎫 Ticket %s für Benutzer %s in <#%s> eröffnet",
+	"tickets.replied":           "✉️ Benutzer %s in Ticket %s geantwortet",
+	"tickets.closed":            "🔒 Ticket %s geschlossen und seine %d Nachrichten in %s gespeichert",
+	"tickets.list":              "%d Tickets (%s) gefunden",
+	"tickets.not_found":         "❌ Offenes Ticket %s nicht gefunden",
+	"tickets.disabled":          "❌ Tickets sind deaktiviert (tickets.enabled)",
+	"rolemenus.created":         "📋 Rollenmenü %s mit %d Rollen in <#%s> gepostet",
+	"rolemenus.list":            "%d Rollenmenüs gefunden",
+	"rolemenus.not_found":       "❌ Rollenmenü %s nicht gefunden",
+	"rolemenus.deleted":         "🗑️ Rollenmenü %s entfernt",
+	"rolemenus.disabled":        "❌ Rollenmenüs sind deaktiviert (role_menus.enabled)",
+	"verify.configured":         "🛂 Verifizierung für Server %s gesetzt: neue Mitglieder erhalten Rolle %s",
+	"verify.verified":           "✅ %s in Server %s verifiziert",
+	"verify.list":               "%d unverifizierte Mitglieder gefunden",
+	"verify.not_configured":     "❌ Für Server %s ist keine Verifizierung eingerichtet",
+	"verify.disabled":           "❌ Verifizierung ist deaktiviert (verification.enabled)",
+	"bridges.created":           "🌉 Brücke %s von <#%s> nach <#%s> erstellt",
+	"bridges.list":              "%d Brücken gefunden",
+	"bridges.not_found":         "❌ Brücke %s nicht gefunden",
+	"bridges.deleted":           "🗑️ Brücke %s entfernt",
+	"bridges.disabled":          "❌ Brücken sind deaktiviert (bridges.enabled)",
+	"relays.created":            "🔗 Relay %s nach <#%s> erstellt; JSON-Payloads per POST an %s senden",
+	"relays.list":               "%d Relays gefunden",
+	"relays.not_found":          "❌ Relay %s nicht gefunden",
+	"relays.deleted":            "🗑️ Relay %s entfernt",
+	"relays.disabled":           "❌ Das Relay ist deaktiviert (relay.enabled)",
+	"feeds.added":               "📰 Feed %s (%s) wird in <#%s> beobachtet; nächste Prüfung um %s",
+	"feeds.list":                "%d Feeds gefunden",
+	"feeds.not_found":           "❌ Feed %s nicht gefunden",
+	"feeds.removed":             "🗑️ Feed %s wird nicht mehr beobachtet",
+	"feeds.disabled":            "❌ Feeds sind deaktiviert (feeds.enabled)",
+	"raid.armed":                "🛡️ Raid-Schutz für Server %s aktiviert",
+	"raid.disarmed":             "🛡️ Raid-Schutz für Server %s deaktiviert (%d Aktionen rückgängig gemacht)",
+	"raid.not_armed":            "❌ Raid-Schutz ist für Server %s nicht aktiv",
+	"raid.incidents":            "%d Raid-Vorfälle in %d geschützten Servern gefunden",
+	"raid.disabled":             "❌ Raid-Schutz ist deaktiviert (raid.enabled)",
+	"undo.done":                 "↩️ %s-Aktion %s rückgängig gemacht",
+	"undo.not_found":            "❌ Aktion %s wurde nicht gefunden oder kann nicht mehr rückgängig gemacht werden",
+	"undo.nothing":              "❌ Keine aktuelle Aktion zum Rückgängigmachen",
+	"undo.disabled":             "❌ Rückgängigmachen ist deaktiviert (undo.enabled)",
+	"plan.checked":              "✅ Alle %d Planschritte haben die Prüfungen bestanden",
+	"plan.completed":            "✅ Alle %d Planschritte ausgeführt",
+	"plan.failed":               "❌ Planschritt %d (%s) ist fehlgeschlagen, nachdem %d von %d Schritten ausgeführt wurden; %d rückgängig gemacht",
+	"plan.rejected":             "❌ Planschritt %d (%s) wurde abgelehnt, daher wurde nichts ausgeführt: %s",
+	"members.streaming":         "📥 Mitglieder des Servers %s werden als Stream %s angefordert",
+	"members.not_found":         "❌ Mitglieder-Stream %s wurde nicht gefunden oder ist abgelaufen",
+	"members.page":              "%d von %d gesammelten Mitgliedern des Streams %s zurückgegeben (%s)",
+	"nicks.audited":             "🔍 %d Mitglieder geprüft: %d markiert, %d umbenannt, %d nicht umbenannt",
+	"membership.log":            "%d Beitritts- und Austrittsereignisse für Server %s gefunden",
+	"membership.stats":          "📈 %d Beitritte und %d Austritte auf Server %s in %d Tagen (netto %+d)",
+	"membership.disabled":       "❌ Das Mitgliederprotokoll ist deaktiviert (membership_log.enabled)",
+	"threads.stale":             "🧵 %d Threads auf Server %s werden innerhalb von %d Stunden automatisch archiviert",
+	"duplicates.found":          "🔁 %d Gruppen doppelter Nachrichten in %d Nachrichten aus %d Kanälen gefunden",
+	"safety.blocked":            "🛡️ Nachricht nicht gesendet: %d unsichere Links oder Anhänge gefunden",
+	"cooldowns.disabled":        "❌ Antwort-Cooldowns sind deaktiviert (response_cooldowns.enabled)",
+	"cooldowns.reset":           "⏱️ %d Antwort-Cooldowns zurückgesetzt",
+	"outbound.disabled":         "❌ Die Sendewarteschlange ist deaktiviert (outbound_queue.enabled)",
+	"outbound.stats":            "📤 %d Nachrichten in der Warteschlange (%d interaktiv, %d normal, %d Massenversand)",
+	"traces.list":               "🔎 %d aktuelle Discord-Traces (Tracing ist an)",
+	"traces.off":                "🔎 %d aktuelle Discord-Traces; Tracing ist aus (trace.enabled aktivieren oder logging/setLevel debug senden)",
+	"watches.created":           "👀 %s-Überwachung %s erstellt",
+	"watches.list":              "%d Überwachungen gefunden",
+	"watches.not_found":         "❌ Überwachung %s nicht gefunden",
+	"watches.deleted":           "🗑️ Überwachung %s gelöscht",
+	"autoresponses.created":     "💬 Automatische Antwort (%s) %s erstellt",
+	"autoresponses.list":        "%d automatische Antworten gefunden",
+	"autoresponses.not_found":   "❌ Automatische Antwort %s nicht gefunden",
+	"autoresponses.deleted":     "🗑️ Automatische Antwort %s gelöscht",
+	"onboarding.created":        "👋 Onboarding-Regel %s für Server %s erstellt",
+	"onboarding.updated":        "👋 Onboarding-Regel %s für Server %s aktualisiert",
+	"onboarding.list":           "%d Onboarding-Regeln gefunden",
+	"onboarding.disabled":       "❌ Onboarding ist deaktiviert (onboarding.enabled)",
+
+	// Voice
+	"voice.joined":        "🔊 Sprachkanal %s beigetreten",
+	"voice.left":          "👋 Sprachkanal im Server %s verlassen",
+	"voice.queued_audio":  "▶️ Audio %s eingereiht (Position %d). Am Ende wird eine discord/playbackFinished-Benachrichtigung gesendet",
+	"voice.queued_speech": "🗣️ Sprachausgabe %s eingereiht (Position %d). Am Ende wird eine discord/playbackFinished-Benachrichtigung gesendet",
+	"soundboard.list":     "%d Soundboard-Sounds in Server %s gefunden",
+	"soundboard.uploaded": "✅ Soundboard-Sound %s (%s) hochgeladen",
+	"soundboard.deleted":  "🗑️ Soundboard-Sound %s (%s) gelöscht",
+	"soundboard.played":   "🔊 Soundboard-Sound %s in Kanal %s abgespielt",
+}
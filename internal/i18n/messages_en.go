@@ -0,0 +1,231 @@
+package i18n
+
+// messagesEN is the English catalog. Every message ID must be defined here;
+// other catalogs fall back to it.
+var messagesEN = map[string]string{
+	// Server
+	"server.not_initialized":     "Server not initialized",
+	"server.tool_not_found":      "Tool not found: %s",
+	"server.discord_unavailable": "Discord is unavailable (connection state: %s), try again shortly",
+	"server.call_queued":         "⏳ Discord is unavailable, so %s was queued as %s and will run once it reconnects (connection state: %s)",
+	"server.call_replaying":      "⏳ %s is already queued as %s and runs shortly; its outcome is sent as discord/queuedCallCompleted",
+	"server.tool_failed":         "Tool execution failed: %v",
+
+	// Errors
+	"error.validation": "❌ Validation Error: %s",
+	"error.permission": "🔒 Permission Error: %s",
+	"error.discord":    "❌ %s: %s",
+	"error.hint":       "💡 %s",
+	"error.name":       "❌ %v",
+
+	// Result paging
+	"response.truncated": "✂️ Returned %d of %d %s; call the tool again with result_cursor %q for more",
+	"response.continued": "📄 %s: %s from item %d of %d",
+
+	// Policies
+	"policy.confirm": "⚠️ %s requires confirmation (%s). Re-run with \"confirm\": true to proceed",
+	"policy.denied":  "🚫 Policy denied %s (%s)",
+
+	// General
+	"ping.failed":           "Discord connection failed: %v",
+	"ping.bot_user_failed":  "Failed to get bot user info: %v",
+	"ping.healthy":          "✅ Discord MCP Server is healthy!\n\n🤖 Bot: %s#%s (ID: %s)\n📡 Connected: %t\n⏱️ Response time: %v\n🕒 Timestamp: %s",
+	"bot.info":              "🤖 %s (ID: %s), %d guilds, %d tools, up %s, %d/%d requests left this minute",
+	"stats.summary":         "📊 %d tool calls to %d tools (%d failed) in %s; %d notifications sent, %d Discord rate-limit waits",
+	"bot.presence":          "✅ Presence set to %s",
+	"bot.presence_activity": "✅ Presence set to %s (%s %s)",
+	"cache.stats":           "📊 Cache enabled: %t",
+	"events.disabled":       "❌ Event buffering is disabled (events.buffer.enabled)",
+	"events.polled":         "📬 %d events since cursor %d",
+	"events.polled_missed":  "📬 %d events since cursor %d (%d older events were evicted before this poll)",
+	"snowflake.parsed":      "🕒 %s was created at %s",
+	"snowflake.boundary":    "🕒 Snowflake boundary for %s: %s",
+
+	// Guilds, users and permissions
+	"guilds.info":              "Guild: %s",
+	"guilds.members":           "Found %d members in guild %s",
+	"widget.enabled":           "Widget of server %s is enabled",
+	"widget.disabled":          "Widget of server %s is disabled",
+	"widget.updated":           "✅ Updated the widget of server %s",
+	"vanity.info":              "Vanity URL: %s (%d uses)",
+	"vanity.none":              "Server %s has no vanity URL",
+	"integrations.list":        "Found %d integrations and %d bots in guild %s",
+	"preview.info":             "Server %s: about %d members, %d online, %d custom emojis",
+	"commands.list":            "Found %d slash commands in guild %s",
+	"commands.not_found":       "Command %s not found",
+	"commands.permissions_set": "✅ Set %d permission overwrites on command %s",
+	"commands.token_missing":   "❌ Changing command permissions needs an OAuth2 bearer token (discord.command_permissions_token)",
+	"analytics.summary":        "📊 Analytics for %s over the last %d days",
+	"users.info":               "👤 User: %s",
+	"permissions.summary":      "🔐 %d of %d operations allowed",
+	"permissions.denied":       "❌ %s: %s",
+	"permissions.missing":      " (missing %v)",
+	"permissions.matrix":       "🔐 Permission matrix for %d roles, %d risky grants",
+	"permissions.risk":         "⚠️ [%s] %s",
+	"resolve.match":            "✅ %q resolved to %s %s (%s)",
+	"resolve.ambiguous":        "❓ %q matches %d %ss; pick one of the candidates",
+	"resolve.no_match":         "❌ No %s matches %q",
+
+	// Channels and roles
+	"channels.list":       "Found %d channels in guild %s",
+	"channels.info":       "Channel: %s",
+	"transcript.built":    "🧵 Transcript of %s: %d messages from %d participants",
+	"summarizer.disabled": "❌ Summaries are not available: no summarizer is configured (summarizer.backend)",
+	"summarizer.summary":  "📝 Summary:",
+	"follows.created":     "📣 Channel %s now follows announcement channel %s",
+	"follows.list":        "Found %d announcement follows in %s",
+	"roles.list":          "Found %d roles in guild %s",
+	"roles.info":          "Role: %s",
+	"roles.created":       "Created role: %s",
+	"roles.deleted":       "Deleted role with ID: %s",
+	"roles.assigned":      "Assigned role %s to user %s",
+	"roles.unassigned":    "Unassigned role %s from user %s",
+	"structure.exported":  "🏗️ Exported %d roles, %d categories and %d channels from server %s",
+	"structure.planned":   "📋 %d changes planned for server %s (dry run)",
+	"structure.unchanged": "✅ Server %s already matches the structure",
+	"structure.applied":   "✅ Applied %d changes to server %s",
+
+	// Messages
+	"messages.sent":             "✅ Message sent successfully to <#%s>",
+	"messages.sent_split":       "✅ Message sent successfully to <#%s> as %d messages",
+	"broadcast.sent":            "📣 Broadcast sent to %d of %d channels",
+	"broadcast.preview":         "🔎 Broadcast would reach %d of %d channels",
+	"messages.retrieved":        "📨 Retrieved %d messages from <#%s>",
+	"messages.retrieved_images": "📨 Retrieved %d messages from <#%s> with %d images",
+	"messages.edited":           "✏️ Message edited successfully in <#%s>",
+	"messages.deleted":          "🗑️ Message deleted successfully from <#%s>",
+	"reactions.added":           "👍 Added reaction %s to message in <#%s>",
+	"reactions.stats":           "📈 %d reactions across %d of %d messages in <#%s>",
+	"emoji.image":               "🖼️ Emoji image for %s: %s",
+	"embeds.built":              "🧱 Built %d embeds for %d message(s). Pass each entry of 'messages' as the embeds of one send_message call",
+	"export.exported":           "📦 Exported %d messages from #%s",
+	"export.written":            "📦 Exported %d messages from #%s to %s",
+	"archive.disabled":          "❌ The message archive is disabled (archive.enabled)",
+	"archive.search_failed":     "❌ Archive search failed: %v",
+	"archive.found":             "🔎 Found %d archived messages",
+	"templates.list":            "📄 Found %d templates",
+	"templates.list_failures":   "📄 Found %d templates (%d files failed to load)",
+	"templates.rendered":        "📝 Rendered template %s",
+	"templates.sent":            "✅ Sent template %s to <#%s>",
+	"schedule.created":          "⏰ Created recurring post %s for channel %s, next run at %s",
+	"schedule.list":             "Found %d recurring posts",
+	"schedule.not_found":        "❌ Recurring post %s not found",
+	"schedule.deleted":          "🗑️ Deleted recurring post %s",
+	"schedule.disabled":         "❌ Recurring posts are disabled (schedule.enabled)",
+	"giveaways.started":         "🎉 Started giveaway %s for %s in channel %s",
+	"giveaways.list":            "Found %d giveaways",
+	"giveaways.not_found":       "❌ Giveaway %s not found",
+	"giveaways.drawn":           "🏆 Drew %d winners for giveaway %s from %d entrants",
+	"giveaways.no_entrants":     "⚠️ Giveaway %s has no eligible entrants yet",
+	"giveaways.disabled":        "❌ Giveaways are disabled (giveaways.enabled)",
+	"giveaways.announcement":    "🎉 Congratulations %s, you won **%s**!",
+	"giveaways.enter":           "React with %s to enter",
+	"giveaways.winner_count":    "Winners: %d",
+	"giveaways.ends":            "Ends %s",
+	"giveaways.winners":         "🏆 Winners: %s",
+	"starboard.created":         "⭐ Created the starboard for guild %s in <#%s> (%d × %s)",
+	"starboard.updated":         "⭐ Updated the starboard for guild %s in <#%s> (%d × %s)",
+	"starboard.list":            "Found %d starboard entries in guild %s",
+	"starboard.not_configured":  "❌ Guild %s has no starboard",
+	"starboard.disabled":        "❌ The starboard is disabled (starboard.enabled)",
+	"pinning.created":           "📌 Created the pinning policy of <#%s> (limit %d)",
+	"pinning.updated":           "📌 Updated the pinning policy of <#%s> (limit %d)",
+	"pinning.list":              "Found %d pinning policies",
+	"pinning.not_found":         "❌ Channel %s has no pinning policy",
+	"pinning.deleted":           "🗑️ Removed the pinning policy of <#%s>",
+	"pinning.disabled":          "❌ Pinning policies are disabled (pinning.enabled)",
+	"notes.added":               "📝 Added note %s about user %s",
+	"notes.list":                "Found %d notes about %s",
+	"notes.not_found":           "❌ Note %s not found",
+	"notes.deleted":             "🗑️ Deleted note %s about user %s",
+	"notes.disabled":            "❌ User notes are disabled (user_notes.enabled)",
+	"warnings.added":            "⚠️ Gave warning %s to user %s (%d active)",
+	"warnings.escalated":        "⚠️ Gave warning %s to user %s (%d active) and applied %s",
+	"warnings.not_escalated":    "⚠️ Gave warning %s to user %s (%d active) but could not apply %s: %v",
+	"warnings.list":             "Found %d warnings for %s",
+	"warnings.not_found":        "❌ No active warning found for %s",
+	"warnings.cleared":          "✅ Cleared %d warnings of user %s",
+	"warnings.disabled":         "❌ Warnings are disabled (warnings.enabled)",
+	"tickets.opened":            "🎫 Opened ticket %s for user %s in <#%s>",
+	"tickets.replied":           "✉️ Replied to user %s in ticket %s",
+	"tickets.closed":            "🔒 Closed ticket %s and saved its %d messages to %s",
+	"tickets.list":              "Found %d %s tickets",
+	"tickets.not_found":         "❌ Open ticket %s not found",
+	"tickets.disabled":          "❌ Tickets are disabled (tickets.enabled)",
+	"rolemenus.created":         "📋 Posted role menu %s with %d roles in <#%s>",
+	"rolemenus.list":            "Found %d role menus",
+	"rolemenus.not_found":       "❌ Role menu %s not found",
+	"rolemenus.deleted":         "🗑️ Removed role menu %s",
+	"rolemenus.disabled":        "❌ Role menus are disabled (role_menus.enabled)",
+	"verify.configured":         "🛂 Set verification for guild %s: new members get role %s",
+	"verify.verified":           "✅ Verified %s in guild %s",
+	"verify.list":               "Found %d unverified members",
+	"verify.not_configured":     "❌ Verification is not configured for guild %s",
+	"verify.disabled":           "❌ Verification is disabled (verification.enabled)",
+	"bridges.created":           "🌉 Created bridge %s from <#%s> into <#%s>",
+	"bridges.list":              "Found %d bridges",
+	"bridges.not_found":         "❌ Bridge %s not found",
+	"bridges.deleted":           "🗑️ Removed bridge %s",
+	"bridges.disabled":          "❌ Bridges are disabled (bridges.enabled)",
+	"relays.created":            "🔗 Created relay %s into <#%s>; POST JSON payloads to %s",
+	"relays.list":               "Found %d relays",
+	"relays.not_found":          "❌ Relay %s not found",
+	"relays.deleted":            "🗑️ Removed relay %s",
+	"relays.disabled":           "❌ The relay is disabled (relay.enabled)",
+	"feeds.added":               "📰 Watching feed %s (%s) in <#%s>; next check at %s",
+	"feeds.list":                "Found %d feeds",
+	"feeds.not_found":           "❌ Feed %s not found",
+	"feeds.removed":             "🗑️ Stopped watching feed %s",
+	"feeds.disabled":            "❌ Feeds are disabled (feeds.enabled)",
+	"raid.armed":                "🛡️ Armed raid protection for guild %s",
+	"raid.disarmed":             "🛡️ Disarmed raid protection for guild %s (%d actions reverted)",
+	"raid.not_armed":            "❌ Raid protection is not armed for guild %s",
+	"raid.incidents":            "Found %d raid incidents across %d protected guilds",
+	"raid.disabled":             "❌ Raid protection is disabled (raid.enabled)",
+	"undo.done":                 "↩️ Undid %s action %s",
+	"undo.not_found":            "❌ Action %s was not found or can no longer be undone",
+	"undo.nothing":              "❌ No recent action to undo",
+	"undo.disabled":             "❌ Undo is disabled (undo.enabled)",
+	"plan.checked":              "✅ All %d plan steps passed their checks",
+	"plan.completed":            "✅ Completed all %d plan steps",
+	"plan.failed":               "❌ Plan step %d (%s) failed after %d of %d steps completed; %d rolled back",
+	"plan.rejected":             "❌ Plan step %d (%s) was refused, so nothing ran: %s",
+	"members.streaming":         "📥 Requesting the members of guild %s as stream %s",
+	"members.not_found":         "❌ Member stream %s was not found or has expired",
+	"members.page":              "Returned %d of %d members collected by stream %s (%s)",
+	"nicks.audited":             "🔍 Scanned %d members: %d flagged, %d renamed, %d not renamed",
+	"membership.log":            "Found %d membership events for guild %s",
+	"membership.stats":          "📈 %d joins and %d leaves in guild %s over %d days (net %+d)",
+	"membership.disabled":       "❌ The membership log is disabled (membership_log.enabled)",
+	"threads.stale":             "🧵 %d threads in guild %s will auto-archive within %d hours",
+	"duplicates.found":          "🔁 Found %d groups of duplicate messages in %d messages across %d channels",
+	"safety.blocked":            "🛡️ Message not sent: %d unsafe links or attachments found",
+	"cooldowns.disabled":        "❌ Response cooldowns are disabled (response_cooldowns.enabled)",
+	"cooldowns.reset":           "⏱️ Cleared %d response cooldowns",
+	"outbound.disabled":         "❌ The outbound queue is disabled (outbound_queue.enabled)",
+	"outbound.stats":            "📤 %d messages queued (%d interactive, %d normal, %d bulk)",
+	"traces.list":               "🔎 %d recent Discord traces (tracing is on)",
+	"traces.off":                "🔎 %d recent Discord traces; tracing is off (enable trace.enabled or send logging/setLevel debug)",
+	"watches.created":           "👀 Created %s watch %s",
+	"watches.list":              "Found %d watches",
+	"watches.not_found":         "❌ Watch %s not found",
+	"watches.deleted":           "🗑️ Deleted watch %s",
+	"autoresponses.created":     "💬 Created %s auto-response %s",
+	"autoresponses.list":        "Found %d auto-responses",
+	"autoresponses.not_found":   "❌ Auto-response %s not found",
+	"autoresponses.deleted":     "🗑️ Deleted auto-response %s",
+	"onboarding.created":        "👋 Created onboarding rule %s for guild %s",
+	"onboarding.updated":        "👋 Updated onboarding rule %s for guild %s",
+	"onboarding.list":           "Found %d onboarding rules",
+	"onboarding.disabled":       "❌ Onboarding is disabled (onboarding.enabled)",
+
+	// Voice
+	"voice.joined":        "🔊 Joined voice channel %s",
+	"voice.left":          "👋 Left voice in guild %s",
+	"voice.queued_audio":  "▶️ Queued audio %s (position %d). A discord/playbackFinished notification is sent when it ends",
+	"voice.queued_speech": "🗣️ Queued speech %s (position %d). A discord/playbackFinished notification is sent when it ends",
+	"soundboard.list":     "Found %d soundboard sounds in guild %s",
+	"soundboard.uploaded": "✅ Uploaded soundboard sound %s (%s)",
+	"soundboard.deleted":  "🗑️ Deleted soundboard sound %s (%s)",
+	"soundboard.played":   "🔊 Played soundboard sound %s in channel %s",
+}
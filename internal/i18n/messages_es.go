@@ -0,0 +1,230 @@
+package i18n
+
+// messagesES is the Spanish catalog
+var messagesES = map[string]string{
+	// Server
+	"server.not_initialized":     "El servidor no está inicializado",
+	"server.tool_not_found":      "Herramienta no encontrada: %s",
+	"server.discord_unavailable": "Discord no está disponible (estado de la conexión: %s), inténtalo de nuevo en breve",
+	"server.call_queued":         "⏳ Discord no está disponible, así que %s se puso en cola como %s y se ejecutará al reconectar (estado de conexión: %s)",
+	"server.call_replaying":      "⏳ %s ya está en cola como %s y se ejecutará en breve; el resultado se envía como discord/queuedCallCompleted",
+	"server.tool_failed":         "Error al ejecutar la herramienta: %v",
+
+	// Errors
+	"error.validation": "❌ Error de validación: %s",
+	"error.permission": "🔒 Error de permisos: %s",
+	"error.discord":    "❌ %s: %s",
+	"error.hint":       "💡 %s",
+	"error.name":       "❌ %v",
+
+	// Result paging
+	"response.truncated": "✂️ Se devolvieron %d de %d %s; vuelve a llamar a la herramienta con result_cursor %q para obtener más",
+	"response.continued": "📄 %s: %s desde el elemento %d de %d",
+
+	// Policies
+	"policy.confirm": "⚠️ %s requiere confirmación (%s). Vuelve a ejecutarla con \"confirm\": true para continuar",
+	"policy.denied":  "🚫 La política denegó %s (%s)",
+
+	// General
+	"ping.failed":           "Falló la conexión con Discord: %v",
+	"ping.bot_user_failed":  "No se pudo obtener la información del bot: %v",
+	"ping.healthy":          "✅ ¡El servidor Discord MCP funciona correctamente!\n\n🤖 Bot: %s#%s (ID: %s)\n📡 Conectado: %t\n⏱️ Tiempo de respuesta: %v\n🕒 Marca de tiempo: %s",
+	"bot.info":              "🤖 %s (ID: %s), %d servidores, %d herramientas, activo desde hace %s, quedan %d/%d solicitudes este minuto",
+	"stats.summary":         "📊 %d llamadas a %d herramientas (%d fallidas) en %s; %d notificaciones enviadas, %d esperas por límites de Discord",
+	"bot.presence":          "✅ Presencia establecida en %s",
+	"bot.presence_activity": "✅ Presencia establecida en %s (%s %s)",
+	"cache.stats":           "📊 Caché activada: %t",
+	"events.disabled":       "❌ El búfer de eventos está desactivado (events.buffer.enabled)",
+	"events.polled":         "📬 %d eventos desde el cursor %d",
+	"events.polled_missed":  "📬 %d eventos desde el cursor %d (%d eventos anteriores se descartaron antes de esta consulta)",
+	"snowflake.parsed":      "🕒 %s se creó el %s",
+	"snowflake.boundary":    "🕒 Límite de snowflake para %s: %s",
+
+	// Guilds, users and permissions
+	"guilds.info":              "Servidor: %s",
+	"guilds.members":           "Se encontraron %d miembros en el servidor %s",
+	"widget.enabled":           "El widget del servidor %s está activado",
+	"widget.disabled":          "El widget del servidor %s está desactivado",
+	"widget.updated":           "✅ Widget del servidor %s actualizado",
+	"vanity.info":              "URL personalizada: %s (%d usos)",
+	"vanity.none":              "El servidor %s no tiene URL personalizada",
+	"integrations.list":        "Se encontraron %d integraciones y %d bots en el servidor %s",
+	"preview.info":             "Servidor %s: unos %d miembros, %d en línea, %d emojis personalizados",
+	"commands.list":            "Se encontraron %d comandos de barra en el servidor %s",
+	"commands.not_found":       "Comando %s no encontrado",
+	"commands.permissions_set": "✅ Se establecieron %d permisos en el comando %s",
+	"commands.token_missing":   "❌ Cambiar los permisos de comandos requiere un token bearer de OAuth2 (discord.command_permissions_token)",
+	"analytics.summary":        "📊 Estadísticas de %s de los últimos %d días",
+	"users.info":               "👤 Usuario: %s",
+	"permissions.summary":      "🔐 %d de %d operaciones permitidas",
+	"permissions.denied":       "❌ %s: %s",
+	"permissions.missing":      " (faltan %v)",
+	"permissions.matrix":       "🔐 Matriz de permisos de %d roles, %d concesiones de riesgo",
+	"permissions.risk":         "⚠️ [%s] %s",
+	"resolve.match":            "✅ %q corresponde a %s %s (%s)",
+	"resolve.ambiguous":        "❓ %q coincide con %d elementos de tipo %s; elige uno de los candidatos",
+	"resolve.no_match":         "❌ Ningún %s coincide con %q",
+
+	// Channels and roles
+	"channels.list":       "Se encontraron %d canales en el servidor %s",
+	"channels.info":       "Canal: %s",
+	"transcript.built":    "🧵 Transcripción de %s: %d mensajes de %d participantes",
+	"summarizer.disabled": "❌ Los resúmenes no están disponibles: no hay ningún resumidor configurado (summarizer.backend)",
+	"summarizer.summary":  "📝 Resumen:",
+	"follows.created":     "📣 El canal %s ahora sigue el canal de anuncios %s",
+	"follows.list":        "Se encontraron %d seguimientos de anuncios en %s",
+	"roles.list":          "Se encontraron %d roles en el servidor %s",
+	"roles.info":          "Rol: %s",
+	"roles.created":       "Rol creado: %s",
+	"roles.deleted":       "Rol eliminado con ID: %s",
+	"roles.assigned":      "Rol %s asignado al usuario %s",
+	"roles.unassigned":    "Rol %s retirado del usuario %s",
+	"structure.exported":  "🏗️ Se exportaron %d roles, %d categorías y %d canales del servidor %s",
+	"structure.planned":   "📋 %d cambios planificados para el servidor %s (simulación)",
+	"structure.unchanged": "✅ El servidor %s ya coincide con la estructura",
+	"structure.applied":   "✅ Se aplicaron %d cambios al servidor %s",
+
+	// Messages
+	"messages.sent":             "✅ Mensaje enviado a <#%s>",
+	"messages.sent_split":       "✅ Mensaje enviado a <#%s> en %d mensajes",
+	"broadcast.sent":            "📣 Difusión enviada a %d de %d canales",
+	"broadcast.preview":         "🔎 La difusión llegaría a %d de %d canales",
+	"messages.retrieved":        "📨 Se obtuvieron %d mensajes de <#%s>",
+	"messages.retrieved_images": "📨 Se obtuvieron %d mensajes de <#%s> con %d imágenes",
+	"messages.edited":           "✏️ Mensaje editado en <#%s>",
+	"messages.deleted":          "🗑️ Mensaje eliminado de <#%s>",
+	"reactions.added":           "👍 Se añadió la reacción %s al mensaje en <#%s>",
+	"reactions.stats":           "📈 %d reacciones en %d de %d mensajes en <#%s>",
+	"emoji.image":               "🖼️ Imagen del emoji %s: %s",
+	"embeds.built":              "🧱 Se crearon %d embeds para %d mensaje(s). Pasa cada entrada de 'messages' como los embeds de una llamada a send_message",
+	"export.exported":           "📦 Se exportaron %d mensajes de #%s",
+	"export.written":            "📦 Se exportaron %d mensajes de #%s a %s",
+	"archive.disabled":          "❌ El archivo de mensajes está desactivado (archive.enabled)",
+	"archive.search_failed":     "❌ Falló la búsqueda en el archivo: %v",
+	"archive.found":             "🔎 Se encontraron %d mensajes archivados",
+	"templates.list":            "📄 Se encontraron %d plantillas",
+	"templates.list_failures":   "📄 Se encontraron %d plantillas (%d archivos no se pudieron cargar)",
+	"templates.rendered":        "📝 Plantilla %s generada",
+	"templates.sent":            "✅ Plantilla %s enviada a <#%s>",
+	"schedule.created":          "⏰ Publicación recurrente %s creada para el canal %s, próxima ejecución %s",
+	"schedule.list":             "Se encontraron %d publicaciones recurrentes",
+	"schedule.not_found":        "❌ Publicación recurrente %s no encontrada",
+	"schedule.deleted":          "🗑️ Publicación recurrente %s eliminada",
+	"schedule.disabled":         "❌ Las publicaciones recurrentes están desactivadas (schedule.enabled)",
+	"giveaways.started":         "🎉 Sorteo %s de %s iniciado en el canal %s",
+	"giveaways.list":            "Se encontraron %d sorteos",
+	"giveaways.not_found":       "❌ Sorteo %s no encontrado",
+	"giveaways.drawn":           "🏆 Se sortearon %d ganadores del sorteo %s entre %d participantes",
+	"giveaways.no_entrants":     "⚠️ El sorteo %s aún no tiene participantes válidos",
+	"giveaways.disabled":        "❌ Los sorteos están desactivados (giveaways.enabled)",
+	"giveaways.announcement":    "🎉 ¡Felicidades %s, has ganado **%s**!",
+	"giveaways.enter":           "Reacciona con %s para participar",
+	"giveaways.winner_count":    "Ganadores: %d",
+	"giveaways.ends":            "Termina %s",
+	"giveaways.winners":         "🏆 Ganadores: %s",
+	"starboard.created":         "⭐ Starboard del servidor %s creado en <#%s> (%d × %s)",
+	"starboard.updated":         "⭐ Starboard del servidor %s actualizado en <#%s> (%d × %s)",
+	"starboard.list":            "Se encontraron %d entradas del starboard en el servidor %s",
+	"starboard.not_configured":  "❌ El servidor %s no tiene starboard",
+	"starboard.disabled":        "❌ El starboard está desactivado (starboard.enabled)",
+	"pinning.created":           "📌 Política de fijado creada para <#%s> (límite %d)",
+	"pinning.updated":           "📌 Política de fijado actualizada para <#%s> (límite %d)",
+	"pinning.list":              "Se encontraron %d políticas de fijado",
+	"pinning.not_found":         "❌ El canal %s no tiene política de fijado",
+	"pinning.deleted":           "🗑️ Política de fijado de <#%s> eliminada",
+	"pinning.disabled":          "❌ Las políticas de fijado están desactivadas (pinning.enabled)",
+	"notes.added":               "📝 Nota %s añadida sobre el usuario %s",
+	"notes.list":                "Se encontraron %d notas sobre %s",
+	"notes.not_found":           "❌ Nota %s no encontrada",
+	"notes.deleted":             "🗑️ Nota %s sobre el usuario %s eliminada",
+	"notes.disabled":            "❌ Las notas de usuario están desactivadas (user_notes.enabled)",
+	"warnings.added":            "⚠️ Advertencia %s dada al usuario %s (%d activas)",
+	"warnings.escalated":        "⚠️ Advertencia %s dada al usuario %s (%d activas) y se aplicó %s",
+	"warnings.not_escalated":    "⚠️ Advertencia %s dada al usuario %s (%d activas), pero no se pudo aplicar %s: %v",
+	"warnings.list":             "Se encontraron %d advertencias para %s",
+	"warnings.not_found":        "❌ No se encontró ninguna advertencia activa para %s",
+	"warnings.cleared":          "✅ Se retiraron %d advertencias del usuario %s",
+	"warnings.disabled":         "❌ Las advertencias están desactivadas (warnings.enabled)",
+	"tickets.opened":            "🎫 Ticket %s abierto para el usuario %s en <#%s>",
+	"tickets.replied":           "✉️ Respuesta enviada al usuario %s en el ticket %s",
+	"tickets.closed":            "🔒 Ticket %s cerrado y sus %d mensajes guardados en %s",
+	"tickets.list":              "Se encontraron %d tickets (%s)",
+	"tickets.not_found":         "❌ No se encontró el ticket abierto %s",
+	"tickets.disabled":          "❌ Los tickets están desactivados (tickets.enabled)",
+	"rolemenus.created":         "📋 Menú de roles %s publicado con %d roles en <#%s>",
+	"rolemenus.list":            "Se encontraron %d menús de roles",
+	"rolemenus.not_found":       "❌ No se encontró el menú de roles %s",
+	"rolemenus.deleted":         "🗑️ Menú de roles %s eliminado",
+	"rolemenus.disabled":        "❌ Los menús de roles están desactivados (role_menus.enabled)",
+	"verify.configured":         "🛂 Verificación configurada para el servidor %s: los nuevos miembros reciben el rol %s",
+	"verify.verified":           "✅ %s verificado en el servidor %s",
+	"verify.list":               "Se encontraron %d miembros sin verificar",
+	"verify.not_configured":     "❌ La verificación no está configurada para el servidor %s",
+	"verify.disabled":           "❌ La verificación está desactivada (verification.enabled)",
+	"bridges.created":           "🌉 Puente %s creado de <#%s> a <#%s>",
+	"bridges.list":              "Se encontraron %d puentes",
+	"bridges.not_found":         "❌ No se encontró el puente %s",
+	"bridges.deleted":           "🗑️ Puente %s eliminado",
+	"bridges.disabled":          "❌ Los puentes están desactivados (bridges.enabled)",
+	"relays.created":            "🔗 Relé %s creado hacia <#%s>; envía payloads JSON por POST a %s",
+	"relays.list":               "Se encontraron %d relés",
+	"relays.not_found":          "❌ No se encontró el relé %s",
+	"relays.deleted":            "🗑️ Relé %s eliminado",
+	"relays.disabled":           "❌ El relé está desactivado (relay.enabled)",
+	"feeds.added":               "📰 Siguiendo el feed %s (%s) en <#%s>; próxima comprobación a las %s",
+	"feeds.list":                "Se encontraron %d feeds",
+	"feeds.not_found":           "❌ No se encontró el feed %s",
+	"feeds.removed":             "🗑️ Se dejó de seguir el feed %s",
+	"feeds.disabled":            "❌ Los feeds están desactivados (feeds.enabled)",
+	"raid.armed":                "🛡️ Protección contra raids activada para el servidor %s",
+	"raid.disarmed":             "🛡️ Protección contra raids desactivada para el servidor %s (%d acciones revertidas)",
+	"raid.not_armed":            "❌ La protección contra raids no está activa en el servidor %s",
+	"raid.incidents":            "Se encontraron %d incidentes de raid en %d servidores protegidos",
+	"raid.disabled":             "❌ La protección contra raids está desactivada (raid.enabled)",
+	"undo.done":                 "↩️ Se deshizo la acción %s %s",
+	"undo.not_found":            "❌ La acción %s no existe o ya no se puede deshacer",
+	"undo.nothing":              "❌ No hay ninguna acción reciente que deshacer",
+	"undo.disabled":             "❌ Deshacer está desactivado (undo.enabled)",
+	"plan.checked":              "✅ Los %d pasos del plan superaron las comprobaciones",
+	"plan.completed":            "✅ Se completaron los %d pasos del plan",
+	"plan.failed":               "❌ El paso %d del plan (%s) falló tras completar %d de %d pasos; %d revertidos",
+	"plan.rejected":             "❌ El paso %d del plan (%s) fue rechazado, así que no se ejecutó nada: %s",
+	"members.streaming":         "📥 Solicitando los miembros del servidor %s como flujo %s",
+	"members.not_found":         "❌ No se encontró el flujo de miembros %s o ha caducado",
+	"members.page":              "Se devolvieron %d de %d miembros recopilados por el flujo %s (%s)",
+	"nicks.audited":             "🔍 Se revisaron %d miembros: %d marcados, %d renombrados, %d sin renombrar",
+	"membership.log":            "Se encontraron %d eventos de membresía para el servidor %s",
+	"membership.stats":          "📈 %d entradas y %d salidas en el servidor %s en %d días (neto %+d)",
+	"membership.disabled":       "❌ El registro de membresía está desactivado (membership_log.enabled)",
+	"threads.stale":             "🧵 %d hilos del servidor %s se archivarán automáticamente en %d horas",
+	"duplicates.found":          "🔁 Se encontraron %d grupos de mensajes duplicados en %d mensajes de %d canales",
+	"safety.blocked":            "🛡️ Mensaje no enviado: se encontraron %d enlaces o adjuntos inseguros",
+	"cooldowns.disabled":        "❌ Los tiempos de espera de respuesta están desactivados (response_cooldowns.enabled)",
+	"cooldowns.reset":           "⏱️ Se restablecieron %d tiempos de espera de respuesta",
+	"outbound.disabled":         "❌ La cola de envío está desactivada (outbound_queue.enabled)",
+	"outbound.stats":            "📤 %d mensajes en cola (%d interactivos, %d normales, %d masivos)",
+	"traces.list":               "🔎 %d trazas recientes de Discord (el rastreo está activado)",
+	"traces.off":                "🔎 %d trazas recientes de Discord; el rastreo está desactivado (activa trace.enabled o envía logging/setLevel debug)",
+	"watches.created":           "👀 Vigilancia %s creada: %s",
+	"watches.list":              "Se encontraron %d vigilancias",
+	"watches.not_found":         "❌ No se encontró la vigilancia %s",
+	"watches.deleted":           "🗑️ Vigilancia %s eliminada",
+	"autoresponses.created":     "💬 Respuesta automática (%s) %s creada",
+	"autoresponses.list":        "Se encontraron %d respuestas automáticas",
+	"autoresponses.not_found":   "❌ Respuesta automática %s no encontrada",
+	"autoresponses.deleted":     "🗑️ Respuesta automática %s eliminada",
+	"onboarding.created":        "👋 Regla de bienvenida %s creada para el servidor %s",
+	"onboarding.updated":        "👋 Regla de bienvenida %s actualizada para el servidor %s",
+	"onboarding.list":           "Se encontraron %d reglas de bienvenida",
+	"onboarding.disabled":       "❌ La bienvenida automática está desactivada (onboarding.enabled)",
+
+	// Voice
+	"voice.joined":        "🔊 Conectado al canal de voz %s",
+	"voice.left":          "👋 Desconectado de la voz en el servidor %s",
+	"voice.queued_audio":  "▶️ Audio %s en cola (posición %d). Se envía una notificación discord/playbackFinished al terminar",
+	"voice.queued_speech": "🗣️ Voz %s en cola (posición %d). Se envía una notificación discord/playbackFinished al terminar",
+	"soundboard.list":     "Se encontraron %d sonidos de la tabla de sonidos en el servidor %s",
+	"soundboard.uploaded": "✅ Sonido %s (%s) subido a la tabla de sonidos",
+	"soundboard.deleted":  "🗑️ Sonido %s (%s) eliminado de la tabla de sonidos",
+	"soundboard.played":   "🔊 Sonido %s reproducido en el canal %s",
+}
@@ -0,0 +1,230 @@
+package i18n
+
+// messagesFR is the French catalog
+var messagesFR = map[string]string{
+	// Server
+	"server.not_initialized":     "Le serveur n'est pas initialisé",
+	"server.tool_not_found":      "Outil introuvable : %s",
+	"server.discord_unavailable": "Discord est indisponible (état de la connexion : %s), réessayez dans un instant",
+	"server.call_queued":         "⏳ Discord est indisponible, %s a donc été mis en file sous %s et s'exécutera à la reconnexion (état de connexion : %s)",
+	"server.call_replaying":      "⏳ %s est déjà en file sous %s et s'exécutera sous peu ; le résultat est envoyé via discord/queuedCallCompleted",
+	"server.tool_failed":         "Échec de l'exécution de l'outil : %v",
+
+	// Errors
+	"error.validation": "❌ Erreur de validation : %s",
+	"error.permission": "🔒 Erreur de permission : %s",
+	"error.discord":    "❌ %s : %s",
+	"error.hint":       "💡 %s",
+	"error.name":       "❌ %v",
+
+	// Result paging
+	"response.truncated": "✂️ %d sur %d %s renvoyés ; rappelez l'outil avec result_cursor %q pour la suite",
+	"response.continued": "📄 %s : %s à partir de l'élément %d sur %d",
+
+	// Policies
+	"policy.confirm": "⚠️ %s nécessite une confirmation (%s). Relancez avec \"confirm\": true pour continuer",
+	"policy.denied":  "🚫 La politique a refusé %s (%s)",
+
+	// General
+	"ping.failed":           "Échec de la connexion à Discord : %v",
+	"ping.bot_user_failed":  "Impossible de récupérer les informations du bot : %v",
+	"ping.healthy":          "✅ Le serveur Discord MCP fonctionne correctement !\n\n🤖 Bot : %s#%s (ID : %s)\n📡 Connecté : %t\n⏱️ Temps de réponse : %v\n🕒 Horodatage : %s",
+	"bot.info":              "🤖 %s (ID : %s), %d serveurs, %d outils, actif depuis %s, %d/%d requêtes restantes cette minute",
+	"stats.summary":         "📊 %d appels à %d outils (%d en échec) en %s ; %d notifications envoyées, %d attentes dues aux limites de Discord",
+	"bot.presence":          "✅ Présence définie sur %s",
+	"bot.presence_activity": "✅ Présence définie sur %s (%s %s)",
+	"cache.stats":           "📊 Cache activé : %t",
+	"events.disabled":       "❌ La mise en mémoire tampon des événements est désactivée (events.buffer.enabled)",
+	"events.polled":         "📬 %d événements depuis le curseur %d",
+	"events.polled_missed":  "📬 %d événements depuis le curseur %d (%d événements plus anciens ont été supprimés avant cette lecture)",
+	"snowflake.parsed":      "🕒 %s a été créé le %s",
+	"snowflake.boundary":    "🕒 Limite de snowflake pour %s : %s",
+
+	// Guilds, users and permissions
+	"guilds.info":              "Serveur : %s",
+	"guilds.members":           "%d membres trouvés sur le serveur %s",
+	"widget.enabled":           "Le widget du serveur %s est activé",
+	"widget.disabled":          "Le widget du serveur %s est désactivé",
+	"widget.updated":           "✅ Widget du serveur %s mis à jour",
+	"vanity.info":              "URL personnalisée : %s (%d utilisations)",
+	"vanity.none":              "Le serveur %s n'a pas d'URL personnalisée",
+	"integrations.list":        "%d intégrations et %d bots trouvés sur le serveur %s",
+	"preview.info":             "Serveur %s : environ %d membres, %d en ligne, %d émojis personnalisés",
+	"commands.list":            "%d commandes slash trouvées sur le serveur %s",
+	"commands.not_found":       "Commande %s introuvable",
+	"commands.permissions_set": "✅ %d autorisations définies sur la commande %s",
+	"commands.token_missing":   "❌ Modifier les autorisations des commandes nécessite un jeton bearer OAuth2 (discord.command_permissions_token)",
+	"analytics.summary":        "📊 Statistiques de %s sur les %d derniers jours",
+	"users.info":               "👤 Utilisateur : %s",
+	"permissions.summary":      "🔐 %d opérations autorisées sur %d",
+	"permissions.denied":       "❌ %s : %s",
+	"permissions.missing":      " (manquant : %v)",
+	"permissions.matrix":       "🔐 Matrice des permissions de %d rôles, %d autorisations à risque",
+	"permissions.risk":         "⚠️ [%s] %s",
+	"resolve.match":            "✅ %q correspond à %s %s (%s)",
+	"resolve.ambiguous":        "❓ %q correspond à %d éléments de type %s ; choisissez l'un des candidats",
+	"resolve.no_match":         "❌ Aucun %s ne correspond à %q",
+
+	// Channels and roles
+	"channels.list":       "%d salons trouvés sur le serveur %s",
+	"channels.info":       "Salon : %s",
+	"transcript.built":    "🧵 Transcription de %s : %d messages de %d participants",
+	"summarizer.disabled": "❌ Les résumés ne sont pas disponibles : aucun résumeur configuré (summarizer.backend)",
+	"summarizer.summary":  "📝 Résumé :",
+	"follows.created":     "📣 Le salon %s suit maintenant le salon d'annonces %s",
+	"follows.list":        "%d abonnements à des salons d'annonces trouvés dans %s",
+	"roles.list":          "%d rôles trouvés sur le serveur %s",
+	"roles.info":          "Rôle : %s",
+	"roles.created":       "Rôle créé : %s",
+	"roles.deleted":       "Rôle supprimé, ID : %s",
+	"roles.assigned":      "Rôle %s attribué à l'utilisateur %s",
+	"roles.unassigned":    "Rôle %s retiré à l'utilisateur %s",
+	"structure.exported":  "🏗️ %d rôles, %d catégories et %d salons exportés depuis le serveur %s",
+	"structure.planned":   "📋 %d modifications prévues pour le serveur %s (simulation)",
+	"structure.unchanged": "✅ Le serveur %s correspond déjà à la structure",
+	"structure.applied":   "✅ %d modifications appliquées au serveur %s",
+
+	// Messages
+	"messages.sent":             "✅ Message envoyé dans <#%s>",
+	"messages.sent_split":       "✅ Message envoyé dans <#%s> en %d messages",
+	"broadcast.sent":            "📣 Diffusion envoyée à %d salons sur %d",
+	"broadcast.preview":         "🔎 La diffusion atteindrait %d salons sur %d",
+	"messages.retrieved":        "📨 %d messages récupérés dans <#%s>",
+	"messages.retrieved_images": "📨 %d messages récupérés dans <#%s> avec %d images",
+	"messages.edited":           "✏️ Message modifié dans <#%s>",
+	"messages.deleted":          "🗑️ Message supprimé de <#%s>",
+	"reactions.added":           "👍 Réaction %s ajoutée au message dans <#%s>",
+	"reactions.stats":           "📈 %d réactions sur %d des %d messages de <#%s>",
+	"emoji.image":               "🖼️ Image de l'emoji %s : %s",
+	"embeds.built":              "🧱 %d embeds créés pour %d message(s). Passez chaque entrée de 'messages' comme embeds d'un appel à send_message",
+	"export.exported":           "📦 %d messages exportés depuis #%s",
+	"export.written":            "📦 %d messages exportés depuis #%s vers %s",
+	"archive.disabled":          "❌ L'archive des messages est désactivée (archive.enabled)",
+	"archive.search_failed":     "❌ Échec de la recherche dans l'archive : %v",
+	"archive.found":             "🔎 %d messages archivés trouvés",
+	"templates.list":            "📄 %d modèles trouvés",
+	"templates.list_failures":   "📄 %d modèles trouvés (%d fichiers n'ont pas pu être chargés)",
+	"templates.rendered":        "📝 Modèle %s généré",
+	"templates.sent":            "✅ Modèle %s envoyé dans <#%s>",
+	"schedule.created":          "⏰ Publication récurrente %s créée pour le salon %s, prochaine exécution %s",
+	"schedule.list":             "%d publications récurrentes trouvées",
+	"schedule.not_found":        "❌ Publication récurrente %s introuvable",
+	"schedule.deleted":          "🗑️ Publication récurrente %s supprimée",
+	"schedule.disabled":         "❌ Les publications récurrentes sont désactivées (schedule.enabled)",
+	"giveaways.started":         "🎉 Concours %s pour %s lancé dans le salon %s",
+	"giveaways.list":            "%d concours trouvés",
+	"giveaways.not_found":       "❌ Concours %s introuvable",
+	"giveaways.drawn":           "🏆 %d gagnants tirés pour le concours %s parmi %d participants",
+	"giveaways.no_entrants":     "⚠️ Le concours %s n'a encore aucun participant éligible",
+	"giveaways.disabled":        "❌ Les concours sont désactivés (giveaways.enabled)",
+	"giveaways.announcement":    "🎉 Félicitations %s, vous avez gagné **%s** !",
+	"giveaways.enter":           "Réagissez avec %s pour participer",
+	"giveaways.winner_count":    "Gagnants : %d",
+	"giveaways.ends":            "Se termine %s",
+	"giveaways.winners":         "🏆 Gagnants : %s",
+	"starboard.created":         "⭐ Starboard du serveur %s créé dans <#%s> (%d × %s)",
+	"starboard.updated":         "⭐ Starboard du serveur %s mis à jour dans <#%s> (%d × %s)",
+	"starboard.list":            "%d entrées du starboard trouvées dans le serveur %s",
+	"starboard.not_configured":  "❌ Le serveur %s n'a pas de starboard",
+	"starboard.disabled":        "❌ Le starboard est désactivé (starboard.enabled)",
+	"pinning.created":           "📌 Règle d'épinglage créée pour <#%s> (limite %d)",
+	"pinning.updated":           "📌 Règle d'épinglage mise à jour pour <#%s> (limite %d)",
+	"pinning.list":              "%d règles d'épinglage trouvées",
+	"pinning.not_found":         "❌ Le salon %s n'a pas de règle d'épinglage",
+	"pinning.deleted":           "🗑️ Règle d'épinglage de <#%s> supprimée",
+	"pinning.disabled":          "❌ Les règles d'épinglage sont désactivées (pinning.enabled)",
+	"notes.added":               "📝 Note %s ajoutée sur l'utilisateur %s",
+	"notes.list":                "%d notes trouvées sur %s",
+	"notes.not_found":           "❌ Note %s introuvable",
+	"notes.deleted":             "🗑️ Note %s sur l'utilisateur %s supprimée",
+	"notes.disabled":            "❌ Les notes utilisateur sont désactivées (user_notes.enabled)",
+	"warnings.added":            "⚠️ Avertissement %s donné à l'utilisateur %s (%d actifs)",
+	"warnings.escalated":        "⚠️ Avertissement %s donné à l'utilisateur %s (%d actifs) et %s appliqué",
+	"warnings.not_escalated":    "⚠️ Avertissement %s donné à l'utilisateur %s (%d actifs), mais impossible d'appliquer %s : %v",
+	"warnings.list":             "%d avertissements trouvés pour %s",
+	"warnings.not_found":        "❌ Aucun avertissement actif trouvé pour %s",
+	"warnings.cleared":          "✅ %d avertissements de l'utilisateur %s levés",
+	"warnings.disabled":         "❌ Les avertissements sont désactivés (warnings.enabled)",
+	"tickets.opened":            "🎫 Ticket %s ouvert pour l'utilisateur %s dans <#%s>",
+	"tickets.replied":           "✉️ Réponse envoyée à l'utilisateur %s dans le ticket %s",
+	"tickets.closed":            "🔒 Ticket %s fermé et ses %d messages enregistrés dans %s",
+	"tickets.list":              "%d tickets (%s) trouvés",
+	"tickets.not_found":         "❌ Ticket ouvert %s introuvable",
+	"tickets.disabled":          "❌ Les tickets sont désactivés (tickets.enabled)",
+	"rolemenus.created":         "📋 Menu de rôles %s publié avec %d rôles dans <#%s>",
+	"rolemenus.list":            "%d menus de rôles trouvés",
+	"rolemenus.not_found":       "❌ Menu de rôles %s introuvable",
+	"rolemenus.deleted":         "🗑️ Menu de rôles %s supprimé",
+	"rolemenus.disabled":        "❌ Les menus de rôles sont désactivés (role_menus.enabled)",
+	"verify.configured":         "🛂 Vérification définie pour le serveur %s : les nouveaux membres reçoivent le rôle %s",
+	"verify.verified":           "✅ %s vérifié sur le serveur %s",
+	"verify.list":               "%d membres non vérifiés trouvés",
+	"verify.not_configured":     "❌ La vérification n'est pas configurée pour le serveur %s",
+	"verify.disabled":           "❌ La vérification est désactivée (verification.enabled)",
+	"bridges.created":           "🌉 Pont %s créé de <#%s> vers <#%s>",
+	"bridges.list":              "%d ponts trouvés",
+	"bridges.not_found":         "❌ Pont %s introuvable",
+	"bridges.deleted":           "🗑️ Pont %s supprimé",
+	"bridges.disabled":          "❌ Les ponts sont désactivés (bridges.enabled)",
+	"relays.created":            "🔗 Relais %s créé vers <#%s> ; envoyez les payloads JSON en POST à %s",
+	"relays.list":               "%d relais trouvés",
+	"relays.not_found":          "❌ Relais %s introuvable",
+	"relays.deleted":            "🗑️ Relais %s supprimé",
+	"relays.disabled":           "❌ Le relais est désactivé (relay.enabled)",
+	"feeds.added":               "📰 Flux %s (%s) suivi dans <#%s> ; prochaine vérification à %s",
+	"feeds.list":                "%d flux trouvés",
+	"feeds.not_found":           "❌ Flux %s introuvable",
+	"feeds.removed":             "🗑️ Le flux %s n'est plus suivi",
+	"feeds.disabled":            "❌ Les flux sont désactivés (feeds.enabled)",
+	"raid.armed":                "🛡️ Protection anti-raid activée pour le serveur %s",
+	"raid.disarmed":             "🛡️ Protection anti-raid désactivée pour le serveur %s (%d actions annulées)",
+	"raid.not_armed":            "❌ La protection anti-raid n'est pas active pour le serveur %s",
+	"raid.incidents":            "%d incidents de raid trouvés dans %d serveurs protégés",
+	"raid.disabled":             "❌ La protection anti-raid est désactivée (raid.enabled)",
+	"undo.done":                 "↩️ Action %s %s annulée",
+	"undo.not_found":            "❌ L'action %s est introuvable ou ne peut plus être annulée",
+	"undo.nothing":              "❌ Aucune action récente à annuler",
+	"undo.disabled":             "❌ L'annulation est désactivée (undo.enabled)",
+	"plan.checked":              "✅ Les %d étapes du plan ont passé les vérifications",
+	"plan.completed":            "✅ Les %d étapes du plan ont été exécutées",
+	"plan.failed":               "❌ L'étape %d du plan (%s) a échoué après %d étapes sur %d ; %d annulées",
+	"plan.rejected":             "❌ L'étape %d du plan (%s) a été refusée, rien n'a été exécuté : %s",
+	"members.streaming":         "📥 Demande des membres du serveur %s en tant que flux %s",
+	"members.not_found":         "❌ Le flux de membres %s est introuvable ou a expiré",
+	"members.page":              "%d des %d membres collectés par le flux %s renvoyés (%s)",
+	"nicks.audited":             "🔍 %d membres analysés : %d signalés, %d renommés, %d non renommés",
+	"membership.log":            "%d événements d'adhésion trouvés pour le serveur %s",
+	"membership.stats":          "📈 %d arrivées et %d départs sur le serveur %s en %d jours (solde %+d)",
+	"membership.disabled":       "❌ Le journal des membres est désactivé (membership_log.enabled)",
+	"threads.stale":             "🧵 %d fils du serveur %s seront archivés automatiquement d'ici %d heures",
+	"duplicates.found":          "🔁 %d groupes de messages en double trouvés parmi %d messages dans %d salons",
+	"safety.blocked":            "🛡️ Message non envoyé : %d liens ou pièces jointes dangereux trouvés",
+	"cooldowns.disabled":        "❌ Les délais de réponse sont désactivés (response_cooldowns.enabled)",
+	"cooldowns.reset":           "⏱️ %d délais de réponse réinitialisés",
+	"outbound.disabled":         "❌ La file d'envoi est désactivée (outbound_queue.enabled)",
+	"outbound.stats":            "📤 %d messages en file (%d interactifs, %d normaux, %d en masse)",
+	"traces.list":               "🔎 %d traces Discord récentes (le traçage est actif)",
+	"traces.off":                "🔎 %d traces Discord récentes ; le traçage est inactif (activez trace.enabled ou envoyez logging/setLevel debug)",
+	"watches.created":           "👀 Surveillance %s créée : %s",
+	"watches.list":              "%d surveillances trouvées",
+	"watches.not_found":         "❌ Surveillance %s introuvable",
+	"watches.deleted":           "🗑️ Surveillance %s supprimée",
+	"autoresponses.created":     "💬 Réponse automatique (%s) %s créée",
+	"autoresponses.list":        "%d réponses automatiques trouvées",
+	"autoresponses.not_found":   "❌ Réponse automatique %s introuvable",
+	"autoresponses.deleted":     "🗑️ Réponse automatique %s supprimée",
+	"onboarding.created":        "👋 Règle d'accueil %s créée pour le serveur %s",
+	"onboarding.updated":        "👋 Règle d'accueil %s mise à jour pour le serveur %s",
+	"onboarding.list":           "%d règles d'accueil trouvées",
+	"onboarding.disabled":       "❌ L'accueil automatique est désactivé (onboarding.enabled)",
+
+	// Voice
+	"voice.joined":        "🔊 Salon vocal %s rejoint",
+	"voice.left":          "👋 Vocal quitté sur le serveur %s",
+	"voice.queued_audio":  "▶️ Audio %s en file d'attente (position %d). Une notification discord/playbackFinished est envoyée à la fin",
+	"voice.queued_speech": "🗣️ Synthèse vocale %s en file d'attente (position %d). Une notification discord/playbackFinished est envoyée à la fin",
+	"soundboard.list":     "%d sons de soundboard trouvés sur le serveur %s",
+	"soundboard.uploaded": "✅ Son de soundboard %s (%s) ajouté",
+	"soundboard.deleted":  "🗑️ Son de soundboard %s (%s) supprimé",
+	"soundboard.played":   "🔊 Son de soundboard %s joué dans le salon %s",
+}
@@ -0,0 +1,230 @@
+package i18n
+
+// messagesPT is the Portuguese catalog
+var messagesPT = map[string]string{
+	// Server
+	"server.not_initialized":     "O servidor não foi inicializado",
+	"server.tool_not_found":      "Ferramenta não encontrada: %s",
+	"server.discord_unavailable": "O Discord está indisponível (estado da conexão: %s), tente novamente em instantes",
+	"server.call_queued":         "⏳ O Discord está indisponível, então %s foi enfileirado como %s e será executado ao reconectar (estado da conexão: %s)",
+	"server.call_replaying":      "⏳ %s já está na fila como %s e será executado em breve; o resultado é enviado como discord/queuedCallCompleted",
+	"server.tool_failed":         "Falha ao executar a ferramenta: %v",
+
+	// Errors
+	"error.validation": "❌ Erro de validação: %s",
+	"error.permission": "🔒 Erro de permissão: %s",
+	"error.discord":    "❌ %s: %s",
+	"error.hint":       "💡 %s",
+	"error.name":       "❌ %v",
+
+	// Result paging
+	"response.truncated": "✂️ %d de %d %s retornados; chame a ferramenta novamente com result_cursor %q para obter mais",
+	"response.continued": "📄 %s: %s a partir do item %d de %d",
+
+	// Policies
+	"policy.confirm": "⚠️ %s requer confirmação (%s). Execute novamente com \"confirm\": true para continuar",
+	"policy.denied":  "🚫 A política negou %s (%s)",
+
+	// General
+	"ping.failed":           "Falha na conexão com o Discord: %v",
+	"ping.bot_user_failed":  "Não foi possível obter as informações do bot: %v",
+	"ping.healthy":          "✅ O servidor Discord MCP está funcionando!\n\n🤖 Bot: %s#%s (ID: %s)\n📡 Conectado: %t\n⏱️ Tempo de resposta: %v\n🕒 Data e hora: %s",
+	"bot.info":              "🤖 %s (ID: %s), %d servidores, %d ferramentas, ativo há %s, %d/%d requisições restantes neste minuto",
+	"stats.summary":         "📊 %d chamadas a %d ferramentas (%d com falha) em %s; %d notificações enviadas, %d esperas por limites do Discord",
+	"bot.presence":          "✅ Presença definida como %s",
+	"bot.presence_activity": "✅ Presença definida como %s (%s %s)",
+	"cache.stats":           "📊 Cache ativado: %t",
+	"events.disabled":       "❌ O buffer de eventos está desativado (events.buffer.enabled)",
+	"events.polled":         "📬 %d eventos desde o cursor %d",
+	"events.polled_missed":  "📬 %d eventos desde o cursor %d (%d eventos mais antigos foram descartados antes desta consulta)",
+	"snowflake.parsed":      "🕒 %s foi criado em %s",
+	"snowflake.boundary":    "🕒 Limite de snowflake para %s: %s",
+
+	// Guilds, users and permissions
+	"guilds.info":              "Servidor: %s",
+	"guilds.members":           "%d membros encontrados no servidor %s",
+	"widget.enabled":           "O widget do servidor %s está ativado",
+	"widget.disabled":          "O widget do servidor %s está desativado",
+	"widget.updated":           "✅ Widget do servidor %s atualizado",
+	"vanity.info":              "URL personalizada: %s (%d usos)",
+	"vanity.none":              "O servidor %s não tem URL personalizada",
+	"integrations.list":        "%d integrações e %d bots encontrados no servidor %s",
+	"preview.info":             "Servidor %s: cerca de %d membros, %d online, %d emojis personalizados",
+	"commands.list":            "%d comandos de barra encontrados no servidor %s",
+	"commands.not_found":       "Comando %s não encontrado",
+	"commands.permissions_set": "✅ %d permissões definidas no comando %s",
+	"commands.token_missing":   "❌ Alterar permissões de comandos requer um token bearer OAuth2 (discord.command_permissions_token)",
+	"analytics.summary":        "📊 Estatísticas de %s nos últimos %d dias",
+	"users.info":               "👤 Usuário: %s",
+	"permissions.summary":      "🔐 %d de %d operações permitidas",
+	"permissions.denied":       "❌ %s: %s",
+	"permissions.missing":      " (faltam %v)",
+	"permissions.matrix":       "🔐 Matriz de permissões de %d cargos, %d concessões de risco",
+	"permissions.risk":         "⚠️ [%s] %s",
+	"resolve.match":            "✅ %q corresponde a %s %s (%s)",
+	"resolve.ambiguous":        "❓ %q corresponde a %d itens do tipo %s; escolha um dos candidatos",
+	"resolve.no_match":         "❌ Nenhum %s corresponde a %q",
+
+	// Channels and roles
+	"channels.list":       "%d canais encontrados no servidor %s",
+	"channels.info":       "Canal: %s",
+	"transcript.built":    "🧵 Transcrição de %s: %d mensagens de %d participantes",
+	"summarizer.disabled": "❌ Resumos indisponíveis: nenhum resumidor configurado (summarizer.backend)",
+	"summarizer.summary":  "📝 Resumo:",
+	"follows.created":     "📣 O canal %s agora segue o canal de anúncios %s",
+	"follows.list":        "%d canais de anúncios seguidos encontrados em %s",
+	"roles.list":          "%d cargos encontrados no servidor %s",
+	"roles.info":          "Cargo: %s",
+	"roles.created":       "Cargo criado: %s",
+	"roles.deleted":       "Cargo com ID %s excluído",
+	"roles.assigned":      "Cargo %s atribuído ao usuário %s",
+	"roles.unassigned":    "Cargo %s removido do usuário %s",
+	"structure.exported":  "🏗️ %d cargos, %d categorias e %d canais exportados do servidor %s",
+	"structure.planned":   "📋 %d alterações planejadas para o servidor %s (simulação)",
+	"structure.unchanged": "✅ O servidor %s já corresponde à estrutura",
+	"structure.applied":   "✅ %d alterações aplicadas ao servidor %s",
+
+	// Messages
+	"messages.sent":             "✅ Mensagem enviada para <#%s>",
+	"messages.sent_split":       "✅ Mensagem enviada para <#%s> em %d mensagens",
+	"broadcast.sent":            "📣 Transmissão enviada para %d de %d canais",
+	"broadcast.preview":         "🔎 A transmissão alcançaria %d de %d canais",
+	"messages.retrieved":        "📨 %d mensagens obtidas de <#%s>",
+	"messages.retrieved_images": "📨 %d mensagens obtidas de <#%s> com %d imagens",
+	"messages.edited":           "✏️ Mensagem editada em <#%s>",
+	"messages.deleted":          "🗑️ Mensagem excluída de <#%s>",
+	"reactions.added":           "👍 Reação %s adicionada à mensagem em <#%s>",
+	"reactions.stats":           "📈 %d reações em %d de %d mensagens em <#%s>",
+	"emoji.image":               "🖼️ Imagem do emoji %s: %s",
+	"embeds.built":              "🧱 %d embeds criados para %d mensagem(ns). Passe cada item de 'messages' como os embeds de uma chamada a send_message",
+	"export.exported":           "📦 %d mensagens exportadas de #%s",
+	"export.written":            "📦 %d mensagens exportadas de #%s para %s",
+	"archive.disabled":          "❌ O arquivo de mensagens está desativado (archive.enabled)",
+	"archive.search_failed":     "❌ Falha na busca no arquivo: %v",
+	"archive.found":             "🔎 %d mensagens arquivadas encontradas",
+	"templates.list":            "📄 %d modelos encontrados",
+	"templates.list_failures":   "📄 %d modelos encontrados (%d arquivos não puderam ser carregados)",
+	"templates.rendered":        "📝 Modelo %s renderizado",
+	"templates.sent":            "✅ Modelo %s enviado para <#%s>",
+	"schedule.created":          "⏰ Publicação recorrente %s criada para o canal %s, próxima execução %s",
+	"schedule.list":             "%d publicações recorrentes encontradas",
+	"schedule.not_found":        "❌ Publicação recorrente %s não encontrada",
+	"schedule.deleted":          "🗑️ Publicação recorrente %s excluída",
+	"schedule.disabled":         "❌ As publicações recorrentes estão desativadas (schedule.enabled)",
+	"giveaways.started":         "🎉 Sorteio %s de %s iniciado no canal %s",
+	"giveaways.list":            "%d sorteios encontrados",
+	"giveaways.not_found":       "❌ Sorteio %s não encontrado",
+	"giveaways.drawn":           "🏆 %d vencedores sorteados no sorteio %s entre %d participantes",
+	"giveaways.no_entrants":     "⚠️ O sorteio %s ainda não tem participantes elegíveis",
+	"giveaways.disabled":        "❌ Os sorteios estão desativados (giveaways.enabled)",
+	"giveaways.announcement":    "🎉 Parabéns %s, você ganhou **%s**!",
+	"giveaways.enter":           "Reaja com %s para participar",
+	"giveaways.winner_count":    "Vencedores: %d",
+	"giveaways.ends":            "Termina %s",
+	"giveaways.winners":         "🏆 Vencedores: %s",
+	"starboard.created":         "⭐ Starboard do servidor %s criado em <#%s> (%d × %s)",
+	"starboard.updated":         "⭐ Starboard do servidor %s atualizado em <#%s> (%d × %s)",
+	"starboard.list":            "%d entradas do starboard encontradas no servidor %s",
+	"starboard.not_configured":  "❌ O servidor %s não tem starboard",
+	"starboard.disabled":        "❌ O starboard está desativado (starboard.enabled)",
+	"pinning.created":           "📌 Política de fixação criada para <#%s> (limite %d)",
+	"pinning.updated":           "📌 Política de fixação atualizada para <#%s> (limite %d)",
+	"pinning.list":              "%d políticas de fixação encontradas",
+	"pinning.not_found":         "❌ O canal %s não tem política de fixação",
+	"pinning.deleted":           "🗑️ Política de fixação de <#%s> removida",
+	"pinning.disabled":          "❌ As políticas de fixação estão desativadas (pinning.enabled)",
+	"notes.added":               "📝 Nota %s adicionada sobre o usuário %s",
+	"notes.list":                "%d notas encontradas sobre %s",
+	"notes.not_found":           "❌ Nota %s não encontrada",
+	"notes.deleted":             "🗑️ Nota %s sobre o usuário %s excluída",
+	"notes.disabled":            "❌ As notas de usuário estão desativadas (user_notes.enabled)",
+	"warnings.added":            "⚠️ Advertência %s dada ao usuário %s (%d ativas)",
+	"warnings.escalated":        "⚠️ Advertência %s dada ao usuário %s (%d ativas) e %s aplicado",
+	"warnings.not_escalated":    "⚠️ Advertência %s dada ao usuário %s (%d ativas), mas não foi possível aplicar %s: %v",
+	"warnings.list":             "Encontradas %d advertências para %s",
+	"warnings.not_found":        "❌ Nenhuma advertência ativa encontrada para %s",
+	"warnings.cleared":          "✅ %d advertências do usuário %s removidas",
+	"warnings.disabled":         "❌ As advertências estão desativadas (warnings.enabled)",
+	"tickets.opened":            "🎫 Ticket %s aberto para o usuário %s em <#%s>",
+	"tickets.replied":           "✉️ Resposta enviada ao usuário %s no ticket %s",
+	"tickets.closed":            "🔒 Ticket %s fechado e suas %d mensagens salvas em %s",
+	"tickets.list":              "Encontrados %d tickets (%s)",
+	"tickets.not_found":         "❌ Ticket aberto %s não encontrado",
+	"tickets.disabled":          "❌ Os tickets estão desativados (tickets.enabled)",
+	"rolemenus.created":         "📋 Menu de cargos %s publicado com %d cargos em <#%s>",
+	"rolemenus.list":            "Encontrados %d menus de cargos",
+	"rolemenus.not_found":       "❌ Menu de cargos %s não encontrado",
+	"rolemenus.deleted":         "🗑️ Menu de cargos %s removido",
+	"rolemenus.disabled":        "❌ Os menus de cargos estão desativados (role_menus.enabled)",
+	"verify.configured":         "🛂 Verificação definida para o servidor %s: novos membros recebem o cargo %s",
+	"verify.verified":           "✅ %s verificado no servidor %s",
+	"verify.list":               "Encontrados %d membros não verificados",
+	"verify.not_configured":     "❌ A verificação não está configurada para o servidor %s",
+	"verify.disabled":           "❌ A verificação está desativada (verification.enabled)",
+	"bridges.created":           "🌉 Ponte %s criada de <#%s> para <#%s>",
+	"bridges.list":              "Encontradas %d pontes",
+	"bridges.not_found":         "❌ Ponte %s não encontrada",
+	"bridges.deleted":           "🗑️ Ponte %s removida",
+	"bridges.disabled":          "❌ As pontes estão desativadas (bridges.enabled)",
+	"relays.created":            "🔗 Relay %s criado para <#%s>; envie payloads JSON via POST para %s",
+	"relays.list":               "Encontrados %d relays",
+	"relays.not_found":          "❌ Relay %s não encontrado",
+	"relays.deleted":            "🗑️ Relay %s removido",
+	"relays.disabled":           "❌ O relay está desativado (relay.enabled)",
+	"feeds.added":               "📰 Acompanhando o feed %s (%s) em <#%s>; próxima verificação às %s",
+	"feeds.list":                "Encontrados %d feeds",
+	"feeds.not_found":           "❌ Feed %s não encontrado",
+	"feeds.removed":             "🗑️ O feed %s deixou de ser acompanhado",
+	"feeds.disabled":            "❌ Os feeds estão desativados (feeds.enabled)",
+	"raid.armed":                "🛡️ Proteção contra raids ativada no servidor %s",
+	"raid.disarmed":             "🛡️ Proteção contra raids desativada no servidor %s (%d ações revertidas)",
+	"raid.not_armed":            "❌ A proteção contra raids não está ativa no servidor %s",
+	"raid.incidents":            "%d incidentes de raid encontrados em %d servidores protegidos",
+	"raid.disabled":             "❌ A proteção contra raids está desativada (raid.enabled)",
+	"undo.done":                 "↩️ Ação %s %s desfeita",
+	"undo.not_found":            "❌ A ação %s não foi encontrada ou não pode mais ser desfeita",
+	"undo.nothing":              "❌ Nenhuma ação recente para desfazer",
+	"undo.disabled":             "❌ Desfazer está desativado (undo.enabled)",
+	"plan.checked":              "✅ Os %d passos do plano passaram nas verificações",
+	"plan.completed":            "✅ Os %d passos do plano foram concluídos",
+	"plan.failed":               "❌ O passo %d do plano (%s) falhou após %d de %d passos concluídos; %d revertidos",
+	"plan.rejected":             "❌ O passo %d do plano (%s) foi recusado, então nada foi executado: %s",
+	"members.streaming":         "📥 Solicitando os membros do servidor %s como fluxo %s",
+	"members.not_found":         "❌ O fluxo de membros %s não foi encontrado ou expirou",
+	"members.page":              "%d de %d membros coletados pelo fluxo %s retornados (%s)",
+	"nicks.audited":             "🔍 %d membros verificados: %d sinalizados, %d renomeados, %d não renomeados",
+	"membership.log":            "Encontrados %d eventos de entrada e saída para o servidor %s",
+	"membership.stats":          "📈 %d entradas e %d saídas no servidor %s em %d dias (saldo %+d)",
+	"membership.disabled":       "❌ O registro de membros está desativado (membership_log.enabled)",
+	"threads.stale":             "🧵 %d tópicos do servidor %s serão arquivados automaticamente em %d horas",
+	"duplicates.found":          "🔁 Encontrados %d grupos de mensagens duplicadas em %d mensagens de %d canais",
+	"safety.blocked":            "🛡️ Mensagem não enviada: encontrados %d links ou anexos inseguros",
+	"cooldowns.disabled":        "❌ Os tempos de espera de resposta estão desativados (response_cooldowns.enabled)",
+	"cooldowns.reset":           "⏱️ %d tempos de espera de resposta redefinidos",
+	"outbound.disabled":         "❌ A fila de envio está desativada (outbound_queue.enabled)",
+	"outbound.stats":            "📤 %d mensagens na fila (%d interativas, %d normais, %d em massa)",
+	"traces.list":               "🔎 %d rastros recentes do Discord (o rastreamento está ativo)",
+	"traces.off":                "🔎 %d rastros recentes do Discord; o rastreamento está desativado (ative trace.enabled ou envie logging/setLevel debug)",
+	"watches.created":           "👀 Monitoramento %s criado: %s",
+	"watches.list":              "%d monitoramentos encontrados",
+	"watches.not_found":         "❌ Monitoramento %s não encontrado",
+	"watches.deleted":           "🗑️ Monitoramento %s excluído",
+	"autoresponses.created":     "💬 Resposta automática (%s) %s criada",
+	"autoresponses.list":        "%d respostas automáticas encontradas",
+	"autoresponses.not_found":   "❌ Resposta automática %s não encontrada",
+	"autoresponses.deleted":     "🗑️ Resposta automática %s excluída",
+	"onboarding.created":        "👋 Regra de boas-vindas %s criada para o servidor %s",
+	"onboarding.updated":        "👋 Regra de boas-vindas %s atualizada para o servidor %s",
+	"onboarding.list":           "%d regras de boas-vindas encontradas",
+	"onboarding.disabled":       "❌ As boas-vindas automáticas estão desativadas (onboarding.enabled)",
+
+	// Voice
+	"voice.joined":        "🔊 Conectado ao canal de voz %s",
+	"voice.left":          "👋 Saiu da voz no servidor %s",
+	"voice.queued_audio":  "▶️ Áudio %s na fila (posição %d). Uma notificação discord/playbackFinished é enviada ao terminar",
+	"voice.queued_speech": "🗣️ Fala %s na fila (posição %d). Uma notificação discord/playbackFinished é enviada ao terminar",
+	"soundboard.list":     "%d sons da soundboard encontrados no servidor %s",
+	"soundboard.uploaded": "✅ Som da soundboard %s (%s) enviado",
+	"soundboard.deleted":  "🗑️ Som da soundboard %s (%s) excluído",
+	"soundboard.played":   "🔊 Som da soundboard %s tocado no canal %s",
+}
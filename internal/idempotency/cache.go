@@ -0,0 +1,234 @@
+// Package idempotency remembers the results of state-changing tool calls by
+// a caller-chosen key, so a retried call returns the original result instead
+// of acting twice.
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"discord-mcp/pkg/types"
+)
+
+// Param is the per-call argument carrying the idempotency key. It is
+// removed from the arguments before the tool runs.
+const Param = "idempotency_key"
+
+// Limits for remembered results
+const (
+	maxKeyLength = 255
+	maxEntries   = 1000
+)
+
+// mutatingTools are the tools that change Discord or server state and so
+// accept an idempotency key
+var mutatingTools = map[string]bool{
+	"send_message":                true,
+	"broadcast_message":           true,
+	"edit_message":                true,
+	"delete_message":              true,
+	"add_reaction":                true,
+	"create_role":                 true,
+	"delete_role":                 true,
+	"assign_role":                 true,
+	"unassign_role":               true,
+	"create_watch":                true,
+	"delete_watch":                true,
+	"set_presence":                true,
+	"join_voice_channel":          true,
+	"leave_voice_channel":         true,
+	"play_audio":                  true,
+	"speak_in_voice":              true,
+	"play_soundboard_sound":       true,
+	"upload_soundboard_sound":     true,
+	"delete_soundboard_sound":     true,
+	"send_templated_message":      true,
+	"apply_guild_structure":       true,
+	"set_onboarding_rule":         true,
+	"create_auto_response":        true,
+	"delete_auto_response":        true,
+	"create_recurring_post":       true,
+	"delete_recurring_post":       true,
+	"start_giveaway":              true,
+	"draw_giveaway_winner":        true,
+	"configure_starboard":         true,
+	"set_pin_policy":              true,
+	"delete_pin_policy":           true,
+	"add_user_note":               true,
+	"delete_user_note":            true,
+	"warn_member":                 true,
+	"clear_warning":               true,
+	"open_ticket":                 true,
+	"reply_ticket":                true,
+	"close_ticket":                true,
+	"create_role_menu":            true,
+	"delete_role_menu":            true,
+	"configure_verification":      true,
+	"verify_member":               true,
+	"create_bridge":               true,
+	"delete_bridge":               true,
+	"create_relay":                true,
+	"delete_relay":                true,
+	"add_feed":                    true,
+	"remove_feed":                 true,
+	"arm_raid_protection":         true,
+	"disarm_raid_protection":      true,
+	"undo_action":                 true,
+	"undo_last_action":            true,
+	"execute_plan":                true,
+	"audit_nicknames":             true,
+	"reset_cooldown":              true,
+	"update_guild_widget":         true,
+	"follow_announcement_channel": true,
+	"set_command_permissions":     true,
+}
+
+// Mutating reports whether a tool changes state and accepts an idempotency
+// key
+func Mutating(tool string) bool {
+	return mutatingTools[tool]
+}
+
+// Take removes the idempotency key from args and returns it, or "" when the
+// call has none
+func Take(args map[string]interface{}) (string, error) {
+	value, ok := args[Param]
+	if !ok {
+		return "", nil
+	}
+	delete(args, Param)
+
+	key, ok := value.(string)
+	if !ok || key == "" || len(key) > maxKeyLength {
+		return "", fmt.Errorf("%s must be a non-empty string of at most %d characters", Param, maxKeyLength)
+	}
+	return key, nil
+}
+
+// Fingerprint identifies a call's arguments so a key reused for a different
+// call can be told apart from a retry
+func Fingerprint(args map[string]interface{}) string {
+	// Map keys are encoded in sorted order, so equal arguments always match
+	data, err := json.Marshal(args)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Cache holds successful results by tool and key until they expire
+type Cache struct {
+	ttl     time.Duration
+	entries map[string]*entry
+	mutex   sync.Mutex
+}
+
+// entry is a remembered result
+type entry struct {
+	fingerprint string
+	result      types.CallToolResult
+	expires     time.Time
+}
+
+// NewCache creates a cache that remembers results for ttl
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		entries: make(map[string]*entry),
+	}
+}
+
+// Lookup returns the remembered result of an earlier call with the same
+// tool and key. It fails when the key was used with different arguments.
+func (c *Cache) Lookup(tool, key, fingerprint string) (types.CallToolResult, bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.entries[tool+"\x00"+key]
+	if !ok || time.Now().After(e.expires) {
+		return types.CallToolResult{}, false, nil
+	}
+	if e.fingerprint != fingerprint {
+		return types.CallToolResult{}, false, fmt.Errorf("%s %q was already used for a %s call with different arguments", Param, key, tool)
+	}
+	return e.result, true, nil
+}
+
+// Store remembers a result. Error results are not kept, so a failed call
+// can be retried with the same key.
+func (c *Cache) Store(tool, key, fingerprint string, result types.CallToolResult) {
+	if result.IsError {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	c.prune(now)
+	c.entries[tool+"\x00"+key] = &entry{
+		fingerprint: fingerprint,
+		result:      result,
+		expires:     now.Add(c.ttl),
+	}
+}
+
+// prune drops expired entries and, when the cache is full, the entry
+// closest to expiring; callers must hold the lock
+func (c *Cache) prune(now time.Time) {
+	for id, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, id)
+		}
+	}
+	if len(c.entries) < maxEntries {
+		return
+	}
+	oldest := ""
+	for id, e := range c.entries {
+		if oldest == "" || e.expires.Before(c.entries[oldest].expires) {
+			oldest = id
+		}
+	}
+	delete(c.entries, oldest)
+}
+
+// WithParam returns a copy of a state-changing tool's definition that
+// advertises the idempotency_key argument; other tools are returned as is
+func WithParam(tool types.Tool) types.Tool {
+	if !Mutating(tool.Name) {
+		return tool
+	}
+	schema, ok := tool.InputSchema.(map[string]interface{})
+	if !ok {
+		return tool
+	}
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return tool
+	}
+
+	copied := make(map[string]interface{}, len(properties)+1)
+	for name, prop := range properties {
+		copied[name] = prop
+	}
+	copied[Param] = map[string]interface{}{
+		"type":        "string",
+		"minLength":   1,
+		"maxLength":   maxKeyLength,
+		"description": "Unique key for this action; repeating a call with the same key returns the original result instead of acting again",
+	}
+
+	result := make(map[string]interface{}, len(schema))
+	for k, v := range schema {
+		result[k] = v
+	}
+	result["properties"] = copied
+	tool.InputSchema = result
+	return tool
+}
@@ -0,0 +1,174 @@
+// Package journal keeps a short-lived record of reversible actions taken
+// through the tools, with the state needed to undo each one.
+package journal
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// MaxActions bounds the actions kept; the oldest are forgotten first
+const MaxActions = 200
+
+// Action types
+const (
+	TypeMessageSent    = "message_sent"
+	TypeMessageEdited  = "message_edited"
+	TypeReactionAdded  = "reaction_added"
+	TypeRoleCreated    = "role_created"
+	TypeRoleAssigned   = "role_assigned"
+	TypeRoleUnassigned = "role_unassigned"
+	TypeChannelEdited  = "channel_edited"
+)
+
+// Action is a reversible change. Only the fields its type needs are set.
+type Action struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Tool      string `json:"tool"`
+	GuildID   string `json:"guild_id,omitempty"`
+	ChannelID string `json:"channel_id,omitempty"`
+	MessageID string `json:"message_id,omitempty"`
+	// MessageIDs lists every message a split send produced
+	MessageIDs []string `json:"message_ids,omitempty"`
+	UserID     string   `json:"user_id,omitempty"`
+	RoleID     string   `json:"role_id,omitempty"`
+	// Emoji is the reaction in API form
+	Emoji string `json:"emoji,omitempty"`
+	// Fields lists the channel settings an edit changed
+	Fields []string `json:"fields,omitempty"`
+	// Before holds a message's content or a channel's settings from before
+	// the change
+	Before    *Before    `json:"before,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UndoneAt  *time.Time `json:"undone_at,omitempty"`
+}
+
+// Before is the state an edit replaced
+type Before struct {
+	Content string                    `json:"content,omitempty"`
+	Embeds  []*discordgo.MessageEmbed `json:"embeds,omitempty"`
+	Channel *ChannelState             `json:"channel,omitempty"`
+}
+
+// ChannelState is the editable part of a channel
+type ChannelState struct {
+	Topic                string                           `json:"topic"`
+	NSFW                 bool                             `json:"nsfw"`
+	RateLimitPerUser     int                              `json:"rate_limit_per_user"`
+	Bitrate              int                              `json:"bitrate,omitempty"`
+	UserLimit            int                              `json:"user_limit"`
+	ParentID             string                           `json:"parent_id,omitempty"`
+	PermissionOverwrites []*discordgo.PermissionOverwrite `json:"permission_overwrites"`
+}
+
+// SnapshotChannel captures a channel's editable settings
+func SnapshotChannel(channel *discordgo.Channel) *ChannelState {
+	overwrites := make([]*discordgo.PermissionOverwrite, len(channel.PermissionOverwrites))
+	for i, overwrite := range channel.PermissionOverwrites {
+		copied := *overwrite
+		overwrites[i] = &copied
+	}
+	return &ChannelState{
+		Topic:                channel.Topic,
+		NSFW:                 channel.NSFW,
+		RateLimitPerUser:     channel.RateLimitPerUser,
+		Bitrate:              channel.Bitrate,
+		UserLimit:            channel.UserLimit,
+		ParentID:             channel.ParentID,
+		PermissionOverwrites: overwrites,
+	}
+}
+
+// Journal records actions and forgets them after a time to live
+type Journal struct {
+	ttl     time.Duration
+	actions []*Action
+	nextID  int
+	mutex   sync.Mutex
+}
+
+// NewJournal creates a journal whose actions can be undone for ttl
+func NewJournal(ttl time.Duration) *Journal {
+	return &Journal{ttl: ttl}
+}
+
+// Record adds an action, assigning its ID and times, and returns it
+func (j *Journal) Record(action Action) Action {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	now := time.Now().UTC()
+	j.prune(now)
+
+	j.nextID++
+	action.ID = fmt.Sprintf("ua%d", j.nextID)
+	action.CreatedAt = now
+	action.ExpiresAt = now.Add(j.ttl)
+	action.UndoneAt = nil
+	j.actions = append(j.actions, &action)
+	if len(j.actions) > MaxActions {
+		j.actions = j.actions[len(j.actions)-MaxActions:]
+	}
+	return action
+}
+
+// Get returns an action that has not expired
+func (j *Journal) Get(id string) (Action, bool) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.prune(time.Now())
+	for _, action := range j.actions {
+		if action.ID == id {
+			return *action, true
+		}
+	}
+	return Action{}, false
+}
+
+// Last returns the newest action not yet undone, limited to a guild unless
+// guildID is empty
+func (j *Journal) Last(guildID string) (Action, bool) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.prune(time.Now())
+	for i := len(j.actions) - 1; i >= 0; i-- {
+		action := j.actions[i]
+		if action.UndoneAt == nil && (guildID == "" || action.GuildID == guildID) {
+			return *action, true
+		}
+	}
+	return Action{}, false
+}
+
+// MarkUndone flags an action as reversed and returns it
+func (j *Journal) MarkUndone(id string) (Action, bool) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	for _, action := range j.actions {
+		if action.ID == id {
+			now := time.Now().UTC()
+			action.UndoneAt = &now
+			return *action, true
+		}
+	}
+	return Action{}, false
+}
+
+// prune drops expired actions; callers must hold the lock
+func (j *Journal) prune(now time.Time) {
+	kept := j.actions[:0]
+	for _, action := range j.actions {
+		if now.Before(action.ExpiresAt) {
+			kept = append(kept, action)
+		}
+	}
+	j.actions = kept
+}
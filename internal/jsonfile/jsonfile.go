@@ -0,0 +1,47 @@
+// Package jsonfile persists the state of the stores that keep their data
+// in a single JSON file, such as giveaways, warnings and tickets.
+package jsonfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrSave reports that a persistence file could not be written
+var ErrSave = errors.New("failed to save")
+
+// Save writes v to path as indented JSON. The data goes to a temporary file
+// first and replaces path in one rename, so a crash never leaves a partly
+// written file behind.
+func Save(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("%w: %v", ErrSave, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("%w: %v", ErrSave, err)
+	}
+	return nil
+}
+
+// Load reads the JSON at path into v. It reports false, leaving v alone,
+// when the file does not exist yet.
+func Load(path string, v interface{}) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,60 @@
+package jsonfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testState struct {
+	NextID int      `json:"next_id"`
+	Items  []string `json:"items"`
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	state := testState{NextID: 1}
+	found, err := Load(path, &state)
+	if err != nil || found {
+		t.Fatalf("Load of a missing file = %v, %v", found, err)
+	}
+	if state.NextID != 1 {
+		t.Errorf("missing file changed the value: %+v", state)
+	}
+
+	if err := Save(path, testState{NextID: 3, Items: []string{"a", "b"}}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("temporary file left behind: %v", err)
+	}
+
+	var loaded testState
+	found, err = Load(path, &loaded)
+	if err != nil || !found {
+		t.Fatalf("Load = %v, %v", found, err)
+	}
+	if loaded.NextID != 3 || len(loaded.Items) != 2 || loaded.Items[1] != "b" {
+		t.Errorf("loaded %+v", loaded)
+	}
+}
+
+func TestSaveError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "state.json")
+	if err := Save(path, testState{}); !errors.Is(err, ErrSave) {
+		t.Errorf("Save into a missing directory = %v, want ErrSave", err)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var state testState
+	if found, err := Load(path, &state); err == nil || found {
+		t.Errorf("Load of invalid JSON = %v, %v", found, err)
+	}
+}
@@ -0,0 +1,65 @@
+// Package logging configures the server logger. Logs are written as text
+// for people, or as one JSON object per line for log pipelines such as ELK
+// or Datadog.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/config"
+)
+
+// Log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Fields attached to tool call log lines
+const (
+	FieldRequestID = "request_id"
+	FieldQueueID   = "queue_id"
+	FieldTool      = "tool"
+	FieldGuildID   = "guild_id"
+	FieldChannelID = "channel_id"
+	FieldDuration  = "duration_ms"
+	FieldOutcome   = "outcome"
+	FieldErrorType = "error_type"
+	FieldErrorCode = "error_code"
+)
+
+// Configure sets the level and format of a logger from the server
+// configuration. Logs go to out, which must not be stdout: stdout carries
+// the MCP protocol.
+func Configure(logger *logrus.Logger, cfg config.ServerConfig, out io.Writer) error {
+	level, err := logrus.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+	if cfg.Debug {
+		level = logrus.DebugLevel
+	}
+
+	switch cfg.LogFormat {
+	case FormatJSON:
+		logger.SetFormatter(&logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+			FieldMap: logrus.FieldMap{
+				logrus.FieldKeyTime: "timestamp",
+				logrus.FieldKeyMsg:  "message",
+			},
+		})
+	case FormatText, "":
+		logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	default:
+		return fmt.Errorf("invalid log format %q (text, json)", cfg.LogFormat)
+	}
+
+	logger.SetLevel(level)
+	logger.SetOutput(out)
+	return nil
+}
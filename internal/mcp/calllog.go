@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/logging"
+	"discord-mcp/pkg/types"
+)
+
+// Outcomes of a tool call in its log line
+const (
+	outcomeSuccess = "success"
+	outcomeError   = "error"
+	outcomeInvalid = "invalid_request"
+	outcomeQueued  = "queued"
+)
+
+// callLog collects the context of a tool call for its log lines
+type callLog struct {
+	fields logrus.Fields
+	// args are the call's arguments; names are resolved to IDs in place,
+	// so the logged guild and channel are IDs
+	args map[string]interface{}
+}
+
+// newCallLog starts the log context of a call. Replayed held calls have no
+// request ID and are identified by their queue ID instead.
+func newCallLog(requestID interface{}, replayID string) *callLog {
+	fields := logrus.Fields{}
+	if requestID != nil {
+		fields[logging.FieldRequestID] = requestID
+	}
+	if replayID != "" {
+		fields[logging.FieldQueueID] = replayID
+	}
+	return &callLog{fields: fields}
+}
+
+// set records the tool and arguments of the call
+func (c *callLog) set(params types.CallToolParams) {
+	c.fields[logging.FieldTool] = params.Name
+	c.args = params.Arguments
+}
+
+// entry returns a log entry with the call's context
+func (c *callLog) entry(logger *logrus.Logger) *logrus.Entry {
+	fields := make(logrus.Fields, len(c.fields)+2)
+	for key, value := range c.fields {
+		fields[key] = value
+	}
+	if id, ok := c.args["guild_id"].(string); ok && id != "" {
+		fields[logging.FieldGuildID] = id
+	}
+	if id, ok := c.args["channel_id"].(string); ok && id != "" {
+		fields[logging.FieldChannelID] = id
+	}
+	return logger.WithFields(fields)
+}
+
+// tool returns the called tool, or an empty string when the request could
+// not be parsed
+func (c *callLog) tool() string {
+	tool, _ := c.fields[logging.FieldTool].(string)
+	return tool
+}
+
+// logToolCall logs one line per tool call with its duration, and returns
+// its outcome
+func (s *Server) logToolCall(call *callLog, resp *types.Response, duration time.Duration) string {
+	entry := call.entry(s.logger).WithField(logging.FieldDuration, duration.Milliseconds())
+
+	if resp.Error != nil {
+		entry.WithFields(logrus.Fields{
+			logging.FieldOutcome:   outcomeInvalid,
+			logging.FieldErrorCode: resp.Error.Code,
+		}).Warn("Tool call rejected")
+		return outcomeInvalid
+	}
+
+	result, _ := resp.Result.(types.CallToolResult)
+	switch {
+	case isHeld(result):
+		entry.WithField(logging.FieldOutcome, outcomeQueued).Info("Tool call queued")
+		return outcomeQueued
+	case result.IsError:
+		fields := logrus.Fields{logging.FieldOutcome: outcomeError}
+		if len(result.Content) > 0 {
+			if data, ok := result.Content[0].Data.(map[string]interface{}); ok {
+				if errorType, ok := data["error_type"]; ok {
+					fields[logging.FieldErrorType] = errorType
+				}
+				// Discord API errors carry code, server errors error_code
+				if code, ok := data["code"]; ok {
+					fields[logging.FieldErrorCode] = code
+				} else if code, ok := data["error_code"]; ok {
+					fields[logging.FieldErrorCode] = code
+				}
+			}
+		}
+		entry.WithFields(fields).Warn("Tool call failed")
+		return outcomeError
+	default:
+		entry.WithField(logging.FieldOutcome, outcomeSuccess).Info("Tool call completed")
+		return outcomeSuccess
+	}
+}
@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/pkg/types"
+)
+
+// logLevels maps MCP log levels to logrus levels
+var logLevels = map[string]logrus.Level{
+	"debug":     logrus.DebugLevel,
+	"info":      logrus.InfoLevel,
+	"notice":    logrus.InfoLevel,
+	"warning":   logrus.WarnLevel,
+	"error":     logrus.ErrorLevel,
+	"critical":  logrus.ErrorLevel,
+	"alert":     logrus.ErrorLevel,
+	"emergency": logrus.ErrorLevel,
+}
+
+// handleSetLevel handles logging/setLevel. The debug level also turns on
+// tracing of Discord requests and gateway events; other levels return
+// tracing to trace.enabled.
+func (s *Server) handleSetLevel(req types.Request) *types.Response {
+	var params types.SetLevelParams
+	err := json.Unmarshal(req.Params, &params)
+	level, ok := logLevels[params.Level]
+	if err == nil && !ok {
+		err = fmt.Errorf("unknown level %q", params.Level)
+	}
+	if err != nil {
+		return &types.Response{
+			JSONRPC: types.JSONRPCVersion,
+			ID:      req.ID,
+			Error: &types.Error{
+				Code:    types.InvalidParams,
+				Message: "Invalid parameters",
+				Data:    err.Error(),
+			},
+		}
+	}
+
+	s.logger.SetLevel(level)
+	tracing := level == logrus.DebugLevel || s.config.Trace.Enabled
+	s.discord.Tracer().SetEnabled(tracing)
+	s.logger.WithFields(logrus.Fields{
+		"level":   params.Level,
+		"tracing": tracing,
+	}).Info("Log level changed")
+
+	return &types.Response{
+		JSONRPC: types.JSONRPCVersion,
+		ID:      req.ID,
+		Result:  map[string]interface{}{},
+	}
+}
@@ -0,0 +1,199 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/idempotency"
+	"discord-mcp/internal/offline"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// Outcomes of a held call reported by discord/queuedCallCompleted
+const (
+	heldSucceeded = "succeeded"
+	heldFailed    = "failed"
+	heldExpired   = "expired"
+)
+
+// holdCall holds a call until the gateway reconnects and returns its queued
+// result, or nil when the offline queue is full
+func (s *Server) holdCall(id interface{}, tool string, args map[string]interface{}, key, state, locale string) *types.Response {
+	now := time.Now()
+	s.notifyExpired(s.offline.Expire(now))
+
+	call, err := s.offline.Add(tool, key, idempotency.Fingerprint(args), args, locale, now)
+	if errors.Is(err, offline.ErrFull) {
+		s.logger.Warnf("Offline queue is full; %s fails while Discord is unavailable", tool)
+		return nil
+	}
+	if err != nil {
+		return &types.Response{
+			JSONRPC: types.JSONRPCVersion,
+			ID:      id,
+			Result: localizeError(validation.FormatValidationError(
+				validation.NewValidationError(idempotency.Param, err.Error(), key)), locale),
+		}
+	}
+
+	s.logger.Infof("Holding %s as %s until Discord reconnects", tool, call.ID)
+	return &types.Response{
+		JSONRPC: types.JSONRPCVersion,
+		ID:      id,
+		Result:  heldResult(call, state, locale),
+	}
+}
+
+// heldResult reports a call that will run after the gateway reconnects
+func heldResult(call offline.Call, state, locale string) types.CallToolResult {
+	data := map[string]interface{}{
+		"status":           "queued",
+		"queue_id":         call.ID,
+		"tool":             call.Tool,
+		"position":         call.Position,
+		"queued_at":        call.QueuedAt.Format(time.RFC3339),
+		"expires_at":       call.ExpiresAt.Format(time.RFC3339),
+		"connection_state": state,
+	}
+	if call.IdempotencyKey != "" {
+		data["idempotency_key"] = call.IdempotencyKey
+	}
+	text := i18n.T(locale, "server.call_queued", call.Tool, call.ID, state)
+	if state == discord.StateConnected {
+		// A retry of a call that is being replayed
+		text = i18n.T(locale, "server.call_replaying", call.Tool, call.ID)
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: data,
+		}},
+	}
+}
+
+// isHeld reports whether a result is the queued result of a held call
+func isHeld(result types.CallToolResult) bool {
+	if len(result.Content) == 0 {
+		return false
+	}
+	data, ok := result.Content[0].Data.(map[string]interface{})
+	return ok && data["status"] == "queued"
+}
+
+// notConnected reports whether a tool failed because the gateway was down,
+// before anything was sent to Discord
+func notConnected(result types.CallToolResult) bool {
+	if !result.IsError || len(result.Content) == 0 {
+		return false
+	}
+	data, ok := result.Content[0].Data.(map[string]interface{})
+	return ok && data["code"] == discord.ErrCodeNotConnected
+}
+
+// replayOffline runs the held calls in the order they were made once the
+// gateway has reconnected, stopping if it goes down again
+func (s *Server) replayOffline() {
+	s.notifyExpired(s.offline.Expire(time.Now()))
+
+	for s.discord.ConnectionState() == discord.StateConnected {
+		call, ok := s.offline.Next()
+		if !ok {
+			return
+		}
+
+		var args map[string]interface{}
+		if err := json.Unmarshal(call.Arguments, &args); err != nil {
+			s.logger.Errorf("Dropping held call %s: %v", call.ID, err)
+			s.offline.Done(call.ID)
+			continue
+		}
+		if call.IdempotencyKey != "" {
+			args[idempotency.Param] = call.IdempotencyKey
+		}
+		params, err := json.Marshal(types.CallToolParams{
+			Name:      call.Tool,
+			Arguments: args,
+			Meta:      &types.RequestMeta{Locale: call.Locale},
+		})
+		if err != nil {
+			s.logger.Errorf("Dropping held call %s: %v", call.ID, err)
+			s.offline.Done(call.ID)
+			continue
+		}
+
+		s.logger.Infof("Running held call %s (%s)", call.ID, call.Tool)
+		resp := s.runToolCall(types.Request{JSONRPC: types.JSONRPCVersion, Method: "tools/call", Params: params}, call.ID)
+		result, _ := resp.Result.(types.CallToolResult)
+		if isHeld(result) {
+			// The gateway went down again; the call waits for the next
+			// reconnect
+			s.offline.Release(call.ID)
+			return
+		}
+		s.offline.Done(call.ID)
+
+		status := heldSucceeded
+		if result.IsError {
+			status = heldFailed
+		}
+		s.notifyHeldCall(call, status, &result)
+	}
+}
+
+// notifyExpired reports held calls that were dropped unrun
+func (s *Server) notifyExpired(calls []offline.Call) {
+	for _, call := range calls {
+		s.logger.Warnf("Held call %s (%s) expired before Discord reconnected", call.ID, call.Tool)
+		s.notifyHeldCall(call, heldExpired, nil)
+	}
+}
+
+// notifyHeldCall sends the outcome of a held call as a
+// discord/queuedCallCompleted notification. It is sent immediately and is
+// not filtered by allowed_events.
+func (s *Server) notifyHeldCall(call offline.Call, status string, result *types.CallToolResult) {
+	if s.notificationSvc == nil {
+		return
+	}
+
+	params := map[string]interface{}{
+		"queue_id":  call.ID,
+		"tool":      call.Tool,
+		"status":    status,
+		"queued_at": call.QueuedAt.Format(time.RFC3339),
+		"waited_ms": time.Since(call.QueuedAt).Milliseconds(),
+	}
+	if call.IdempotencyKey != "" {
+		params["idempotency_key"] = call.IdempotencyKey
+	}
+	if result != nil {
+		params["result"] = result
+	}
+
+	data, err := json.Marshal(params)
+	if err != nil {
+		s.logger.Errorf("Failed to marshal queuedCallCompleted notification: %v", err)
+		return
+	}
+	if err := s.notificationSvc.SendImmediate(&types.Notification{Method: "discord/queuedCallCompleted", Params: data}); err != nil {
+		s.logger.Errorf("Failed to send queuedCallCompleted notification: %v", err)
+	}
+}
+
+// copyArguments returns a deep copy of tool arguments
+func copyArguments(args map[string]interface{}) map[string]interface{} {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return args
+	}
+	var copied map[string]interface{}
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return args
+	}
+	return copied
+}
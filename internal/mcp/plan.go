@@ -0,0 +1,338 @@
+package mcp
+
+import (
+	"fmt"
+	"time"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/resolve"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// PlanToolName is the tool that runs several tool calls as one plan
+const PlanToolName = "execute_plan"
+
+// Step outcomes reported by execute_plan
+const (
+	stepChecked       = "checked"
+	stepSucceeded     = "succeeded"
+	stepFailed        = "failed"
+	stepSkipped       = "skipped"
+	stepRolledBack    = "rolled_back"
+	stepRollbackError = "rollback_failed"
+	stepIrreversible  = "not_reversible"
+)
+
+// planStep is one tool call of a plan and what became of it
+type planStep struct {
+	Index     int                    `json:"index"`
+	Tool      string                 `json:"tool"`
+	Status    string                 `json:"status"`
+	Text      string                 `json:"text,omitempty"`
+	Data      interface{}            `json:"data,omitempty"`
+	ActionIDs []string               `json:"action_ids,omitempty"`
+	Rollback  map[string]interface{} `json:"rollback,omitempty"`
+
+	arguments map[string]interface{}
+}
+
+// planTool implements the execute_plan MCP tool. It runs inside
+// handleToolCall, which holds the server's read lock.
+type planTool struct {
+	server      *Server
+	validator   *validation.Validator
+	permissions *permissions.Checker
+}
+
+// newPlanTool creates the execute_plan tool for a server
+func newPlanTool(server *Server) *planTool {
+	return &planTool{
+		server:      server,
+		validator:   validation.NewValidator(server.config.Validation, server.logger),
+		permissions: permissions.NewChecker(server.discord, server.logger),
+	}
+}
+
+// Execute checks every step, then runs them in order, stopping at the
+// first failure and optionally undoing the steps that completed
+func (t *planTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	if err := t.validator.ValidateToolParams(PlanToolName, params.Arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	locale := i18n.Locale(params)
+	rollback := true
+	if val, ok := params.Arguments["rollback_on_failure"].(bool); ok {
+		rollback = val
+	}
+	dryRun, _ := params.Arguments["dry_run"].(bool)
+
+	rawSteps := params.Arguments["steps"].([]interface{})
+	steps := make([]*planStep, len(rawSteps))
+	for i, raw := range rawSteps {
+		entry := raw.(map[string]interface{})
+		arguments, _ := entry["arguments"].(map[string]interface{})
+		if arguments == nil {
+			arguments = map[string]interface{}{}
+		}
+		steps[i] = &planStep{Index: i, Tool: entry["tool"].(string), Status: stepSkipped, arguments: arguments}
+	}
+
+	// Nothing runs unless every step would be allowed
+	for _, step := range steps {
+		if rejected, ok := t.check(step, params.Meta); !ok {
+			return t.rejected(locale, step, rejected), nil
+		}
+	}
+
+	if dryRun {
+		for _, step := range steps {
+			step.Status = stepChecked
+		}
+		return types.CallToolResult{
+			Content: []types.Content{{
+				Type: "text",
+				Text: i18n.T(locale, "plan.checked", len(steps)),
+				Data: planData(steps, true),
+			}},
+		}, nil
+	}
+
+	for i, step := range steps {
+		t.server.logger.Debugf("Executing plan step %d: %s", i, step.Tool)
+		stepParams := types.CallToolParams{Name: step.Tool, Arguments: step.arguments, Meta: params.Meta}
+		started := time.Now()
+		result, err := t.server.tools[step.Tool].Execute(stepParams)
+		if t.server.hooks.AfterToolCall != nil {
+			t.server.hooks.AfterToolCall(stepParams, result, err, time.Since(started))
+		}
+		if err != nil {
+			result = types.CallToolResult{
+				IsError: true,
+				Content: []types.Content{{Type: "text", Text: i18n.T(locale, "server.tool_failed", err)}},
+			}
+		}
+		step.record(localizeError(result, locale))
+
+		if result.IsError {
+			step.Status = stepFailed
+			rolledBack := 0
+			if rollback {
+				rolledBack = t.rollback(steps[:i], params.Meta)
+			}
+			data := planData(steps, false)
+			data["failed_step"] = i
+			data["rolled_back"] = rolledBack
+			return types.CallToolResult{
+				Content: []types.Content{{
+					Type: "text",
+					Text: i18n.T(locale, "plan.failed", i+1, step.Tool, i, len(steps), rolledBack),
+					Data: data,
+				}},
+				IsError: true,
+			}, nil
+		}
+		step.Status = stepSucceeded
+	}
+
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(locale, "plan.completed", len(steps)),
+			Data: planData(steps, false),
+		}},
+	}, nil
+}
+
+// check runs a step through the checks a direct call would get before its
+// tool runs, including the BeforeToolCall hook, plus the tool's permission
+// requirements. It returns the error result of a step that would be
+// refused.
+func (t *planTool) check(step *planStep, meta *types.RequestMeta) (types.CallToolResult, bool) {
+	if _, exists := t.server.tools[step.Tool]; !exists || step.Tool == PlanToolName {
+		return validation.FormatValidationError(validation.NewValidationError("unknown tool",
+			fmt.Sprintf("%q is not a tool that can run in a plan", step.Tool), "tool")), false
+	}
+
+	// Resolve names to IDs so policies and tools only see IDs
+	if t.server.names != nil {
+		if err := t.server.names.ExpandNames(step.arguments); err != nil {
+			if nameErr, ok := err.(*resolve.NameError); ok {
+				return resolve.FormatNameError(nameErr), false
+			}
+			return validation.FormatValidationError(validation.NewValidationError("invalid name", err.Error(), nil)), false
+		}
+	}
+
+	params := types.CallToolParams{Name: step.Tool, Arguments: step.arguments, Meta: meta}
+	if result, blocked := t.server.policy.Enforce(&params); blocked {
+		return result, false
+	}
+	if err := t.validator.ValidateToolParams(step.Tool, step.arguments); err != nil {
+		return validation.FormatValidationError(err), false
+	}
+	// Let an embedding program reject or adjust the step, as it could a
+	// direct call
+	if t.server.hooks.BeforeToolCall != nil {
+		if err := t.server.hooks.BeforeToolCall(&params); err != nil {
+			return hookRejection(err), false
+		}
+		step.arguments = params.Arguments
+	}
+	if err := t.checkPermissions(step.Tool, step.arguments); err != nil {
+		if permErr, ok := err.(*permissions.PermissionError); ok {
+			return permissions.FormatPermissionError(permErr), false
+		}
+		return validation.FormatValidationError(validation.NewValidationError("permission check failed", err.Error(), nil)), false
+	}
+	return types.CallToolResult{}, true
+}
+
+// checkPermissions checks the Discord permissions a state-changing tool
+// needs. Other tools check their own permissions when they run.
+func (t *planTool) checkPermissions(tool string, args map[string]interface{}) error {
+	channelID, _ := args["channel_id"].(string)
+	guildID, _ := args["guild_id"].(string)
+	roleID, _ := args["role_id"].(string)
+
+	switch tool {
+	case "send_message", "send_templated_message":
+		tts, _ := args["tts"].(bool)
+		return t.permissions.ValidateMessageOperation("send_message", channelID, map[string]interface{}{"tts": tts})
+	case "edit_message", "delete_message":
+		return t.permissions.ValidateMessageOperation(tool, channelID, map[string]interface{}{"message_id": args["message_id"]})
+	case "add_reaction":
+		return t.permissions.ValidateMessageOperation(tool, channelID, map[string]interface{}{"emoji": args["emoji"]})
+	case "create_role":
+		return t.permissions.CanManageRoles(guildID)
+	case "delete_role", "assign_role", "unassign_role":
+		return t.permissions.CanManageRole(guildID, roleID)
+	case "apply_guild_structure":
+		if err := t.permissions.CanManageRoles(guildID); err != nil {
+			return err
+		}
+		return t.permissions.CanManageChannels(guildID)
+	}
+	return nil
+}
+
+// rollback undoes completed steps newest first with undo_action and
+// returns how many were fully undone
+func (t *planTool) rollback(completed []*planStep, meta *types.RequestMeta) int {
+	undo, ok := t.server.tools["undo_action"]
+	rolledBack := 0
+	for i := len(completed) - 1; i >= 0; i-- {
+		step := completed[i]
+		if len(step.ActionIDs) == 0 || !ok {
+			step.Status = stepIrreversible
+			continue
+		}
+
+		step.Status = stepRolledBack
+		step.Rollback = map[string]interface{}{"action_ids": step.ActionIDs}
+		for j := len(step.ActionIDs) - 1; j >= 0; j-- {
+			result, err := undo.Execute(types.CallToolParams{
+				Name:      "undo_action",
+				Arguments: map[string]interface{}{"action_id": step.ActionIDs[j]},
+				Meta:      meta,
+			})
+			if err == nil && !result.IsError {
+				continue
+			}
+			message := ""
+			if err != nil {
+				message = err.Error()
+			} else if len(result.Content) > 0 {
+				message = result.Content[0].Text
+			}
+			t.server.logger.Warnf("Failed to roll back plan step %d (%s): %s", step.Index, step.Tool, message)
+			step.Status = stepRollbackError
+			step.Rollback["error"] = message
+			break
+		}
+		if step.Status == stepRolledBack {
+			rolledBack++
+		}
+	}
+	return rolledBack
+}
+
+// rejected reports a step refused before anything ran
+func (t *planTool) rejected(locale string, step *planStep, result types.CallToolResult) types.CallToolResult {
+	result = localizeError(result, locale)
+	reason := ""
+	var details interface{}
+	if len(result.Content) > 0 {
+		reason = result.Content[0].Text
+		details = result.Content[0].Data
+	}
+	// Prefer the bare message over text that repeats the error heading
+	if data, ok := details.(map[string]interface{}); ok {
+		for _, key := range []string{"message", "description"} {
+			if message, ok := data[key].(string); ok && message != "" {
+				reason = message
+				break
+			}
+		}
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(locale, "plan.rejected", step.Index+1, step.Tool, reason),
+			Data: map[string]interface{}{
+				"error_type": "plan_rejected",
+				"step":       step.Index,
+				"tool":       step.Tool,
+				"reason":     reason,
+				"details":    details,
+			},
+		}},
+		IsError: true,
+	}
+}
+
+// record keeps a step's result text and data, and the undo journal IDs
+// the tool returned
+func (s *planStep) record(result types.CallToolResult) {
+	if len(result.Content) == 0 {
+		return
+	}
+	s.Text = result.Content[0].Text
+	s.Data = result.Content[0].Data
+
+	data, ok := s.Data.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if id, ok := data["action_id"].(string); ok {
+		s.ActionIDs = append(s.ActionIDs, id)
+	}
+	if ids, ok := data["action_ids"].([]string); ok {
+		s.ActionIDs = append(s.ActionIDs, ids...)
+	}
+}
+
+// planData summarizes a plan's steps
+func planData(steps []*planStep, dryRun bool) map[string]interface{} {
+	completed := 0
+	for _, step := range steps {
+		switch step.Status {
+		case stepSucceeded, stepRolledBack, stepRollbackError, stepIrreversible:
+			completed++
+		}
+	}
+	return map[string]interface{}{
+		"dry_run":    dryRun,
+		"step_count": len(steps),
+		"completed":  completed,
+		"steps":      steps,
+	}
+}
+
+// GetDefinition returns the tool definition
+func (t *planTool) GetDefinition() types.Tool {
+	return validation.GetToolDefinition(PlanToolName, "Run several tool calls in order after checking every step's arguments, policies and permissions up front; on failure, undo the steps that completed")
+}
@@ -6,13 +6,24 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"discord-mcp/internal/config"
 	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/idempotency"
 	"discord-mcp/internal/notifications"
+	"discord-mcp/internal/offline"
+	"discord-mcp/internal/permissions"
+	"discord-mcp/internal/policy"
+	"discord-mcp/internal/resolve"
+	"discord-mcp/internal/response"
+	"discord-mcp/internal/stats"
+	"discord-mcp/internal/validation"
 	"discord-mcp/pkg/types"
 )
 
@@ -25,6 +36,45 @@ type Server struct {
 	initialized     bool
 	mutex           sync.RWMutex
 	notificationSvc *notifications.Service
+	policy          *policy.Engine
+	pager           *response.Pager
+
+	// idempotency remembers results of state-changing calls by
+	// idempotency_key; nil when disabled
+	idempotency *idempotency.Cache
+
+	// offline holds calls made while the gateway is down; nil when disabled
+	offline *offline.Queue
+
+	// names resolves channel, role and user names in tool arguments; nil
+	// when discord.strict_ids is set
+	names *resolve.Resolver
+
+	// stats counts tool calls since startup
+	stats *stats.Tools
+
+	// hooks are set by programs embedding the server
+	hooks Hooks
+
+	// Event handlers are set up and the gateway connected once, however
+	// many streams are served
+	handlersOnce sync.Once
+	connectMutex sync.Mutex
+	connected    bool
+}
+
+// Hooks let programs embedding the server observe and intercept tool
+// calls. Either may be nil.
+type Hooks struct {
+	// BeforeToolCall runs after policies, just before a tool executes. It
+	// may change the arguments; returning an error rejects the call with
+	// the error's message. The steps of execute_plan are each passed to it
+	// while the plan is checked, before any step runs.
+	BeforeToolCall func(params *types.CallToolParams) error
+	// AfterToolCall runs when a tool returns, with its result or error and
+	// how long it ran, before the result is shaped and paged. It also runs
+	// for each step of execute_plan that executes.
+	AfterToolCall func(params types.CallToolParams, result types.CallToolResult, err error, elapsed time.Duration)
 }
 
 // ToolHandler defines the interface for tool handlers
@@ -35,12 +85,33 @@ type ToolHandler interface {
 
 // NewServer creates a new MCP server
 func NewServer(cfg *config.Config, logger *logrus.Logger, discordClient *discord.Client) *Server {
-	return &Server{
+	server := &Server{
 		config:  cfg,
 		logger:  logger,
 		discord: discordClient,
 		tools:   make(map[string]ToolHandler),
+		policy:  policy.NewEngine(cfg.Policy, discordClient, logger),
+		pager:   response.NewPager(cfg.MCP.MaxResultBytes),
+		stats:   stats.NewTools(),
+	}
+	// Notifications are written once Serve knows the output
+	server.notificationSvc = notifications.NewService(nil, logger)
+	server.notificationSvc.Configure(cfg.Events)
+	checker := permissions.NewChecker(discordClient, logger)
+	// Watches must not forward messages from excluded guilds and channels
+	discordClient.SetChannelAccess(checker.IsEventAccessible)
+	if !cfg.Discord.StrictIDs {
+		server.names = resolve.NewResolver(discordClient, checker)
+	}
+	if cfg.MCP.IdempotencyTTLSeconds > 0 {
+		server.idempotency = idempotency.NewCache(time.Duration(cfg.MCP.IdempotencyTTLSeconds) * time.Second)
+	}
+	if cfg.Offline.Enabled {
+		server.offline = offline.NewQueue(cfg.Offline.MaxCalls, time.Duration(cfg.Offline.TTLSeconds)*time.Second)
+		discordClient.OnConnected(server.replayOffline)
 	}
+	server.RegisterTool(newPlanTool(server))
+	return server
 }
 
 // RegisterTool registers a tool handler
@@ -53,31 +124,116 @@ func (s *Server) RegisterTool(handler ToolHandler) {
 	s.logger.Debugf("Registered tool: %s", tool.Name)
 }
 
-// Start starts the MCP server
+// SetHooks replaces the tool call hooks
+func (s *Server) SetHooks(hooks Hooks) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.hooks = hooks
+}
+
+// Notifications returns the service that sends notifications to the client
+func (s *Server) Notifications() *notifications.Service {
+	return s.notificationSvc
+}
+
+// ToolStats returns the call totals of each called tool and of all tools
+func (s *Server) ToolStats() ([]stats.ToolStats, stats.ToolStats) {
+	return s.stats.Snapshot()
+}
+
+// NotificationStats returns the notification totals since startup
+func (s *Server) NotificationStats() notifications.Stats {
+	if s.notificationSvc == nil {
+		return notifications.Stats{Sent: map[string]int64{}}
+	}
+	return s.notificationSvc.Stats()
+}
+
+// ToolNames returns the names of the registered tools in sorted order
+func (s *Server) ToolNames() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	names := make([]string, 0, len(s.tools))
+	for name := range s.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Start starts the MCP server on stdin and stdout
 func (s *Server) Start() error {
+	return s.Serve(os.Stdin, os.Stdout)
+}
+
+// Serve connects to Discord and handles JSON-RPC messages read from input
+// until it ends, writing responses and notifications to output. Programs
+// embedding the server can serve any stream, such as a socket. Streams are
+// served one after another: the first call connects to Discord, and later
+// calls reuse the connection and only move notifications to their output.
+func (s *Server) Serve(input io.Reader, output io.Writer) error {
 	s.logger.Info("Starting MCP server...")
 
-	// Create the notification service and pass it to the Discord client
-	s.notificationSvc = notifications.NewService(os.Stdout, s.logger)
-	s.discord.SetupEventHandlers(s.notificationSvc)
+	// Responses and notifications share the output one line at a time
+	out := &lockedWriter{writer: output}
+	s.notificationSvc.SetWriter(out)
+	if err := s.connect(); err != nil {
+		return err
+	}
 
-	// Connect to Discord
+	return s.handleCommunication(input, out)
+}
+
+// connect registers the Discord event handlers and connects to the gateway
+// unless an earlier Serve already did
+func (s *Server) connect() error {
+	s.handlersOnce.Do(func() {
+		s.discord.SetupEventHandlers(s.notificationSvc)
+	})
+
+	s.connectMutex.Lock()
+	defer s.connectMutex.Unlock()
+	if s.connected {
+		return nil
+	}
 	if err := s.discord.Connect(); err != nil {
 		return fmt.Errorf("failed to connect to Discord: %w", err)
 	}
+	s.connected = true
+	return nil
+}
+
+// lockedWriter serializes writes so concurrent messages do not interleave
+type lockedWriter struct {
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+// Write writes p in one piece
+func (w *lockedWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
 
-	// Start handling stdin/stdout communication
-	return s.handleCommunication(os.Stdin, os.Stdout)
+	return w.writer.Write(p)
 }
 
 // Stop stops the MCP server
 func (s *Server) Stop() error {
 	s.logger.Info("Stopping MCP server...")
 
+	s.connectMutex.Lock()
+	s.connected = false
+	s.connectMutex.Unlock()
 	if err := s.discord.Disconnect(); err != nil {
 		s.logger.Warnf("Error disconnecting from Discord: %v", err)
 	}
 
+	if s.notificationSvc != nil {
+		s.notificationSvc.Close()
+	}
+
 	return nil
 }
 
@@ -131,7 +287,14 @@ func (s *Server) processMessage(message string) *types.Response {
 		}
 	}
 
-	// Handle the request based on method
+	span := s.startRequestSpan(req)
+	resp := s.dispatch(req)
+	endRequestSpan(span, resp)
+	return resp
+}
+
+// dispatch handles a request based on its method
+func (s *Server) dispatch(req types.Request) *types.Response {
 	switch req.Method {
 	case "initialize":
 		return s.handleInitialize(req)
@@ -143,6 +306,8 @@ func (s *Server) processMessage(message string) *types.Response {
 		return s.handleToolCall(req)
 	case "ping":
 		return s.handlePing(req)
+	case "logging/setLevel":
+		return s.handleSetLevel(req)
 	default:
 		return &types.Response{
 			JSONRPC: types.JSONRPCVersion,
@@ -183,6 +348,7 @@ func (s *Server) handleInitialize(req types.Request) *types.Response {
 			Tools: &types.ToolsCapability{
 				ListChanged: false,
 			},
+			Logging: &types.LoggingCapability{},
 		},
 		ServerInfo: types.ServerInfo{
 			Name:    s.config.MCP.ServerName,
@@ -221,7 +387,7 @@ func (s *Server) handleToolsList(req types.Request) *types.Response {
 				Content: []types.Content{
 					{
 						Type: "text",
-						Text: "Server not initialized",
+						Text: i18n.T(s.locale(types.CallToolParams{}), "server.not_initialized"),
 					},
 				},
 			},
@@ -230,7 +396,11 @@ func (s *Server) handleToolsList(req types.Request) *types.Response {
 
 	var tools []types.Tool
 	for _, handler := range s.tools {
-		tools = append(tools, handler.GetDefinition())
+		tool := handler.GetDefinition()
+		if s.names != nil {
+			tool = validation.WithNameParams(tool)
+		}
+		tools = append(tools, idempotency.WithParam(response.WithParams(tool)))
 	}
 
 	result := types.ToolsListResult{
@@ -246,6 +416,25 @@ func (s *Server) handleToolsList(req types.Request) *types.Response {
 
 // handleToolCall handles the tools/call request
 func (s *Server) handleToolCall(req types.Request) *types.Response {
+	return s.runToolCall(req, "")
+}
+
+// runToolCall runs a tool call and logs its outcome; replayID is the
+// offline queue ID when the call is a held call being replayed
+func (s *Server) runToolCall(req types.Request, replayID string) *types.Response {
+	start := time.Now()
+	call := newCallLog(req.ID, replayID)
+	resp := s.callTool(req, replayID, call)
+	duration := time.Since(start)
+	outcome := s.logToolCall(call, resp, duration)
+	if tool := call.tool(); tool != "" {
+		s.stats.Record(tool, outcome == outcomeError || outcome == outcomeInvalid, duration)
+	}
+	return resp
+}
+
+// callTool runs a tool call, recording its tool and arguments in call
+func (s *Server) callTool(req types.Request, replayID string, call *callLog) *types.Response {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -258,7 +447,7 @@ func (s *Server) handleToolCall(req types.Request) *types.Response {
 				Content: []types.Content{
 					{
 						Type: "text",
-						Text: "Server not initialized",
+						Text: i18n.T(s.locale(types.CallToolParams{}), "server.not_initialized"),
 					},
 				},
 			},
@@ -278,6 +467,35 @@ func (s *Server) handleToolCall(req types.Request) *types.Response {
 		}
 	}
 
+	// Tools fill schema defaults into the argument map, so always pass one
+	if params.Arguments == nil {
+		params.Arguments = map[string]interface{}{}
+	}
+	call.set(params)
+
+	// Handlers read the response language from _meta.locale
+	locale := s.locale(params)
+	if params.Meta == nil {
+		params.Meta = &types.RequestMeta{}
+	}
+	params.Meta.Locale = locale
+
+	// detail and fields shape the result, so tools never see them; neither
+	// do idempotency keys
+	var idempotencyKey string
+	shape, err := response.TakeOptions(params.Arguments, s.config.MCP.ResponseDetail)
+	if err == nil {
+		idempotencyKey, err = idempotency.Take(params.Arguments)
+	}
+	if err != nil {
+		return &types.Response{
+			JSONRPC: types.JSONRPCVersion,
+			ID:      req.ID,
+			Result: localizeError(validation.FormatValidationError(
+				validation.NewValidationError("invalid parameter", err.Error(), nil)), locale),
+		}
+	}
+
 	handler, exists := s.tools[params.Name]
 	if !exists {
 		return &types.Response{
@@ -288,15 +506,167 @@ func (s *Server) handleToolCall(req types.Request) *types.Response {
 				Content: []types.Content{
 					{
 						Type: "text",
-						Text: fmt.Sprintf("Tool not found: %s", params.Name),
+						Text: i18n.T(locale, "server.tool_not_found", params.Name),
+					},
+				},
+			},
+		}
+	}
+
+	// A result cursor returns the next page of an earlier truncated result
+	// without running the tool again
+	if value, ok := params.Arguments[response.CursorParam]; ok {
+		cursor, ok := value.(string)
+		result, err := s.pager.Continue(params.Name, cursor, locale)
+		if !ok {
+			err = fmt.Errorf("%s must be a string", response.CursorParam)
+		}
+		if err != nil {
+			result = localizeError(validation.FormatValidationError(
+				validation.NewValidationError(response.CursorParam, err.Error(), value)), locale)
+		}
+		return &types.Response{
+			JSONRPC: types.JSONRPCVersion,
+			ID:      req.ID,
+			Result:  result,
+		}
+	}
+
+	// Fail fast while the Discord gateway is down instead of letting every
+	// tool time out against a dead connection. Calls that are safe to run
+	// late are held until it reconnects.
+	queueable := s.offline != nil && offline.Queueable(params.Name, idempotency.Mutating(params.Name), idempotencyKey != "" && s.idempotency != nil)
+	var heldArgs map[string]interface{}
+	if queueable {
+		// Tools change their arguments, so a call is held as it was made
+		heldArgs = copyArguments(params.Arguments)
+	}
+	if state := s.discord.ConnectionState(); state != discord.StateConnected {
+		if queueable {
+			if resp := s.holdCall(req.ID, params.Name, heldArgs, idempotencyKey, state, locale); resp != nil {
+				return resp
+			}
+		}
+		return &types.Response{
+			JSONRPC: types.JSONRPCVersion,
+			ID:      req.ID,
+			Result: types.CallToolResult{
+				IsError: true,
+				Content: []types.Content{
+					{
+						Type: "text",
+						Text: i18n.T(locale, "server.discord_unavailable", state),
+						Data: map[string]interface{}{
+							"error_code":       types.DiscordUnavailable,
+							"connection_state": state,
+						},
 					},
 				},
 			},
 		}
 	}
 
-	s.logger.Debugf("Executing tool: %s", params.Name)
+	// A held call runs once, after the outage, however often it is retried
+	if queueable && idempotencyKey != "" {
+		held, ok, err := s.offline.Held(params.Name, idempotencyKey, idempotency.Fingerprint(heldArgs))
+		if err != nil {
+			return &types.Response{
+				JSONRPC: types.JSONRPCVersion,
+				ID:      req.ID,
+				Result: localizeError(validation.FormatValidationError(
+					validation.NewValidationError(idempotency.Param, err.Error(), idempotencyKey)), locale),
+			}
+		}
+		if ok && held.ID != replayID {
+			return &types.Response{
+				JSONRPC: types.JSONRPCVersion,
+				ID:      req.ID,
+				Result:  heldResult(held, s.discord.ConnectionState(), locale),
+			}
+		}
+	}
+
+	// Resolve names to IDs so policies and tools only see IDs
+	if s.names != nil {
+		if err := s.names.ExpandNames(params.Arguments); err != nil {
+			result := types.CallToolResult{
+				IsError: true,
+				Content: []types.Content{{Type: "text", Text: i18n.T(locale, "error.name", err)}},
+			}
+			if nameErr, ok := err.(*resolve.NameError); ok {
+				result = localizeError(resolve.FormatNameError(nameErr), locale)
+			}
+			return &types.Response{
+				JSONRPC: types.JSONRPCVersion,
+				ID:      req.ID,
+				Result:  result,
+			}
+		}
+	}
+
+	// Results show times in the zone of the guild the call acts in
+	zone := s.discord.Location(s.guildOf(params.Arguments))
+
+	// Evaluate operator policies before the tool runs its Discord permission checks
+	if result, blocked := s.policy.Enforce(&params); blocked {
+		return &types.Response{
+			JSONRPC: types.JSONRPCVersion,
+			ID:      req.ID,
+			Result:  result,
+		}
+	}
+
+	// A repeated idempotency key returns the original result instead of
+	// acting twice
+	useKey := idempotencyKey != "" && s.idempotency != nil && idempotency.Mutating(params.Name)
+	var fingerprint string
+	if useKey {
+		fingerprint = idempotency.Fingerprint(params.Arguments)
+		cached, found, err := s.idempotency.Lookup(params.Name, idempotencyKey, fingerprint)
+		if err != nil {
+			return &types.Response{
+				JSONRPC: types.JSONRPCVersion,
+				ID:      req.ID,
+				Result: localizeError(validation.FormatValidationError(
+					validation.NewValidationError(idempotency.Param, err.Error(), idempotencyKey)), locale),
+			}
+		}
+		if found {
+			call.entry(s.logger).Infof("Returning the original result for idempotency key %q", idempotencyKey)
+			return &types.Response{
+				JSONRPC: types.JSONRPCVersion,
+				ID:      req.ID,
+				Result:  localizeError(s.pager.Limit(params.Name, response.Apply(response.Localize(cached, zone), shape), locale), locale),
+			}
+		}
+	}
+
+	// Let an embedding program reject or adjust the call
+	if s.hooks.BeforeToolCall != nil {
+		if err := s.hooks.BeforeToolCall(&params); err != nil {
+			return &types.Response{
+				JSONRPC: types.JSONRPCVersion,
+				ID:      req.ID,
+				Result:  hookRejection(err),
+			}
+		}
+	}
+
+	call.entry(s.logger).Debug("Executing tool")
+	started := time.Now()
 	result, err := handler.Execute(params)
+	if s.hooks.AfterToolCall != nil {
+		s.hooks.AfterToolCall(params, result, err, time.Since(started))
+	}
+	if err == nil && queueable && notConnected(result) {
+		// The gateway dropped after the check above; nothing was sent
+		if resp := s.holdCall(req.ID, params.Name, heldArgs, idempotencyKey, s.discord.ConnectionState(), locale); resp != nil {
+			return resp
+		}
+	}
+	if err == nil && useKey {
+		s.idempotency.Store(params.Name, idempotencyKey, fingerprint, result)
+	}
 	if err != nil {
 		return &types.Response{
 			JSONRPC: types.JSONRPCVersion,
@@ -306,7 +676,7 @@ func (s *Server) handleToolCall(req types.Request) *types.Response {
 				Content: []types.Content{
 					{
 						Type: "text",
-						Text: fmt.Sprintf("Tool execution failed: %v", err),
+						Text: i18n.T(locale, "server.tool_failed", err),
 					},
 				},
 			},
@@ -316,8 +686,95 @@ func (s *Server) handleToolCall(req types.Request) *types.Response {
 	return &types.Response{
 		JSONRPC: types.JSONRPCVersion,
 		ID:      req.ID,
-		Result:  result,
+		Result:  localizeError(s.pager.Limit(params.Name, response.Apply(response.Localize(result, zone), shape), locale), locale),
+	}
+}
+
+// hookRejection is the result of a call BeforeToolCall refused
+func hookRejection(err error) types.CallToolResult {
+	return types.CallToolResult{
+		IsError: true,
+		Content: []types.Content{{
+			Type: "text",
+			Text: err.Error(),
+			Data: map[string]interface{}{
+				"error_type": "rejected",
+				"message":    err.Error(),
+			},
+		}},
+	}
+}
+
+// locale returns the locale a tool call responds in: the client's
+// _meta.locale when there is a catalog for it, otherwise server.locale
+func (s *Server) locale(params types.CallToolParams) string {
+	if params.Meta != nil {
+		if locale := i18n.Normalize(params.Meta.Locale); locale != "" {
+			return locale
+		}
+	}
+	if guild, ok := s.config.Guilds[s.guildOf(params.Arguments)]; ok {
+		if locale := i18n.Normalize(guild.Locale); locale != "" {
+			return locale
+		}
+	}
+	if locale := i18n.Normalize(s.config.Server.Locale); locale != "" {
+		return locale
+	}
+	return i18n.DefaultLocale
+}
+
+// guildOf returns the guild a tool call acts in for the guilds settings:
+// its guild_id, or the guild of its channel_id as known to the gateway
+// state. Nothing is looked up when no guild has settings.
+func (s *Server) guildOf(args map[string]interface{}) string {
+	if len(s.config.Guilds) == 0 {
+		return ""
+	}
+	if guildID, ok := args["guild_id"].(string); ok && guildID != "" {
+		return guildID
+	}
+	if channelID, ok := args["channel_id"].(string); ok && channelID != "" {
+		if channel, err := s.discord.Session().State.Channel(channelID); err == nil {
+			return channel.GuildID
+		}
 	}
+	return ""
+}
+
+// localizeError renders the text of a validation, permission or Discord
+// API error result in the given locale from the result's structured data.
+// The shared error formatters have no access to the call, so they always
+// produce English text.
+func localizeError(result types.CallToolResult, locale string) types.CallToolResult {
+	if !result.IsError || len(result.Content) == 0 || locale == i18n.DefaultLocale {
+		return result
+	}
+	data, ok := result.Content[0].Data.(map[string]interface{})
+	if !ok {
+		return result
+	}
+
+	content := result.Content[0]
+	switch data["error_type"] {
+	case "permission":
+		content.Text = i18n.T(locale, "error.permission", data["description"])
+	case "discord_api":
+		content.Text = i18n.T(locale, "error.discord", data["message"], data["details"])
+		if hint, ok := data["hint"].(string); ok {
+			content.Text += "\n" + i18n.T(locale, "error.hint", hint)
+		}
+	default:
+		// Validation errors carry a field, which may be nil
+		if _, ok := data["field"]; !ok {
+			return result
+		}
+		content.Text = i18n.T(locale, "error.validation", data["message"])
+	}
+
+	localized := result
+	localized.Content = append([]types.Content{content}, result.Content[1:]...)
+	return localized
 }
 
 // handlePing handles ping requests
@@ -334,13 +791,14 @@ func (s *Server) handlePing(req types.Request) *types.Response {
 				Content: []types.Content{
 					{
 						Type: "text",
-						Text: "Server not initialized",
+						Text: i18n.T(s.locale(types.CallToolParams{}), "server.not_initialized"),
 					},
 				},
 			},
 		}
 	}
 
+	locale := s.locale(types.CallToolParams{})
 	handler, exists := s.tools["ping"]
 	if !exists {
 		return &types.Response{
@@ -351,7 +809,7 @@ func (s *Server) handlePing(req types.Request) *types.Response {
 				Content: []types.Content{
 					{
 						Type: "text",
-						Text: "Tool not found: ping",
+						Text: i18n.T(locale, "server.tool_not_found", "ping"),
 					},
 				},
 			},
@@ -361,6 +819,7 @@ func (s *Server) handlePing(req types.Request) *types.Response {
 	s.logger.Debugf("Executing tool: ping")
 	result, err := handler.Execute(types.CallToolParams{
 		Name: "ping",
+		Meta: &types.RequestMeta{Locale: locale},
 	})
 	if err != nil {
 		return &types.Response{
@@ -371,7 +830,7 @@ func (s *Server) handlePing(req types.Request) *types.Response {
 				Content: []types.Content{
 					{
 						Type: "text",
-						Text: fmt.Sprintf("Tool execution failed: %v", err),
+						Text: i18n.T(locale, "server.tool_failed", err),
 					},
 				},
 			},
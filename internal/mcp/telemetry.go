@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"discord-mcp/internal/telemetry"
+	"discord-mcp/pkg/types"
+)
+
+// startRequestSpan starts the span of a JSON-RPC request, or returns nil
+// when telemetry is disabled or the request is not sampled
+func (s *Server) startRequestSpan(req types.Request) *telemetry.Span {
+	span := s.discord.Telemetry().StartRequest(req.Method)
+	if span == nil {
+		return nil
+	}
+	span.SetAttribute("rpc.system", "jsonrpc")
+	span.SetAttribute("rpc.method", req.Method)
+	if req.ID != nil {
+		span.SetAttribute("rpc.jsonrpc.request_id", fmt.Sprint(req.ID))
+	}
+	if req.Method == "tools/call" {
+		var params struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(req.Params, &params) == nil && params.Name != "" {
+			span.SetAttribute("mcp.tool", params.Name)
+		}
+	}
+	return span
+}
+
+// endRequestSpan ends the span of a request, marking it failed when the
+// request or the tool call failed
+func endRequestSpan(span *telemetry.Span, resp *types.Response) {
+	if span == nil {
+		return
+	}
+	if resp != nil && resp.Error != nil {
+		span.SetAttribute("rpc.jsonrpc.error_code", resp.Error.Code)
+		span.SetError(resp.Error.Message)
+	} else if resp != nil {
+		if result, ok := resp.Result.(types.CallToolResult); ok {
+			switch {
+			case result.IsError:
+				span.SetAttribute("mcp.outcome", outcomeError)
+				if len(result.Content) > 0 {
+					if data, ok := result.Content[0].Data.(map[string]interface{}); ok && data["error_type"] != nil {
+						span.SetAttribute("error.type", fmt.Sprint(data["error_type"]))
+					}
+					span.SetError(result.Content[0].Text)
+				} else {
+					span.SetError("tool call failed")
+				}
+			case isHeld(result):
+				span.SetAttribute("mcp.outcome", outcomeQueued)
+			default:
+				span.SetAttribute("mcp.outcome", outcomeSuccess)
+			}
+		}
+	}
+	span.End(nil)
+}
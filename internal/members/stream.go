@@ -0,0 +1,191 @@
+// Package members collects guild members requested over the gateway, which
+// answers in chunks of up to 1000, and serves them in pages while and after
+// they arrive.
+package members
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Limits for kept streams
+const (
+	// MaxStreams bounds the streams kept; the oldest finished ones are
+	// forgotten first
+	MaxStreams = 10
+	// StreamTTL is how long a stream's members can be read after it starts
+	StreamTTL = 30 * time.Minute
+	// IdleTimeout fails a stream when no chunk arrives for this long
+	IdleTimeout = time.Minute
+)
+
+// Stream statuses
+const (
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusTimedOut  = "timed_out"
+)
+
+// Stream is a gateway member request and its progress
+type Stream struct {
+	ID             string     `json:"stream_id"`
+	GuildID        string     `json:"guild_id"`
+	Query          string     `json:"query,omitempty"`
+	Limit          int        `json:"limit,omitempty"`
+	Status         string     `json:"status"`
+	ChunkCount     int        `json:"chunk_count"`
+	ChunksReceived int        `json:"chunks_received"`
+	MemberCount    int        `json:"member_count"`
+	StartedAt      time.Time  `json:"started_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+}
+
+// stream holds a stream's members and the chunks seen
+type stream struct {
+	Stream
+	members  []*discordgo.Member
+	chunks   map[int]bool
+	lastSeen time.Time
+}
+
+// Registry tracks member streams by ID, which is also the gateway nonce
+type Registry struct {
+	streams map[string]*stream
+	nextID  int
+	mutex   sync.Mutex
+}
+
+// NewRegistry creates an empty stream registry
+func NewRegistry() *Registry {
+	return &Registry{streams: make(map[string]*stream)}
+}
+
+// Start registers a stream for a guild, or returns the running stream with
+// the same guild, query and limit so a repeated request is not sent twice.
+// The second result reports whether the stream is new.
+func (r *Registry) Start(guildID, query string, limit int) (Stream, bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	r.prune(now)
+	for _, s := range r.streams {
+		if s.Status == StatusRunning && s.GuildID == guildID && s.Query == query && s.Limit == limit {
+			return s.Stream, false, nil
+		}
+	}
+	if len(r.streams) >= MaxStreams && !r.dropOldestFinished() {
+		return Stream{}, false, fmt.Errorf("%d member streams are already running", MaxStreams)
+	}
+
+	r.nextID++
+	s := &stream{
+		Stream: Stream{
+			ID:        fmt.Sprintf("ms%d", r.nextID),
+			GuildID:   guildID,
+			Query:     query,
+			Limit:     limit,
+			Status:    StatusRunning,
+			StartedAt: now.UTC(),
+			ExpiresAt: now.Add(StreamTTL).UTC(),
+		},
+		chunks:   make(map[int]bool),
+		lastSeen: now,
+	}
+	r.streams[s.ID] = s
+	return s.Stream, true, nil
+}
+
+// Remove forgets a stream, used when its request could not be sent
+func (r *Registry) Remove(id string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.streams, id)
+}
+
+// AddChunk stores a chunk answering the stream whose ID is the chunk's
+// nonce. It reports false for chunks of other requests.
+func (r *Registry) AddChunk(chunk *discordgo.GuildMembersChunk) (Stream, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	s, ok := r.streams[chunk.Nonce]
+	if !ok || s.Status != StatusRunning || s.chunks[chunk.ChunkIndex] {
+		return Stream{}, false
+	}
+
+	now := time.Now()
+	s.chunks[chunk.ChunkIndex] = true
+	s.lastSeen = now
+	s.members = append(s.members, chunk.Members...)
+	s.ChunkCount = chunk.ChunkCount
+	s.ChunksReceived = len(s.chunks)
+	s.MemberCount = len(s.members)
+	if s.ChunksReceived >= s.ChunkCount {
+		s.Status = StatusCompleted
+		completed := now.UTC()
+		s.CompletedAt = &completed
+	}
+	return s.Stream, true
+}
+
+// Page returns up to limit members of a stream from offset, in the order
+// they arrived, with the stream's progress
+func (r *Registry) Page(id string, offset, limit int) ([]*discordgo.Member, Stream, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.prune(time.Now())
+	s, ok := r.streams[id]
+	if !ok {
+		return nil, Stream{}, false
+	}
+	if offset > len(s.members) {
+		offset = len(s.members)
+	}
+	end := offset + limit
+	if end > len(s.members) {
+		end = len(s.members)
+	}
+	page := append([]*discordgo.Member(nil), s.members[offset:end]...)
+	return page, s.Stream, true
+}
+
+// prune drops expired streams and times out idle ones; callers must hold
+// the lock
+func (r *Registry) prune(now time.Time) {
+	for id, s := range r.streams {
+		if now.After(s.ExpiresAt) {
+			delete(r.streams, id)
+			continue
+		}
+		if s.Status == StatusRunning && now.Sub(s.lastSeen) > IdleTimeout {
+			s.Status = StatusTimedOut
+		}
+	}
+}
+
+// dropOldestFinished removes the oldest stream that is no longer running,
+// reporting false when every stream is still running; callers must hold
+// the lock
+func (r *Registry) dropOldestFinished() bool {
+	oldest := ""
+	for id, s := range r.streams {
+		if s.Status == StatusRunning {
+			continue
+		}
+		if oldest == "" || s.StartedAt.Before(r.streams[oldest].StartedAt) {
+			oldest = id
+		}
+	}
+	if oldest == "" {
+		return false
+	}
+	delete(r.streams, oldest)
+	return true
+}
@@ -0,0 +1,93 @@
+package membership
+
+import (
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// inviteUse is the last seen state of an invite
+type inviteUse struct {
+	uses      int
+	maxUses   int
+	inviterID string
+}
+
+// Invites remembers the use counts of each guild's invites so the invite a
+// new member joined with can be found by comparing the counts after a join
+type Invites struct {
+	guilds map[string]map[string]inviteUse
+	mutex  sync.Mutex
+}
+
+// NewInvites creates an empty invite tracker
+func NewInvites() *Invites {
+	return &Invites{guilds: make(map[string]map[string]inviteUse)}
+}
+
+// Seed records a guild's current invites
+func (i *Invites) Seed(guildID string, invites []*discordgo.Invite) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.guilds[guildID] = snapshot(invites)
+}
+
+// Match compares a guild's current invites with the last ones seen and
+// returns the invite a single join used. It reports false when the guild
+// was not seen before or the use counts do not single out one invite, as
+// when several members joined between the two reads. The current invites
+// replace the last seen ones either way.
+func (i *Invites) Match(guildID string, invites []*discordgo.Invite) (code, inviterID string, ok bool) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	previous, seen := i.guilds[guildID]
+	current := snapshot(invites)
+	i.guilds[guildID] = current
+	if !seen {
+		return "", "", false
+	}
+
+	grown := 0
+	for c, use := range current {
+		before := previous[c].uses
+		if use.uses > before {
+			grown += use.uses - before
+			code, inviterID = c, use.inviterID
+		}
+	}
+	if grown == 1 {
+		return code, inviterID, true
+	}
+	if grown > 1 {
+		return "", "", false
+	}
+
+	// Discord deletes an invite when its last use is taken, so a missing
+	// invite that had one use left is the one used
+	candidates := 0
+	for c, use := range previous {
+		if _, ok := current[c]; !ok && use.maxUses > 0 && use.uses == use.maxUses-1 {
+			candidates++
+			code, inviterID = c, use.inviterID
+		}
+	}
+	if candidates == 1 {
+		return code, inviterID, true
+	}
+	return "", "", false
+}
+
+// snapshot indexes invites by code
+func snapshot(invites []*discordgo.Invite) map[string]inviteUse {
+	uses := make(map[string]inviteUse, len(invites))
+	for _, invite := range invites {
+		use := inviteUse{uses: invite.Uses, maxUses: invite.MaxUses}
+		if invite.Inviter != nil {
+			use.inviterID = invite.Inviter.ID
+		}
+		uses[invite.Code] = use
+	}
+	return uses
+}
@@ -0,0 +1,285 @@
+// Package membership keeps a persisted log of members joining and leaving
+// guilds, with the invite they joined with when it is known, and summarises
+// it for churn analysis.
+package membership
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/snowflake"
+)
+
+// Event types
+const (
+	EventJoin  = "join"
+	EventLeave = "leave"
+)
+
+// QuickLeaveWindow is how soon after joining a leave counts as a quick leave
+const QuickLeaveWindow = 24 * time.Hour
+
+// NewAccountAge is the account age below which a joining member counts as
+// a new account
+const NewAccountAge = 7 * 24 * time.Hour
+
+// Event is a member joining or leaving a guild
+type Event struct {
+	Type     string `json:"type"`
+	GuildID  string `json:"guild_id"`
+	UserID   string `json:"user_id"`
+	Username string `json:"username,omitempty"`
+	Bot      bool   `json:"bot,omitempty"`
+	// AccountCreatedAt is decoded from the user ID
+	AccountCreatedAt time.Time `json:"account_created_at"`
+	// AccountAgeDays is the account's age when the event happened
+	AccountAgeDays int `json:"account_age_days"`
+	// InviteCode and InviterID are set for joins whose invite is known
+	InviteCode string `json:"invite_code,omitempty"`
+	InviterID  string `json:"inviter_id,omitempty"`
+	// MemberForSeconds is how long a leaving member had been in the guild,
+	// when the log holds their join
+	MemberForSeconds int64     `json:"member_for_seconds,omitempty"`
+	At               time.Time `json:"at"`
+}
+
+// Filter selects events from the log
+type Filter struct {
+	GuildID string
+	UserID  string
+	Type    string
+	After   time.Time
+	Before  time.Time
+}
+
+// matches reports whether an event passes the filter
+func (f Filter) matches(event Event) bool {
+	switch {
+	case f.GuildID != "" && event.GuildID != f.GuildID:
+		return false
+	case f.UserID != "" && event.UserID != f.UserID:
+		return false
+	case f.Type != "" && event.Type != f.Type:
+		return false
+	case !f.After.IsZero() && event.At.Before(f.After):
+		return false
+	case !f.Before.IsZero() && !event.At.Before(f.Before):
+		return false
+	}
+	return true
+}
+
+// Log holds join and leave events for the retention period and appends
+// every new event to a JSON lines file so the history survives restarts
+type Log struct {
+	path      string
+	retention time.Duration
+	logger    *logrus.Logger
+
+	// events are ordered oldest first
+	events []Event
+	// stale counts expired events still in the file
+	stale int
+	mutex sync.RWMutex
+}
+
+// NewLog creates a log keeping events for retention, loading the events
+// saved at path
+func NewLog(path string, retention time.Duration, logger *logrus.Logger) (*Log, error) {
+	l := &Log{
+		path:      path,
+		retention: retention,
+		logger:    logger,
+	}
+	if err := l.load(); err != nil {
+		return nil, fmt.Errorf("failed to load membership log from %s: %w", path, err)
+	}
+	return l, nil
+}
+
+// Retention returns how long events are kept
+func (l *Log) Retention() time.Duration {
+	return l.retention
+}
+
+// Record adds an event, filling in its time, account age and, for a leave,
+// how long the member had been in the guild
+func (l *Log) Record(event Event) Event {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if event.At.IsZero() {
+		event.At = time.Now().UTC()
+	}
+	if parts, err := snowflake.Parse(event.UserID); err == nil {
+		event.AccountCreatedAt = parts.Timestamp
+		event.AccountAgeDays = int(event.At.Sub(parts.Timestamp).Hours() / 24)
+	}
+	if event.Type == EventLeave && event.MemberForSeconds == 0 {
+		if joined, ok := l.lastJoin(event.GuildID, event.UserID); ok {
+			event.MemberForSeconds = int64(event.At.Sub(joined.At).Seconds())
+		}
+	}
+
+	// Gateway events can arrive slightly out of order; keep the log sorted
+	i := sort.Search(len(l.events), func(i int) bool { return l.events[i].At.After(event.At) })
+	l.events = append(l.events, Event{})
+	copy(l.events[i+1:], l.events[i:])
+	l.events[i] = event
+
+	l.stale += l.prune(event.At)
+	l.persist(event)
+	return event
+}
+
+// Query returns the events matching the filter, newest first, skipping
+// offset events and returning at most limit, along with the total matched
+func (l *Log) Query(filter Filter, offset, limit int) ([]Event, int) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	page := make([]Event, 0)
+	total := 0
+	for i := len(l.events) - 1; i >= 0; i-- {
+		if !filter.matches(l.events[i]) {
+			continue
+		}
+		if total >= offset && len(page) < limit {
+			page = append(page, l.events[i])
+		}
+		total++
+	}
+	return page, total
+}
+
+// Oldest returns the time of the oldest event held for a guild
+func (l *Log) Oldest(guildID string) (time.Time, bool) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	for _, event := range l.events {
+		if event.GuildID == guildID {
+			return event.At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// lastJoin returns a user's most recent join of a guild; callers must hold
+// the lock
+func (l *Log) lastJoin(guildID, userID string) (Event, bool) {
+	for i := len(l.events) - 1; i >= 0; i-- {
+		event := l.events[i]
+		if event.Type == EventJoin && event.GuildID == guildID && event.UserID == userID {
+			return event, true
+		}
+	}
+	return Event{}, false
+}
+
+// prune drops events older than the retention period and returns how many
+// were dropped; callers must hold the write lock
+func (l *Log) prune(now time.Time) int {
+	cutoff := now.Add(-l.retention)
+	n := sort.Search(len(l.events), func(i int) bool { return !l.events[i].At.Before(cutoff) })
+	if n > 0 {
+		l.events = append(l.events[:0], l.events[n:]...)
+	}
+	return n
+}
+
+// persist appends an event to the log file, rewriting the file instead once
+// it holds more expired events than retained ones; callers must hold the
+// write lock
+func (l *Log) persist(event Event) {
+	if l.stale > len(l.events) {
+		if err := l.rewrite(); err != nil {
+			l.logger.Warnf("Failed to compact membership log %s: %v", l.path, err)
+		}
+		return
+	}
+
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		l.logger.Warnf("Failed to persist membership event: %v", err)
+		return
+	}
+	defer file.Close()
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		l.logger.Warnf("Failed to marshal membership event: %v", err)
+		return
+	}
+	if _, err := fmt.Fprintln(file, string(line)); err != nil {
+		l.logger.Warnf("Failed to persist membership event: %v", err)
+	}
+}
+
+// rewrite replaces the log file with the retained events; callers must hold
+// the write lock
+func (l *Log) rewrite() error {
+	tmpPath := l.path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	for _, event := range l.events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		fmt.Fprintln(writer, string(line))
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	l.stale = 0
+	return os.Rename(tmpPath, l.path)
+}
+
+// load reads the persisted events, dropping expired ones, and compacts the
+// file
+func (l *Log) load() error {
+	file, err := os.Open(l.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		l.events = append(l.events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	sort.SliceStable(l.events, func(i, j int) bool { return l.events[i].At.Before(l.events[j].At) })
+	l.prune(time.Now())
+	l.logger.Infof("Loaded %d membership events", len(l.events))
+	return l.rewrite()
+}
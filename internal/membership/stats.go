@@ -0,0 +1,171 @@
+package membership
+
+import (
+	"sort"
+	"time"
+)
+
+// DailyCount is one day's joins and leaves, by UTC date
+type DailyCount struct {
+	Date   string `json:"date"`
+	Joins  int    `json:"joins"`
+	Leaves int    `json:"leaves"`
+	Net    int    `json:"net"`
+}
+
+// InviteCount is the joins attributed to one invite
+type InviteCount struct {
+	Code      string `json:"code"`
+	InviterID string `json:"inviter_id,omitempty"`
+	Joins     int    `json:"joins"`
+	// Left counts the members who joined with it and have since left
+	Left int `json:"left"`
+}
+
+// Stats summarises a guild's joins and leaves since a point in time
+type Stats struct {
+	GuildID string    `json:"guild_id"`
+	Since   time.Time `json:"since"`
+	Joins   int       `json:"joins"`
+	Leaves  int       `json:"leaves"`
+	Net     int       `json:"net"`
+	// Rejoins counts joins by members who had joined before
+	Rejoins int `json:"rejoins"`
+	// QuickLeaves counts leaves within QuickLeaveWindow of joining
+	QuickLeaves int `json:"quick_leaves"`
+	// Retained counts the period's joiners who have not left since
+	Retained      int     `json:"retained"`
+	RetentionRate float64 `json:"retention_rate"`
+	// ChurnRate is leaves per join over the period
+	ChurnRate            float64 `json:"churn_rate"`
+	BotJoins             int     `json:"bot_joins"`
+	NewAccountJoins      int     `json:"new_account_joins"`
+	MedianAccountAgeDays int     `json:"median_account_age_days"`
+	// AttributedJoins counts the joins whose invite is known
+	AttributedJoins int           `json:"attributed_joins"`
+	Daily           []DailyCount  `json:"daily"`
+	TopInvites      []InviteCount `json:"top_invites"`
+	// LogStart is the oldest event held for the guild; figures before it
+	// are not known
+	LogStart *time.Time `json:"log_start,omitempty"`
+}
+
+// maxTopInvites bounds the invites listed in Stats
+const maxTopInvites = 10
+
+// Stats summarises a guild's events since the given time
+func (l *Log) Stats(guildID string, since time.Time) Stats {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	stats := Stats{
+		GuildID:    guildID,
+		Since:      since,
+		Daily:      make([]DailyCount, 0),
+		TopInvites: make([]InviteCount, 0),
+	}
+
+	daily := make(map[string]*DailyCount)
+	invites := make(map[string]*InviteCount)
+	// joined holds the members seen joining before each event
+	joined := make(map[string]bool)
+	// periodJoins maps members who joined during the period to the invite
+	// of their latest join
+	periodJoins := make(map[string]string)
+	joiners := make(map[string]bool)
+	var ages []int
+
+	for _, event := range l.events {
+		if event.GuildID != guildID {
+			continue
+		}
+		if stats.LogStart == nil {
+			at := event.At
+			stats.LogStart = &at
+		}
+		if event.At.Before(since) {
+			if event.Type == EventJoin {
+				joined[event.UserID] = true
+			}
+			continue
+		}
+
+		date := event.At.UTC().Format("2006-01-02")
+		day, ok := daily[date]
+		if !ok {
+			day = &DailyCount{Date: date}
+			daily[date] = day
+		}
+
+		switch event.Type {
+		case EventJoin:
+			stats.Joins++
+			day.Joins++
+			if joined[event.UserID] {
+				stats.Rejoins++
+			}
+			joined[event.UserID] = true
+			joiners[event.UserID] = true
+			periodJoins[event.UserID] = event.InviteCode
+			if event.Bot {
+				stats.BotJoins++
+			}
+			if time.Duration(event.AccountAgeDays)*24*time.Hour < NewAccountAge {
+				stats.NewAccountJoins++
+			}
+			ages = append(ages, event.AccountAgeDays)
+			if event.InviteCode != "" {
+				stats.AttributedJoins++
+				invite, ok := invites[event.InviteCode]
+				if !ok {
+					invite = &InviteCount{Code: event.InviteCode, InviterID: event.InviterID}
+					invites[event.InviteCode] = invite
+				}
+				invite.Joins++
+			}
+		case EventLeave:
+			stats.Leaves++
+			day.Leaves++
+			if event.MemberForSeconds > 0 && time.Duration(event.MemberForSeconds)*time.Second <= QuickLeaveWindow {
+				stats.QuickLeaves++
+			}
+			if code, ok := periodJoins[event.UserID]; ok {
+				delete(periodJoins, event.UserID)
+				if invite, ok := invites[code]; ok {
+					invite.Left++
+				}
+			}
+		}
+	}
+
+	stats.Net = stats.Joins - stats.Leaves
+	stats.Retained = len(periodJoins)
+	if stats.Joins > 0 {
+		stats.RetentionRate = float64(stats.Retained) / float64(len(joiners))
+		stats.ChurnRate = float64(stats.Leaves) / float64(stats.Joins)
+	}
+	if len(ages) > 0 {
+		sort.Ints(ages)
+		stats.MedianAccountAgeDays = ages[len(ages)/2]
+	}
+
+	for _, day := range daily {
+		day.Net = day.Joins - day.Leaves
+		stats.Daily = append(stats.Daily, *day)
+	}
+	sort.Slice(stats.Daily, func(i, j int) bool { return stats.Daily[i].Date < stats.Daily[j].Date })
+
+	for _, invite := range invites {
+		stats.TopInvites = append(stats.TopInvites, *invite)
+	}
+	sort.Slice(stats.TopInvites, func(i, j int) bool {
+		if stats.TopInvites[i].Joins != stats.TopInvites[j].Joins {
+			return stats.TopInvites[i].Joins > stats.TopInvites[j].Joins
+		}
+		return stats.TopInvites[i].Code < stats.TopInvites[j].Code
+	})
+	if len(stats.TopInvites) > maxTopInvites {
+		stats.TopInvites = stats.TopInvites[:maxTopInvites]
+	}
+	return stats
+}
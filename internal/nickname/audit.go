@@ -0,0 +1,118 @@
+// Package nickname checks member display names against moderation rules
+// and produces cleaned replacements for the names that break them.
+package nickname
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// MaxLength is the longest nickname Discord accepts
+const MaxLength = 32
+
+// Reasons a name is flagged
+const (
+	ReasonPattern    = "pattern"
+	ReasonBannedWord = "banned_word"
+	ReasonHoisted    = "hoisted"
+)
+
+// Rules are the checks a display name must pass
+type Rules struct {
+	// Pattern flags names it matches; nil disables the check
+	Pattern *regexp.Regexp
+	// BannedWords flags names containing any of them, ignoring case
+	BannedWords []string
+	// Dehoist flags names starting with characters that sort them above
+	// the member list, such as "!" or "."
+	Dehoist bool
+}
+
+// NewRules compiles the pattern and lower-cases the banned words
+func NewRules(pattern string, bannedWords []string, dehoist bool) (Rules, error) {
+	rules := Rules{Dehoist: dehoist}
+	if pattern != "" {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return Rules{}, fmt.Errorf("invalid pattern: %w", err)
+		}
+		rules.Pattern = regex
+	}
+	for _, word := range bannedWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			rules.BannedWords = append(rules.BannedWords, word)
+		}
+	}
+	if rules.Pattern == nil && len(rules.BannedWords) == 0 && !rules.Dehoist {
+		return Rules{}, fmt.Errorf("at least one of pattern, banned_words or dehoist is required")
+	}
+	return rules, nil
+}
+
+// Check returns the reasons a name breaks the rules, or nil when it passes
+func (r Rules) Check(name string) []string {
+	var reasons []string
+	if r.Pattern != nil && r.Pattern.MatchString(name) {
+		reasons = append(reasons, ReasonPattern)
+	}
+	lower := strings.ToLower(name)
+	for _, word := range r.BannedWords {
+		if strings.Contains(lower, word) {
+			reasons = append(reasons, ReasonBannedWord)
+			break
+		}
+	}
+	if r.Dehoist && hoisted(name) {
+		reasons = append(reasons, ReasonHoisted)
+	}
+	return reasons
+}
+
+// Normalize removes pattern matches and banned words, strips leading
+// hoisting characters and collapses whitespace. It returns "" when nothing
+// acceptable is left.
+func (r Rules) Normalize(name string) string {
+	if r.Pattern != nil {
+		name = r.Pattern.ReplaceAllString(name, "")
+	}
+	for _, word := range r.BannedWords {
+		name = removeFold(name, word)
+	}
+	name = strings.Join(strings.Fields(name), " ")
+	if r.Dehoist {
+		name = strings.TrimLeftFunc(name, func(c rune) bool {
+			return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+		})
+	}
+	if runes := []rune(name); len(runes) > MaxLength {
+		name = strings.TrimSpace(string(runes[:MaxLength]))
+	}
+	if name == "" || len(r.Check(name)) > 0 {
+		return ""
+	}
+	return name
+}
+
+// hoisted reports whether a name starts with a character other than a
+// letter or digit
+func hoisted(name string) bool {
+	for _, c := range name {
+		return !unicode.IsLetter(c) && !unicode.IsDigit(c)
+	}
+	return false
+}
+
+// removeFold removes every case-insensitive occurrence of a lower-case word
+func removeFold(name, word string) string {
+	for {
+		i := strings.Index(strings.ToLower(name), word)
+		// Lower-casing can change byte lengths; give up rather than cut a
+		// character in half
+		if i < 0 || len(strings.ToLower(name)) != len(name) {
+			return name
+		}
+		name = name[:i] + name[i+len(word):]
+	}
+}
@@ -0,0 +1,165 @@
+// Package notes keeps private moderator notes about guild members, such as
+// past warnings, so agents keep that context across sessions. Notes are
+// persisted to disk.
+package notes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+// MaxContentLength is the longest note accepted
+const MaxContentLength = 2000
+
+// Note is a note about one member of a guild
+type Note struct {
+	ID      string   `json:"id"`
+	GuildID string   `json:"guild_id"`
+	UserID  string   `json:"user_id"`
+	Content string   `json:"content"`
+	Tags    []string `json:"tags,omitempty"`
+	// Author names who wrote the note, such as a moderator or an agent
+	Author    string    `json:"author,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// storedNotes is the layout of the persistence file
+type storedNotes struct {
+	NextID int     `json:"next_id"`
+	Notes  []*Note `json:"notes"`
+}
+
+// Store holds notes by ID and saves them to a JSON file on every change
+type Store struct {
+	path       string
+	maxPerUser int
+
+	notes  map[string]*Note
+	nextID int
+	mutex  sync.Mutex
+}
+
+// NewStore creates a store keeping at most maxPerUser notes per member,
+// loading the notes saved at path
+func NewStore(path string, maxPerUser int) (*Store, error) {
+	s := &Store{
+		path:       path,
+		maxPerUser: maxPerUser,
+		notes:      make(map[string]*Note),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load user notes from %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Add validates and saves a note, assigning its ID and creation time
+func (s *Store) Add(note Note) (Note, error) {
+	note.Content = strings.TrimSpace(note.Content)
+	if note.GuildID == "" || note.UserID == "" {
+		return Note{}, fmt.Errorf("guild and user are required")
+	}
+	if note.Content == "" {
+		return Note{}, fmt.Errorf("content must not be empty")
+	}
+	if len(note.Content) > MaxContentLength {
+		return Note{}, fmt.Errorf("content exceeds %d characters", MaxContentLength)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	count := 0
+	for _, existing := range s.notes {
+		if existing.GuildID == note.GuildID && existing.UserID == note.UserID {
+			count++
+		}
+	}
+	if count >= s.maxPerUser {
+		return Note{}, fmt.Errorf("user %s already has %d notes; delete some first", note.UserID, count)
+	}
+
+	s.nextID++
+	note.ID = fmt.Sprintf("un%d", s.nextID)
+	note.CreatedAt = time.Now().UTC()
+	s.notes[note.ID] = &note
+	if err := s.save(); err != nil {
+		delete(s.notes, note.ID)
+		return Note{}, err
+	}
+	return note, nil
+}
+
+// List returns a member's notes in a guild, newest first. An empty userID
+// lists the notes about every member of the guild.
+func (s *Store) List(guildID, userID string) []Note {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	notes := make([]Note, 0)
+	for _, note := range s.notes {
+		if note.GuildID == guildID && (userID == "" || note.UserID == userID) {
+			notes = append(notes, *note)
+		}
+	}
+	sort.Slice(notes, func(i, j int) bool {
+		if !notes[i].CreatedAt.Equal(notes[j].CreatedAt) {
+			return notes[i].CreatedAt.After(notes[j].CreatedAt)
+		}
+		// IDs are numbered, so a longer ID is a newer one
+		if len(notes[i].ID) != len(notes[j].ID) {
+			return len(notes[i].ID) > len(notes[j].ID)
+		}
+		return notes[i].ID > notes[j].ID
+	})
+	return notes
+}
+
+// Remove deletes a note from a guild, returning it and whether it existed
+func (s *Store) Remove(guildID, noteID string) (Note, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	note, ok := s.notes[noteID]
+	if !ok || note.GuildID != guildID {
+		return Note{}, false, nil
+	}
+	delete(s.notes, noteID)
+	if err := s.save(); err != nil {
+		s.notes[noteID] = note
+		return Note{}, false, err
+	}
+	return *note, true, nil
+}
+
+// save writes every note to the persistence file; callers must hold the
+// lock
+func (s *Store) save() error {
+	stored := storedNotes{NextID: s.nextID, Notes: make([]*Note, 0, len(s.notes))}
+	for _, note := range s.notes {
+		stored.Notes = append(stored.Notes, note)
+	}
+	sort.Slice(stored.Notes, func(i, j int) bool {
+		return stored.Notes[i].CreatedAt.Before(stored.Notes[j].CreatedAt)
+	})
+
+	return jsonfile.Save(s.path, stored)
+}
+
+// load reads saved notes
+func (s *Store) load() error {
+	var stored storedNotes
+	if found, err := jsonfile.Load(s.path, &stored); err != nil || !found {
+		return err
+	}
+	s.nextID = stored.NextID
+	for _, note := range stored.Notes {
+		s.notes[note.ID] = note
+	}
+	return nil
+}
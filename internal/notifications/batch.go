@@ -0,0 +1,158 @@
+package notifications
+
+import (
+	"encoding/json"
+	"time"
+
+	"discord-mcp/internal/config"
+	"discord-mcp/pkg/types"
+)
+
+// BatchMethod is the notification method used for coalesced events.
+const BatchMethod = "discord/eventBatch"
+
+// rateWindow counts notifications of one event type in a fixed one-minute window.
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+// batchEntry aggregates coalesced notifications of one event type.
+type batchEntry struct {
+	Count   int               `json:"count"`
+	Samples []json.RawMessage `json:"samples"`
+}
+
+// Configure applies per-event rate limits and batching. Events over their
+// rate limit, or every event when batching is enabled, are coalesced into
+// periodic discord/eventBatch notifications with counts and samples.
+func (s *Service) Configure(cfg config.EventsConfig) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.rateLimits = cfg.RateLimits
+	s.defaultRateLimit = cfg.DefaultRateLimit
+	s.windows = make(map[string]*rateWindow)
+	s.batching = cfg.Batching.Enabled
+	s.maxSamples = cfg.Batching.MaxSamples
+	s.batch = make(map[string]*batchEntry)
+
+	if s.stopBatch != nil {
+		return
+	}
+	if !s.batching && s.defaultRateLimit == 0 && len(s.rateLimits) == 0 {
+		return
+	}
+
+	interval := time.Duration(cfg.Batching.IntervalMs) * time.Millisecond
+	s.stopBatch = make(chan struct{})
+	go s.flushLoop(interval, s.stopBatch)
+}
+
+// Close stops the batch flush loop after delivering any remaining batch.
+func (s *Service) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stopBatch != nil {
+		close(s.stopBatch)
+		s.stopBatch = nil
+	}
+	s.flush()
+}
+
+// coalesce reports whether a notification should be added to the batch
+// instead of being sent on its own; callers must hold the mutex.
+func (s *Service) coalesce(notification *types.Notification) bool {
+	if s.batch == nil {
+		return false
+	}
+	if s.batching {
+		return true
+	}
+
+	limit, ok := s.rateLimits[notification.Method]
+	if !ok {
+		limit = s.defaultRateLimit
+	}
+	if limit <= 0 {
+		return false
+	}
+
+	now := time.Now()
+	window, ok := s.windows[notification.Method]
+	if !ok || now.Sub(window.start) >= time.Minute {
+		window = &rateWindow{start: now}
+		s.windows[notification.Method] = window
+	}
+
+	window.count++
+	return window.count > limit
+}
+
+// addToBatch records a coalesced notification; callers must hold the mutex.
+func (s *Service) addToBatch(notification *types.Notification) {
+	if len(s.batch) == 0 {
+		s.batchStart = time.Now().UTC()
+	}
+
+	entry, ok := s.batch[notification.Method]
+	if !ok {
+		entry = &batchEntry{Samples: []json.RawMessage{}}
+		s.batch[notification.Method] = entry
+	}
+
+	entry.Count++
+	if len(entry.Samples) < s.maxSamples {
+		entry.Samples = append(entry.Samples, notification.Params)
+	}
+}
+
+func (s *Service) flushLoop(interval time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.mutex.Lock()
+			s.flush()
+			s.mutex.Unlock()
+		}
+	}
+}
+
+// flush sends the pending batch as one notification; callers must hold the mutex.
+func (s *Service) flush() {
+	if len(s.batch) == 0 {
+		return
+	}
+
+	total := 0
+	for _, entry := range s.batch {
+		total += entry.Count
+	}
+
+	params, err := json.Marshal(map[string]interface{}{
+		"window_start": s.batchStart.Format(time.RFC3339),
+		"window_end":   time.Now().UTC().Format(time.RFC3339),
+		"total":        total,
+		"events":       s.batch,
+	})
+	s.batch = make(map[string]*batchEntry)
+	if err != nil {
+		s.logger.Errorf("Failed to marshal event batch: %v", err)
+		return
+	}
+
+	notification := &types.Notification{
+		JSONRPC: types.JSONRPCVersion,
+		Method:  BatchMethod,
+		Params:  params,
+	}
+	if err := s.deliver(notification); err != nil {
+		s.logger.Errorf("Failed to send event batch: %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package notifications
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/pkg/types"
+)
+
+// BufferedEvent is a notification recorded in the event buffer.
+type BufferedEvent struct {
+	Cursor    int64           `json:"cursor"`
+	Method    string          `json:"method"`
+	Params    json.RawMessage `json:"params"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// PollResult is a page of buffered events.
+type PollResult struct {
+	Events     []BufferedEvent `json:"events"`
+	NextCursor int64           `json:"next_cursor"`
+	HasMore    bool            `json:"has_more"`
+	// Missed counts events that were evicted before the caller polled them.
+	Missed int64 `json:"missed_events"`
+}
+
+// Buffer keeps a ring of recent events so clients that ignore notifications
+// can poll for them. It can optionally persist events to a JSON lines file so
+// the history survives restarts.
+type Buffer struct {
+	size        int
+	persistPath string
+	logger      *logrus.Logger
+
+	events   []BufferedEvent
+	cursor   int64
+	appended int
+	mutex    sync.RWMutex
+}
+
+// NewBuffer creates an event buffer holding up to size events. When
+// persistPath is set, previously persisted events are loaded.
+func NewBuffer(size int, persistPath string, logger *logrus.Logger) *Buffer {
+	b := &Buffer{
+		size:        size,
+		persistPath: persistPath,
+		logger:      logger,
+	}
+
+	if persistPath != "" {
+		if err := b.load(); err != nil {
+			logger.Warnf("Failed to load persisted events from %s: %v", persistPath, err)
+		}
+	}
+	return b
+}
+
+// Add records a notification and returns its cursor.
+func (b *Buffer) Add(notification *types.Notification) int64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.cursor++
+	event := BufferedEvent{
+		Cursor:    b.cursor,
+		Method:    notification.Method,
+		Params:    notification.Params,
+		Timestamp: time.Now().UTC(),
+	}
+
+	b.events = append(b.events, event)
+	if len(b.events) > b.size {
+		b.events = b.events[len(b.events)-b.size:]
+	}
+
+	if b.persistPath != "" {
+		b.persist(event)
+	}
+	return b.cursor
+}
+
+// Poll returns up to limit events after cursor, optionally restricted to the
+// given methods.
+func (b *Buffer) Poll(cursor int64, limit int, methods []string) PollResult {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	result := PollResult{Events: []BufferedEvent{}, NextCursor: cursor}
+	if len(b.events) == 0 {
+		if cursor < b.cursor {
+			result.NextCursor = b.cursor
+		}
+		return result
+	}
+
+	if oldest := b.events[0].Cursor; cursor < oldest-1 {
+		result.Missed = oldest - 1 - cursor
+	}
+
+	for _, event := range b.events {
+		if event.Cursor <= cursor {
+			continue
+		}
+		if len(result.Events) >= limit {
+			result.HasMore = true
+			break
+		}
+
+		result.NextCursor = event.Cursor
+		if len(methods) > 0 && !containsMethod(methods, event.Method) {
+			continue
+		}
+		result.Events = append(result.Events, event)
+	}
+
+	return result
+}
+
+// Cursor returns the cursor of the most recent event.
+func (b *Buffer) Cursor() int64 {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.cursor
+}
+
+// persist appends an event to the persistence file, compacting it once it
+// has grown past the buffer size; callers must hold the write lock.
+func (b *Buffer) persist(event BufferedEvent) {
+	b.appended++
+	if b.appended > b.size {
+		if err := b.rewrite(); err != nil {
+			b.logger.Warnf("Failed to compact event file %s: %v", b.persistPath, err)
+		}
+		return
+	}
+
+	file, err := os.OpenFile(b.persistPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		b.logger.Warnf("Failed to persist event: %v", err)
+		return
+	}
+	defer file.Close()
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		b.logger.Warnf("Failed to marshal event: %v", err)
+		return
+	}
+	if _, err := fmt.Fprintln(file, string(line)); err != nil {
+		b.logger.Warnf("Failed to persist event: %v", err)
+	}
+}
+
+// rewrite replaces the persistence file with the buffered events; callers
+// must hold the write lock.
+func (b *Buffer) rewrite() error {
+	tmpPath := b.persistPath + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	for _, event := range b.events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		fmt.Fprintln(writer, string(line))
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	b.appended = 0
+	return os.Rename(tmpPath, b.persistPath)
+}
+
+// load reads persisted events, keeping the most recent ones.
+func (b *Buffer) load() error {
+	file, err := os.Open(b.persistPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event BufferedEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		b.events = append(b.events, event)
+		if len(b.events) > b.size {
+			b.events = b.events[1:]
+		}
+		if event.Cursor > b.cursor {
+			b.cursor = event.Cursor
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	b.logger.Infof("Loaded %d persisted events", len(b.events))
+	return b.rewrite()
+}
+
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
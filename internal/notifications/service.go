@@ -5,16 +5,39 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 
 	"discord-mcp/pkg/types"
 	"github.com/sirupsen/logrus"
 )
 
+// maxPending bounds the number of notifications held while paused.
+const maxPending = 1000
+
 // Service handles sending JSON-RPC notifications.
 type Service struct {
 	writer io.Writer
 	logger *logrus.Logger
 	mutex  sync.Mutex
+
+	// While paused, notifications are queued and delivered on Resume.
+	paused  bool
+	pending []*types.Notification
+
+	// Totals since startup, reported by Stats.
+	sent    map[string]int64
+	dropped int64
+	batched int64
+
+	// Per-event rate limiting and batching, set up by Configure.
+	rateLimits       map[string]int
+	defaultRateLimit int
+	windows          map[string]*rateWindow
+	batching         bool
+	maxSamples       int
+	batch            map[string]*batchEntry
+	batchStart       time.Time
+	stopBatch        chan struct{}
 }
 
 // NewService creates a new notification service.
@@ -22,14 +45,120 @@ func NewService(writer io.Writer, logger *logrus.Logger) *Service {
 	return &Service{
 		writer: writer,
 		logger: logger,
+		sent:   make(map[string]int64),
 	}
 }
 
-// Send marshals and sends a notification to the client.
+// SetWriter sets where notifications are written, such as the output of
+// the stream the server serves
+func (s *Service) SetWriter(writer io.Writer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.writer = writer
+}
+
+// Send marshals and sends a notification to the client. While the service is
+// paused the notification is queued instead. Rate-limited or batched
+// notifications are coalesced into the next event batch.
 func (s *Service) Send(notification *types.Notification) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	if s.coalesce(notification) {
+		s.addToBatch(notification)
+		s.batched++
+		return nil
+	}
+
+	return s.deliver(notification)
+}
+
+// deliver sends a notification or queues it while paused; callers must hold
+// the mutex.
+func (s *Service) deliver(notification *types.Notification) error {
+	if s.paused {
+		if len(s.pending) >= maxPending {
+			s.logger.Warnf("Notification queue full, dropping oldest pending notification (%s)", s.pending[0].Method)
+			s.pending = s.pending[1:]
+			s.dropped++
+		}
+		s.pending = append(s.pending, notification)
+		return nil
+	}
+
+	return s.write(notification)
+}
+
+// SendImmediate sends a notification even while the service is paused.
+func (s *Service) SendImmediate(notification *types.Notification) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.write(notification)
+}
+
+// Stats are the notification totals since startup.
+type Stats struct {
+	// Sent counts the notifications written, by method
+	Sent  map[string]int64 `json:"sent"`
+	Total int64            `json:"total"`
+	// Pending counts the notifications held while the gateway reconnects
+	Pending int `json:"pending"`
+	// Dropped counts pending notifications dropped when the queue was full
+	Dropped int64 `json:"dropped"`
+	// Batched counts notifications coalesced into discord/eventBatch
+	Batched int64 `json:"batched"`
+}
+
+// Stats returns the notification totals since startup.
+func (s *Service) Stats() Stats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats := Stats{
+		Sent:    make(map[string]int64, len(s.sent)),
+		Pending: len(s.pending),
+		Dropped: s.dropped,
+		Batched: s.batched,
+	}
+	for method, count := range s.sent {
+		stats.Sent[method] = count
+		stats.Total += count
+	}
+	return stats
+}
+
+// Pause starts queueing notifications instead of sending them.
+func (s *Service) Pause() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.paused = true
+}
+
+// Resume stops queueing and delivers all pending notifications in order.
+func (s *Service) Resume() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.paused = false
+	pending := s.pending
+	s.pending = nil
+
+	for _, notification := range pending {
+		if err := s.write(notification); err != nil {
+			s.logger.Errorf("Failed to deliver pending %s notification: %v", notification.Method, err)
+		}
+	}
+
+	if len(pending) > 0 {
+		s.logger.Infof("Delivered %d pending notifications", len(pending))
+	}
+}
+
+// write sends a notification; callers must hold the mutex.
+func (s *Service) write(notification *types.Notification) error {
 	if s.writer == nil {
 		return fmt.Errorf("notification writer not configured")
 	}
@@ -45,6 +174,55 @@ func (s *Service) Send(notification *types.Notification) error {
 	if _, err := fmt.Fprintln(s.writer, string(responseJSON)); err != nil {
 		return fmt.Errorf("failed to write notification: %w", err)
 	}
+	s.sent[notification.Method]++
 
 	return nil
 }
+
+// SendProgress sends a notifications/progress notification for a request
+// that supplied a progress token. It is a no-op without a token.
+func (s *Service) SendProgress(token interface{}, progress, total float64, message string) {
+	if token == nil {
+		return
+	}
+
+	params, err := json.Marshal(types.ProgressParams{
+		ProgressToken: token,
+		Progress:      progress,
+		Total:         total,
+		Message:       message,
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to marshal progress notification: %v", err)
+		return
+	}
+
+	if err := s.SendImmediate(&types.Notification{Method: "notifications/progress", Params: params}); err != nil {
+		s.logger.Errorf("Failed to send progress notification: %v", err)
+	}
+}
+
+// SendPartialResult sends one chunk of a streamed tool result for a request
+// that supplied a progress token. Chunks are numbered from 1 and sent
+// immediately so they arrive in order and before the final result.
+func (s *Service) SendPartialResult(token interface{}, tool string, chunk, count int, items interface{}) {
+	if token == nil {
+		return
+	}
+
+	params, err := json.Marshal(types.PartialResultParams{
+		ProgressToken: token,
+		Tool:          tool,
+		Chunk:         chunk,
+		Count:         count,
+		Items:         items,
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to marshal partial result notification: %v", err)
+		return
+	}
+
+	if err := s.SendImmediate(&types.Notification{Method: "discord/partialResult", Params: params}); err != nil {
+		s.logger.Errorf("Failed to send partial result notification: %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+// Package offline holds tool calls made while the Discord gateway is down
+// so they can run after it reconnects. Only calls that are safe to run
+// late and at most once are held: state-changing calls with an idempotency
+// key, and calls that have the same effect however often they run.
+package offline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrFull is returned when the queue holds its maximum number of calls
+var ErrFull = errors.New("offline queue is full")
+
+// idempotentTools have the same effect when repeated, so they are queued
+// without an idempotency key
+var idempotentTools = map[string]bool{
+	"assign_role":   true,
+	"unassign_role": true,
+	"add_reaction":  true,
+}
+
+// Queueable reports whether a call can be held for later. Calls to other
+// state-changing tools need an idempotency key, so a client retrying after
+// the outage cannot make them act twice.
+func Queueable(tool string, mutating, hasKey bool) bool {
+	return idempotentTools[tool] || (mutating && hasKey)
+}
+
+// Call is a held tool call
+type Call struct {
+	ID             string          `json:"queue_id"`
+	Tool           string          `json:"tool"`
+	Arguments      json.RawMessage `json:"-"`
+	IdempotencyKey string          `json:"idempotency_key,omitempty"`
+	Locale         string          `json:"-"`
+	QueuedAt       time.Time       `json:"queued_at"`
+	ExpiresAt      time.Time       `json:"expires_at"`
+	// Position is the number of calls ahead of this one plus one
+	Position int `json:"position"`
+
+	// identity matches repeats of the call: the tool and key, or the tool
+	// and arguments for calls without a key
+	identity    string
+	fingerprint string
+	running     bool
+}
+
+// Queue holds calls in the order they were made
+type Queue struct {
+	max    int
+	ttl    time.Duration
+	mutex  sync.Mutex
+	calls  []*Call
+	nextID int
+}
+
+// NewQueue creates a queue holding up to max calls for ttl each
+func NewQueue(max int, ttl time.Duration) *Queue {
+	return &Queue{max: max, ttl: ttl}
+}
+
+// Add holds a call; fingerprint identifies its arguments. A repeat of a
+// call that is already held returns the held call instead of queueing it
+// twice; a key reused with different arguments is an error.
+func (q *Queue) Add(tool, key, fingerprint string, args map[string]interface{}, locale string, now time.Time) (Call, error) {
+	identity := tool + "\x00" + fingerprint
+	if key != "" {
+		identity = tool + "\x00key\x00" + key
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, call := range q.calls {
+		if call.identity != identity {
+			continue
+		}
+		if call.fingerprint != fingerprint {
+			return Call{}, fmt.Errorf("idempotency_key %q is already queued for a %s call with different arguments", key, tool)
+		}
+		held := *call
+		held.Position = i + 1
+		return held, nil
+	}
+
+	if len(q.calls) >= q.max {
+		return Call{}, ErrFull
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return Call{}, fmt.Errorf("failed to queue arguments: %w", err)
+	}
+
+	q.nextID++
+	call := &Call{
+		ID:             fmt.Sprintf("q%d", q.nextID),
+		Tool:           tool,
+		Arguments:      data,
+		IdempotencyKey: key,
+		Locale:         locale,
+		QueuedAt:       now,
+		ExpiresAt:      now.Add(q.ttl),
+		identity:       identity,
+		fingerprint:    fingerprint,
+	}
+	q.calls = append(q.calls, call)
+	held := *call
+	held.Position = len(q.calls)
+	return held, nil
+}
+
+// Held returns the held call with an idempotency key, so a client retrying
+// the call after reconnecting waits for it instead of running it twice. A
+// key reused with different arguments is an error.
+func (q *Queue) Held(tool, key, fingerprint string) (Call, bool, error) {
+	identity := tool + "\x00key\x00" + key
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, call := range q.calls {
+		if call.identity != identity {
+			continue
+		}
+		if call.fingerprint != fingerprint {
+			return Call{}, false, fmt.Errorf("idempotency_key %q is already queued for a %s call with different arguments", key, tool)
+		}
+		held := *call
+		held.Position = i + 1
+		return held, true, nil
+	}
+	return Call{}, false, nil
+}
+
+// Expire removes and returns the calls that were not run in time
+func (q *Queue) Expire(now time.Time) []Call {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var expired []Call
+	kept := q.calls[:0]
+	for _, call := range q.calls {
+		if !call.running && now.After(call.ExpiresAt) {
+			expired = append(expired, *call)
+			continue
+		}
+		kept = append(kept, call)
+	}
+	q.calls = kept
+	return expired
+}
+
+// Next marks the oldest waiting call as running and returns it
+func (q *Queue) Next() (Call, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, call := range q.calls {
+		if !call.running {
+			call.running = true
+			return *call, true
+		}
+	}
+	return Call{}, false
+}
+
+// Done removes a call that has run
+func (q *Queue) Done(id string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for i, call := range q.calls {
+		if call.ID == id {
+			q.calls = append(q.calls[:i], q.calls[i+1:]...)
+			return
+		}
+	}
+}
+
+// Release returns a running call to the queue, e.g. when the gateway went
+// down again before it could run
+func (q *Queue) Release(id string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for _, call := range q.calls {
+		if call.ID == id {
+			call.running = false
+			return
+		}
+	}
+}
+
+// Len returns the number of held calls
+func (q *Queue) Len() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return len(q.calls)
+}
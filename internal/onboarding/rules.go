@@ -0,0 +1,202 @@
+// Package onboarding holds the rules run when a member joins a guild:
+// assigning roles, sending a welcome direct message and posting a welcome
+// message in a channel.
+package onboarding
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/templates"
+)
+
+// Rule sources
+const (
+	SourceConfig = "config"
+	SourceTool   = "tool"
+)
+
+// MaxRules bounds the number of registered rules
+const MaxRules = 100
+
+// Placeholders are the {{name}} values available to welcome messages
+var Placeholders = []string{"user", "username", "display_name", "user_id", "server", "member_count"}
+
+// Rule is a set of actions run for every member joining a guild
+type Rule struct {
+	Name    string   `json:"name"`
+	GuildID string   `json:"guild_id"`
+	RoleIDs []string `json:"role_ids,omitempty"`
+	// DirectMessage is sent to the new member
+	DirectMessage string `json:"direct_message,omitempty"`
+	// ChannelMessage is posted in ChannelID
+	ChannelID      string `json:"channel_id,omitempty"`
+	ChannelMessage string `json:"channel_message,omitempty"`
+	IncludeBots    bool   `json:"include_bots"`
+	Enabled        bool   `json:"enabled"`
+	Source         string `json:"source"`
+
+	UpdatedAt time.Time  `json:"updated_at"`
+	Runs      int64      `json:"run_count"`
+	Failures  int64      `json:"failure_count"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// Registry holds the onboarding rules, keyed by guild and name
+type Registry struct {
+	rules map[string]*Rule
+	mutex sync.RWMutex
+}
+
+// NewRegistry creates an empty onboarding registry
+func NewRegistry() *Registry {
+	return &Registry{
+		rules: make(map[string]*Rule),
+	}
+}
+
+// Set validates and stores a rule, replacing any rule with the same guild
+// and name. Run counters survive replacement. It reports whether the rule
+// is new.
+func (r *Registry) Set(rule Rule) (Rule, bool, error) {
+	if err := rule.check(); err != nil {
+		return Rule{}, false, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	k := key(rule.GuildID, rule.Name)
+	existing, ok := r.rules[k]
+	if !ok && len(r.rules) >= MaxRules {
+		return Rule{}, false, fmt.Errorf("onboarding rule limit reached (%d)", MaxRules)
+	}
+	if ok {
+		rule.Runs = existing.Runs
+		rule.Failures = existing.Failures
+		rule.LastRunAt = existing.LastRunAt
+		rule.LastError = existing.LastError
+	}
+	rule.UpdatedAt = time.Now().UTC()
+	r.rules[k] = &rule
+	return rule, !ok, nil
+}
+
+// List returns the rules of a guild, or of every guild when guildID is
+// empty, ordered by guild and name
+func (r *Registry) List(guildID string) []Rule {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	rules := make([]Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		if guildID == "" || rule.GuildID == guildID {
+			rules = append(rules, *rule)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].GuildID != rules[j].GuildID {
+			return rules[i].GuildID < rules[j].GuildID
+		}
+		return rules[i].Name < rules[j].Name
+	})
+	return rules
+}
+
+// Match returns the enabled rules that apply to a member joining a guild
+func (r *Registry) Match(guildID string, bot bool) []Rule {
+	var matches []Rule
+	for _, rule := range r.List(guildID) {
+		if rule.Enabled && (!bot || rule.IncludeBots) {
+			matches = append(matches, rule)
+		}
+	}
+	return matches
+}
+
+// Record counts a run of a rule and keeps its most recent error
+func (r *Registry) Record(guildID, name string, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	rule, ok := r.rules[key(guildID, name)]
+	if !ok {
+		return
+	}
+	now := time.Now().UTC()
+	rule.Runs++
+	rule.LastRunAt = &now
+	rule.LastError = ""
+	if err != nil {
+		rule.Failures++
+		rule.LastError = err.Error()
+	}
+}
+
+// Values returns the placeholder values for a member joining a guild
+func Values(member *discordgo.Member, guild *discordgo.Guild) map[string]string {
+	values := map[string]string{
+		"user":         member.Mention(),
+		"username":     member.User.Username,
+		"display_name": member.DisplayName(),
+		"user_id":      member.User.ID,
+		"server":       "",
+		"member_count": "",
+	}
+	if guild != nil {
+		values["server"] = guild.Name
+		values["member_count"] = strconv.Itoa(guild.MemberCount)
+	}
+	return values
+}
+
+// Render substitutes placeholder values into a welcome message
+func Render(text string, values map[string]string) (string, error) {
+	rendered, err := (&templates.Template{Content: text}).Render(values)
+	if err != nil {
+		return "", err
+	}
+	return rendered.Content, nil
+}
+
+// check validates a rule's fields and placeholders
+func (rule *Rule) check() error {
+	if rule.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if rule.GuildID == "" {
+		return fmt.Errorf("guild_id is required")
+	}
+	if len(rule.RoleIDs) == 0 && rule.DirectMessage == "" && rule.ChannelMessage == "" {
+		return fmt.Errorf("rule %q has no actions: set role_ids, direct_message or channel_message", rule.Name)
+	}
+	if (rule.ChannelID == "") != (rule.ChannelMessage == "") {
+		return fmt.Errorf("channel_id and channel_message must be set together")
+	}
+
+	known := make(map[string]bool, len(Placeholders))
+	for _, name := range Placeholders {
+		known[name] = true
+	}
+	for _, message := range []struct{ field, text string }{
+		{"direct_message", rule.DirectMessage},
+		{"channel_message", rule.ChannelMessage},
+	} {
+		for _, name := range (&templates.Template{Content: message.text}).Placeholders() {
+			if !known[name] {
+				return fmt.Errorf("%s: unknown placeholder {{%s}}", message.field, name)
+			}
+		}
+	}
+	return nil
+}
+
+func key(guildID, name string) string {
+	return guildID + "/" + name
+}
@@ -0,0 +1,340 @@
+// Package outbound queues the messages the bot sends so that interactive
+// replies go out before bulk posts. Messages to one channel are always sent
+// in the order they were queued, and a full queue refuses new messages
+// instead of delaying them indefinitely.
+package outbound
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Priority orders queued messages; lower values are sent first
+type Priority int
+
+// Priorities from most to least urgent
+const (
+	// PriorityInteractive is for tool calls and replies to users
+	PriorityInteractive Priority = iota
+	// PriorityNormal is for posts triggered by events, such as welcomes
+	PriorityNormal
+	// PriorityBulk is for scheduled and batch posts, such as digests
+	PriorityBulk
+)
+
+// priorityCount is the number of priorities
+const priorityCount = 3
+
+// String returns the priority name
+func (p Priority) String() string {
+	switch p {
+	case PriorityInteractive:
+		return "interactive"
+	case PriorityNormal:
+		return "normal"
+	case PriorityBulk:
+		return "bulk"
+	}
+	return "unknown"
+}
+
+var (
+	// ErrQueueFull is returned when a message is refused for backpressure
+	ErrQueueFull = errors.New("outbound queue is full")
+	// ErrClosed is returned for messages queued after, or still pending
+	// at, Close
+	ErrClosed = errors.New("outbound queue is closed")
+)
+
+// Options size the queue
+type Options struct {
+	// Workers is the number of messages sent at once
+	Workers int
+	// InteractiveWorkers are reserved for interactive messages, so bulk
+	// posts never occupy every worker
+	InteractiveWorkers int
+	// MaxPending refuses interactive and normal messages beyond this many
+	// pending messages
+	MaxPending int
+	// BulkMaxPending refuses bulk messages beyond this many pending
+	// messages
+	BulkMaxPending int
+}
+
+// Stats describe the queue for backpressure reporting
+type Stats struct {
+	Pending    int            `json:"pending"`
+	ByPriority map[string]int `json:"pending_by_priority"`
+	Channels   int            `json:"channels"`
+	// OldestWaitMs is how long the oldest pending message has waited
+	OldestWaitMs int64 `json:"oldest_wait_ms"`
+	// Saturated is true when bulk messages are being refused
+	Saturated      bool `json:"saturated"`
+	MaxPending     int  `json:"max_pending"`
+	BulkMaxPending int  `json:"bulk_max_pending"`
+	Workers        int  `json:"workers"`
+	// Totals since startup, by priority
+	Sent      map[string]int64 `json:"sent"`
+	Failed    map[string]int64 `json:"failed"`
+	Rejected  map[string]int64 `json:"rejected"`
+	AvgWaitMs map[string]int64 `json:"avg_wait_ms"`
+	MaxWaitMs map[string]int64 `json:"max_wait_ms"`
+}
+
+// job is one queued message
+type job struct {
+	priority Priority
+	send     func() error
+	queued   time.Time
+	done     chan result
+}
+
+// result is the outcome of a job
+type result struct {
+	waited time.Duration
+	err    error
+}
+
+// channelQueue holds a channel's pending messages in queue order
+type channelQueue struct {
+	jobs []*job
+	// busy is set while a worker sends the channel's head message
+	busy bool
+}
+
+// priority is the most urgent priority pending in the channel. Every
+// message in the channel is sent at it, so an urgent message is never held
+// back by the less urgent ones queued before it.
+func (c *channelQueue) priority() Priority {
+	best := PriorityBulk
+	for _, j := range c.jobs {
+		if j.priority < best {
+			best = j.priority
+		}
+	}
+	return best
+}
+
+// counters are the totals of one priority
+type counters struct {
+	sent, failed, rejected int64
+	waitTotal, waitMax     time.Duration
+}
+
+// Queue sends messages with a pool of workers
+type Queue struct {
+	opts Options
+
+	mutex    sync.Mutex
+	ready    *sync.Cond
+	channels map[string]*channelQueue
+	pending  [priorityCount]int
+	totals   [priorityCount]counters
+	closed   bool
+}
+
+// NewQueue creates a queue and starts its workers
+func NewQueue(opts Options) *Queue {
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.InteractiveWorkers >= opts.Workers {
+		opts.InteractiveWorkers = opts.Workers - 1
+	}
+	q := &Queue{
+		opts:     opts,
+		channels: make(map[string]*channelQueue),
+	}
+	q.ready = sync.NewCond(&q.mutex)
+	for i := 0; i < opts.Workers; i++ {
+		go q.work(i < opts.InteractiveWorkers)
+	}
+	return q
+}
+
+// Send queues a message to a channel and waits until send has run. It
+// returns how long the message waited in the queue, and ErrQueueFull
+// without queueing it when the queue is over the priority's limit.
+func (q *Queue) Send(channelID string, priority Priority, send func() error) (time.Duration, error) {
+	if priority < PriorityInteractive || priority > PriorityBulk {
+		priority = PriorityNormal
+	}
+
+	q.mutex.Lock()
+	if q.closed {
+		q.mutex.Unlock()
+		return 0, ErrClosed
+	}
+	limit := q.opts.MaxPending
+	if priority == PriorityBulk {
+		limit = q.opts.BulkMaxPending
+	}
+	if limit > 0 && q.total() >= limit {
+		q.totals[priority].rejected++
+		q.mutex.Unlock()
+		return 0, ErrQueueFull
+	}
+
+	j := &job{priority: priority, send: send, queued: time.Now(), done: make(chan result, 1)}
+	channel, ok := q.channels[channelID]
+	if !ok {
+		channel = &channelQueue{}
+		q.channels[channelID] = channel
+	}
+	channel.jobs = append(channel.jobs, j)
+	q.pending[priority]++
+	q.ready.Broadcast()
+	q.mutex.Unlock()
+
+	r := <-j.done
+	return r.waited, r.err
+}
+
+// Stats returns the queue depth and totals
+func (q *Queue) Stats() Stats {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	now := time.Now()
+	stats := Stats{
+		Pending:        q.total(),
+		ByPriority:     make(map[string]int, priorityCount),
+		Channels:       len(q.channels),
+		MaxPending:     q.opts.MaxPending,
+		BulkMaxPending: q.opts.BulkMaxPending,
+		Workers:        q.opts.Workers,
+		Sent:           make(map[string]int64, priorityCount),
+		Failed:         make(map[string]int64, priorityCount),
+		Rejected:       make(map[string]int64, priorityCount),
+		AvgWaitMs:      make(map[string]int64, priorityCount),
+		MaxWaitMs:      make(map[string]int64, priorityCount),
+	}
+	stats.Saturated = q.opts.BulkMaxPending > 0 && stats.Pending >= q.opts.BulkMaxPending
+	for p := Priority(0); p < priorityCount; p++ {
+		name := p.String()
+		t := q.totals[p]
+		stats.ByPriority[name] = q.pending[p]
+		stats.Sent[name] = t.sent
+		stats.Failed[name] = t.failed
+		stats.Rejected[name] = t.rejected
+		stats.MaxWaitMs[name] = t.waitMax.Milliseconds()
+		stats.AvgWaitMs[name] = 0
+		if done := t.sent + t.failed; done > 0 {
+			stats.AvgWaitMs[name] = (t.waitTotal / time.Duration(done)).Milliseconds()
+		}
+	}
+	for _, channel := range q.channels {
+		for _, j := range channel.jobs {
+			if wait := now.Sub(j.queued).Milliseconds(); wait > stats.OldestWaitMs {
+				stats.OldestWaitMs = wait
+			}
+		}
+	}
+	return stats
+}
+
+// Close stops the workers once their current messages are sent and fails
+// the pending ones with ErrClosed
+func (q *Queue) Close() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	for id, channel := range q.channels {
+		start := 0
+		if channel.busy {
+			// The worker sending the head message finishes it
+			start = 1
+		}
+		for _, j := range channel.jobs[start:] {
+			q.pending[j.priority]--
+			j.done <- result{err: ErrClosed}
+		}
+		channel.jobs = channel.jobs[:start]
+		if len(channel.jobs) == 0 {
+			delete(q.channels, id)
+		}
+	}
+	q.ready.Broadcast()
+}
+
+// work sends messages until the queue is closed. A reserved worker only
+// takes channels with an interactive message pending.
+func (q *Queue) work(reserved bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for {
+		id, channel := q.next(reserved)
+		for channel == nil {
+			if q.closed {
+				return
+			}
+			q.ready.Wait()
+			id, channel = q.next(reserved)
+		}
+
+		j := channel.jobs[0]
+		channel.busy = true
+		waited := time.Since(j.queued)
+		q.mutex.Unlock()
+
+		err := j.send()
+
+		q.mutex.Lock()
+		channel.jobs = channel.jobs[1:]
+		channel.busy = false
+		if len(channel.jobs) == 0 {
+			delete(q.channels, id)
+		}
+		q.pending[j.priority]--
+		t := &q.totals[j.priority]
+		if err != nil {
+			t.failed++
+		} else {
+			t.sent++
+		}
+		t.waitTotal += waited
+		if waited > t.waitMax {
+			t.waitMax = waited
+		}
+		j.done <- result{waited: waited, err: err}
+		// The channel may have more messages for another worker
+		q.ready.Broadcast()
+	}
+}
+
+// next picks the idle channel with the most urgent pending message, oldest
+// first among equals
+func (q *Queue) next(reserved bool) (string, *channelQueue) {
+	var bestID string
+	var best *channelQueue
+	var bestPriority Priority
+	for id, channel := range q.channels {
+		if channel.busy || len(channel.jobs) == 0 {
+			continue
+		}
+		priority := channel.priority()
+		if reserved && priority != PriorityInteractive {
+			continue
+		}
+		if best == nil || priority < bestPriority ||
+			(priority == bestPriority && channel.jobs[0].queued.Before(best.jobs[0].queued)) {
+			bestID, best, bestPriority = id, channel, priority
+		}
+	}
+	return bestID, best
+}
+
+// total returns the number of pending messages
+func (q *Queue) total() int {
+	n := 0
+	for _, count := range q.pending {
+		n += count
+	}
+	return n
+}
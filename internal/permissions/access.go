@@ -0,0 +1,93 @@
+package permissions
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CanAccessChannel checks the channel against the configured allow/deny lists
+func (c *Checker) CanAccessChannel(channelID string) error {
+	channel, err := c.getChannelInfo(channelID)
+	if err != nil {
+		return err
+	}
+
+	if !c.IsChannelAccessible(channel) {
+		return newChannelAccessError(channelID)
+	}
+	return nil
+}
+
+// IsEventAccessible reports whether an event from a channel may be passed
+// to the client: its guild must be in discord.allowed_guilds, if set, and
+// the channel must pass the channel access lists
+func (c *Checker) IsEventAccessible(guildID, channelID string) bool {
+	if guildID != "" && !c.discord.IsGuildAllowed(guildID) {
+		return false
+	}
+	return c.CanAccessChannel(channelID) == nil
+}
+
+// IsChannelAccessible reports whether the channel passes the configured
+// allowed/denied channel and category lists. Threads are matched by their
+// own ID as well as their parent channel's ID and category. Deny rules win
+// over allow rules; when any allow list is set, only matching channels are
+// accessible.
+func (c *Checker) IsChannelAccessible(channel *discordgo.Channel) bool {
+	cfg := c.discord.Config().Discord
+	if len(cfg.AllowedChannels) == 0 && len(cfg.DeniedChannels) == 0 &&
+		len(cfg.AllowedCategories) == 0 && len(cfg.DeniedCategories) == 0 {
+		return true
+	}
+
+	channelIDs := []string{channel.ID}
+	categoryID := channel.ParentID
+
+	switch {
+	case channel.Type == discordgo.ChannelTypeGuildCategory:
+		categoryID = channel.ID
+	case channel.IsThread():
+		channelIDs = append(channelIDs, channel.ParentID)
+		categoryID = ""
+		if parent, err := c.discord.GetChannel(channel.ParentID); err == nil {
+			categoryID = parent.ParentID
+		}
+	}
+
+	for _, id := range channelIDs {
+		if contains(cfg.DeniedChannels, id) {
+			return false
+		}
+	}
+	if categoryID != "" && contains(cfg.DeniedCategories, categoryID) {
+		return false
+	}
+
+	if len(cfg.AllowedChannels) == 0 && len(cfg.AllowedCategories) == 0 {
+		return true
+	}
+
+	for _, id := range channelIDs {
+		if contains(cfg.AllowedChannels, id) {
+			return true
+		}
+	}
+	return categoryID != "" && contains(cfg.AllowedCategories, categoryID)
+}
+
+// newChannelAccessError creates the error returned for channels excluded by config
+func newChannelAccessError(channelID string) *PermissionError {
+	return NewPermissionError("channel_access", "CHANNEL_ACCESS_POLICY",
+		fmt.Sprintf("channel:%s", channelID),
+		"Channel is not permitted by the configured channel access rules")
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
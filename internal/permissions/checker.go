@@ -7,6 +7,8 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/telemetry"
 	"discord-mcp/pkg/types"
 )
 
@@ -52,7 +54,7 @@ func FormatPermissionError(err *PermissionError) types.CallToolResult {
 	return types.CallToolResult{
 		Content: []types.Content{{
 			Type: "text",
-			Text: fmt.Sprintf("🔒 Permission Error: %s", err.Description),
+			Text: i18n.T(i18n.DefaultLocale, "error.permission", err.Description),
 			Data: map[string]interface{}{
 				"error_type":  "permission",
 				"operation":   err.Operation,
@@ -131,6 +133,22 @@ func (c *Checker) CanManageMessages(channelID string) error {
 	return nil
 }
 
+// CanManageWebhooks checks if the bot can manage webhooks in a channel
+func (c *Checker) CanManageWebhooks(channelID string) error {
+	permissions, err := c.getUserChannelPermissions(channelID)
+	if err != nil {
+		return err
+	}
+
+	if permissions&discordgo.PermissionManageWebhooks == 0 {
+		return NewPermissionError("manage_webhooks", "MANAGE_WEBHOOKS",
+			fmt.Sprintf("channel:%s", channelID),
+			"Bot cannot manage webhooks in this channel")
+	}
+
+	return nil
+}
+
 // CanAddReactions checks if the bot can add reactions to messages
 func (c *Checker) CanAddReactions(channelID string) error {
 	permissions, err := c.getUserChannelPermissions(channelID)
@@ -179,6 +197,57 @@ func (c *Checker) CanViewChannel(channelID string) error {
 	return nil
 }
 
+// CanUseVoice checks if the bot can connect and speak in a voice channel
+func (c *Checker) CanUseVoice(channelID string) error {
+	permissions, err := c.getUserChannelPermissions(channelID)
+	if err != nil {
+		return err
+	}
+
+	if permissions&discordgo.PermissionVoiceConnect == 0 {
+		return NewPermissionError("join_voice", "CONNECT",
+			fmt.Sprintf("channel:%s", channelID),
+			"Bot cannot connect to this voice channel")
+	}
+
+	if permissions&discordgo.PermissionVoiceSpeak == 0 {
+		return NewPermissionError("join_voice", "SPEAK",
+			fmt.Sprintf("channel:%s", channelID),
+			"Bot cannot speak in this voice channel")
+	}
+
+	return nil
+}
+
+// CanUseSoundboard checks if the bot can play soundboard sounds in a voice
+// channel, including sounds from other guilds when external is set
+func (c *Checker) CanUseSoundboard(channelID string, external bool) error {
+	permissions, err := c.getUserChannelPermissions(channelID)
+	if err != nil {
+		return err
+	}
+
+	if permissions&discordgo.PermissionVoiceSpeak == 0 {
+		return NewPermissionError("use_soundboard", "SPEAK",
+			fmt.Sprintf("channel:%s", channelID),
+			"Bot cannot speak in this voice channel")
+	}
+
+	if permissions&discordgo.PermissionUseSoundboard == 0 {
+		return NewPermissionError("use_soundboard", "USE_SOUNDBOARD",
+			fmt.Sprintf("channel:%s", channelID),
+			"Bot cannot use the soundboard in this voice channel")
+	}
+
+	if external && permissions&discordgo.PermissionUseExternalSounds == 0 {
+		return NewPermissionError("use_soundboard", "USE_EXTERNAL_SOUNDS",
+			fmt.Sprintf("channel:%s", channelID),
+			"Bot cannot play sounds from other servers in this voice channel")
+	}
+
+	return nil
+}
+
 // Guild Permission Methods
 
 // CanViewGuild checks if the bot can view guild information
@@ -216,6 +285,88 @@ func (c *Checker) CanManageRoles(guildID string) error {
 	return nil
 }
 
+// CanManageChannels checks if the bot can manage channels in a guild
+func (c *Checker) CanManageChannels(guildID string) error {
+	permissions, err := c.getBotGuildPermissions(guildID)
+	if err != nil {
+		return err
+	}
+
+	if permissions&discordgo.PermissionManageChannels == 0 {
+		return NewPermissionError("manage_channels", "MANAGE_CHANNELS",
+			fmt.Sprintf("guild:%s", guildID),
+			"Bot cannot manage channels in this guild")
+	}
+
+	return nil
+}
+
+// CanManageGuild checks if the bot can manage a guild's settings
+func (c *Checker) CanManageGuild(guildID string) error {
+	permissions, err := c.getBotGuildPermissions(guildID)
+	if err != nil {
+		return err
+	}
+
+	if permissions&discordgo.PermissionManageGuild == 0 {
+		return NewPermissionError("manage_guild", "MANAGE_GUILD",
+			fmt.Sprintf("guild:%s", guildID),
+			"Bot cannot manage this guild's settings")
+	}
+
+	return nil
+}
+
+// CanCreateGuildExpressions checks if the bot can add emojis, stickers and
+// soundboard sounds to a guild
+func (c *Checker) CanCreateGuildExpressions(guildID string) error {
+	permissions, err := c.getBotGuildPermissions(guildID)
+	if err != nil {
+		return err
+	}
+
+	if permissions&(discordgo.PermissionCreateGuildExpressions|discordgo.PermissionManageGuildExpressions) == 0 {
+		return NewPermissionError("create_expressions", "CREATE_GUILD_EXPRESSIONS",
+			fmt.Sprintf("guild:%s", guildID),
+			"Bot cannot add emojis, stickers or sounds to this guild")
+	}
+
+	return nil
+}
+
+// CanManageGuildExpressions checks if the bot can edit and delete a guild's
+// emojis, stickers and soundboard sounds
+func (c *Checker) CanManageGuildExpressions(guildID string) error {
+	permissions, err := c.getBotGuildPermissions(guildID)
+	if err != nil {
+		return err
+	}
+
+	if permissions&discordgo.PermissionManageGuildExpressions == 0 {
+		return NewPermissionError("manage_expressions", "MANAGE_GUILD_EXPRESSIONS",
+			fmt.Sprintf("guild:%s", guildID),
+			"Bot cannot manage this guild's emojis, stickers or sounds")
+	}
+
+	return nil
+}
+
+// CanManageGuildWebhooks checks if the bot can manage webhooks across a guild
+func (c *Checker) CanManageGuildWebhooks(guildID string) error {
+	permissions, err := c.getBotGuildPermissions(guildID)
+	if err != nil {
+		return err
+	}
+
+	if permissions&discordgo.PermissionManageWebhooks == 0 {
+		return NewPermissionError("manage_webhooks", "MANAGE_WEBHOOKS",
+			fmt.Sprintf("guild:%s", guildID),
+			"Bot cannot manage webhooks in this guild")
+	}
+
+	return nil
+}
+
 // Message-specific Permission Methods
 
 // CanEditMessage checks if the bot can edit a specific message
@@ -296,6 +447,15 @@ func (c *Checker) CanDeleteMessage(channelID, messageID string) error {
 
 // getUserChannelPermissions gets the bot's permissions for a specific channel
 func (c *Checker) getUserChannelPermissions(channelID string) (int64, error) {
+	span := c.discord.Telemetry().Start("permissions.channel", telemetry.KindInternal)
+	span.SetAttribute("discord.channel_id", channelID)
+	permissions, err := c.channelPermissions(channelID)
+	span.End(err)
+	return permissions, err
+}
+
+// channelPermissions computes the bot's permissions for a channel
+func (c *Checker) channelPermissions(channelID string) (int64, error) {
 	botUser, err := c.discord.GetBotUser()
 	if err != nil {
 		return 0, fmt.Errorf("failed to get bot user: %w", err)
@@ -307,23 +467,38 @@ func (c *Checker) getUserChannelPermissions(channelID string) (int64, error) {
 		return 0, err
 	}
 
+	// Every channel-level check passes through here, so enforce the
+	// configured channel access lists centrally
+	if !c.IsChannelAccessible(channel) {
+		return 0, newChannelAccessError(channelID)
+	}
+
 	if channel.GuildID == "" {
 		// DM channel - bots have basic permissions in DMs
 		return discordgo.PermissionSendMessages | discordgo.PermissionReadMessageHistory | discordgo.PermissionAddReactions, nil
 	}
 
-	// Get user permissions in the channel
+	if permissions, ok := c.discord.Cache().Permissions(channelID); ok {
+		return permissions, nil
+	}
+
+	// Get user permissions in the channel, computing them from REST data
+	// when the guild or member is missing from the gateway state
 	permissions, err := c.discord.Session().UserChannelPermissions(botUser.ID, channelID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get channel permissions: %w", err)
+		permissions, err = c.computeChannelPermissions(channel, botUser.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get channel permissions: %w", err)
+		}
 	}
 
+	c.discord.Cache().SetPermissions(channel.GuildID, channelID, permissions)
 	return permissions, nil
 }
 
 // getChannelInfo gets basic channel information
 func (c *Checker) getChannelInfo(channelID string) (*discordgo.Channel, error) {
-	channel, err := c.discord.Session().Channel(channelID)
+	channel, err := c.discord.GetChannel(channelID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get channel info: %w", err)
 	}
@@ -341,36 +516,142 @@ func (c *Checker) getMessageInfo(channelID, messageID string) (*discordgo.Messag
 
 // getBotGuildPermissions gets the bot's permissions for a specific guild
 func (c *Checker) getBotGuildPermissions(guildID string) (int64, error) {
+	span := c.discord.Telemetry().Start("permissions.guild", telemetry.KindInternal)
+	span.SetAttribute("discord.guild_id", guildID)
+	permissions, err := c.guildPermissions(guildID)
+	span.End(err)
+	return permissions, err
+}
+
+// guildPermissions computes the bot's permissions for a guild
+func (c *Checker) guildPermissions(guildID string) (int64, error) {
 	botUser, err := c.discord.GetBotUser()
 	if err != nil {
 		return 0, fmt.Errorf("failed to get bot user: %w", err)
 	}
 
-	_, err = c.discord.GetGuild(guildID)
+	guild, err := c.discord.GetGuild(guildID)
 	if err != nil {
 		return 0, err
 	}
 
-	member, err := c.discord.Session().State.Member(guildID, botUser.ID)
+	if permissions, ok := c.discord.Cache().GuildPermissions(guildID); ok {
+		return permissions, nil
+	}
+
+	member, err := c.getMember(guildID, botUser.ID)
 	if err != nil {
-		member, err = c.discord.Session().GuildMember(guildID, botUser.ID)
-		if err != nil {
-			return 0, fmt.Errorf("failed to get bot member info: %w", err)
-		}
+		return 0, fmt.Errorf("failed to get bot member info: %w", err)
 	}
 
-	var permissions int64
-	for _, roleID := range member.Roles {
-		role, err := c.discord.Session().State.Role(guildID, roleID)
-		if err != nil {
-			return 0, fmt.Errorf("failed to get role info: %w", err)
+	roles, err := c.discord.GetRoles(guildID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get role info: %w", err)
+	}
+
+	permissions, err := computeBasePermissions(guild.OwnerID, guildID, botUser.ID, member.Roles, roles)
+	if err != nil {
+		return 0, err
+	}
+
+	c.discord.Cache().SetGuildPermissions(guildID, permissions)
+	return permissions, nil
+}
+
+// computeBasePermissions computes a member's guild-wide permissions the way
+// Discord does: the owner has every permission, otherwise the @everyone
+// role (whose ID is the guild ID) is combined with the member's roles, and
+// ADMINISTRATOR grants every permission
+func computeBasePermissions(ownerID, guildID, userID string, memberRoles []string, roles []*discordgo.Role) (int64, error) {
+	if userID == ownerID {
+		return discordgo.PermissionAll, nil
+	}
+
+	rolePermissions := make(map[string]int64, len(roles))
+	for _, role := range roles {
+		rolePermissions[role.ID] = role.Permissions
+	}
+
+	permissions := rolePermissions[guildID]
+	for _, roleID := range memberRoles {
+		perms, ok := rolePermissions[roleID]
+		if !ok {
+			return 0, fmt.Errorf("failed to get role info: role %s not found", roleID)
 		}
-		permissions |= role.Permissions
+		permissions |= perms
 	}
 
+	if permissions&discordgo.PermissionAdministrator != 0 {
+		return discordgo.PermissionAll, nil
+	}
 	return permissions, nil
 }
 
+// computeChannelPermissions computes a member's permissions in a channel
+// without relying on the gateway state
+func (c *Checker) computeChannelPermissions(channel *discordgo.Channel, userID string) (int64, error) {
+	guild, err := c.discord.GetGuild(channel.GuildID)
+	if err != nil {
+		return 0, err
+	}
+
+	member, err := c.getMember(channel.GuildID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get member info: %w", err)
+	}
+
+	roles, err := c.discord.GetRoles(channel.GuildID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get role info: %w", err)
+	}
+
+	base, err := computeBasePermissions(guild.OwnerID, channel.GuildID, userID, member.Roles, roles)
+	if err != nil {
+		return 0, err
+	}
+	return applyOverwrites(base, channel.GuildID, userID, member.Roles, channel.PermissionOverwrites), nil
+}
+
+// applyOverwrites applies a channel's permission overwrites to a member's
+// base permissions in Discord's order: @everyone, then the member's roles
+// combined, then the member. ADMINISTRATOR is not affected by overwrites.
+func applyOverwrites(base int64, guildID, userID string, memberRoles []string, overwrites []*discordgo.PermissionOverwrite) int64 {
+	if base&discordgo.PermissionAdministrator != 0 {
+		return discordgo.PermissionAll
+	}
+
+	hasRole := make(map[string]bool, len(memberRoles))
+	for _, roleID := range memberRoles {
+		hasRole[roleID] = true
+	}
+
+	permissions := base
+	for _, overwrite := range overwrites {
+		if overwrite.Type == discordgo.PermissionOverwriteTypeRole && overwrite.ID == guildID {
+			permissions &^= overwrite.Deny
+			permissions |= overwrite.Allow
+		}
+	}
+
+	var deny, allow int64
+	for _, overwrite := range overwrites {
+		if overwrite.Type == discordgo.PermissionOverwriteTypeRole && hasRole[overwrite.ID] {
+			deny |= overwrite.Deny
+			allow |= overwrite.Allow
+		}
+	}
+	permissions &^= deny
+	permissions |= allow
+
+	for _, overwrite := range overwrites {
+		if overwrite.Type == discordgo.PermissionOverwriteTypeMember && overwrite.ID == userID {
+			permissions &^= overwrite.Deny
+			permissions |= overwrite.Allow
+		}
+	}
+	return permissions
+}
+
 // GetChannelPermissions returns a summary of bot permissions for a channel
 func (c *Checker) GetChannelPermissions(channelID string) (map[string]bool, error) {
 	permissions, err := c.getUserChannelPermissions(channelID)
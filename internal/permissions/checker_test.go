@@ -0,0 +1,163 @@
+package permissions
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	testGuild = "100"
+	testOwner = "200"
+	testUser  = "300"
+	testRoleA = "400"
+	testRoleB = "500"
+)
+
+var testRoles = []*discordgo.Role{
+	{ID: testGuild, Permissions: discordgo.PermissionViewChannel | discordgo.PermissionSendMessages},
+	{ID: testRoleA, Permissions: discordgo.PermissionManageMessages},
+	{ID: testRoleB, Permissions: discordgo.PermissionAdministrator},
+}
+
+func TestComputeBasePermissions(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      string
+		memberRoles []string
+		want        int64
+		wantErr     bool
+	}{
+		{
+			name:   "guild owner has every permission",
+			userID: testOwner,
+			want:   discordgo.PermissionAll,
+		},
+		{
+			name:   "member without roles gets @everyone",
+			userID: testUser,
+			want:   discordgo.PermissionViewChannel | discordgo.PermissionSendMessages,
+		},
+		{
+			name:        "roles add to @everyone",
+			userID:      testUser,
+			memberRoles: []string{testRoleA},
+			want:        discordgo.PermissionViewChannel | discordgo.PermissionSendMessages | discordgo.PermissionManageMessages,
+		},
+		{
+			name:        "administrator grants every permission",
+			userID:      testUser,
+			memberRoles: []string{testRoleB},
+			want:        discordgo.PermissionAll,
+		},
+		{
+			name:        "unknown role is an error",
+			userID:      testUser,
+			memberRoles: []string{"999"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := computeBasePermissions(testOwner, testGuild, tt.userID, tt.memberRoles, testRoles)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("permissions = %b, want %b", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOverwrites(t *testing.T) {
+	const (
+		view   = discordgo.PermissionViewChannel
+		send   = discordgo.PermissionSendMessages
+		manage = discordgo.PermissionManageMessages
+	)
+	everyone := func(allow, deny int64) *discordgo.PermissionOverwrite {
+		return &discordgo.PermissionOverwrite{ID: testGuild, Type: discordgo.PermissionOverwriteTypeRole, Allow: allow, Deny: deny}
+	}
+	role := func(id string, allow, deny int64) *discordgo.PermissionOverwrite {
+		return &discordgo.PermissionOverwrite{ID: id, Type: discordgo.PermissionOverwriteTypeRole, Allow: allow, Deny: deny}
+	}
+	member := func(id string, allow, deny int64) *discordgo.PermissionOverwrite {
+		return &discordgo.PermissionOverwrite{ID: id, Type: discordgo.PermissionOverwriteTypeMember, Allow: allow, Deny: deny}
+	}
+
+	tests := []struct {
+		name        string
+		base        int64
+		memberRoles []string
+		overwrites  []*discordgo.PermissionOverwrite
+		want        int64
+	}{
+		{
+			name: "no overwrites keep the base",
+			base: view | send,
+			want: view | send,
+		},
+		{
+			name:       "administrator ignores overwrites",
+			base:       discordgo.PermissionAdministrator,
+			overwrites: []*discordgo.PermissionOverwrite{everyone(0, view)},
+			want:       discordgo.PermissionAll,
+		},
+		{
+			name:       "@everyone deny removes a permission",
+			base:       view | send,
+			overwrites: []*discordgo.PermissionOverwrite{everyone(0, send)},
+			want:       view,
+		},
+		{
+			name:        "role allow overrides @everyone deny",
+			base:        view | send,
+			memberRoles: []string{testRoleA},
+			overwrites:  []*discordgo.PermissionOverwrite{everyone(0, send), role(testRoleA, send, 0)},
+			want:        view | send,
+		},
+		{
+			name:        "role allow wins over another role's deny",
+			base:        view | send,
+			memberRoles: []string{testRoleA, testRoleB},
+			overwrites:  []*discordgo.PermissionOverwrite{role(testRoleA, 0, send), role(testRoleB, send, 0)},
+			want:        view | send,
+		},
+		{
+			name:       "overwrites of roles the member lacks are ignored",
+			base:       view,
+			overwrites: []*discordgo.PermissionOverwrite{role(testRoleA, manage, view)},
+			want:       view,
+		},
+		{
+			name:        "member deny overrides role allow",
+			base:        view,
+			memberRoles: []string{testRoleA},
+			overwrites:  []*discordgo.PermissionOverwrite{role(testRoleA, send, 0), member(testUser, 0, send)},
+			want:        view,
+		},
+		{
+			name:       "member allow overrides @everyone deny",
+			base:       view | send,
+			overwrites: []*discordgo.PermissionOverwrite{everyone(0, view|send), member(testUser, view, 0)},
+			want:       view,
+		},
+		{
+			name:       "overwrites of other members are ignored",
+			base:       view,
+			overwrites: []*discordgo.PermissionOverwrite{member(testOwner, manage, view)},
+			want:       view,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyOverwrites(tt.base, testGuild, testUser, tt.memberRoles, tt.overwrites)
+			if got != tt.want {
+				t.Errorf("permissions = %b, want %b", got, tt.want)
+			}
+		})
+	}
+}
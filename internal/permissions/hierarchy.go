@@ -0,0 +1,216 @@
+package permissions
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// hierarchy is the bot's standing in a guild's role hierarchy
+type hierarchy struct {
+	guild   *discordgo.Guild
+	botID   string
+	topRole *discordgo.Role
+	roles   map[string]*discordgo.Role
+}
+
+// getHierarchy loads the guild's roles and the bot's highest role
+func (c *Checker) getHierarchy(guildID string) (*hierarchy, error) {
+	guild, err := c.discord.GetGuild(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	botUser, err := c.discord.GetBotUser()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bot user: %w", err)
+	}
+
+	member, err := c.getMember(guildID, botUser.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bot member info: %w", err)
+	}
+
+	roles, err := c.discord.GetRoles(guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get role info: %w", err)
+	}
+
+	h := &hierarchy{
+		guild: guild,
+		botID: botUser.ID,
+		roles: make(map[string]*discordgo.Role, len(roles)),
+	}
+	for _, role := range roles {
+		h.roles[role.ID] = role
+	}
+	h.topRole = h.highestRole(member)
+	return h, nil
+}
+
+// getMember gets a guild member from the gateway state or the API
+func (c *Checker) getMember(guildID, userID string) (*discordgo.Member, error) {
+	member, err := c.discord.Session().State.Member(guildID, userID)
+	if err != nil {
+		return c.discord.GetMember(guildID, userID)
+	}
+	return member, nil
+}
+
+// highestRole returns the member's highest role, falling back to @everyone
+func (h *hierarchy) highestRole(member *discordgo.Member) *discordgo.Role {
+	top := h.roles[h.guild.ID]
+	for _, roleID := range member.Roles {
+		role, ok := h.roles[roleID]
+		if ok && (top == nil || roleAbove(role, top)) {
+			top = role
+		}
+	}
+	return top
+}
+
+// botIsOwner reports whether the bot owns the guild and so bypasses the
+// hierarchy
+func (h *hierarchy) botIsOwner() bool {
+	return h.guild.OwnerID == h.botID
+}
+
+// outranks reports whether the bot's highest role is above role
+func (h *hierarchy) outranks(role *discordgo.Role) bool {
+	return h.topRole != nil && roleAbove(h.topRole, role)
+}
+
+// roleAbove reports whether a sorts above b. Discord breaks position ties
+// by ID, with the older role ranking higher.
+func roleAbove(a, b *discordgo.Role) bool {
+	if a.Position != b.Position {
+		return a.Position > b.Position
+	}
+	return a.ID < b.ID
+}
+
+// roleLabel describes a role for error messages
+func roleLabel(role *discordgo.Role) string {
+	if role == nil {
+		return "@everyone"
+	}
+	return fmt.Sprintf("@%s (position %d)", role.Name, role.Position)
+}
+
+// CanManageRole checks that the bot has MANAGE_ROLES and that its highest
+// role is above the target role, as Discord requires to assign, edit or
+// delete it
+func (c *Checker) CanManageRole(guildID, roleID string) error {
+	if err := c.CanManageRoles(guildID); err != nil {
+		return err
+	}
+
+	h, err := c.getHierarchy(guildID)
+	if err != nil {
+		return err
+	}
+	return h.checkRole("manage_role", roleID)
+}
+
+// checkRole checks that the bot can act on a role under the hierarchy
+func (h *hierarchy) checkRole(operation, roleID string) error {
+	resource := fmt.Sprintf("role:%s", roleID)
+
+	role, ok := h.roles[roleID]
+	if !ok {
+		return fmt.Errorf("failed to get role info: role %s not found", roleID)
+	}
+	if roleID == h.guild.ID {
+		return NewPermissionError(operation, "ROLE_HIERARCHY", resource,
+			"The @everyone role cannot be assigned or deleted")
+	}
+	if role.Managed {
+		return NewPermissionError(operation, "ROLE_HIERARCHY", resource,
+			fmt.Sprintf("Role %s is managed by an integration and cannot be assigned or deleted", roleLabel(role)))
+	}
+	if h.botIsOwner() {
+		return nil
+	}
+	if !h.outranks(role) {
+		return NewPermissionError(operation, "ROLE_HIERARCHY", resource,
+			fmt.Sprintf("Role %s is not below the bot's highest role %s; move the bot's role above it",
+				roleLabel(role), roleLabel(h.topRole)))
+	}
+	return nil
+}
+
+// moderationPermissions maps moderation operations to the permission they
+// need in addition to the hierarchy
+var moderationPermissions = map[string]struct {
+	bit  int64
+	name string
+}{
+	"kick_member":      {discordgo.PermissionKickMembers, "KICK_MEMBERS"},
+	"ban_member":       {discordgo.PermissionBanMembers, "BAN_MEMBERS"},
+	"timeout_member":   {discordgo.PermissionModerateMembers, "MODERATE_MEMBERS"},
+	"manage_nicknames": {discordgo.PermissionManageNicknames, "MANAGE_NICKNAMES"},
+}
+
+// CanModerateMember checks that the bot can kick, ban, time out or rename a
+// member: it needs the operation's permission, the target must not own the
+// guild, and the bot's highest role must be above the target's
+func (c *Checker) CanModerateMember(guildID, userID, operation string) error {
+	required, ok := moderationPermissions[operation]
+	if !ok {
+		return fmt.Errorf("unknown moderation operation %q", operation)
+	}
+
+	if err := c.CanViewGuild(guildID); err != nil {
+		return err
+	}
+
+	perms, err := c.getBotGuildPermissions(guildID)
+	if err != nil {
+		return err
+	}
+	if perms&required.bit == 0 {
+		return NewPermissionError(operation, required.name,
+			fmt.Sprintf("guild:%s", guildID),
+			fmt.Sprintf("Bot lacks %s in this guild", required.name))
+	}
+
+	h, err := c.getHierarchy(guildID)
+	if err != nil {
+		return err
+	}
+	return c.checkMember(h, operation, userID)
+}
+
+// checkMember checks that the bot can act on a member under the hierarchy
+func (c *Checker) checkMember(h *hierarchy, operation, userID string) error {
+	resource := fmt.Sprintf("user:%s", userID)
+
+	if userID == h.guild.OwnerID {
+		return NewPermissionError(operation, "ROLE_HIERARCHY", resource,
+			"The guild owner cannot be moderated")
+	}
+	if userID == h.botID {
+		return NewPermissionError(operation, "ROLE_HIERARCHY", resource,
+			"The bot cannot moderate itself")
+	}
+	if h.botIsOwner() {
+		return nil
+	}
+
+	member, err := c.getMember(h.guild.ID, userID)
+	if err != nil {
+		// Banning users who are not members is allowed
+		if operation == "ban_member" {
+			return nil
+		}
+		return fmt.Errorf("failed to get member info: %w", err)
+	}
+
+	target := h.highestRole(member)
+	if target != nil && !h.outranks(target) {
+		return NewPermissionError(operation, "ROLE_HIERARCHY", resource,
+			fmt.Sprintf("Member's highest role %s is not below the bot's highest role %s",
+				roleLabel(target), roleLabel(h.topRole)))
+	}
+	return nil
+}
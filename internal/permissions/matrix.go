@@ -0,0 +1,188 @@
+package permissions
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Risk severities
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityMedium   = "medium"
+)
+
+// MatrixColumns are the permissions a permission matrix reports, in order
+var MatrixColumns = []struct {
+	Key string
+	Bit int64
+}{
+	{"view", discordgo.PermissionViewChannel},
+	{"send", discordgo.PermissionSendMessages},
+	{"read_history", discordgo.PermissionReadMessageHistory},
+	{"attach_files", discordgo.PermissionAttachFiles},
+	{"mention_everyone", discordgo.PermissionMentionEveryone},
+	{"manage_messages", discordgo.PermissionManageMessages},
+	{"manage_channels", discordgo.PermissionManageChannels},
+	{"manage_roles", discordgo.PermissionManageRoles},
+	{"manage_webhooks", discordgo.PermissionManageWebhooks},
+	{"manage_guild", discordgo.PermissionManageGuild},
+	{"kick_members", discordgo.PermissionKickMembers},
+	{"ban_members", discordgo.PermissionBanMembers},
+	{"administrator", discordgo.PermissionAdministrator},
+}
+
+// everyoneRisks are grants that are dangerous for @everyone, which every
+// member holds
+var everyoneRisks = []struct {
+	bit      int64
+	severity string
+	message  string
+}{
+	{discordgo.PermissionAdministrator, SeverityCritical, "@everyone has Administrator, so every member has every permission"},
+	{discordgo.PermissionManageRoles, SeverityCritical, "@everyone can manage roles"},
+	{discordgo.PermissionManageGuild, SeverityCritical, "@everyone can manage the server"},
+	{discordgo.PermissionBanMembers, SeverityCritical, "@everyone can ban members"},
+	{discordgo.PermissionKickMembers, SeverityHigh, "@everyone can kick members"},
+	{discordgo.PermissionManageChannels, SeverityHigh, "@everyone can manage channels"},
+	{discordgo.PermissionManageWebhooks, SeverityHigh, "@everyone can manage webhooks, which can post as anyone"},
+	{discordgo.PermissionManageMessages, SeverityHigh, "@everyone can delete and pin other members' messages"},
+	{discordgo.PermissionMentionEveryone, SeverityHigh, "@everyone can mention @everyone and @here"},
+}
+
+// MatrixRow is one role's effective permissions
+type MatrixRow struct {
+	RoleID      string          `json:"role_id"`
+	RoleName    string          `json:"role_name"`
+	Position    int             `json:"position"`
+	Managed     bool            `json:"managed,omitempty"`
+	Permissions map[string]bool `json:"permissions"`
+	// Overwritten is true when a channel overwrite for the role applies
+	Overwritten bool `json:"overwritten,omitempty"`
+}
+
+// Risk is a grant worth reviewing
+type Risk struct {
+	Severity   string `json:"severity"`
+	RoleID     string `json:"role_id"`
+	RoleName   string `json:"role_name"`
+	Permission string `json:"permission"`
+	Message    string `json:"message"`
+}
+
+// Matrix computes what a member holding only @everyone and each role could
+// do, highest role first. With a channel, its overwrites are applied the
+// way Discord applies them for such a member. Roles that can do more than
+// they should are reported as risks.
+func Matrix(guildID string, roles []*discordgo.Role, channel *discordgo.Channel) ([]MatrixRow, []Risk) {
+	var everyone *discordgo.Role
+	sorted := make([]*discordgo.Role, 0, len(roles))
+	for _, role := range roles {
+		if role.ID == guildID {
+			everyone = role
+		}
+		sorted = append(sorted, role)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return roleAbove(sorted[i], sorted[j]) })
+
+	var base int64
+	if everyone != nil {
+		base = everyone.Permissions
+	}
+
+	rows := make([]MatrixRow, 0, len(sorted))
+	var risks []Risk
+	for _, role := range sorted {
+		perms := base | role.Permissions
+		var memberRoles []string
+		if role.ID != guildID {
+			memberRoles = []string{role.ID}
+		}
+		if perms&discordgo.PermissionAdministrator != 0 {
+			perms = discordgo.PermissionAll
+		}
+
+		row := MatrixRow{
+			RoleID:      role.ID,
+			RoleName:    role.Name,
+			Position:    role.Position,
+			Managed:     role.Managed,
+			Permissions: make(map[string]bool, len(MatrixColumns)),
+		}
+		if channel != nil {
+			perms = applyOverwrites(perms, guildID, "", memberRoles, channel.PermissionOverwrites)
+			// Without VIEW_CHANNEL every other channel permission is denied
+			if perms&discordgo.PermissionViewChannel == 0 {
+				perms &^= discordgo.PermissionAllChannel
+			}
+			row.Overwritten = hasRoleOverwrite(channel, role.ID)
+		}
+		for _, column := range MatrixColumns {
+			row.Permissions[column.Key] = perms&column.Bit == column.Bit
+		}
+		rows = append(rows, row)
+		risks = append(risks, roleRisks(role, guildID, perms)...)
+	}
+
+	sort.SliceStable(risks, func(i, j int) bool {
+		return severityRank(risks[i].Severity) < severityRank(risks[j].Severity)
+	})
+	return rows, risks
+}
+
+// roleRisks flags the risky grants of one role's effective permissions
+func roleRisks(role *discordgo.Role, guildID string, perms int64) []Risk {
+	var risks []Risk
+	if role.ID == guildID {
+		for _, check := range everyoneRisks {
+			if perms&check.bit != 0 {
+				risks = append(risks, Risk{
+					Severity:   check.severity,
+					RoleID:     role.ID,
+					RoleName:   role.Name,
+					Permission: PermissionNames(check.bit)[0],
+					Message:    check.message,
+				})
+			}
+		}
+		// Administrator implies everything else; one finding is enough
+		if perms == discordgo.PermissionAll && len(risks) > 1 {
+			risks = risks[:1]
+		}
+		return risks
+	}
+
+	if role.Permissions&discordgo.PermissionAdministrator != 0 && !role.Managed {
+		risks = append(risks, Risk{
+			Severity:   SeverityMedium,
+			RoleID:     role.ID,
+			RoleName:   role.Name,
+			Permission: "ADMINISTRATOR",
+			Message:    fmt.Sprintf("Role %s has Administrator, which bypasses every channel overwrite", roleLabel(role)),
+		})
+	}
+	return risks
+}
+
+// hasRoleOverwrite reports whether a channel has an overwrite for a role
+func hasRoleOverwrite(channel *discordgo.Channel, roleID string) bool {
+	for _, overwrite := range channel.PermissionOverwrites {
+		if overwrite.Type == discordgo.PermissionOverwriteTypeRole && overwrite.ID == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// severityRank orders severities from most to least severe
+func severityRank(severity string) int {
+	switch severity {
+	case SeverityCritical:
+		return 0
+	case SeverityHigh:
+		return 1
+	}
+	return 2
+}
@@ -0,0 +1,329 @@
+package permissions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Permission scopes for operation requirements
+const (
+	ScopeChannel = "channel"
+	ScopeGuild   = "guild"
+)
+
+// Requirement lists the permissions an operation needs and where they apply
+type Requirement struct {
+	Permissions int64
+	Scope       string
+}
+
+// OperationRequirements maps the operations check_permissions understands to
+// the permissions Discord requires for them
+var OperationRequirements = map[string]Requirement{
+	"view_channel":        {discordgo.PermissionViewChannel, ScopeChannel},
+	"send_message":        {discordgo.PermissionViewChannel | discordgo.PermissionSendMessages, ScopeChannel},
+	"send_tts_message":    {discordgo.PermissionViewChannel | discordgo.PermissionSendMessages | discordgo.PermissionSendTTSMessages, ScopeChannel},
+	"send_embeds":         {discordgo.PermissionViewChannel | discordgo.PermissionSendMessages | discordgo.PermissionEmbedLinks, ScopeChannel},
+	"attach_files":        {discordgo.PermissionViewChannel | discordgo.PermissionSendMessages | discordgo.PermissionAttachFiles, ScopeChannel},
+	"mention_everyone":    {discordgo.PermissionViewChannel | discordgo.PermissionSendMessages | discordgo.PermissionMentionEveryone, ScopeChannel},
+	"read_messages":       {discordgo.PermissionViewChannel | discordgo.PermissionReadMessageHistory, ScopeChannel},
+	"add_reaction":        {discordgo.PermissionViewChannel | discordgo.PermissionReadMessageHistory | discordgo.PermissionAddReactions, ScopeChannel},
+	"use_external_emojis": {discordgo.PermissionViewChannel | discordgo.PermissionUseExternalEmojis, ScopeChannel},
+	"manage_messages":     {discordgo.PermissionViewChannel | discordgo.PermissionManageMessages, ScopeChannel},
+	"delete_message":      {discordgo.PermissionViewChannel | discordgo.PermissionManageMessages, ScopeChannel},
+	"pin_message":         {discordgo.PermissionViewChannel | discordgo.PermissionManageMessages, ScopeChannel},
+	"create_thread":       {discordgo.PermissionViewChannel | discordgo.PermissionCreatePublicThreads, ScopeChannel},
+	"manage_threads":      {discordgo.PermissionViewChannel | discordgo.PermissionManageThreads, ScopeChannel},
+	"manage_channel":      {discordgo.PermissionViewChannel | discordgo.PermissionManageChannels, ScopeChannel},
+	"manage_webhooks":     {discordgo.PermissionViewChannel | discordgo.PermissionManageWebhooks, ScopeChannel},
+	"join_voice":          {discordgo.PermissionViewChannel | discordgo.PermissionVoiceConnect | discordgo.PermissionVoiceSpeak, ScopeChannel},
+	"move_members":        {discordgo.PermissionVoiceMoveMembers, ScopeChannel},
+
+	"manage_roles":     {discordgo.PermissionManageRoles, ScopeGuild},
+	"manage_channels":  {discordgo.PermissionManageChannels, ScopeGuild},
+	"manage_guild":     {discordgo.PermissionManageGuild, ScopeGuild},
+	"manage_nicknames": {discordgo.PermissionManageNicknames, ScopeGuild},
+	"manage_emojis":    {discordgo.PermissionManageGuildExpressions, ScopeGuild},
+	"manage_events":    {discordgo.PermissionManageEvents, ScopeGuild},
+	"kick_member":      {discordgo.PermissionKickMembers, ScopeGuild},
+	"ban_member":       {discordgo.PermissionBanMembers, ScopeGuild},
+	"timeout_member":   {discordgo.PermissionModerateMembers, ScopeGuild},
+	"view_audit_log":   {discordgo.PermissionViewAuditLogs, ScopeGuild},
+	"create_invite":    {discordgo.PermissionCreateInstantInvite, ScopeGuild},
+}
+
+// permissionNames lists permission bits with their API names, in display order
+var permissionNames = []struct {
+	bit  int64
+	name string
+}{
+	{discordgo.PermissionAdministrator, "ADMINISTRATOR"},
+	{discordgo.PermissionViewChannel, "VIEW_CHANNEL"},
+	{discordgo.PermissionSendMessages, "SEND_MESSAGES"},
+	{discordgo.PermissionSendTTSMessages, "SEND_TTS_MESSAGES"},
+	{discordgo.PermissionEmbedLinks, "EMBED_LINKS"},
+	{discordgo.PermissionAttachFiles, "ATTACH_FILES"},
+	{discordgo.PermissionMentionEveryone, "MENTION_EVERYONE"},
+	{discordgo.PermissionReadMessageHistory, "READ_MESSAGE_HISTORY"},
+	{discordgo.PermissionAddReactions, "ADD_REACTIONS"},
+	{discordgo.PermissionUseExternalEmojis, "USE_EXTERNAL_EMOJIS"},
+	{discordgo.PermissionManageMessages, "MANAGE_MESSAGES"},
+	{discordgo.PermissionCreatePublicThreads, "CREATE_PUBLIC_THREADS"},
+	{discordgo.PermissionManageThreads, "MANAGE_THREADS"},
+	{discordgo.PermissionManageChannels, "MANAGE_CHANNELS"},
+	{discordgo.PermissionManageWebhooks, "MANAGE_WEBHOOKS"},
+	{discordgo.PermissionVoiceConnect, "CONNECT"},
+	{discordgo.PermissionVoiceSpeak, "SPEAK"},
+	{discordgo.PermissionVoiceMoveMembers, "MOVE_MEMBERS"},
+	{discordgo.PermissionManageRoles, "MANAGE_ROLES"},
+	{discordgo.PermissionManageGuild, "MANAGE_GUILD"},
+	{discordgo.PermissionManageNicknames, "MANAGE_NICKNAMES"},
+	{discordgo.PermissionManageGuildExpressions, "MANAGE_GUILD_EXPRESSIONS"},
+	{discordgo.PermissionManageEvents, "MANAGE_EVENTS"},
+	{discordgo.PermissionKickMembers, "KICK_MEMBERS"},
+	{discordgo.PermissionBanMembers, "BAN_MEMBERS"},
+	{discordgo.PermissionModerateMembers, "MODERATE_MEMBERS"},
+	{discordgo.PermissionViewAuditLogs, "VIEW_AUDIT_LOG"},
+	{discordgo.PermissionCreateInstantInvite, "CREATE_INSTANT_INVITE"},
+	{discordgo.PermissionVoicePrioritySpeaker, "PRIORITY_SPEAKER"},
+	{discordgo.PermissionVoiceStreamVideo, "STREAM"},
+	{discordgo.PermissionViewGuildInsights, "VIEW_GUILD_INSIGHTS"},
+	{discordgo.PermissionVoiceMuteMembers, "MUTE_MEMBERS"},
+	{discordgo.PermissionVoiceDeafenMembers, "DEAFEN_MEMBERS"},
+	{discordgo.PermissionVoiceUseVAD, "USE_VAD"},
+	{discordgo.PermissionChangeNickname, "CHANGE_NICKNAME"},
+	{discordgo.PermissionUseApplicationCommands, "USE_APPLICATION_COMMANDS"},
+	{discordgo.PermissionVoiceRequestToSpeak, "REQUEST_TO_SPEAK"},
+	{discordgo.PermissionCreatePrivateThreads, "CREATE_PRIVATE_THREADS"},
+	{discordgo.PermissionUseExternalStickers, "USE_EXTERNAL_STICKERS"},
+	{discordgo.PermissionSendMessagesInThreads, "SEND_MESSAGES_IN_THREADS"},
+	{discordgo.PermissionUseEmbeddedActivities, "USE_EMBEDDED_ACTIVITIES"},
+	{discordgo.PermissionViewCreatorMonetizationAnalytics, "VIEW_CREATOR_MONETIZATION_ANALYTICS"},
+	{discordgo.PermissionUseSoundboard, "USE_SOUNDBOARD"},
+	{discordgo.PermissionCreateGuildExpressions, "CREATE_GUILD_EXPRESSIONS"},
+	{discordgo.PermissionCreateEvents, "CREATE_EVENTS"},
+	{discordgo.PermissionUseExternalSounds, "USE_EXTERNAL_SOUNDS"},
+	{discordgo.PermissionSendVoiceMessages, "SEND_VOICE_MESSAGES"},
+	{discordgo.PermissionSendPolls, "SEND_POLLS"},
+	{discordgo.PermissionUseExternalApps, "USE_EXTERNAL_APPS"},
+}
+
+// PermissionNames returns the API names of the permissions set in bits
+func PermissionNames(bits int64) []string {
+	names := []string{}
+	for _, p := range permissionNames {
+		if bits&p.bit != 0 {
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
+
+// PermissionBits returns the permission bits for API names, reporting the
+// first name it does not know
+func PermissionBits(names []string) (int64, error) {
+	var bits int64
+	for _, name := range names {
+		found := false
+		for _, p := range permissionNames {
+			if strings.EqualFold(p.name, name) {
+				bits |= p.bit
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown permission %q", name)
+		}
+	}
+	return bits, nil
+}
+
+// KnownPermissions returns the bits PermissionNames can name
+func KnownPermissions() int64 {
+	var bits int64
+	for _, p := range permissionNames {
+		bits |= p.bit
+	}
+	return bits
+}
+
+// OperationNames returns the operations check_permissions understands
+func OperationNames() []string {
+	names := make([]string, 0, len(OperationRequirements))
+	for name := range OperationRequirements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RoleGrant is a role that would grant missing permissions
+type RoleGrant struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// OperationCheck is the preflight result for a single operation
+type OperationCheck struct {
+	Operation string   `json:"operation"`
+	Allowed   bool     `json:"allowed"`
+	Scope     string   `json:"scope"`
+	Required  []string `json:"required"`
+	Missing   []string `json:"missing,omitempty"`
+	Reason    string   `json:"reason,omitempty"`
+	// GrantingRoles are assignable roles that include every missing
+	// permission; channel overwrites may still deny them
+	GrantingRoles []RoleGrant `json:"granting_roles,omitempty"`
+}
+
+// Target optionally names the role or member an operation acts on, so
+// preflight checks can include the role hierarchy
+type Target struct {
+	RoleID string
+	UserID string
+}
+
+// Preflight checks which operations the bot can perform in a channel or
+// guild. Channel-scoped operations checked against a guild use the bot's
+// guild-wide permissions.
+func (c *Checker) Preflight(guildID, channelID string, operations []string, target Target) ([]OperationCheck, error) {
+	var channelPerms int64
+	channelDenied := ""
+	if channelID != "" {
+		channel, err := c.getChannelInfo(channelID)
+		if err != nil {
+			return nil, err
+		}
+		guildID = channel.GuildID
+
+		perms, err := c.getUserChannelPermissions(channelID)
+		if err != nil {
+			permErr, ok := err.(*PermissionError)
+			if !ok {
+				return nil, err
+			}
+			channelDenied = permErr.Description
+		}
+		channelPerms = perms
+	}
+
+	var guildPerms int64
+	var roles []*discordgo.Role
+	if guildID != "" {
+		if err := c.CanViewGuild(guildID); err != nil {
+			return nil, err
+		}
+		perms, err := c.getBotGuildPermissions(guildID)
+		if err != nil {
+			return nil, err
+		}
+		guildPerms = perms
+
+		if roles, err = c.discord.GetRoles(guildID); err != nil {
+			return nil, fmt.Errorf("failed to get role info: %w", err)
+		}
+	}
+
+	var h *hierarchy
+	checks := make([]OperationCheck, 0, len(operations))
+	for _, op := range operations {
+		req, ok := OperationRequirements[op]
+		if !ok {
+			return nil, fmt.Errorf("unknown operation %q", op)
+		}
+
+		check := OperationCheck{
+			Operation: op,
+			Scope:     req.Scope,
+			Required:  PermissionNames(req.Permissions),
+		}
+
+		have := guildPerms
+		if req.Scope == ScopeChannel && channelID != "" {
+			if channelDenied != "" {
+				check.Reason = channelDenied
+				checks = append(checks, check)
+				continue
+			}
+			have = channelPerms
+		}
+
+		missing := req.Permissions &^ have
+		if missing == 0 {
+			herr, err := c.preflightHierarchy(&h, guildID, op, target)
+			if err != nil {
+				return nil, err
+			}
+			if herr != nil {
+				check.Reason = herr.Description
+			} else {
+				check.Allowed = true
+			}
+			checks = append(checks, check)
+			continue
+		}
+
+		check.Missing = PermissionNames(missing)
+		if req.Scope == ScopeChannel && channelID != "" && guildPerms&missing == missing {
+			check.Reason = "A permission overwrite on this channel or its category denies the bot these permissions"
+		} else {
+			check.Reason = "None of the bot's roles grant these permissions"
+			check.GrantingRoles = grantingRoles(roles, guildID, missing)
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// preflightHierarchy checks an operation's target against the role
+// hierarchy, loading the hierarchy into h on first use. It returns the
+// permission error that blocks the operation, if any.
+func (c *Checker) preflightHierarchy(h **hierarchy, guildID, op string, target Target) (*PermissionError, error) {
+	_, moderation := moderationPermissions[op]
+	checkRole := op == "manage_roles" && target.RoleID != ""
+	checkMember := moderation && target.UserID != ""
+	if !checkRole && !checkMember {
+		return nil, nil
+	}
+
+	if *h == nil {
+		loaded, err := c.getHierarchy(guildID)
+		if err != nil {
+			return nil, err
+		}
+		*h = loaded
+	}
+
+	var err error
+	if checkRole {
+		err = (*h).checkRole(op, target.RoleID)
+	} else {
+		err = c.checkMember(*h, op, target.UserID)
+	}
+	if err == nil {
+		return nil, nil
+	}
+	if permErr, ok := err.(*PermissionError); ok {
+		return permErr, nil
+	}
+	return nil, err
+}
+
+// grantingRoles returns the roles that include every permission in missing.
+// @everyone and integration-managed roles cannot be assigned and are skipped.
+func grantingRoles(roles []*discordgo.Role, guildID string, missing int64) []RoleGrant {
+	var grants []RoleGrant
+	for _, role := range roles {
+		if role.ID == guildID || role.Managed {
+			continue
+		}
+		if role.Permissions&missing == missing || role.Permissions&discordgo.PermissionAdministrator != 0 {
+			grants = append(grants, RoleGrant{ID: role.ID, Name: role.Name})
+		}
+	}
+	return grants
+}
@@ -0,0 +1,235 @@
+// Package pinning holds per-channel pinning policies: rules that pin new
+// messages matching author roles or keywords, and unpin the oldest pins to
+// stay under a limit. Policies are persisted to disk.
+package pinning
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+// MaxPins is the most messages Discord lets a channel pin
+const MaxPins = 50
+
+// DefaultPinLimit is the pin count at which the oldest pins are removed,
+// leaving room below Discord's limit for manual pins
+const DefaultPinLimit = 45
+
+// Policy pins new messages in a channel that match its criteria. A message
+// matches when its author has one of RoleIDs and its content contains one
+// of Keywords; an empty list places no condition.
+type Policy struct {
+	GuildID   string   `json:"guild_id"`
+	ChannelID string   `json:"channel_id"`
+	RoleIDs   []string `json:"role_ids,omitempty"`
+	// Keywords are matched case-insensitively anywhere in the content
+	Keywords []string `json:"keywords,omitempty"`
+	// PinLimit is the most pins the policy lets the channel hold. Once it is
+	// reached, the oldest pins are removed first when AutoUnpin is set, and
+	// nothing more is pinned otherwise.
+	PinLimit  int       `json:"pin_limit"`
+	AutoUnpin bool      `json:"auto_unpin"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Pinned      int64      `json:"pinned_count"`
+	Unpinned    int64      `json:"unpinned_count"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// Matches reports whether a message with the given content, sent by a
+// member with the given roles, should be pinned
+func (p Policy) Matches(content string, roleIDs []string) bool {
+	if len(p.RoleIDs) > 0 && !hasAny(p.RoleIDs, roleIDs) {
+		return false
+	}
+	if len(p.Keywords) == 0 {
+		return true
+	}
+	content = strings.ToLower(content)
+	for _, keyword := range p.Keywords {
+		if strings.Contains(content, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAny reports whether the lists share an element
+func hasAny(want, have []string) bool {
+	for _, a := range want {
+		for _, b := range have {
+			if a == b {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// storedPolicies is the layout of the persistence file
+type storedPolicies struct {
+	Policies []*Policy `json:"policies"`
+}
+
+// Store holds pinning policies by channel and saves them to a JSON file on
+// every change
+type Store struct {
+	path string
+
+	policies map[string]*Policy
+	mutex    sync.Mutex
+}
+
+// NewStore creates a store, loading the policies saved at path
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:     path,
+		policies: make(map[string]*Policy),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load pinning policies from %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// SetPolicy validates and saves a channel's policy, replacing any previous
+// one, and reports whether it was newly created. Counts carry over from the
+// policy it replaces.
+func (s *Store) SetPolicy(policy Policy) (Policy, bool, error) {
+	if policy.GuildID == "" || policy.ChannelID == "" {
+		return Policy{}, false, fmt.Errorf("guild and channel are required")
+	}
+	if len(policy.RoleIDs) == 0 && len(policy.Keywords) == 0 {
+		return Policy{}, false, fmt.Errorf("role_ids or keywords are required")
+	}
+	for _, keyword := range policy.Keywords {
+		if strings.TrimSpace(keyword) == "" {
+			return Policy{}, false, fmt.Errorf("keywords must not be empty")
+		}
+	}
+	if policy.PinLimit < 1 || policy.PinLimit > MaxPins {
+		return Policy{}, false, fmt.Errorf("pin_limit must be between 1 and %d", MaxPins)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, exists := s.policies[policy.ChannelID]
+	if exists {
+		policy.Pinned = previous.Pinned
+		policy.Unpinned = previous.Unpinned
+	}
+	policy.LastError = ""
+	policy.LastErrorAt = nil
+	policy.UpdatedAt = time.Now().UTC()
+	s.policies[policy.ChannelID] = &policy
+	if err := s.save(); err != nil {
+		if exists {
+			s.policies[policy.ChannelID] = previous
+		} else {
+			delete(s.policies, policy.ChannelID)
+		}
+		return Policy{}, false, err
+	}
+	return policy, !exists, nil
+}
+
+// Policy returns a channel's policy
+func (s *Store) Policy(channelID string) (Policy, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	policy, ok := s.policies[channelID]
+	if !ok {
+		return Policy{}, false
+	}
+	return *policy, true
+}
+
+// List returns the policies of a guild, or of every guild when guildID is
+// empty, ordered by channel
+func (s *Store) List(guildID string) []Policy {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	policies := make([]Policy, 0)
+	for _, policy := range s.policies {
+		if guildID == "" || policy.GuildID == guildID {
+			policies = append(policies, *policy)
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].ChannelID < policies[j].ChannelID
+	})
+	return policies
+}
+
+// Remove deletes a channel's policy, reporting whether it existed
+func (s *Store) Remove(channelID string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, ok := s.policies[channelID]
+	if !ok {
+		return false, nil
+	}
+	delete(s.policies, channelID)
+	if err := s.save(); err != nil {
+		s.policies[channelID] = previous
+		return false, err
+	}
+	return true, nil
+}
+
+// Record adds the pins and unpins a policy made and remembers its most
+// recent error, if any
+func (s *Store) Record(channelID string, pinned, unpinned int, runErr error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	policy, ok := s.policies[channelID]
+	if !ok {
+		return nil
+	}
+	policy.Pinned += int64(pinned)
+	policy.Unpinned += int64(unpinned)
+	if runErr != nil {
+		now := time.Now().UTC()
+		policy.LastError = runErr.Error()
+		policy.LastErrorAt = &now
+	}
+	return s.save()
+}
+
+// save writes every policy to the persistence file; callers must hold the
+// lock
+func (s *Store) save() error {
+	stored := storedPolicies{Policies: make([]*Policy, 0, len(s.policies))}
+	for _, policy := range s.policies {
+		stored.Policies = append(stored.Policies, policy)
+	}
+	sort.Slice(stored.Policies, func(i, j int) bool {
+		return stored.Policies[i].ChannelID < stored.Policies[j].ChannelID
+	})
+
+	return jsonfile.Save(s.path, stored)
+}
+
+// load reads saved policies
+func (s *Store) load() error {
+	var stored storedPolicies
+	if found, err := jsonfile.Load(s.path, &stored); err != nil || !found {
+		return err
+	}
+	for _, policy := range stored.Policies {
+		s.policies[policy.ChannelID] = policy
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+// Package plugins runs tools provided by external programs declared in the
+// configuration, so the tool set can grow without changing the server.
+//
+// Each call starts the plugin program with a JSON request on stdin:
+//
+//	{"tool": "get_weather", "arguments": {"city": "Berlin"}}
+//
+// The program writes its result to stdout, either as an MCP tool result
+// ({"content": [...], "isError": false}) or as plain text, and exits with
+// status 0. A non-zero exit fails the call with the program's stderr.
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/config"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// defaultTimeout bounds calls of plugins that set no timeout
+const defaultTimeout = 30 * time.Second
+
+// maxOutputBytes bounds the result a plugin may write
+const maxOutputBytes = 4 * 1024 * 1024
+
+// Request is what a plugin program reads from stdin
+type Request struct {
+	Tool      string                 `json:"tool"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// Tool is a tool provided by a plugin program
+type Tool struct {
+	plugin     config.PluginConfig
+	definition types.Tool
+	schema     *validation.Schema
+	logger     *logrus.Logger
+}
+
+// Load creates the tools of every configured plugin, compiling their input
+// schemas
+func Load(plugins []config.PluginConfig, logger *logrus.Logger) ([]*Tool, error) {
+	var tools []*Tool
+	for _, plugin := range plugins {
+		for _, decl := range plugin.Tools {
+			schema := decl.InputSchema
+			if schema == nil {
+				schema = map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{},
+				}
+			}
+			compiled, err := validation.Compile(schema)
+			if err != nil {
+				return nil, fmt.Errorf("plugin %s: input schema of tool %s: %w", plugin.Name, decl.Name, err)
+			}
+			tools = append(tools, &Tool{
+				plugin: plugin,
+				definition: types.Tool{
+					Name:        decl.Name,
+					Description: decl.Description,
+					InputSchema: schema,
+				},
+				schema: compiled,
+				logger: logger,
+			})
+		}
+	}
+	return tools, nil
+}
+
+// GetDefinition returns the tool definition declared in the configuration
+func (t *Tool) GetDefinition() types.Tool {
+	return t.definition
+}
+
+// Plugin returns the name of the plugin providing the tool
+func (t *Tool) Plugin() string {
+	return t.plugin.Name
+}
+
+// Execute validates the arguments against the declared schema and runs the
+// plugin program
+func (t *Tool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	// Validate parameters
+	arguments := params.Arguments
+	if arguments == nil {
+		arguments = map[string]interface{}{}
+	}
+	t.schema.ApplyDefaults(arguments)
+	if err := t.schema.Validate("", arguments); err != nil {
+		return validation.FormatValidationError(err), nil
+	}
+
+	timeout := defaultTimeout
+	if t.plugin.TimeoutSeconds > 0 {
+		timeout = time.Duration(t.plugin.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	output, err := t.run(ctx, Request{Tool: t.definition.Name, Arguments: arguments})
+	if ctx.Err() == context.DeadlineExceeded {
+		return types.CallToolResult{}, fmt.Errorf("plugin %s timed out after %s", t.plugin.Name, timeout)
+	}
+	if err != nil {
+		return types.CallToolResult{}, err
+	}
+	return parseResult(output), nil
+}
+
+// run starts the plugin program and returns what it wrote to stdout
+func (t *Tool) run(ctx context.Context, req Request) ([]byte, error) {
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, t.plugin.Command[0], t.plugin.Command[1:]...)
+	cmd.Dir = t.plugin.Dir
+	cmd.Env = os.Environ()
+	for name, value := range t.plugin.Env {
+		cmd.Env = append(cmd.Env, name+"="+value)
+	}
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &limitedBuffer{buf: &stdout, limit: maxOutputBytes}
+	cmd.Stderr = &limitedBuffer{buf: &stderr, limit: maxOutputBytes}
+
+	t.logger.WithFields(logrus.Fields{"plugin": t.plugin.Name, "tool": req.Tool}).Debug("Running plugin")
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("plugin %s failed: %v %s", t.plugin.Name, err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() > maxOutputBytes {
+		return nil, fmt.Errorf("plugin %s: result exceeds %d bytes", t.plugin.Name, maxOutputBytes)
+	}
+	return stdout.Bytes(), nil
+}
+
+// parseResult reads a plugin's output as an MCP tool result, or as text
+// when it is not one
+func parseResult(output []byte) types.CallToolResult {
+	trimmed := bytes.TrimSpace(output)
+	var result types.CallToolResult
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		var probe map[string]json.RawMessage
+		if json.Unmarshal(trimmed, &probe) == nil && probe["content"] != nil && json.Unmarshal(trimmed, &result) == nil {
+			return result
+		}
+	}
+	return types.CallToolResult{
+		Content: []types.Content{{Type: "text", Text: string(trimmed)}},
+	}
+}
+
+// limitedBuffer keeps the first limit+1 bytes written and discards the
+// rest, so a runaway plugin cannot exhaust memory while Run still sees
+// every write succeed
+type limitedBuffer struct {
+	buf   *bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit + 1 - b.buf.Len(); room > 0 {
+		if len(p) > room {
+			b.buf.Write(p[:room])
+		} else {
+			b.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
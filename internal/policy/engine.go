@@ -0,0 +1,224 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/config"
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/i18n"
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// Policy actions
+const (
+	ActionAllow   = "allow"
+	ActionDeny    = "deny"
+	ActionConfirm = "confirm"
+)
+
+// ConfirmParam is the tool argument used to confirm a call that a policy
+// marked as requiring confirmation. It is stripped before the tool runs.
+const ConfirmParam = "confirm"
+
+// Decision is the outcome of evaluating a tool call against the policy rules
+type Decision struct {
+	Action string `json:"action"`
+	Rule   string `json:"rule,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Engine evaluates tool calls against the configured policy rules
+type Engine struct {
+	config  config.PolicyConfig
+	discord *discord.Client
+	logger  *logrus.Logger
+}
+
+// NewEngine creates a new policy engine
+func NewEngine(cfg config.PolicyConfig, discordClient *discord.Client, logger *logrus.Logger) *Engine {
+	return &Engine{
+		config:  cfg,
+		discord: discordClient,
+		logger:  logger,
+	}
+}
+
+// Evaluate returns the decision of the first rule matching the call, or the
+// default action if none match
+func (e *Engine) Evaluate(toolName string, args map[string]interface{}) Decision {
+	if !e.config.Enabled {
+		return Decision{Action: ActionAllow}
+	}
+
+	target := e.resolveTarget(args)
+	for i, rule := range e.config.Rules {
+		if !e.matches(rule, toolName, target) {
+			continue
+		}
+
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("rule %d", i+1)
+		}
+		e.logger.Debugf("Policy %s matched %s: %s", name, toolName, rule.Action)
+		return Decision{Action: rule.Action, Rule: name, Reason: rule.Reason}
+	}
+
+	return Decision{Action: e.defaultAction()}
+}
+
+// Enforce evaluates a tool call and returns a result to send instead of
+// executing the tool when the call is blocked. A confirmed call has its
+// confirm argument removed so the tool's own validation accepts it.
+func (e *Engine) Enforce(params *types.CallToolParams) (types.CallToolResult, bool) {
+	confirmed, _ := params.Arguments[ConfirmParam].(bool)
+	delete(params.Arguments, ConfirmParam)
+
+	decision := e.Evaluate(params.Name, params.Arguments)
+	switch decision.Action {
+	case ActionAllow:
+		return types.CallToolResult{}, false
+	case ActionConfirm:
+		if confirmed {
+			e.logger.Infof("Policy %s: %s confirmed by caller", decision.Rule, params.Name)
+			return types.CallToolResult{}, false
+		}
+		return formatDecision(params.Name, decision,
+			i18n.T(i18n.Locale(*params), "policy.confirm", params.Name, describe(decision))), true
+	default:
+		e.logger.Warnf("Policy %s denied %s", decision.Rule, params.Name)
+		return formatDecision(params.Name, decision,
+			i18n.T(i18n.Locale(*params), "policy.denied", params.Name, describe(decision))), true
+	}
+}
+
+// target holds the resources a tool call operates on
+type target struct {
+	guildID   string
+	channelID string
+	roleID    string
+}
+
+// resolveTarget extracts the guild, channel and role a call targets. The
+// guild is derived from the channel when it is not given explicitly.
+func (e *Engine) resolveTarget(args map[string]interface{}) target {
+	t := target{}
+	t.guildID, _ = args["guild_id"].(string)
+	t.channelID, _ = args["channel_id"].(string)
+	t.roleID, _ = args["role_id"].(string)
+
+	// Links are expanded by the validator after policies run, so read the
+	// IDs from them here as well
+	for _, name := range []string{"channel_id", "message_id"} {
+		value, _ := args[name].(string)
+		if guildID, channelID, _, ok := validation.ParseDiscordURL(value); ok {
+			if name == "channel_id" || t.channelID == "" {
+				t.channelID = channelID
+			}
+			if t.guildID == "" {
+				t.guildID = guildID
+			}
+		}
+	}
+
+	if t.guildID == "" && t.channelID != "" {
+		if channel, err := e.discord.GetChannel(t.channelID); err == nil {
+			t.guildID = channel.GuildID
+		}
+	}
+	if t.guildID == "" {
+		t.guildID = e.discord.Config().Discord.DefaultGuildID
+	}
+	return t
+}
+
+// matches reports whether every criterion set on the rule matches the call
+func (e *Engine) matches(rule config.PolicyRule, toolName string, t target) bool {
+	if len(rule.Tools) > 0 && !matchAny(rule.Tools, toolName) {
+		return false
+	}
+	if len(rule.Guilds) > 0 && !matchAny(rule.Guilds, t.guildID) {
+		return false
+	}
+	if len(rule.Channels) > 0 && !matchAny(rule.Channels, t.channelID) {
+		return false
+	}
+	if len(rule.Roles) > 0 && !matchAny(rule.Roles, t.roleID) {
+		return false
+	}
+	if rule.RolePositionAbove != nil {
+		position, ok := e.rolePosition(t.guildID, t.roleID)
+		if !ok || position <= *rule.RolePositionAbove {
+			return false
+		}
+	}
+	return true
+}
+
+// rolePosition looks up the position of a role in the role hierarchy
+func (e *Engine) rolePosition(guildID, roleID string) (int, bool) {
+	if guildID == "" || roleID == "" {
+		return 0, false
+	}
+
+	roles, err := e.discord.GetRoles(guildID)
+	if err != nil {
+		e.logger.Warnf("Policy could not look up roles for guild %s: %v", guildID, err)
+		return 0, false
+	}
+
+	for _, role := range roles {
+		if role.ID == roleID {
+			return role.Position, true
+		}
+	}
+	return 0, false
+}
+
+func (e *Engine) defaultAction() string {
+	if e.config.DefaultAction == "" {
+		return ActionAllow
+	}
+	return e.config.DefaultAction
+}
+
+// formatDecision creates the tool result for a blocked call
+func formatDecision(toolName string, decision Decision, text string) types.CallToolResult {
+	return types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: text,
+			Data: map[string]interface{}{
+				"error_type":            "policy",
+				"tool":                  toolName,
+				"action":                decision.Action,
+				"rule":                  decision.Rule,
+				"reason":                decision.Reason,
+				"requires_confirmation": decision.Action == ActionConfirm,
+			},
+		}},
+		IsError: true,
+	}
+}
+
+func describe(decision Decision) string {
+	if decision.Rule == "" {
+		return "default policy"
+	}
+	if decision.Reason == "" {
+		return decision.Rule
+	}
+	return fmt.Sprintf("%s: %s", decision.Rule, decision.Reason)
+}
+
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == value {
+			return true
+		}
+	}
+	return false
+}
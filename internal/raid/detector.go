@@ -0,0 +1,294 @@
+// Package raid detects join and message floods in guilds with raid
+// protection armed and records the resulting incidents.
+package raid
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MaxIncidents bounds the incidents kept; the oldest are forgotten first
+const MaxIncidents = 100
+
+// Incident kinds
+const (
+	KindJoinRate    = "join_rate"
+	KindMessageRate = "message_rate"
+)
+
+// Action types
+const (
+	ActionSlowmode     = "slowmode"
+	ActionPauseInvites = "pause_invites"
+)
+
+// Settings are the thresholds and responses of an armed guild
+type Settings struct {
+	JoinThreshold        int  `json:"join_threshold"`
+	JoinWindowSeconds    int  `json:"join_window_seconds"`
+	MessageThreshold     int  `json:"message_threshold"`
+	MessageWindowSeconds int  `json:"message_window_seconds"`
+	SlowmodeSeconds      int  `json:"slowmode_seconds"`
+	PauseInvites         bool `json:"pause_invites"`
+	CooldownSeconds      int  `json:"cooldown_seconds"`
+}
+
+// Validate checks that the settings are usable
+func (s Settings) Validate() error {
+	switch {
+	case s.JoinThreshold < 2:
+		return fmt.Errorf("join threshold must be at least 2")
+	case s.MessageThreshold < 2:
+		return fmt.Errorf("message threshold must be at least 2")
+	case s.JoinWindowSeconds < 1 || s.MessageWindowSeconds < 1:
+		return fmt.Errorf("windows must be at least 1 second")
+	case s.SlowmodeSeconds < 0 || s.SlowmodeSeconds > 21600:
+		return fmt.Errorf("slowmode must be between 0 and 21600 seconds")
+	case s.CooldownSeconds < 0:
+		return fmt.Errorf("cooldown must not be negative")
+	}
+	return nil
+}
+
+// Protection is an armed guild
+type Protection struct {
+	GuildID  string    `json:"guild_id"`
+	Settings Settings  `json:"settings"`
+	ArmedAt  time.Time `json:"armed_at"`
+}
+
+// Action is a response taken to an incident. Previous holds the channel's
+// slowmode before it was raised so it can be restored.
+type Action struct {
+	Type      string `json:"type"`
+	ChannelID string `json:"channel_id,omitempty"`
+	Previous  int    `json:"previous,omitempty"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+	Reverted  bool   `json:"reverted,omitempty"`
+}
+
+// Incident is a suspected raid
+type Incident struct {
+	ID            string    `json:"id"`
+	GuildID       string    `json:"guild_id"`
+	Kind          string    `json:"kind"`
+	ChannelID     string    `json:"channel_id,omitempty"`
+	Count         int       `json:"count"`
+	WindowSeconds int       `json:"window_seconds"`
+	DetectedAt    time.Time `json:"detected_at"`
+	Actions       []Action  `json:"actions"`
+}
+
+// guard tracks recent activity of an armed guild
+type guard struct {
+	protection Protection
+	joins      []time.Time
+	messages   map[string][]time.Time
+	// lastIncident maps a kind and channel to the last incident time
+	lastIncident map[string]time.Time
+}
+
+// Detector counts joins and messages in armed guilds and raises an incident
+// when a rate crosses its threshold
+type Detector struct {
+	defaults  Settings
+	guards    map[string]*guard
+	incidents []*Incident
+	nextID    int
+	mutex     sync.Mutex
+}
+
+// NewDetector creates a detector whose armed guilds use defaults unless
+// given their own settings
+func NewDetector(defaults Settings) *Detector {
+	return &Detector{
+		defaults: defaults,
+		guards:   make(map[string]*guard),
+	}
+}
+
+// Defaults returns the settings used when arming without overrides
+func (d *Detector) Defaults() Settings {
+	return d.defaults
+}
+
+// Arm starts protecting a guild, replacing its settings if already armed
+func (d *Detector) Arm(guildID string, settings Settings) (Protection, error) {
+	if err := settings.Validate(); err != nil {
+		return Protection{}, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	protection := Protection{GuildID: guildID, Settings: settings, ArmedAt: time.Now().UTC()}
+	d.guards[guildID] = &guard{
+		protection:   protection,
+		messages:     make(map[string][]time.Time),
+		lastIncident: make(map[string]time.Time),
+	}
+	return protection, nil
+}
+
+// Disarm stops protecting a guild, reporting whether it was armed
+func (d *Detector) Disarm(guildID string) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if _, ok := d.guards[guildID]; !ok {
+		return false
+	}
+	delete(d.guards, guildID)
+	return true
+}
+
+// Protections returns the armed guilds ordered by ID
+func (d *Detector) Protections() []Protection {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	protections := make([]Protection, 0, len(d.guards))
+	for _, g := range d.guards {
+		protections = append(protections, g.protection)
+	}
+	sort.Slice(protections, func(i, j int) bool {
+		return protections[i].GuildID < protections[j].GuildID
+	})
+	return protections
+}
+
+// RecordJoin counts a member join. It returns an incident if the join rate
+// crossed the threshold, or nil, together with the guild's settings.
+func (d *Detector) RecordJoin(guildID string, now time.Time) (*Incident, Settings) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	g, ok := d.guards[guildID]
+	if !ok {
+		return nil, Settings{}
+	}
+	settings := g.protection.Settings
+
+	var count int
+	g.joins, count = record(g.joins, now, settings.JoinThreshold, settings.JoinWindowSeconds)
+	if count < settings.JoinThreshold {
+		return nil, settings
+	}
+	return d.raise(g, KindJoinRate, "", count, settings.JoinWindowSeconds, now), settings
+}
+
+// RecordMessage counts a message. It returns an incident if the channel's
+// message rate crossed the threshold, or nil, together with the guild's
+// settings.
+func (d *Detector) RecordMessage(guildID, channelID string, now time.Time) (*Incident, Settings) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	g, ok := d.guards[guildID]
+	if !ok {
+		return nil, Settings{}
+	}
+	settings := g.protection.Settings
+
+	var count int
+	g.messages[channelID], count = record(g.messages[channelID], now, settings.MessageThreshold, settings.MessageWindowSeconds)
+	if count < settings.MessageThreshold {
+		return nil, settings
+	}
+	return d.raise(g, KindMessageRate, channelID, count, settings.MessageWindowSeconds, now), settings
+}
+
+// SetActions stores the responses taken to an incident
+func (d *Detector) SetActions(id string, actions []Action) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, incident := range d.incidents {
+		if incident.ID == id {
+			incident.Actions = actions
+			return
+		}
+	}
+}
+
+// MarkReverted flags an incident's action as undone
+func (d *Detector) MarkReverted(id string, index int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	for _, incident := range d.incidents {
+		if incident.ID == id && index < len(incident.Actions) {
+			incident.Actions[index].Reverted = true
+			return
+		}
+	}
+}
+
+// Incidents returns a guild's incidents, or every incident when guildID is
+// empty, newest first
+func (d *Detector) Incidents(guildID string) []Incident {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	incidents := make([]Incident, 0)
+	for i := len(d.incidents) - 1; i >= 0; i-- {
+		incident := d.incidents[i]
+		if guildID == "" || incident.GuildID == guildID {
+			copied := *incident
+			copied.Actions = append([]Action(nil), incident.Actions...)
+			incidents = append(incidents, copied)
+		}
+	}
+	return incidents
+}
+
+// raise records an incident unless the same kind and channel raised one
+// within the cooldown, in which case it returns nil; callers must hold the
+// lock
+func (d *Detector) raise(g *guard, kind, channelID string, count, window int, now time.Time) *Incident {
+	key := kind + "/" + channelID
+	cooldown := time.Duration(g.protection.Settings.CooldownSeconds) * time.Second
+	if last, ok := g.lastIncident[key]; ok && now.Sub(last) < cooldown {
+		return nil
+	}
+	g.lastIncident[key] = now
+
+	d.nextID++
+	incident := &Incident{
+		ID:            fmt.Sprintf("ri%d", d.nextID),
+		GuildID:       g.protection.GuildID,
+		Kind:          kind,
+		ChannelID:     channelID,
+		Count:         count,
+		WindowSeconds: window,
+		DetectedAt:    now.UTC(),
+		Actions:       []Action{},
+	}
+	d.incidents = append(d.incidents, incident)
+	if len(d.incidents) > MaxIncidents {
+		d.incidents = d.incidents[len(d.incidents)-MaxIncidents:]
+	}
+
+	copied := *incident
+	return &copied
+}
+
+// record appends an event, keeps at most threshold of the most recent ones
+// and returns them with the number inside the window
+func record(times []time.Time, now time.Time, threshold, windowSeconds int) ([]time.Time, int) {
+	times = append(times, now)
+	if len(times) > threshold {
+		times = times[len(times)-threshold:]
+	}
+	cutoff := now.Add(-time.Duration(windowSeconds) * time.Second)
+	count := 0
+	for _, t := range times {
+		if t.After(cutoff) {
+			count++
+		}
+	}
+	return times, count
+}
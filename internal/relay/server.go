@@ -0,0 +1,185 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// PostFunc posts a payload received by a relay to Discord
+type PostFunc func(relay Relay, values map[string]string, payload []byte) error
+
+// eventHeaders name the event of a payload for common senders
+var eventHeaders = []string{"X-GitHub-Event", "X-Gitlab-Event", "X-Event-Key", "X-Event"}
+
+// Server accepts payloads at /relay/{token} and hands them to a PostFunc
+type Server struct {
+	store   *Store
+	post    PostFunc
+	maxBody int64
+	logger  *logrus.Logger
+
+	server *http.Server
+}
+
+// NewServer creates a relay server listening on addr
+func NewServer(addr string, maxBody int64, store *Store, post PostFunc, logger *logrus.Logger) *Server {
+	s := &Server{
+		store:   store,
+		post:    post,
+		maxBody: maxBody,
+		logger:  logger,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/relay/{token}", s.handle)
+	s.server = &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return s
+}
+
+// Start opens the listener and serves in the background
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for relays on %s: %w", s.server.Addr, err)
+	}
+	s.logger.Infof("Relay endpoint listening on %s", listener.Addr())
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Errorf("Relay endpoint stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Close stops accepting payloads, letting requests in flight finish
+func (s *Server) Close() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.logger.Warnf("Failed to stop relay endpoint: %v", err)
+	}
+}
+
+// handle posts one payload to the relay its token belongs to
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeStatus(w, http.StatusMethodNotAllowed, "use POST")
+		return
+	}
+	relay, ok := s.store.ByToken(r.PathValue("token"))
+	if !ok {
+		writeStatus(w, http.StatusNotFound, "unknown relay")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxBody))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeStatus(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("payload larger than %d bytes", s.maxBody))
+			return
+		}
+		writeStatus(w, http.StatusBadRequest, "failed to read payload")
+		return
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var payload interface{}
+	if err := decoder.Decode(&payload); err != nil {
+		writeStatus(w, http.StatusBadRequest, "payload is not valid JSON")
+		return
+	}
+
+	values := Flatten(payload)
+	values["relay_name"] = relay.Name
+	for _, header := range eventHeaders {
+		if event := r.Header.Get(header); event != "" {
+			values["event"] = event
+			break
+		}
+	}
+
+	postErr := s.post(relay, values, body)
+	if err := s.store.Record(relay.ID, time.Now(), postErr); err != nil {
+		s.logger.Warnf("Failed to save relay %s: %v", relay.ID, err)
+	}
+	if postErr != nil {
+		s.logger.Warnf("Relay %s failed to post payload: %v", relay.ID, postErr)
+		writeStatus(w, http.StatusBadGateway, "failed to post to Discord")
+		return
+	}
+	writeStatus(w, http.StatusAccepted, "")
+}
+
+// writeStatus answers with a small JSON body
+func writeStatus(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	response := map[string]interface{}{"ok": status < 300}
+	if message != "" {
+		response["error"] = message
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// unsafeKeyChars matches what template placeholders cannot contain
+var unsafeKeyChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// Flatten turns a JSON payload into template values: nested keys are joined
+// with underscores and array elements are numbered from 0, so
+// {"repository": {"full_name": "a/b"}} becomes repository_full_name and
+// {"alerts": [{"status": "firing"}]} becomes alerts_0_status. The number of
+// elements of each array is added as <key>_count.
+func Flatten(payload interface{}) map[string]string {
+	values := make(map[string]string)
+	flatten(values, "", payload)
+	return values
+}
+
+// flatten adds the values under prefix
+func flatten(values map[string]string, prefix string, value interface{}) {
+	join := func(key string) string {
+		key = unsafeKeyChars.ReplaceAllString(key, "_")
+		if prefix == "" {
+			return key
+		}
+		return prefix + "_" + key
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			flatten(values, join(key), child)
+		}
+	case []interface{}:
+		if prefix != "" {
+			values[prefix+"_count"] = fmt.Sprint(len(v))
+		}
+		for i, child := range v {
+			flatten(values, join(fmt.Sprint(i)), child)
+		}
+	case nil:
+		if prefix != "" {
+			values[prefix] = ""
+		}
+	default:
+		if prefix != "" {
+			values[prefix] = strings.TrimSpace(fmt.Sprint(v))
+		}
+	}
+}
@@ -0,0 +1,201 @@
+// Package relay turns JSON payloads posted by other services, such as
+// GitHub or Grafana webhooks, into Discord messages. Each relay maps a
+// secret URL token to a channel and a template; relays are persisted to
+// disk.
+package relay
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+// Relay posts the payloads sent to its token to a channel
+type Relay struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	GuildID   string `json:"guild_id"`
+	ChannelID string `json:"channel_id"`
+	// Token is the secret last path segment of the relay URL. It is only
+	// returned when the relay is created.
+	Token string `json:"token,omitempty"`
+
+	// Template names a template from the templates directory; Content is
+	// an inline template used instead. Without either the payload is
+	// posted as JSON.
+	Template string `json:"template,omitempty"`
+	Content  string `json:"content,omitempty"`
+
+	CreatedAt      time.Time  `json:"created_at"`
+	Received       int        `json:"received"`
+	Failed         int        `json:"failed"`
+	LastReceivedAt *time.Time `json:"last_received_at,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+}
+
+// Redacted returns a copy of the relay without its token
+func (r Relay) Redacted() Relay {
+	r.Token = ""
+	return r
+}
+
+// storedRelays is the layout of the persistence file
+type storedRelays struct {
+	NextID int      `json:"next_id"`
+	Relays []*Relay `json:"relays"`
+}
+
+// Store holds relays by ID and saves them to a JSON file on every change
+type Store struct {
+	path string
+
+	relays map[string]*Relay
+	nextID int
+	mutex  sync.Mutex
+}
+
+// NewStore creates a store, loading the relays saved at path
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:   path,
+		relays: make(map[string]*Relay),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load relays from %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Add saves a new relay, assigning its ID and a random token
+func (s *Store) Add(relay Relay) (Relay, error) {
+	token, err := newToken()
+	if err != nil {
+		return Relay{}, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.nextID++
+	relay.ID = fmt.Sprintf("rl%d", s.nextID)
+	relay.Token = token
+	relay.CreatedAt = time.Now().UTC()
+	s.relays[relay.ID] = &relay
+	if err := s.save(); err != nil {
+		delete(s.relays, relay.ID)
+		s.nextID--
+		return Relay{}, err
+	}
+	return relay, nil
+}
+
+// ByToken returns the relay a URL token belongs to
+func (s *Store) ByToken(token string) (Relay, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, relay := range s.relays {
+		if subtle.ConstantTimeCompare([]byte(relay.Token), []byte(token)) == 1 {
+			return *relay, true
+		}
+	}
+	return Relay{}, false
+}
+
+// List returns the relays posting to a guild, or every relay when guildID
+// is empty, newest first
+func (s *Store) List(guildID string) []Relay {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	relays := make([]Relay, 0)
+	for _, relay := range s.relays {
+		if guildID == "" || relay.GuildID == guildID {
+			relays = append(relays, *relay)
+		}
+	}
+	sort.Slice(relays, func(i, j int) bool {
+		return relays[i].CreatedAt.After(relays[j].CreatedAt)
+	})
+	return relays
+}
+
+// Record counts a payload received by a relay and whether posting it
+// failed
+func (s *Store) Record(relayID string, at time.Time, postErr error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	relay, ok := s.relays[relayID]
+	if !ok {
+		return nil
+	}
+	relay.Received++
+	at = at.UTC()
+	relay.LastReceivedAt = &at
+	relay.LastError = ""
+	if postErr != nil {
+		relay.Failed++
+		relay.LastError = postErr.Error()
+	}
+	return s.save()
+}
+
+// Remove deletes a relay, returning it and whether it existed
+func (s *Store) Remove(relayID string) (Relay, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	relay, ok := s.relays[relayID]
+	if !ok {
+		return Relay{}, false, nil
+	}
+	delete(s.relays, relayID)
+	if err := s.save(); err != nil {
+		s.relays[relayID] = relay
+		return Relay{}, false, err
+	}
+	return *relay, true, nil
+}
+
+// newToken returns a random URL token
+func newToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate relay token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// save writes every relay to the persistence file; callers must hold the
+// lock
+func (s *Store) save() error {
+	stored := storedRelays{NextID: s.nextID, Relays: make([]*Relay, 0, len(s.relays))}
+	for _, relay := range s.relays {
+		stored.Relays = append(stored.Relays, relay)
+	}
+	sort.Slice(stored.Relays, func(i, j int) bool {
+		return stored.Relays[i].CreatedAt.Before(stored.Relays[j].CreatedAt)
+	})
+
+	return jsonfile.Save(s.path, stored)
+}
+
+// load reads saved relays
+func (s *Store) load() error {
+	var stored storedRelays
+	if found, err := jsonfile.Load(s.path, &stored); err != nil || !found {
+		return err
+	}
+	s.nextID = stored.NextID
+	for _, relay := range stored.Relays {
+		s.relays[relay.ID] = relay
+	}
+	return nil
+}
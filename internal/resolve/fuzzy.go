@@ -0,0 +1,82 @@
+package resolve
+
+import (
+	"strings"
+	"unicode"
+)
+
+// normalize lowercases a name and drops everything but letters and digits,
+// so "📢-Announcements" and "announcements" compare equal
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Score rates how well a candidate name matches a query, from 0 (no match)
+// to 1 (exact match)
+func Score(query, candidate string) float64 {
+	if strings.EqualFold(query, candidate) {
+		return 1
+	}
+
+	q, c := normalize(query), normalize(candidate)
+	switch {
+	case q == "" || c == "":
+		return 0
+	case q == c:
+		return 0.95
+	case strings.HasPrefix(c, q):
+		return 0.8 + 0.1*float64(len(q))/float64(len(c))
+	case strings.Contains(c, q):
+		return 0.6 + 0.1*float64(len(q))/float64(len(c))
+	}
+
+	// Typos: similarity from edit distance
+	longest := len([]rune(q))
+	if n := len([]rune(c)); n > longest {
+		longest = n
+	}
+	similarity := 1 - float64(levenshtein(q, c))/float64(longest)
+	if similarity < 0.5 {
+		return 0
+	}
+	return similarity * 0.7
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
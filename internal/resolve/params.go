@@ -0,0 +1,150 @@
+package resolve
+
+import (
+	"fmt"
+	"strings"
+
+	"discord-mcp/internal/validation"
+	"discord-mcp/pkg/types"
+)
+
+// exactScore is the minimum score for a name used in place of an ID; fuzzy
+// matches are only suggested, never acted on
+const exactScore = 0.95
+
+// NameParams are the ID parameters that accept names
+var NameParams = []string{"channel_id", "role_id", "user_id"}
+
+// NameError reports a name parameter that did not resolve to exactly one entity
+type NameError struct {
+	Param      string
+	Name       string
+	Candidates []Candidate
+}
+
+// Error implements the error interface
+func (e *NameError) Error() string {
+	if len(e.Candidates) == 0 {
+		return fmt.Sprintf("%s: nothing is named %q", e.Param, e.Name)
+	}
+
+	options := make([]string, len(e.Candidates))
+	for i, c := range e.Candidates {
+		options[i] = fmt.Sprintf("%s (%s)", c.Name, c.ID)
+	}
+	if e.Candidates[0].Score >= exactScore {
+		return fmt.Sprintf("%s: %q is ambiguous, use one of the IDs: %s", e.Param, e.Name, strings.Join(options, ", "))
+	}
+	return fmt.Sprintf("%s: nothing is named %q; did you mean %s?", e.Param, e.Name, strings.Join(options, ", "))
+}
+
+// FormatNameError creates a validation error response listing the candidates
+func FormatNameError(err *NameError) types.CallToolResult {
+	errType := "unknown name"
+	if len(err.Candidates) > 0 && err.Candidates[0].Score >= exactScore {
+		errType = "ambiguous name"
+	}
+
+	result := validation.FormatValidationError(validation.NewValidationError(errType, err.Error(), err.Param))
+	if data, ok := result.Content[0].Data.(map[string]interface{}); ok {
+		data["candidates"] = err.Candidates
+	}
+	return result
+}
+
+// ExpandNames replaces channel, role and user names in tool arguments with
+// their IDs. The guild comes from guild_id, the resolved channel, or the
+// configured default guild.
+func (r *Resolver) ExpandNames(args map[string]interface{}) error {
+	guildID, _ := args["guild_id"].(string)
+
+	if name, ok := nameValue(args, "channel_id"); ok {
+		if guildID == "" {
+			guildID = r.discord.Config().Discord.DefaultGuildID
+		}
+		if guildID == "" {
+			return &NameError{Param: "channel_id", Name: name}
+		}
+		id, err := r.expand("channel_id", name, guildID, r.Channel)
+		if err != nil {
+			return err
+		}
+		args["channel_id"] = id
+	}
+
+	if guildID == "" {
+		if channelID, ok := args["channel_id"].(string); ok && isID(channelID) {
+			if channel, err := r.discord.GetChannel(channelID); err == nil {
+				guildID = channel.GuildID
+			}
+		}
+	}
+	if guildID == "" {
+		guildID = r.discord.Config().Discord.DefaultGuildID
+	}
+
+	lookups := map[string]func(guildID, query string, limit int) (*Result, error){
+		"role_id": r.Role,
+		"user_id": r.User,
+	}
+	for _, param := range []string{"role_id", "user_id"} {
+		name, ok := nameValue(args, param)
+		if !ok {
+			continue
+		}
+		if guildID == "" {
+			return &NameError{Param: param, Name: name}
+		}
+		id, err := r.expand(param, name, guildID, lookups[param])
+		if err != nil {
+			return err
+		}
+		args[param] = id
+	}
+
+	return nil
+}
+
+// expand resolves one name, requiring a single exact match
+func (r *Resolver) expand(param, name, guildID string, lookup func(guildID, query string, limit int) (*Result, error)) (string, error) {
+	result, err := lookup(guildID, name, 5)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s %q: %w", param, name, err)
+	}
+
+	var exact []Candidate
+	for _, c := range result.Candidates {
+		if c.Score >= exactScore {
+			exact = append(exact, c)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0].ID, nil
+	}
+	if len(exact) > 1 {
+		return "", &NameError{Param: param, Name: name, Candidates: exact}
+	}
+	return "", &NameError{Param: param, Name: name, Candidates: result.Candidates}
+}
+
+// nameValue returns a parameter's value if it is a name rather than an ID or link
+func nameValue(args map[string]interface{}, param string) (string, bool) {
+	value, ok := args[param].(string)
+	if !ok || strings.TrimSpace(value) == "" || isID(value) {
+		return "", false
+	}
+	if _, _, _, isLink := validation.ParseDiscordURL(value); isLink {
+		return "", false
+	}
+	return value, true
+}
+
+// isID reports whether a value is a numeric ID
+func isID(value string) bool {
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
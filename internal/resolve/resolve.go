@@ -0,0 +1,245 @@
+// Package resolve maps human-readable channel, role and user names to Discord
+// IDs with fuzzy matching.
+package resolve
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/permissions"
+)
+
+// minScore is the lowest score reported as a candidate
+const minScore = 0.4
+
+// ambiguityMargin is how close the runner-up may score before a match is
+// considered ambiguous
+const ambiguityMargin = 0.05
+
+var (
+	channelMention = regexp.MustCompile(`^<#([0-9]+)>$`)
+	roleMention    = regexp.MustCompile(`^<@&([0-9]+)>$`)
+	userMention    = regexp.MustCompile(`^<@!?([0-9]+)>$`)
+	rawID          = regexp.MustCompile(`^[0-9]{17,20}$`)
+)
+
+// Candidate is a possible match for a name
+type Candidate struct {
+	ID     string                 `json:"id"`
+	Name   string                 `json:"name"`
+	Score  float64                `json:"score"`
+	Detail map[string]interface{} `json:"detail,omitempty"`
+}
+
+// Result lists candidates best first. Match is set when one candidate is a
+// clear winner; otherwise Ambiguous is true and the caller should pick.
+type Result struct {
+	Query      string      `json:"query"`
+	Match      *Candidate  `json:"match,omitempty"`
+	Ambiguous  bool        `json:"ambiguous"`
+	Candidates []Candidate `json:"candidates"`
+}
+
+// Resolver looks up entities by name
+type Resolver struct {
+	discord     *discord.Client
+	permissions *permissions.Checker
+}
+
+// NewResolver creates a new resolver
+func NewResolver(discordClient *discord.Client, permChecker *permissions.Checker) *Resolver {
+	return &Resolver{
+		discord:     discordClient,
+		permissions: permChecker,
+	}
+}
+
+// Channel resolves a channel name ("#announcements", "announcements" or a
+// <#id> mention) within a guild. Channels hidden by the access lists are
+// never returned.
+func (r *Resolver) Channel(guildID, query string, limit int) (*Result, error) {
+	channels, err := r.discord.GetChannels(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	id := mentionID(query, channelMention)
+	name := strings.TrimPrefix(strings.TrimSpace(query), "#")
+
+	var candidates []Candidate
+	for _, channel := range channels {
+		if !r.permissions.IsChannelAccessible(channel) {
+			continue
+		}
+		score := Score(name, channel.Name)
+		if channel.ID == id {
+			score = 1
+		}
+		if score < minScore {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			ID:    channel.ID,
+			Name:  channel.Name,
+			Score: score,
+			Detail: map[string]interface{}{
+				"type":      int(channel.Type),
+				"parent_id": channel.ParentID,
+			},
+		})
+	}
+	return rank(query, candidates, limit), nil
+}
+
+// Role resolves a role name ("@Moderators", "moderators" or a <@&id>
+// mention) within a guild
+func (r *Resolver) Role(guildID, query string, limit int) (*Result, error) {
+	roles, err := r.discord.GetRoles(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	id := mentionID(query, roleMention)
+	name := strings.TrimPrefix(strings.TrimSpace(query), "@")
+
+	var candidates []Candidate
+	for _, role := range roles {
+		score := Score(name, role.Name)
+		if role.ID == id {
+			score = 1
+		}
+		if score < minScore {
+			continue
+		}
+		candidates = append(candidates, Candidate{
+			ID:    role.ID,
+			Name:  role.Name,
+			Score: score,
+			Detail: map[string]interface{}{
+				"position": role.Position,
+				"managed":  role.Managed,
+			},
+		})
+	}
+	return rank(query, candidates, limit), nil
+}
+
+// User resolves a member by username, global display name or nickname
+// ("@alice", "alice" or a <@id> mention) within a guild
+func (r *Resolver) User(guildID, query string, limit int) (*Result, error) {
+	if id := mentionID(query, userMention); id != "" {
+		member, err := r.discord.GetMember(guildID, id)
+		if err != nil {
+			return nil, fmt.Errorf("user %s is not a member of guild %s: %w", id, guildID, err)
+		}
+		candidate := userCandidate(member, 1)
+		return &Result{Query: query, Match: &candidate, Candidates: []Candidate{candidate}}, nil
+	}
+
+	name := strings.TrimPrefix(strings.TrimSpace(query), "@")
+
+	// Discord's member search only matches prefixes of usernames and
+	// nicknames, so it is combined with cached members for fuzzy matches
+	members := make(map[string]*discordgo.Member)
+	var found []*discordgo.Member
+	_, err := r.discord.Retry(func() (err error) {
+		found, err = r.discord.Session().GuildMembersSearch(guildID, name, 100)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search members: %w", err)
+	}
+	for _, member := range found {
+		members[member.User.ID] = member
+	}
+	if guild, err := r.discord.Session().State.Guild(guildID); err == nil {
+		for _, member := range guild.Members {
+			if member.User != nil {
+				members[member.User.ID] = member
+			}
+		}
+	}
+
+	var candidates []Candidate
+	for _, member := range members {
+		score := Score(name, member.User.Username)
+		if s := Score(name, member.User.GlobalName); s > score {
+			score = s
+		}
+		if s := Score(name, member.Nick); s > score {
+			score = s
+		}
+		if score < minScore {
+			continue
+		}
+		candidates = append(candidates, userCandidate(member, score))
+	}
+	return rank(query, candidates, limit), nil
+}
+
+// userCandidate describes a member as a candidate
+func userCandidate(member *discordgo.Member, score float64) Candidate {
+	name := member.User.Username
+	if member.Nick != "" {
+		name = member.Nick
+	} else if member.User.GlobalName != "" {
+		name = member.User.GlobalName
+	}
+	return Candidate{
+		ID:    member.User.ID,
+		Name:  name,
+		Score: score,
+		Detail: map[string]interface{}{
+			"username":    member.User.Username,
+			"global_name": member.User.GlobalName,
+			"nick":        member.Nick,
+			"bot":         member.User.Bot,
+		},
+	}
+}
+
+// rank sorts candidates best first and decides whether the top one is a
+// clear match
+func rank(query string, candidates []Candidate, limit int) *Result {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	result := &Result{Query: query, Candidates: candidates}
+	if result.Candidates == nil {
+		result.Candidates = []Candidate{}
+	}
+	if len(candidates) == 0 {
+		return result
+	}
+
+	if len(candidates) > 1 && candidates[0].Score-candidates[1].Score < ambiguityMargin {
+		result.Ambiguous = true
+		return result
+	}
+	result.Match = &candidates[0]
+	return result
+}
+
+// mentionID returns the ID from a mention or bare ID, or ""
+func mentionID(query string, mention *regexp.Regexp) string {
+	query = strings.TrimSpace(query)
+	if match := mention.FindStringSubmatch(query); match != nil {
+		return match[1]
+	}
+	if rawID.MatchString(query) {
+		return query
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package response
+
+import (
+	"strings"
+	"time"
+
+	"discord-mcp/pkg/types"
+)
+
+// Keys added to result data by Localize
+const (
+	localSuffix = "_local"
+	timezoneKey = "timezone"
+)
+
+// Localize adds a <key>_local field next to every RFC 3339 timestamp in the
+// result's data, giving the same time in loc, and names the zone in a
+// top-level timezone field. Timestamps stay in UTC. Results are returned
+// unchanged for UTC and when they carry no timestamps.
+func Localize(result types.CallToolResult, loc *time.Location) types.CallToolResult {
+	if loc == nil || loc == time.UTC {
+		return result
+	}
+
+	localized := result
+	localized.Content = make([]types.Content, len(result.Content))
+	for i, content := range result.Content {
+		if content.Data != nil && content.Type != "image" {
+			if data, err := normalize(content.Data); err == nil && addLocalTimes(data, loc) {
+				if top, ok := data.(map[string]interface{}); ok {
+					if _, taken := top[timezoneKey]; !taken {
+						top[timezoneKey] = loc.String()
+					}
+				}
+				content.Data = data
+			}
+		}
+		localized.Content[i] = content
+	}
+	return localized
+}
+
+// addLocalTimes walks normalized data, adding local times next to
+// timestamps, and reports whether it added any
+func addLocalTimes(value interface{}, loc *time.Location) bool {
+	added := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		local := make(map[string]interface{})
+		for key, field := range v {
+			if s, ok := field.(string); ok {
+				if strings.HasSuffix(key, localSuffix) {
+					continue
+				}
+				if t, ok := parseTimestamp(s); ok {
+					local[key+localSuffix] = t.In(loc).Format(time.RFC3339)
+				}
+				continue
+			}
+			if addLocalTimes(field, loc) {
+				added = true
+			}
+		}
+		for key, field := range local {
+			if _, taken := v[key]; !taken {
+				v[key] = field
+				added = true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if addLocalTimes(item, loc) {
+				added = true
+			}
+		}
+	}
+	return added
+}
+
+// parseTimestamp reads strings shaped like RFC 3339 timestamps; other text,
+// such as message content, is rejected cheaply
+func parseTimestamp(s string) (time.Time, bool) {
+	if len(s) < len("2006-01-02T15:04:05Z") || len(s) > len("2006-01-02T15:04:05.999999999-07:00") || s[4] != '-' || s[10] != 'T' {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
@@ -0,0 +1,259 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"discord-mcp/internal/i18n"
+	"discord-mcp/pkg/types"
+)
+
+// CursorParam is the per-call argument that fetches the next page of a
+// truncated result instead of running the tool again
+const CursorParam = "result_cursor"
+
+// Limits for stored continuations
+const (
+	pageTTL      = 10 * time.Minute
+	maxPending   = 100
+	textEllipsis = "…"
+)
+
+// Pager caps the encoded size of tool results. When a result is too large,
+// its largest top-level list is cut to fit and the remaining items are kept
+// for a continuation cursor.
+type Pager struct {
+	maxBytes int
+	pending  map[string]*continuation
+	nextID   int
+	mutex    sync.Mutex
+}
+
+// continuation holds the items of a truncated list still to be returned
+type continuation struct {
+	tool    string
+	data    map[string]interface{}
+	field   string
+	items   []interface{}
+	offset  int
+	expires time.Time
+}
+
+// NewPager creates a pager for results up to maxBytes of JSON; 0 disables
+// the limit
+func NewPager(maxBytes int) *Pager {
+	return &Pager{
+		maxBytes: maxBytes,
+		pending:  make(map[string]*continuation),
+	}
+}
+
+// Limit returns result unchanged when it fits, and otherwise a truncated
+// copy whose data reports truncated, total_count, returned_count and, when
+// items remain, next_cursor
+func (p *Pager) Limit(tool string, result types.CallToolResult, locale string) types.CallToolResult {
+	if p.maxBytes <= 0 || result.IsError || len(result.Content) == 0 || p.fits(result) {
+		return result
+	}
+
+	data, field, items := largestList(result.Content[0].Data)
+	if field == "" {
+		return p.truncateText(result)
+	}
+
+	c := &continuation{tool: tool, data: data, field: field, items: items}
+	return p.page(result, c, locale)
+}
+
+// Continue returns the next page of a truncated result
+func (p *Pager) Continue(tool, cursor, locale string) (types.CallToolResult, error) {
+	p.mutex.Lock()
+	c, ok := p.pending[cursor]
+	if ok {
+		delete(p.pending, cursor)
+	}
+	p.mutex.Unlock()
+
+	if !ok || time.Now().After(c.expires) {
+		return types.CallToolResult{}, fmt.Errorf("result cursor %s is unknown or has expired; run the tool again", cursor)
+	}
+	if c.tool != tool {
+		return types.CallToolResult{}, fmt.Errorf("result cursor %s belongs to %s, not %s", cursor, c.tool, tool)
+	}
+
+	start := c.offset + 1
+	result := types.CallToolResult{
+		Content: []types.Content{{
+			Type: "text",
+			Text: i18n.T(locale, "response.continued", c.tool, c.field, start, len(c.items)),
+		}},
+	}
+	return p.page(result, c, locale), nil
+}
+
+// page fills result with as many of the continuation's items as fit, and
+// stores the continuation again when items remain
+func (p *Pager) page(result types.CallToolResult, c *continuation, locale string) types.CallToolResult {
+	remaining := c.items[c.offset:]
+
+	// Binary search for the largest page that fits; always return at least
+	// one item so continuation makes progress
+	build := func(n int) types.CallToolResult {
+		paged := withPage(result, c, remaining[:n], n < len(remaining))
+		if n < len(remaining) {
+			// Placeholder cursor so the size estimate includes the note
+			paged.Content[0].Text += "\n" + i18n.T(locale, "response.truncated", c.offset+n, len(c.items), c.field, "r0000000000")
+		}
+		return paged
+	}
+	lo, hi := 1, len(remaining)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if p.fits(build(mid)) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	if len(remaining) == 0 {
+		lo = 0
+	}
+
+	paged := withPage(result, c, remaining[:lo], lo < len(remaining))
+	if lo < len(remaining) {
+		c.offset += lo
+		cursor := p.store(c)
+		paged.Content[0].Data.(map[string]interface{})["next_cursor"] = cursor
+		paged.Content[0].Text += "\n" + i18n.T(locale, "response.truncated", c.offset, len(c.items), c.field, cursor)
+	}
+	if !p.fits(paged) {
+		paged = p.truncateText(paged)
+	}
+	return paged
+}
+
+// withPage returns a copy of result whose data holds one page of items
+func withPage(result types.CallToolResult, c *continuation, items []interface{}, truncated bool) types.CallToolResult {
+	data := make(map[string]interface{}, len(c.data)+5)
+	for key, value := range c.data {
+		data[key] = value
+	}
+	data[c.field] = items
+	data["truncated"] = truncated
+	data["truncated_field"] = c.field
+	data["total_count"] = len(c.items)
+	data["returned_count"] = len(items)
+	if truncated {
+		// Placeholder so the size estimate includes the cursor
+		data["next_cursor"] = "r0000000000"
+	}
+
+	paged := result
+	paged.Content = append([]types.Content(nil), result.Content...)
+	paged.Content[0].Data = data
+	return paged
+}
+
+// truncateText shortens the first text content until the result fits, as a
+// last resort for results without a list to page through
+func (p *Pager) truncateText(result types.CallToolResult) types.CallToolResult {
+	truncated := result
+	truncated.Content = append([]types.Content(nil), result.Content...)
+	text := []rune(truncated.Content[0].Text)
+
+	lo, hi := 0, len(text)
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		truncated.Content[0].Text = string(text[:mid]) + textEllipsis
+		if p.fits(truncated) {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	if lo < len(text) {
+		truncated.Content[0].Text = string(text[:lo]) + textEllipsis
+		if data, ok := truncated.Content[0].Data.(map[string]interface{}); ok {
+			data["text_truncated"] = true
+		}
+	} else {
+		truncated.Content[0].Text = string(text)
+	}
+	return truncated
+}
+
+// store saves a continuation and returns its cursor, evicting expired and,
+// beyond maxPending, the oldest continuations
+func (p *Pager) store(c *continuation) string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := time.Now()
+	c.expires = now.Add(pageTTL)
+	for cursor, pending := range p.pending {
+		if now.After(pending.expires) {
+			delete(p.pending, cursor)
+		}
+	}
+	if len(p.pending) >= maxPending {
+		cursors := make([]string, 0, len(p.pending))
+		for cursor := range p.pending {
+			cursors = append(cursors, cursor)
+		}
+		sort.Slice(cursors, func(i, j int) bool {
+			return p.pending[cursors[i]].expires.Before(p.pending[cursors[j]].expires)
+		})
+		for _, cursor := range cursors[:len(cursors)-maxPending+1] {
+			delete(p.pending, cursor)
+		}
+	}
+
+	p.nextID++
+	cursor := fmt.Sprintf("r%d", p.nextID)
+	p.pending[cursor] = c
+	return cursor
+}
+
+// fits reports whether result is within the limit. Image content is not
+// counted; images.max_bytes bounds it instead.
+func (p *Pager) fits(result types.CallToolResult) bool {
+	counted := result
+	counted.Content = make([]types.Content, 0, len(result.Content))
+	for _, content := range result.Content {
+		if content.Type != "image" {
+			counted.Content = append(counted.Content, content)
+		}
+	}
+	encoded, err := json.Marshal(counted)
+	return err != nil || len(encoded) <= p.maxBytes
+}
+
+// largestList finds the top-level list in data with the largest encoding
+func largestList(data interface{}) (map[string]interface{}, string, []interface{}) {
+	normalized, err := normalize(data)
+	if err != nil {
+		return nil, "", nil
+	}
+	fields, ok := normalized.(map[string]interface{})
+	if !ok {
+		return nil, "", nil
+	}
+
+	var field string
+	var items []interface{}
+	largest := 0
+	for key, value := range fields {
+		list, ok := value.([]interface{})
+		if !ok || len(list) < 2 {
+			continue
+		}
+		encoded, _ := json.Marshal(list)
+		if len(encoded) > largest {
+			field, items, largest = key, list, len(encoded)
+		}
+	}
+	return fields, field, items
+}
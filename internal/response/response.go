@@ -0,0 +1,250 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"discord-mcp/pkg/types"
+)
+
+// Detail levels for tool result data
+const (
+	// DetailFull returns the data as produced by the tool
+	DetailFull = "full"
+	// DetailCompact keeps only IDs, names and top-level counts and flags
+	DetailCompact = "compact"
+)
+
+// Per-call arguments accepted by every tool. They are removed from the
+// arguments before the tool runs.
+const (
+	DetailParam = "detail"
+	FieldsParam = "fields"
+)
+
+// nameKeys are the keys kept alongside IDs in compact data
+var nameKeys = map[string]bool{
+	"name":         true,
+	"username":     true,
+	"global_name":  true,
+	"display_name": true,
+	"nick":         true,
+	"title":        true,
+}
+
+// Options selects how much of a result's data is returned
+type Options struct {
+	Detail string
+	// Fields lists the data fields to keep, as dotted paths such as
+	// "messages.id"; lists are traversed transparently
+	Fields []string
+}
+
+// TakeOptions removes the detail and fields arguments from args and returns
+// them, using defaultDetail when the call does not set a detail level
+func TakeOptions(args map[string]interface{}, defaultDetail string) (Options, error) {
+	opts := Options{Detail: defaultDetail}
+	if opts.Detail == "" {
+		opts.Detail = DetailFull
+	}
+
+	if value, ok := args[DetailParam]; ok {
+		delete(args, DetailParam)
+		detail, ok := value.(string)
+		if !ok || (detail != DetailFull && detail != DetailCompact) {
+			return opts, fmt.Errorf("detail must be %q or %q", DetailFull, DetailCompact)
+		}
+		opts.Detail = detail
+	}
+
+	if value, ok := args[FieldsParam]; ok {
+		delete(args, FieldsParam)
+		list, ok := value.([]interface{})
+		if !ok {
+			return opts, fmt.Errorf("fields must be an array of field paths")
+		}
+		for _, item := range list {
+			field, ok := item.(string)
+			if !ok || strings.Trim(field, ".") == "" {
+				return opts, fmt.Errorf("fields must be an array of non-empty field paths")
+			}
+			opts.Fields = append(opts.Fields, strings.Trim(field, "."))
+		}
+	}
+	return opts, nil
+}
+
+// Apply shapes the data of a successful result. Fields take precedence over
+// the detail level. Error results and text are returned unchanged.
+func Apply(result types.CallToolResult, opts Options) types.CallToolResult {
+	if result.IsError || (opts.Detail != DetailCompact && len(opts.Fields) == 0) {
+		return result
+	}
+
+	shaped := result
+	shaped.Content = make([]types.Content, len(result.Content))
+	for i, content := range result.Content {
+		if content.Data != nil && content.Type != "image" {
+			if data, err := normalize(content.Data); err == nil {
+				if len(opts.Fields) > 0 {
+					content.Data = project(data, parseFields(opts.Fields))
+				} else {
+					content.Data, _ = compact(data, true)
+				}
+			}
+		}
+		shaped.Content[i] = content
+	}
+	return shaped
+}
+
+// normalize converts data to the generic form produced by encoding/json so
+// structs and typed maps can be walked uniformly
+func normalize(data interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	err = json.Unmarshal(encoded, &generic)
+	return generic, err
+}
+
+// fieldTree is a set of field paths; a nil subtree keeps the whole value
+type fieldTree map[string]fieldTree
+
+func parseFields(fields []string) fieldTree {
+	tree := fieldTree{}
+	for _, field := range fields {
+		node := tree
+		parts := strings.Split(field, ".")
+		for i, part := range parts {
+			sub, seen := node[part]
+			if seen && sub == nil {
+				// A shorter path already keeps the whole value
+				break
+			}
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			if sub == nil {
+				sub = fieldTree{}
+				node[part] = sub
+			}
+			node = sub
+		}
+	}
+	return tree
+}
+
+// project keeps the fields of value selected by tree
+func project(value interface{}, tree fieldTree) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		projected := make(map[string]interface{}, len(tree))
+		for key, sub := range tree {
+			field, ok := v[key]
+			if !ok {
+				continue
+			}
+			if sub == nil {
+				projected[key] = field
+			} else {
+				projected[key] = project(field, sub)
+			}
+		}
+		return projected
+	case []interface{}:
+		projected := make([]interface{}, len(v))
+		for i, item := range v {
+			projected[i] = project(item, tree)
+		}
+		return projected
+	}
+	return value
+}
+
+// compact keeps IDs and names at every level, plus numbers and booleans at
+// the top level, which carry counts, cursors and flags such as has_more. It
+// reports false when nothing is left.
+func compact(value interface{}, top bool) (interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		kept := make(map[string]interface{})
+		for key, field := range v {
+			switch f := field.(type) {
+			case map[string]interface{}, []interface{}:
+				if isIdentifying(key) {
+					kept[key] = f
+				} else if c, ok := compact(f, false); ok {
+					kept[key] = c
+				}
+			case bool, float64:
+				if top || isIdentifying(key) {
+					kept[key] = f
+				}
+			default:
+				if isIdentifying(key) {
+					kept[key] = f
+				}
+			}
+		}
+		return kept, len(kept) > 0
+	case []interface{}:
+		var kept []interface{}
+		for _, item := range v {
+			if c, ok := compact(item, false); ok {
+				kept = append(kept, c)
+			}
+		}
+		return kept, len(kept) > 0
+	}
+	return nil, false
+}
+
+// isIdentifying reports whether a data key holds an ID or a name
+func isIdentifying(key string) bool {
+	return key == "id" || strings.HasSuffix(key, "_id") || strings.HasSuffix(key, "_ids") || nameKeys[key]
+}
+
+// WithParams returns a copy of a tool definition that advertises the detail,
+// fields and result_cursor arguments
+func WithParams(tool types.Tool) types.Tool {
+	schema, ok := tool.InputSchema.(map[string]interface{})
+	if !ok {
+		return tool
+	}
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return tool
+	}
+
+	copied := make(map[string]interface{}, len(properties)+3)
+	for name, prop := range properties {
+		copied[name] = prop
+	}
+	copied[DetailParam] = map[string]interface{}{
+		"type":        "string",
+		"enum":        []string{DetailFull, DetailCompact},
+		"description": "Amount of result data: full, or compact for IDs, names and top-level counts only",
+	}
+	copied[FieldsParam] = map[string]interface{}{
+		"type":        "array",
+		"items":       map[string]interface{}{"type": "string", "minLength": 1},
+		"description": "Result data fields to return, as dotted paths such as \"messages.id\"; overrides detail",
+	}
+	copied[CursorParam] = map[string]interface{}{
+		"type":        "string",
+		"description": "next_cursor of a truncated result; returns its next page instead of running the tool",
+	}
+
+	result := make(map[string]interface{}, len(schema))
+	for k, v := range schema {
+		result[k] = v
+	}
+	result["properties"] = copied
+	tool.InputSchema = result
+	return tool
+}
@@ -0,0 +1,220 @@
+// Package rolemenu keeps self-assign role menus: messages with a select menu
+// whose options map to roles, so members pick their own roles. Menus are
+// persisted to disk.
+package rolemenu
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+// CustomIDPrefix starts the custom ID of every role menu's select component
+const CustomIDPrefix = "rolemenu:"
+
+// MaxOptions is the most options Discord allows in a select menu
+const MaxOptions = 25
+
+// Option maps one select menu option to a role
+type Option struct {
+	RoleID      string `json:"role_id"`
+	Label       string `json:"label"`
+	Description string `json:"description,omitempty"`
+	// Emoji is a Unicode emoji, or a custom emoji as "name:id"
+	Emoji string `json:"emoji,omitempty"`
+}
+
+// Menu is a posted role menu
+type Menu struct {
+	ID          string    `json:"id"`
+	GuildID     string    `json:"guild_id"`
+	ChannelID   string    `json:"channel_id"`
+	MessageID   string    `json:"message_id"`
+	Placeholder string    `json:"placeholder,omitempty"`
+	MinValues   int       `json:"min_values"`
+	MaxValues   int       `json:"max_values"`
+	Options     []Option  `json:"options"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Assigned and Removed count the roles changed through the menu
+	Assigned int `json:"assigned"`
+	Removed  int `json:"removed"`
+}
+
+// CustomID returns the custom ID of the menu's select component
+func (m Menu) CustomID() string {
+	return CustomIDPrefix + m.ID
+}
+
+// Changes returns the menu roles to add and remove so that a member with
+// roleIDs holds exactly the selected ones
+func (m Menu) Changes(selected, roleIDs []string) (add, remove []string) {
+	held := make(map[string]bool, len(roleIDs))
+	for _, roleID := range roleIDs {
+		held[roleID] = true
+	}
+	chosen := make(map[string]bool, len(selected))
+	for _, roleID := range selected {
+		chosen[roleID] = true
+	}
+	for _, option := range m.Options {
+		switch {
+		case chosen[option.RoleID] && !held[option.RoleID]:
+			add = append(add, option.RoleID)
+		case !chosen[option.RoleID] && held[option.RoleID]:
+			remove = append(remove, option.RoleID)
+		}
+	}
+	return add, remove
+}
+
+// storedMenus is the layout of the persistence file
+type storedMenus struct {
+	NextID int     `json:"next_id"`
+	Menus  []*Menu `json:"menus"`
+}
+
+// Store holds menus by ID and saves them to a JSON file on every change
+type Store struct {
+	path string
+
+	menus  map[string]*Menu
+	nextID int
+	mutex  sync.Mutex
+}
+
+// NewStore creates a store, loading the menus saved at path
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:  path,
+		menus: make(map[string]*Menu),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load role menus from %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// NextID reserves the ID of the next menu, so its custom ID is known before
+// the message is posted
+func (s *Store) NextID() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.nextID++
+	return fmt.Sprintf("rm%d", s.nextID)
+}
+
+// Add saves a posted menu whose ID was reserved with NextID
+func (s *Store) Add(menu Menu) (Menu, error) {
+	if len(menu.Options) == 0 || len(menu.Options) > MaxOptions {
+		return Menu{}, fmt.Errorf("a role menu needs 1 to %d options", MaxOptions)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	menu.CreatedAt = time.Now().UTC()
+	s.menus[menu.ID] = &menu
+	if err := s.save(); err != nil {
+		delete(s.menus, menu.ID)
+		return Menu{}, err
+	}
+	return menu, nil
+}
+
+// ByCustomID returns the menu a select component belongs to
+func (s *Store) ByCustomID(customID string) (Menu, bool) {
+	if !strings.HasPrefix(customID, CustomIDPrefix) {
+		return Menu{}, false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	menu, ok := s.menus[strings.TrimPrefix(customID, CustomIDPrefix)]
+	if !ok {
+		return Menu{}, false
+	}
+	return *menu, true
+}
+
+// List returns the menus of a guild, or of every guild when guildID is
+// empty, newest first
+func (s *Store) List(guildID string) []Menu {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	menus := make([]Menu, 0)
+	for _, menu := range s.menus {
+		if guildID == "" || menu.GuildID == guildID {
+			menus = append(menus, *menu)
+		}
+	}
+	sort.Slice(menus, func(i, j int) bool {
+		return menus[i].CreatedAt.After(menus[j].CreatedAt)
+	})
+	return menus
+}
+
+// Record counts the roles a selection assigned and removed
+func (s *Store) Record(menuID string, assigned, removed int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	menu, ok := s.menus[menuID]
+	if !ok {
+		return nil
+	}
+	menu.Assigned += assigned
+	menu.Removed += removed
+	return s.save()
+}
+
+// Remove deletes a menu from a guild, returning it and whether it existed
+func (s *Store) Remove(guildID, menuID string) (Menu, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	menu, ok := s.menus[menuID]
+	if !ok || menu.GuildID != guildID {
+		return Menu{}, false, nil
+	}
+	delete(s.menus, menuID)
+	if err := s.save(); err != nil {
+		s.menus[menuID] = menu
+		return Menu{}, false, err
+	}
+	return *menu, true, nil
+}
+
+// save writes every menu to the persistence file; callers must hold the
+// lock
+func (s *Store) save() error {
+	stored := storedMenus{NextID: s.nextID, Menus: make([]*Menu, 0, len(s.menus))}
+	for _, menu := range s.menus {
+		stored.Menus = append(stored.Menus, menu)
+	}
+	sort.Slice(stored.Menus, func(i, j int) bool {
+		return stored.Menus[i].CreatedAt.Before(stored.Menus[j].CreatedAt)
+	})
+
+	return jsonfile.Save(s.path, stored)
+}
+
+// load reads saved menus
+func (s *Store) load() error {
+	var stored storedMenus
+	if found, err := jsonfile.Load(s.path, &stored); err != nil || !found {
+		return err
+	}
+	s.nextID = stored.NextID
+	for _, menu := range stored.Menus {
+		s.menus[menu.ID] = menu
+	}
+	return nil
+}
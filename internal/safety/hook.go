@@ -0,0 +1,97 @@
+package safety
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxHookResponseBytes bounds the response read from a hook
+const maxHookResponseBytes = 1024 * 1024
+
+// HTTPScanner posts {"items": [...]} to an external service and reads
+// {"findings": [{"url": ..., "reason": ...}]} back. Items missing from the
+// findings are safe.
+type HTTPScanner struct {
+	URL     string
+	Headers map[string]string
+	client  *http.Client
+}
+
+// hookFinding is a finding as returned by a hook
+type hookFinding struct {
+	URL    string `json:"url"`
+	Reason string `json:"reason"`
+}
+
+// Name returns the scanner name
+func (s *HTTPScanner) Name() string {
+	return "hook"
+}
+
+// Scan calls the hook
+func (s *HTTPScanner) Scan(ctx context.Context, items []Item) ([]Finding, error) {
+	body, err := json.Marshal(map[string]interface{}{"items": items})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode safety hook request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build safety hook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range s.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach safety hook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxHookResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read safety hook response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("safety hook returned HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(data))
+	}
+
+	var decoded struct {
+		Findings []hookFinding `json:"findings"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("invalid safety hook response: %w", err)
+	}
+
+	// Report findings against the items sent, so a hook cannot add links
+	// the message does not contain
+	byURL := make(map[string]Item, len(items))
+	for _, item := range items {
+		byURL[item.URL] = item
+	}
+	findings := make([]Finding, 0, len(decoded.Findings))
+	for _, f := range decoded.Findings {
+		item, ok := byURL[f.URL]
+		if !ok {
+			continue
+		}
+		reason := f.Reason
+		if reason == "" {
+			reason = "flagged by safety hook"
+		}
+		findings = append(findings, Finding{
+			Scanner:  s.Name(),
+			Kind:     item.Kind,
+			URL:      item.URL,
+			Filename: item.Filename,
+			Reason:   reason,
+		})
+	}
+	return findings, nil
+}
@@ -0,0 +1,279 @@
+// Package safety checks the links and attachments of messages with a chain
+// of scanners: built-in domain and file type lists and an external HTTP
+// hook, such as a proxy in front of a Safe Browsing service.
+package safety
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/config"
+)
+
+// Item kinds
+const (
+	KindURL        = "url"
+	KindAttachment = "attachment"
+)
+
+// Message directions
+const (
+	DirectionInbound  = "inbound"
+	DirectionOutbound = "outbound"
+)
+
+// urlPattern finds links in message text; angle brackets, which Discord
+// uses to suppress embeds, end a link
+var urlPattern = regexp.MustCompile(`https?://[^\s<>"'` + "`" + `]+`)
+
+// Item is a link or attachment to check
+type Item struct {
+	Kind        string `json:"kind"`
+	URL         string `json:"url"`
+	Filename    string `json:"filename,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int    `json:"size,omitempty"`
+}
+
+// Finding is an item a scanner considers unsafe
+type Finding struct {
+	Scanner  string `json:"scanner"`
+	Kind     string `json:"kind"`
+	URL      string `json:"url"`
+	Filename string `json:"filename,omitempty"`
+	Reason   string `json:"reason"`
+}
+
+// Scanner checks items and returns the unsafe ones. Scanners are called
+// with every item that no earlier list marked as trusted.
+type Scanner interface {
+	Name() string
+	Scan(ctx context.Context, items []Item) ([]Finding, error)
+}
+
+// Result is the outcome of checking a message
+type Result struct {
+	// Scanned counts the items checked, trusted ones included
+	Scanned  int       `json:"scanned"`
+	Findings []Finding `json:"findings"`
+	// Errors holds the failure of each scanner that could not run; the
+	// items it would have checked are let through
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// Unsafe reports whether any scanner flagged an item
+func (r Result) Unsafe() bool {
+	return len(r.Findings) > 0
+}
+
+// Pipeline runs the domain and file type lists and then every other
+// scanner over a message's items
+type Pipeline struct {
+	lists    *ListScanner
+	scanners []Scanner
+	timeout  time.Duration
+}
+
+// NewPipeline creates the scanners set in the configuration
+func NewPipeline(cfg config.SafetyConfig) (*Pipeline, error) {
+	timeout := time.Duration(cfg.Hook.TimeoutMs) * time.Millisecond
+	p := &Pipeline{
+		lists:   NewListScanner(cfg.AllowedDomains, cfg.DeniedDomains, cfg.DeniedExtensions),
+		timeout: timeout,
+	}
+	if cfg.Hook.URL != "" {
+		if _, err := url.ParseRequestURI(cfg.Hook.URL); err != nil {
+			return nil, fmt.Errorf("invalid safety hook URL: %w", err)
+		}
+		p.scanners = append(p.scanners, &HTTPScanner{
+			URL:     cfg.Hook.URL,
+			Headers: cfg.Hook.Headers,
+			client:  &http.Client{Timeout: timeout},
+		})
+	}
+	return p, nil
+}
+
+// AddScanner appends a scanner to the chain
+func (p *Pipeline) AddScanner(scanner Scanner) {
+	p.scanners = append(p.scanners, scanner)
+}
+
+// Scan checks the links in a message's content and embeds and its
+// attachments
+func (p *Pipeline) Scan(content string, embeds []*discordgo.MessageEmbed, attachments []*discordgo.MessageAttachment) Result {
+	items := Items(content, embeds, attachments)
+	result := Result{Scanned: len(items), Findings: make([]Finding, 0)}
+	if len(items) == 0 {
+		return result
+	}
+
+	untrusted := make([]Item, 0, len(items))
+	for _, item := range items {
+		if !p.lists.Trusted(item) {
+			untrusted = append(untrusted, item)
+		}
+	}
+	findings, _ := p.lists.Scan(context.Background(), untrusted)
+	result.Findings = append(result.Findings, findings...)
+	if len(untrusted) == 0 {
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	for _, scanner := range p.scanners {
+		findings, err := scanner.Scan(ctx, untrusted)
+		if err != nil {
+			if result.Errors == nil {
+				result.Errors = make(map[string]string)
+			}
+			result.Errors[scanner.Name()] = err.Error()
+			continue
+		}
+		result.Findings = append(result.Findings, findings...)
+	}
+	return result
+}
+
+// Items collects the distinct links and attachments of a message
+func Items(content string, embeds []*discordgo.MessageEmbed, attachments []*discordgo.MessageAttachment) []Item {
+	texts := []string{content}
+	for _, embed := range embeds {
+		if embed == nil {
+			continue
+		}
+		texts = append(texts, embed.URL, embed.Description)
+		for _, field := range embed.Fields {
+			texts = append(texts, field.Value)
+		}
+	}
+
+	seen := make(map[string]bool)
+	var items []Item
+	for _, text := range texts {
+		for _, link := range ExtractURLs(text) {
+			if !seen[link] {
+				seen[link] = true
+				items = append(items, Item{Kind: KindURL, URL: link})
+			}
+		}
+	}
+	for _, attachment := range attachments {
+		items = append(items, Item{
+			Kind:        KindAttachment,
+			URL:         attachment.URL,
+			Filename:    attachment.Filename,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+		})
+	}
+	return items
+}
+
+// ExtractURLs returns the http and https links in text, without trailing
+// punctuation
+func ExtractURLs(text string) []string {
+	matches := urlPattern.FindAllString(text, -1)
+	links := make([]string, 0, len(matches))
+	for _, match := range matches {
+		match = strings.TrimRight(match, ".,:;!?)]}*_~|")
+		if u, err := url.Parse(match); err == nil && u.Host != "" {
+			links = append(links, match)
+		}
+	}
+	return links
+}
+
+// ListScanner flags links to denied domains and files with denied
+// extensions. Links to allowed domains are trusted and skip every scanner.
+type ListScanner struct {
+	allowed    []string
+	denied     []string
+	extensions []string
+}
+
+// NewListScanner creates a list scanner; domains match their subdomains
+// too and extensions match case-insensitively
+func NewListScanner(allowed, denied, extensions []string) *ListScanner {
+	s := &ListScanner{}
+	for _, domain := range allowed {
+		s.allowed = append(s.allowed, strings.ToLower(strings.TrimPrefix(domain, "*.")))
+	}
+	for _, domain := range denied {
+		s.denied = append(s.denied, strings.ToLower(strings.TrimPrefix(domain, "*.")))
+	}
+	for _, ext := range extensions {
+		s.extensions = append(s.extensions, strings.ToLower(ext))
+	}
+	return s
+}
+
+// Name returns the scanner name
+func (s *ListScanner) Name() string {
+	return "lists"
+}
+
+// Trusted reports whether an item links to an allowed domain. Attachments
+// are never trusted, since any file can be uploaded to Discord's CDN.
+func (s *ListScanner) Trusted(item Item) bool {
+	return item.Kind == KindURL && matchesDomain(hostOf(item.URL), s.allowed) != ""
+}
+
+// Scan flags denied domains and extensions
+func (s *ListScanner) Scan(_ context.Context, items []Item) ([]Finding, error) {
+	var findings []Finding
+	for _, item := range items {
+		finding := Finding{Scanner: s.Name(), Kind: item.Kind, URL: item.URL, Filename: item.Filename}
+		if domain := matchesDomain(hostOf(item.URL), s.denied); domain != "" && item.Kind == KindURL {
+			finding.Reason = "denied domain " + domain
+			findings = append(findings, finding)
+			continue
+		}
+		name := item.Filename
+		if name == "" {
+			if u, err := url.Parse(item.URL); err == nil {
+				name = path.Base(u.Path)
+			}
+		}
+		ext := strings.ToLower(path.Ext(name))
+		for _, denied := range s.extensions {
+			if ext != "" && ext == denied {
+				finding.Reason = "denied file type " + ext
+				findings = append(findings, finding)
+				break
+			}
+		}
+	}
+	return findings, nil
+}
+
+// hostOf returns the lower-cased host of a URL
+func hostOf(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// matchesDomain returns the listed domain a host is or is a subdomain of
+func matchesDomain(host string, domains []string) string {
+	if host == "" {
+		return ""
+	}
+	for _, domain := range domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return domain
+		}
+	}
+	return ""
+}
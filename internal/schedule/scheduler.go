@@ -0,0 +1,292 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	// Embedded zone data so timezones resolve on hosts without tzdata
+	_ "time/tzdata"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+// MaxPosts bounds the number of recurring posts
+const MaxPosts = 100
+
+// Post is a message sent to a channel on a cron schedule. It carries either
+// a template name with variables or literal content.
+type Post struct {
+	ID        string            `json:"id"`
+	Cron      string            `json:"cron"`
+	Timezone  string            `json:"timezone"`
+	GuildID   string            `json:"guild_id,omitempty"`
+	ChannelID string            `json:"channel_id"`
+	Template  string            `json:"template,omitempty"`
+	Variables map[string]string `json:"variables,omitempty"`
+	Content   string            `json:"content,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	NextRunAt time.Time         `json:"next_run_at"`
+	LastRunAt *time.Time        `json:"last_run_at,omitempty"`
+	LastError string            `json:"last_error,omitempty"`
+	Runs      int64             `json:"run_count"`
+
+	cron     *Cron
+	location *time.Location
+}
+
+// storedPosts is the layout of the persistence file
+type storedPosts struct {
+	NextID int     `json:"next_id"`
+	Posts  []*Post `json:"posts"`
+}
+
+// Scheduler sends recurring posts when they are due. Posts are saved to a
+// JSON file on every change so they survive restarts; runs missed while the
+// server was down are skipped.
+type Scheduler struct {
+	path   string
+	logger *logrus.Logger
+
+	posts  map[string]*Post
+	nextID int
+	mutex  sync.Mutex
+
+	wake     chan struct{}
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewScheduler creates a scheduler, loading the posts saved at path
+func NewScheduler(path string, logger *logrus.Logger) (*Scheduler, error) {
+	s := &Scheduler{
+		path:   path,
+		logger: logger,
+		posts:  make(map[string]*Post),
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load recurring posts from %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Start sends due posts with send until Stop is called
+func (s *Scheduler) Start(send func(Post) error) {
+	go func() {
+		timer := time.NewTimer(s.untilNext())
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-s.wake:
+			case <-timer.C:
+				s.runDue(send, time.Now())
+			}
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(s.untilNext())
+		}
+	}()
+}
+
+// Stop stops sending posts
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// Add validates, schedules and saves a post, assigning its ID
+func (s *Scheduler) Add(post Post) (Post, error) {
+	if post.Timezone == "" {
+		post.Timezone = "UTC"
+	}
+	if err := post.compile(); err != nil {
+		return Post{}, err
+	}
+	if post.Template == "" && post.Content == "" {
+		return Post{}, fmt.Errorf("either template or content is required")
+	}
+
+	now := time.Now()
+	post.NextRunAt = post.cron.Next(now.In(post.location))
+	if post.NextRunAt.IsZero() {
+		return Post{}, fmt.Errorf("cron expression %q never matches", post.Cron)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(s.posts) >= MaxPosts {
+		return Post{}, fmt.Errorf("recurring post limit reached (%d)", MaxPosts)
+	}
+
+	s.nextID++
+	post.ID = fmt.Sprintf("rp%d", s.nextID)
+	post.CreatedAt = now.UTC()
+	s.posts[post.ID] = &post
+	if err := s.save(); err != nil {
+		delete(s.posts, post.ID)
+		return Post{}, err
+	}
+
+	s.signal()
+	return post, nil
+}
+
+// Remove deletes and saves, reporting whether the post existed
+func (s *Scheduler) Remove(id string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	post, ok := s.posts[id]
+	if !ok {
+		return false, nil
+	}
+	delete(s.posts, id)
+	if err := s.save(); err != nil {
+		s.posts[id] = post
+		return false, err
+	}
+
+	s.signal()
+	return true, nil
+}
+
+// List returns all posts ordered by creation
+func (s *Scheduler) List() []Post {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	posts := make([]Post, 0, len(s.posts))
+	for _, post := range s.posts {
+		posts = append(posts, *post)
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].CreatedAt.Before(posts[j].CreatedAt)
+	})
+	return posts
+}
+
+// runDue sends every post whose next run has passed and schedules its
+// following run. Sending happens outside the lock so tools stay responsive.
+func (s *Scheduler) runDue(send func(Post) error, now time.Time) {
+	s.mutex.Lock()
+	var due []Post
+	for _, post := range s.posts {
+		if !post.NextRunAt.After(now) {
+			due = append(due, *post)
+			post.NextRunAt = post.cron.Next(now.In(post.location))
+		}
+	}
+	s.mutex.Unlock()
+
+	for _, post := range due {
+		s.logger.Debugf("Sending recurring post %s to channel %s", post.ID, post.ChannelID)
+		err := send(post)
+		if err != nil {
+			s.logger.Warnf("Recurring post %s failed: %v", post.ID, err)
+		}
+
+		s.mutex.Lock()
+		if stored, ok := s.posts[post.ID]; ok {
+			ran := now.UTC()
+			stored.LastRunAt = &ran
+			stored.Runs++
+			stored.LastError = ""
+			if err != nil {
+				stored.LastError = err.Error()
+			}
+		}
+		s.mutex.Unlock()
+	}
+
+	if len(due) > 0 {
+		s.mutex.Lock()
+		if err := s.save(); err != nil {
+			s.logger.Warn(err)
+		}
+		s.mutex.Unlock()
+	}
+}
+
+// untilNext returns the time until the earliest next run, capped so the
+// timer also recovers from clock changes
+func (s *Scheduler) untilNext() time.Duration {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	wait := time.Hour
+	for _, post := range s.posts {
+		if d := time.Until(post.NextRunAt); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// signal wakes the run loop to recompute its timer
+func (s *Scheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// compile parses the post's cron expression and timezone
+func (post *Post) compile() error {
+	cron, err := ParseCron(post.Cron)
+	if err != nil {
+		return err
+	}
+	location, err := time.LoadLocation(post.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid timezone %q", post.Timezone)
+	}
+	post.cron = cron
+	post.location = location
+	return nil
+}
+
+// save writes all posts to the persistence file; callers must hold the lock
+func (s *Scheduler) save() error {
+	stored := storedPosts{NextID: s.nextID, Posts: make([]*Post, 0, len(s.posts))}
+	for _, post := range s.posts {
+		stored.Posts = append(stored.Posts, post)
+	}
+	sort.Slice(stored.Posts, func(i, j int) bool {
+		return stored.Posts[i].CreatedAt.Before(stored.Posts[j].CreatedAt)
+	})
+
+	return jsonfile.Save(s.path, stored)
+}
+
+// load reads saved posts and schedules their next runs from now
+func (s *Scheduler) load() error {
+	var stored storedPosts
+	if found, err := jsonfile.Load(s.path, &stored); err != nil || !found {
+		return err
+	}
+
+	now := time.Now()
+	s.nextID = stored.NextID
+	for _, post := range stored.Posts {
+		if err := post.compile(); err != nil {
+			return fmt.Errorf("post %s: %w", post.ID, err)
+		}
+		post.NextRunAt = post.cron.Next(now.In(post.location))
+		s.posts[post.ID] = post
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/config"
+)
+
+// Provider fetches a secret value from some backing store
+type Provider interface {
+	// Name identifies the provider in logs
+	Name() string
+	// Fetch returns the current secret value
+	Fetch(ctx context.Context) (string, error)
+}
+
+// NewTokenProvider returns the provider configured for the Discord bot token.
+// token_secret takes precedence over token_file, which takes precedence over
+// an inline token. DISCORD_TOKEN and DISCORD_TOKEN_FILE have already replaced
+// these in cfg when set; see config.LoadFromEnv.
+func NewTokenProvider(cfg config.DiscordConfig) (Provider, error) {
+	secret := cfg.TokenSecret
+
+	switch secret.Provider {
+	case "":
+		if cfg.TokenFile != "" {
+			return &FileProvider{Path: cfg.TokenFile}, nil
+		}
+		return &StaticProvider{Value: cfg.Token}, nil
+	case "file":
+		path := secret.Path
+		if path == "" {
+			path = cfg.TokenFile
+		}
+		if path == "" {
+			return nil, fmt.Errorf("token_secret.path is required for the file provider")
+		}
+		return &FileProvider{Path: path}, nil
+	case "env":
+		if secret.EnvVar == "" {
+			return nil, fmt.Errorf("token_secret.env_var is required for the env provider")
+		}
+		return &EnvProvider{Var: secret.EnvVar}, nil
+	case "vault":
+		return NewVaultProvider(secret)
+	case "aws":
+		return NewAWSProvider(secret)
+	default:
+		return nil, fmt.Errorf("unknown secret provider: %s", secret.Provider)
+	}
+}
+
+// StaticProvider returns a fixed value, used for inline config tokens
+type StaticProvider struct {
+	Value string
+}
+
+// Name returns the provider name
+func (p *StaticProvider) Name() string {
+	return "config"
+}
+
+// Fetch returns the static value
+func (p *StaticProvider) Fetch(ctx context.Context) (string, error) {
+	if p.Value == "" {
+		return "", fmt.Errorf("discord.token is required")
+	}
+	return p.Value, nil
+}
+
+// FileProvider reads a secret from a file, e.g. a mounted Docker/Kubernetes secret
+type FileProvider struct {
+	Path string
+}
+
+// Name returns the provider name
+func (p *FileProvider) Name() string {
+	return "file"
+}
+
+// Fetch reads and trims the file contents
+func (p *FileProvider) Fetch(ctx context.Context) (string, error) {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return "", fmt.Errorf("secret file %s is empty", p.Path)
+	}
+	return value, nil
+}
+
+// EnvProvider reads a secret from an environment variable
+type EnvProvider struct {
+	Var string
+}
+
+// Name returns the provider name
+func (p *EnvProvider) Name() string {
+	return "env"
+}
+
+// Fetch reads the environment variable
+func (p *EnvProvider) Fetch(ctx context.Context) (string, error) {
+	value := strings.TrimSpace(os.Getenv(p.Var))
+	if value == "" {
+		return "", fmt.Errorf("environment variable %s is not set", p.Var)
+	}
+	return value, nil
+}
+
+// Watcher periodically re-reads a secret and reports rotations
+type Watcher struct {
+	provider Provider
+	interval time.Duration
+	logger   *logrus.Logger
+
+	current  string
+	mutex    sync.Mutex
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewWatcher creates a watcher that starts from an already fetched value
+func NewWatcher(provider Provider, initial string, interval time.Duration, logger *logrus.Logger) *Watcher {
+	return &Watcher{
+		provider: provider,
+		interval: interval,
+		logger:   logger,
+		current:  initial,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start polls the provider until Stop is called, invoking onChange whenever
+// the secret value changes
+func (w *Watcher) Start(onChange func(value string)) {
+	if w.interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-ticker.C:
+				w.poll(onChange)
+			}
+		}
+	}()
+}
+
+// Stop stops polling
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
+
+func (w *Watcher) poll(onChange func(value string)) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	value, err := w.provider.Fetch(ctx)
+	if err != nil {
+		w.logger.Warnf("Failed to refresh secret from %s provider: %v", w.provider.Name(), err)
+		return
+	}
+
+	w.mutex.Lock()
+	changed := value != w.current
+	w.current = value
+	w.mutex.Unlock()
+
+	if changed {
+		w.logger.Infof("Secret rotated via %s provider", w.provider.Name())
+		onChange(value)
+	}
+}
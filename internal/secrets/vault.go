@@ -0,0 +1,96 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"discord-mcp/internal/config"
+)
+
+// VaultProvider reads a secret from a HashiCorp Vault KV v2 engine
+type VaultProvider struct {
+	address string
+	token   string
+	path    string
+	field   string
+	client  *http.Client
+}
+
+// NewVaultProvider creates a Vault provider. The address and token default to
+// the standard VAULT_ADDR and VAULT_TOKEN environment variables.
+func NewVaultProvider(cfg config.SecretConfig) (*VaultProvider, error) {
+	address := cfg.Address
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		return nil, fmt.Errorf("token_secret.address or VAULT_ADDR is required for the vault provider")
+	}
+
+	token := os.Getenv("VAULT_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("VAULT_TOKEN is required for the vault provider")
+	}
+
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("token_secret.path is required for the vault provider")
+	}
+
+	field := cfg.Field
+	if field == "" {
+		field = "token"
+	}
+
+	return &VaultProvider{
+		address: strings.TrimRight(address, "/"),
+		token:   token,
+		path:    strings.TrimLeft(cfg.Path, "/"),
+		field:   field,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Name returns the provider name
+func (p *VaultProvider) Name() string {
+	return "vault"
+}
+
+// Fetch reads the configured field from the KV v2 secret. The path is given
+// as "<mount>/data/<secret>", e.g. "secret/data/discord-mcp".
+func (p *VaultProvider) Fetch(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+"/v1/"+p.path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned HTTP %d for %s", resp.StatusCode, p.path)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	value, ok := body.Data.Data[p.field].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("vault secret %s has no field %q", p.path, p.field)
+	}
+	return value, nil
+}
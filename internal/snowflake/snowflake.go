@@ -0,0 +1,45 @@
+// Package snowflake converts between Discord IDs and timestamps.
+package snowflake
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Epoch is the first millisecond of 2015, the origin of Discord snowflakes
+const Epoch = 1420070400000
+
+// Parts are the fields encoded in a snowflake
+type Parts struct {
+	Timestamp time.Time
+	WorkerID  int64
+	ProcessID int64
+	Increment int64
+}
+
+// Parse decodes a snowflake ID
+func Parse(id string) (Parts, error) {
+	value, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return Parts{}, fmt.Errorf("invalid snowflake %q", id)
+	}
+
+	return Parts{
+		Timestamp: time.UnixMilli(int64(value>>22) + Epoch).UTC(),
+		WorkerID:  int64(value>>17) & 0x1f,
+		ProcessID: int64(value>>12) & 0x1f,
+		Increment: int64(value) & 0xfff,
+	}, nil
+}
+
+// At returns the smallest snowflake generated at or after t. Used as an
+// "after" cursor it includes messages from t onwards; as a "before" cursor
+// it excludes them.
+func At(t time.Time) string {
+	ms := t.UnixMilli() - Epoch
+	if ms < 0 {
+		ms = 0
+	}
+	return strconv.FormatUint(uint64(ms)<<22, 10)
+}
@@ -0,0 +1,236 @@
+// Package starboard holds per-guild starboard settings and the messages
+// reposted to each starboard. Both are persisted to disk so reposts are not
+// duplicated after a restart.
+package starboard
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+// MaxEntriesPerGuild bounds the reposts remembered per guild; the oldest
+// entries are forgotten first
+const MaxEntriesPerGuild = 1000
+
+// Board is a guild's starboard: messages reaching Threshold reactions of
+// Emoji are reposted to ChannelID
+type Board struct {
+	GuildID      string    `json:"guild_id"`
+	ChannelID    string    `json:"channel_id"`
+	Emoji        string    `json:"emoji"`
+	EmojiAPIName string    `json:"emoji_api_name"`
+	Threshold    int       `json:"threshold"`
+	Enabled      bool      `json:"enabled"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Entry is a message reposted to a starboard
+type Entry struct {
+	GuildID            string    `json:"guild_id"`
+	ChannelID          string    `json:"channel_id"`
+	MessageID          string    `json:"message_id"`
+	AuthorID           string    `json:"author_id"`
+	StarboardMessageID string    `json:"starboard_message_id"`
+	Stars              int       `json:"stars"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// storedStarboard is the layout of the persistence file
+type storedStarboard struct {
+	Boards  []*Board `json:"boards"`
+	Entries []*Entry `json:"entries"`
+}
+
+// Store holds starboards and their entries and saves them to a JSON file on
+// every change
+type Store struct {
+	path string
+
+	boards  map[string]*Board
+	entries map[string]*Entry
+	mutex   sync.Mutex
+}
+
+// NewStore creates a store, loading the starboards saved at path
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:    path,
+		boards:  make(map[string]*Board),
+		entries: make(map[string]*Entry),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load starboard from %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// SetBoard validates and saves a guild's starboard, replacing any previous
+// one, and reports whether it was newly created
+func (s *Store) SetBoard(board Board) (Board, bool, error) {
+	if board.GuildID == "" || board.ChannelID == "" {
+		return Board{}, false, fmt.Errorf("guild and channel are required")
+	}
+	if board.EmojiAPIName == "" {
+		return Board{}, false, fmt.Errorf("emoji is required")
+	}
+	if board.Threshold < 1 {
+		return Board{}, false, fmt.Errorf("threshold must be at least 1")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, exists := s.boards[board.GuildID]
+	board.UpdatedAt = time.Now().UTC()
+	s.boards[board.GuildID] = &board
+	if err := s.save(); err != nil {
+		if exists {
+			s.boards[board.GuildID] = previous
+		} else {
+			delete(s.boards, board.GuildID)
+		}
+		return Board{}, false, err
+	}
+	return board, !exists, nil
+}
+
+// Board returns a guild's starboard
+func (s *Store) Board(guildID string) (Board, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	board, ok := s.boards[guildID]
+	if !ok {
+		return Board{}, false
+	}
+	return *board, true
+}
+
+// Entry returns the repost of a message
+func (s *Store) Entry(messageID string) (Entry, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, ok := s.entries[messageID]
+	if !ok {
+		return Entry{}, false
+	}
+	return *entry, true
+}
+
+// Entries returns a guild's reposts, most starred first
+func (s *Store) Entries(guildID string) []Entry {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entries := make([]Entry, 0)
+	for _, entry := range s.entries {
+		if entry.GuildID == guildID {
+			entries = append(entries, *entry)
+		}
+	}
+	sortEntries(entries)
+	return entries
+}
+
+// SaveEntry records a new repost or an updated star count
+func (s *Store) SaveEntry(entry Entry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now().UTC()
+	previous, exists := s.entries[entry.MessageID]
+	if exists {
+		entry.CreatedAt = previous.CreatedAt
+	} else {
+		entry.CreatedAt = now
+	}
+	entry.UpdatedAt = now
+	s.entries[entry.MessageID] = &entry
+	if !exists {
+		s.prune(entry.GuildID)
+	}
+
+	if err := s.save(); err != nil {
+		if exists {
+			s.entries[entry.MessageID] = previous
+		} else {
+			delete(s.entries, entry.MessageID)
+		}
+		return err
+	}
+	return nil
+}
+
+// prune forgets a guild's oldest entries beyond MaxEntriesPerGuild; callers
+// must hold the lock
+func (s *Store) prune(guildID string) {
+	var entries []*Entry
+	for _, entry := range s.entries {
+		if entry.GuildID == guildID {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) <= MaxEntriesPerGuild {
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	for _, entry := range entries[:len(entries)-MaxEntriesPerGuild] {
+		delete(s.entries, entry.MessageID)
+	}
+}
+
+// sortEntries orders entries by star count, then newest first
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Stars != entries[j].Stars {
+			return entries[i].Stars > entries[j].Stars
+		}
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+}
+
+// save writes everything to the persistence file; callers must hold the lock
+func (s *Store) save() error {
+	stored := storedStarboard{
+		Boards:  make([]*Board, 0, len(s.boards)),
+		Entries: make([]*Entry, 0, len(s.entries)),
+	}
+	for _, board := range s.boards {
+		stored.Boards = append(stored.Boards, board)
+	}
+	sort.Slice(stored.Boards, func(i, j int) bool {
+		return stored.Boards[i].GuildID < stored.Boards[j].GuildID
+	})
+	for _, entry := range s.entries {
+		stored.Entries = append(stored.Entries, entry)
+	}
+	sort.Slice(stored.Entries, func(i, j int) bool {
+		return stored.Entries[i].CreatedAt.Before(stored.Entries[j].CreatedAt)
+	})
+
+	return jsonfile.Save(s.path, stored)
+}
+
+// load reads saved starboards and entries
+func (s *Store) load() error {
+	var stored storedStarboard
+	if found, err := jsonfile.Load(s.path, &stored); err != nil || !found {
+		return err
+	}
+
+	for _, board := range stored.Boards {
+		s.boards[board.GuildID] = board
+	}
+	for _, entry := range stored.Entries {
+		s.entries[entry.MessageID] = entry
+	}
+	return nil
+}
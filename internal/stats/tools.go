@@ -0,0 +1,98 @@
+// Package stats counts tool calls since startup, so users can see what
+// their agent has been doing and how well it went.
+package stats
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ToolStats are the totals of one tool
+type ToolStats struct {
+	Tool   string `json:"tool"`
+	Calls  int64  `json:"calls"`
+	Errors int64  `json:"errors"`
+	// ErrorRate is the share of calls that failed, from 0 to 1
+	ErrorRate    float64 `json:"error_rate"`
+	AvgLatencyMs int64   `json:"avg_latency_ms"`
+	MaxLatencyMs int64   `json:"max_latency_ms"`
+}
+
+// counters are the running totals of one tool
+type counters struct {
+	calls, errors       int64
+	latency, maxLatency time.Duration
+}
+
+// Tools counts calls per tool
+type Tools struct {
+	mutex sync.Mutex
+	tools map[string]*counters
+}
+
+// NewTools creates an empty tool call counter
+func NewTools() *Tools {
+	return &Tools{tools: make(map[string]*counters)}
+}
+
+// Record counts a call to a tool that took duration
+func (t *Tools) Record(tool string, failed bool, duration time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	c, ok := t.tools[tool]
+	if !ok {
+		c = &counters{}
+		t.tools[tool] = c
+	}
+	c.calls++
+	if failed {
+		c.errors++
+	}
+	c.latency += duration
+	if duration > c.maxLatency {
+		c.maxLatency = duration
+	}
+}
+
+// Snapshot returns the totals of every called tool, most called first, and
+// the totals over all tools
+func (t *Tools) Snapshot() ([]ToolStats, ToolStats) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	tools := make([]ToolStats, 0, len(t.tools))
+	total := counters{}
+	for name, c := range t.tools {
+		tools = append(tools, c.stats(name))
+		total.calls += c.calls
+		total.errors += c.errors
+		total.latency += c.latency
+		if c.maxLatency > total.maxLatency {
+			total.maxLatency = c.maxLatency
+		}
+	}
+	sort.Slice(tools, func(i, j int) bool {
+		if tools[i].Calls != tools[j].Calls {
+			return tools[i].Calls > tools[j].Calls
+		}
+		return tools[i].Tool < tools[j].Tool
+	})
+	return tools, total.stats("")
+}
+
+// stats converts running totals to reported totals
+func (c *counters) stats(tool string) ToolStats {
+	s := ToolStats{
+		Tool:         tool,
+		Calls:        c.calls,
+		Errors:       c.errors,
+		MaxLatencyMs: c.maxLatency.Milliseconds(),
+	}
+	if c.calls > 0 {
+		s.ErrorRate = float64(c.errors) / float64(c.calls)
+		s.AvgLatencyMs = (c.latency / time.Duration(c.calls)).Milliseconds()
+	}
+	return s
+}
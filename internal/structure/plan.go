@@ -0,0 +1,633 @@
+package structure
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Change actions
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionReorder = "reorder"
+)
+
+// Change kinds
+const (
+	KindRole     = "role"
+	KindCategory = "category"
+	KindChannel  = "channel"
+)
+
+// Change is one step of a plan
+type Change struct {
+	Action string `json:"action"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name,omitempty"`
+	ID     string `json:"id,omitempty"`
+	// Category is the category a created or moved channel ends up in
+	Category string `json:"category,omitempty"`
+	// Fields lists what an update changes
+	Fields []string `json:"fields,omitempty"`
+
+	role     *Role
+	category *Category
+	channel  *Channel
+	// stale lists the current overwrite targets, cleared one by one when a
+	// layout removes every overwrite
+	stale []string
+}
+
+// Plan is the ordered list of changes that make a guild match a layout:
+// roles first, so overwrites can refer to new roles, then categories,
+// channels, ordering, and finally deletions.
+type Plan struct {
+	GuildID string
+	Changes []Change
+
+	roleIDs     map[string]string
+	categoryIDs map[string]string
+	channelIDs  map[string]string
+	desired     *Layout
+}
+
+// Session is the part of the Discord API that Apply uses
+type Session interface {
+	GuildRoleCreate(guildID string, data *discordgo.RoleParams, options ...discordgo.RequestOption) (*discordgo.Role, error)
+	GuildRoleEdit(guildID, roleID string, data *discordgo.RoleParams, options ...discordgo.RequestOption) (*discordgo.Role, error)
+	GuildRoleReorder(guildID string, roles []*discordgo.Role, options ...discordgo.RequestOption) ([]*discordgo.Role, error)
+	GuildRoleDelete(guildID, roleID string, options ...discordgo.RequestOption) error
+	GuildChannelCreateComplex(guildID string, data discordgo.GuildChannelCreateData, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	ChannelEdit(channelID string, data *discordgo.ChannelEdit, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	GuildChannelsReorder(guildID string, channels []*discordgo.Channel, options ...discordgo.RequestOption) error
+	ChannelDelete(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+	ChannelPermissionDelete(channelID, targetID string, options ...discordgo.RequestOption) error
+}
+
+// NewPlan compares a guild with a layout. Roles, categories and channels
+// are matched by name. Things the layout does not mention are deleted only
+// when prune is set; managed roles are never changed.
+func NewPlan(guildID string, desired *Layout, roles []*discordgo.Role, channels []*discordgo.Channel, prune bool) (*Plan, error) {
+	p := &Plan{
+		GuildID:     guildID,
+		roleIDs:     map[string]string{EveryoneRole: guildID},
+		categoryIDs: make(map[string]string),
+		channelIDs:  make(map[string]string),
+		desired:     desired,
+	}
+	names := roleNames(guildID, roles)
+
+	// Roles
+	currentRoles := make(map[string]*discordgo.Role)
+	var roleOrder []string
+	sorted := make([]*discordgo.Role, len(roles))
+	copy(sorted, roles)
+	sort.SliceStable(sorted, func(i, j int) bool { return roleAbove(sorted[i], sorted[j]) })
+	for _, role := range sorted {
+		name := names[role.ID]
+		if _, ok := currentRoles[name]; ok {
+			continue
+		}
+		currentRoles[name] = role
+		p.roleIDs[name] = role.ID
+		if !role.Managed && name != EveryoneRole {
+			roleOrder = append(roleOrder, name)
+		}
+	}
+
+	desiredRoles := make(map[string]bool)
+	var wantOrder, created []string
+	for i := range desired.Roles {
+		role := &desired.Roles[i]
+		desiredRoles[role.Name] = true
+		if role.Managed {
+			continue
+		}
+		if role.Name != EveryoneRole {
+			wantOrder = append(wantOrder, role.Name)
+		}
+
+		current, ok := currentRoles[role.Name]
+		if !ok {
+			if role.Name == EveryoneRole {
+				continue
+			}
+			p.Changes = append(p.Changes, Change{Action: ActionCreate, Kind: KindRole, Name: role.Name, role: role})
+			created = append(created, role.Name)
+			continue
+		}
+		if current.Managed {
+			continue
+		}
+		if fields := roleFields(fromRole(guildID, current), *role); len(fields) > 0 {
+			p.Changes = append(p.Changes, Change{Action: ActionUpdate, Kind: KindRole, Name: role.Name, ID: current.ID, Fields: fields, role: role})
+		}
+	}
+	if !sameOrder(keep(roleOrder, wantOrder), created, wantOrder) {
+		p.Changes = append(p.Changes, Change{Action: ActionReorder, Kind: KindRole})
+	}
+
+	// Every overwrite must name a role that exists or will be created
+	known := func(name string) bool { return desiredRoles[name] || currentRoles[name] != nil }
+	for _, category := range desired.Categories {
+		if err := checkRoleNames(category.Overwrites, known); err != nil {
+			return nil, fmt.Errorf("category %s: %w", category.Name, err)
+		}
+	}
+	for _, channel := range desired.allChannels() {
+		if err := checkRoleNames(channel.Overwrites, known); err != nil {
+			return nil, fmt.Errorf("channel %s: %w", channel.Name, err)
+		}
+	}
+
+	// Categories
+	currentCategories := make(map[string]*discordgo.Channel)
+	var categoryOrder []string
+	for _, channel := range sortedChannels(channels) {
+		if channel.Type != discordgo.ChannelTypeGuildCategory {
+			continue
+		}
+		if _, ok := currentCategories[channel.Name]; !ok {
+			currentCategories[channel.Name] = channel
+			p.categoryIDs[channel.Name] = channel.ID
+			categoryOrder = append(categoryOrder, channel.Name)
+		}
+	}
+	categoryNames := make(map[string]string)
+	for name, channel := range currentCategories {
+		categoryNames[channel.ID] = name
+	}
+
+	var wantCategories, createdCategories []string
+	desiredCategories := make(map[string]bool)
+	for i := range desired.Categories {
+		category := &desired.Categories[i]
+		desiredCategories[category.Name] = true
+		wantCategories = append(wantCategories, category.Name)
+		current, ok := currentCategories[category.Name]
+		if !ok {
+			p.Changes = append(p.Changes, Change{Action: ActionCreate, Kind: KindCategory, Name: category.Name, category: category})
+			createdCategories = append(createdCategories, category.Name)
+			continue
+		}
+		if !sameOverwrites(fromOverwrites(current.PermissionOverwrites, names), category.Overwrites) {
+			p.Changes = append(p.Changes, Change{Action: ActionUpdate, Kind: KindCategory, Name: category.Name, ID: current.ID,
+				Fields: []string{"overwrites"}, category: category, stale: overwriteTargets(current)})
+		}
+	}
+	reorder := !sameOrder(keep(categoryOrder, wantCategories), createdCategories, wantCategories)
+
+	// Channels, matched by name across categories so a moved channel keeps
+	// its history
+	currentChannels := make(map[string]*discordgo.Channel)
+	currentOrder := make(map[string][]string)
+	for _, channel := range sortedChannels(channels) {
+		if _, ok := fromChannel(channel, names); !ok {
+			continue
+		}
+		if _, ok := currentChannels[channel.Name]; ok {
+			continue
+		}
+		currentChannels[channel.Name] = channel
+		p.channelIDs[channel.Name] = channel.ID
+		parent := categoryNames[channel.ParentID]
+		currentOrder[parent] = append(currentOrder[parent], channel.Name)
+	}
+
+	desiredChannels := make(map[string]bool)
+	planChannels := func(categoryName string, list []Channel) error {
+		var want, added []string
+		for i := range list {
+			channel := &list[i]
+			desiredChannels[channel.Name] = true
+			want = append(want, channel.Name)
+			current, ok := currentChannels[channel.Name]
+			if !ok {
+				p.Changes = append(p.Changes, Change{Action: ActionCreate, Kind: KindChannel, Name: channel.Name,
+					Category: categoryName, channel: channel})
+				added = append(added, channel.Name)
+				continue
+			}
+
+			converted, _ := fromChannel(current, names)
+			if converted.Type != channel.Type {
+				return fmt.Errorf("channel %s is %s, not %s; Discord cannot change a channel's type, so rename or delete it first",
+					channel.Name, converted.Type, channel.Type)
+			}
+			fields := channelFields(converted, *channel)
+			if categoryNames[current.ParentID] != categoryName {
+				fields = append(fields, "category")
+				added = append(added, channel.Name)
+			}
+			if len(fields) > 0 {
+				p.Changes = append(p.Changes, Change{Action: ActionUpdate, Kind: KindChannel, Name: channel.Name, ID: current.ID,
+					Category: categoryName, Fields: fields, channel: channel, stale: overwriteTargets(current)})
+			}
+		}
+		if !sameOrder(keep(currentOrder[categoryName], want), added, want) {
+			reorder = true
+		}
+		return nil
+	}
+	for i := range desired.Categories {
+		if err := planChannels(desired.Categories[i].Name, desired.Categories[i].Channels); err != nil {
+			return nil, err
+		}
+	}
+	if err := planChannels("", desired.Channels); err != nil {
+		return nil, err
+	}
+	if reorder {
+		p.Changes = append(p.Changes, Change{Action: ActionReorder, Kind: KindChannel})
+	}
+
+	// Deletions, channels first so categories are empty when removed
+	if prune {
+		for _, channel := range sortedChannels(channels) {
+			if current, ok := currentChannels[channel.Name]; ok && current.ID == channel.ID && !desiredChannels[channel.Name] {
+				p.Changes = append(p.Changes, Change{Action: ActionDelete, Kind: KindChannel, Name: channel.Name, ID: channel.ID})
+			}
+		}
+		for _, name := range categoryOrder {
+			if !desiredCategories[name] {
+				p.Changes = append(p.Changes, Change{Action: ActionDelete, Kind: KindCategory, Name: name, ID: currentCategories[name].ID})
+			}
+		}
+		for _, name := range roleOrder {
+			if !desiredRoles[name] {
+				p.Changes = append(p.Changes, Change{Action: ActionDelete, Kind: KindRole, Name: name, ID: currentRoles[name].ID})
+			}
+		}
+	}
+	return p, nil
+}
+
+// Apply makes the planned changes in order, stopping at the first failure.
+// retry wraps each API call, and options, such as the audit log reason, go
+// with each of them. It returns the changes that were applied.
+func (p *Plan) Apply(session Session, retry func(func() error) error, options ...discordgo.RequestOption) ([]Change, error) {
+	var applied []Change
+	for _, change := range p.Changes {
+		if err := p.apply(session, retry, &change, options); err != nil {
+			return applied, fmt.Errorf("%s %s %s: %w", change.Action, change.Kind, change.Name, err)
+		}
+		applied = append(applied, change)
+	}
+	return applied, nil
+}
+
+func (p *Plan) apply(session Session, retry func(func() error) error, change *Change, options []discordgo.RequestOption) error {
+	switch change.Kind + "/" + change.Action {
+	case KindRole + "/" + ActionCreate, KindRole + "/" + ActionUpdate:
+		params, err := roleParams(change.role)
+		if err != nil {
+			return err
+		}
+		var role *discordgo.Role
+		err = retry(func() (err error) {
+			if change.Action == ActionCreate {
+				role, err = session.GuildRoleCreate(p.GuildID, params, options...)
+			} else {
+				role, err = session.GuildRoleEdit(p.GuildID, change.ID, params, options...)
+			}
+			return err
+		})
+		if err == nil && change.Action == ActionCreate {
+			change.ID = role.ID
+			p.roleIDs[change.Name] = role.ID
+		}
+		return err
+
+	case KindRole + "/" + ActionReorder:
+		var roles []*discordgo.Role
+		var order []string
+		for _, role := range p.desired.Roles {
+			if !role.Managed && role.Name != EveryoneRole {
+				order = append(order, role.Name)
+			}
+		}
+		for i, name := range order {
+			roles = append(roles, &discordgo.Role{ID: p.roleIDs[name], Position: len(order) - i})
+		}
+		return retry(func() error {
+			_, err := session.GuildRoleReorder(p.GuildID, roles, options...)
+			return err
+		})
+
+	case KindCategory + "/" + ActionCreate, KindChannel + "/" + ActionCreate:
+		data, err := p.createData(change)
+		if err != nil {
+			return err
+		}
+		var channel *discordgo.Channel
+		err = retry(func() (err error) {
+			channel, err = session.GuildChannelCreateComplex(p.GuildID, data, options...)
+			return err
+		})
+		if err == nil {
+			change.ID = channel.ID
+			if change.Kind == KindCategory {
+				p.categoryIDs[change.Name] = channel.ID
+			} else {
+				p.channelIDs[change.Name] = channel.ID
+			}
+		}
+		return err
+
+	case KindCategory + "/" + ActionUpdate, KindChannel + "/" + ActionUpdate:
+		edit, err := p.channelEdit(change)
+		if err != nil {
+			return err
+		}
+		if err := retry(func() error {
+			_, err := session.ChannelEdit(change.ID, edit, options...)
+			return err
+		}); err != nil {
+			return err
+		}
+		// The API client omits an empty overwrite list, so removing every
+		// overwrite takes one call per target
+		if len(edit.PermissionOverwrites) == 0 && contains(change.Fields, "overwrites") {
+			for _, target := range change.stale {
+				if err := retry(func() error {
+					return session.ChannelPermissionDelete(change.ID, target, options...)
+				}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+
+	case KindChannel + "/" + ActionReorder:
+		var channels []*discordgo.Channel
+		for i, category := range p.desired.Categories {
+			channels = append(channels, &discordgo.Channel{ID: p.categoryIDs[category.Name], Position: i})
+			for j, channel := range category.Channels {
+				channels = append(channels, &discordgo.Channel{ID: p.channelIDs[channel.Name], Position: j})
+			}
+		}
+		for j, channel := range p.desired.Channels {
+			channels = append(channels, &discordgo.Channel{ID: p.channelIDs[channel.Name], Position: j})
+		}
+		return retry(func() error {
+			return session.GuildChannelsReorder(p.GuildID, channels, options...)
+		})
+
+	case KindRole + "/" + ActionDelete:
+		return retry(func() error {
+			return session.GuildRoleDelete(p.GuildID, change.ID, options...)
+		})
+
+	case KindCategory + "/" + ActionDelete, KindChannel + "/" + ActionDelete:
+		return retry(func() error {
+			_, err := session.ChannelDelete(change.ID, options...)
+			return err
+		})
+	}
+	return fmt.Errorf("unsupported change")
+}
+
+func roleParams(role *Role) (*discordgo.RoleParams, error) {
+	color, err := parseColor(role.Color)
+	if err != nil {
+		return nil, err
+	}
+	perms, err := permissionBits(role.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	params := &discordgo.RoleParams{Permissions: &perms}
+	// @everyone can only change its permissions
+	if role.Name != EveryoneRole {
+		params.Name = role.Name
+		params.Color = &color
+		params.Hoist = &role.Hoist
+		params.Mentionable = &role.Mentionable
+	}
+	return params, nil
+}
+
+func (p *Plan) createData(change *Change) (discordgo.GuildChannelCreateData, error) {
+	if change.Kind == KindCategory {
+		overwrites, err := p.overwrites(change.category.Overwrites)
+		return discordgo.GuildChannelCreateData{
+			Name:                 change.Name,
+			Type:                 discordgo.ChannelTypeGuildCategory,
+			PermissionOverwrites: overwrites,
+		}, err
+	}
+
+	channel := change.channel
+	overwrites, err := p.overwrites(channel.Overwrites)
+	return discordgo.GuildChannelCreateData{
+		Name:                 channel.Name,
+		Type:                 channelTypes[channel.Type],
+		Topic:                channel.Topic,
+		NSFW:                 channel.NSFW,
+		RateLimitPerUser:     channel.Slowmode,
+		Bitrate:              channel.Bitrate,
+		UserLimit:            channel.UserLimit,
+		ParentID:             p.categoryIDs[change.Category],
+		PermissionOverwrites: overwrites,
+	}, err
+}
+
+func (p *Plan) channelEdit(change *Change) (*discordgo.ChannelEdit, error) {
+	if change.Kind == KindCategory {
+		overwrites, err := p.overwrites(change.category.Overwrites)
+		return &discordgo.ChannelEdit{PermissionOverwrites: overwrites}, err
+	}
+
+	channel := change.channel
+	edit := &discordgo.ChannelEdit{}
+	for _, field := range change.Fields {
+		switch field {
+		case "topic":
+			edit.Topic = channel.Topic
+		case "nsfw":
+			edit.NSFW = &channel.NSFW
+		case "slowmode":
+			edit.RateLimitPerUser = &channel.Slowmode
+		case "bitrate":
+			edit.Bitrate = channel.Bitrate
+		case "user_limit":
+			edit.UserLimit = channel.UserLimit
+		case "category":
+			edit.ParentID = p.categoryIDs[change.Category]
+		case "overwrites":
+			overwrites, err := p.overwrites(channel.Overwrites)
+			if err != nil {
+				return nil, err
+			}
+			edit.PermissionOverwrites = overwrites
+		}
+	}
+	return edit, nil
+}
+
+// overwrites converts layout overwrites to Discord's, looking up role IDs
+// that may have been created earlier in the plan
+func (p *Plan) overwrites(list []Overwrite) ([]*discordgo.PermissionOverwrite, error) {
+	converted := make([]*discordgo.PermissionOverwrite, 0, len(list))
+	for _, o := range list {
+		allow, err := permissionBits(o.Allow)
+		if err != nil {
+			return nil, err
+		}
+		deny, err := permissionBits(o.Deny)
+		if err != nil {
+			return nil, err
+		}
+		entry := &discordgo.PermissionOverwrite{ID: o.Member, Type: discordgo.PermissionOverwriteTypeMember, Allow: allow, Deny: deny}
+		if o.Role != "" {
+			id, ok := p.roleIDs[o.Role]
+			if !ok {
+				return nil, fmt.Errorf("unknown role %q in overwrites", o.Role)
+			}
+			entry.ID, entry.Type = id, discordgo.PermissionOverwriteTypeRole
+		}
+		converted = append(converted, entry)
+	}
+	return converted, nil
+}
+
+// allChannels lists every channel of a layout
+func (l *Layout) allChannels() []Channel {
+	all := append([]Channel(nil), l.Channels...)
+	for _, category := range l.Categories {
+		all = append(all, category.Channels...)
+	}
+	return all
+}
+
+// roleFields lists the fields that differ between two roles
+func roleFields(current, desired Role) []string {
+	var fields []string
+	if desired.Name != EveryoneRole {
+		if !sameColor(current.Color, desired.Color) {
+			fields = append(fields, "color")
+		}
+		if current.Hoist != desired.Hoist {
+			fields = append(fields, "hoist")
+		}
+		if current.Mentionable != desired.Mentionable {
+			fields = append(fields, "mentionable")
+		}
+	}
+	if !samePermissions(current.Permissions, desired.Permissions) {
+		fields = append(fields, "permissions")
+	}
+	return fields
+}
+
+// channelFields lists the fields that differ between two channels. An empty
+// topic and a zero bitrate or user limit leave the current value unchanged.
+func channelFields(current, desired Channel) []string {
+	var fields []string
+	if desired.Topic != "" && current.Topic != desired.Topic {
+		fields = append(fields, "topic")
+	}
+	if current.NSFW != desired.NSFW {
+		fields = append(fields, "nsfw")
+	}
+	if current.Slowmode != desired.Slowmode {
+		fields = append(fields, "slowmode")
+	}
+	if desired.Bitrate != 0 && current.Bitrate != desired.Bitrate {
+		fields = append(fields, "bitrate")
+	}
+	if desired.UserLimit != 0 && current.UserLimit != desired.UserLimit {
+		fields = append(fields, "user_limit")
+	}
+	if !sameOverwrites(current.Overwrites, desired.Overwrites) {
+		fields = append(fields, "overwrites")
+	}
+	return fields
+}
+
+func sameColor(a, b string) bool {
+	x, _ := parseColor(a)
+	y, _ := parseColor(b)
+	return x == y
+}
+
+func samePermissions(a, b []string) bool {
+	x, _ := permissionBits(a)
+	y, _ := permissionBits(b)
+	return x == y
+}
+
+// sameOverwrites compares overwrite sets regardless of order
+func sameOverwrites(a, b []Overwrite) bool {
+	index := func(list []Overwrite) map[string][2]int64 {
+		m := make(map[string][2]int64, len(list))
+		for _, o := range list {
+			allow, _ := permissionBits(o.Allow)
+			deny, _ := permissionBits(o.Deny)
+			m["role:"+o.Role+"/member:"+o.Member] = [2]int64{allow, deny}
+		}
+		return m
+	}
+	return reflect.DeepEqual(index(a), index(b))
+}
+
+// overwriteTargets lists the role and member IDs a channel has overwrites for
+func overwriteTargets(channel *discordgo.Channel) []string {
+	targets := make([]string, len(channel.PermissionOverwrites))
+	for i, o := range channel.PermissionOverwrites {
+		targets[i] = o.ID
+	}
+	return targets
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func checkRoleNames(overwrites []Overwrite, known func(string) bool) error {
+	for _, o := range overwrites {
+		if o.Role != "" && !known(o.Role) {
+			return fmt.Errorf("overwrite refers to unknown role %q", o.Role)
+		}
+	}
+	return nil
+}
+
+// keep returns the names in order that also appear in want
+func keep(order, want []string) []string {
+	wanted := make(map[string]bool, len(want))
+	for _, name := range want {
+		wanted[name] = true
+	}
+	var kept []string
+	for _, name := range order {
+		if wanted[name] {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
+// sameOrder reports whether the existing order, with added names placed at
+// the end as Discord does, already matches want
+func sameOrder(existing, added, want []string) bool {
+	got := append(append([]string(nil), existing...), added...)
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
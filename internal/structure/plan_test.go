@@ -0,0 +1,128 @@
+package structure
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// recordingSession records the audit log reason of every call
+type recordingSession struct {
+	calls   []string
+	reasons []string
+	nextID  int
+}
+
+func (s *recordingSession) record(call string, options []discordgo.RequestOption) {
+	cfg := &discordgo.RequestConfig{Request: &http.Request{Header: http.Header{}}}
+	for _, option := range options {
+		option(cfg)
+	}
+	s.calls = append(s.calls, call)
+	s.reasons = append(s.reasons, cfg.Request.Header.Get("X-Audit-Log-Reason"))
+}
+
+func (s *recordingSession) id() string {
+	s.nextID++
+	return strconv.Itoa(900 + s.nextID)
+}
+
+func (s *recordingSession) GuildRoleCreate(guildID string, data *discordgo.RoleParams, options ...discordgo.RequestOption) (*discordgo.Role, error) {
+	s.record("GuildRoleCreate", options)
+	return &discordgo.Role{ID: s.id(), Name: data.Name}, nil
+}
+
+func (s *recordingSession) GuildRoleEdit(guildID, roleID string, data *discordgo.RoleParams, options ...discordgo.RequestOption) (*discordgo.Role, error) {
+	s.record("GuildRoleEdit", options)
+	return &discordgo.Role{ID: roleID}, nil
+}
+
+func (s *recordingSession) GuildRoleReorder(guildID string, roles []*discordgo.Role, options ...discordgo.RequestOption) ([]*discordgo.Role, error) {
+	s.record("GuildRoleReorder", options)
+	return roles, nil
+}
+
+func (s *recordingSession) GuildRoleDelete(guildID, roleID string, options ...discordgo.RequestOption) error {
+	s.record("GuildRoleDelete", options)
+	return nil
+}
+
+func (s *recordingSession) GuildChannelCreateComplex(guildID string, data discordgo.GuildChannelCreateData, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	s.record("GuildChannelCreateComplex", options)
+	return &discordgo.Channel{ID: s.id(), Name: data.Name}, nil
+}
+
+func (s *recordingSession) ChannelEdit(channelID string, data *discordgo.ChannelEdit, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	s.record("ChannelEdit", options)
+	return &discordgo.Channel{ID: channelID}, nil
+}
+
+func (s *recordingSession) GuildChannelsReorder(guildID string, channels []*discordgo.Channel, options ...discordgo.RequestOption) error {
+	s.record("GuildChannelsReorder", options)
+	return nil
+}
+
+func (s *recordingSession) ChannelDelete(channelID string, options ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	s.record("ChannelDelete", options)
+	return &discordgo.Channel{ID: channelID}, nil
+}
+
+func (s *recordingSession) ChannelPermissionDelete(channelID, targetID string, options ...discordgo.RequestOption) error {
+	s.record("ChannelPermissionDelete", options)
+	return nil
+}
+
+func TestApplySendsAuditLogReason(t *testing.T) {
+	const guildID = "100"
+	roles := []*discordgo.Role{
+		{ID: guildID, Name: "@everyone"},
+		{ID: "200", Name: "Moderators", Position: 1},
+		{ID: "201", Name: "Old", Position: 2},
+	}
+	channels := []*discordgo.Channel{
+		{ID: "300", Name: "general", Type: discordgo.ChannelTypeGuildText, GuildID: guildID, Topic: "old"},
+		{ID: "301", Name: "stale", Type: discordgo.ChannelTypeGuildText, GuildID: guildID},
+	}
+	layout, err := Parse([]byte(`
+roles:
+  - name: Admins
+    color: "#ff0000"
+  - name: Moderators
+    hoist: true
+  - name: "@everyone"
+channels:
+  - name: general
+    type: text
+    topic: new
+  - name: announcements
+    type: text
+`))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	plan, err := NewPlan(guildID, layout, roles, channels, true)
+	if err != nil {
+		t.Fatalf("NewPlan: %v", err)
+	}
+	session := &recordingSession{}
+	retry := func(fn func() error) error { return fn() }
+	if _, err := plan.Apply(session, retry, discordgo.WithAuditLogReason("layout v2")); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, call := range session.calls {
+		seen[call] = true
+		if session.reasons[i] != "layout v2" {
+			t.Errorf("%s sent audit log reason %q", call, session.reasons[i])
+		}
+	}
+	for _, call := range []string{"GuildRoleCreate", "GuildRoleEdit", "GuildRoleDelete", "GuildChannelCreateComplex", "ChannelEdit", "ChannelDelete"} {
+		if !seen[call] {
+			t.Errorf("plan made no %s call: %v", call, session.calls)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+// Package structure converts a guild's roles, categories and channels to and
+// from a YAML layout, and plans the changes that make a guild match a layout.
+package structure
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"gopkg.in/yaml.v3"
+
+	"discord-mcp/internal/permissions"
+)
+
+// EveryoneRole is the layout name of the guild's @everyone role
+const EveryoneRole = "@everyone"
+
+// Layout is a guild's structure. Roles are listed highest first, and
+// categories and channels in their display order.
+type Layout struct {
+	Roles      []Role     `yaml:"roles" json:"roles"`
+	Categories []Category `yaml:"categories,omitempty" json:"categories,omitempty"`
+	// Channels lists the channels outside any category
+	Channels []Channel `yaml:"channels,omitempty" json:"channels,omitempty"`
+}
+
+// Role is a guild role. Managed roles belong to integrations and are listed
+// for reference only.
+type Role struct {
+	Name string `yaml:"name" json:"name"`
+	// Color is a hex color such as #5865F2
+	Color       string   `yaml:"color,omitempty" json:"color,omitempty"`
+	Hoist       bool     `yaml:"hoist,omitempty" json:"hoist,omitempty"`
+	Mentionable bool     `yaml:"mentionable,omitempty" json:"mentionable,omitempty"`
+	Permissions []string `yaml:"permissions,omitempty" json:"permissions,omitempty"`
+	Managed     bool     `yaml:"managed,omitempty" json:"managed,omitempty"`
+}
+
+// Category is a channel category and the channels inside it
+type Category struct {
+	Name       string      `yaml:"name" json:"name"`
+	Overwrites []Overwrite `yaml:"overwrites,omitempty" json:"overwrites,omitempty"`
+	Channels   []Channel   `yaml:"channels,omitempty" json:"channels,omitempty"`
+}
+
+// Channel is a guild channel
+type Channel struct {
+	Name string `yaml:"name" json:"name"`
+	// Type is text, voice, news, stage, forum or media
+	Type  string `yaml:"type" json:"type"`
+	Topic string `yaml:"topic,omitempty" json:"topic,omitempty"`
+	NSFW  bool   `yaml:"nsfw,omitempty" json:"nsfw,omitempty"`
+	// Slowmode is the per-user message interval in seconds
+	Slowmode int `yaml:"slowmode,omitempty" json:"slowmode,omitempty"`
+	// Bitrate of voice channels; 0 leaves it unchanged
+	Bitrate    int         `yaml:"bitrate,omitempty" json:"bitrate,omitempty"`
+	UserLimit  int         `yaml:"user_limit,omitempty" json:"user_limit,omitempty"`
+	Overwrites []Overwrite `yaml:"overwrites,omitempty" json:"overwrites,omitempty"`
+}
+
+// Overwrite is a channel permission overwrite for a role, named as in the
+// layout, or for a member by user ID
+type Overwrite struct {
+	Role   string   `yaml:"role,omitempty" json:"role,omitempty"`
+	Member string   `yaml:"member,omitempty" json:"member,omitempty"`
+	Allow  []string `yaml:"allow,omitempty" json:"allow,omitempty"`
+	Deny   []string `yaml:"deny,omitempty" json:"deny,omitempty"`
+}
+
+// channelTypes maps layout channel types to Discord's
+var channelTypes = map[string]discordgo.ChannelType{
+	"text":  discordgo.ChannelTypeGuildText,
+	"voice": discordgo.ChannelTypeGuildVoice,
+	"news":  discordgo.ChannelTypeGuildNews,
+	"stage": discordgo.ChannelTypeGuildStageVoice,
+	"forum": discordgo.ChannelTypeGuildForum,
+	"media": discordgo.ChannelTypeGuildMedia,
+}
+
+// ChannelTypes returns the channel types a layout can use
+func ChannelTypes() []string {
+	names := make([]string, 0, len(channelTypes))
+	for name := range channelTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FromGuild builds the layout of a guild. Threads and channel types a layout
+// cannot express are left out.
+func FromGuild(guildID string, roles []*discordgo.Role, channels []*discordgo.Channel) *Layout {
+	layout := &Layout{}
+	names := roleNames(guildID, roles)
+
+	sorted := make([]*discordgo.Role, len(roles))
+	copy(sorted, roles)
+	sort.SliceStable(sorted, func(i, j int) bool { return roleAbove(sorted[i], sorted[j]) })
+	for _, role := range sorted {
+		layout.Roles = append(layout.Roles, fromRole(guildID, role))
+	}
+
+	categories := make(map[string]int)
+	for _, channel := range sortedChannels(channels) {
+		if channel.Type == discordgo.ChannelTypeGuildCategory {
+			categories[channel.ID] = len(layout.Categories)
+			layout.Categories = append(layout.Categories, Category{
+				Name:       channel.Name,
+				Overwrites: fromOverwrites(channel.PermissionOverwrites, names),
+			})
+		}
+	}
+	for _, channel := range sortedChannels(channels) {
+		converted, ok := fromChannel(channel, names)
+		if !ok {
+			continue
+		}
+		if index, ok := categories[channel.ParentID]; ok {
+			layout.Categories[index].Channels = append(layout.Categories[index].Channels, converted)
+		} else {
+			layout.Channels = append(layout.Channels, converted)
+		}
+	}
+	return layout
+}
+
+// Marshal encodes a layout as YAML
+func Marshal(layout *Layout) ([]byte, error) {
+	return yaml.Marshal(layout)
+}
+
+// Parse decodes and checks a YAML (or JSON) layout
+func Parse(data []byte) (*Layout, error) {
+	var layout Layout
+	if err := yaml.Unmarshal(data, &layout); err != nil {
+		return nil, fmt.Errorf("invalid layout: %w", err)
+	}
+	if err := layout.check(); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+// check reports the first problem that would make a layout ambiguous or
+// impossible to apply
+func (l *Layout) check() error {
+	roles := make(map[string]bool)
+	for i, role := range l.Roles {
+		if strings.TrimSpace(role.Name) == "" {
+			return fmt.Errorf("roles[%d]: name must not be empty", i)
+		}
+		if roles[role.Name] {
+			return fmt.Errorf("roles[%d]: duplicate role %q", i, role.Name)
+		}
+		roles[role.Name] = true
+		if _, err := parseColor(role.Color); err != nil {
+			return fmt.Errorf("roles[%d] (%s): %w", i, role.Name, err)
+		}
+		if _, err := permissionBits(role.Permissions); err != nil {
+			return fmt.Errorf("roles[%d] (%s): %w", i, role.Name, err)
+		}
+	}
+
+	categories := make(map[string]bool)
+	channels := make(map[string]bool)
+	checkChannels := func(at string, list []Channel) error {
+		for i, channel := range list {
+			where := fmt.Sprintf("%s[%d]", at, i)
+			if strings.TrimSpace(channel.Name) == "" {
+				return fmt.Errorf("%s: name must not be empty", where)
+			}
+			if _, ok := channelTypes[channel.Type]; !ok {
+				return fmt.Errorf("%s (%s): type must be one of %s, got %q", where, channel.Name, strings.Join(ChannelTypes(), ", "), channel.Type)
+			}
+			if channels[channel.Name] {
+				return fmt.Errorf("%s: duplicate channel %q", where, channel.Name)
+			}
+			channels[channel.Name] = true
+			if err := checkOverwrites(channel.Overwrites); err != nil {
+				return fmt.Errorf("%s (%s): %w", where, channel.Name, err)
+			}
+		}
+		return nil
+	}
+
+	for i, category := range l.Categories {
+		if strings.TrimSpace(category.Name) == "" {
+			return fmt.Errorf("categories[%d]: name must not be empty", i)
+		}
+		if categories[category.Name] {
+			return fmt.Errorf("categories[%d]: duplicate category %q", i, category.Name)
+		}
+		categories[category.Name] = true
+		if err := checkOverwrites(category.Overwrites); err != nil {
+			return fmt.Errorf("categories[%d] (%s): %w", i, category.Name, err)
+		}
+		if err := checkChannels(fmt.Sprintf("categories[%d].channels", i), category.Channels); err != nil {
+			return err
+		}
+	}
+	return checkChannels("channels", l.Channels)
+}
+
+func checkOverwrites(overwrites []Overwrite) error {
+	for i, o := range overwrites {
+		if (o.Role == "") == (o.Member == "") {
+			return fmt.Errorf("overwrites[%d]: set exactly one of role or member", i)
+		}
+		if _, err := permissionBits(o.Allow); err != nil {
+			return fmt.Errorf("overwrites[%d].allow: %w", i, err)
+		}
+		if _, err := permissionBits(o.Deny); err != nil {
+			return fmt.Errorf("overwrites[%d].deny: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func fromRole(guildID string, role *discordgo.Role) Role {
+	converted := Role{
+		Name:        role.Name,
+		Hoist:       role.Hoist,
+		Mentionable: role.Mentionable,
+		Permissions: permissionList(role.Permissions),
+		Managed:     role.Managed,
+	}
+	if role.ID == guildID {
+		converted.Name = EveryoneRole
+	}
+	if role.Color != 0 {
+		converted.Color = fmt.Sprintf("#%06X", role.Color)
+	}
+	return converted
+}
+
+func fromChannel(channel *discordgo.Channel, roles map[string]string) (Channel, bool) {
+	var typeName string
+	for name, t := range channelTypes {
+		if t == channel.Type {
+			typeName = name
+		}
+	}
+	if typeName == "" {
+		return Channel{}, false
+	}
+
+	converted := Channel{
+		Name:       channel.Name,
+		Type:       typeName,
+		Topic:      channel.Topic,
+		NSFW:       channel.NSFW,
+		Slowmode:   channel.RateLimitPerUser,
+		Overwrites: fromOverwrites(channel.PermissionOverwrites, roles),
+	}
+	if isVoice(channel.Type) {
+		converted.Bitrate = channel.Bitrate
+		converted.UserLimit = channel.UserLimit
+	}
+	return converted, true
+}
+
+// fromOverwrites converts overwrites in a stable order: roles by name, then
+// members by ID. Overwrites for deleted roles are dropped.
+func fromOverwrites(overwrites []*discordgo.PermissionOverwrite, roles map[string]string) []Overwrite {
+	var converted []Overwrite
+	for _, o := range overwrites {
+		entry := Overwrite{Allow: permissionList(o.Allow), Deny: permissionList(o.Deny)}
+		if o.Type == discordgo.PermissionOverwriteTypeMember {
+			entry.Member = o.ID
+		} else if name, ok := roles[o.ID]; ok {
+			entry.Role = name
+		} else {
+			continue
+		}
+		converted = append(converted, entry)
+	}
+	sort.SliceStable(converted, func(i, j int) bool {
+		if (converted[i].Role == "") != (converted[j].Role == "") {
+			return converted[i].Role != ""
+		}
+		return converted[i].Role+converted[i].Member < converted[j].Role+converted[j].Member
+	})
+	return converted
+}
+
+// roleNames maps role IDs to layout names
+func roleNames(guildID string, roles []*discordgo.Role) map[string]string {
+	names := make(map[string]string, len(roles))
+	for _, role := range roles {
+		names[role.ID] = role.Name
+	}
+	names[guildID] = EveryoneRole
+	return names
+}
+
+// sortedChannels orders channels as Discord displays them
+func sortedChannels(channels []*discordgo.Channel) []*discordgo.Channel {
+	sorted := make([]*discordgo.Channel, len(channels))
+	copy(sorted, channels)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		// Voice-like channels sort after text-like ones in the same category
+		if isVoice(sorted[i].Type) != isVoice(sorted[j].Type) {
+			return !isVoice(sorted[i].Type)
+		}
+		if sorted[i].Position != sorted[j].Position {
+			return sorted[i].Position < sorted[j].Position
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	return sorted
+}
+
+func isVoice(t discordgo.ChannelType) bool {
+	return t == discordgo.ChannelTypeGuildVoice || t == discordgo.ChannelTypeGuildStageVoice
+}
+
+// roleAbove orders roles highest first, ties broken by ID as Discord does
+func roleAbove(a, b *discordgo.Role) bool {
+	if a.Position != b.Position {
+		return a.Position > b.Position
+	}
+	return a.ID < b.ID
+}
+
+// permissionList names the bits in a permission set. Bits without a name are
+// kept as a decimal number so they survive a round trip.
+func permissionList(bits int64) []string {
+	names := permissions.PermissionNames(bits)
+	if unknown := bits &^ permissions.KnownPermissions(); unknown != 0 {
+		names = append(names, strconv.FormatInt(unknown, 10))
+	}
+	return names
+}
+
+// permissionBits is the inverse of permissionList
+func permissionBits(names []string) (int64, error) {
+	var bits int64
+	var named []string
+	for _, name := range names {
+		if n, err := strconv.ParseInt(name, 10, 64); err == nil {
+			bits |= n
+			continue
+		}
+		named = append(named, name)
+	}
+	known, err := permissions.PermissionBits(named)
+	return bits | known, err
+}
+
+// parseColor parses a #RRGGBB color; empty means no color
+func parseColor(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseUint(strings.TrimPrefix(s, "#"), 16, 32)
+	if err != nil || value > 0xFFFFFF {
+		return 0, fmt.Errorf("color must be a hex color such as #5865F2, got %q", s)
+	}
+	return int(value), nil
+}
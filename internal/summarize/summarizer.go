@@ -0,0 +1,169 @@
+// Package summarize hands message transcripts to an external summarizer, a
+// local command or an HTTP endpoint, so tools can return a short summary of
+// histories too long for a client's context.
+package summarize
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"discord-mcp/internal/config"
+)
+
+// maxSummaryBytes bounds the summary a backend may return
+const maxSummaryBytes = 1024 * 1024
+
+// Request is what a summarizer is asked to condense
+type Request struct {
+	// Text is the transcript, one "[time] author: content" line per message
+	Text string `json:"text"`
+	// Instructions tell the summarizer what to focus on
+	Instructions string `json:"instructions,omitempty"`
+	// Source names what was read, such as "channel:123"
+	Source       string `json:"source"`
+	MessageCount int    `json:"message_count"`
+}
+
+// Summarizer condenses a transcript
+type Summarizer interface {
+	Summarize(ctx context.Context, req Request) (string, error)
+}
+
+// NewSummarizer creates the configured backend, or nil if none is set
+func NewSummarizer(cfg config.SummarizerConfig) (Summarizer, error) {
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case "command":
+		if len(cfg.Command) == 0 {
+			return nil, fmt.Errorf("summarizer.command is required for the command backend")
+		}
+		return &CommandSummarizer{Command: cfg.Command}, nil
+	case "http":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("summarizer.url is required for the http backend")
+		}
+		return &HTTPSummarizer{URL: cfg.URL, Headers: cfg.Headers, client: &http.Client{}}, nil
+	default:
+		return nil, fmt.Errorf("unknown summarizer backend: %s", cfg.Backend)
+	}
+}
+
+// CommandSummarizer runs a local program that reads the transcript on stdin
+// and writes the summary to stdout. The "{instructions}" and "{source}"
+// placeholders in arguments are substituted.
+type CommandSummarizer struct {
+	Command []string
+}
+
+// Summarize runs the summarizer command
+func (s *CommandSummarizer) Summarize(ctx context.Context, req Request) (string, error) {
+	args := make([]string, len(s.Command)-1)
+	for i, arg := range s.Command[1:] {
+		arg = strings.ReplaceAll(arg, "{instructions}", req.Instructions)
+		args[i] = strings.ReplaceAll(arg, "{source}", req.Source)
+	}
+
+	cmd := exec.CommandContext(ctx, s.Command[0], args...)
+	cmd.Stdin = strings.NewReader(req.Text)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("summarizer command failed: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() > maxSummaryBytes {
+		return "", fmt.Errorf("summary exceeds %d bytes", maxSummaryBytes)
+	}
+	summary := strings.TrimSpace(stdout.String())
+	if summary == "" {
+		return "", fmt.Errorf("summarizer command produced no summary")
+	}
+	return summary, nil
+}
+
+// HTTPSummarizer posts the request as JSON to a summarization endpoint. The
+// response is either JSON with a "summary" field or the summary as plain
+// text.
+type HTTPSummarizer struct {
+	URL     string
+	Headers map[string]string
+	client  *http.Client
+}
+
+// Summarize calls the summarization endpoint
+func (s *HTTPSummarizer) Summarize(ctx context.Context, req Request) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode summarizer request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to build summarizer request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for name, value := range s.Headers {
+		httpReq.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("failed to reach summarizer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSummaryBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read summarizer response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("summarizer returned HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(data))
+	}
+	if len(data) > maxSummaryBytes {
+		return "", fmt.Errorf("summary exceeds %d bytes", maxSummaryBytes)
+	}
+
+	summary := string(data)
+	var decoded struct {
+		Summary string `json:"summary"`
+	}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return "", fmt.Errorf("failed to decode summarizer response: %w", err)
+		}
+		summary = decoded.Summary
+	}
+	summary = strings.TrimSpace(summary)
+	if summary == "" {
+		return "", fmt.Errorf("summarizer returned no summary")
+	}
+	return summary, nil
+}
+
+// Fit trims a transcript to at most maxChars by dropping its oldest lines,
+// returning the text and the number of lines dropped. A maxChars of zero or
+// less keeps everything.
+func Fit(lines []string, maxChars int) (string, int) {
+	if maxChars <= 0 {
+		return strings.Join(lines, "\n"), 0
+	}
+	used := 0
+	keepFrom := len(lines)
+	for i := len(lines) - 1; i >= 0; i-- {
+		size := len(lines[i]) + 1
+		if used+size > maxChars {
+			break
+		}
+		used += size
+		keepFrom = i
+	}
+	return strings.Join(lines[keepFrom:], "\n"), keepFrom
+}
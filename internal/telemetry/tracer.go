@@ -0,0 +1,223 @@
+// Package telemetry exports OpenTelemetry traces over OTLP/HTTP with JSON
+// encoding, so operators can see where the time of each request goes. A
+// span is started for every JSON-RPC request, and the permission checks
+// and Discord REST calls made while it runs become its children.
+//
+// Requests are handled one at a time, so the request span being handled is
+// the parent of every span started meanwhile. Work outside requests, such
+// as event handlers and schedules, is not traced.
+//
+// A nil *Tracer and a nil *Span are valid and do nothing, so callers need
+// not check whether telemetry is enabled.
+package telemetry
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	mathrand "math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Span kinds, as numbered by OTLP
+const (
+	KindInternal = 1
+	KindServer   = 2
+	KindClient   = 3
+)
+
+// Options configure a tracer
+type Options struct {
+	// Endpoint is the OTLP/HTTP traces URL, e.g.
+	// http://localhost:4318/v1/traces
+	Endpoint string
+	// Headers are sent with every export, e.g. for authentication
+	Headers        map[string]string
+	ServiceName    string
+	ServiceVersion string
+	// SampleRatio is the share of requests traced, from 0 to 1
+	SampleRatio float64
+	// FlushInterval is how often finished spans are exported
+	FlushInterval time.Duration
+	// MaxQueue bounds the finished spans waiting for export; the oldest
+	// are dropped beyond it
+	MaxQueue int
+}
+
+// Span is a timed operation within a trace
+type Span struct {
+	tracer   *Tracer
+	traceID  string
+	spanID   string
+	parentID string
+	name     string
+	kind     int
+	start    time.Time
+	end      time.Time
+
+	mutex      sync.Mutex
+	attributes map[string]interface{}
+	errMessage string
+	ended      bool
+}
+
+// Tracer starts spans and exports them in the background
+type Tracer struct {
+	opts   Options
+	logger *logrus.Logger
+	client *http.Client
+
+	mutex   sync.Mutex
+	active  *Span
+	queue   []*Span
+	dropped int64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewTracer creates a tracer and starts exporting
+func NewTracer(opts Options, logger *logrus.Logger) *Tracer {
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = 5 * time.Second
+	}
+	if opts.MaxQueue < 1 {
+		opts.MaxQueue = 1
+	}
+	t := &Tracer{
+		opts:   opts,
+		logger: logger,
+		client: &http.Client{Timeout: 10 * time.Second},
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go t.exportLoop()
+	return t
+}
+
+// StartRequest starts the span of a request and makes it the parent of
+// the spans started until it ends. It returns nil when the request is not
+// sampled.
+func (t *Tracer) StartRequest(name string) *Span {
+	if t == nil || mathrand.Float64() >= t.opts.SampleRatio {
+		return nil
+	}
+	span := &Span{
+		tracer:     t,
+		traceID:    randomID(16),
+		spanID:     randomID(8),
+		name:       name,
+		kind:       KindServer,
+		start:      time.Now(),
+		attributes: map[string]interface{}{},
+	}
+
+	t.mutex.Lock()
+	t.active = span
+	t.mutex.Unlock()
+	return span
+}
+
+// Start starts a child span of the request being handled, or returns nil
+// when no traced request is running
+func (t *Tracer) Start(name string, kind int) *Span {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	parent := t.active
+	t.mutex.Unlock()
+	if parent == nil {
+		return nil
+	}
+	return &Span{
+		tracer:     t,
+		traceID:    parent.traceID,
+		spanID:     randomID(8),
+		parentID:   parent.spanID,
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: map[string]interface{}{},
+	}
+}
+
+// SetAttribute sets a string, bool, integer or float attribute
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.attributes[key] = value
+}
+
+// SetError marks the span as failed
+func (s *Span) SetError(message string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.errMessage = message
+}
+
+// End finishes the span, marking it failed if err is not nil, and queues
+// it for export
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	if err != nil {
+		s.errMessage = err.Error()
+	}
+	s.mutex.Unlock()
+
+	s.tracer.finish(s)
+}
+
+// finish queues an ended span and clears it as the parent of new spans
+func (t *Tracer) finish(span *Span) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.active == span {
+		t.active = nil
+	}
+	if len(t.queue) >= t.opts.MaxQueue {
+		t.queue = t.queue[1:]
+		t.dropped++
+	}
+	t.queue = append(t.queue, span)
+}
+
+// Close exports the queued spans and stops exporting
+func (t *Tracer) Close() {
+	if t == nil {
+		return
+	}
+	select {
+	case <-t.stop:
+		return
+	default:
+	}
+	close(t.stop)
+	<-t.done
+}
+
+// randomID returns n random bytes in hex
+func randomID(n int) string {
+	id := make([]byte, n)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
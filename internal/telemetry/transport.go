@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"net/http"
+
+	"discord-mcp/internal/trace"
+)
+
+// Transport wraps an HTTP transport to add a client span for each request
+// made while a traced request is running
+func (t *Tracer) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, tracer: t}
+}
+
+// transport adds spans to REST calls
+type transport struct {
+	base   http.RoundTripper
+	tracer *Tracer
+}
+
+// RoundTrip sends a request inside a span
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	span := t.tracer.Start("discord.rest "+req.Method, KindClient)
+	if span == nil {
+		return t.base.RoundTrip(req)
+	}
+	span.SetAttribute("http.request.method", req.Method)
+	span.SetAttribute("server.address", req.URL.Host)
+	// Webhook and interaction URLs carry tokens
+	span.SetAttribute("url.path", trace.Sanitize(req.URL.Path))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		// The error includes the URL
+		span.SetError(trace.Sanitize(err.Error()))
+	} else {
+		span.SetAttribute("http.response.status_code", resp.StatusCode)
+		if resp.StatusCode >= 400 {
+			span.SetError(resp.Status)
+		}
+	}
+	span.End(nil)
+	return resp, err
+}
@@ -0,0 +1,253 @@
+// Package templates loads message templates from a directory and renders
+// them with variable substitution.
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"gopkg.in/yaml.v3"
+)
+
+// placeholderPattern matches {{name}} placeholders
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_]+)\s*\}\}`)
+
+// Template is a message layout stored as a YAML or JSON file
+type Template struct {
+	// Name defaults to the file name without its extension
+	Name        string        `yaml:"name" json:"name"`
+	Description string        `yaml:"description,omitempty" json:"description,omitempty"`
+	Variables   []Variable    `yaml:"variables,omitempty" json:"variables,omitempty"`
+	Content     string        `yaml:"content,omitempty" json:"content,omitempty"`
+	Embeds      []EmbedLayout `yaml:"embeds,omitempty" json:"embeds,omitempty"`
+	File        string        `yaml:"-" json:"file"`
+}
+
+// Variable documents a placeholder; variables without a default are required
+type Variable struct {
+	Name        string  `yaml:"name" json:"name"`
+	Description string  `yaml:"description,omitempty" json:"description,omitempty"`
+	Default     *string `yaml:"default,omitempty" json:"default,omitempty"`
+}
+
+// EmbedLayout is an embed whose text fields may contain placeholders
+type EmbedLayout struct {
+	Title       string `yaml:"title,omitempty" json:"title,omitempty"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	URL         string `yaml:"url,omitempty" json:"url,omitempty"`
+	// Color is a hex color such as #5865F2
+	Color     string        `yaml:"color,omitempty" json:"color,omitempty"`
+	Footer    string        `yaml:"footer,omitempty" json:"footer,omitempty"`
+	Image     string        `yaml:"image,omitempty" json:"image,omitempty"`
+	Thumbnail string        `yaml:"thumbnail,omitempty" json:"thumbnail,omitempty"`
+	Fields    []FieldLayout `yaml:"fields,omitempty" json:"fields,omitempty"`
+}
+
+// FieldLayout is an embed field
+type FieldLayout struct {
+	Name   string `yaml:"name" json:"name"`
+	Value  string `yaml:"value" json:"value"`
+	Inline bool   `yaml:"inline,omitempty" json:"inline,omitempty"`
+}
+
+// Rendered is a template with all placeholders substituted
+type Rendered struct {
+	Content string
+	Embeds  []*discordgo.MessageEmbed
+}
+
+// Store reads templates from a directory. Files are re-read on every call so
+// edits take effect without a restart.
+type Store struct {
+	dir string
+}
+
+// NewStore creates a store for the given directory
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Load reads every .yaml, .yml and .json file in the directory. Files that
+// fail to parse are reported in the returned errors and skipped.
+func (s *Store) Load() ([]*Template, []error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, []error{fmt.Errorf("failed to read template directory: %w", err)}
+	}
+
+	var templates []*Template
+	var errs []error
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml" && ext != ".json") {
+			continue
+		}
+
+		tmpl, err := loadFile(filepath.Join(s.dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if other, ok := seen[tmpl.Name]; ok {
+			errs = append(errs, fmt.Errorf("%s: template %q is already defined in %s", entry.Name(), tmpl.Name, other))
+			continue
+		}
+		seen[tmpl.Name] = entry.Name()
+		templates = append(templates, tmpl)
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, errs
+}
+
+// Get returns the template with the given name
+func (s *Store) Get(name string) (*Template, error) {
+	templates, errs := s.Load()
+	for _, tmpl := range templates {
+		if tmpl.Name == name {
+			return tmpl, nil
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("template %q not found (%d template files failed to load: %v)", name, len(errs), errs[0])
+	}
+	return nil, fmt.Errorf("template %q not found", name)
+}
+
+// loadFile parses a single template file. JSON is parsed as YAML, of which
+// it is a subset.
+func loadFile(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+
+	var tmpl Template
+	if err := yaml.Unmarshal(data, &tmpl); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	tmpl.File = filepath.Base(path)
+	if tmpl.Name == "" {
+		tmpl.Name = strings.TrimSuffix(tmpl.File, filepath.Ext(tmpl.File))
+	}
+	if tmpl.Content == "" && len(tmpl.Embeds) == 0 {
+		return nil, fmt.Errorf("%s: template has neither content nor embeds", tmpl.File)
+	}
+	for i, layout := range tmpl.Embeds {
+		if layout.Color != "" && !placeholderPattern.MatchString(layout.Color) {
+			if _, err := parseColor(layout.Color); err != nil {
+				return nil, fmt.Errorf("%s: embed %d: %w", tmpl.File, i, err)
+			}
+		}
+	}
+	return &tmpl, nil
+}
+
+// Placeholders returns the names used in the template, in order of first use
+func (t *Template) Placeholders() []string {
+	var names []string
+	seen := make(map[string]bool)
+	collect := func(s string) {
+		for _, m := range placeholderPattern.FindAllStringSubmatch(s, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				names = append(names, m[1])
+			}
+		}
+	}
+
+	collect(t.Content)
+	for _, e := range t.Embeds {
+		for _, s := range []string{e.Title, e.Description, e.URL, e.Color, e.Footer, e.Image, e.Thumbnail} {
+			collect(s)
+		}
+		for _, f := range e.Fields {
+			collect(f.Name)
+			collect(f.Value)
+		}
+	}
+	return names
+}
+
+// Render substitutes values, falling back to variable defaults. Every
+// placeholder must have a value.
+func (t *Template) Render(values map[string]string) (*Rendered, error) {
+	resolved := make(map[string]string)
+	for _, v := range t.Variables {
+		if v.Default != nil {
+			resolved[v.Name] = *v.Default
+		}
+	}
+	for name, value := range values {
+		resolved[name] = value
+	}
+
+	var missing []string
+	for _, name := range t.Placeholders() {
+		if _, ok := resolved[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing values for variables: %s", strings.Join(missing, ", "))
+	}
+
+	sub := func(s string) string {
+		return placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+			return resolved[placeholderPattern.FindStringSubmatch(match)[1]]
+		})
+	}
+
+	rendered := &Rendered{Content: sub(t.Content)}
+	for i, layout := range t.Embeds {
+		embed := &discordgo.MessageEmbed{
+			Title:       sub(layout.Title),
+			Description: sub(layout.Description),
+			URL:         sub(layout.URL),
+		}
+		if layout.Color != "" {
+			color, err := parseColor(sub(layout.Color))
+			if err != nil {
+				return nil, fmt.Errorf("embed %d: %w", i, err)
+			}
+			embed.Color = color
+		}
+		if footer := sub(layout.Footer); footer != "" {
+			embed.Footer = &discordgo.MessageEmbedFooter{Text: footer}
+		}
+		if image := sub(layout.Image); image != "" {
+			embed.Image = &discordgo.MessageEmbedImage{URL: image}
+		}
+		if thumbnail := sub(layout.Thumbnail); thumbnail != "" {
+			embed.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: thumbnail}
+		}
+		for _, f := range layout.Fields {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   sub(f.Name),
+				Value:  sub(f.Value),
+				Inline: f.Inline,
+			})
+		}
+		rendered.Embeds = append(rendered.Embeds, embed)
+	}
+	return rendered, nil
+}
+
+// parseColor parses a hex color such as #5865F2
+func parseColor(s string) (int, error) {
+	color, err := strconv.ParseInt(strings.TrimPrefix(strings.TrimSpace(s), "#"), 16, 32)
+	if err != nil || color < 0 || color > 0xFFFFFF {
+		return 0, fmt.Errorf("invalid color %q, expected a hex color such as #5865F2", s)
+	}
+	return int(color), nil
+}
@@ -0,0 +1,150 @@
+// Package textsplit breaks long Markdown text into pieces that fit Discord's
+// length limits without breaking code blocks.
+package textsplit
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Message splits content into messages of at most limit characters. Breaks
+// fall between paragraphs where possible, then between lines; a code block
+// is only split when it alone exceeds the limit, and each part is then
+// re-fenced so it renders on its own.
+func Message(content string, limit int) []string {
+	if utf8.RuneCountInString(content) <= limit {
+		return []string{content}
+	}
+
+	var messages []string
+	var current strings.Builder
+	size := 0
+	emit := func() {
+		if size > 0 {
+			messages = append(messages, current.String())
+		}
+		current.Reset()
+		size = 0
+	}
+
+	for _, paragraph := range Paragraphs(content) {
+		for _, part := range Block(paragraph, limit) {
+			n := utf8.RuneCountInString(part)
+			if size > 0 && size+2+n > limit {
+				emit()
+			}
+			if size > 0 {
+				current.WriteString("\n\n")
+				size += 2
+			}
+			current.WriteString(part)
+			size += n
+		}
+	}
+	emit()
+	return messages
+}
+
+// Paragraphs splits text into blocks separated by blank lines. A fenced code
+// block is always a block of its own, blank lines included.
+func Paragraphs(text string) []string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var blocks []string
+	var block []string
+	inFence := false
+	flush := func() {
+		if strings.TrimSpace(strings.Join(block, "\n")) != "" {
+			blocks = append(blocks, strings.TrimRight(strings.Join(block, "\n"), " \t"))
+		}
+		block = nil
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			if !inFence {
+				flush()
+			}
+			block = append(block, line)
+			inFence = !inFence
+			if !inFence {
+				flush()
+			}
+		case inFence:
+			block = append(block, line)
+		case trimmed == "":
+			flush()
+		default:
+			block = append(block, line)
+		}
+	}
+	flush()
+	return blocks
+}
+
+// Block breaks a block longer than limit at line boundaries, and a line
+// longer than limit at the last space that keeps it in bounds. A code block
+// is split into several complete code blocks.
+func Block(block string, limit int) []string {
+	if utf8.RuneCountInString(block) <= limit {
+		return []string{block}
+	}
+
+	lines := strings.Split(block, "\n")
+	open, close := "", ""
+	if strings.HasPrefix(strings.TrimSpace(lines[0]), "```") {
+		open, close = lines[0]+"\n", "\n```"
+		lines = lines[1:]
+		if n := len(lines); n > 0 && strings.TrimSpace(lines[n-1]) == "```" {
+			lines = lines[:n-1]
+		}
+	}
+	budget := limit - utf8.RuneCountInString(open) - utf8.RuneCountInString(close)
+	if budget < 1 {
+		// The fence opener alone does not fit, so the block is split as
+		// plain lines without re-fencing
+		lines = strings.Split(block, "\n")
+		open, close, budget = "", "", limit
+	}
+
+	var parts []string
+	var part []string
+	size := 0
+	emit := func() {
+		if len(part) > 0 {
+			parts = append(parts, open+strings.Join(part, "\n")+close)
+		}
+		part, size = nil, 0
+	}
+
+	for _, line := range lines {
+		// Hard-split lines that cannot fit on their own
+		for utf8.RuneCountInString(line) > budget {
+			emit()
+			runes := []rune(line)
+			cut := budget
+			for i := budget; i > budget/2; i-- {
+				if runes[i] == ' ' {
+					cut = i
+					break
+				}
+			}
+			part = []string{string(runes[:cut])}
+			emit()
+			line = strings.TrimLeft(string(runes[cut:]), " ")
+		}
+		n := utf8.RuneCountInString(line)
+		if len(part) > 0 && size+1+n > budget {
+			emit()
+		}
+		if len(part) > 0 {
+			size++
+		}
+		part = append(part, line)
+		size += n
+	}
+	emit()
+	return parts
+}
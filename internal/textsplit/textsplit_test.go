@@ -0,0 +1,47 @@
+package textsplit
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMessage(t *testing.T) {
+	code := "```go\n" + strings.Repeat("fmt.Println(1)\n", 20) + "```"
+	tests := []struct {
+		name    string
+		content string
+		limit   int
+		parts   int
+	}{
+		{name: "short content is one message", content: "hello", limit: 10, parts: 1},
+		{name: "paragraphs", content: strings.Repeat("a", 8) + "\n\n" + strings.Repeat("b", 8), limit: 10, parts: 2},
+		{name: "long line", content: strings.Repeat("word ", 10), limit: 12, parts: 5},
+		{name: "code block is re-fenced", content: code, limit: 100, parts: 4},
+		{name: "oversized fence opener", content: "```" + strings.Repeat("a", 2100) + "\nx\n```", limit: 2000, parts: 2},
+		{name: "fence opener of exactly the limit", content: "```" + strings.Repeat("a", 17) + "\nx\n```", limit: 20, parts: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts := Message(tt.content, tt.limit)
+			if len(parts) != tt.parts {
+				t.Errorf("got %d parts, want %d: %q", len(parts), tt.parts, parts)
+			}
+			for _, part := range parts {
+				if n := utf8.RuneCountInString(part); n > tt.limit {
+					t.Errorf("part of %d characters exceeds %d: %q", n, tt.limit, part)
+				}
+			}
+		})
+	}
+}
+
+func TestBlockRefencesCode(t *testing.T) {
+	block := "```go\n" + strings.Repeat("line\n", 10) + "```"
+	for _, part := range Block(block, 20) {
+		if !strings.HasPrefix(part, "```go\n") || !strings.HasSuffix(part, "\n```") {
+			t.Errorf("part is not a complete code block: %q", part)
+		}
+	}
+}
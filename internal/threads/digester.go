@@ -0,0 +1,50 @@
+package threads
+
+import (
+	"sync"
+	"time"
+)
+
+// Digester runs the thread digest once a day at a fixed hour (UTC)
+type Digester struct {
+	hourUTC int
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewDigester creates a digester that runs at the given hour
+func NewDigester(hourUTC int) *Digester {
+	return &Digester{hourUTC: hourUTC, stop: make(chan struct{})}
+}
+
+// Start calls run every day at the digest hour until Stop is called
+func (d *Digester) Start(run func()) {
+	go func() {
+		for {
+			timer := time.NewTimer(time.Until(NextRun(time.Now(), d.hourUTC)))
+			select {
+			case <-d.stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+				run()
+			}
+		}
+	}()
+}
+
+// Stop stops running the digest
+func (d *Digester) Stop() {
+	d.stopOnce.Do(func() { close(d.stop) })
+}
+
+// NextRun returns the first time after now at the given hour (UTC)
+func NextRun(now time.Time, hourUTC int) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), hourUTC, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
@@ -0,0 +1,147 @@
+// Package threads finds active threads that are about to auto-archive and
+// builds the daily digest that lists them.
+package threads
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+
+	"discord-mcp/internal/snowflake"
+)
+
+// MaxDigestThreads bounds the threads listed in a digest
+const MaxDigestThreads = 25
+
+// MaxParticipants bounds the participants listed per thread
+const MaxParticipants = 5
+
+// Participant is a member who posted recently in a thread
+type Participant struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// Stale is an active thread that will auto-archive soon without new activity
+type Stale struct {
+	ThreadID   string `json:"thread_id"`
+	Name       string `json:"name"`
+	GuildID    string `json:"guild_id"`
+	ParentID   string `json:"parent_id"`
+	ParentName string `json:"parent_name,omitempty"`
+	OwnerID    string `json:"owner_id,omitempty"`
+	// MessageCount and MemberCount are Discord's approximate counts
+	MessageCount int       `json:"message_count"`
+	MemberCount  int       `json:"member_count"`
+	LastActivity time.Time `json:"last_activity"`
+	ArchivesAt   time.Time `json:"archives_at"`
+	// AutoArchiveMinutes is the inactivity after which the thread archives
+	AutoArchiveMinutes int           `json:"auto_archive_minutes"`
+	Participants       []Participant `json:"participants,omitempty"`
+}
+
+// LastActivity returns when a thread was last active: its newest message,
+// or when it was created or unarchived if that is more recent
+func LastActivity(thread *discordgo.Channel) time.Time {
+	var last time.Time
+	if thread.ThreadMetadata != nil {
+		last = thread.ThreadMetadata.ArchiveTimestamp
+	}
+	if parts, err := snowflake.Parse(thread.LastMessageID); err == nil && parts.Timestamp.After(last) {
+		last = parts.Timestamp
+	}
+	if last.IsZero() {
+		if parts, err := snowflake.Parse(thread.ID); err == nil {
+			last = parts.Timestamp
+		}
+	}
+	return last.UTC()
+}
+
+// FindStale returns the unarchived threads that will auto-archive within
+// the window, soonest first
+func FindStale(threads []*discordgo.Channel, now time.Time, within time.Duration) []Stale {
+	stale := make([]Stale, 0)
+	for _, thread := range threads {
+		meta := thread.ThreadMetadata
+		if meta == nil || meta.Archived || meta.AutoArchiveDuration <= 0 {
+			continue
+		}
+		last := LastActivity(thread)
+		archivesAt := last.Add(time.Duration(meta.AutoArchiveDuration) * time.Minute)
+		if archivesAt.Sub(now) > within {
+			continue
+		}
+		stale = append(stale, Stale{
+			ThreadID:           thread.ID,
+			Name:               thread.Name,
+			GuildID:            thread.GuildID,
+			ParentID:           thread.ParentID,
+			OwnerID:            thread.OwnerID,
+			MessageCount:       thread.MessageCount,
+			MemberCount:        thread.MemberCount,
+			LastActivity:       last,
+			ArchivesAt:         archivesAt,
+			AutoArchiveMinutes: meta.AutoArchiveDuration,
+		})
+	}
+	sort.SliceStable(stale, func(i, j int) bool { return stale[i].ArchivesAt.Before(stale[j].ArchivesAt) })
+	return stale
+}
+
+// Participants lists the distinct human authors of messages, most recent
+// first, up to MaxParticipants
+func Participants(messages []*discordgo.Message) []Participant {
+	seen := make(map[string]bool)
+	participants := make([]Participant, 0)
+	for _, msg := range messages {
+		if msg.Author == nil || msg.Author.Bot || seen[msg.Author.ID] {
+			continue
+		}
+		seen[msg.Author.ID] = true
+		participants = append(participants, Participant{UserID: msg.Author.ID, Username: msg.Author.Username})
+		if len(participants) == MaxParticipants {
+			break
+		}
+	}
+	return participants
+}
+
+// Digest is the list of a guild's threads about to auto-archive
+type Digest struct {
+	GuildID     string    `json:"guild_id"`
+	GeneratedAt time.Time `json:"generated_at"`
+	WindowHours int       `json:"window_hours"`
+	Threads     []Stale   `json:"threads"`
+	// Total counts every stale thread, including those beyond
+	// MaxDigestThreads
+	Total int `json:"total"`
+}
+
+// Render formats a digest as a Discord message
+func (d Digest) Render() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Threads archiving in the next %d hours: %d**", d.WindowHours, d.Total)
+	for _, thread := range d.Threads {
+		fmt.Fprintf(&b, "\n• <#%s>", thread.ThreadID)
+		if thread.ParentID != "" {
+			fmt.Fprintf(&b, " in <#%s>", thread.ParentID)
+		}
+		fmt.Fprintf(&b, ": archives <t:%d:R>, last active <t:%d:R>, %d messages",
+			thread.ArchivesAt.Unix(), thread.LastActivity.Unix(), thread.MessageCount)
+		if len(thread.Participants) > 0 {
+			names := make([]string, len(thread.Participants))
+			for i, p := range thread.Participants {
+				names[i] = p.Username
+			}
+			fmt.Fprintf(&b, " (%s)", strings.Join(names, ", "))
+		}
+	}
+	if hidden := d.Total - len(d.Threads); hidden > 0 {
+		fmt.Fprintf(&b, "\n\n…and %d more", hidden)
+	}
+	return b.String()
+}
@@ -0,0 +1,251 @@
+// Package tickets keeps modmail-style support tickets: a private channel or
+// thread per member that their direct messages are relayed into until the
+// ticket is closed. Tickets are persisted to disk.
+package tickets
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+// Ticket kinds
+const (
+	KindChannel = "channel"
+	KindThread  = "thread"
+)
+
+// Ticket statuses
+const (
+	StatusOpen   = "open"
+	StatusClosed = "closed"
+)
+
+// ErrAlreadyOpen reports that the member already has an open ticket in the
+// guild
+var ErrAlreadyOpen = errors.New("member already has an open ticket")
+
+// Ticket is one member's support conversation in a guild
+type Ticket struct {
+	ID        string    `json:"id"`
+	GuildID   string    `json:"guild_id"`
+	UserID    string    `json:"user_id"`
+	ChannelID string    `json:"channel_id"`
+	Kind      string    `json:"kind"`
+	Subject   string    `json:"subject,omitempty"`
+	Status    string    `json:"status"`
+	OpenedBy  string    `json:"opened_by,omitempty"`
+	OpenedAt  time.Time `json:"opened_at"`
+
+	// RelayedCount counts the member's direct messages relayed into the
+	// ticket
+	RelayedCount  int        `json:"relayed_count"`
+	LastMessageAt *time.Time `json:"last_message_at,omitempty"`
+
+	ClosedAt       *time.Time `json:"closed_at,omitempty"`
+	ClosedBy       string     `json:"closed_by,omitempty"`
+	CloseReason    string     `json:"close_reason,omitempty"`
+	TranscriptPath string     `json:"transcript_path,omitempty"`
+}
+
+// storedTickets is the layout of the persistence file
+type storedTickets struct {
+	NextID  int       `json:"next_id"`
+	Tickets []*Ticket `json:"tickets"`
+}
+
+// Store holds tickets by ID and saves them to a JSON file on every change
+type Store struct {
+	path string
+
+	tickets map[string]*Ticket
+	nextID  int
+	mutex   sync.Mutex
+}
+
+// NewStore creates a store, loading the tickets saved at path
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:    path,
+		tickets: make(map[string]*Ticket),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load tickets from %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// NextID reserves the ID of the next ticket, so its channel can be named
+// before the ticket is saved
+func (s *Store) NextID() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.nextID++
+	return fmt.Sprintf("tk%d", s.nextID)
+}
+
+// CheckOpen returns ErrAlreadyOpen when the member has an open ticket in the
+// guild, along with that ticket
+func (s *Store) CheckOpen(guildID, userID string) (Ticket, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if open := s.openFor(guildID, userID); open != nil {
+		return *open, ErrAlreadyOpen
+	}
+	return Ticket{}, nil
+}
+
+// Open saves a new open ticket whose ID was reserved with NextID
+func (s *Store) Open(ticket Ticket) (Ticket, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if open := s.openFor(ticket.GuildID, ticket.UserID); open != nil {
+		return *open, ErrAlreadyOpen
+	}
+	ticket.Status = StatusOpen
+	ticket.OpenedAt = time.Now().UTC()
+	s.tickets[ticket.ID] = &ticket
+	if err := s.save(); err != nil {
+		delete(s.tickets, ticket.ID)
+		return Ticket{}, err
+	}
+	return ticket, nil
+}
+
+// Get returns a ticket of a guild by ID
+func (s *Store) Get(guildID, ticketID string) (Ticket, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ticket, ok := s.tickets[ticketID]
+	if !ok || ticket.GuildID != guildID {
+		return Ticket{}, false
+	}
+	return *ticket, true
+}
+
+// ForUser returns a member's most recently opened open ticket across all
+// guilds, which their direct messages are relayed into
+func (s *Store) ForUser(userID string) (Ticket, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var latest *Ticket
+	for _, ticket := range s.tickets {
+		if ticket.UserID != userID || ticket.Status != StatusOpen {
+			continue
+		}
+		if latest == nil || ticket.OpenedAt.After(latest.OpenedAt) {
+			latest = ticket
+		}
+	}
+	if latest == nil {
+		return Ticket{}, false
+	}
+	return *latest, true
+}
+
+// List returns a guild's tickets with the given status ("" for all),
+// optionally for one member, newest first
+func (s *Store) List(guildID, userID, status string) []Ticket {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tickets := make([]Ticket, 0)
+	for _, ticket := range s.tickets {
+		if ticket.GuildID != guildID || (userID != "" && ticket.UserID != userID) {
+			continue
+		}
+		if status == "" || ticket.Status == status {
+			tickets = append(tickets, *ticket)
+		}
+	}
+	sort.Slice(tickets, func(i, j int) bool {
+		return tickets[i].OpenedAt.After(tickets[j].OpenedAt)
+	})
+	return tickets
+}
+
+// RecordMessage counts a direct message relayed into a ticket
+func (s *Store) RecordMessage(ticketID string, at time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ticket, ok := s.tickets[ticketID]
+	if !ok {
+		return nil
+	}
+	ticket.RelayedCount++
+	at = at.UTC()
+	ticket.LastMessageAt = &at
+	return s.save()
+}
+
+// Close marks an open ticket closed, returning it and whether it was open
+func (s *Store) Close(guildID, ticketID, by, reason, transcriptPath string) (Ticket, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ticket, ok := s.tickets[ticketID]
+	if !ok || ticket.GuildID != guildID || ticket.Status != StatusOpen {
+		return Ticket{}, false, nil
+	}
+
+	previous := *ticket
+	now := time.Now().UTC()
+	ticket.Status = StatusClosed
+	ticket.ClosedAt = &now
+	ticket.ClosedBy = by
+	ticket.CloseReason = reason
+	ticket.TranscriptPath = transcriptPath
+	if err := s.save(); err != nil {
+		*ticket = previous
+		return Ticket{}, false, err
+	}
+	return *ticket, true, nil
+}
+
+// openFor returns a member's open ticket in a guild; callers must hold the
+// lock
+func (s *Store) openFor(guildID, userID string) *Ticket {
+	for _, ticket := range s.tickets {
+		if ticket.GuildID == guildID && ticket.UserID == userID && ticket.Status == StatusOpen {
+			return ticket
+		}
+	}
+	return nil
+}
+
+// save writes every ticket to the persistence file; callers must hold the
+// lock
+func (s *Store) save() error {
+	stored := storedTickets{NextID: s.nextID, Tickets: make([]*Ticket, 0, len(s.tickets))}
+	for _, ticket := range s.tickets {
+		stored.Tickets = append(stored.Tickets, ticket)
+	}
+	sort.Slice(stored.Tickets, func(i, j int) bool {
+		return stored.Tickets[i].OpenedAt.Before(stored.Tickets[j].OpenedAt)
+	})
+
+	return jsonfile.Save(s.path, stored)
+}
+
+// load reads saved tickets
+func (s *Store) load() error {
+	var stored storedTickets
+	if found, err := jsonfile.Load(s.path, &stored); err != nil || !found {
+		return err
+	}
+	s.nextID = stored.NextID
+	for _, ticket := range stored.Tickets {
+		s.tickets[ticket.ID] = ticket
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+package timezone
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Direction says which way a bare duration or weekday points: "2h" is two
+// hours ago for a search bound but two hours from now for an end time
+type Direction int
+
+const (
+	// Past reads "2h" as two hours ago and "friday" as the last Friday
+	Past Direction = iota
+	// Future reads "2h" as in two hours and "friday" as the coming Friday
+	Future
+)
+
+// durationUnits maps the unit words of durations to their length
+var durationUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "wk": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+// durationPart matches one amount and unit of a duration such as 1h30m or
+// "2 days 6 hours"
+var durationPart = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-z]+)`)
+
+// ParseDuration reads a positive duration: Go syntax such as 90m or 1h30m,
+// days and weeks such as 3d or 2w, or words such as "2 hours" or
+// "an hour and 30 minutes"
+func ParseDuration(value string) (time.Duration, error) {
+	text := strings.ToLower(strings.TrimSpace(value))
+	if d, err := time.ParseDuration(text); err == nil && d > 0 {
+		return d, nil
+	}
+
+	text = strings.NewReplacer(",", " ", " and ", " ").Replace(text)
+	for _, article := range []string{"an ", "a "} {
+		if strings.HasPrefix(text, article) {
+			text = "1 " + text[len(article):]
+		}
+	}
+	var total time.Duration
+	for text = strings.TrimSpace(text); text != ""; text = strings.TrimSpace(text) {
+		m := durationPart.FindStringSubmatch(text)
+		if m == nil {
+			return 0, fmt.Errorf("invalid duration %q: use a duration such as 90m, 2h, 3d or \"2 hours\"", value)
+		}
+		unit, ok := durationUnits[m[2]]
+		if !ok {
+			return 0, fmt.Errorf("invalid duration %q: unknown unit %q", value, m[2])
+		}
+		amount, _ := strconv.ParseFloat(m[1], 64)
+		total += time.Duration(amount * float64(unit))
+		text = text[len(m[0]):]
+	}
+	if total <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: use a duration such as 90m, 2h, 3d or \"2 hours\"", value)
+	}
+	return total, nil
+}
+
+// weekdays maps day names and their abbreviations to weekdays
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// clockPattern matches a time of day such as 6pm, 6:30 pm or 18:00
+var clockPattern = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+
+// Resolve reads a time as Parse does, or relative to now: a duration
+// ("2h", "in 3 days", "90m ago"), a day ("yesterday", "tomorrow", "friday",
+// "next friday", "last monday") with an optional time of day ("6pm",
+// "18:30", "noon"), or a time of day alone. A leading "since" is ignored.
+// Days and times of day are read in loc; days without a time start at
+// midnight. dir decides which way bare durations, weekdays and times of
+// day point.
+func Resolve(value string, now time.Time, loc *time.Location, dir Direction) (time.Time, error) {
+	if t, err := Parse(value, loc); err == nil {
+		return t, nil
+	}
+	if t, ok := resolveRelative(value, now, loc, dir); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use RFC 3339, YYYY-MM-DD HH:MM with an optional zone, a duration such as 2h, or a day such as yesterday or next friday 6pm", value)
+}
+
+// resolveRelative reads the relative forms accepted by Resolve
+func resolveRelative(value string, now time.Time, loc *time.Location, dir Direction) (time.Time, bool) {
+	text := strings.Join(strings.Fields(strings.ToLower(value)), " ")
+	for _, prefix := range []string{"since ", "from "} {
+		text = strings.TrimPrefix(text, prefix)
+	}
+	if text == "now" {
+		return now, true
+	}
+
+	// Durations, pointing the way they say or else the way of dir
+	switch {
+	case strings.HasPrefix(text, "in "):
+		if d, err := ParseDuration(text[len("in "):]); err == nil {
+			return now.Add(d), true
+		}
+	case strings.HasSuffix(text, " ago"):
+		if d, err := ParseDuration(text[:len(text)-len(" ago")]); err == nil {
+			return now.Add(-d), true
+		}
+	default:
+		if d, err := ParseDuration(text); err == nil {
+			if dir == Future {
+				return now.Add(d), true
+			}
+			return now.Add(-d), true
+		}
+	}
+
+	// A day, then an optional time of day
+	local := now.In(loc)
+	words := strings.Fields(text)
+	offset, day := 0, false
+	switch {
+	case len(words) == 0:
+		return time.Time{}, false
+	case words[0] == "today":
+		words, day = words[1:], true
+	case words[0] == "tomorrow":
+		words, offset, day = words[1:], 1, true
+	case words[0] == "yesterday":
+		words, offset, day = words[1:], -1, true
+	default:
+		modifier := ""
+		if words[0] == "next" || words[0] == "last" || words[0] == "this" {
+			modifier, words = words[0], words[1:]
+		}
+		if len(words) == 0 {
+			return time.Time{}, false
+		}
+		weekday, ok := weekdays[words[0]]
+		if !ok {
+			if modifier != "" {
+				return time.Time{}, false
+			}
+			break
+		}
+		words, day = words[1:], true
+		ahead := (int(weekday) - int(local.Weekday()) + 7) % 7
+		switch {
+		case modifier == "next" || modifier == "this" || (modifier == "" && dir == Future):
+			offset = ahead
+			if modifier == "next" && ahead == 0 {
+				offset = 7
+			}
+		default:
+			offset = -((int(local.Weekday()) - int(weekday) + 7) % 7)
+			if modifier == "last" && offset == 0 {
+				offset = -7
+			}
+		}
+	}
+	if len(words) > 0 && words[0] == "at" {
+		words = words[1:]
+	}
+
+	hour, minute := 0, 0
+	if len(words) > 0 {
+		var ok bool
+		if hour, minute, ok = parseClock(strings.Join(words, " ")); !ok {
+			return time.Time{}, false
+		}
+	} else if !day {
+		return time.Time{}, false
+	}
+
+	t := time.Date(local.Year(), local.Month(), local.Day()+offset, hour, minute, 0, 0, loc)
+	if !day {
+		// A time of day alone is the next or last time the clock shows it
+		if dir == Future && !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		} else if dir == Past && t.After(now) {
+			t = t.AddDate(0, 0, -1)
+		}
+	}
+	return t, true
+}
+
+// parseClock reads a time of day such as 6pm, 6:30 pm, 18:00, noon or
+// midnight
+func parseClock(text string) (hour, minute int, ok bool) {
+	switch text {
+	case "noon", "midday":
+		return 12, 0, true
+	case "midnight":
+		return 0, 0, true
+	}
+	m := clockPattern.FindStringSubmatch(text)
+	if m == nil {
+		return 0, 0, false
+	}
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	} else if m[3] == "" {
+		// A bare number is not a time of day
+		return 0, 0, false
+	}
+	switch m[3] {
+	case "am":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		if hour != 12 {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 {
+		return 0, 0, false
+	}
+	return hour, minute, true
+}
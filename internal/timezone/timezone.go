@@ -0,0 +1,138 @@
+// Package timezone resolves the time zone of each guild and parses the
+// timestamps agents pass to tools, which may name their own zone.
+package timezone
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	// Embedded zone data so timezones resolve on hosts without tzdata
+	_ "time/tzdata"
+)
+
+// Zones maps guilds to the time zone their times are read and shown in
+type Zones struct {
+	fallback *time.Location
+	guilds   map[string]*time.Location
+}
+
+// NewZones loads the fallback zone and the zones of individual guilds, all
+// given as IANA names such as Europe/Berlin
+func NewZones(fallback string, guilds map[string]string) (*Zones, error) {
+	z := &Zones{guilds: make(map[string]*time.Location)}
+	var err error
+	if z.fallback, err = Load(fallback); err != nil {
+		return nil, err
+	}
+	for guildID, name := range guilds {
+		if name == "" {
+			continue
+		}
+		if z.guilds[guildID], err = Load(name); err != nil {
+			return nil, fmt.Errorf("guild %s: %w", guildID, err)
+		}
+	}
+	return z, nil
+}
+
+// For returns a guild's zone, or the fallback zone for guilds without one
+func (z *Zones) For(guildID string) *time.Location {
+	if loc, ok := z.guilds[guildID]; ok {
+		return loc
+	}
+	return z.fallback
+}
+
+// Load returns the zone with an IANA name; an empty name is UTC
+func Load(name string) (*time.Location, error) {
+	if name == "" || strings.EqualFold(name, "UTC") {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("invalid timezone %q: use an IANA name such as Europe/Berlin", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: use an IANA name such as Europe/Berlin", name)
+	}
+	return loc, nil
+}
+
+// abbreviations are the fixed offsets of common zone abbreviations. Some
+// abbreviations are ambiguous; IST is read as India Standard Time.
+var abbreviations = map[string]int{
+	"UTC": 0, "GMT": 0, "Z": 0,
+	"WET": 0, "WEST": 1 * 3600, "BST": 1 * 3600,
+	"CET": 1 * 3600, "CEST": 2 * 3600,
+	"EET": 2 * 3600, "EEST": 3 * 3600, "MSK": 3 * 3600,
+	"IST": 5*3600 + 1800,
+	"SGT": 8 * 3600, "HKT": 8 * 3600, "AWST": 8 * 3600,
+	"JST": 9 * 3600, "KST": 9 * 3600,
+	"ACST": 9*3600 + 1800, "AEST": 10 * 3600, "AEDT": 11 * 3600,
+	"NZST": 12 * 3600, "NZDT": 13 * 3600,
+	"HST": -10 * 3600, "AKST": -9 * 3600, "AKDT": -8 * 3600,
+	"PST": -8 * 3600, "PDT": -7 * 3600,
+	"MST": -7 * 3600, "MDT": -6 * 3600,
+	"CST": -6 * 3600, "CDT": -5 * 3600,
+	"EST": -5 * 3600, "EDT": -4 * 3600,
+}
+
+// offsetPattern matches numeric UTC offsets such as +01:00 or -0530
+var offsetPattern = regexp.MustCompile(`^([+-])(\d{2}):?(\d{2})$`)
+
+// localLayouts are the timestamp forms read in a zone given separately
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// Parse reads an RFC 3339 timestamp, or a date with an optional time
+// followed by an optional zone: "2025-01-05", "2025-01-05 18:00 CET",
+// "2025-01-05T18:00 Europe/Berlin" or "2025-01-05 18:00 +01:00". Times
+// without a zone are read in loc.
+func Parse(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	body, zone := value, loc
+	if i := strings.LastIndexByte(value, ' '); i > 0 {
+		if z, ok := lookup(value[i+1:]); ok {
+			body, zone = strings.TrimSpace(value[:i]), z
+		}
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, body, zone); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use RFC 3339 or YYYY-MM-DD HH:MM with an optional zone such as CET or Europe/Berlin", value)
+}
+
+// lookup resolves a zone abbreviation, numeric offset or IANA name
+func lookup(token string) (*time.Location, bool) {
+	if offset, ok := abbreviations[strings.ToUpper(token)]; ok {
+		return time.FixedZone(strings.ToUpper(token), offset), true
+	}
+	if m := offsetPattern.FindStringSubmatch(token); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		offset := hours*3600 + minutes*60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return time.FixedZone(token, offset), true
+	}
+	if strings.Contains(token, "/") {
+		if loc, err := time.LoadLocation(token); err == nil {
+			return loc, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,251 @@
+// Package trace records Discord REST requests and responses and gateway
+// events while tracing is on, so a failed tool call can be debugged from
+// what was actually sent and received. Traces are sanitized: headers are
+// never recorded, and tokens in URLs and payloads are redacted.
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kinds of trace
+const (
+	KindREST    = "rest"
+	KindGateway = "gateway"
+)
+
+// Entry is one REST exchange or gateway event
+type Entry struct {
+	ID   int64     `json:"id"`
+	Time time.Time `json:"time"`
+	Kind string    `json:"kind"`
+	// REST exchanges
+	Method     string `json:"method,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Status     int    `json:"status,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+	Request    string `json:"request,omitempty"`
+	// Gateway events
+	Event    string `json:"event,omitempty"`
+	Sequence int64  `json:"sequence,omitempty"`
+	// Response is the REST response body or the gateway event payload
+	Response string `json:"response,omitempty"`
+	Error    string `json:"error,omitempty"`
+	// Truncated is set when a body was cut to the maximum body size
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Failed reports whether the entry is a failed REST exchange
+func (e Entry) Failed() bool {
+	return e.Error != "" || e.Status >= 400
+}
+
+// Options configure a recorder
+type Options struct {
+	// Path is the trace file; empty keeps traces only in memory
+	Path string
+	// MaxFileBytes rotates the file when it would grow past this size
+	MaxFileBytes int64
+	// MaxFiles is the number of rotated files kept besides the current one
+	MaxFiles int
+	// Recent is the number of traces kept in memory
+	Recent int
+	// MaxBodyBytes cuts request and response bodies to this size
+	MaxBodyBytes int
+}
+
+var (
+	// tokenField matches JSON fields holding secrets
+	tokenField = regexp.MustCompile(`"(token|access_token|refresh_token|password|secret)"\s*:\s*"[^"]*"`)
+	// webhookToken matches the token in webhook and interaction URLs
+	webhookToken = regexp.MustCompile(`/(webhooks|interactions)/([0-9]+)/[A-Za-z0-9_.\-]+`)
+	// botToken matches Discord bot tokens
+	botToken = regexp.MustCompile(`[A-Za-z0-9_\-]{24,}\.[A-Za-z0-9_\-]{6}\.[A-Za-z0-9_\-]{27,}`)
+)
+
+// Recorder keeps recent traces in memory and appends them to a rotating
+// file
+type Recorder struct {
+	opts    Options
+	enabled atomic.Bool
+
+	mutex  sync.Mutex
+	recent []Entry
+	next   int
+	nextID int64
+	file   *os.File
+	size   int64
+	// fileErr is the last file error, logged once per error
+	fileErr string
+}
+
+// NewRecorder creates a recorder; tracing starts off
+func NewRecorder(opts Options) *Recorder {
+	if opts.Recent < 1 {
+		opts.Recent = 1
+	}
+	return &Recorder{opts: opts}
+}
+
+// Enabled reports whether traces are being recorded
+func (r *Recorder) Enabled() bool {
+	return r.enabled.Load()
+}
+
+// SetEnabled turns tracing on or off
+func (r *Recorder) SetEnabled(enabled bool) {
+	r.enabled.Store(enabled)
+}
+
+// Record sanitizes and stores an entry if tracing is on. It returns a file
+// error, which does not stop the entry from being kept in memory.
+func (r *Recorder) Record(entry Entry) error {
+	if !r.Enabled() {
+		return nil
+	}
+	entry.URL = Sanitize(entry.URL)
+	entry.Request, entry.Truncated = r.body(entry.Request, entry.Truncated)
+	entry.Response, entry.Truncated = r.body(entry.Response, entry.Truncated)
+	entry.Error = Sanitize(entry.Error)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.nextID++
+	entry.ID = r.nextID
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	if len(r.recent) < r.opts.Recent {
+		r.recent = append(r.recent, entry)
+	} else {
+		r.recent[r.next] = entry
+	}
+	r.next = (r.next + 1) % r.opts.Recent
+
+	if r.opts.Path == "" {
+		return nil
+	}
+	return r.write(entry)
+}
+
+// Recent returns up to limit traces, newest first. kind filters by kind
+// when set, failedOnly keeps failed REST exchanges, and contains matches
+// the URL or event name.
+func (r *Recorder) Recent(limit int, kind string, failedOnly bool, contains string) []Entry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entries := make([]Entry, 0, limit)
+	for i := 0; i < len(r.recent) && len(entries) < limit; i++ {
+		// Walk back from the newest entry
+		index := (r.next - 1 - i + len(r.recent)) % len(r.recent)
+		entry := r.recent[index]
+		if kind != "" && entry.Kind != kind {
+			continue
+		}
+		if failedOnly && !entry.Failed() {
+			continue
+		}
+		if contains != "" && !strings.Contains(entry.URL, contains) && !strings.Contains(entry.Event, contains) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Close closes the trace file
+func (r *Recorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Sanitize redacts tokens in a URL or payload
+func Sanitize(text string) string {
+	text = tokenField.ReplaceAllString(text, `"$1":"[redacted]"`)
+	text = webhookToken.ReplaceAllString(text, "/$1/$2/[redacted]")
+	return botToken.ReplaceAllString(text, "[redacted]")
+}
+
+// body sanitizes a body and cuts it to the maximum size
+func (r *Recorder) body(body string, truncated bool) (string, bool) {
+	body = Sanitize(body)
+	if r.opts.MaxBodyBytes > 0 && len(body) > r.opts.MaxBodyBytes {
+		return body[:r.opts.MaxBodyBytes], true
+	}
+	return body, truncated
+}
+
+// write appends an entry to the trace file, rotating it when full
+func (r *Recorder) write(entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode trace: %w", err)
+	}
+	line = append(line, '\n')
+
+	if r.file != nil && r.opts.MaxFileBytes > 0 && r.size+int64(len(line)) > r.opts.MaxFileBytes {
+		r.file.Close()
+		r.file = nil
+		r.rotate()
+	}
+	if r.file == nil {
+		file, err := os.OpenFile(r.opts.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return r.fail(fmt.Errorf("failed to open trace file: %w", err))
+		}
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return r.fail(fmt.Errorf("failed to open trace file: %w", err))
+		}
+		r.file, r.size = file, info.Size()
+	}
+
+	n, err := r.file.Write(line)
+	r.size += int64(n)
+	if err != nil {
+		return r.fail(fmt.Errorf("failed to write trace file: %w", err))
+	}
+	r.fileErr = ""
+	return nil
+}
+
+// rotate shifts path to path.1, path.1 to path.2 and so on, dropping the
+// oldest file
+func (r *Recorder) rotate() {
+	os.Remove(fmt.Sprintf("%s.%d", r.opts.Path, r.opts.MaxFiles))
+	for i := r.opts.MaxFiles - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", r.opts.Path, i), fmt.Sprintf("%s.%d", r.opts.Path, i+1))
+	}
+	if r.opts.MaxFiles > 0 {
+		os.Rename(r.opts.Path, r.opts.Path+".1")
+	} else {
+		os.Remove(r.opts.Path)
+	}
+}
+
+// fail returns a file error the first time it occurs, so a full disk does
+// not log once per trace
+func (r *Recorder) fail(err error) error {
+	if err.Error() == r.fileErr {
+		return nil
+	}
+	r.fileErr = err.Error()
+	return err
+}
@@ -0,0 +1,106 @@
+package trace
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Transport wraps an HTTP transport to record each request and response
+// while tracing is on. onError receives trace file errors.
+func (r *Recorder) Transport(base http.RoundTripper, onError func(error)) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, recorder: r, onError: onError}
+}
+
+// transport records REST exchanges
+type transport struct {
+	base     http.RoundTripper
+	recorder *Recorder
+	onError  func(error)
+}
+
+// RoundTrip sends a request and records it
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.recorder.Enabled() {
+		return t.base.RoundTrip(req)
+	}
+
+	entry := Entry{
+		Time:   time.Now(),
+		Kind:   KindREST,
+		Method: req.Method,
+		URL:    req.URL.String(),
+	}
+	entry.Request, entry.Truncated = t.requestBody(req)
+
+	resp, err := t.base.RoundTrip(req)
+	entry.DurationMs = time.Since(entry.Time).Milliseconds()
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		var truncated bool
+		entry.Response, truncated = t.responseBody(resp)
+		entry.Truncated = entry.Truncated || truncated
+	}
+
+	if recordErr := t.recorder.Record(entry); recordErr != nil && t.onError != nil {
+		t.onError(recordErr)
+	}
+	return resp, err
+}
+
+// requestBody returns the start of a request body without consuming it.
+// Uploads are not recorded.
+func (t *transport) requestBody(req *http.Request) (string, bool) {
+	if req.Body == nil || req.GetBody == nil {
+		return "", false
+	}
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/") {
+		return "[multipart body not recorded]", false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return "", false
+	}
+	defer body.Close()
+	return t.read(body)
+}
+
+// responseBody returns the start of a response body and puts what it read
+// back, so the caller still receives the whole body
+func (t *transport) responseBody(resp *http.Response) (string, bool) {
+	if resp.Body == nil {
+		return "", false
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if contentType != "" && !strings.Contains(contentType, "json") && !strings.HasPrefix(contentType, "text/") {
+		return "[" + contentType + " body not recorded]", false
+	}
+	text, truncated := t.read(resp.Body)
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(strings.NewReader(text), resp.Body), resp.Body}
+	return text, truncated
+}
+
+// read reads up to the maximum body size
+func (t *transport) read(body io.Reader) (string, bool) {
+	limit := t.recorder.opts.MaxBodyBytes
+	if limit <= 0 {
+		data, _ := io.ReadAll(body)
+		return string(data), false
+	}
+	var buf bytes.Buffer
+	n, _ := io.CopyN(&buf, body, int64(limit)+1)
+	if n > int64(limit) {
+		return buf.String(), true
+	}
+	return buf.String(), false
+}
@@ -0,0 +1,52 @@
+package validation
+
+import "discord-mcp/pkg/types"
+
+// nameExamples are the ID parameters that accept names, with an example of each
+var nameExamples = map[string]string{
+	"channel_id": "#channel-name",
+	"role_id":    "@Role Name",
+	"user_id":    "username",
+}
+
+// WithNameParams returns a copy of a tool definition whose channel_id, role_id
+// and user_id parameters advertise that names are accepted in place of IDs
+func WithNameParams(tool types.Tool) types.Tool {
+	schema, ok := tool.InputSchema.(map[string]interface{})
+	if !ok {
+		return tool
+	}
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return tool
+	}
+
+	copied := make(map[string]interface{}, len(properties))
+	for name, prop := range properties {
+		copied[name] = prop
+		example, accepts := nameExamples[name]
+		propMap, isMap := prop.(map[string]interface{})
+		if !accepts || !isMap {
+			continue
+		}
+
+		updated := make(map[string]interface{}, len(propMap))
+		for k, v := range propMap {
+			if k != "pattern" {
+				updated[k] = v
+			}
+		}
+		if desc, ok := propMap["description"].(string); ok {
+			updated["description"] = desc + "; a name such as \"" + example + "\" is also accepted"
+		}
+		copied[name] = updated
+	}
+
+	result := make(map[string]interface{}, len(schema))
+	for k, v := range schema {
+		result[k] = v
+	}
+	result["properties"] = copied
+	tool.InputSchema = result
+	return tool
+}
@@ -0,0 +1,43 @@
+package validation
+
+import "discord-mcp/pkg/types"
+
+// outputSchemas describe the data returned by tools whose results are built
+// from the Discord summary types in pkg/types. Results may carry more fields
+// than listed, such as retries or action_id.
+var outputSchemas = map[string]map[string]interface{}{
+	"get_channel_messages": listOutput("channel_id", "message_count", "messages", types.MessageSummary{}),
+	"list_channels":        listOutput("guild_id", "channel_count", "channels", types.ChannelSummary{}),
+	"get_channel_info":     types.SchemaOf(types.ChannelSummary{}),
+	"list_roles":           listOutput("guild_id", "role_count", "roles", types.RoleSummary{}),
+	"get_role_info":        types.SchemaOf(types.RoleSummary{}),
+	"create_role":          types.SchemaOf(types.RoleSummary{}),
+	"list_guild_members":   listOutput("guild_id", "member_count", "members", types.MemberSummary{}),
+	"get_user_info":        types.SchemaOf(types.UserProfile{}),
+	"get_streamed_members": {
+		"type": "object",
+		"properties": map[string]interface{}{
+			"members":  map[string]interface{}{"type": "array", "items": types.SchemaOf(types.MemberSummary{})},
+			"returned": map[string]interface{}{"type": "integer"},
+		},
+	},
+}
+
+// listOutput describes data listing items of one type with the ID of their
+// guild or channel and a count
+func listOutput(idField, countField, listField string, item interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			idField:    map[string]interface{}{"type": "string"},
+			countField: map[string]interface{}{"type": "integer"},
+			listField:  map[string]interface{}{"type": "array", "items": types.SchemaOf(item)},
+		},
+	}
+}
+
+// GetOutputSchema returns the output schema of a tool, if it has one
+func GetOutputSchema(toolName string) (map[string]interface{}, bool) {
+	schema, exists := outputSchemas[toolName]
+	return schema, exists
+}
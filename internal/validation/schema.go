@@ -0,0 +1,901 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Schema is a compiled JSON Schema. It implements the Draft 2020-12
+// applicator and validation vocabularies and asserts format; default is
+// kept for ApplyDefaults and other annotations such as description are
+// ignored. References
+// ($ref, $defs) are not supported because tool schemas are self-contained.
+type Schema struct {
+	// alwaysFalse is set for the boolean schema false
+	alwaysFalse bool
+
+	types    []string
+	enum     []interface{}
+	constant interface{}
+	hasConst bool
+
+	defaultValue interface{}
+	hasDefault   bool
+
+	minLength int
+	maxLength int
+	pattern   *regexp.Regexp
+	format    string
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	multipleOf       *float64
+
+	prefixItems []*Schema
+	items       *Schema
+	contains    *Schema
+	minContains int
+	maxContains int
+	minItems    int
+	maxItems    int
+	uniqueItems bool
+
+	properties           map[string]*Schema
+	patternProperties    []patternSchema
+	additionalProperties *Schema
+	propertyNames        *Schema
+	required             []string
+	dependentRequired    map[string][]string
+	minProperties        int
+	maxProperties        int
+
+	allOf      []*Schema
+	anyOf      []*Schema
+	oneOf      []*Schema
+	not        *Schema
+	ifSchema   *Schema
+	thenSchema *Schema
+	elseSchema *Schema
+}
+
+// patternSchema applies a schema to properties whose names match a pattern
+type patternSchema struct {
+	pattern *regexp.Regexp
+	schema  *Schema
+}
+
+// annotationKeywords carry no validation meaning
+var annotationKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true,
+	"description": true, "examples": true,
+	"deprecated": true, "readOnly": true, "writeOnly": true,
+}
+
+// jsonTypes are the type names a schema may declare
+var jsonTypes = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true,
+	"number": true, "integer": true, "string": true,
+}
+
+// formatCheckers validate the formats tool schemas may assert
+var formatCheckers = map[string]func(string) bool{
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse("2006-01-02", s)
+		return err == nil
+	},
+	"time": func(s string) bool {
+		_, err := time.Parse("15:04:05Z07:00", s)
+		return err == nil
+	},
+	"duration": func(s string) bool {
+		return durationPattern.MatchString(s) && s != "P" && !strings.HasSuffix(s, "T")
+	},
+	"email": func(s string) bool {
+		addr, err := mail.ParseAddress(s)
+		return err == nil && addr.Address == s
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.IsAbs()
+	},
+	"uri-reference": func(s string) bool {
+		_, err := url.Parse(s)
+		return err == nil
+	},
+	"uuid": func(s string) bool {
+		return uuidPattern.MatchString(s)
+	},
+	"ipv4": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+	},
+	"ipv6": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && strings.Contains(s, ":")
+	},
+	"hostname": func(s string) bool {
+		return len(s) <= 253 && hostnamePattern.MatchString(s)
+	},
+	"regex": func(s string) bool {
+		_, err := regexp.Compile(s)
+		return err == nil
+	},
+}
+
+var (
+	durationPattern = regexp.MustCompile(`^P(\d+W|(\d+Y)?(\d+M)?(\d+D)?(T(\d+H)?(\d+M)?(\d+S)?)?)$`)
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnamePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+)
+
+// Compile compiles a schema declared as Go maps and slices. Lists may be
+// declared as []string, []interface{} or []map[string]interface{}, and
+// numbers as any Go integer or float type.
+func Compile(raw interface{}) (*Schema, error) {
+	return compile(raw, "#")
+}
+
+// newSchema returns a schema with every count limit unset
+func newSchema() *Schema {
+	return &Schema{minLength: -1, maxLength: -1, minItems: -1, maxItems: -1,
+		minContains: -1, maxContains: -1, minProperties: -1, maxProperties: -1}
+}
+
+func compile(raw interface{}, at string) (*Schema, error) {
+	if b, ok := raw.(bool); ok {
+		s := newSchema()
+		s.alwaysFalse = !b
+		return s, nil
+	}
+
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s: schema must be an object or boolean, got %T", at, raw)
+	}
+
+	s := newSchema()
+
+	// Keywords are compiled in sorted order so errors are deterministic
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := m[key]
+		where := at + "/" + key
+		var err error
+
+		switch key {
+		case "type":
+			s.types, err = stringList(value)
+			for _, t := range s.types {
+				if err == nil && !jsonTypes[t] {
+					err = fmt.Errorf("unknown type %q", t)
+				}
+			}
+		case "enum":
+			s.enum, err = valueList(value)
+		case "const":
+			s.constant, s.hasConst = value, true
+		case "default":
+			s.defaultValue, s.hasDefault = value, true
+
+		case "minLength":
+			s.minLength, err = nonNegativeInt(value)
+		case "maxLength":
+			s.maxLength, err = nonNegativeInt(value)
+		case "pattern":
+			var pattern string
+			if pattern, ok = value.(string); !ok {
+				err = fmt.Errorf("must be a string")
+			} else {
+				s.pattern, err = regexp.Compile(pattern)
+			}
+		case "format":
+			if s.format, ok = value.(string); !ok {
+				err = fmt.Errorf("must be a string")
+			} else if _, known := formatCheckers[s.format]; !known {
+				err = fmt.Errorf("unsupported format %q", s.format)
+			}
+
+		case "minimum":
+			s.minimum, err = number(value)
+		case "maximum":
+			s.maximum, err = number(value)
+		case "exclusiveMinimum":
+			s.exclusiveMinimum, err = number(value)
+		case "exclusiveMaximum":
+			s.exclusiveMaximum, err = number(value)
+		case "multipleOf":
+			s.multipleOf, err = number(value)
+			if err == nil && *s.multipleOf <= 0 {
+				err = fmt.Errorf("must be greater than 0")
+			}
+
+		case "prefixItems":
+			s.prefixItems, err = schemaList(value, where)
+		case "items":
+			s.items, err = compile(value, where)
+		case "contains":
+			s.contains, err = compile(value, where)
+		case "minContains":
+			s.minContains, err = nonNegativeInt(value)
+		case "maxContains":
+			s.maxContains, err = nonNegativeInt(value)
+		case "minItems":
+			s.minItems, err = nonNegativeInt(value)
+		case "maxItems":
+			s.maxItems, err = nonNegativeInt(value)
+		case "uniqueItems":
+			if s.uniqueItems, ok = value.(bool); !ok {
+				err = fmt.Errorf("must be a boolean")
+			}
+
+		case "properties":
+			s.properties, err = schemaMap(value, where)
+		case "patternProperties":
+			var compiled map[string]*Schema
+			if compiled, err = schemaMap(value, where); err == nil {
+				for _, pattern := range sortedKeys(compiled) {
+					re, reErr := regexp.Compile(pattern)
+					if reErr != nil {
+						err = reErr
+						break
+					}
+					s.patternProperties = append(s.patternProperties, patternSchema{re, compiled[pattern]})
+				}
+			}
+		case "additionalProperties":
+			s.additionalProperties, err = compile(value, where)
+		case "propertyNames":
+			s.propertyNames, err = compile(value, where)
+		case "required":
+			s.required, err = stringList(value)
+		case "dependentRequired":
+			deps, isMap := value.(map[string]interface{})
+			if !isMap {
+				err = fmt.Errorf("must be an object")
+				break
+			}
+			s.dependentRequired = make(map[string][]string, len(deps))
+			for name, list := range deps {
+				if s.dependentRequired[name], err = stringList(list); err != nil {
+					break
+				}
+			}
+		case "minProperties":
+			s.minProperties, err = nonNegativeInt(value)
+		case "maxProperties":
+			s.maxProperties, err = nonNegativeInt(value)
+
+		case "allOf":
+			s.allOf, err = schemaList(value, where)
+		case "anyOf":
+			s.anyOf, err = schemaList(value, where)
+		case "oneOf":
+			s.oneOf, err = schemaList(value, where)
+		case "not":
+			s.not, err = compile(value, where)
+		case "if":
+			s.ifSchema, err = compile(value, where)
+		case "then":
+			s.thenSchema, err = compile(value, where)
+		case "else":
+			s.elseSchema, err = compile(value, where)
+
+		default:
+			if !annotationKeywords[key] {
+				err = fmt.Errorf("unsupported keyword")
+			}
+		}
+
+		if err != nil {
+			// Nested compile errors already carry their location
+			if strings.HasPrefix(err.Error(), "#") {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%s: %w", where, err)
+		}
+	}
+
+	return s, nil
+}
+
+// Validate checks a value against the schema. path names the value in
+// error messages and is empty for the root.
+func (s *Schema) Validate(path string, value interface{}) error {
+	if err := s.validate(path, value); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ApplyDefaults fills in missing object properties whose schema declares a
+// default, recursing into the objects and arrays that are present.
+// Defaults are inserted as JSON-decoded values, so numbers become float64
+// exactly as if the client had sent them.
+func (s *Schema) ApplyDefaults(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, prop := range s.properties {
+			if _, ok := v[name]; !ok && prop.hasDefault {
+				v[name] = jsonValue(prop.defaultValue)
+			}
+		}
+		for name, item := range v {
+			if prop, ok := s.properties[name]; ok {
+				prop.ApplyDefaults(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			itemSchema := s.items
+			if i < len(s.prefixItems) {
+				itemSchema = s.prefixItems[i]
+			}
+			if itemSchema != nil {
+				itemSchema.ApplyDefaults(item)
+			}
+		}
+	}
+}
+
+// UnknownProperties returns the sorted names in obj that the schema neither
+// defines nor matches with patternProperties, when additional properties
+// are disallowed
+func (s *Schema) UnknownProperties(obj map[string]interface{}) []string {
+	if s.additionalProperties == nil || !s.additionalProperties.alwaysFalse {
+		return nil
+	}
+
+	var unknown []string
+	for _, name := range sortedKeys(obj) {
+		if _, ok := s.properties[name]; ok {
+			continue
+		}
+		matched := false
+		for _, pp := range s.patternProperties {
+			if pp.pattern.MatchString(name) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+func (s *Schema) validate(path string, value interface{}) *ValidationError {
+	if s.alwaysFalse {
+		return newPathError("invalid parameter", "{} is not allowed", path)
+	}
+
+	if len(s.types) > 0 && !s.matchesType(value) {
+		if value == nil {
+			return newPathError("null value", "{} cannot be null", path)
+		}
+		article := "a"
+		if strings.IndexAny(s.types[0], "aeiou") == 0 {
+			article = "an"
+		}
+		return newPathError("type mismatch", fmt.Sprintf("{} must be %s %s, got %T",
+			article, strings.Join(s.types, " or "), value), path)
+	}
+
+	if len(s.enum) > 0 {
+		found := false
+		for _, allowed := range s.enum {
+			if jsonEqual(value, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return newPathError("enum constraint", fmt.Sprintf("{} must be one of: %v, got '%v'", s.enum, value), path)
+		}
+	}
+	if s.hasConst && !jsonEqual(value, s.constant) {
+		return newPathError("enum constraint", fmt.Sprintf("{} must be %v, got '%v'", s.constant, value), path)
+	}
+
+	switch kind := jsonKind(value); kind {
+	case "string":
+		if err := s.validateString(path, reflect.ValueOf(value).String()); err != nil {
+			return err
+		}
+	case "number":
+		f, _ := toFloat(value)
+		if err := s.validateNumber(path, f, value); err != nil {
+			return err
+		}
+	case "array":
+		if err := s.validateArray(path, reflect.ValueOf(value)); err != nil {
+			return err
+		}
+	case "object":
+		if err := s.validateObject(path, value.(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	return s.validateApplicators(path, value)
+}
+
+func (s *Schema) validateString(path, value string) *ValidationError {
+	length := utf8.RuneCountInString(value)
+	if s.minLength >= 0 && length < s.minLength {
+		return newPathError("length constraint", fmt.Sprintf("{} must be at least %d characters, got %d", s.minLength, length), path)
+	}
+	if s.maxLength >= 0 && length > s.maxLength {
+		return newPathError("length constraint", fmt.Sprintf("{} must be at most %d characters, got %d", s.maxLength, length), path)
+	}
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		return newPathError("pattern mismatch", fmt.Sprintf("{} does not match required pattern: %s", s.pattern), path)
+	}
+	if s.format != "" && !formatCheckers[s.format](value) {
+		return newPathError("format mismatch", fmt.Sprintf("{} must be a valid %s", s.format), path)
+	}
+	return nil
+}
+
+func (s *Schema) validateNumber(path string, f float64, raw interface{}) *ValidationError {
+	switch {
+	case s.minimum != nil && f < *s.minimum:
+		return newPathError("range constraint", fmt.Sprintf("{} must be at least %s, got %v", formatNumber(*s.minimum), raw), path)
+	case s.maximum != nil && f > *s.maximum:
+		return newPathError("range constraint", fmt.Sprintf("{} must be at most %s, got %v", formatNumber(*s.maximum), raw), path)
+	case s.exclusiveMinimum != nil && f <= *s.exclusiveMinimum:
+		return newPathError("range constraint", fmt.Sprintf("{} must be greater than %s, got %v", formatNumber(*s.exclusiveMinimum), raw), path)
+	case s.exclusiveMaximum != nil && f >= *s.exclusiveMaximum:
+		return newPathError("range constraint", fmt.Sprintf("{} must be less than %s, got %v", formatNumber(*s.exclusiveMaximum), raw), path)
+	case s.multipleOf != nil:
+		quotient := f / *s.multipleOf
+		if math.Abs(quotient-math.Round(quotient)) > 1e-9 {
+			return newPathError("range constraint", fmt.Sprintf("{} must be a multiple of %s, got %v", formatNumber(*s.multipleOf), raw), path)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateArray(path string, rv reflect.Value) *ValidationError {
+	length := rv.Len()
+	if s.minItems >= 0 && length < s.minItems {
+		return newPathError("array constraint", fmt.Sprintf("{} must have at least %d items, got %d", s.minItems, length), path)
+	}
+	if s.maxItems >= 0 && length > s.maxItems {
+		return newPathError("array constraint", fmt.Sprintf("{} must have at most %d items, got %d", s.maxItems, length), path)
+	}
+
+	if s.uniqueItems {
+		for i := 0; i < length; i++ {
+			for j := i + 1; j < length; j++ {
+				if jsonEqual(rv.Index(i).Interface(), rv.Index(j).Interface()) {
+					return newPathError("uniqueness constraint", "{} contains duplicate items", path)
+				}
+			}
+		}
+	}
+
+	matches := 0
+	for i := 0; i < length; i++ {
+		item := rv.Index(i).Interface()
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+		itemSchema := s.items
+		if i < len(s.prefixItems) {
+			itemSchema = s.prefixItems[i]
+		}
+		if itemSchema != nil {
+			if err := itemSchema.validate(itemPath, item); err != nil {
+				return err
+			}
+		}
+		if s.contains != nil && s.contains.validate(itemPath, item) == nil {
+			matches++
+		}
+	}
+
+	if s.contains != nil {
+		minContains := 1
+		if s.minContains >= 0 {
+			minContains = s.minContains
+		}
+		if matches < minContains {
+			return newPathError("array constraint", fmt.Sprintf("{} must contain at least %d matching items, got %d", minContains, matches), path)
+		}
+		if s.maxContains >= 0 && matches > s.maxContains {
+			return newPathError("array constraint", fmt.Sprintf("{} must contain at most %d matching items, got %d", s.maxContains, matches), path)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateObject(path string, obj map[string]interface{}) *ValidationError {
+	for _, name := range s.required {
+		if _, ok := obj[name]; !ok {
+			return newPathError("missing required parameter", "required {} is missing", joinPath(path, name))
+		}
+	}
+	for _, name := range sortedKeys(s.dependentRequired) {
+		if _, ok := obj[name]; !ok {
+			continue
+		}
+		for _, dep := range s.dependentRequired[name] {
+			if _, ok := obj[dep]; !ok {
+				return newPathError("missing required parameter",
+					fmt.Sprintf("required {} is missing (needed by '%s')", name), joinPath(path, dep))
+			}
+		}
+	}
+
+	if s.minProperties >= 0 && len(obj) < s.minProperties {
+		return newPathError("object constraint", fmt.Sprintf("{} must have at least %d properties, got %d", s.minProperties, len(obj)), path)
+	}
+	if s.maxProperties >= 0 && len(obj) > s.maxProperties {
+		return newPathError("object constraint", fmt.Sprintf("{} must have at most %d properties, got %d", s.maxProperties, len(obj)), path)
+	}
+
+	for _, name := range sortedKeys(obj) {
+		value := obj[name]
+		propPath := joinPath(path, name)
+
+		if s.propertyNames != nil {
+			if err := s.propertyNames.validate(propPath, name); err != nil {
+				return newPathError("invalid parameter", fmt.Sprintf("{} is not an allowed name: %s", err.Message), propPath)
+			}
+		}
+
+		matched := false
+		if prop, ok := s.properties[name]; ok {
+			matched = true
+			if err := prop.validate(propPath, value); err != nil {
+				return err
+			}
+		}
+		for _, pp := range s.patternProperties {
+			if pp.pattern.MatchString(name) {
+				matched = true
+				if err := pp.schema.validate(propPath, value); err != nil {
+					return err
+				}
+			}
+		}
+
+		if !matched && s.additionalProperties != nil {
+			if s.additionalProperties.alwaysFalse {
+				return newPathError("unknown parameter", "{} is not defined", propPath)
+			}
+			if err := s.additionalProperties.validate(propPath, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateApplicators(path string, value interface{}) *ValidationError {
+	for _, sub := range s.allOf {
+		if err := sub.validate(path, value); err != nil {
+			return err
+		}
+	}
+
+	if len(s.anyOf) > 0 {
+		matched := false
+		for _, sub := range s.anyOf {
+			if sub.validate(path, value) == nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			if names := requiredAlternatives(s.anyOf); names != nil {
+				return newPathError("conditional constraint",
+					fmt.Sprintf("at least one of %s must be provided", strings.Join(names, ", ")), "")
+			}
+			return newPathError("conditional constraint", "{} must match at least one of the allowed alternatives", path)
+		}
+	}
+
+	if len(s.oneOf) > 0 {
+		matches := 0
+		for _, sub := range s.oneOf {
+			if sub.validate(path, value) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			if names := requiredAlternatives(s.oneOf); names != nil {
+				return newPathError("conditional constraint",
+					fmt.Sprintf("exactly one of %s must be provided", strings.Join(names, ", ")), "")
+			}
+			return newPathError("conditional constraint",
+				fmt.Sprintf("{} must match exactly one of the allowed alternatives, matched %d", matches), path)
+		}
+	}
+
+	if s.not != nil && s.not.validate(path, value) == nil {
+		return newPathError("conditional constraint", "{} must not satisfy the excluded condition", path)
+	}
+
+	if s.ifSchema != nil {
+		if s.ifSchema.validate(path, value) == nil {
+			if s.thenSchema != nil {
+				return s.thenSchema.validate(path, value)
+			}
+		} else if s.elseSchema != nil {
+			return s.elseSchema.validate(path, value)
+		}
+	}
+	return nil
+}
+
+// matchesType reports whether value is one of the schema's types
+func (s *Schema) matchesType(value interface{}) bool {
+	kind := jsonKind(value)
+	for _, t := range s.types {
+		switch {
+		case t == kind:
+			return true
+		case t == "integer" && kind == "number":
+			if f, ok := toFloat(value); ok && f == math.Trunc(f) && !math.IsInf(f, 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// requiredAlternatives returns the property names when every alternative
+// only requires a single property, as in "provide content or embeds"
+func requiredAlternatives(alternatives []*Schema) []string {
+	names := make([]string, 0, len(alternatives))
+	for _, alt := range alternatives {
+		if len(alt.required) != 1 || len(alt.types) > 0 || alt.properties != nil || alt.anyOf != nil || alt.allOf != nil {
+			return nil
+		}
+		names = append(names, alt.required[0])
+	}
+	return names
+}
+
+// newPathError builds a validation error whose message names the value at
+// path in place of the {} placeholder
+func newPathError(errorType, message, path string) *ValidationError {
+	if path == "" {
+		return NewValidationError(errorType, strings.Replace(message, "{}", "arguments", 1), nil)
+	}
+	return NewValidationError(errorType, strings.Replace(message, "{}", fmt.Sprintf("parameter '%s'", path), 1), rootField(path))
+}
+
+// rootField returns the top-level parameter name of a path such as
+// "embeds[0].title", used as the error's field
+func rootField(path string) string {
+	if i := strings.IndexAny(path, ".["); i > 0 {
+		return path[:i]
+	}
+	return path
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// jsonKind returns the JSON type of a decoded or Go value
+func jsonKind(value interface{}) string {
+	if value == nil {
+		return "null"
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map:
+		if _, ok := value.(map[string]interface{}); ok {
+			return "object"
+		}
+	}
+	return "unknown"
+}
+
+// jsonEqual compares two values by JSON semantics, so 1 equals 1.0 and
+// []string{"a"} equals []interface{}{"a"}
+func jsonEqual(a, b interface{}) bool {
+	ka, kb := jsonKind(a), jsonKind(b)
+	if ka != kb {
+		return false
+	}
+
+	switch ka {
+	case "number":
+		fa, _ := toFloat(a)
+		fb, _ := toFloat(b)
+		return fa == fb
+	case "array":
+		ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+		if ra.Len() != rb.Len() {
+			return false
+		}
+		for i := 0; i < ra.Len(); i++ {
+			if !jsonEqual(ra.Index(i).Interface(), rb.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	case "object":
+		ma, mb := a.(map[string]interface{}), b.(map[string]interface{})
+		if len(ma) != len(mb) {
+			return false
+		}
+		for key, va := range ma {
+			vb, ok := mb[key]
+			if !ok || !jsonEqual(va, vb) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
+
+// jsonValue returns a fresh copy of a schema-declared value in the form
+// encoding/json decodes into: float64 numbers, []interface{} lists and
+// map[string]interface{} objects
+func jsonValue(value interface{}) interface{} {
+	switch jsonKind(value) {
+	case "number":
+		f, _ := toFloat(value)
+		return f
+	case "array":
+		rv := reflect.ValueOf(value)
+		list := make([]interface{}, rv.Len())
+		for i := range list {
+			list[i] = jsonValue(rv.Index(i).Interface())
+		}
+		return list
+	case "object":
+		obj := value.(map[string]interface{})
+		copied := make(map[string]interface{}, len(obj))
+		for key, item := range obj {
+			copied[key] = jsonValue(item)
+		}
+		return copied
+	}
+	return value
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Keyword value helpers
+
+func number(value interface{}) (*float64, error) {
+	f, ok := toFloat(value)
+	if !ok {
+		return nil, fmt.Errorf("must be a number")
+	}
+	return &f, nil
+}
+
+func nonNegativeInt(value interface{}) (int, error) {
+	f, ok := toFloat(value)
+	if !ok || f < 0 || f != math.Trunc(f) {
+		return 0, fmt.Errorf("must be a non-negative integer")
+	}
+	return int(f), nil
+}
+
+func stringList(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []string:
+		return v, nil
+	case []interface{}:
+		list := make([]string, len(v))
+		for i, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("must be a list of strings")
+			}
+			list[i] = s
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("must be a list of strings")
+}
+
+func valueList(value interface{}) ([]interface{}, error) {
+	rv := reflect.ValueOf(value)
+	if value == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return nil, fmt.Errorf("must be a list")
+	}
+	list := make([]interface{}, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+	return list, nil
+}
+
+func schemaList(value interface{}, at string) ([]*Schema, error) {
+	raw, err := valueList(value)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*Schema, len(raw))
+	for i, item := range raw {
+		if list[i], err = compile(item, fmt.Sprintf("%s/%d", at, i)); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+func schemaMap(value interface{}, at string) (map[string]*Schema, error) {
+	raw, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be an object")
+	}
+	compiled := make(map[string]*Schema, len(raw))
+	for name, item := range raw {
+		sub, err := compile(item, at+"/"+name)
+		if err != nil {
+			return nil, err
+		}
+		compiled[name] = sub
+	}
+	return compiled, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -16,14 +16,19 @@ var ToolSchemas = map[string]interface{}{
 			"content": map[string]interface{}{
 				"type":        "string",
 				"minLength":   1,
-				"maxLength":   2000,
-				"description": "Message content (Discord markdown supported)",
+				"maxLength":   20000,
+				"description": "Message content (Discord markdown supported). At most 2000 characters unless auto_split is set",
 			},
 			"tts": map[string]interface{}{
 				"type":        "boolean",
 				"default":     false,
 				"description": "Whether message should be read aloud using TTS",
 			},
+			"auto_split": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Send content longer than 2000 characters as several messages, split between paragraphs and never inside code blocks",
+			},
 			"reply_to": map[string]interface{}{
 				"type":        "string",
 				"pattern":     "^[0-9]+$",
@@ -119,18 +124,38 @@ var ToolSchemas = map[string]interface{}{
 			},
 			"before": map[string]interface{}{
 				"type":        "string",
-				"pattern":     "^[0-9]+$",
-				"description": "Get messages before this message ID",
+				"minLength":   1,
+				"description": "Get messages before this message ID, or this time (RFC 3339 or relative such as 2h or yesterday)",
 			},
 			"after": map[string]interface{}{
 				"type":        "string",
-				"pattern":     "^[0-9]+$",
-				"description": "Get messages after this message ID",
+				"minLength":   1,
+				"description": "Get messages after this message ID, or this time (RFC 3339 or relative such as 2h or yesterday)",
 			},
 			"around": map[string]interface{}{
 				"type":        "string",
-				"pattern":     "^[0-9]+$",
-				"description": "Get messages around this message ID",
+				"minLength":   1,
+				"description": "Get messages around this message ID, or this time (RFC 3339 or relative such as 2h or yesterday)",
+			},
+			"include_images": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Also return small image attachments as image content so multimodal clients can see them",
+			},
+			"summarize": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Also return a summary from the configured summarizer (summarizer.backend)",
+			},
+			"summary_instructions": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   2000,
+				"description": "What the summary should focus on; defaults to summarizer.instructions",
+			},
+			"summary_only": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Leave the raw messages out when a summary was produced",
 			},
 		},
 		"required": []string{"channel_id"},
@@ -220,7 +245,8 @@ var ToolSchemas = map[string]interface{}{
 			},
 			"emoji": map[string]interface{}{
 				"type":        "string",
-				"description": "Emoji to add (Unicode emoji or custom emoji format)",
+				"minLength":   1,
+				"description": "Emoji to add: a Unicode emoji, a shortcode such as :thumbsup: or :wave::skin-tone-2:, or a custom emoji as <:name:id>, name:id or :name: from a server the bot is in",
 			},
 		},
 		"required": []string{"channel_id", "message_id", "emoji"},
@@ -248,6 +274,11 @@ var ToolSchemas = map[string]interface{}{
 				"default":     false,
 				"description": "Include bot permissions for each channel",
 			},
+			"include_activity": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Include last message time, slowmode, active thread counts and voice member counts",
+			},
 		},
 		"required": []string{"guild_id"},
 	},
@@ -280,7 +311,12 @@ var ToolSchemas = map[string]interface{}{
 			"include_counts": map[string]interface{}{
 				"type":        "boolean",
 				"default":     true,
-				"description": "Include member and channel counts",
+				"description": "Include approximate member and online counts, plus channel and role counts",
+			},
+			"include_features": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Include the guild's feature flags",
 			},
 		},
 		"required": []string{"guild_id"},
@@ -294,6 +330,11 @@ var ToolSchemas = map[string]interface{}{
 				"pattern":     "^[0-9]+$",
 				"description": "Guild (server) ID",
 			},
+			"stream": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Page through every member and send each page as a discord/partialResult notification (requires _meta.progressToken); the result then only reports counts",
+			},
 		},
 		"required": []string{"guild_id"},
 	},
@@ -327,6 +368,11 @@ var ToolSchemas = map[string]interface{}{
 				"type":        "string",
 				"description": "Name of the new role",
 			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for creating the role (appears in audit log)",
+			},
 		},
 		"required": []string{"guild_id", "name"},
 	},
@@ -344,6 +390,11 @@ var ToolSchemas = map[string]interface{}{
 				"pattern":     "^[0-9]+$",
 				"description": "Role ID to delete",
 			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for deleting the role (appears in audit log)",
+			},
 		},
 		"required": []string{"guild_id", "role_id"},
 	},
@@ -366,6 +417,11 @@ var ToolSchemas = map[string]interface{}{
 				"pattern":     "^[0-9]+$",
 				"description": "User ID to assign the role to",
 			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for assigning the role (appears in audit log)",
+			},
 		},
 		"required": []string{"guild_id", "role_id", "user_id"},
 	},
@@ -388,6 +444,11 @@ var ToolSchemas = map[string]interface{}{
 				"pattern":     "^[0-9]+$",
 				"description": "User ID to unassign the role from",
 			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for unassigning the role (appears in audit log)",
+			},
 		},
 		"required": []string{"guild_id", "role_id", "user_id"},
 	},
@@ -403,29 +464,2624 @@ var ToolSchemas = map[string]interface{}{
 		},
 		"required": []string{"guild_id"},
 	},
-}
 
-// GetToolSchema returns the JSON schema for a specific tool
-func GetToolSchema(toolName string) (interface{}, bool) {
-	schema, exists := ToolSchemas[toolName]
-	return schema, exists
-}
+	"cache_stats": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"flush": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Drop all cached entries after reporting statistics",
+			},
+		},
+		"required": []string{},
+	},
 
-// GetToolDefinition returns a types.Tool with the schema for a given tool
-func GetToolDefinition(toolName, description string) types.Tool {
-	schema, exists := GetToolSchema(toolName)
-	if !exists {
-		// Return a basic schema if not found
-		schema = map[string]interface{}{
-			"type":       "object",
-			"properties": map[string]interface{}{},
-			"required":   []string{},
-		}
-	}
+	"poll_events": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"cursor": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"default":     0,
+				"description": "Return events after this cursor (use next_cursor from the previous poll; 0 for all buffered events)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     500,
+				"default":     100,
+				"description": "Maximum number of events to return",
+			},
+			"events": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type":    "string",
+					"pattern": "^discord/[A-Za-z]+$",
+				},
+				"description": "Only return these event types (e.g. discord/messageCreated)",
+			},
+		},
+		"required": []string{},
+	},
 
-	return types.Tool{
-		Name:        toolName,
-		Description: description,
-		InputSchema: schema,
+	"create_watch": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"type": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"keyword", "mention", "user", "emoji"},
+				"description": "What to watch for: a keyword regex, bot mentions, messages from a user, or reactions with an emoji",
+			},
+			"pattern": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   200,
+				"description": "Regular expression matched against message content (keyword watches). Prefix with (?i) for case-insensitive matching",
+			},
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "User whose messages trigger the watch (user watches)",
+			},
+			"emoji": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"description": "Unicode emoji, custom emoji name, or <:name:id> (emoji watches)",
+			},
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only trigger in this guild",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only trigger in this channel",
+			},
+		},
+		"required": []string{"type"},
+	},
+
+	"list_watches": map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+		"required":   []string{},
+	},
+
+	"delete_watch": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"watch_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^w[0-9]+$",
+				"description": "ID of the watch to delete",
+			},
+		},
+		"required": []string{"watch_id"},
+	},
+
+	"set_presence": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"status": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"online", "idle", "dnd", "invisible"},
+				"default":     "online",
+				"description": "Bot status",
+			},
+			"activity_type": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"playing", "streaming", "listening", "watching", "custom", "competing"},
+				"default":     "playing",
+				"description": "Activity type shown before the text (e.g. \"Watching ...\"); custom shows the text on its own",
+			},
+			"activity_text": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   128,
+				"description": "Activity text, e.g. \"for questions\". Omit to clear the activity",
+			},
+			"stream_url": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^https://(www\\.)?(twitch\\.tv|youtube\\.com)/",
+				"description": "Twitch or YouTube URL (required for the streaming activity type)",
+			},
+		},
+		"required": []string{},
+	},
+
+	"join_voice_channel": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Voice or stage channel ID to join",
+			},
+		},
+		"required": []string{"channel_id"},
+	},
+
+	"leave_voice_channel": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID whose voice channel to leave",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+
+	"play_audio": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID where the bot is connected to voice",
+			},
+			"url": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^https?://",
+				"description": "http(s) URL of an audio file or stream",
+			},
+			"audio_base64": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"description": "Base64-encoded audio file (mp3, ogg, wav, ...), up to 25 MB",
+			},
+			"filename": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   200,
+				"description": "Name of the uploaded file, used in notifications",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"speak_in_voice": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID to speak in",
+			},
+			"text": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   5000,
+				"description": "Text to speak",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Voice channel to join first (optional if the bot is already connected)",
+			},
+			"voice": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   100,
+				"description": "Voice name passed to the TTS engine (defaults to voice.tts.voice)",
+			},
+		},
+		"required": []string{"guild_id", "text"},
+	},
+	"export_channel": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel ID to export",
+			},
+			"format": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"json", "csv", "markdown"},
+				"description": "Transcript format (default json)",
+			},
+			"after": map[string]interface{}{
+				"type":        "string",
+				"description": "Only export messages at or after this time (RFC 3339, YYYY-MM-DD [HH:MM] with an optional zone, or relative such as 2h, since yesterday or last friday 6pm; read in the guild's time zone)",
+			},
+			"before": map[string]interface{}{
+				"type":        "string",
+				"description": "Only export messages before this time (RFC 3339, YYYY-MM-DD [HH:MM] with an optional zone, or relative such as 2h, since yesterday or last friday 6pm; read in the guild's time zone)",
+			},
+			"output": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"file", "resource"},
+				"description": "Write to the export directory (file, default) or return an embedded MCP resource",
+			},
+			"max_messages": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"description": "Maximum number of messages to export (capped by export.max_messages)",
+			},
+			"scan_cursor": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   4096,
+				"description": "Continue with older messages after a truncated export (scan_cursor from its result)",
+			},
+			"stream": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Also send each fetched page of messages as a discord/partialResult notification (requires _meta.progressToken)",
+			},
+			"summarize": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Also return a summary from the configured summarizer (summarizer.backend)",
+			},
+			"summary_instructions": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   2000,
+				"description": "What the summary should focus on; defaults to summarizer.instructions",
+			},
+			"summary_only": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "With output resource, leave the transcript out when a summary was produced",
+			},
+		},
+		"required": []string{"channel_id"},
+	},
+	"get_guild_analytics": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"days": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     30,
+				"default":     7,
+				"description": "Number of days covered by trends and channel activity",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"get_conversation_context": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel (or thread) ID containing the message",
+			},
+			"message_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Message to build context around",
+			},
+			"surrounding": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"maximum":     100,
+				"default":     20,
+				"description": "Number of messages around the target to consider",
+			},
+			"max_chars": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     200,
+				"maximum":     100000,
+				"default":     8000,
+				"description": "Character budget for the transcript",
+			},
+			"max_tokens": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     50,
+				"maximum":     25000,
+				"description": "Approximate token budget (about 4 characters per token); overrides max_chars",
+			},
+		},
+		"required": []string{"channel_id", "message_id"},
+	},
+	"search_archive": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"query": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   500,
+				"description": "Full-text query (FTS5 syntax: words, \"exact phrases\", OR, NOT, prefix*)",
+			},
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only return messages from this guild",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only return messages from this channel",
+			},
+			"author_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only return messages by this user",
+			},
+			"after": map[string]interface{}{
+				"type":        "string",
+				"description": "Only return messages at or after this time (RFC 3339, YYYY-MM-DD [HH:MM] with an optional zone, or relative such as 2h, since yesterday or last friday 6pm; read in the guild's time zone)",
+			},
+			"before": map[string]interface{}{
+				"type":        "string",
+				"description": "Only return messages before this time (RFC 3339, YYYY-MM-DD [HH:MM] with an optional zone, or relative such as 2h, since yesterday or last friday 6pm; read in the guild's time zone)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     100,
+				"default":     25,
+				"description": "Maximum number of results",
+			},
+			"stream": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Send results in chunks as discord/partialResult notifications (requires _meta.progressToken); the result then only reports counts",
+			},
+		},
+	},
+	"get_reaction_stats": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel ID to scan",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     1000,
+				"default":     100,
+				"description": "Number of recent messages to scan",
+			},
+			"top": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     50,
+				"default":     10,
+				"description": "Number of most-reacted messages and top reactors to return",
+			},
+			"include_reactors": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Look up who reacted on the most-reacted messages (extra API calls)",
+			},
+			"include_images": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Also return the most used custom emoji as image content",
+			},
+			"scan_cursor": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   4096,
+				"description": "Scan the messages older than a previous scan (scan_cursor from its result)",
+			},
+		},
+		"required": []string{"channel_id"},
+	},
+	"parse_snowflake": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Any Discord ID (message, user, channel, guild, ...)",
+			},
+		},
+		"required": []string{"id"},
+	},
+	"snowflake_for_time": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"timestamp": map[string]interface{}{
+				"type":        "string",
+				"description": "RFC 3339 timestamp, YYYY-MM-DD [HH:MM] in UTC or with a zone such as CET, or relative such as yesterday or last friday 6pm",
+			},
+			"ago": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+(\\.[0-9]+)?(ms|s|m|h)([0-9]+(\\.[0-9]+)?(ms|s|m|h))*$",
+				"description": "Duration before now, e.g. 30m, 24h, 1h30m, 3d or \"2 hours\"",
+			},
+		},
+	},
+	"resolve_channel": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID to search (defaults to the configured guild)",
+			},
+			"query": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   100,
+				"description": "Channel name, mention or ID, e.g. \"#announcements\"",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     25,
+				"default":     5,
+				"description": "Maximum number of candidates to return",
+			},
+		},
+		"required": []string{"query"},
+	},
+	"resolve_role": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID to search (defaults to the configured guild)",
+			},
+			"query": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   100,
+				"description": "Role name, mention or ID, e.g. \"@Moderators\"",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     25,
+				"default":     5,
+				"description": "Maximum number of candidates to return",
+			},
+		},
+		"required": []string{"query"},
+	},
+	"resolve_user": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID to search (defaults to the configured guild)",
+			},
+			"query": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   100,
+				"description": "Username, display name or nickname name, mention or ID, e.g. \"@alice\"",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     25,
+				"default":     5,
+				"description": "Maximum number of candidates to return",
+			},
+		},
+		"required": []string{"query"},
+	},
+	"build_embed_from_markdown": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"markdown": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   60000,
+				"description": "Markdown document; '# Heading' or '---' starts a new embed",
+			},
+			"color": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^#?[0-9a-fA-F]{6}$",
+				"description": "Embed color as hex, e.g. #5865F2",
+			},
+		},
+		"required": []string{"markdown"},
+	},
+	"list_templates": map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+		"required":   []string{},
+	},
+	"render_template": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"template": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"description": "Template name, as returned by list_templates",
+			},
+			"variables": map[string]interface{}{
+				"type":        "object",
+				"description": "Values for the template's {{placeholders}}, keyed by variable name",
+			},
+		},
+		"required": []string{"template"},
+	},
+	"send_templated_message": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"minLength":   1,
+				"description": "Discord channel ID (snowflake)",
+			},
+			"template": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"description": "Template name, as returned by list_templates",
+			},
+			"variables": map[string]interface{}{
+				"type":        "object",
+				"description": "Values for the template's {{placeholders}}, keyed by variable name",
+			},
+			"reply_to": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Message ID to reply to",
+			},
+		},
+		"required": []string{"channel_id", "template"},
+	},
+	"get_user_info": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"minLength":   1,
+				"description": "Discord user ID (snowflake)",
+			},
+			"include_mutual_guilds": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Include the guilds the user shares with the bot",
+			},
+		},
+		"required": []string{"user_id"},
+	},
+
+	"check_permissions": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID to check guild-wide permissions in",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel ID to check permissions in, including its overwrites",
+			},
+			"role_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Role that manage_roles would assign or delete, checked against the bot's highest role",
+			},
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Member targeted by kick_member, ban_member, timeout_member or manage_nicknames, checked against the role hierarchy",
+			},
+			"operations": map[string]interface{}{
+				"type":        "array",
+				"description": "Operations the agent intends to perform",
+				"minItems":    1,
+				"items": map[string]interface{}{
+					"type": "string",
+					"enum": []string{"add_reaction", "attach_files", "ban_member", "create_invite", "create_thread", "delete_message", "join_voice", "kick_member", "manage_channel", "manage_channels", "manage_emojis", "manage_events", "manage_guild", "manage_messages", "manage_nicknames", "manage_roles", "manage_threads", "manage_webhooks", "mention_everyone", "move_members", "pin_message", "read_messages", "send_embeds", "send_message", "send_tts_message", "timeout_member", "use_external_emojis", "view_audit_log", "view_channel"},
+				},
+				"uniqueItems": true,
+			},
+		},
+		"required": []string{"operations"},
+	},
+
+	"get_bot_info": map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+		"required":   []string{},
+	},
+	"get_emoji_image": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"emoji_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Custom emoji ID (snowflake)",
+			},
+			"size": map[string]interface{}{
+				"type":        "integer",
+				"enum":        []int{16, 32, 64, 128, 256, 512, 1024, 2048, 4096},
+				"description": "Image size in pixels; defaults to cdn.image_size",
+			},
+			"format": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"png", "jpg", "webp", "gif"},
+				"description": "Image format; defaults to gif for animated emoji and png otherwise",
+			},
+			"include_image": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Return the image as image content as well as its URL",
+			},
+		},
+		"required": []string{"emoji_id"},
+	},
+	"export_guild_structure": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID to export",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"apply_guild_structure": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID to change",
+			},
+			"structure": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"description": "YAML structure in the format export_guild_structure returns",
+			},
+			"dry_run": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Only return the planned changes; set to false to apply them",
+			},
+			"prune": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Delete roles, categories and channels the structure does not list",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for the changes (appears in audit log)",
+			},
+		},
+		"required": []string{"guild_id", "structure"},
+	},
+	"set_onboarding_rule": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Server whose new members the rule applies to",
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   100,
+				"description": "Rule name; setting an existing name in the same server replaces that rule",
+			},
+			"role_ids": map[string]interface{}{
+				"type":        "array",
+				"maxItems":    10,
+				"description": "Roles to assign to new members",
+				"items": map[string]interface{}{
+					"type":    "string",
+					"pattern": "^[0-9]+$",
+				},
+			},
+			"direct_message": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "Welcome DM sent to new members. Placeholders: {{user}}, {{username}}, {{display_name}}, {{user_id}}, {{server}}, {{member_count}}",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel to post channel_message in",
+			},
+			"channel_message": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "Welcome message posted in channel_id; takes the same placeholders as direct_message",
+			},
+			"include_bots": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Also run the rule for bots added to the server",
+			},
+			"enabled": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Set to false to keep the rule without running it",
+			},
+		},
+		"required": []string{"guild_id", "name"},
+		"dependentRequired": map[string]interface{}{
+			"channel_message": []string{"channel_id"},
+			"channel_id":      []string{"channel_message"},
+		},
+	},
+
+	"list_onboarding_rules": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list rules for this server",
+			},
+		},
+		"required": []string{},
+	},
+	"create_auto_response": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"trigger": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"exact", "regex", "mention"},
+				"description": "When to reply: a message equal to pattern (ignoring case and surrounding spaces), a message matching the pattern regex, or a message mentioning the bot",
+			},
+			"pattern": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   200,
+				"description": "Text or regular expression to match (exact and regex triggers). Prefix a regex with (?i) for case-insensitive matching",
+			},
+			"reply": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "Message sent as a reply to the matching message; it never pings anyone",
+			},
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only reply in this guild",
+			},
+			"channel_ids": map[string]interface{}{
+				"type":        "array",
+				"maxItems":    25,
+				"description": "Only reply in these channels",
+				"items": map[string]interface{}{
+					"type":    "string",
+					"pattern": "^[0-9]+$",
+				},
+			},
+			"cooldown_seconds": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"maximum":     86400,
+				"default":     10,
+				"description": "Minimum time between replies from this rule in the same channel",
+			},
+		},
+		"required": []string{"trigger", "reply"},
+	},
+
+	"list_auto_responses": map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+		"required":   []string{},
+	},
+
+	"delete_auto_response": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"auto_response_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^ar[0-9]+$",
+				"description": "ID of the auto-response to delete",
+			},
+		},
+		"required": []string{"auto_response_id"},
+	},
+	"create_recurring_post": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel to post in",
+			},
+			"cron": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   100,
+				"description": "Five-field cron expression (minute hour day-of-month month day-of-week), e.g. \"0 9 * * MON\" for Mondays at 09:00, or @hourly, @daily, @weekly, @monthly",
+			},
+			"timezone": map[string]interface{}{
+				"type":        "string",
+				"description": "IANA timezone the cron expression is evaluated in, e.g. Europe/Berlin; defaults to the guild's time zone",
+			},
+			"template": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"description": "Template name, as returned by list_templates; it is re-read on every run",
+			},
+			"variables": map[string]interface{}{
+				"type":        "object",
+				"description": "Values for the template's {{placeholders}}, keyed by variable name",
+			},
+			"content": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "Message text to post instead of a template",
+			},
+		},
+		"required": []string{"channel_id", "cron"},
+		"oneOf": []map[string]interface{}{
+			{"required": []string{"template"}},
+			{"required": []string{"content"}},
+		},
+	},
+
+	"list_recurring_posts": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list posts for this channel",
+			},
+		},
+		"required": []string{},
+	},
+
+	"delete_recurring_post": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"recurring_post_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^rp[0-9]+$",
+				"description": "ID of the recurring post to delete",
+			},
+		},
+		"required": []string{"recurring_post_id"},
+	},
+	"start_giveaway": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel to post the giveaway in",
+			},
+			"prize": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   200,
+				"description": "What the winners receive; used as the embed title",
+			},
+			"description": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   2000,
+				"description": "Extra details shown in the embed, such as eligibility rules",
+			},
+			"winner_count": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     20,
+				"default":     1,
+				"description": "Number of winners to draw",
+			},
+			"emoji": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"default":     "🎉",
+				"description": "Reaction members enter with: a Unicode emoji, a shortcode such as :tada:, or a custom emoji from a server the bot is in",
+			},
+			"ends_at": map[string]interface{}{
+				"type":        "string",
+				"description": "End time shown in the embed: RFC 3339, YYYY-MM-DD HH:MM with an optional zone such as CET, or relative such as 3d or next friday 6pm, read in the guild's time zone; winners are only drawn when draw_giveaway_winner is called",
+			},
+		},
+		"required": []string{"channel_id", "prize"},
+	},
+
+	"list_giveaways": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list giveaways in this guild",
+			},
+			"status": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"open", "drawn"},
+				"description": "Only list open giveaways or giveaways whose winners were drawn",
+			},
+		},
+	},
+
+	"draw_giveaway_winner": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"giveaway_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^g[0-9]+$",
+				"description": "Giveaway ID, as returned by start_giveaway or list_giveaways",
+			},
+			"winner_count": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     20,
+				"description": "Number of winners to draw; defaults to the giveaway's winner_count",
+			},
+			"reroll": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Draw again for a giveaway that already has winners, excluding the previous winners",
+			},
+			"announce": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Reply to the giveaway message mentioning the winners and list them in the embed",
+			},
+		},
+		"required": []string{"giveaway_id"},
+	},
+	"configure_starboard": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild the starboard belongs to",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel messages are reposted to",
+			},
+			"emoji": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"default":     "⭐",
+				"description": "Reaction that counts as a star: a Unicode emoji, a shortcode such as :star:, or a custom emoji from a server the bot is in",
+			},
+			"threshold": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     100,
+				"default":     3,
+				"description": "Reactions a message needs before it is reposted",
+			},
+			"enabled": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Set to false to pause the starboard without losing its settings",
+			},
+		},
+		"required": []string{"guild_id", "channel_id"},
+	},
+
+	"list_starboard_entries": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild whose starboard to list",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     100,
+				"default":     25,
+				"description": "Maximum number of entries to return",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"arm_raid_protection": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild to protect",
+			},
+			"join_threshold": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     2,
+				"maximum":     1000,
+				"description": "Joins within join_window_seconds that count as a raid; defaults to raid.join_threshold",
+			},
+			"join_window_seconds": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     3600,
+				"description": "Window joins are counted over; defaults to raid.join_window_seconds",
+			},
+			"message_threshold": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     2,
+				"maximum":     1000,
+				"description": "Messages in one channel within message_window_seconds that count as a flood; defaults to raid.message_threshold",
+			},
+			"message_window_seconds": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     3600,
+				"description": "Window messages are counted over; defaults to raid.message_window_seconds",
+			},
+			"slowmode_seconds": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"maximum":     21600,
+				"description": "Slowmode applied to a flooded channel, 0 to leave slowmode alone; defaults to raid.slowmode_seconds",
+			},
+			"pause_invites": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Pause invites to the guild after a join flood; defaults to raid.pause_invites",
+			},
+			"cooldown_seconds": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"maximum":     86400,
+				"description": "Time before the same flood raises another incident; defaults to raid.cooldown_seconds",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+
+	"disarm_raid_protection": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild to stop protecting",
+			},
+			"revert": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Restore the slowmode of channels raised during incidents and resume paused invites",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+
+	"list_raid_incidents": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list incidents and protection for this guild",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     100,
+				"default":     25,
+				"description": "Maximum number of incidents to return",
+			},
+		},
+	},
+
+	"undo_action": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"action_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^ua[0-9]+$",
+				"description": "The action_id returned by the tool whose action to reverse",
+			},
+		},
+		"required": []string{"action_id"},
+	},
+
+	"undo_last_action": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only undo the latest action in this guild",
+			},
+		},
+	},
+
+	"execute_plan": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"steps": map[string]interface{}{
+				"type":        "array",
+				"minItems":    1,
+				"maxItems":    25,
+				"description": "Tool calls to run in order",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"tool": map[string]interface{}{
+							"type":        "string",
+							"minLength":   1,
+							"description": "Name of the tool to call",
+						},
+						"arguments": map[string]interface{}{
+							"type":        "object",
+							"description": "Arguments for the tool, as in a direct call",
+						},
+					},
+					"required": []string{"tool"},
+				},
+			},
+			"rollback_on_failure": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "When a step fails, undo the completed steps that recorded an undo action",
+			},
+			"dry_run": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Only check the steps without running them",
+			},
+		},
+		"required": []string{"steps"},
+	},
+
+	"stream_guild_members": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"query": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   100,
+				"description": "Only members whose username starts with this text (all members when omitted)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"default":     0,
+				"description": "Maximum number of members to collect (0 for no limit; Discord caps queried requests at 100)",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+
+	"get_streamed_members": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"stream_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^ms[0-9]+$",
+				"description": "Stream ID returned by stream_guild_members",
+			},
+			"cursor": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"default":     0,
+				"description": "Position to read from (use next_cursor from the previous page; 0 for the start)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     1000,
+				"default":     100,
+				"description": "Maximum number of members to return",
+			},
+		},
+		"required": []string{"stream_id"},
+	},
+
+	"audit_nicknames": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"pattern": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   500,
+				"description": "Regular expression that flags display names it matches (prefix (?i) to ignore case)",
+			},
+			"banned_words": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type":      "string",
+					"minLength": 1,
+					"maxLength": 100,
+				},
+				"maxItems":    200,
+				"description": "Words that flag display names containing them, ignoring case",
+			},
+			"dehoist": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Flag display names starting with a character other than a letter or digit, which hoists them to the top of the member list",
+			},
+			"action": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"report", "normalize", "reset"},
+				"default":     "report",
+				"description": "report only lists offending names; normalize sets a cleaned nickname; reset removes the nickname, or sets fallback_nickname when the account name itself offends",
+			},
+			"fallback_nickname": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   32,
+				"default":     "Moderated Nickname",
+				"description": "Nickname used when nothing acceptable is left of a name",
+			},
+			"max_members": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     10000,
+				"default":     1000,
+				"description": "Maximum number of members to scan",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for the nickname changes (appears in audit log)",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+
+	"permission_matrix": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID for guild-wide role permissions",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel ID to apply the channel's permission overwrites (takes precedence over guild_id)",
+			},
+		},
+	},
+
+	"get_membership_log": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Discord guild (server) ID",
+			},
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only return the joins and leaves of this user",
+			},
+			"type": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"join", "leave"},
+				"description": "Only return joins or only leaves",
+			},
+			"after": map[string]interface{}{
+				"type":        "string",
+				"description": "Only return events at or after this time (RFC 3339, YYYY-MM-DD [HH:MM] with an optional zone, or relative such as 2h, since yesterday or last friday 6pm; read in the guild's time zone)",
+			},
+			"before": map[string]interface{}{
+				"type":        "string",
+				"description": "Only return events before this time (RFC 3339, YYYY-MM-DD [HH:MM] with an optional zone, or relative such as 2h, since yesterday or last friday 6pm; read in the guild's time zone)",
+			},
+			"cursor": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"default":     0,
+				"description": "Position to read from (use next_cursor from the previous page; 0 for the newest events)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     500,
+				"default":     50,
+				"description": "Maximum number of events to return",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+
+	"get_join_leave_stats": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Discord guild (server) ID",
+			},
+			"days": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     3650,
+				"default":     30,
+				"description": "Number of days to summarise; limited to the log's retention",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+
+	"get_stale_threads": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Discord guild (server) ID",
+			},
+			"within_hours": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     168,
+				"default":     24,
+				"description": "List threads that auto-archive within this many hours",
+			},
+			"parent_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list threads of this channel or forum",
+			},
+			"include_participants": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Read each listed thread's recent messages to name its participants (one request per thread)",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     100,
+				"default":     25,
+				"description": "Maximum number of threads to return",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+
+	"find_duplicate_messages": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_ids": map[string]interface{}{
+				"type":        "array",
+				"minItems":    1,
+				"maxItems":    20,
+				"description": "Channels to scan",
+				"items": map[string]interface{}{
+					"type":    "string",
+					"pattern": "^[0-9]+$",
+				},
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     1000,
+				"default":     200,
+				"description": "Recent messages to read per channel",
+			},
+			"threshold": map[string]interface{}{
+				"type":        "number",
+				"minimum":     0.5,
+				"maximum":     1,
+				"default":     0.85,
+				"description": "Similarity from 0.5 to 1 at which messages count as duplicates; 1 only matches identical text",
+			},
+			"min_length": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     500,
+				"default":     10,
+				"description": "Ignore messages shorter than this many characters, so short replies like \"ok\" are not grouped",
+			},
+			"min_count": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     2,
+				"maximum":     100,
+				"default":     3,
+				"description": "Fewest copies a group needs to be reported",
+			},
+			"include_bots": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Compare messages sent by bots too",
+			},
+			"max_groups": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     100,
+				"default":     20,
+				"description": "Maximum number of groups to return, largest first",
+			},
+			"scan_cursor": map[string]interface{}{
+				"type":        "string",
+				"description": "Continue with older messages (scan_cursor from a previous result)",
+			},
+		},
+		"required": []string{"channel_ids"},
+	},
+	"reset_cooldown": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "User whose cooldown to clear",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel whose cooldown to clear",
+			},
+			"all": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Clear every user and channel cooldown",
+			},
+		},
+	},
+	"get_send_queue": map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	},
+	"get_recent_traces": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     200,
+				"default":     20,
+				"description": "Number of traces to return, newest first (1-200)",
+			},
+			"kind": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"rest", "gateway"},
+				"description": "Only REST requests or only gateway events",
+			},
+			"failed_only": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Only REST requests that failed or returned an error status",
+			},
+			"contains": map[string]interface{}{
+				"type":        "string",
+				"description": "Only traces whose URL or event name contains this text, e.g. a channel ID or MESSAGE_CREATE",
+			},
+		},
+	},
+	"get_server_stats": map[string]interface{}{
+		"type":       "object",
+		"properties": map[string]interface{}{},
+	},
+	"get_guild_widget": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"update_guild_widget": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"enabled": map[string]interface{}{
+				"type":        "boolean",
+				"description": "Whether the widget is enabled",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^([0-9]+)?$",
+				"description": "Channel the widget's invite points to; an empty string clears it",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for the change (appears in audit log)",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"get_vanity_url": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"list_integrations": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"max_members": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     10000,
+				"default":     1000,
+				"description": "Maximum number of members to scan for bots",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"follow_announcement_channel": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"source_channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Announcement channel to follow",
+			},
+			"target_channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel that receives the crossposted messages",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for the follow (appears in audit log)",
+			},
+		},
+		"required": []string{"source_channel_id", "target_channel_id"},
+	},
+	"list_announcement_follows": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "List every follow in this guild",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "List only the follows posting into this channel",
+			},
+		},
+	},
+	"set_pin_policy": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel the policy pins messages in",
+			},
+			"role_ids": map[string]interface{}{
+				"type":        "array",
+				"maxItems":    25,
+				"description": "Pin only messages from members with one of these roles",
+				"items": map[string]interface{}{
+					"type":    "string",
+					"pattern": "^[0-9]+$",
+				},
+			},
+			"keywords": map[string]interface{}{
+				"type":        "array",
+				"maxItems":    25,
+				"description": "Pin only messages containing one of these words, ignoring case",
+				"items": map[string]interface{}{
+					"type":      "string",
+					"minLength": 1,
+					"maxLength": 100,
+				},
+			},
+			"pin_limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     50,
+				"default":     45,
+				"description": "Most pins the channel keeps; the oldest are removed to stay within it",
+			},
+			"auto_unpin": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Unpin the oldest pins at the limit; otherwise stop pinning",
+			},
+			"enabled": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Whether the policy pins messages",
+			},
+		},
+		"required": []string{"channel_id"},
+	},
+	"list_pin_policies": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list this guild's policies",
+			},
+		},
+	},
+	"delete_pin_policy": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel whose policy to remove",
+			},
+		},
+		"required": []string{"channel_id"},
+	},
+	"get_thread_transcript": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"thread_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Thread or forum post ID",
+			},
+			"max_messages": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     10000,
+				"default":     2000,
+				"description": "Most messages to read, newest first",
+			},
+			"merge_window_minutes": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"maximum":     1440,
+				"default":     10,
+				"description": "Merge consecutive messages by one author sent within this many minutes of each other",
+			},
+			"max_chars": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     200,
+				"maximum":     200000,
+				"default":     16000,
+				"description": "Character budget for the transcript",
+			},
+			"max_tokens": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     50,
+				"maximum":     50000,
+				"description": "Approximate token budget (about 4 characters per token); overrides max_chars",
+			},
+			"summarize": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Also return a summary from the configured summarizer (summarizer.backend)",
+			},
+			"summary_instructions": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   2000,
+				"description": "What the summary should focus on; defaults to summarizer.instructions",
+			},
+			"summary_only": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Leave the transcript out when a summary was produced",
+			},
+		},
+		"required": []string{"thread_id"},
+	},
+	"get_guild_preview": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID; the bot need not be a member of discoverable servers",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"list_command_permissions": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"command_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list this command",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"set_command_permissions": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"command_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Command ID, or the application ID to set the defaults for every command",
+			},
+			"permissions": map[string]interface{}{
+				"type":     "array",
+				"maxItems": 100,
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"type": map[string]interface{}{
+							"type":        "string",
+							"enum":        []string{"role", "user", "channel"},
+							"description": "What the overwrite applies to",
+						},
+						"id": map[string]interface{}{
+							"type":        "string",
+							"pattern":     "^[0-9]+$",
+							"description": "Role, user or channel ID; the guild ID is @everyone and the guild ID minus 1 is every channel",
+						},
+						"allow": map[string]interface{}{
+							"type":        "boolean",
+							"description": "Allow (true) or deny (false) the command",
+						},
+					},
+					"required": []string{"type", "id", "allow"},
+				},
+				"description": "The command's new overwrites, replacing all current ones; an empty list restores the defaults",
+			},
+		},
+		"required": []string{"guild_id", "command_id", "permissions"},
+	},
+	"list_soundboard_sounds": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"include_defaults": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Also list Discord's default sounds, which can be played in every server",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"upload_soundboard_sound": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"minLength":   2,
+				"maxLength":   32,
+				"description": "Sound name",
+			},
+			"sound_base64": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   700000,
+				"description": "Base64-encoded MP3 or Ogg file of up to 512 KB and 5.2 seconds",
+			},
+			"volume": map[string]interface{}{
+				"type":        "number",
+				"minimum":     0,
+				"maximum":     1,
+				"description": "Playback volume from 0 to 1 (default 1)",
+			},
+			"emoji": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   100,
+				"description": "Emoji shown with the sound: Unicode, :shortcode: or a custom emoji",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for uploading (appears in audit log)",
+			},
+		},
+		"required": []string{"guild_id", "name", "sound_base64"},
+	},
+	"delete_soundboard_sound": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"sound_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Soundboard sound ID",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for deletion (appears in audit log)",
+			},
+		},
+		"required": []string{"guild_id", "sound_id"},
+	},
+	"play_soundboard_sound": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"sound_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Soundboard sound ID",
+			},
+			"source_guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Server the sound belongs to, when it is not guild_id; omit for default sounds",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Voice channel to join first; defaults to the bot's current voice channel",
+			},
+		},
+		"required": []string{"guild_id", "sound_id"},
+	},
+	"add_user_note": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Member the note is about; former members can have notes too",
+			},
+			"content": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "The note, e.g. \"warned on 3/2 for spam\"",
+			},
+			"tags": map[string]interface{}{
+				"type":        "array",
+				"maxItems":    10,
+				"items":       map[string]interface{}{"type": "string", "minLength": 1, "maxLength": 32},
+				"description": "Tags to filter notes by later, e.g. [\"warning\"]",
+			},
+			"author": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   100,
+				"description": "Who wrote the note, e.g. a moderator's name",
+			},
+		},
+		"required": []string{"guild_id", "user_id", "content"},
+	},
+	"get_user_notes": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Member to read notes about; omit for every member",
+			},
+			"tag": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   32,
+				"description": "Only notes with this tag",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     500,
+				"default":     50,
+				"description": "Most notes to return, newest first",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"delete_user_note": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"note_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^un[0-9]+$",
+				"description": "Note ID from add_user_note or get_user_notes",
+			},
+		},
+		"required": []string{"guild_id", "note_id"},
+	},
+	"warn_member": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Member to warn",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   512,
+				"description": "Why the member is warned (appears in the audit log if the warning escalates)",
+			},
+			"moderator": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   100,
+				"description": "Who gave the warning, e.g. a moderator's name",
+			},
+			"escalate": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Apply the configured timeout, kick or ban when this warning reaches an escalation threshold",
+			},
+		},
+		"required": []string{"guild_id", "user_id", "reason"},
+	},
+	"list_warnings": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list this member's warnings",
+			},
+			"include_inactive": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Also list cleared and expired warnings",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     500,
+				"default":     50,
+				"description": "Most warnings to return",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"clear_warning": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"warning_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^wn[0-9]+$",
+				"description": "Warning ID from warn_member or list_warnings",
+			},
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Clear every active warning of this member instead of one warning",
+			},
+			"moderator": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   100,
+				"description": "Who cleared the warning",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Why the warning is cleared",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"open_ticket": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Member the ticket is for; their direct messages to the bot are relayed into it",
+			},
+			"subject": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   200,
+				"description": "What the ticket is about, shown in its topic and first message",
+			},
+			"kind": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"channel", "thread"},
+				"default":     "channel",
+				"description": "channel creates a text channel hidden from everyone but the bot and staff_role_ids; thread creates a private thread in parent_id",
+			},
+			"parent_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Category for a ticket channel, or the text channel a ticket thread is created in (required for threads)",
+			},
+			"staff_role_ids": map[string]interface{}{
+				"type":        "array",
+				"maxItems":    10,
+				"items":       map[string]interface{}{"type": "string", "pattern": "^[0-9]+$"},
+				"description": "Roles that can see the ticket; in a thread they are mentioned so their members are added",
+			},
+			"message": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "Direct message sent to the member, e.g. to say the ticket is open",
+			},
+			"opened_by": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   100,
+				"description": "Who opened the ticket",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for creating the ticket channel (appears in audit log)",
+			},
+		},
+		"required": []string{"guild_id", "user_id"},
+	},
+	"reply_ticket": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"ticket_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^tk[0-9]+$",
+				"description": "Ticket ID from open_ticket or list_tickets",
+			},
+			"content": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "Reply sent to the member by direct message",
+			},
+			"author": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   100,
+				"description": "Name shown with the copy of the reply in the ticket (default \"Staff\")",
+			},
+		},
+		"required": []string{"guild_id", "ticket_id", "content"},
+	},
+	"close_ticket": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"ticket_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^tk[0-9]+$",
+				"description": "Ticket ID from open_ticket or list_tickets",
+			},
+			"format": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"json", "csv", "markdown"},
+				"default":     "markdown",
+				"description": "Transcript format",
+			},
+			"delete_channel": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Delete a ticket channel once its transcript is saved; ticket threads are always archived and locked instead",
+			},
+			"message": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "Direct message sent to the member, e.g. to say the ticket is closed",
+			},
+			"closed_by": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   100,
+				"description": "Who closed the ticket",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for closing the ticket (appears in audit log)",
+			},
+		},
+		"required": []string{"guild_id", "ticket_id"},
+	},
+	"list_tickets": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list this member's tickets",
+			},
+			"status": map[string]interface{}{
+				"type":        "string",
+				"enum":        []string{"open", "closed", "all"},
+				"default":     "open",
+				"description": "Tickets to list",
+			},
+			"limit": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     500,
+				"default":     50,
+				"description": "Most tickets to return",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"create_role_menu": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel to post the menu in",
+			},
+			"content": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "Message text above the menu, e.g. \"Pick your notification roles\"",
+			},
+			"placeholder": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   150,
+				"description": "Text shown in the menu before anything is selected",
+			},
+			"options": map[string]interface{}{
+				"type":     "array",
+				"minItems": 1,
+				"maxItems": 25,
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"role_id": map[string]interface{}{
+							"type":        "string",
+							"pattern":     "^[0-9]+$",
+							"description": "Role given when the option is selected",
+						},
+						"label": map[string]interface{}{
+							"type":        "string",
+							"minLength":   1,
+							"maxLength":   100,
+							"description": "Option text (default: the role's name)",
+						},
+						"description": map[string]interface{}{
+							"type":        "string",
+							"maxLength":   100,
+							"description": "Smaller text under the label",
+						},
+						"emoji": map[string]interface{}{
+							"type":        "string",
+							"description": "Emoji shown with the option: Unicode, :shortcode: or <:name:id>",
+						},
+					},
+					"required": []string{"role_id"},
+				},
+				"description": "Roles members can choose; selecting sets exactly these menu roles, removing the menu's roles left unselected",
+			},
+			"min_values": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"maximum":     25,
+				"default":     0,
+				"description": "Fewest roles a member must select; 0 lets them clear all of the menu's roles",
+			},
+			"max_values": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     25,
+				"description": "Most roles a member can select (default: all options); 1 makes the roles mutually exclusive",
+			},
+		},
+		"required": []string{"channel_id", "content", "options"},
+	},
+	"list_role_menus": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list this guild's menus",
+			},
+		},
+	},
+	"delete_role_menu": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"menu_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^rm[0-9]+$",
+				"description": "Menu ID from create_role_menu or list_role_menus",
+			},
+			"delete_message": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Also delete the menu's message",
+			},
+		},
+		"required": []string{"guild_id", "menu_id"},
+	},
+	"configure_verification": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"unverified_role_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Restricted role given to members when they join and removed when they are verified",
+			},
+			"member_role_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Role given to members when they are verified",
+			},
+			"kick_after_minutes": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"maximum":     40320,
+				"default":     0,
+				"description": "Kick members still unverified this many minutes after joining; 0 never kicks",
+			},
+			"enabled": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Set to false to stop gating new members without losing the settings",
+			},
+			"button_channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Post a message with a Verify button members press to verify themselves in this channel",
+			},
+			"button_message": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "Text of the message posted with the Verify button",
+			},
+			"button_label": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   80,
+				"default":     "Verify",
+				"description": "Label of the Verify button",
+			},
+		},
+		"required": []string{"guild_id", "unverified_role_id"},
+	},
+	"verify_member": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+			"user_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Member to verify",
+			},
+			"reason": map[string]interface{}{
+				"type":        "string",
+				"maxLength":   512,
+				"description": "Reason for verifying the member (appears in audit log)",
+			},
+		},
+		"required": []string{"guild_id", "user_id"},
+	},
+	"list_unverified_members": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Guild (server) ID",
+			},
+		},
+		"required": []string{"guild_id"},
+	},
+	"broadcast_message": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"list": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"description": "Name of a channel list under broadcast.lists in the configuration",
+			},
+			"channel_ids": map[string]interface{}{
+				"type":        "array",
+				"minItems":    1,
+				"maxItems":    50,
+				"items":       map[string]interface{}{"type": "string", "pattern": "^[0-9]+$"},
+				"description": "Channels to send to, in any allowed guilds; use instead of list",
+			},
+			"content": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "Message content (Discord markdown supported)",
+			},
+			"embeds": map[string]interface{}{
+				"type":        "array",
+				"maxItems":    10,
+				"description": "Embed objects, as in send_message",
+			},
+			"interval_ms": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     0,
+				"maximum":     60000,
+				"description": "Pause between two channels; defaults to broadcast.interval_ms",
+			},
+			"dry_run": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Only check each channel and return the preview without sending",
+			},
+		},
+		"anyOf": []map[string]interface{}{
+			{"required": []string{"content"}},
+			{"required": []string{"embeds"}},
+		},
+	},
+	"create_bridge": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"source_channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel whose messages are mirrored",
+			},
+			"target_channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Text or announcement channel messages are mirrored into, in the same or another guild",
+			},
+			"include_bots": map[string]interface{}{
+				"type":        "boolean",
+				"default":     false,
+				"description": "Also mirror messages posted by other bots",
+			},
+			"webhook_name": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   80,
+				"default":     "Bridge",
+				"description": "Name of the webhook created in the target channel",
+			},
+		},
+		"required": []string{"source_channel_id", "target_channel_id"},
+	},
+	"list_bridges": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list bridges with either end in this guild",
+			},
+		},
+	},
+	"delete_bridge": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"bridge_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^br[0-9]+$",
+				"description": "Bridge ID from create_bridge or list_bridges",
+			},
+			"delete_webhook": map[string]interface{}{
+				"type":        "boolean",
+				"default":     true,
+				"description": "Also delete the bridge's webhook",
+			},
+		},
+		"required": []string{"bridge_id"},
+	},
+	"create_relay": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel payloads are posted to",
+			},
+			"name": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   100,
+				"description": "Label for the relay, available to templates as {{relay_name}}",
+			},
+			"template": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"description": "Template from the templates directory to render payloads with",
+			},
+			"content": map[string]interface{}{
+				"type":        "string",
+				"minLength":   1,
+				"maxLength":   2000,
+				"description": "Inline template used instead of a template file, e.g. \"{{sender_login}} pushed to {{repository_full_name}}\". Without template or content the payload is posted as JSON",
+			},
+		},
+		"required": []string{"channel_id", "name"},
+	},
+	"list_relays": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list relays posting to this guild",
+			},
+		},
+	},
+	"delete_relay": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"relay_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^rl[0-9]+$",
+				"description": "Relay ID from create_relay or list_relays",
+			},
+		},
+		"required": []string{"relay_id"},
+	},
+	"add_feed": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"url": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^https?://",
+				"maxLength":   2048,
+				"description": "URL of the RSS or Atom feed",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Channel new entries are posted to",
+			},
+			"interval_minutes": map[string]interface{}{
+				"type":        "integer",
+				"minimum":     1,
+				"maximum":     1440,
+				"description": "Minutes between polls; defaults to feeds.interval_minutes and cannot be below feeds.min_interval_minutes",
+			},
+		},
+		"required": []string{"url", "channel_id"},
+	},
+	"list_feeds": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"guild_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list feeds posting to this guild",
+			},
+			"channel_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^[0-9]+$",
+				"description": "Only list feeds posting to this channel",
+			},
+		},
+	},
+	"remove_feed": map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"feed_id": map[string]interface{}{
+				"type":        "string",
+				"pattern":     "^fd[0-9]+$",
+				"description": "Feed ID from add_feed or list_feeds",
+			},
+		},
+		"required": []string{"feed_id"},
+	},
+}
+
+// GetToolSchema returns the JSON schema for a specific tool
+func GetToolSchema(toolName string) (interface{}, bool) {
+	schema, exists := ToolSchemas[toolName]
+	return schema, exists
+}
+
+// GetToolDefinition returns a types.Tool with the schema for a given tool
+func GetToolDefinition(toolName, description string) types.Tool {
+	schema, exists := GetToolSchema(toolName)
+	if !exists {
+		// Return a basic schema if not found
+		schema = map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{},
+			"required":   []string{},
+		}
+	}
+
+	if schemaMap, ok := schema.(map[string]interface{}); ok {
+		schema = linkAwareSchema(schemaMap)
+	}
+
+	tool := types.Tool{
+		Name:        toolName,
+		Description: description,
+		InputSchema: schema,
+	}
+	if output, exists := GetOutputSchema(toolName); exists {
+		tool.OutputSchema = output
 	}
+	return tool
 }
@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// discordURLPattern matches channel and message links copied from Discord:
+// https://discord.com/channels/<guild or @me>/<channel>[/<message>]
+var discordURLPattern = regexp.MustCompile(`^<?https?://(?:(?:www|ptb|canary)\.)?discord(?:app)?\.com/channels/(@me|[0-9]+)/([0-9]+)(?:/([0-9]+))?/?>?$`)
+
+// idOrURLPattern is the published pattern for ID parameters that also accept links
+const idOrURLPattern = `^([0-9]+|<?https?://(?:(?:www|ptb|canary)\.)?discord(?:app)?\.com/channels/(@me|[0-9]+)/[0-9]+(/[0-9]+)?/?>?)$`
+
+// linkParams are the ID parameters that accept a Discord link instead
+var linkParams = []string{"channel_id", "message_id"}
+
+// ParseDiscordURL extracts the IDs from a Discord channel or message link.
+// guildID is empty for DM links.
+func ParseDiscordURL(value string) (guildID, channelID, messageID string, ok bool) {
+	match := discordURLPattern.FindStringSubmatch(value)
+	if match == nil {
+		return "", "", "", false
+	}
+	if match[1] != "@me" {
+		guildID = match[1]
+	}
+	return guildID, match[2], match[3], true
+}
+
+// expandDiscordURLs replaces link values of channel_id/message_id with the
+// IDs they contain, also filling in the other ID parameters the tool accepts
+func expandDiscordURLs(properties map[string]interface{}, params map[string]interface{}) error {
+	derived := make(map[string]string)
+	for _, name := range linkParams {
+		value, ok := params[name].(string)
+		if !ok {
+			continue
+		}
+		guildID, channelID, messageID, ok := ParseDiscordURL(value)
+		if !ok {
+			continue
+		}
+		if name == "message_id" && messageID == "" {
+			return NewValidationError("invalid parameter", "message_id link does not point to a message", name)
+		}
+		delete(params, name)
+
+		ids := map[string]string{"guild_id": guildID, "channel_id": channelID, "message_id": messageID}
+		for param, id := range ids {
+			if _, accepted := properties[param]; !accepted || id == "" {
+				continue
+			}
+			if previous, ok := derived[param]; ok && previous != id {
+				return NewValidationError("conflicting parameters",
+					fmt.Sprintf("links disagree on %s (%s vs %s)", param, previous, id), param)
+			}
+			derived[param] = id
+		}
+	}
+
+	for param, id := range derived {
+		if existing, set := params[param].(string); set && existing != id {
+			return NewValidationError("conflicting parameters",
+				fmt.Sprintf("%s %s does not match the link (%s)", param, existing, id), param)
+		}
+		params[param] = id
+	}
+	return nil
+}
+
+// linkAwareSchema returns a copy of a tool schema whose ID parameters
+// advertise that Discord links are accepted
+func linkAwareSchema(schema map[string]interface{}) map[string]interface{} {
+	properties, ok := schema["properties"].(map[string]interface{})
+	if !ok {
+		return schema
+	}
+
+	var copied map[string]interface{}
+	for _, name := range linkParams {
+		prop, ok := properties[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if copied == nil {
+			copied = make(map[string]interface{}, len(properties))
+			for k, v := range properties {
+				copied[k] = v
+			}
+		}
+
+		updated := make(map[string]interface{}, len(prop))
+		for k, v := range prop {
+			updated[k] = v
+		}
+		updated["pattern"] = idOrURLPattern
+		if desc, ok := prop["description"].(string); ok {
+			updated["description"] = desc + " (an ID or a https://discord.com/channels/... link)"
+		}
+		copied[name] = updated
+	}
+	if copied == nil {
+		return schema
+	}
+
+	result := make(map[string]interface{}, len(schema))
+	for k, v := range schema {
+		result[k] = v
+	}
+	result["properties"] = copied
+	return result
+}
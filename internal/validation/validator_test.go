@@ -0,0 +1,364 @@
+package validation
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/config"
+)
+
+// patternSamples are values matching the patterns tool schemas declare
+var patternSamples = map[string]string{
+	"^[0-9]+$":           "123456789012345678",
+	"^([0-9]+)?$":        "42",
+	"^https?://":         "https://example.com/feed",
+	"^#?[0-9a-fA-F]{6}$": "#ff8800",
+	"^https://(www\\.)?(twitch\\.tv|youtube\\.com)/":                "https://twitch.tv/example",
+	"^[0-9]+(\\.[0-9]+)?(ms|s|m|h)([0-9]+(\\.[0-9]+)?(ms|s|m|h))*$": "1h30m",
+	"^discord/[A-Za-z]+$": "discord/messageCreated",
+}
+
+// formatSamples are values of the formats tool schemas assert
+var formatSamples = map[string]string{
+	"uri":       "https://example.com/image.png",
+	"date-time": "2025-01-05T18:00:00Z",
+	"date":      "2025-01-05",
+	"email":     "someone@example.com",
+}
+
+// sampleValue builds a value that satisfies a schema, for the keywords
+// tool schemas use
+func sampleValue(t *testing.T, tool string, raw interface{}) interface{} {
+	schema, ok := raw.(map[string]interface{})
+	if !ok {
+		return "x"
+	}
+	if value, ok := schema["const"]; ok {
+		return value
+	}
+	if values := listOf(schema["enum"]); len(values) > 0 {
+		return values[0]
+	}
+	if value, ok := schema["default"]; ok {
+		return value
+	}
+
+	switch typeOf(schema) {
+	case "object":
+		object := map[string]interface{}{}
+		fillRequired(t, tool, schema, object)
+		return object
+	case "array":
+		count := 1
+		if n, ok := toFloat(schema["minItems"]); ok && int(n) > count {
+			count = int(n)
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			items[i] = sampleValue(t, tool, schema["items"])
+		}
+		if schema["uniqueItems"] == true && count > 1 {
+			t.Fatalf("%s: cannot build unique array items", tool)
+		}
+		return items
+	case "integer", "number":
+		value := 1.0
+		if n, ok := toFloat(schema["minimum"]); ok {
+			value = n
+		}
+		if n, ok := toFloat(schema["exclusiveMinimum"]); ok {
+			value = n + 1
+		}
+		return value
+	case "boolean":
+		return true
+	case "string":
+		if pattern, ok := schema["pattern"].(string); ok {
+			if strings.HasPrefix(pattern, "^") && strings.HasSuffix(pattern, "[0-9]+$") && len(pattern) < 12 {
+				// Stored IDs such as tk12 or fd3
+				return strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "[0-9]+$") + "1"
+			}
+			sample, ok := patternSamples[pattern]
+			if !ok {
+				t.Fatalf("%s: no sample for pattern %q", tool, pattern)
+			}
+			return sample
+		}
+		if format, ok := schema["format"].(string); ok {
+			return formatSamples[format]
+		}
+		length := 1
+		if n, ok := toFloat(schema["minLength"]); ok && int(n) > length {
+			length = int(n)
+		}
+		return strings.Repeat("a", length)
+	}
+	return "x"
+}
+
+// fillRequired adds sample values for the required properties of an object
+// schema, including those of its first anyOf or oneOf alternative
+func fillRequired(t *testing.T, tool string, schema map[string]interface{}, object map[string]interface{}) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	required := listOf(schema["required"])
+	for _, key := range []string{"anyOf", "oneOf"} {
+		if alternatives := listOf(schema[key]); len(alternatives) > 0 {
+			if first, ok := alternatives[0].(map[string]interface{}); ok {
+				required = append(required, listOf(first["required"])...)
+			}
+		}
+	}
+	for _, name := range required {
+		name := name.(string)
+		if _, ok := object[name]; !ok {
+			object[name] = sampleValue(t, tool, properties[name])
+		}
+	}
+}
+
+// typeOf returns the first type a schema declares
+func typeOf(schema map[string]interface{}) string {
+	switch value := schema["type"].(type) {
+	case string:
+		return value
+	case []string:
+		return value[0]
+	case []interface{}:
+		return value[0].(string)
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	return ""
+}
+
+// listOf returns a schema list keyword as a slice of values
+func listOf(value interface{}) []interface{} {
+	switch list := value.(type) {
+	case []interface{}:
+		return list
+	case []string:
+		values := make([]interface{}, len(list))
+		for i, s := range list {
+			values[i] = s
+		}
+		return values
+	case []map[string]interface{}:
+		values := make([]interface{}, len(list))
+		for i, m := range list {
+			values[i] = m
+		}
+		return values
+	}
+	return nil
+}
+
+// newTestValidator returns a validator that rejects unknown parameters
+func newTestValidator() *Validator {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return NewValidator(config.ValidationConfig{UnknownParameters: ModeStrict}, logger)
+}
+
+func TestToolSchemasCompile(t *testing.T) {
+	v := newTestValidator()
+	for tool, err := range v.SchemaErrors() {
+		t.Errorf("%s: %v", tool, err)
+	}
+}
+
+func TestToolSchemas(t *testing.T) {
+	v := newTestValidator()
+
+	tools := make([]string, 0, len(ToolSchemas))
+	for tool := range ToolSchemas {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
+
+	for _, tool := range tools {
+		t.Run(tool, func(t *testing.T) {
+			schema := ToolSchemas[tool].(map[string]interface{})
+
+			valid := map[string]interface{}{}
+			fillRequired(t, tool, schema, valid)
+			if err := v.ValidateToolParams(tool, copyPayload(valid)); err != nil {
+				t.Fatalf("valid payload %v rejected: %v", valid, err)
+			}
+
+			// An unknown parameter is rejected in strict mode, a missing
+			// required parameter always
+			invalid := copyPayload(valid)
+			invalid["not_a_parameter"] = true
+			if err := v.ValidateToolParams(tool, invalid); err == nil {
+				t.Errorf("payload with an unknown parameter accepted")
+			}
+			if required := listOf(schema["required"]); len(required) > 0 {
+				invalid := copyPayload(valid)
+				delete(invalid, required[0].(string))
+				if err := v.ValidateToolParams(tool, invalid); err == nil {
+					t.Errorf("payload without %s accepted", required[0])
+				}
+			}
+		})
+	}
+}
+
+// copyPayload copies the top level of a payload, since validation fills in
+// defaults
+func copyPayload(payload map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(payload))
+	for k, v := range payload {
+		copied[k] = v
+	}
+	return copied
+}
+
+func TestSchemaKeywords(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  map[string]interface{}
+		value   interface{}
+		wantErr bool
+	}{
+		{
+			name:   "format date-time accepts RFC 3339",
+			schema: map[string]interface{}{"type": "string", "format": "date-time"},
+			value:  "2025-01-05T18:00:00+01:00",
+		},
+		{
+			name:    "format date-time rejects other dates",
+			schema:  map[string]interface{}{"type": "string", "format": "date-time"},
+			value:   "05.01.2025",
+			wantErr: true,
+		},
+		{
+			name:    "format uri requires an absolute URI",
+			schema:  map[string]interface{}{"type": "string", "format": "uri"},
+			value:   "/relative/path",
+			wantErr: true,
+		},
+		{
+			name:    "format email",
+			schema:  map[string]interface{}{"type": "string", "format": "email"},
+			value:   "not an address",
+			wantErr: true,
+		},
+		{
+			name:   "enum accepts a listed value",
+			schema: map[string]interface{}{"type": "string", "enum": []string{"text", "voice"}},
+			value:  "voice",
+		},
+		{
+			name:    "enum rejects other values",
+			schema:  map[string]interface{}{"type": "string", "enum": []string{"text", "voice"}},
+			value:   "stage",
+			wantErr: true,
+		},
+		{
+			name:    "enum compares numbers by value",
+			schema:  map[string]interface{}{"enum": []interface{}{1, 2}},
+			value:   3.0,
+			wantErr: true,
+		},
+		{
+			name: "allOf requires every schema",
+			schema: map[string]interface{}{"allOf": []interface{}{
+				map[string]interface{}{"type": "string"},
+				map[string]interface{}{"minLength": 3},
+			}},
+			value:   "ab",
+			wantErr: true,
+		},
+		{
+			name: "oneOf accepts exactly one match",
+			schema: map[string]interface{}{"type": "object", "oneOf": []interface{}{
+				map[string]interface{}{"required": []string{"channel_id"}},
+				map[string]interface{}{"required": []string{"user_id"}},
+			}},
+			value: map[string]interface{}{"channel_id": "1"},
+		},
+		{
+			name: "oneOf rejects several matches",
+			schema: map[string]interface{}{"type": "object", "oneOf": []interface{}{
+				map[string]interface{}{"required": []string{"channel_id"}},
+				map[string]interface{}{"required": []string{"user_id"}},
+			}},
+			value:   map[string]interface{}{"channel_id": "1", "user_id": "2"},
+			wantErr: true,
+		},
+		{
+			name: "oneOf rejects no match",
+			schema: map[string]interface{}{"type": "object", "oneOf": []interface{}{
+				map[string]interface{}{"required": []string{"channel_id"}},
+				map[string]interface{}{"required": []string{"user_id"}},
+			}},
+			value:   map[string]interface{}{},
+			wantErr: true,
+		},
+		{
+			name: "nested required is enforced",
+			schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"embed": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"fields": map[string]interface{}{
+								"type": "array",
+								"items": map[string]interface{}{
+									"type":     "object",
+									"required": []string{"name", "value"},
+								},
+							},
+						},
+					},
+				},
+			},
+			value: map[string]interface{}{"embed": map[string]interface{}{
+				"fields": []interface{}{map[string]interface{}{"name": "a"}},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "nested required is satisfied",
+			schema: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"embed": map[string]interface{}{"type": "object", "required": []string{"title"}},
+				},
+			},
+			value: map[string]interface{}{"embed": map[string]interface{}{"title": "a"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Compile(tt.schema)
+			if err != nil {
+				t.Fatalf("compile: %v", err)
+			}
+			err = schema.Validate("", tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCompileRejectsInvalidSchemas(t *testing.T) {
+	for _, raw := range []map[string]interface{}{
+		{"type": "text"},
+		{"type": "string", "pattern": "("},
+		{"minLength": -1},
+		{"$ref": "#/$defs/id"},
+	} {
+		if _, err := Compile(raw); err == nil {
+			t.Errorf("schema %v compiled", raw)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// Package verification keeps the verification gate of each guild: new
+// members hold a restricted role until they are verified, and members who
+// stay unverified too long are kicked. Settings and the members awaiting
+// verification are persisted to disk.
+package verification
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+// CustomIDPrefix starts the custom ID of a guild's verify button
+const CustomIDPrefix = "verify:"
+
+// SweepInterval is how often members past their guild's deadline are kicked
+const SweepInterval = time.Minute
+
+// Settings is the verification gate of one guild
+type Settings struct {
+	GuildID string `json:"guild_id"`
+	Enabled bool   `json:"enabled"`
+	// UnverifiedRoleID is given to members when they join and removed when
+	// they are verified
+	UnverifiedRoleID string `json:"unverified_role_id"`
+	// MemberRoleID is given to members when they are verified, if set
+	MemberRoleID string `json:"member_role_id,omitempty"`
+	// KickAfterMinutes kicks members still unverified this long after
+	// joining; 0 never kicks
+	KickAfterMinutes int `json:"kick_after_minutes"`
+
+	ButtonChannelID string `json:"button_channel_id,omitempty"`
+	ButtonMessageID string `json:"button_message_id,omitempty"`
+
+	Verified  int       `json:"verified"`
+	Kicked    int       `json:"kicked"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ButtonCustomID returns the custom ID of the guild's verify button
+func (s Settings) ButtonCustomID() string {
+	return CustomIDPrefix + s.GuildID
+}
+
+// GuildFromCustomID returns the guild a verify button belongs to
+func GuildFromCustomID(customID string) (string, bool) {
+	if !strings.HasPrefix(customID, CustomIDPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(customID, CustomIDPrefix), true
+}
+
+// Pending is a member awaiting verification
+type Pending struct {
+	GuildID  string     `json:"guild_id"`
+	UserID   string     `json:"user_id"`
+	JoinedAt time.Time  `json:"joined_at"`
+	KickAt   *time.Time `json:"kick_at,omitempty"`
+}
+
+// storedVerification is the layout of the persistence file
+type storedVerification struct {
+	Guilds  []*Settings `json:"guilds"`
+	Pending []*Pending  `json:"pending"`
+}
+
+// Store holds each guild's settings and pending members and saves them to a
+// JSON file on every change
+type Store struct {
+	path string
+
+	guilds  map[string]*Settings
+	pending map[string]*Pending
+	mutex   sync.Mutex
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewStore creates a store, loading the settings and pending members saved
+// at path
+func NewStore(path string) (*Store, error) {
+	s := &Store{
+		path:    path,
+		guilds:  make(map[string]*Settings),
+		pending: make(map[string]*Pending),
+		stop:    make(chan struct{}),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load verification from %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Start calls sweep every SweepInterval until Stop is called
+func (s *Store) Start(sweep func()) {
+	go func() {
+		ticker := time.NewTicker(SweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				sweep()
+			}
+		}
+	}()
+}
+
+// Stop stops sweeping
+func (s *Store) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+// Configure saves a guild's settings, keeping its counts
+func (s *Store) Configure(settings Settings) (Settings, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	previous, existed := s.guilds[settings.GuildID]
+	if existed {
+		settings.Verified = previous.Verified
+		settings.Kicked = previous.Kicked
+	}
+	settings.UpdatedAt = time.Now().UTC()
+	s.guilds[settings.GuildID] = &settings
+	if err := s.save(); err != nil {
+		if existed {
+			s.guilds[settings.GuildID] = previous
+		} else {
+			delete(s.guilds, settings.GuildID)
+		}
+		return Settings{}, err
+	}
+	return settings, nil
+}
+
+// Settings returns a guild's settings
+func (s *Store) Settings(guildID string) (Settings, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	settings, ok := s.guilds[guildID]
+	if !ok {
+		return Settings{}, false
+	}
+	return *settings, true
+}
+
+// AddPending records a member awaiting verification
+func (s *Store) AddPending(guildID, userID string, joinedAt time.Time) (Pending, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pending := &Pending{GuildID: guildID, UserID: userID, JoinedAt: joinedAt.UTC()}
+	s.pending[pendingKey(guildID, userID)] = pending
+	if err := s.save(); err != nil {
+		delete(s.pending, pendingKey(guildID, userID))
+		return Pending{}, err
+	}
+	return s.withDeadline(*pending), nil
+}
+
+// RemovePending forgets a member awaiting verification, returning whether
+// they were pending
+func (s *Store) RemovePending(guildID, userID string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := pendingKey(guildID, userID)
+	pending, ok := s.pending[key]
+	if !ok {
+		return false, nil
+	}
+	delete(s.pending, key)
+	if err := s.save(); err != nil {
+		s.pending[key] = pending
+		return false, err
+	}
+	return true, nil
+}
+
+// Pending returns a guild's members awaiting verification, longest waiting
+// first
+func (s *Store) Pending(guildID string) []Pending {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pending := make([]Pending, 0)
+	for _, p := range s.pending {
+		if p.GuildID == guildID {
+			pending = append(pending, s.withDeadline(*p))
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].JoinedAt.Before(pending[j].JoinedAt)
+	})
+	return pending
+}
+
+// Due returns the pending members of enabled guilds whose kick deadline has
+// passed at now
+func (s *Store) Due(now time.Time) []Pending {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	due := make([]Pending, 0)
+	for _, p := range s.pending {
+		withDeadline := s.withDeadline(*p)
+		if withDeadline.KickAt != nil && !now.Before(*withDeadline.KickAt) {
+			due = append(due, withDeadline)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].JoinedAt.Before(due[j].JoinedAt)
+	})
+	return due
+}
+
+// Record adds to a guild's verified and kicked counts
+func (s *Store) Record(guildID string, verified, kicked int) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	settings, ok := s.guilds[guildID]
+	if !ok {
+		return nil
+	}
+	settings.Verified += verified
+	settings.Kicked += kicked
+	return s.save()
+}
+
+// withDeadline fills in when a pending member is kicked under their guild's
+// settings; callers must hold the lock
+func (s *Store) withDeadline(p Pending) Pending {
+	p.KickAt = nil
+	settings, ok := s.guilds[p.GuildID]
+	if ok && settings.Enabled && settings.KickAfterMinutes > 0 {
+		kickAt := p.JoinedAt.Add(time.Duration(settings.KickAfterMinutes) * time.Minute)
+		p.KickAt = &kickAt
+	}
+	return p
+}
+
+// pendingKey identifies a pending member
+func pendingKey(guildID, userID string) string {
+	return guildID + ":" + userID
+}
+
+// save writes the settings and pending members to the persistence file;
+// callers must hold the lock
+func (s *Store) save() error {
+	stored := storedVerification{
+		Guilds:  make([]*Settings, 0, len(s.guilds)),
+		Pending: make([]*Pending, 0, len(s.pending)),
+	}
+	for _, settings := range s.guilds {
+		stored.Guilds = append(stored.Guilds, settings)
+	}
+	for _, pending := range s.pending {
+		stored.Pending = append(stored.Pending, pending)
+	}
+	sort.Slice(stored.Guilds, func(i, j int) bool {
+		return stored.Guilds[i].GuildID < stored.Guilds[j].GuildID
+	})
+	sort.Slice(stored.Pending, func(i, j int) bool {
+		return stored.Pending[i].JoinedAt.Before(stored.Pending[j].JoinedAt)
+	})
+
+	return jsonfile.Save(s.path, stored)
+}
+
+// load reads saved settings and pending members
+func (s *Store) load() error {
+	var stored storedVerification
+	if found, err := jsonfile.Load(s.path, &stored); err != nil || !found {
+		return err
+	}
+	for _, settings := range stored.Guilds {
+		s.guilds[settings.GuildID] = settings
+	}
+	for _, pending := range stored.Pending {
+		s.pending[pendingKey(pending.GuildID, pending.UserID)] = pending
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+package voice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/config"
+)
+
+// Playback outcomes reported to the finished callback
+const (
+	PlaybackCompleted = "completed"
+	PlaybackStopped   = "stopped"
+	PlaybackFailed    = "failed"
+)
+
+// maxQueueLength bounds the number of tracks waiting per guild
+const maxQueueLength = 20
+
+// Track is a single audio source queued for playback
+type Track struct {
+	ID        string
+	GuildID   string
+	ChannelID string
+	// Source describes the track for logs and notifications
+	Source string
+
+	url  string
+	data []byte
+}
+
+// Result describes a finished track
+type Result struct {
+	Track    Track
+	Status   string
+	Duration time.Duration
+	Err      error
+}
+
+// Manager owns the bot's voice connections and their playback queues
+type Manager struct {
+	session *discordgo.Session
+	config  config.VoiceConfig
+	logger  *logrus.Logger
+
+	// OnFinished is called after every track finishes, is stopped, or fails
+	OnFinished func(Result)
+
+	// tts is the text-to-speech backend, nil when none is configured
+	tts Synthesizer
+
+	connections map[string]*connection // guildID -> connection
+	nextID      int
+	mutex       sync.Mutex
+}
+
+// connection is a joined voice channel with its playback queue
+type connection struct {
+	voice  *discordgo.VoiceConnection
+	queue  chan Track
+	cancel context.CancelFunc
+	// skip stops the current track
+	skip  context.CancelFunc
+	mutex sync.Mutex
+}
+
+// NewManager creates a voice manager
+func NewManager(session *discordgo.Session, cfg config.VoiceConfig, logger *logrus.Logger) *Manager {
+	tts, err := NewSynthesizer(cfg.TTS)
+	if err != nil {
+		logger.Warnf("Text-to-speech disabled: %v", err)
+	}
+
+	return &Manager{
+		session:     session,
+		config:      cfg,
+		logger:      logger,
+		tts:         tts,
+		connections: make(map[string]*connection),
+	}
+}
+
+// Join connects to a voice channel, moving the existing connection if the
+// bot is already in another channel of the guild
+func (m *Manager) Join(guildID, channelID string) error {
+	if !m.config.Enabled {
+		return fmt.Errorf("voice support is disabled (voice.enabled)")
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if conn, ok := m.connections[guildID]; ok {
+		if conn.voice.ChannelID == channelID {
+			return nil
+		}
+		if err := conn.voice.ChangeChannel(channelID, false, true); err != nil {
+			return fmt.Errorf("failed to move to voice channel: %w", err)
+		}
+		return nil
+	}
+
+	vc, err := m.session.ChannelVoiceJoin(guildID, channelID, false, true)
+	if err != nil {
+		return fmt.Errorf("failed to join voice channel: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	conn := &connection{
+		voice:  vc,
+		queue:  make(chan Track, maxQueueLength),
+		cancel: cancel,
+	}
+	m.connections[guildID] = conn
+	go m.playLoop(ctx, conn)
+
+	m.logger.Infof("Joined voice channel %s in guild %s", channelID, guildID)
+	return nil
+}
+
+// Leave stops playback, drops the queue and disconnects from voice in a guild
+func (m *Manager) Leave(guildID string) error {
+	m.mutex.Lock()
+	conn, ok := m.connections[guildID]
+	delete(m.connections, guildID)
+	m.mutex.Unlock()
+
+	if !ok {
+		return fmt.Errorf("not connected to voice in guild %s", guildID)
+	}
+
+	conn.cancel()
+	conn.stopCurrent()
+	if err := conn.voice.Disconnect(); err != nil {
+		return fmt.Errorf("failed to leave voice channel: %w", err)
+	}
+
+	m.logger.Infof("Left voice in guild %s", guildID)
+	return nil
+}
+
+// ChannelID returns the voice channel the bot is connected to in a guild
+func (m *Manager) ChannelID(guildID string) (string, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	conn, ok := m.connections[guildID]
+	if !ok {
+		return "", false
+	}
+	return conn.voice.ChannelID, true
+}
+
+// Undeafen clears the bot's self-deafen in a guild's voice channel, which
+// Discord requires before it plays soundboard sounds
+func (m *Manager) Undeafen(guildID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	conn, ok := m.connections[guildID]
+	if !ok {
+		return fmt.Errorf("not connected to voice in guild %s", guildID)
+	}
+	if err := conn.voice.ChangeChannel(conn.voice.ChannelID, false, false); err != nil {
+		return fmt.Errorf("failed to undeafen: %w", err)
+	}
+	return nil
+}
+
+// PlayURL queues audio from an http(s) URL and returns the track and its
+// position in the queue
+func (m *Manager) PlayURL(guildID, rawURL string) (Track, int, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return Track{}, 0, fmt.Errorf("audio URL must be an http or https URL")
+	}
+	return m.enqueue(guildID, Track{Source: rawURL, url: rawURL})
+}
+
+// PlayData queues in-memory audio in any format ffmpeg understands
+func (m *Manager) PlayData(guildID, source string, data []byte) (Track, int, error) {
+	if len(data) == 0 {
+		return Track{}, 0, fmt.Errorf("audio data is empty")
+	}
+	return m.enqueue(guildID, Track{Source: source, data: data})
+}
+
+// Speak synthesizes text with the configured TTS backend and queues it
+func (m *Manager) Speak(guildID, text, voice string) (Track, int, error) {
+	if m.tts == nil {
+		return Track{}, 0, fmt.Errorf("no text-to-speech backend configured (voice.tts.backend)")
+	}
+	if _, ok := m.ChannelID(guildID); !ok {
+		return Track{}, 0, fmt.Errorf("not connected to voice in guild %s; use join_voice_channel first", guildID)
+	}
+	if len([]rune(text)) > m.config.TTS.MaxTextLength {
+		return Track{}, 0, fmt.Errorf("text exceeds %d characters", m.config.TTS.MaxTextLength)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(m.config.TTS.TimeoutSeconds)*time.Second)
+	defer cancel()
+
+	audio, err := m.tts.Synthesize(ctx, text, voice)
+	if err != nil {
+		return Track{}, 0, err
+	}
+	return m.enqueue(guildID, Track{Source: "tts", data: audio})
+}
+
+func (m *Manager) enqueue(guildID string, track Track) (Track, int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	conn, ok := m.connections[guildID]
+	if !ok {
+		return Track{}, 0, fmt.Errorf("not connected to voice in guild %s; use join_voice_channel first", guildID)
+	}
+
+	m.nextID++
+	track.ID = fmt.Sprintf("p%d", m.nextID)
+	track.GuildID = guildID
+	track.ChannelID = conn.voice.ChannelID
+
+	select {
+	case conn.queue <- track:
+		return track, len(conn.queue), nil
+	default:
+		return Track{}, 0, fmt.Errorf("playback queue is full (%d tracks)", maxQueueLength)
+	}
+}
+
+// playLoop plays queued tracks one at a time until the connection closes
+func (m *Manager) playLoop(ctx context.Context, conn *connection) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case track := <-conn.queue:
+			trackCtx, skip := context.WithCancel(ctx)
+			conn.mutex.Lock()
+			conn.skip = skip
+			conn.mutex.Unlock()
+
+			result := m.play(trackCtx, conn.voice, track)
+			skip()
+
+			if m.OnFinished != nil {
+				m.OnFinished(result)
+			}
+		}
+	}
+}
+
+// play transcodes a track to Opus with ffmpeg and streams it to Discord
+func (m *Manager) play(ctx context.Context, vc *discordgo.VoiceConnection, track Track) Result {
+	started := time.Now()
+	result := Result{Track: track}
+
+	args := []string{"-hide_banner", "-loglevel", "error"}
+	if track.url != "" {
+		// Never let a remote source make ffmpeg read local files
+		args = append(args, "-protocol_whitelist", "http,https,tcp,tls", "-i", track.url)
+	} else {
+		args = append(args, "-i", "pipe:0")
+	}
+	args = append(args,
+		"-t", strconv.Itoa(m.config.MaxDurationSeconds),
+		"-vn", "-c:a", "libopus", "-b:a", fmt.Sprintf("%dk", m.config.BitrateKbps),
+		"-ar", "48000", "-ac", "2", "-frame_duration", "20", "-application", "audio",
+		"-f", "ogg", "pipe:1")
+
+	cmd := exec.CommandContext(ctx, m.config.FFmpegPath, args...)
+	if track.data != nil {
+		cmd.Stdin = bytes.NewReader(track.data)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		result.Status, result.Err = PlaybackFailed, err
+		return result
+	}
+	if err := cmd.Start(); err != nil {
+		result.Status, result.Err = PlaybackFailed, fmt.Errorf("failed to start ffmpeg: %w", err)
+		return result
+	}
+
+	if err := vc.Speaking(true); err != nil {
+		m.logger.Warnf("Failed to set speaking state: %v", err)
+	}
+	defer vc.Speaking(false)
+
+	errStopped := errors.New("stopped")
+	streamErr := readOpusPackets(stdout, func(packet []byte) error {
+		select {
+		case vc.OpusSend <- packet:
+			return nil
+		case <-ctx.Done():
+			return errStopped
+		}
+	})
+	if streamErr != nil {
+		// Drain so ffmpeg can exit
+		io.Copy(io.Discard, stdout)
+	}
+	waitErr := cmd.Wait()
+	result.Duration = time.Since(started)
+
+	switch {
+	case ctx.Err() != nil || streamErr == errStopped:
+		result.Status = PlaybackStopped
+	case streamErr != nil:
+		result.Status, result.Err = PlaybackFailed, streamErr
+	case waitErr != nil:
+		result.Status, result.Err = PlaybackFailed, fmt.Errorf("ffmpeg failed: %v %s", waitErr, stderr.String())
+	default:
+		result.Status = PlaybackCompleted
+	}
+	return result
+}
+
+// stopCurrent stops the track currently playing, if any
+func (c *connection) stopCurrent() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.skip != nil {
+		c.skip()
+	}
+}
+
+// Close leaves every voice channel
+func (m *Manager) Close() {
+	m.mutex.Lock()
+	guilds := make([]string, 0, len(m.connections))
+	for guildID := range m.connections {
+		guilds = append(guilds, guildID)
+	}
+	m.mutex.Unlock()
+
+	for _, guildID := range guilds {
+		if err := m.Leave(guildID); err != nil {
+			m.logger.Warnf("Failed to leave voice in guild %s: %v", guildID, err)
+		}
+	}
+}
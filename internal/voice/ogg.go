@@ -0,0 +1,55 @@
+package voice
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// readOpusPackets demultiplexes an Ogg/Opus stream, calling emit for every
+// audio packet. The OpusHead and OpusTags header packets are skipped.
+func readOpusPackets(r io.Reader, emit func(packet []byte) error) error {
+	reader := bufio.NewReader(r)
+	header := make([]byte, 27)
+	var packet []byte
+
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("failed to read ogg page: %w", err)
+		}
+		if string(header[:4]) != "OggS" {
+			return fmt.Errorf("invalid ogg page header")
+		}
+
+		segments := make([]byte, header[26])
+		if _, err := io.ReadFull(reader, segments); err != nil {
+			return fmt.Errorf("failed to read ogg segment table: %w", err)
+		}
+
+		for _, lacing := range segments {
+			segment := make([]byte, lacing)
+			if _, err := io.ReadFull(reader, segment); err != nil {
+				return fmt.Errorf("failed to read ogg segment: %w", err)
+			}
+			packet = append(packet, segment...)
+
+			// A lacing value below 255 terminates the packet
+			if lacing < 255 {
+				if !isOpusHeader(packet) {
+					if err := emit(packet); err != nil {
+						return err
+					}
+				}
+				packet = nil
+			}
+		}
+	}
+}
+
+func isOpusHeader(packet []byte) bool {
+	return bytes.HasPrefix(packet, []byte("OpusHead")) || bytes.HasPrefix(packet, []byte("OpusTags"))
+}
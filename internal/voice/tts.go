@@ -0,0 +1,130 @@
+package voice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"discord-mcp/internal/config"
+)
+
+// maxSpeechBytes bounds the audio a TTS backend may return
+const maxSpeechBytes = 25 * 1024 * 1024
+
+// Synthesizer turns text into audio in any format ffmpeg understands
+type Synthesizer interface {
+	Synthesize(ctx context.Context, text, voice string) ([]byte, error)
+}
+
+// NewSynthesizer creates the configured TTS backend, or nil if none is set
+func NewSynthesizer(cfg config.TTSConfig) (Synthesizer, error) {
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case "command":
+		if len(cfg.Command) == 0 {
+			return nil, fmt.Errorf("voice.tts.command is required for the command backend")
+		}
+		return &CommandSynthesizer{Command: cfg.Command, DefaultVoice: cfg.Voice}, nil
+	case "http":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("voice.tts.url is required for the http backend")
+		}
+		return &HTTPSynthesizer{URL: cfg.URL, DefaultVoice: cfg.Voice, client: &http.Client{}}, nil
+	default:
+		return nil, fmt.Errorf("unknown TTS backend: %s", cfg.Backend)
+	}
+}
+
+// CommandSynthesizer runs a local TTS program that writes audio to stdout.
+// The "{text}" and "{voice}" placeholders in arguments are substituted; if no
+// argument contains "{text}" the text is written to the program's stdin.
+type CommandSynthesizer struct {
+	Command      []string
+	DefaultVoice string
+}
+
+// Synthesize runs the TTS command
+func (s *CommandSynthesizer) Synthesize(ctx context.Context, text, voice string) ([]byte, error) {
+	if voice == "" {
+		voice = s.DefaultVoice
+	}
+
+	textInArgs := false
+	args := make([]string, len(s.Command)-1)
+	for i, arg := range s.Command[1:] {
+		if strings.Contains(arg, "{text}") {
+			textInArgs = true
+		}
+		arg = strings.ReplaceAll(arg, "{text}", text)
+		args[i] = strings.ReplaceAll(arg, "{voice}", voice)
+	}
+
+	cmd := exec.CommandContext(ctx, s.Command[0], args...)
+	if !textInArgs {
+		cmd.Stdin = strings.NewReader(text)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("TTS command failed: %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() == 0 {
+		return nil, fmt.Errorf("TTS command produced no audio")
+	}
+	return stdout.Bytes(), nil
+}
+
+// HTTPSynthesizer posts {"text": ..., "voice": ...} to a TTS server and uses
+// the response body as audio
+type HTTPSynthesizer struct {
+	URL          string
+	DefaultVoice string
+	client       *http.Client
+}
+
+// Synthesize calls the TTS server
+func (s *HTTPSynthesizer) Synthesize(ctx context.Context, text, voice string) ([]byte, error) {
+	if voice == "" {
+		voice = s.DefaultVoice
+	}
+
+	body, err := json.Marshal(map[string]string{"text": text, "voice": voice})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode TTS request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build TTS request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach TTS server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	audio, err := io.ReadAll(io.LimitReader(resp.Body, maxSpeechBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TTS response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("TTS server returned HTTP %d: %s", resp.StatusCode, bytes.TrimSpace(audio))
+	}
+	if len(audio) > maxSpeechBytes {
+		return nil, fmt.Errorf("TTS audio exceeds %d bytes", maxSpeechBytes)
+	}
+	if len(audio) == 0 {
+		return nil, fmt.Errorf("TTS server returned no audio")
+	}
+	return audio, nil
+}
@@ -0,0 +1,273 @@
+// Package warnings keeps a ledger of moderation warnings given to guild
+// members and decides when a member's warnings call for an escalation, such
+// as a timeout. Warnings are persisted to disk.
+package warnings
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"discord-mcp/internal/jsonfile"
+)
+
+// Escalation actions
+const (
+	ActionTimeout = "timeout"
+	ActionKick    = "kick"
+	ActionBan     = "ban"
+)
+
+// Step is an escalation taken when a member's active warnings reach
+// Warnings
+type Step struct {
+	Warnings int
+	Action   string
+	// Duration is the length of a timeout
+	Duration time.Duration
+}
+
+// String describes the step, e.g. "timeout for 1h0m0s"
+func (s Step) String() string {
+	if s.Action == ActionTimeout {
+		return fmt.Sprintf("%s for %s", s.Action, s.Duration)
+	}
+	return s.Action
+}
+
+// Warning is one warning given to a member of a guild. Cleared warnings stay
+// in the ledger but no longer count.
+type Warning struct {
+	ID        string     `json:"id"`
+	GuildID   string     `json:"guild_id"`
+	UserID    string     `json:"user_id"`
+	Reason    string     `json:"reason"`
+	Moderator string     `json:"moderator,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Escalation is the action the warning triggered, if any
+	Escalation      string `json:"escalation,omitempty"`
+	EscalationError string `json:"escalation_error,omitempty"`
+
+	ClearedAt   *time.Time `json:"cleared_at,omitempty"`
+	ClearedBy   string     `json:"cleared_by,omitempty"`
+	ClearReason string     `json:"clear_reason,omitempty"`
+}
+
+// Active reports whether the warning counts toward escalation at now
+func (w Warning) Active(now time.Time) bool {
+	return w.ClearedAt == nil && (w.ExpiresAt == nil || now.Before(*w.ExpiresAt))
+}
+
+// storedWarnings is the layout of the persistence file
+type storedWarnings struct {
+	NextID   int        `json:"next_id"`
+	Warnings []*Warning `json:"warnings"`
+}
+
+// Store holds warnings by ID and saves them to a JSON file on every change
+type Store struct {
+	path   string
+	expire time.Duration
+	steps  []Step
+
+	warnings map[string]*Warning
+	nextID   int
+	mutex    sync.Mutex
+}
+
+// NewStore creates a store whose warnings expire after expire (0 keeps them
+// active until cleared) and escalate by steps, loading the warnings saved
+// at path
+func NewStore(path string, expire time.Duration, steps []Step) (*Store, error) {
+	sorted := append([]Step(nil), steps...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Warnings < sorted[j].Warnings
+	})
+	s := &Store{
+		path:     path,
+		expire:   expire,
+		steps:    sorted,
+		warnings: make(map[string]*Warning),
+	}
+	if err := s.load(); err != nil {
+		return nil, fmt.Errorf("failed to load warnings from %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Steps returns the escalation steps, fewest warnings first
+func (s *Store) Steps() []Step {
+	return append([]Step(nil), s.steps...)
+}
+
+// Add saves a warning and returns it with the member's active warning count
+// and the escalation step that applies: the step with the most warnings the
+// count has reached, if any
+func (s *Store) Add(warning Warning) (Warning, int, *Step, error) {
+	if warning.GuildID == "" || warning.UserID == "" {
+		return Warning{}, 0, nil, fmt.Errorf("guild and user are required")
+	}
+	if warning.Reason == "" {
+		return Warning{}, 0, nil, fmt.Errorf("reason must not be empty")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now().UTC()
+	s.nextID++
+	warning.ID = fmt.Sprintf("wn%d", s.nextID)
+	warning.CreatedAt = now
+	if s.expire > 0 {
+		expires := now.Add(s.expire)
+		warning.ExpiresAt = &expires
+	}
+	s.warnings[warning.ID] = &warning
+	if err := s.save(); err != nil {
+		delete(s.warnings, warning.ID)
+		return Warning{}, 0, nil, err
+	}
+
+	active := s.activeCount(warning.GuildID, warning.UserID, now)
+	var step *Step
+	for i := range s.steps {
+		if s.steps[i].Warnings <= active {
+			step = &s.steps[i]
+		}
+	}
+	return warning, active, step, nil
+}
+
+// RecordEscalation remembers the action a warning triggered and whether it
+// failed
+func (s *Store) RecordEscalation(warningID, action string, escalationErr error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	warning, ok := s.warnings[warningID]
+	if !ok {
+		return nil
+	}
+	warning.Escalation = action
+	if escalationErr != nil {
+		warning.EscalationError = escalationErr.Error()
+	}
+	return s.save()
+}
+
+// List returns the warnings of a guild, or of one member when userID is
+// set, newest first. Cleared and expired warnings are included when
+// includeInactive is set.
+func (s *Store) List(guildID, userID string, includeInactive bool) []Warning {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	warnings := make([]Warning, 0)
+	for _, warning := range s.warnings {
+		if warning.GuildID != guildID || (userID != "" && warning.UserID != userID) {
+			continue
+		}
+		if includeInactive || warning.Active(now) {
+			warnings = append(warnings, *warning)
+		}
+	}
+	sort.Slice(warnings, func(i, j int) bool {
+		return warnings[i].CreatedAt.After(warnings[j].CreatedAt)
+	})
+	return warnings
+}
+
+// ActiveCount returns the number of warnings counting against a member
+func (s *Store) ActiveCount(guildID, userID string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.activeCount(guildID, userID, time.Now())
+}
+
+// Clear marks active warnings as cleared: one warning when warningID is
+// set, otherwise every active warning of userID. It returns the warnings it
+// cleared.
+func (s *Store) Clear(guildID, warningID, userID, by, reason string) ([]Warning, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now().UTC()
+	var cleared []*Warning
+	for _, warning := range s.warnings {
+		if warning.GuildID != guildID || !warning.Active(now) {
+			continue
+		}
+		if (warningID != "" && warning.ID == warningID) || (warningID == "" && warning.UserID == userID) {
+			cleared = append(cleared, warning)
+		}
+	}
+	if len(cleared) == 0 {
+		return nil, nil
+	}
+
+	for _, warning := range cleared {
+		warning.ClearedAt = &now
+		warning.ClearedBy = by
+		warning.ClearReason = reason
+	}
+	if err := s.save(); err != nil {
+		for _, warning := range cleared {
+			warning.ClearedAt = nil
+			warning.ClearedBy = ""
+			warning.ClearReason = ""
+		}
+		return nil, err
+	}
+
+	result := make([]Warning, len(cleared))
+	for i, warning := range cleared {
+		result[i] = *warning
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// activeCount counts a member's active warnings; callers must hold the lock
+func (s *Store) activeCount(guildID, userID string, now time.Time) int {
+	count := 0
+	for _, warning := range s.warnings {
+		if warning.GuildID == guildID && warning.UserID == userID && warning.Active(now) {
+			count++
+		}
+	}
+	return count
+}
+
+// save writes every warning to the persistence file; callers must hold the
+// lock
+func (s *Store) save() error {
+	stored := storedWarnings{NextID: s.nextID, Warnings: make([]*Warning, 0, len(s.warnings))}
+	for _, warning := range s.warnings {
+		stored.Warnings = append(stored.Warnings, warning)
+	}
+	sort.Slice(stored.Warnings, func(i, j int) bool {
+		return stored.Warnings[i].CreatedAt.Before(stored.Warnings[j].CreatedAt)
+	})
+
+	return jsonfile.Save(s.path, stored)
+}
+
+// load reads saved warnings
+func (s *Store) load() error {
+	var stored storedWarnings
+	if found, err := jsonfile.Load(s.path, &stored); err != nil || !found {
+		return err
+	}
+	s.nextID = stored.NextID
+	for _, warning := range stored.Warnings {
+		s.warnings[warning.ID] = warning
+	}
+	return nil
+}
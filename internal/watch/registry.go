@@ -0,0 +1,203 @@
+package watch
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Watch types
+const (
+	TypeKeyword = "keyword"
+	TypeMention = "mention"
+	TypeUser    = "user"
+	TypeEmoji   = "emoji"
+)
+
+// MaxWatches bounds the number of registered watches
+const MaxWatches = 100
+
+// Watch is a server-side trigger evaluated against incoming messages or reactions
+type Watch struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Pattern   string    `json:"pattern,omitempty"`
+	UserID    string    `json:"user_id,omitempty"`
+	Emoji     string    `json:"emoji,omitempty"`
+	GuildID   string    `json:"guild_id,omitempty"`
+	ChannelID string    `json:"channel_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Triggers  int64     `json:"trigger_count"`
+
+	regex *regexp.Regexp
+}
+
+// Match describes a watch that fired
+type Match struct {
+	Watch Watch
+	// Text is the matched keyword, or empty for non-keyword watches
+	Text string
+}
+
+// Registry holds the active watches
+type Registry struct {
+	watches map[string]*Watch
+	nextID  int
+	mutex   sync.RWMutex
+}
+
+// NewRegistry creates an empty watch registry
+func NewRegistry() *Registry {
+	return &Registry{
+		watches: make(map[string]*Watch),
+	}
+}
+
+// Add validates and registers a watch, assigning its ID
+func (r *Registry) Add(w Watch) (Watch, error) {
+	switch w.Type {
+	case TypeKeyword:
+		if w.Pattern == "" {
+			return Watch{}, fmt.Errorf("pattern is required for keyword watches")
+		}
+		regex, err := regexp.Compile(w.Pattern)
+		if err != nil {
+			return Watch{}, fmt.Errorf("invalid pattern: %w", err)
+		}
+		w.regex = regex
+	case TypeMention:
+	case TypeUser:
+		if w.UserID == "" {
+			return Watch{}, fmt.Errorf("user_id is required for user watches")
+		}
+	case TypeEmoji:
+		if w.Emoji == "" {
+			return Watch{}, fmt.Errorf("emoji is required for emoji watches")
+		}
+	default:
+		return Watch{}, fmt.Errorf("unknown watch type: %s", w.Type)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.watches) >= MaxWatches {
+		return Watch{}, fmt.Errorf("watch limit reached (%d)", MaxWatches)
+	}
+
+	r.nextID++
+	w.ID = fmt.Sprintf("w%d", r.nextID)
+	w.CreatedAt = time.Now().UTC()
+	r.watches[w.ID] = &w
+	return w, nil
+}
+
+// Remove deletes a watch, reporting whether it existed
+func (r *Registry) Remove(id string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.watches[id]; !ok {
+		return false
+	}
+	delete(r.watches, id)
+	return true
+}
+
+// List returns all watches ordered by creation
+func (r *Registry) List() []Watch {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	watches := make([]Watch, 0, len(r.watches))
+	for _, w := range r.watches {
+		watches = append(watches, *w)
+	}
+	sort.Slice(watches, func(i, j int) bool {
+		return watches[i].CreatedAt.Before(watches[j].CreatedAt)
+	})
+	return watches
+}
+
+// MatchMessage returns the watches triggered by a new message
+func (r *Registry) MatchMessage(msg *discordgo.Message, botID string) []Match {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var matches []Match
+	for _, w := range r.watches {
+		if w.Type == TypeEmoji || !w.inScope(msg.GuildID, msg.ChannelID) {
+			continue
+		}
+
+		text, ok := w.matchMessage(msg, botID)
+		if !ok {
+			continue
+		}
+		w.Triggers++
+		matches = append(matches, Match{Watch: *w, Text: text})
+	}
+	return matches
+}
+
+// MatchReaction returns the emoji watches triggered by a reaction
+func (r *Registry) MatchReaction(reaction *discordgo.MessageReaction) []Match {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var matches []Match
+	for _, w := range r.watches {
+		if w.Type != TypeEmoji || !w.inScope(reaction.GuildID, reaction.ChannelID) {
+			continue
+		}
+		if !matchEmoji(w.Emoji, reaction.Emoji) {
+			continue
+		}
+		w.Triggers++
+		matches = append(matches, Match{Watch: *w, Text: reaction.Emoji.Name})
+	}
+	return matches
+}
+
+func (w *Watch) inScope(guildID, channelID string) bool {
+	if w.GuildID != "" && w.GuildID != guildID {
+		return false
+	}
+	return w.ChannelID == "" || w.ChannelID == channelID
+}
+
+func (w *Watch) matchMessage(msg *discordgo.Message, botID string) (string, bool) {
+	// Never trigger on the bot's own messages
+	if msg.Author == nil || msg.Author.ID == botID {
+		return "", false
+	}
+
+	switch w.Type {
+	case TypeKeyword:
+		text := w.regex.FindString(msg.Content)
+		return text, text != ""
+	case TypeMention:
+		for _, user := range msg.Mentions {
+			if user.ID == botID {
+				return "", true
+			}
+		}
+	case TypeUser:
+		return "", msg.Author.ID == w.UserID
+	}
+	return "", false
+}
+
+// matchEmoji compares a watch emoji, given as a unicode emoji, a custom emoji
+// name, or a custom emoji in <:name:id> form, against a reaction emoji
+func matchEmoji(want string, emoji discordgo.Emoji) bool {
+	if emoji.ID != "" && strings.Contains(want, emoji.ID) {
+		return true
+	}
+	return strings.Trim(want, ":") == emoji.Name
+}
@@ -0,0 +1,182 @@
+// Package discordmcp embeds the Discord MCP server in other Go programs. A
+// program loads a configuration, creates a Server, registers its own tools
+// next to the built-in ones and serves MCP over stdio or any other stream.
+//
+//	cfg, err := discordmcp.LoadConfig("config.yaml")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	server, err := discordmcp.New(cfg, discordmcp.Options{})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	server.RegisterTool(discordmcp.NewTool(types.Tool{
+//		Name:        "echo",
+//		Description: "Echo the text argument",
+//		InputSchema: map[string]interface{}{"type": "object"},
+//	}, func(params types.CallToolParams) (types.CallToolResult, error) {
+//		text, _ := params.Arguments["text"].(string)
+//		return types.CallToolResult{Content: []types.Content{{Type: "text", Text: text}}}, nil
+//	}))
+//	log.Fatal(server.ServeStdio())
+package discordmcp
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/sirupsen/logrus"
+
+	"discord-mcp/internal/config"
+	"discord-mcp/internal/discord"
+	"discord-mcp/internal/handlers"
+	"discord-mcp/internal/mcp"
+	"discord-mcp/internal/plugins"
+	"discord-mcp/pkg/types"
+)
+
+// Config is the server configuration, as read from config.yaml
+type Config = config.Config
+
+// Tool is an MCP tool: its definition for tools/list and the function that
+// runs it for tools/call
+type Tool = mcp.ToolHandler
+
+// Hooks observe and intercept tool calls
+type Hooks = mcp.Hooks
+
+// DefaultConfig returns the configuration used for keys a file leaves out
+func DefaultConfig() *Config {
+	return config.DefaultConfig()
+}
+
+// LoadConfig reads a configuration file, applies the environment variables
+// the server binary reads, such as DISCORD_TOKEN, and validates the result.
+// A missing file gives the default configuration.
+func LoadConfig(path string) (*Config, error) {
+	return config.LoadConfig(path)
+}
+
+// Options configure a Server
+type Options struct {
+	// Logger receives the server's logs; defaults to a logger writing to
+	// stderr, since stdout carries MCP messages
+	Logger *logrus.Logger
+	// WithoutBuiltinTools leaves the built-in Discord tools out, so only
+	// registered tools are offered
+	WithoutBuiltinTools bool
+	// Hooks observe and intercept tool calls
+	Hooks Hooks
+}
+
+// Server is an embeddable Discord MCP server
+type Server struct {
+	server  *mcp.Server
+	discord *discord.Client
+}
+
+// New creates a server for a configuration, with the built-in tools and the
+// tools of the configured plugins. It does not connect to Discord until it
+// serves.
+func New(cfg *Config, opts Options) (*Server, error) {
+	logger := opts.Logger
+	if logger == nil {
+		logger = logrus.New()
+		logger.SetOutput(os.Stderr)
+	}
+
+	client, err := discord.NewClient(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	server := mcp.NewServer(cfg, logger, client)
+	if !opts.WithoutBuiltinTools {
+		for _, tool := range handlers.Builtin(client, server.Notifications(), server, logger) {
+			server.RegisterTool(tool)
+		}
+	}
+
+	// Plugin tools may not replace built-in tools
+	pluginTools, err := plugins.Load(cfg.Plugins, logger)
+	if err != nil {
+		return nil, err
+	}
+	registered := make(map[string]bool)
+	for _, name := range server.ToolNames() {
+		registered[name] = true
+	}
+	for _, tool := range pluginTools {
+		if name := tool.GetDefinition().Name; registered[name] {
+			return nil, fmt.Errorf("plugin %s: tool %s conflicts with a built-in tool", tool.Plugin(), name)
+		}
+		server.RegisterTool(tool)
+	}
+	server.SetHooks(opts.Hooks)
+
+	return &Server{server: server, discord: client}, nil
+}
+
+// RegisterTool adds a tool, replacing a tool of the same name. Tools
+// registered after the client initialized are offered on its next
+// tools/list.
+func (s *Server) RegisterTool(tool Tool) {
+	s.server.RegisterTool(tool)
+}
+
+// SetHooks replaces the tool call hooks
+func (s *Server) SetHooks(hooks Hooks) {
+	s.server.SetHooks(hooks)
+}
+
+// ToolNames returns the names of the registered tools in sorted order
+func (s *Server) ToolNames() []string {
+	return s.server.ToolNames()
+}
+
+// Session returns the Discord session, for tools that call Discord
+// themselves
+func (s *Server) Session() *discordgo.Session {
+	return s.discord.Session()
+}
+
+// ServeStdio connects to Discord and serves MCP on stdin and stdout until
+// stdin is closed
+func (s *Server) ServeStdio() error {
+	return s.server.Start()
+}
+
+// Serve connects to Discord and serves MCP on any stream, such as a socket
+// connection, until input ends. Serve one stream at a time: later calls
+// keep the Discord connection of the first, and notifications go to the
+// stream served last.
+func (s *Server) Serve(input io.Reader, output io.Writer) error {
+	return s.server.Serve(input, output)
+}
+
+// Stop disconnects from Discord
+func (s *Server) Stop() error {
+	return s.server.Stop()
+}
+
+// NewTool makes a Tool from a definition and the function that runs it
+func NewTool(definition types.Tool, execute func(params types.CallToolParams) (types.CallToolResult, error)) Tool {
+	return &funcTool{definition: definition, execute: execute}
+}
+
+// funcTool is a Tool made by NewTool
+type funcTool struct {
+	definition types.Tool
+	execute    func(params types.CallToolParams) (types.CallToolResult, error)
+}
+
+// Execute runs the tool
+func (t *funcTool) Execute(params types.CallToolParams) (types.CallToolResult, error) {
+	return t.execute(params)
+}
+
+// GetDefinition returns the tool definition
+func (t *funcTool) GetDefinition() types.Tool {
+	return t.definition
+}
@@ -0,0 +1,189 @@
+package types
+
+import "time"
+
+// Discord objects as returned in tool result data. Formatters build these
+// instead of ad-hoc maps so every tool serializes an object the same way,
+// and tool definitions describe them in their outputSchema.
+
+// UserSummary is a Discord user as it appears in messages
+type UserSummary struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	Discriminator string `json:"discriminator"`
+	Avatar        string `json:"avatar"`
+	AvatarURL     string `json:"avatar_url"`
+	Bot           bool   `json:"bot"`
+}
+
+// UserProfile is a user's global profile
+type UserProfile struct {
+	UserSummary
+	GlobalName  string `json:"global_name"`
+	DisplayName string `json:"display_name"`
+	System      bool   `json:"system"`
+	PublicFlags int    `json:"public_flags"`
+	AccentColor int    `json:"accent_color"`
+	BannerURL   string `json:"banner_url,omitempty"`
+	// CreatedAt and AccountAgeDays are decoded from the user ID
+	CreatedAt      string `json:"created_at,omitempty"`
+	AccountAgeDays *int   `json:"account_age_days,omitempty"`
+}
+
+// MessageSummary is a Discord message
+type MessageSummary struct {
+	ID              string              `json:"id"`
+	Content         string              `json:"content"`
+	Author          UserSummary         `json:"author"`
+	Timestamp       string              `json:"timestamp"`
+	Edited          bool                `json:"edited"`
+	TTS             bool                `json:"tts"`
+	MentionEveryone bool                `json:"mention_everyone"`
+	Mentions        []UserSummary       `json:"mentions"`
+	Attachments     []AttachmentSummary `json:"attachments"`
+	Embeds          []EmbedSummary      `json:"embeds"`
+	Reactions       []ReactionSummary   `json:"reactions"`
+	Stickers        []StickerSummary    `json:"stickers"`
+	Pinned          bool                `json:"pinned"`
+	Type            int                 `json:"type"`
+	Flags           int                 `json:"flags"`
+	MessageURL      string              `json:"message_url"`
+	// ContentUnavailable is set when Discord withheld the content because
+	// the message content intent is not enabled
+	ContentUnavailable bool `json:"content_unavailable"`
+}
+
+// AttachmentSummary is a file attached to a message
+type AttachmentSummary struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Size     int    `json:"size"`
+	URL      string `json:"url"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+}
+
+// EmbedSummary is a rich embed of a message
+type EmbedSummary struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Color       int                 `json:"color"`
+	URL         string              `json:"url"`
+	Thumbnail   *EmbedImageSummary  `json:"thumbnail,omitempty"`
+	Image       *EmbedImageSummary  `json:"image,omitempty"`
+	Fields      []EmbedFieldSummary `json:"fields,omitempty"`
+}
+
+// EmbedImageSummary is the thumbnail or image of an embed
+type EmbedImageSummary struct {
+	URL string `json:"url"`
+}
+
+// EmbedFieldSummary is a field of an embed
+type EmbedFieldSummary struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// ReactionSummary is a reaction on a message
+type ReactionSummary struct {
+	Emoji EmojiSummary `json:"emoji"`
+	Count int          `json:"count"`
+	Me    bool         `json:"me"`
+}
+
+// EmojiSummary is a Unicode or custom emoji; custom emojis have an ID and
+// a CDN URL
+type EmojiSummary struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+	URL  string `json:"url"`
+}
+
+// StickerSummary is a sticker sent with a message
+type StickerSummary struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	FormatType int    `json:"format_type"`
+	URL        string `json:"url"`
+}
+
+// ChannelSummary is a guild channel or thread. Fields that only apply to
+// some channel types are left out for the others.
+type ChannelSummary struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Position int    `json:"position"`
+	NSFW     bool   `json:"nsfw"`
+	ParentID string `json:"parent_id"`
+	GuildID  string `json:"guild_id"`
+	Topic    string `json:"topic"`
+	// CreatedAt is decoded from the channel ID
+	CreatedAt                     string             `json:"created_at"`
+	PermissionOverwrites          []OverwriteSummary `json:"permission_overwrites"`
+	RateLimitPerUser              *int               `json:"rate_limit_per_user,omitempty"`
+	Bitrate                       *int               `json:"bitrate,omitempty"`
+	UserLimit                     *int               `json:"user_limit,omitempty"`
+	AvailableTags                 []ForumTagSummary  `json:"available_tags,omitempty"`
+	DefaultThreadRateLimitPerUser *int               `json:"default_thread_rate_limit_per_user,omitempty"`
+	Archived                      *bool              `json:"archived,omitempty"`
+	Locked                        *bool              `json:"locked,omitempty"`
+	AutoArchiveDuration           *int               `json:"auto_archive_duration,omitempty"`
+	MessageCount                  *int               `json:"message_count,omitempty"`
+	MemberCount                   *int               `json:"member_count,omitempty"`
+	RTCRegion                     string             `json:"rtc_region,omitempty"`
+	DefaultAutoArchiveDuration    int                `json:"default_auto_archive_duration,omitempty"`
+	Permissions                   interface{}        `json:"permissions,omitempty"`
+	VoiceMemberCount              *int               `json:"voice_member_count,omitempty"`
+	ActiveThreadCount             *int               `json:"active_thread_count,omitempty"`
+	SlowmodeSeconds               *int               `json:"slowmode_seconds,omitempty"`
+	LastMessageID                 string             `json:"last_message_id,omitempty"`
+	LastMessageAt                 string             `json:"last_message_at,omitempty"`
+}
+
+// OverwriteSummary is a permission overwrite of a channel for a role or
+// member
+type OverwriteSummary struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Allow int64  `json:"allow"`
+	Deny  int64  `json:"deny"`
+}
+
+// ForumTagSummary is a tag posts in a forum or media channel can carry
+type ForumTagSummary struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Moderated bool   `json:"moderated"`
+	EmojiID   string `json:"emoji_id"`
+	EmojiName string `json:"emoji_name"`
+}
+
+// RoleSummary is a guild role
+type RoleSummary struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Color       int    `json:"color"`
+	IconURL     string `json:"icon_url"`
+	Hoist       bool   `json:"hoist"`
+	Position    int    `json:"position"`
+	Permissions int64  `json:"permissions"`
+	Managed     bool   `json:"managed"`
+	Mentionable bool   `json:"mentionable"`
+}
+
+// MemberSummary is a member of a guild
+type MemberSummary struct {
+	ID            string    `json:"id"`
+	Username      string    `json:"username"`
+	Discriminator string    `json:"discriminator"`
+	Nick          string    `json:"nick"`
+	AvatarURL     string    `json:"avatar_url"`
+	Roles         []string  `json:"roles"`
+	JoinedAt      time.Time `json:"joined_at"`
+	Deaf          bool      `json:"deaf"`
+	Mute          bool      `json:"mute"`
+	Bot           bool      `json:"bot"`
+}
@@ -64,6 +64,7 @@ type ServerCapabilities struct {
 	Tools     *ToolsCapability     `json:"tools,omitempty"`
 	Resources *ResourcesCapability `json:"resources,omitempty"`
 	Prompts   *PromptsCapability   `json:"prompts,omitempty"`
+	Logging   *LoggingCapability   `json:"logging,omitempty"`
 }
 
 // ToolsCapability describes tool capabilities
@@ -82,11 +83,21 @@ type PromptsCapability struct {
 	ListChanged bool `json:"listChanged,omitempty"`
 }
 
+// LoggingCapability indicates the server accepts logging/setLevel
+type LoggingCapability struct{}
+
+// SetLevelParams contains the parameters of logging/setLevel
+type SetLevelParams struct {
+	Level string `json:"level"`
+}
+
 // Tool represents a tool that can be called
 type Tool struct {
 	Name        string      `json:"name"`
 	Description string      `json:"description"`
 	InputSchema interface{} `json:"inputSchema"`
+	// OutputSchema describes the data of the tool's text content
+	OutputSchema interface{} `json:"outputSchema,omitempty"`
 }
 
 // ToolsListResult contains the list of available tools
@@ -104,6 +115,32 @@ type ErrorResult struct {
 type CallToolParams struct {
 	Name      string                 `json:"name"`
 	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Meta      *RequestMeta           `json:"_meta,omitempty"`
+}
+
+// RequestMeta carries request metadata such as the client's progress token
+type RequestMeta struct {
+	ProgressToken interface{} `json:"progressToken,omitempty"`
+	// Locale selects the language of tool result text, e.g. "de" or "pt-BR"
+	Locale string `json:"locale,omitempty"`
+}
+
+// ProgressParams contains parameters for a notifications/progress notification
+type ProgressParams struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Progress      float64     `json:"progress"`
+	Total         float64     `json:"total,omitempty"`
+	Message       string      `json:"message,omitempty"`
+}
+
+// PartialResultParams contains parameters for a discord/partialResult
+// notification, which carries one chunk of a streamed tool result
+type PartialResultParams struct {
+	ProgressToken interface{} `json:"progressToken"`
+	Tool          string      `json:"tool"`
+	Chunk         int         `json:"chunk"`
+	Count         int         `json:"count"`
+	Items         interface{} `json:"items"`
 }
 
 // CallToolResult contains the result of a tool call
@@ -112,11 +149,21 @@ type CallToolResult struct {
 	IsError bool      `json:"isError,omitempty"`
 }
 
-// Content represents different types of content that can be returned
+// Content represents different types of content that can be returned. For
+// "image" content, Data holds the base64-encoded image.
 type Content struct {
-	Type string      `json:"type"`
-	Text string      `json:"text,omitempty"`
-	Data interface{} `json:"data,omitempty"`
+	Type     string            `json:"type"`
+	Text     string            `json:"text,omitempty"`
+	Data     interface{}       `json:"data,omitempty"`
+	MimeType string            `json:"mimeType,omitempty"`
+	Resource *ResourceContents `json:"resource,omitempty"`
+}
+
+// ResourceContents is an embedded resource returned in "resource" content
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
 }
 
 // Constants for MCP protocol
@@ -134,4 +181,5 @@ const (
 	InternalError        = -32603
 	RequestCancelled     = -32800
 	ContentExceedsMaxLen = -32000
+	DiscordUnavailable   = -32001
 )
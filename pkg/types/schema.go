@@ -0,0 +1,70 @@
+package types
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// SchemaOf returns a JSON Schema describing how v serializes, read from the
+// json tags of its struct fields. No field is marked required: detail and
+// fields arguments can leave any of them out of a result.
+func SchemaOf(v interface{}) map[string]interface{} {
+	return schemaFor(reflect.TypeOf(v))
+}
+
+// schemaFor describes one Go type
+func schemaFor(t reflect.Type) map[string]interface{} {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaFor(t.Elem())
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			// Fields of an embedded struct are encoded inline; the outer
+			// struct's own fields win when names collide
+			if field.Anonymous && field.Tag.Get("json") == "" && field.Type.Kind() == reflect.Struct {
+				embedded := schemaFor(field.Type)["properties"].(map[string]interface{})
+				for name, schema := range embedded {
+					if _, exists := properties[name]; !exists {
+						properties[name] = schema
+					}
+				}
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			properties[name] = schemaFor(field.Type)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		// Interfaces hold values of more than one shape
+		return map[string]interface{}{}
+	}
+}
@@ -0,0 +1,33 @@
+package types
+
+import "testing"
+
+func TestSchemaOfEmbeddedStruct(t *testing.T) {
+	type withRetries struct {
+		RoleSummary
+		Name    int `json:"name"`
+		Retries int `json:"retries"`
+	}
+
+	properties := SchemaOf(withRetries{})["properties"].(map[string]interface{})
+	if _, ok := properties["RoleSummary"]; ok {
+		t.Errorf("embedded struct described as a property: %v", properties)
+	}
+	for _, name := range []string{"id", "position", "managed", "retries"} {
+		if _, ok := properties[name]; !ok {
+			t.Errorf("property %q missing: %v", name, properties)
+		}
+	}
+	if got := properties["name"].(map[string]interface{})["type"]; got != "integer" {
+		t.Errorf("outer field did not win over the embedded one: type %v", got)
+	}
+}
+
+func TestSchemaOfUserProfile(t *testing.T) {
+	properties := SchemaOf(UserProfile{})["properties"].(map[string]interface{})
+	for _, name := range []string{"id", "username", "avatar_url", "bot", "global_name", "account_age_days"} {
+		if _, ok := properties[name]; !ok {
+			t.Errorf("property %q missing", name)
+		}
+	}
+}